@@ -43,10 +43,13 @@ func main() {
 	sig := make(chan os.Signal, 1)
 	signal.Notify(sig, syscall.SIGHUP, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
 
-	httpServer := server.
+	httpServer, err := server.
 		New(cfg, logger, sig).
 		WithTracing().
 		Build()
+	if err != nil {
+		panic(err)
+	}
 
 	httpServer.RegisterRoute(http.MethodGet, "/hello", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(200)
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"os"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/health"
+	"github.com/ralvescosta/gokit/http/server"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	pg "github.com/ralvescosta/gokit/sql/postgres"
+)
+
+var newPostgres = func(logger logging.ILogger, cfg *env.Configs) (*sql.DB, error) {
+	return pg.New(logger, cfg, nil).Connect().Build()
+}
+
+var newRabbitMQ = rabbitmq.New
+
+func (p *postgresComponent) Name() string {
+	return PostgresComponent
+}
+
+func (p *postgresComponent) Start(ctx context.Context, c *Container) error {
+	db, err := newPostgres(c.Logger, c.Cfg)
+	if err != nil {
+		return err
+	}
+
+	p.db = db
+	c.DB = db
+	c.Health.Readiness(health.SqlProbe(PostgresComponent, db))
+
+	return nil
+}
+
+func (p *postgresComponent) Stop(ctx context.Context) error {
+	return p.db.Close()
+}
+
+func (r *rabbitMQComponent) Name() string {
+	return RabbitMQComponent
+}
+
+func (r *rabbitMQComponent) Start(ctx context.Context, c *Container) error {
+	messaging := newRabbitMQ(c.Cfg, c.Logger)
+
+	for _, setup := range r.setups {
+		if err := setup(c, messaging); err != nil {
+			return err
+		}
+	}
+
+	messaging, err := messaging.Build()
+	if err != nil {
+		return err
+	}
+
+	r.messaging = messaging
+	c.Messaging = messaging
+
+	return nil
+}
+
+// Run consume the registered dispatchers until the shutdown
+func (r *rabbitMQComponent) Run(ctx context.Context) error {
+	return r.messaging.Consume()
+}
+
+func (r *rabbitMQComponent) Stop(ctx context.Context) error {
+	return r.messaging.Shutdown(ctx)
+}
+
+func (h *httpServerComponent) Name() string {
+	return HTTPServerComponent
+}
+
+// Start create the server exposing the probes registered in the Container
+func (h *httpServerComponent) Start(ctx context.Context, c *Container) error {
+	h.sig = make(chan os.Signal, 1)
+	srv, err := server.
+		New(c.Cfg, c.Logger, h.sig).
+		WithHealth(c.Health.Build()).
+		Build()
+	if err != nil {
+		return err
+	}
+
+	h.srv = srv
+
+	for _, setup := range h.setups {
+		if err := setup(c, h.srv); err != nil {
+			return err
+		}
+	}
+
+	c.HTTPServer = h.srv
+
+	return nil
+}
+
+func (h *httpServerComponent) Run(ctx context.Context) error {
+	return h.srv.Run()
+}
+
+// Stop drain the server bounded by the App shutdown timeout, the App waits Run returns
+func (h *httpServerComponent) Stop(ctx context.Context) error {
+	return h.srv.Shutdown(ctx)
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultShutdownTimeout = 30 * time.Second
+
+	AppProbeName        = "app"
+	PostgresComponent   = "postgres"
+	RabbitMQComponent   = "rabbitmq"
+	HTTPServerComponent = "http-server"
+
+	// STARTING_EVENT the App started running, before the configs are loaded
+	STARTING_EVENT LifecycleEventKind = "starting"
+	// CONFIG_LOADED_EVENT the configs were loaded and the logger created
+	CONFIG_LOADED_EVENT LifecycleEventKind = "config-loaded"
+	// DEPENDENCY_READY_EVENT a component was started, published once per component
+	DEPENDENCY_READY_EVENT LifecycleEventKind = "dependency-ready"
+	// READY_EVENT all the components were started and the runners are running
+	READY_EVENT LifecycleEventKind = "ready"
+	// DRAINING_EVENT the shutdown started, due the ctx, a signal or a runner failure
+	DRAINING_EVENT LifecycleEventKind = "draining"
+	// STOPPED_EVENT the components were stopped, also published when a component fails to start
+	STOPPED_EVENT LifecycleEventKind = "stopped"
+
+	MeterName = "github.com/ralvescosta/gokit/app"
+	// LifecycleEventsMetric counter of the lifecycle events, labeled by app, event and component
+	LifecycleEventsMetric = "app.lifecycle.events"
+	// StartupDurationMetric histogram of the seconds until the App is ready
+	StartupDurationMetric = "app.startup.duration"
+	// ComponentStartupDurationMetric histogram of the seconds each component took to start, labeled by component
+	ComponentStartupDurationMetric = "app.component.startup.duration"
+	// ShutdownDurationMetric histogram of the seconds to stop the components
+	ShutdownDurationMetric = "app.shutdown.duration"
+)
+
+var (
+	ErrorLoggerRequired = errors.New("logger is required, use WithLogger or Logger")
+	ErrorNotReady       = errors.New("application is not ready")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::app] " + msg
+}
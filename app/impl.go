@@ -0,0 +1,235 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/health"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// New create an application builder, the components are started in the order they are registered
+//
+// e.g: app.New().WithLogger().WithPostgres().WithRabbitMQ(setup).WithHTTPServer(routes).Run(ctx)
+func New() AppBuilder {
+	return &App{
+		shutdownTimeout: DefaultShutdownTimeout,
+	}
+}
+
+func (a *App) Configs(cfg *env.Configs) AppBuilder {
+	a.cfg = cfg
+	return a
+}
+
+func (a *App) Logger(logger logging.ILogger) AppBuilder {
+	a.logger = logger
+	return a
+}
+
+func (a *App) WithLogger() AppBuilder {
+	a.withLogger = true
+	return a
+}
+
+func (a *App) WithPostgres() AppBuilder {
+	a.configAreas = append(a.configAreas, env.IConfigs.Database)
+	return a.WithComponent(&postgresComponent{})
+}
+
+func (a *App) WithRabbitMQ(setups ...RabbitMQSetup) AppBuilder {
+	a.configAreas = append(a.configAreas, env.IConfigs.Messaging)
+	return a.WithComponent(&rabbitMQComponent{setups: setups})
+}
+
+func (a *App) WithHTTPServer(setups ...HTTPServerSetup) AppBuilder {
+	a.configAreas = append(a.configAreas, env.IConfigs.HTTPServer)
+	return a.WithComponent(&httpServerComponent{setups: setups})
+}
+
+func (a *App) WithComponent(components ...Component) AppBuilder {
+	a.components = append(a.components, components...)
+	return a
+}
+
+func (a *App) OnLifecycle(listeners ...LifecycleListener) AppBuilder {
+	a.listeners = append(a.listeners, listeners...)
+	return a
+}
+
+func (a *App) ShutdownTimeout(t time.Duration) AppBuilder {
+	a.shutdownTimeout = t
+	return a
+}
+
+func (a *App) Run(ctx context.Context) error {
+	startedAt := time.Now()
+
+	cfg, err := a.configs()
+	if err != nil {
+		return err
+	}
+
+	logger, err := a.newLogger(cfg)
+	if err != nil {
+		return err
+	}
+
+	// the events are published after the logger is created, the STARTING_EVENT keeps the time the App started running
+	lifecycle := a.newLifecycle(logger, cfg.APP_NAME, startedAt)
+	lifecycle.publish(&LifecycleEvent{Kind: STARTING_EVENT, Timestamp: startedAt})
+	lifecycle.publish(&LifecycleEvent{Kind: CONFIG_LOADED_EVENT, Elapsed: lifecycle.since()})
+
+	c := &Container{
+		Cfg:       cfg,
+		Logger:    logger,
+		Health:    health.New(logger),
+		Lifecycle: lifecycle,
+	}
+	c.Health.Readiness(health.CustomProbe(AppProbeName, a.readiness))
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	started := []Component{}
+	for _, component := range a.components {
+		logger.Debug(LogMessage(fmt.Sprintf("starting %s...", component.Name())))
+
+		componentStartedAt := time.Now()
+		if err := component.Start(ctx, c); err != nil {
+			logger.Error(LogMessage(fmt.Sprintf("failure to start %s", component.Name())), logging.ErrorField(err))
+			stoppingAt := time.Now()
+			a.stop(logger, started, nil)
+			lifecycle.publish(&LifecycleEvent{Kind: STOPPED_EVENT, Elapsed: time.Since(stoppingAt), Err: err})
+			return err
+		}
+
+		started = append(started, component)
+		lifecycle.publish(&LifecycleEvent{Kind: DEPENDENCY_READY_EVENT, Component: component.Name(), Elapsed: time.Since(componentStartedAt)})
+	}
+
+	// the runners are not bounded by ctx, they are stopped in order through Component.Stop
+	runCtx, cancelRun := context.WithCancel(context.Background())
+	defer cancelRun()
+
+	runErr := make(chan error, len(started))
+	done := make([]chan struct{}, len(started))
+	for i, component := range started {
+		runner, ok := component.(Runner)
+		if !ok {
+			continue
+		}
+
+		done[i] = make(chan struct{})
+		go func(name string, runner Runner, done chan struct{}) {
+			defer close(done)
+
+			if err := runner.Run(runCtx); err != nil {
+				logger.Error(LogMessage(fmt.Sprintf("%s stopped with failure", name)), logging.ErrorField(err))
+				runErr <- err
+			}
+		}(component.Name(), runner, done[i])
+	}
+
+	lifecycle.publish(&LifecycleEvent{Kind: READY_EVENT, Elapsed: lifecycle.since()})
+
+	select {
+	case <-ctx.Done():
+	case err = <-runErr:
+	}
+
+	drainingAt := time.Now()
+	lifecycle.publish(&LifecycleEvent{Kind: DRAINING_EVENT, Elapsed: lifecycle.since(), Err: err})
+
+	if stopErr := a.stop(logger, started, done); err == nil {
+		err = stopErr
+	}
+
+	lifecycle.publish(&LifecycleEvent{Kind: STOPPED_EVENT, Elapsed: time.Since(drainingAt), Err: err})
+
+	return err
+}
+
+// newLifecycle subscribe the logging, metrics and readiness listeners before the listeners registered by OnLifecycle
+func (a *App) newLifecycle(logger logging.ILogger, app string, startedAt time.Time) *Lifecycle {
+	lifecycle := newLifecycle(logger, app, startedAt)
+	lifecycle.Subscribe(logLifecycle(logger))
+	lifecycle.Subscribe(recordLifecycle)
+	lifecycle.Subscribe(a.trackReadiness)
+
+	for _, listener := range a.listeners {
+		lifecycle.Subscribe(listener)
+	}
+
+	return lifecycle
+}
+
+func (a *App) configs() (*env.Configs, error) {
+	if a.cfg != nil {
+		return a.cfg, nil
+	}
+
+	builder := env.New()
+	for _, area := range a.configAreas {
+		builder = area(builder)
+	}
+
+	return builder.Build()
+}
+
+func (a *App) newLogger(cfg *env.Configs) (logging.ILogger, error) {
+	if a.logger != nil {
+		return a.logger, nil
+	}
+
+	if !a.withLogger {
+		return nil, ErrorLoggerRequired
+	}
+
+	return logging.NewDefaultLogger(cfg)
+}
+
+func (a *App) readiness(ctx context.Context) error {
+	if atomic.LoadInt32(&a.ready) == 0 {
+		return ErrorNotReady
+	}
+
+	return nil
+}
+
+// stop the components in the reverse order, the runner of each component must return before the next component stops
+func (a *App) stop(logger logging.ILogger, started []Component, done []chan struct{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), a.shutdownTimeout)
+	defer cancel()
+
+	var err error
+	for i := len(started) - 1; i >= 0; i-- {
+		component := started[i]
+		logger.Debug(LogMessage(fmt.Sprintf("stopping %s...", component.Name())))
+
+		if stopErr := component.Stop(ctx); stopErr != nil {
+			logger.Error(LogMessage(fmt.Sprintf("failure to stop %s", component.Name())), logging.ErrorField(stopErr))
+			if err == nil {
+				err = stopErr
+			}
+		}
+
+		if done == nil || done[i] == nil {
+			continue
+		}
+
+		select {
+		case <-done[i]:
+		case <-ctx.Done():
+			logger.Warn(LogMessage(fmt.Sprintf("%s was not stopped before the shutdown timeout", component.Name())))
+		}
+	}
+
+	return err
+}
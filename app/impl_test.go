@@ -0,0 +1,429 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/health"
+	"github.com/ralvescosta/gokit/http/server"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+type AppTestSuite struct {
+	suite.Suite
+
+	cfg    *env.Configs
+	logger logging.ILogger
+	events *events
+}
+
+type events struct {
+	mu   sync.Mutex
+	list []string
+}
+
+func (e *events) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.list = append(e.list, event)
+}
+
+func (e *events) all() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string{}, e.list...)
+}
+
+type fakeComponent struct {
+	name     string
+	events   *events
+	startErr error
+	stopErr  error
+}
+
+type fakeRunner struct {
+	fakeComponent
+	runErr   error
+	stopped  chan struct{}
+	checkers chan health.IHealthChecker
+}
+
+func (f *fakeComponent) Name() string { return f.name }
+
+func (f *fakeComponent) Start(ctx context.Context, c *Container) error {
+	f.events.add("start:" + f.name)
+	return f.startErr
+}
+
+func (f *fakeComponent) Stop(ctx context.Context) error {
+	f.events.add("stop:" + f.name)
+	return f.stopErr
+}
+
+func (f *fakeRunner) Start(ctx context.Context, c *Container) error {
+	f.checkers <- c.Health.CacheTTL(0).Build()
+	return f.fakeComponent.Start(ctx, c)
+}
+
+func (f *fakeRunner) Run(ctx context.Context) error {
+	if f.runErr != nil {
+		return f.runErr
+	}
+
+	<-f.stopped
+	f.events.add("run-returned:" + f.name)
+	return nil
+}
+
+func (f *fakeRunner) Stop(ctx context.Context) error {
+	close(f.stopped)
+	return f.fakeComponent.Stop(ctx)
+}
+
+func TestAppTestSuite(t *testing.T) {
+	suite.Run(t, new(AppTestSuite))
+}
+
+func (s *AppTestSuite) SetupTest() {
+	s.cfg = &env.Configs{}
+	s.logger = logging.NewMockLogger()
+	s.events = &events{}
+}
+
+func (s *AppTestSuite) component(name string) *fakeComponent {
+	return &fakeComponent{name: name, events: s.events}
+}
+
+func (s *AppTestSuite) runner(name string) *fakeRunner {
+	return &fakeRunner{fakeComponent: *s.component(name), stopped: make(chan struct{}), checkers: make(chan health.IHealthChecker, 1)}
+}
+
+func (s *AppTestSuite) TestRunOrderedStartupAndShutdown() {
+	runner := s.runner("runner")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	result := make(chan error)
+	go func() {
+		result <- New().
+			Configs(s.cfg).
+			Logger(s.logger).
+			WithComponent(s.component("first"), runner, s.component("last")).
+			Run(ctx)
+	}()
+
+	checker := <-runner.checkers
+	s.Eventually(func() bool {
+		return checker.Readiness(context.Background()).Status == health.UP_STATUS
+	}, time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	s.NoError(<-result)
+	s.Equal([]string{
+		"start:first", "start:runner", "start:last",
+		"stop:last", "stop:runner", "run-returned:runner", "stop:first",
+	}, s.events.all())
+	s.Equal(health.DOWN_STATUS, checker.Readiness(context.Background()).Status)
+}
+
+func (s *AppTestSuite) TestRunStartFailure() {
+	failing := s.component("failing")
+	failing.startErr = errors.New("some error")
+
+	err := New().
+		Configs(s.cfg).
+		Logger(s.logger).
+		WithComponent(s.component("first"), failing, s.component("last")).
+		Run(context.Background())
+
+	s.ErrorIs(err, failing.startErr)
+	s.Equal([]string{"start:first", "start:failing", "stop:first"}, s.events.all())
+}
+
+func (s *AppTestSuite) TestRunRunnerFailure() {
+	runner := s.runner("runner")
+	runner.runErr = errors.New("some error")
+
+	last := s.component("last")
+	last.stopErr = errors.New("stop error")
+
+	err := New().
+		Configs(s.cfg).
+		Logger(s.logger).
+		WithComponent(s.component("first"), runner, last).
+		Run(context.Background())
+
+	s.ErrorIs(err, runner.runErr)
+	s.Equal([]string{
+		"start:first", "start:runner", "start:last",
+		"stop:last", "stop:runner", "stop:first",
+	}, s.events.all())
+}
+
+func (s *AppTestSuite) lifecycle(evt *LifecycleEvent) {
+	event := "event:" + string(evt.Kind)
+	if evt.Component != "" {
+		event += ":" + evt.Component
+	}
+
+	s.Equal("orders", evt.App)
+	s.False(evt.Timestamp.IsZero())
+	s.events.add(event)
+}
+
+func (s *AppTestSuite) TestLifecycleEvents() {
+	s.cfg.APP_NAME = "orders"
+
+	runner := s.runner("runner")
+	runner.runErr = errors.New("some error")
+
+	var draining, stopped *LifecycleEvent
+	err := New().
+		Configs(s.cfg).
+		Logger(s.logger).
+		OnLifecycle(s.lifecycle, func(evt *LifecycleEvent) {
+			switch evt.Kind {
+			case DRAINING_EVENT:
+				draining = evt
+			case STOPPED_EVENT:
+				stopped = evt
+			}
+		}).
+		WithComponent(s.component("first"), runner).
+		Run(context.Background())
+
+	s.ErrorIs(err, runner.runErr)
+	s.Equal([]string{
+		"event:starting", "event:config-loaded",
+		"start:first", "event:dependency-ready:first",
+		"start:runner", "event:dependency-ready:runner",
+		"event:ready", "event:draining",
+		"stop:runner", "stop:first",
+		"event:stopped",
+	}, s.events.all())
+	s.ErrorIs(draining.Err, runner.runErr)
+	s.ErrorIs(stopped.Err, runner.runErr)
+}
+
+func (s *AppTestSuite) TestLifecycleStartFailure() {
+	s.cfg.APP_NAME = "orders"
+
+	failing := s.component("failing")
+	failing.startErr = errors.New("some error")
+
+	var stopped *LifecycleEvent
+	subscriber := &subscriberComponent{fakeComponent: s.component("subscriber"), stopped: &stopped}
+
+	err := New().
+		Configs(s.cfg).
+		Logger(s.logger).
+		OnLifecycle(s.lifecycle, func(evt *LifecycleEvent) { panic("listener failure") }).
+		WithComponent(subscriber, failing).
+		Run(context.Background())
+
+	s.ErrorIs(err, failing.startErr)
+	s.Equal([]string{
+		"event:starting", "event:config-loaded",
+		"start:subscriber", "event:dependency-ready:subscriber",
+		"start:failing", "stop:subscriber",
+		"event:stopped",
+	}, s.events.all())
+	s.Require().NotNil(stopped)
+	s.ErrorIs(stopped.Err, failing.startErr)
+}
+
+// subscriberComponent subscribe to the next lifecycle events when started
+type subscriberComponent struct {
+	*fakeComponent
+	stopped **LifecycleEvent
+}
+
+func (c *subscriberComponent) Start(ctx context.Context, container *Container) error {
+	container.Lifecycle.Subscribe(func(evt *LifecycleEvent) {
+		if evt.Kind == STOPPED_EVENT {
+			*c.stopped = evt
+		}
+	})
+
+	return c.fakeComponent.Start(ctx, container)
+}
+
+func (s *AppTestSuite) TestRunShutdownTimeout() {
+	runner := s.runner("runner")
+	stuck := &stuckRunner{fakeRunner: runner}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New().
+		Configs(s.cfg).
+		Logger(s.logger).
+		ShutdownTimeout(50*time.Millisecond).
+		WithComponent(stuck, s.component("last")).
+		Run(ctx)
+
+	s.NoError(err)
+	s.Equal([]string{"start:runner", "start:last", "stop:last", "stop:runner"}, s.events.all())
+}
+
+type stuckRunner struct {
+	*fakeRunner
+}
+
+func (r *stuckRunner) Stop(ctx context.Context) error {
+	return r.fakeComponent.Stop(ctx)
+}
+
+func (s *AppTestSuite) TestRunLoggerRequired() {
+	err := New().Configs(s.cfg).Run(context.Background())
+
+	s.ErrorIs(err, ErrorLoggerRequired)
+}
+
+func (s *AppTestSuite) TestMockComponent() {
+	component := NewMockComponent()
+	component.On("Name").Return("mock")
+	component.On("Start", mock.Anything, mock.AnythingOfType("*app.Container")).Return(nil)
+	component.On("Stop", mock.Anything).Return(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New().Configs(s.cfg).Logger(s.logger).WithComponent(component).Run(ctx)
+
+	s.NoError(err)
+	component.AssertExpectations(s.T())
+}
+
+func (s *AppTestSuite) TestWithPostgres() {
+	db, sqlMock, _ := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	sqlMock.ExpectPing()
+	sqlMock.ExpectClose()
+	newPostgres = func(logger logging.ILogger, cfg *env.Configs) (*sql.DB, error) {
+		return db, nil
+	}
+
+	var injected *sql.DB
+	probe := s.component("probe")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	result := make(chan error)
+	go func() {
+		result <- New().
+			Configs(s.cfg).
+			Logger(s.logger).
+			WithPostgres().
+			WithComponent(&injector{fakeComponent: probe, inject: func(c *Container) {
+				injected = c.DB
+				s.Equal(health.UP_STATUS, c.Health.Build().Readiness(context.Background()).Checks[PostgresComponent].Status)
+			}}).
+			Run(ctx)
+	}()
+
+	s.Eventually(func() bool { return len(s.events.all()) == 1 }, time.Second, 10*time.Millisecond)
+	cancel()
+
+	s.NoError(<-result)
+	s.Equal(db, injected)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *AppTestSuite) TestWithPostgresErr() {
+	newPostgres = func(logger logging.ILogger, cfg *env.Configs) (*sql.DB, error) {
+		return nil, errors.New("some error")
+	}
+
+	err := New().Configs(s.cfg).Logger(s.logger).WithPostgres().WithComponent(s.component("last")).Run(context.Background())
+
+	s.Error(err)
+	s.Empty(s.events.all())
+}
+
+func (s *AppTestSuite) TestWithRabbitMQ() {
+	messaging := rabbitmq.NewMockRabbitMQMessaging()
+	messaging.On("Build", nil).Return(messaging, nil)
+	messaging.On("Consume", nil).Return(nil)
+	messaging.On("Shutdown", mock.Anything).Return(nil)
+	newRabbitMQ = func(cfg *env.Configs, logger logging.ILogger) rabbitmq.IRabbitMQMessaging {
+		return messaging
+	}
+
+	setupCalled := false
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := New().
+		Configs(s.cfg).
+		Logger(s.logger).
+		WithRabbitMQ(func(c *Container, m rabbitmq.IRabbitMQMessaging) error {
+			setupCalled = m == messaging
+			return nil
+		}).
+		Run(ctx)
+
+	s.NoError(err)
+	s.True(setupCalled)
+	messaging.AssertExpectations(s.T())
+}
+
+func (s *AppTestSuite) TestWithHTTPServer() {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	addr := listener.Addr().String()
+	listener.Close()
+	s.cfg.HTTP_ADDR = addr
+
+	ctx, cancel := context.WithCancel(context.Background())
+	result := make(chan error)
+	go func() {
+		result <- New().
+			Configs(s.cfg).
+			Logger(s.logger).
+			WithHTTPServer(func(c *Container, srv server.IHTTPServer) error {
+				return srv.RegisterRoute(http.MethodGet, "/ping", func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusNoContent)
+				})
+			}).
+			Run(ctx)
+	}()
+
+	s.Eventually(func() bool {
+		res, err := http.Get(fmt.Sprintf("http://%s%s", addr, health.ReadinessPath))
+		if err != nil {
+			return false
+		}
+		res.Body.Close()
+		return res.StatusCode == http.StatusOK
+	}, 3*time.Second, 50*time.Millisecond)
+
+	res, err := http.Get(fmt.Sprintf("http://%s/ping", addr))
+	s.NoError(err)
+	s.Equal(http.StatusNoContent, res.StatusCode)
+	res.Body.Close()
+
+	cancel()
+
+	s.NoError(<-result)
+	_, err = http.Get(fmt.Sprintf("http://%s/ping", addr))
+	s.Error(err)
+}
+
+type injector struct {
+	*fakeComponent
+	inject func(c *Container)
+}
+
+func (i *injector) Start(ctx context.Context, c *Container) error {
+	i.inject(c)
+	return i.fakeComponent.Start(ctx, c)
+}
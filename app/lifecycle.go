@@ -0,0 +1,145 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/version"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+)
+
+func newLifecycle(logger logging.ILogger, app string, startedAt time.Time) *Lifecycle {
+	return &Lifecycle{logger: logger, app: app, startedAt: startedAt}
+}
+
+// Subscribe register the listener to the next events
+func (l *Lifecycle) Subscribe(listener LifecycleListener) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.listeners = append(l.listeners, listener)
+}
+
+// publish fill the app name and the timestamp of the event and call the listeners
+func (l *Lifecycle) publish(evt *LifecycleEvent) {
+	evt.App = l.app
+	if evt.Timestamp.IsZero() {
+		evt.Timestamp = time.Now()
+	}
+
+	l.mu.RLock()
+	listeners := append([]LifecycleListener{}, l.listeners...)
+	l.mu.RUnlock()
+
+	for _, listener := range listeners {
+		l.notify(listener, evt)
+	}
+}
+
+// notify a listener failure must not stop the App
+func (l *Lifecycle) notify(listener LifecycleListener, evt *LifecycleEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			l.logger.Warn(LogMessage(fmt.Sprintf("lifecycle listener panicked on the %s event: %v", evt.Kind, r)))
+		}
+	}()
+
+	listener(evt)
+}
+
+func (l *Lifecycle) since() time.Duration {
+	return time.Since(l.startedAt)
+}
+
+// logLifecycle the startup diagnostics, the component events are logged in debug
+func logLifecycle(logger logging.ILogger) LifecycleListener {
+	return func(evt *LifecycleEvent) {
+		fields := []zap.Field{
+			logging.MessageField("event", string(evt.Kind)),
+			logging.MessageField("elapsed", evt.Elapsed.String()),
+		}
+
+		switch evt.Kind {
+		case STARTING_EVENT:
+			info := version.Get()
+			logger.Info(
+				LogMessage(fmt.Sprintf("starting %s %s", evt.App, info)),
+				append(fields,
+					logging.MessageField("version", info.Version),
+					logging.MessageField("commit", info.Commit),
+					logging.MessageField("buildDate", info.BuildDate),
+					logging.MessageField("goVersion", info.GoVersion),
+				)...,
+			)
+		case CONFIG_LOADED_EVENT:
+			logger.Debug(LogMessage(fmt.Sprintf("configs loaded in %s", evt.Elapsed)), fields...)
+		case DEPENDENCY_READY_EVENT:
+			logger.Debug(LogMessage(fmt.Sprintf("%s started in %s", evt.Component, evt.Elapsed)), append(fields, logging.MessageField("component", evt.Component))...)
+		case READY_EVENT:
+			logger.Info(LogMessage(fmt.Sprintf("application started in %s", evt.Elapsed)), fields...)
+		case DRAINING_EVENT:
+			if evt.Err != nil {
+				logger.Warn(LogMessage("shutting down the application due a runner failure..."), append(fields, logging.ErrorField(evt.Err))...)
+				return
+			}
+			logger.Info(LogMessage("shutting down the application..."), fields...)
+		case STOPPED_EVENT:
+			if evt.Err != nil {
+				logger.Error(LogMessage("application stopped with failure"), append(fields, logging.ErrorField(evt.Err))...)
+				return
+			}
+			logger.Info(LogMessage("application stopped"), fields...)
+		}
+	}
+}
+
+// recordLifecycle the instruments are created with the global meter provider on each event, so the provider configured
+// by the components is used
+func recordLifecycle(evt *LifecycleEvent) {
+	meter := otel.Meter(MeterName)
+
+	attrs := []attribute.KeyValue{attribute.String("app.name", evt.App)}
+	if evt.Component != "" {
+		attrs = append(attrs, attribute.String("app.component", evt.Component))
+	}
+
+	counter, err := meter.Int64Counter(LifecycleEventsMetric, metric.WithDescription("application lifecycle events"))
+	if err == nil {
+		counter.Add(context.Background(), 1, metric.WithAttributes(append(attrs, attribute.String("app.lifecycle.event", string(evt.Kind)))...))
+	}
+
+	var name, description string
+	switch evt.Kind {
+	case DEPENDENCY_READY_EVENT:
+		name, description = ComponentStartupDurationMetric, "the time each component took to start"
+	case READY_EVENT:
+		name, description = StartupDurationMetric, "the time until the application is ready"
+	case STOPPED_EVENT:
+		name, description = ShutdownDurationMetric, "the time to stop the components"
+	default:
+		return
+	}
+
+	histogram, err := meter.Float64Histogram(name, metric.WithDescription(description), metric.WithUnit("s"))
+	if err != nil {
+		return
+	}
+
+	histogram.Record(context.Background(), evt.Elapsed.Seconds(), metric.WithAttributes(attrs...))
+}
+
+// trackReadiness the App probe is up between the READY_EVENT and the DRAINING_EVENT
+func (a *App) trackReadiness(evt *LifecycleEvent) {
+	switch evt.Kind {
+	case READY_EVENT:
+		atomic.StoreInt32(&a.ready, 1)
+	case DRAINING_EVENT, STOPPED_EVENT:
+		atomic.StoreInt32(&a.ready, 0)
+	}
+}
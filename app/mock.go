@@ -0,0 +1,35 @@
+package app
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockComponent struct {
+		mock.Mock
+	}
+)
+
+func (m *MockComponent) Name() string {
+	args := m.Called()
+
+	return args.String(0)
+}
+
+func (m *MockComponent) Start(ctx context.Context, c *Container) error {
+	args := m.Called(ctx, c)
+
+	return args.Error(0)
+}
+
+func (m *MockComponent) Stop(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func NewMockComponent() *MockComponent {
+	return new(MockComponent)
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/health"
+	"github.com/ralvescosta/gokit/http/server"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+type (
+	LifecycleEventKind string
+
+	// Component a part of the application with its lifecycle managed by the App
+	//
+	// Components are started in the order they were registered and stopped in the reverse order
+	Component interface {
+		Name() string
+		// Start initialize the component, it may read and fill the shared Container
+		Start(ctx context.Context, c *Container) error
+		// Stop release the component resources, the ctx is bounded by the shutdown timeout
+		Stop(ctx context.Context) error
+	}
+
+	// Runner is implemented by the components that block while serving, e.g: http server and consumers
+	//
+	// Run is called after all the components were started, an error returned before the shutdown stops the App
+	Runner interface {
+		Run(ctx context.Context) error
+	}
+
+	// Container the shared dependencies injected in the components
+	Container struct {
+		Cfg        *env.Configs
+		Logger     logging.ILogger
+		DB         *sql.DB
+		Messaging  rabbitmq.IRabbitMQMessaging
+		HTTPServer server.IHTTPServer
+		// Health register the readiness and liveness probes, the http server exposes them
+		Health health.HealthBuilder
+		// Lifecycle subscribe the components to the lifecycle events, e.g: to warm a cache when the App is ready
+		Lifecycle *Lifecycle
+	}
+
+	// LifecycleEvent published by the App while it starts and stops
+	LifecycleEvent struct {
+		Kind LifecycleEventKind
+		App  string
+		// Component the component started in the DEPENDENCY_READY_EVENT
+		Component string
+		// Elapsed the time since the STARTING_EVENT, except the component start time in the DEPENDENCY_READY_EVENT and the
+		// time to stop the components in the STOPPED_EVENT
+		Elapsed   time.Duration
+		Timestamp time.Time
+		// Err the failure that stopped the App, set in the DRAINING_EVENT and the STOPPED_EVENT
+		Err error
+	}
+
+	// LifecycleListener called synchronously in the events order, it must not block the App
+	LifecycleListener = func(evt *LifecycleEvent)
+
+	// Lifecycle the internal bus of the lifecycle events, the logging, metrics and readiness listeners are subscribed
+	// before the listeners registered by OnLifecycle and the components
+	Lifecycle struct {
+		logger    logging.ILogger
+		app       string
+		startedAt time.Time
+		mu        sync.RWMutex
+		listeners []LifecycleListener
+	}
+
+	// RabbitMQSetup declare the topologies and register the dispatchers before the messaging is built
+	RabbitMQSetup = func(c *Container, messaging rabbitmq.IRabbitMQMessaging) error
+
+	// HTTPServerSetup register the routes in the server
+	HTTPServerSetup = func(c *Container, srv server.IHTTPServer) error
+
+	AppBuilder interface {
+		// Configs use a configs already built instead of reading the env areas required by the components
+		Configs(cfg *env.Configs) AppBuilder
+		// Logger use a custom logger
+		Logger(logger logging.ILogger) AppBuilder
+		// WithLogger create the default logger using the configs
+		WithLogger() AppBuilder
+		WithPostgres() AppBuilder
+		WithRabbitMQ(setups ...RabbitMQSetup) AppBuilder
+		WithHTTPServer(setups ...HTTPServerSetup) AppBuilder
+		// WithComponent register custom components
+		WithComponent(components ...Component) AppBuilder
+		// OnLifecycle subscribe to the lifecycle events, e.g: to publish the startup diagnostics to other system
+		OnLifecycle(listeners ...LifecycleListener) AppBuilder
+		// ShutdownTimeout the max time to stop all the components
+		ShutdownTimeout(t time.Duration) AppBuilder
+		// Run start the components, blocks until ctx is done, a SIGINT/SIGTERM is received or a runner fails and then stops the components
+		Run(ctx context.Context) error
+	}
+
+	App struct {
+		cfg             *env.Configs
+		logger          logging.ILogger
+		withLogger      bool
+		configAreas     []func(env.IConfigs) env.IConfigs
+		components      []Component
+		listeners       []LifecycleListener
+		shutdownTimeout time.Duration
+		ready           int32
+	}
+
+	postgresComponent struct {
+		db *sql.DB
+	}
+
+	rabbitMQComponent struct {
+		setups    []RabbitMQSetup
+		messaging rabbitmq.IRabbitMQMessaging
+	}
+
+	httpServerComponent struct {
+		setups []HTTPServerSetup
+		srv    server.IHTTPServer
+		sig    chan os.Signal
+	}
+)
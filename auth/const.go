@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+)
+
+const (
+	AuthorizationHeader = "Authorization"
+	AuthorizationMeta   = "authorization"
+	BearerPrefix        = "Bearer "
+
+	DefaultJWKSRefreshInterval   = 15 * time.Minute
+	DefaultJWKSMinRefreshTimeout = 10 * time.Second
+	DefaultJWKSRequestTimeout    = 5 * time.Second
+
+	// JWKSPath the well known path of the TokenIssuer JWKSHandler
+	JWKSPath               = "/.well-known/jwks.json"
+	DefaultJWKSCacheMaxAge = 5 * time.Minute
+
+	DefaultAccessTokenTTL     = 15 * time.Minute
+	DefaultRefreshTokenTTL    = 30 * 24 * time.Hour
+	DefaultRefreshTokensTable = "refresh_tokens"
+
+	RefreshTokensSchema = `CREATE TABLE IF NOT EXISTS %s (
+	token_hash TEXT PRIMARY KEY,
+	family_id TEXT NOT NULL,
+	subject TEXT NOT NULL,
+	claims TEXT NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL,
+	used_at TIMESTAMPTZ,
+	revoked_at TIMESTAMPTZ,
+	created_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS %s_family_id_idx ON %s (family_id);`
+)
+
+var (
+	ErrorMissingToken       = errors.New("missing bearer token")
+	ErrorInvalidToken       = errors.New("invalid token")
+	ErrorInvalidIssuer      = errors.New("invalid token issuer")
+	ErrorInvalidAudience    = errors.New("invalid token audience")
+	ErrorKeyNotFound        = errors.New("signing key not found in jwks")
+	ErrorUnsupportedKey     = errors.New("unsupported jwk key type")
+	ErrorJWKSFetch          = errors.New("failure to fetch jwks")
+	ErrorInsufficientScopes = errors.New("insufficient scopes")
+	ErrorInsufficientRoles  = errors.New("insufficient roles")
+	ErrorClientCredentials  = errors.New("client id, client secret and token url are required")
+
+	ErrorSigningKey          = errors.New("the signing key must have a kid and a rsa or ecdsa private key")
+	ErrorRetireSigningKey    = errors.New("the signing key can not be retired, rotate it first")
+	ErrorInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrorRefreshTokenReused  = errors.New("refresh token reused, the token family was revoked")
+)
+
+// systemClock is replaced in the tests
+var systemClock = clock.New()
+
+func LogMessage(msg string) string {
+	return "[gokit::auth] " + msg
+}
@@ -0,0 +1,16 @@
+package auth
+
+import "context"
+
+type claimsCtxKey struct{}
+
+// ContextWithClaims store the validated claims in the context
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsCtxKey{}, claims)
+}
+
+// ClaimsFromContext get the validated claims from the context
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsCtxKey{}).(*Claims)
+	return claims, ok
+}
@@ -0,0 +1,35 @@
+module github.com/ralvescosta/gokit/auth
+
+go 1.18
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/golang-jwt/jwt/v4 v4.4.2
+	github.com/ralvescosta/gokit/clock v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/crypto v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/sql v0.0.0-20261016183433-cb393702ac94
+	github.com/stretchr/testify v1.8.0
+	golang.org/x/oauth2 v0.0.0-20220718184931-c8730f7fcb92
+	golang.org/x/sync v0.14.0
+	google.golang.org/grpc v1.65.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/ralvescosta/dotenv v1.0.4 // indirect
+	github.com/stretchr/objx v0.4.0 // indirect
+	go.uber.org/atomic v1.9.0 // indirect
+	go.uber.org/multierr v1.8.0 // indirect
+	go.uber.org/zap v1.21.0 // indirect
+	golang.org/x/net v0.0.0-20220624214902-1bab6f366d9e // indirect
+	golang.org/x/sys v0.0.0-20220712014510-0a85c31ab51e // indirect
+	golang.org/x/text v0.3.7 // indirect
+	google.golang.org/appengine v1.6.7 // indirect
+	google.golang.org/genproto v0.0.0-20250603155806-513f23925822 // indirect
+	google.golang.org/protobuf v1.28.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
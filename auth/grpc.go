@@ -0,0 +1,67 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type wrappedServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *wrappedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryServerInterceptor validate the bearer token received in the authorization metadata
+func UnaryServerInterceptor(validator ITokenValidator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := authorizeGrpc(ctx, validator)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor validate the bearer token received in the authorization metadata
+func StreamServerInterceptor(validator ITokenValidator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorizeGrpc(ss.Context(), validator)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+func authorizeGrpc(ctx context.Context, validator ITokenValidator) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok || len(md.Get(AuthorizationMeta)) == 0 {
+		return nil, status.Error(codes.Unauthenticated, ErrorMissingToken.Error())
+	}
+
+	token, err := bearerToken(md.Get(AuthorizationMeta)[0])
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	claims, err := validator.Validate(ctx, token)
+	if err != nil {
+		if errors.Is(err, ErrorJWKSFetch) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	return ContextWithClaims(ctx, claims), nil
+}
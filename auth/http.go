@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+)
+
+// HTTPMiddleware validate the bearer token and store the claims in the request context
+func HTTPMiddleware(validator ITokenValidator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, err := bearerToken(r.Header.Get(AuthorizationHeader))
+			if err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			claims, err := validator.Validate(r.Context(), token)
+			if err != nil {
+				status := http.StatusUnauthorized
+				if errors.Is(err, ErrorJWKSFetch) {
+					status = http.StatusServiceUnavailable
+				}
+
+				w.WriteHeader(status)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(ContextWithClaims(r.Context(), claims)))
+		})
+	}
+}
+
+// RequireScopes must be used after HTTPMiddleware, respond 403 when the token does not have the scopes
+func RequireScopes(scopes ...string) func(http.Handler) http.Handler {
+	return require(func(c *Claims) bool { return c.HasScopes(scopes...) })
+}
+
+// RequireRoles must be used after HTTPMiddleware, respond 403 when the token does not have the roles
+func RequireRoles(roles ...string) func(http.Handler) http.Handler {
+	return require(func(c *Claims) bool { return c.HasRoles(roles...) })
+}
+
+func require(allowed func(c *Claims) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			claims, ok := ClaimsFromContext(r.Context())
+			if !ok {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			if !allowed(claims) {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	gokitCrypto "github.com/ralvescosta/gokit/crypto"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// NewTokenIssuer create the issuer that signs the tokens with the opts.Key, the issuer and audience claims are the AUTH_ISSUER and AUTH_AUDIENCE
+func NewTokenIssuer(cfg *env.Configs, logger logging.ILogger, opts *IssuerOpts) (ITokenIssuer, error) {
+	if opts.AccessTokenTTL == 0 {
+		opts.AccessTokenTTL = DefaultAccessTokenTTL
+	}
+
+	issuer := &TokenIssuer{
+		logger:   logger,
+		issuer:   cfg.AUTH_ISSUER,
+		audience: cfg.AUTH_AUDIENCE,
+		ttl:      opts.AccessTokenTTL,
+	}
+
+	if err := issuer.Rotate(opts.Key); err != nil {
+		return nil, err
+	}
+
+	return issuer, nil
+}
+
+func (i *TokenIssuer) Sign(claims *Claims) (string, error) {
+	now := systemClock.Now()
+
+	jti, err := gokitCrypto.RandomToken(16)
+	if err != nil {
+		return "", err
+	}
+
+	signed := *claims
+	signed.Issuer = i.issuer
+	signed.IssuedAt = jwt.NewNumericDate(now)
+	signed.NotBefore = jwt.NewNumericDate(now)
+	signed.ExpiresAt = jwt.NewNumericDate(now.Add(i.ttl))
+	signed.ID = jti
+
+	if len(signed.Audience) == 0 && i.audience != "" {
+		signed.Audience = jwt.ClaimStrings{i.audience}
+	}
+
+	i.mu.RLock()
+	key := i.keys[len(i.keys)-1]
+	i.mu.RUnlock()
+
+	token := jwt.NewWithClaims(key.method, &signed)
+	token.Header["kid"] = key.Kid
+
+	return token.SignedString(key.Private)
+}
+
+func (i *TokenIssuer) AccessTokenTTL() time.Duration {
+	return i.ttl
+}
+
+// Rotate the key become the signing key, the previous keys are still published in the JWKS until Retire,
+// so the tokens already issued remain valid while the validators refresh the JWKS
+func (i *TokenIssuer) Rotate(key SigningKey) error {
+	method, jwk, err := signingKeyJWK(key)
+	if err != nil {
+		return err
+	}
+
+	key.method = method
+	key.jwk = jwk
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	keys := []SigningKey{}
+	for _, k := range i.keys {
+		if k.Kid != key.Kid {
+			keys = append(keys, k)
+		}
+	}
+
+	i.keys = append(keys, key)
+
+	i.logger.Debug(LogMessage(fmt.Sprintf("signing key rotated, kid: %s", key.Kid)))
+
+	return nil
+}
+
+// Retire remove the key from the JWKS, the signing key can not be retired
+func (i *TokenIssuer) Retire(kid string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for idx, k := range i.keys {
+		if k.Kid != kid {
+			continue
+		}
+
+		if idx == len(i.keys)-1 {
+			return ErrorRetireSigningKey
+		}
+
+		i.keys = append(i.keys[:idx:idx], i.keys[idx+1:]...)
+		return nil
+	}
+
+	return ErrorKeyNotFound
+}
+
+func (i *TokenIssuer) JWKS() *JWKS {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	jwks := &JWKS{Keys: make([]JWK, 0, len(i.keys))}
+	for _, k := range i.keys {
+		jwks.Keys = append(jwks.Keys, k.jwk)
+	}
+
+	return jwks
+}
+
+// JWKSHandler serve the JWKS, it is usually mounted in the JWKSPath so the TokenValidator of the other services can fetch it
+func (i *TokenIssuer) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(DefaultJWKSCacheMaxAge.Seconds())))
+
+		if err := json.NewEncoder(w).Encode(i.JWKS()); err != nil {
+			i.logger.Error(LogMessage("failure to encode the jwks"), logging.ErrorField(err))
+		}
+	})
+}
+
+// NewJWK the public jwk of the rsa or ecdsa public key
+func NewJWK(kid, alg string, key crypto.PublicKey) (JWK, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kid: kid,
+			Kty: "RSA",
+			Alg: alg,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		size := (k.Curve.Params().BitSize + 7) / 8
+
+		return JWK{
+			Kid: kid,
+			Kty: "EC",
+			Alg: alg,
+			Use: "sig",
+			Crv: k.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, size))),
+			Y:   base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, size))),
+		}, nil
+	default:
+		return JWK{}, ErrorUnsupportedKey
+	}
+}
+
+// signingKeyJWK the rsa keys sign with RS256 and the ecdsa keys with the ES algorithm of the curve
+func signingKeyJWK(key SigningKey) (jwt.SigningMethod, JWK, error) {
+	if key.Kid == "" {
+		return nil, JWK{}, ErrorSigningKey
+	}
+
+	var method jwt.SigningMethod
+	var public crypto.PublicKey
+
+	switch k := key.Private.(type) {
+	case *rsa.PrivateKey:
+		method, public = jwt.SigningMethodRS256, &k.PublicKey
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			method = jwt.SigningMethodES256
+		case elliptic.P384():
+			method = jwt.SigningMethodES384
+		case elliptic.P521():
+			method = jwt.SigningMethodES512
+		default:
+			return nil, JWK{}, ErrorUnsupportedKey
+		}
+
+		public = &k.PublicKey
+	default:
+		return nil, JWK{}, ErrorSigningKey
+	}
+
+	jwk, err := NewJWK(key.Kid, method.Alg(), public)
+	return method, jwk, err
+}
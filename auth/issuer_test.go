@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenIssuerTestSuite struct {
+	suite.Suite
+
+	cfg    *env.Configs
+	rsaKey *rsa.PrivateKey
+	ecKey  *ecdsa.PrivateKey
+	issuer ITokenIssuer
+}
+
+func TestTokenIssuerTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenIssuerTestSuite))
+}
+
+func (s *TokenIssuerTestSuite) SetupTest() {
+	s.cfg = &env.Configs{AUTH_ISSUER: "issuer", AUTH_AUDIENCE: "audience"}
+	s.rsaKey, _ = rsa.GenerateKey(rand.Reader, 2048)
+	s.ecKey, _ = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	var err error
+	s.issuer, err = NewTokenIssuer(s.cfg, logging.NewMockLogger(), &IssuerOpts{Key: SigningKey{Kid: "rsa", Private: s.rsaKey}})
+	s.NoError(err)
+}
+
+func (s *TokenIssuerTestSuite) validator() (ITokenValidator, func()) {
+	server := httptest.NewServer(s.issuer.JWKSHandler())
+	cfg := *s.cfg
+	cfg.AUTH_JWKS_URL = server.URL
+
+	return NewTokenValidator(&cfg, logging.NewMockLogger(), NewJWKSProvider(&cfg, logging.NewMockLogger())), server.Close
+}
+
+func (s *TokenIssuerTestSuite) TestNewTokenIssuer() {
+	_, err := NewTokenIssuer(s.cfg, logging.NewMockLogger(), &IssuerOpts{Key: SigningKey{Private: s.rsaKey}})
+	s.ErrorIs(err, ErrorSigningKey)
+
+	_, err = NewTokenIssuer(s.cfg, logging.NewMockLogger(), &IssuerOpts{Key: SigningKey{Kid: "kid"}})
+	s.ErrorIs(err, ErrorSigningKey)
+
+	s.Equal(DefaultAccessTokenTTL, s.issuer.AccessTokenTTL())
+}
+
+func (s *TokenIssuerTestSuite) TestSign() {
+	validator, close := s.validator()
+	defer close()
+
+	claims := &Claims{Roles: []string{"admin"}}
+	claims.Subject = "user"
+
+	token, err := s.issuer.Sign(claims)
+	s.NoError(err)
+
+	validated, err := validator.Validate(context.Background(), token)
+	s.NoError(err)
+	s.Equal("user", validated.Subject)
+	s.Equal("issuer", validated.Issuer)
+	s.NotEmpty(validated.ID)
+	s.True(validated.HasRoles("admin"))
+	s.Empty(claims.Issuer)
+}
+
+func (s *TokenIssuerTestSuite) TestRotate() {
+	validator, close := s.validator()
+	defer close()
+
+	old, _ := s.issuer.Sign(&Claims{})
+
+	s.NoError(s.issuer.Rotate(SigningKey{Kid: "ec", Private: s.ecKey}))
+
+	jwks := s.issuer.JWKS()
+	s.Len(jwks.Keys, 2)
+	s.Equal("ES256", jwks.Keys[1].Alg)
+	s.Equal("P-256", jwks.Keys[1].Crv)
+
+	token, _ := s.issuer.Sign(&Claims{})
+	_, err := validator.Validate(context.Background(), token)
+	s.NoError(err)
+
+	_, err = validator.Validate(context.Background(), old)
+	s.NoError(err)
+
+	s.ErrorIs(s.issuer.Retire("ec"), ErrorRetireSigningKey)
+	s.ErrorIs(s.issuer.Retire("unknown"), ErrorKeyNotFound)
+	s.NoError(s.issuer.Retire("rsa"))
+	s.Len(s.issuer.JWKS().Keys, 1)
+}
+
+func (s *TokenIssuerTestSuite) TestJWKSHandler() {
+	rec := httptest.NewRecorder()
+	s.issuer.JWKSHandler().ServeHTTP(rec, httptest.NewRequest("GET", JWKSPath, nil))
+
+	jwks := &JWKS{}
+	s.NoError(json.NewDecoder(rec.Body).Decode(jwks))
+	s.Equal("rsa", jwks.Keys[0].Kid)
+	s.Equal("RS256", jwks.Keys[0].Alg)
+	s.Equal("public, max-age=300", rec.Header().Get("Cache-Control"))
+
+	key, err := jwks.Keys[0].PublicKey()
+	s.NoError(err)
+	s.Equal(&s.rsaKey.PublicKey, key)
+}
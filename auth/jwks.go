@@ -0,0 +1,188 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// NewJWKSProvider create a provider that fetches the JWKS from the configured url
+//
+// The keys are cached and refreshed each AUTH_JWKS_REFRESH_INTERVAL, when a token is signed with an unknown kid
+// the keys are refreshed again so key rotation is handled without restarts
+func NewJWKSProvider(cfg *env.Configs, logger logging.ILogger) *JWKSProvider {
+	interval := cfg.AUTH_JWKS_REFRESH_INTERVAL
+	if interval == 0 {
+		interval = DefaultJWKSRefreshInterval
+	}
+
+	return &JWKSProvider{
+		logger:             logger,
+		url:                cfg.AUTH_JWKS_URL,
+		client:             &http.Client{Timeout: DefaultJWKSRequestTimeout},
+		refreshInterval:    interval,
+		minRefreshInterval: DefaultJWKSMinRefreshTimeout,
+		keys:               map[string]crypto.PublicKey{},
+	}
+}
+
+func (p *JWKSProvider) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	p.mu.RLock()
+	key, ok := p.keys[kid]
+	expired := time.Since(p.lastRefresh) > p.refreshInterval
+	p.mu.RUnlock()
+
+	if ok && !expired {
+		return key, nil
+	}
+
+	if err := p.Refresh(ctx, !ok); err != nil {
+		if ok {
+			// the key is still valid but the jwks endpoint is unavailable, keep using the cached key
+			return key, nil
+		}
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	key, ok = p.keys[kid]
+	if !ok {
+		return nil, ErrorKeyNotFound
+	}
+
+	return key, nil
+}
+
+// Refresh fetch the JWKS again, when unknownKid is true the refresh is rate limited to avoid flooding the issuer.
+// The concurrent refreshes share the same fetch and the cached keys are only locked to be replaced
+func (p *JWKSProvider) Refresh(ctx context.Context, unknownKid bool) error {
+	p.mu.RLock()
+	lastRefresh := p.lastRefresh
+	p.mu.RUnlock()
+
+	if unknownKid && time.Since(lastRefresh) < p.minRefreshInterval {
+		return ErrorKeyNotFound
+	}
+
+	_, err, _ := p.group.Do(p.url, func() (interface{}, error) {
+		keys, err := p.fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		p.mu.Lock()
+		p.keys = keys
+		p.lastRefresh = time.Now()
+		p.mu.Unlock()
+
+		p.logger.Debug(LogMessage("jwks refreshed"))
+		return nil, nil
+	})
+
+	return err
+}
+
+func (p *JWKSProvider) fetch(ctx context.Context) (map[string]crypto.PublicKey, error) {
+	p.logger.Debug(LogMessage("fetching jwks..."))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		p.logger.Error(LogMessage("failure to fetch jwks"), logging.ErrorField(err))
+		return nil, ErrorJWKSFetch
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		p.logger.Error(LogMessage(fmt.Sprintf("failure to fetch jwks - status code: %d", res.StatusCode)))
+		return nil, ErrorJWKSFetch
+	}
+
+	jwks := &JWKS{}
+	if err := json.NewDecoder(res.Body).Decode(jwks); err != nil {
+		p.logger.Error(LogMessage("failure to decode jwks"), logging.ErrorField(err))
+		return nil, ErrorJWKSFetch
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		key, err := jwk.PublicKey()
+		if err != nil {
+			p.logger.Warn(LogMessage("skipping jwk"), logging.MessageField("kid", jwk.Kid), logging.ErrorField(err))
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// PublicKey convert the jwk into a rsa or ecdsa public key
+func (k *JWK) PublicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, ErrorUnsupportedKey
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, ErrorUnsupportedKey
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	byt, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(byt), nil
+}
@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ralvescosta/gokit/env"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// NewClientCredentialsTokenSource create a cached and auto renewable token source using the OAuth2 client credentials flow
+func NewClientCredentialsTokenSource(ctx context.Context, cfg *env.Configs) (oauth2.TokenSource, error) {
+	if cfg.AUTH_CLIENT_ID == "" || cfg.AUTH_CLIENT_SECRET == "" || cfg.AUTH_TOKEN_URL == "" {
+		return nil, ErrorClientCredentials
+	}
+
+	c := &clientcredentials.Config{
+		ClientID:     cfg.AUTH_CLIENT_ID,
+		ClientSecret: cfg.AUTH_CLIENT_SECRET,
+		TokenURL:     cfg.AUTH_TOKEN_URL,
+		Scopes:       cfg.AUTH_SCOPES,
+	}
+
+	if cfg.AUTH_AUDIENCE != "" {
+		c.EndpointParams = map[string][]string{"audience": {cfg.AUTH_AUDIENCE}}
+	}
+
+	return c.TokenSource(ctx), nil
+}
+
+// NewM2MHTTPClient create a http client that sends the client credentials token in each request
+func NewM2MHTTPClient(ctx context.Context, cfg *env.Configs, base http.RoundTripper) (*http.Client, error) {
+	ts, err := NewClientCredentialsTokenSource(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &http.Client{
+		Transport: &oauth2.Transport{Source: ts, Base: base},
+	}, nil
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"net/http"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockTokenValidator struct {
+		mock.Mock
+	}
+
+	MockJWKSProvider struct {
+		mock.Mock
+	}
+
+	MockTokenIssuer struct {
+		mock.Mock
+	}
+
+	MockRefreshTokenRotator struct {
+		mock.Mock
+	}
+)
+
+func (m *MockTokenValidator) Validate(ctx context.Context, token string) (*Claims, error) {
+	args := m.Called(ctx, token)
+
+	res, _ := args.Get(0).(*Claims)
+
+	return res, args.Error(1)
+}
+
+func (m *MockJWKSProvider) Key(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	args := m.Called(ctx, kid)
+
+	return args.Get(0), args.Error(1)
+}
+
+func NewMockTokenValidator() *MockTokenValidator {
+	return new(MockTokenValidator)
+}
+
+func NewMockJWKSProvider() *MockJWKSProvider {
+	return new(MockJWKSProvider)
+}
+
+func (m *MockTokenIssuer) Sign(claims *Claims) (string, error) {
+	args := m.Called(claims)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenIssuer) AccessTokenTTL() time.Duration {
+	args := m.Called()
+
+	return args.Get(0).(time.Duration)
+}
+
+func (m *MockTokenIssuer) Rotate(key SigningKey) error {
+	args := m.Called(key)
+
+	return args.Error(0)
+}
+
+func (m *MockTokenIssuer) Retire(kid string) error {
+	args := m.Called(kid)
+
+	return args.Error(0)
+}
+
+func (m *MockTokenIssuer) JWKS() *JWKS {
+	args := m.Called()
+
+	res, _ := args.Get(0).(*JWKS)
+
+	return res
+}
+
+func (m *MockTokenIssuer) JWKSHandler() http.Handler {
+	args := m.Called()
+
+	res, _ := args.Get(0).(http.Handler)
+
+	return res
+}
+
+func (m *MockRefreshTokenRotator) Issue(ctx context.Context, claims *Claims) (*TokenPair, error) {
+	args := m.Called(ctx, claims)
+
+	res, _ := args.Get(0).(*TokenPair)
+
+	return res, args.Error(1)
+}
+
+func (m *MockRefreshTokenRotator) Rotate(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	args := m.Called(ctx, refreshToken)
+
+	res, _ := args.Get(0).(*TokenPair)
+
+	return res, args.Error(1)
+}
+
+func (m *MockRefreshTokenRotator) Revoke(ctx context.Context, refreshToken string) error {
+	args := m.Called(ctx, refreshToken)
+
+	return args.Error(0)
+}
+
+func NewMockTokenIssuer() *MockTokenIssuer {
+	return new(MockTokenIssuer)
+}
+
+func NewMockRefreshTokenRotator() *MockRefreshTokenRotator {
+	return new(MockRefreshTokenRotator)
+}
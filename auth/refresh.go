@@ -0,0 +1,170 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	gokitCrypto "github.com/ralvescosta/gokit/crypto"
+	"github.com/ralvescosta/gokit/logging"
+	gokitSQL "github.com/ralvescosta/gokit/sql"
+)
+
+// NewRefreshTokenRotator the refresh tokens are opaque random tokens, only their sha256 is stored in the table, see MigrateRefreshTokens
+func NewRefreshTokenRotator(logger logging.ILogger, db *sql.DB, issuer ITokenIssuer, opts *RefreshOpts) IRefreshTokenRotator {
+	if opts == nil {
+		opts = &RefreshOpts{}
+	}
+
+	if opts.Table == "" {
+		opts.Table = DefaultRefreshTokensTable
+	}
+
+	if opts.RefreshTokenTTL == 0 {
+		opts.RefreshTokenTTL = DefaultRefreshTokenTTL
+	}
+
+	return &RefreshTokenRotator{
+		Repository: gokitSQL.NewRepository(db),
+		logger:     logger,
+		issuer:     issuer,
+		opts:       opts,
+	}
+}
+
+// MigrateRefreshTokens create the refresh tokens table if it does not exist
+func MigrateRefreshTokens(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultRefreshTokensTable
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(RefreshTokensSchema, table, table, table))
+	return err
+}
+
+func (r *RefreshTokenRotator) Issue(ctx context.Context, claims *Claims) (*TokenPair, error) {
+	family, err := gokitCrypto.RandomToken(16)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.issue(ctx, family, claims)
+}
+
+// Rotate the refresh token can be used once, when a used or revoked token is presented all the tokens of its family are revoked,
+// since the token was probably stolen, and ErrorRefreshTokenReused is returned
+func (r *RefreshTokenRotator) Rotate(ctx context.Context, refreshToken string) (*TokenPair, error) {
+	var pair *TokenPair
+	reused := false
+
+	err := r.WithTx(ctx, func(ctx context.Context) error {
+		record, err := r.get(ctx, refreshToken)
+		if err != nil {
+			return err
+		}
+
+		now := systemClock.Now()
+
+		if record.usedAt.Valid || record.revokedAt.Valid {
+			reused = true
+			return r.revokeFamily(ctx, record.family, now)
+		}
+
+		if !now.Before(record.expiresAt) {
+			return ErrorInvalidRefreshToken
+		}
+
+		query := fmt.Sprintf("UPDATE %s SET used_at = $2 WHERE token_hash = $1", r.opts.Table)
+		if _, err := r.Querier(ctx).ExecContext(ctx, query, record.hash, now); err != nil {
+			return err
+		}
+
+		pair, err = r.issue(ctx, record.family, record.claims)
+		return err
+	})
+
+	if reused {
+		r.logger.Warn(LogMessage("refresh token reused, the token family was revoked"))
+		return nil, ErrorRefreshTokenReused
+	}
+
+	return pair, err
+}
+
+// Revoke revoke the refresh token family, e.g: on the logout
+func (r *RefreshTokenRotator) Revoke(ctx context.Context, refreshToken string) error {
+	return r.WithTx(ctx, func(ctx context.Context) error {
+		record, err := r.get(ctx, refreshToken)
+		if err != nil {
+			return err
+		}
+
+		return r.revokeFamily(ctx, record.family, systemClock.Now())
+	})
+}
+
+func (r *RefreshTokenRotator) issue(ctx context.Context, family string, claims *Claims) (*TokenPair, error) {
+	access, err := r.issuer.Sign(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh, err := gokitCrypto.RandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	now := systemClock.Now()
+	query := fmt.Sprintf("INSERT INTO %s (token_hash, family_id, subject, claims, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6)", r.opts.Table)
+
+	_, err = r.Querier(ctx).ExecContext(ctx, query, gokitCrypto.SHA256([]byte(refresh)), family, claims.Subject, string(encoded), now.Add(r.opts.RefreshTokenTTL), now)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenPair{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(r.issuer.AccessTokenTTL() / time.Second),
+	}, nil
+}
+
+func (r *RefreshTokenRotator) get(ctx context.Context, refreshToken string) (*refreshTokenRecord, error) {
+	query := fmt.Sprintf("SELECT token_hash, family_id, claims, expires_at, used_at, revoked_at FROM %s WHERE token_hash = $1 FOR UPDATE", r.opts.Table)
+
+	record := &refreshTokenRecord{}
+	var claims string
+
+	err := r.Querier(ctx).QueryRowContext(ctx, query, gokitCrypto.SHA256([]byte(refreshToken))).
+		Scan(&record.hash, &record.family, &claims, &record.expiresAt, &record.usedAt, &record.revokedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrorInvalidRefreshToken
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	record.claims = &Claims{}
+	if err := json.Unmarshal([]byte(claims), record.claims); err != nil {
+		return nil, err
+	}
+
+	return record, nil
+}
+
+func (r *RefreshTokenRotator) revokeFamily(ctx context.Context, family string, now time.Time) error {
+	query := fmt.Sprintf("UPDATE %s SET revoked_at = $2 WHERE family_id = $1 AND revoked_at IS NULL", r.opts.Table)
+
+	_, err := r.Querier(ctx).ExecContext(ctx, query, family, now)
+	return err
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/clock"
+	gokitCrypto "github.com/ralvescosta/gokit/crypto"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RefreshTokenRotatorTestSuite struct {
+	suite.Suite
+
+	db      *sql.DB
+	sqlMock sqlmock.Sqlmock
+	issuer  *MockTokenIssuer
+	now     time.Time
+	rotator IRefreshTokenRotator
+}
+
+func TestRefreshTokenRotatorTestSuite(t *testing.T) {
+	suite.Run(t, new(RefreshTokenRotatorTestSuite))
+}
+
+func (s *RefreshTokenRotatorTestSuite) SetupTest() {
+	s.db, s.sqlMock, _ = sqlmock.New()
+	s.now = time.Date(2022, 7, 21, 0, 0, 0, 0, time.UTC)
+	systemClock = clock.NewFake(s.now)
+
+	s.issuer = NewMockTokenIssuer()
+	s.issuer.On("Sign", mock.Anything).Return("access", nil)
+	s.issuer.On("AccessTokenTTL").Return(15 * time.Minute)
+
+	s.rotator = NewRefreshTokenRotator(logging.NewMockLogger(), s.db, s.issuer, nil)
+}
+
+func (s *RefreshTokenRotatorTestSuite) TearDownTest() {
+	systemClock = clock.New()
+	s.NoError(s.sqlMock.ExpectationsWereMet())
+}
+
+func (s *RefreshTokenRotatorTestSuite) expectGet(token string, expiresAt time.Time, usedAt any) {
+	claims, _ := json.Marshal(&Claims{Roles: []string{"admin"}})
+
+	s.sqlMock.ExpectQuery("SELECT token_hash, family_id, claims, expires_at, used_at, revoked_at FROM refresh_tokens").
+		WithArgs(gokitCrypto.SHA256([]byte(token))).
+		WillReturnRows(sqlmock.NewRows([]string{"token_hash", "family_id", "claims", "expires_at", "used_at", "revoked_at"}).
+			AddRow(gokitCrypto.SHA256([]byte(token)), "family", string(claims), expiresAt, usedAt, nil))
+}
+
+func (s *RefreshTokenRotatorTestSuite) TestIssue() {
+	s.sqlMock.ExpectExec("INSERT INTO refresh_tokens").
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "user", sqlmock.AnyArg(), s.now.Add(DefaultRefreshTokenTTL), s.now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	claims := &Claims{}
+	claims.Subject = "user"
+
+	pair, err := s.rotator.Issue(context.Background(), claims)
+	s.NoError(err)
+	s.Equal("access", pair.AccessToken)
+	s.Len(pair.RefreshToken, 43)
+	s.Equal(int64(900), pair.ExpiresIn)
+}
+
+func (s *RefreshTokenRotatorTestSuite) TestRotate() {
+	s.sqlMock.ExpectBegin()
+	s.expectGet("refresh", s.now.Add(time.Hour), nil)
+	s.sqlMock.ExpectExec("UPDATE refresh_tokens SET used_at").WithArgs(gokitCrypto.SHA256([]byte("refresh")), s.now).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.sqlMock.ExpectExec("INSERT INTO refresh_tokens").WithArgs(sqlmock.AnyArg(), "family", "", sqlmock.AnyArg(), sqlmock.AnyArg(), s.now).WillReturnResult(sqlmock.NewResult(1, 1))
+	s.sqlMock.ExpectCommit()
+
+	pair, err := s.rotator.Rotate(context.Background(), "refresh")
+	s.NoError(err)
+	s.NotEqual("refresh", pair.RefreshToken)
+
+	signed := s.issuer.Calls[0].Arguments.Get(0).(*Claims)
+	s.Equal([]string{"admin"}, signed.Roles)
+}
+
+func (s *RefreshTokenRotatorTestSuite) TestRotateReused() {
+	s.sqlMock.ExpectBegin()
+	s.expectGet("refresh", s.now.Add(time.Hour), s.now.Add(-time.Minute))
+	s.sqlMock.ExpectExec("UPDATE refresh_tokens SET revoked_at").WithArgs("family", s.now).WillReturnResult(sqlmock.NewResult(0, 2))
+	s.sqlMock.ExpectCommit()
+
+	_, err := s.rotator.Rotate(context.Background(), "refresh")
+	s.ErrorIs(err, ErrorRefreshTokenReused)
+}
+
+func (s *RefreshTokenRotatorTestSuite) TestRotateInvalid() {
+	s.sqlMock.ExpectBegin()
+	s.expectGet("expired", s.now, nil)
+	s.sqlMock.ExpectRollback()
+
+	_, err := s.rotator.Rotate(context.Background(), "expired")
+	s.ErrorIs(err, ErrorInvalidRefreshToken)
+
+	s.sqlMock.ExpectBegin()
+	s.sqlMock.ExpectQuery("SELECT token_hash").WillReturnError(sql.ErrNoRows)
+	s.sqlMock.ExpectRollback()
+
+	_, err = s.rotator.Rotate(context.Background(), "unknown")
+	s.ErrorIs(err, ErrorInvalidRefreshToken)
+
+	s.sqlMock.ExpectBegin()
+	s.sqlMock.ExpectQuery("SELECT token_hash").WillReturnError(errors.New("some error"))
+	s.sqlMock.ExpectRollback()
+
+	_, err = s.rotator.Rotate(context.Background(), "refresh")
+	s.EqualError(err, "some error")
+}
+
+func (s *RefreshTokenRotatorTestSuite) TestRevoke() {
+	s.sqlMock.ExpectBegin()
+	s.expectGet("refresh", s.now.Add(time.Hour), nil)
+	s.sqlMock.ExpectExec("UPDATE refresh_tokens SET revoked_at").WithArgs("family", s.now).WillReturnResult(sqlmock.NewResult(0, 1))
+	s.sqlMock.ExpectCommit()
+
+	s.NoError(s.rotator.Revoke(context.Background(), "refresh"))
+}
+
+func (s *RefreshTokenRotatorTestSuite) TestMigrateRefreshTokens() {
+	s.sqlMock.ExpectExec("CREATE TABLE IF NOT EXISTS refresh_tokens").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	s.NoError(MigrateRefreshTokens(context.Background(), s.db, ""))
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"database/sql"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ralvescosta/gokit/logging"
+	gokitSQL "github.com/ralvescosta/gokit/sql"
+	"golang.org/x/sync/singleflight"
+)
+
+type (
+	// Claims the registered claims with the scopes and roles used to authorize the requests
+	Claims struct {
+		jwt.RegisteredClaims
+		Scope string   `json:"scope,omitempty"`
+		Scp   []string `json:"scp,omitempty"`
+		Roles []string `json:"roles,omitempty"`
+	}
+
+	// JWK a json web key as described in RFC 7517
+	JWK struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		Alg string `json:"alg"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	}
+
+	// JWKS a json web key set
+	JWKS struct {
+		Keys []JWK `json:"keys"`
+	}
+
+	// IJWKSProvider resolve the public key used to sign a token
+	IJWKSProvider interface {
+		Key(ctx context.Context, kid string) (crypto.PublicKey, error)
+	}
+
+	// ITokenValidator validate a raw token and return its claims
+	ITokenValidator interface {
+		Validate(ctx context.Context, token string) (*Claims, error)
+	}
+
+	JWKSProvider struct {
+		logger             logging.ILogger
+		url                string
+		client             *http.Client
+		refreshInterval    time.Duration
+		minRefreshInterval time.Duration
+
+		mu          sync.RWMutex
+		keys        map[string]crypto.PublicKey
+		lastRefresh time.Time
+		// group collapse the concurrent refreshes into one fetch
+		group singleflight.Group
+	}
+
+	TokenValidator struct {
+		logger   logging.ILogger
+		provider IJWKSProvider
+		issuer   string
+		audience string
+		parser   *jwt.Parser
+	}
+
+	// SigningKey the rsa or ecdsa private key, the Kid is sent in the token header
+	SigningKey struct {
+		Kid     string
+		Private crypto.Signer
+
+		method jwt.SigningMethod
+		jwk    JWK
+	}
+
+	IssuerOpts struct {
+		Key SigningKey
+		// AccessTokenTTL default DefaultAccessTokenTTL
+		AccessTokenTTL time.Duration
+	}
+
+	ITokenIssuer interface {
+		// Sign the access token with the signing key, the iss, aud, iat, nbf, exp and jti claims are set by the issuer
+		Sign(claims *Claims) (string, error)
+		AccessTokenTTL() time.Duration
+		// Rotate the key become the signing key
+		Rotate(key SigningKey) error
+		// Retire remove the old key from the JWKS
+		Retire(kid string) error
+		JWKS() *JWKS
+		JWKSHandler() http.Handler
+	}
+
+	TokenIssuer struct {
+		logger   logging.ILogger
+		issuer   string
+		audience string
+		ttl      time.Duration
+
+		mu sync.RWMutex
+		// keys the last key signs the tokens
+		keys []SigningKey
+	}
+
+	TokenPair struct {
+		AccessToken  string `json:"access_token"`
+		RefreshToken string `json:"refresh_token"`
+		TokenType    string `json:"token_type"`
+		ExpiresIn    int64  `json:"expires_in"`
+	}
+
+	RefreshOpts struct {
+		// Table default DefaultRefreshTokensTable
+		Table string
+		// RefreshTokenTTL default DefaultRefreshTokenTTL
+		RefreshTokenTTL time.Duration
+	}
+
+	IRefreshTokenRotator interface {
+		// Issue the access token and a refresh token of a new token family, e.g: on the login
+		Issue(ctx context.Context, claims *Claims) (*TokenPair, error)
+		// Rotate exchange the refresh token by a new pair
+		Rotate(ctx context.Context, refreshToken string) (*TokenPair, error)
+		// Revoke the refresh token family
+		Revoke(ctx context.Context, refreshToken string) error
+	}
+
+	RefreshTokenRotator struct {
+		gokitSQL.Repository
+
+		logger logging.ILogger
+		issuer ITokenIssuer
+		opts   *RefreshOpts
+	}
+
+	refreshTokenRecord struct {
+		hash      string
+		family    string
+		claims    *Claims
+		expiresAt time.Time
+		usedAt    sql.NullTime
+		revokedAt sql.NullTime
+	}
+)
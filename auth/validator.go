@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// NewTokenValidator create a validator that verifies the signature, expiration, issuer and audience of the tokens
+func NewTokenValidator(cfg *env.Configs, logger logging.ILogger, provider IJWKSProvider) ITokenValidator {
+	return &TokenValidator{
+		logger:   logger,
+		provider: provider,
+		issuer:   cfg.AUTH_ISSUER,
+		audience: cfg.AUTH_AUDIENCE,
+		parser:   jwt.NewParser(jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"})),
+	}
+}
+
+func (v *TokenValidator) Validate(ctx context.Context, token string) (*Claims, error) {
+	claims := &Claims{}
+
+	_, err := v.parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		kid, _ := t.Header["kid"].(string)
+		return v.provider.Key(ctx, kid)
+	})
+	if err != nil {
+		v.logger.Warn(LogMessage("invalid token"), logging.ErrorField(err))
+
+		if errors.Is(err, ErrorKeyNotFound) || errors.Is(err, ErrorJWKSFetch) {
+			return nil, err
+		}
+
+		return nil, ErrorInvalidToken
+	}
+
+	// the parser only verifies the exp when present, the tokens without expiration are rejected
+	if !claims.VerifyExpiresAt(time.Now(), true) {
+		v.logger.Warn(LogMessage("token without expiration"))
+		return nil, ErrorInvalidToken
+	}
+
+	if !claims.VerifyIssuer(v.issuer, true) {
+		return nil, ErrorInvalidIssuer
+	}
+
+	if !claims.VerifyAudience(v.audience, true) {
+		return nil, ErrorInvalidAudience
+	}
+
+	return claims, nil
+}
+
+// Scopes merge the space delimited scope claim with the scp claim
+func (c *Claims) Scopes() []string {
+	scopes := append([]string{}, c.Scp...)
+	if c.Scope != "" {
+		scopes = append(scopes, strings.Fields(c.Scope)...)
+	}
+
+	return scopes
+}
+
+// HasScopes returns true when the token has all the scopes
+func (c *Claims) HasScopes(scopes ...string) bool {
+	return containsAll(c.Scopes(), scopes)
+}
+
+// HasRoles returns true when the token has all the roles
+func (c *Claims) HasRoles(roles ...string) bool {
+	return containsAll(c.Roles, roles)
+}
+
+func containsAll(have, want []string) bool {
+	set := make(map[string]bool, len(have))
+	for _, h := range have {
+		set[h] = true
+	}
+
+	for _, w := range want {
+		if !set[w] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func bearerToken(value string) (string, error) {
+	if len(value) <= len(BearerPrefix) || !strings.EqualFold(value[:len(BearerPrefix)], BearerPrefix) {
+		return "", ErrorMissingToken
+	}
+
+	return strings.TrimSpace(value[len(BearerPrefix):]), nil
+}
@@ -0,0 +1,191 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type TokenValidatorTestSuite struct {
+	suite.Suite
+
+	key       *rsa.PrivateKey
+	jwksCalls int
+	server    *httptest.Server
+	cfg       *env.Configs
+	validator ITokenValidator
+}
+
+func TestTokenValidatorTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenValidatorTestSuite))
+}
+
+func (s *TokenValidatorTestSuite) SetupTest() {
+	s.key, _ = rsa.GenerateKey(rand.Reader, 2048)
+	s.jwksCalls = 0
+
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.jwksCalls++
+		json.NewEncoder(w).Encode(&JWKS{Keys: []JWK{{
+			Kid: "kid",
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(s.key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(s.key.E)).Bytes()),
+		}}})
+	}))
+
+	s.cfg = &env.Configs{
+		AUTH_ISSUER:   "issuer",
+		AUTH_AUDIENCE: "audience",
+		AUTH_JWKS_URL: s.server.URL,
+	}
+
+	s.validator = NewTokenValidator(s.cfg, logging.NewMockLogger(), NewJWKSProvider(s.cfg, logging.NewMockLogger()))
+}
+
+func (s *TokenValidatorTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *TokenValidatorTestSuite) sign(kid string, claims *Claims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	raw, _ := token.SignedString(s.key)
+	return raw
+}
+
+func (s *TokenValidatorTestSuite) claims() *Claims {
+	return &Claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    "issuer",
+			Audience:  jwt.ClaimStrings{"audience"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)),
+		},
+		Scope: "read write",
+		Roles: []string{"admin"},
+	}
+}
+
+func (s *TokenValidatorTestSuite) TestValidate() {
+	claims, err := s.validator.Validate(context.Background(), s.sign("kid", s.claims()))
+
+	s.NoError(err)
+	s.True(claims.HasScopes("read", "write"))
+	s.True(claims.HasRoles("admin"))
+	s.False(claims.HasScopes("delete"))
+}
+
+func (s *TokenValidatorTestSuite) TestValidateCachedKeys() {
+	s.validator.Validate(context.Background(), s.sign("kid", s.claims()))
+	s.validator.Validate(context.Background(), s.sign("kid", s.claims()))
+
+	s.Equal(1, s.jwksCalls)
+}
+
+func (s *TokenValidatorTestSuite) TestConcurrentRefresh() {
+	release := make(chan struct{})
+	calls := int32(0)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		json.NewEncoder(w).Encode(&JWKS{})
+	}))
+	defer server.Close()
+
+	provider := NewJWKSProvider(&env.Configs{AUTH_JWKS_URL: server.URL}, logging.NewMockLogger())
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			provider.Refresh(context.Background(), false)
+		}()
+	}
+
+	s.Eventually(func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+
+	// the cached keys are readable while the jwks is fetched
+	s.True(provider.mu.TryRLock())
+	provider.mu.RUnlock()
+
+	close(release)
+	wg.Wait()
+
+	s.Equal(int32(1), atomic.LoadInt32(&calls))
+}
+
+func (s *TokenValidatorTestSuite) TestValidateErr() {
+	claims := s.claims()
+	claims.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+	_, err := s.validator.Validate(context.Background(), s.sign("kid", claims))
+	s.ErrorIs(err, ErrorInvalidToken)
+
+	claims = s.claims()
+	claims.ExpiresAt = nil
+	_, err = s.validator.Validate(context.Background(), s.sign("kid", claims))
+	s.ErrorIs(err, ErrorInvalidToken)
+
+	claims = s.claims()
+	claims.Issuer = "other"
+	_, err = s.validator.Validate(context.Background(), s.sign("kid", claims))
+	s.ErrorIs(err, ErrorInvalidIssuer)
+
+	claims = s.claims()
+	claims.Audience = jwt.ClaimStrings{"other"}
+	_, err = s.validator.Validate(context.Background(), s.sign("kid", claims))
+	s.ErrorIs(err, ErrorInvalidAudience)
+
+	_, err = s.validator.Validate(context.Background(), s.sign("unknown", s.claims()))
+	s.Error(err)
+}
+
+func (s *TokenValidatorTestSuite) TestHTTPMiddleware() {
+	handler := HTTPMiddleware(s.validator)(RequireScopes("read")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		claims, ok := ClaimsFromContext(r.Context())
+		s.True(ok)
+		s.Equal("issuer", claims.Issuer)
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(AuthorizationHeader, BearerPrefix+s.sign("kid", s.claims()))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	s.Equal(http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal(http.StatusUnauthorized, rec.Code)
+}
+
+func (s *TokenValidatorTestSuite) TestRequireRolesForbidden() {
+	handler := HTTPMiddleware(s.validator)(RequireRoles("root")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(AuthorizationHeader, BearerPrefix+s.sign("kid", s.claims()))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusForbidden, rec.Code)
+}
+
+func (s *TokenValidatorTestSuite) TestClientCredentialsRequiredConfigs() {
+	_, err := NewClientCredentialsTokenSource(context.Background(), &env.Configs{})
+
+	s.ErrorIs(err, ErrorClientCredentials)
+}
@@ -0,0 +1,38 @@
+package circuitbreaker
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// CLOSED_STATE calls are allowed and the outcomes recorded
+	CLOSED_STATE State = 0
+	// OPEN_STATE calls are rejected until the open timeout
+	OPEN_STATE State = 1
+	// HALF_OPEN_STATE a limited number of calls are allowed to check if the dependency recovered
+	HALF_OPEN_STATE State = 2
+
+	DefaultWindowSize            = 100
+	DefaultMinimumCalls          = 10
+	DefaultFailureRateThreshold  = 0.5
+	DefaultSlowCallDuration      = 5 * time.Second
+	DefaultSlowCallRateThreshold = 1.0
+	DefaultOpenTimeout           = 30 * time.Second
+	DefaultHalfOpenMaxCalls      = 1
+)
+
+var (
+	ErrorOpenState       = errors.New("circuit breaker is open")
+	ErrorTooManyRequests = errors.New("circuit breaker is half-open and the probe calls are in progress")
+
+	stateNames = map[State]string{
+		CLOSED_STATE:    "closed",
+		OPEN_STATE:      "open",
+		HALF_OPEN_STATE: "half-open",
+	}
+)
+
+func (s State) String() string {
+	return stateNames[s]
+}
@@ -0,0 +1,222 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// New create a circuit breaker
+func New(cfg *Config) ICircuitBreaker {
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	if cfg.WindowSize <= 0 {
+		cfg.WindowSize = DefaultWindowSize
+	}
+
+	if cfg.MinimumCalls <= 0 {
+		cfg.MinimumCalls = DefaultMinimumCalls
+	}
+
+	if cfg.MinimumCalls > cfg.WindowSize {
+		cfg.MinimumCalls = cfg.WindowSize
+	}
+
+	if cfg.FailureRateThreshold <= 0 {
+		cfg.FailureRateThreshold = DefaultFailureRateThreshold
+	}
+
+	if cfg.SlowCallDuration <= 0 {
+		cfg.SlowCallDuration = DefaultSlowCallDuration
+	}
+
+	if cfg.SlowCallRateThreshold <= 0 {
+		cfg.SlowCallRateThreshold = DefaultSlowCallRateThreshold
+	}
+
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultOpenTimeout
+	}
+
+	if cfg.HalfOpenMaxCalls <= 0 {
+		cfg.HalfOpenMaxCalls = DefaultHalfOpenMaxCalls
+	}
+
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = func(err error) bool {
+			return err != nil && !errors.Is(err, context.Canceled)
+		}
+	}
+
+	return &CircuitBreaker{
+		cfg:     cfg,
+		window:  make([]outcome, cfg.WindowSize),
+		timeNow: time.Now,
+	}
+}
+
+func (cb *CircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	done, err := cb.Allow()
+	if err != nil {
+		return err
+	}
+
+	err = fn(ctx)
+	done(err)
+
+	return err
+}
+
+// Execute generic helper to calls that return a value
+func Execute[T any](ctx context.Context, cb ICircuitBreaker, fn func(ctx context.Context) (T, error)) (T, error) {
+	var result T
+
+	err := cb.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = fn(ctx)
+		return err
+	})
+
+	return result, err
+}
+
+func (cb *CircuitBreaker) Allow() (DoneFunc, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if cb.state == OPEN_STATE && cb.timeNow().Sub(cb.openedAt) >= cb.cfg.OpenTimeout {
+		cb.transition(HALF_OPEN_STATE)
+	}
+
+	switch cb.state {
+	case OPEN_STATE:
+		cb.rejected++
+		return nil, ErrorOpenState
+	case HALF_OPEN_STATE:
+		if cb.probes >= cb.cfg.HalfOpenMaxCalls {
+			cb.rejected++
+			return nil, ErrorTooManyRequests
+		}
+		cb.probes++
+	}
+
+	start := cb.timeNow()
+	generation := cb.generation
+	return func(err error) {
+		cb.record(generation, outcome{
+			failure: cb.cfg.IsFailure(err),
+			slow:    cb.timeNow().Sub(start) >= cb.cfg.SlowCallDuration,
+		})
+	}, nil
+}
+
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	return cb.state
+}
+
+func (cb *CircuitBreaker) Metrics() Metrics {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	failures, slow := cb.totals()
+	metrics := Metrics{
+		State:     cb.state,
+		Calls:     cb.count,
+		Failures:  failures,
+		SlowCalls: slow,
+		Rejected:  cb.rejected,
+	}
+
+	if cb.count > 0 {
+		metrics.FailureRate = float64(failures) / float64(cb.count)
+		metrics.SlowCallRate = float64(slow) / float64(cb.count)
+	}
+
+	return metrics
+}
+
+// record the outcome, the calls allowed before the last state change are ignored, e.g: a slow call started in the
+// closed state must not be counted as a half-open probe
+func (cb *CircuitBreaker) record(generation uint64, o outcome) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if generation != cb.generation {
+		return
+	}
+
+	if cb.state == HALF_OPEN_STATE {
+		if o.failure || o.slow {
+			cb.transition(OPEN_STATE)
+			return
+		}
+
+		cb.probes--
+		cb.count++
+		if cb.count >= cb.cfg.HalfOpenMaxCalls {
+			cb.transition(CLOSED_STATE)
+		}
+		return
+	}
+
+	if cb.state != CLOSED_STATE {
+		return
+	}
+
+	cb.window[cb.next] = o
+	cb.next = (cb.next + 1) % len(cb.window)
+	if cb.count < len(cb.window) {
+		cb.count++
+	}
+
+	if cb.count < cb.cfg.MinimumCalls {
+		return
+	}
+
+	failures, slow := cb.totals()
+	if float64(failures)/float64(cb.count) >= cb.cfg.FailureRateThreshold ||
+		float64(slow)/float64(cb.count) >= cb.cfg.SlowCallRateThreshold {
+		cb.transition(OPEN_STATE)
+	}
+}
+
+func (cb *CircuitBreaker) totals() (int, int) {
+	if cb.state == HALF_OPEN_STATE {
+		return 0, 0
+	}
+
+	failures, slow := 0, 0
+	for i := 0; i < cb.count; i++ {
+		if cb.window[i].failure {
+			failures++
+		}
+		if cb.window[i].slow {
+			slow++
+		}
+	}
+
+	return failures, slow
+}
+
+// transition change the state and reset the window, must be called holding the lock
+func (cb *CircuitBreaker) transition(to State) {
+	from := cb.state
+	cb.state = to
+	cb.count = 0
+	cb.next = 0
+	cb.probes = 0
+	cb.generation++
+
+	if to == OPEN_STATE {
+		cb.openedAt = cb.timeNow()
+	}
+
+	if cb.cfg.OnStateChange != nil && from != to {
+		go cb.cfg.OnStateChange(cb.cfg.Name, from, to)
+	}
+}
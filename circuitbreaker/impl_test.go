@@ -0,0 +1,146 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CircuitBreakerTestSuite struct {
+	suite.Suite
+
+	now time.Time
+}
+
+func TestCircuitBreakerTestSuite(t *testing.T) {
+	suite.Run(t, new(CircuitBreakerTestSuite))
+}
+
+func (s *CircuitBreakerTestSuite) new(cfg *Config) ICircuitBreaker {
+	s.now = time.Now()
+	cb := New(cfg)
+	cb.(*CircuitBreaker).timeNow = func() time.Time { return s.now }
+
+	return cb
+}
+
+func (s *CircuitBreakerTestSuite) fail(ctx context.Context) error {
+	return errors.New("some error")
+}
+
+func (s *CircuitBreakerTestSuite) succeed(ctx context.Context) error {
+	return nil
+}
+
+func (s *CircuitBreakerTestSuite) TestFailureRate() {
+	changes := make(chan State, 3)
+	cb := s.new(&Config{
+		WindowSize:           4,
+		MinimumCalls:         4,
+		FailureRateThreshold: 0.5,
+		OpenTimeout:          time.Second,
+		OnStateChange:        func(name string, from, to State) { changes <- to },
+	})
+
+	cb.Execute(context.Background(), s.succeed)
+	cb.Execute(context.Background(), s.succeed)
+	cb.Execute(context.Background(), s.fail)
+	s.Equal(CLOSED_STATE, cb.State())
+
+	cb.Execute(context.Background(), s.fail)
+	s.Equal(OPEN_STATE, cb.State())
+	s.Equal(OPEN_STATE, <-changes)
+
+	s.ErrorIs(cb.Execute(context.Background(), s.succeed), ErrorOpenState)
+	s.Equal(uint64(1), cb.Metrics().Rejected)
+
+	s.now = s.now.Add(time.Second)
+	done, err := cb.Allow()
+	s.NoError(err)
+	s.Equal(HALF_OPEN_STATE, cb.State())
+	s.Equal(HALF_OPEN_STATE, <-changes)
+
+	_, err = cb.Allow()
+	s.ErrorIs(err, ErrorTooManyRequests)
+
+	done(nil)
+	s.Equal(CLOSED_STATE, cb.State())
+	s.Equal(CLOSED_STATE, <-changes)
+}
+
+func (s *CircuitBreakerTestSuite) TestHalfOpenFailure() {
+	cb := s.new(&Config{WindowSize: 1, MinimumCalls: 1, OpenTimeout: time.Second})
+
+	cb.Execute(context.Background(), s.fail)
+	s.Equal(OPEN_STATE, cb.State())
+
+	s.now = s.now.Add(time.Second)
+	cb.Execute(context.Background(), s.fail)
+	s.Equal(OPEN_STATE, cb.State())
+}
+
+func (s *CircuitBreakerTestSuite) TestStaleOutcomes() {
+	cb := s.new(&Config{WindowSize: 1, MinimumCalls: 1, OpenTimeout: time.Second, HalfOpenMaxCalls: 1})
+
+	stale, err := cb.Allow()
+	s.NoError(err)
+
+	cb.Execute(context.Background(), s.fail)
+	s.Equal(OPEN_STATE, cb.State())
+
+	s.now = s.now.Add(time.Second)
+	probe, err := cb.Allow()
+	s.NoError(err)
+	s.Equal(HALF_OPEN_STATE, cb.State())
+
+	// the call started in the closed state is not a probe
+	stale(nil)
+	s.Equal(HALF_OPEN_STATE, cb.State())
+	_, err = cb.Allow()
+	s.ErrorIs(err, ErrorTooManyRequests)
+
+	probe(nil)
+	s.Equal(CLOSED_STATE, cb.State())
+}
+
+func (s *CircuitBreakerTestSuite) TestWrappedCanceled() {
+	cb := s.new(&Config{WindowSize: 1, MinimumCalls: 1})
+
+	cb.Execute(context.Background(), func(ctx context.Context) error {
+		return fmt.Errorf("request: %w", context.Canceled)
+	})
+	s.Equal(CLOSED_STATE, cb.State())
+}
+
+func (s *CircuitBreakerTestSuite) TestSlowCalls() {
+	cb := s.new(&Config{WindowSize: 2, MinimumCalls: 2, SlowCallDuration: time.Second, SlowCallRateThreshold: 1})
+
+	slow := func(ctx context.Context) error {
+		s.now = s.now.Add(2 * time.Second)
+		return nil
+	}
+
+	cb.Execute(context.Background(), slow)
+	cb.Execute(context.Background(), slow)
+	s.Equal(OPEN_STATE, cb.State())
+}
+
+func (s *CircuitBreakerTestSuite) TestMetricsAndGeneric() {
+	cb := s.new(nil)
+
+	result, err := Execute(context.Background(), cb, func(ctx context.Context) (int, error) { return 1, nil })
+	s.NoError(err)
+	s.Equal(1, result)
+
+	Execute(context.Background(), cb, func(ctx context.Context) (int, error) { return 0, errors.New("some error") })
+
+	metrics := cb.Metrics()
+	s.Equal(2, metrics.Calls)
+	s.Equal(1, metrics.Failures)
+	s.Equal(0.5, metrics.FailureRate)
+	s.Equal("closed", metrics.State.String())
+}
@@ -0,0 +1,40 @@
+package circuitbreaker
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockCircuitBreaker struct {
+	mock.Mock
+}
+
+func (m *MockCircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx, fn)
+
+	return args.Error(0)
+}
+
+func (m *MockCircuitBreaker) Allow() (DoneFunc, error) {
+	args := m.Called()
+
+	done, _ := args.Get(0).(DoneFunc)
+	return done, args.Error(1)
+}
+
+func (m *MockCircuitBreaker) State() State {
+	args := m.Called()
+
+	return args.Get(0).(State)
+}
+
+func (m *MockCircuitBreaker) Metrics() Metrics {
+	args := m.Called()
+
+	return args.Get(0).(Metrics)
+}
+
+func NewMockCircuitBreaker() *MockCircuitBreaker {
+	return new(MockCircuitBreaker)
+}
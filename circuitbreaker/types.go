@@ -0,0 +1,78 @@
+package circuitbreaker
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type (
+	State int8
+
+	// Config circuit breaker configuration, zero values are replaced by the defaults
+	Config struct {
+		Name string
+		// WindowSize how many of the last calls are used to calculate the rates
+		WindowSize int
+		// MinimumCalls the rates are only evaluated after this number of calls
+		MinimumCalls int
+		// FailureRateThreshold opens the breaker when the failure rate is greater or equal, between 0 and 1
+		FailureRateThreshold float64
+		// SlowCallDuration calls longer than this are considered slow
+		SlowCallDuration time.Duration
+		// SlowCallRateThreshold opens the breaker when the slow call rate is greater or equal, between 0 and 1
+		SlowCallRateThreshold float64
+		// OpenTimeout how long the breaker stays open before the half-open state
+		OpenTimeout time.Duration
+		// HalfOpenMaxCalls the probe calls allowed in the half-open state
+		HalfOpenMaxCalls int
+		// IsFailure classify the errors, by default all the errors except context.Canceled are failures
+		IsFailure func(err error) bool
+		// OnStateChange called when the state changes
+		OnStateChange func(name string, from, to State)
+	}
+
+	// Metrics snapshot of the breaker
+	Metrics struct {
+		State        State
+		Calls        int
+		Failures     int
+		SlowCalls    int
+		Rejected     uint64
+		FailureRate  float64
+		SlowCallRate float64
+	}
+
+	// DoneFunc must be called with the call result when the call allowed by Allow finishes
+	DoneFunc = func(err error)
+
+	ICircuitBreaker interface {
+		// Execute the fn if the breaker allows, returns ErrorOpenState or ErrorTooManyRequests otherwise
+		Execute(ctx context.Context, fn func(ctx context.Context) error) error
+		// Allow check if a call is allowed, useful when the call can not be wrapped in a function
+		Allow() (DoneFunc, error)
+		State() State
+		Metrics() Metrics
+	}
+
+	outcome struct {
+		failure bool
+		slow    bool
+	}
+
+	CircuitBreaker struct {
+		cfg *Config
+
+		mu       sync.Mutex
+		state    State
+		window   []outcome
+		next     int
+		count    int
+		openedAt time.Time
+		probes   int
+		rejected uint64
+		timeNow  func() time.Time
+		// generation incremented on each transition, so the outcomes of the previous states are ignored
+		generation uint64
+	}
+)
@@ -0,0 +1,192 @@
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// NewFake create the fake clock starting at now
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.now
+}
+
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// Sleep blocks until the time is advanced by d
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	w := &fakeWaiter{clock: f, c: make(chan time.Time, 1)}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.schedule(d)
+
+	return fakeTimer{w}
+}
+
+func (f *Fake) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("non-positive interval for clock.Fake.NewTicker")
+	}
+
+	w := &fakeWaiter{clock: f, c: make(chan time.Time, 1), period: d}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w.schedule(d)
+
+	return fakeTicker{w}
+}
+
+// Advance move the time forward firing the timers and tickers in the deadline order
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set move the time to now, the clock never goes back
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if now.Before(f.now) {
+		return
+	}
+
+	for {
+		sort.SliceStable(f.waiters, func(i, j int) bool {
+			return f.waiters[i].deadline.Before(f.waiters[j].deadline)
+		})
+
+		if len(f.waiters) == 0 || f.waiters[0].deadline.After(now) {
+			break
+		}
+
+		w := f.waiters[0]
+		f.now = w.deadline
+		w.fire()
+	}
+
+	f.now = now
+}
+
+// BlockUntil wait until n timers or tickers are active, so the Advance happens after the code under test started waiting
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for len(f.waiters) < n {
+		f.cond.Wait()
+	}
+}
+
+// Waiters the number of active timers and tickers
+func (f *Fake) Waiters() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.waiters)
+}
+
+// remove returns false when the waiter was not active
+func (f *Fake) remove(w *fakeWaiter) bool {
+	for i, active := range f.waiters {
+		if active == w {
+			f.waiters = append(f.waiters[:i], f.waiters[i+1:]...)
+			return true
+		}
+	}
+
+	return false
+}
+
+// schedule the timers with a non-positive duration fire immediately, must be called holding the clock lock
+func (w *fakeWaiter) schedule(d time.Duration) {
+	w.deadline = w.clock.now.Add(d)
+	if d <= 0 {
+		w.fire()
+		return
+	}
+
+	w.clock.waiters = append(w.clock.waiters, w)
+	w.clock.cond.Broadcast()
+}
+
+// fire like the time package the value is dropped when the previous one was not received, the timers are removed and the tickers scheduled to the next period
+func (w *fakeWaiter) fire() {
+	select {
+	case w.c <- w.deadline:
+	default:
+	}
+
+	if w.period > 0 {
+		w.deadline = w.deadline.Add(w.period)
+		return
+	}
+
+	w.clock.remove(w)
+}
+
+func (w *fakeWaiter) C() <-chan time.Time {
+	return w.c
+}
+
+func (w *fakeWaiter) stop() bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+
+	return w.clock.remove(w)
+}
+
+func (w *fakeWaiter) reset(d time.Duration) bool {
+	w.clock.mu.Lock()
+	defer w.clock.mu.Unlock()
+
+	active := w.clock.remove(w)
+	if w.period > 0 {
+		w.period = d
+	}
+
+	w.schedule(d)
+
+	return active
+}
+
+func (t fakeTimer) Stop() bool {
+	return t.stop()
+}
+
+func (t fakeTimer) Reset(d time.Duration) bool {
+	return t.reset(d)
+}
+
+func (t fakeTicker) Stop() {
+	t.stop()
+}
+
+func (t fakeTicker) Reset(d time.Duration) {
+	if d <= 0 {
+		panic("non-positive interval for clock.Ticker.Reset")
+	}
+
+	t.reset(d)
+}
@@ -0,0 +1,104 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ClockTestSuite struct {
+	suite.Suite
+
+	start time.Time
+	clock *Fake
+}
+
+func TestClockTestSuite(t *testing.T) {
+	suite.Run(t, new(ClockTestSuite))
+}
+
+func (s *ClockTestSuite) SetupTest() {
+	s.start = time.Date(2022, 7, 21, 0, 0, 0, 0, time.UTC)
+	s.clock = NewFake(s.start)
+}
+
+func (s *ClockTestSuite) TestSystemClock() {
+	c := New()
+
+	s.WithinDuration(time.Now(), c.Now(), time.Second)
+	s.Less(c.Since(time.Now()), time.Second)
+
+	timer := c.NewTimer(time.Millisecond)
+	<-timer.C()
+	s.False(timer.Stop())
+
+	ticker := c.NewTicker(time.Millisecond)
+	<-ticker.C()
+	ticker.Stop()
+
+	<-c.After(time.Millisecond)
+	c.Sleep(time.Millisecond)
+}
+
+func (s *ClockTestSuite) TestAdvance() {
+	after := s.clock.After(time.Minute)
+
+	s.clock.Advance(30 * time.Second)
+	s.Equal(s.start.Add(30*time.Second), s.clock.Now())
+	s.Len(after, 0)
+
+	s.clock.Advance(30 * time.Second)
+	s.Equal(s.start.Add(time.Minute), <-after)
+	s.Equal(time.Minute, s.clock.Since(s.start))
+	s.Equal(0, s.clock.Waiters())
+
+	s.clock.Set(s.start)
+	s.Equal(s.start.Add(time.Minute), s.clock.Now())
+}
+
+func (s *ClockTestSuite) TestTimer() {
+	timer := s.clock.NewTimer(time.Second)
+	s.True(timer.Stop())
+	s.False(timer.Stop())
+
+	s.False(timer.Reset(2 * time.Second))
+	s.clock.Advance(2 * time.Second)
+	s.Equal(s.start.Add(2*time.Second), <-timer.C())
+
+	s.False(timer.Reset(0))
+	s.Len(timer.C(), 1)
+}
+
+func (s *ClockTestSuite) TestTicker() {
+	ticker := s.clock.NewTicker(time.Second)
+
+	s.clock.Advance(time.Second)
+	s.Equal(s.start.Add(time.Second), <-ticker.C())
+
+	// the ticks are dropped when they are not received
+	s.clock.Advance(3 * time.Second)
+	s.Equal(s.start.Add(2*time.Second), <-ticker.C())
+	s.Len(ticker.C(), 0)
+
+	ticker.Reset(10 * time.Second)
+	s.clock.Advance(9 * time.Second)
+	s.Len(ticker.C(), 0)
+
+	ticker.Stop()
+	s.clock.Advance(time.Hour)
+	s.Len(ticker.C(), 0)
+	s.Panics(func() { s.clock.NewTicker(0) })
+}
+
+func (s *ClockTestSuite) TestSleep() {
+	done := make(chan struct{})
+	go func() {
+		s.clock.Sleep(time.Hour)
+		close(done)
+	}()
+
+	s.clock.BlockUntil(1)
+	s.clock.Advance(time.Hour)
+	<-done
+}
@@ -0,0 +1,72 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// Clock the time source of the toolkit internals, New returns the system clock and NewFake a controllable one to the tests
+	Clock interface {
+		Now() time.Time
+		Since(t time.Time) time.Duration
+		After(d time.Duration) <-chan time.Time
+		Sleep(d time.Duration)
+		NewTimer(d time.Duration) Timer
+		NewTicker(d time.Duration) Ticker
+	}
+
+	// Timer see time.Timer
+	Timer interface {
+		C() <-chan time.Time
+		Stop() bool
+		Reset(d time.Duration) bool
+	}
+
+	// Ticker see time.Ticker
+	Ticker interface {
+		C() <-chan time.Time
+		Stop()
+		Reset(d time.Duration)
+	}
+
+	systemClock struct{}
+
+	systemTimer struct {
+		*time.Timer
+	}
+
+	systemTicker struct {
+		*time.Ticker
+	}
+
+	// Fake the time only moves with Advance or Set, the timers and tickers fire when the time reaches their deadline
+	//
+	//	clk := clock.NewFake(time.Now())
+	//	go scheduler.Start()
+	//
+	//	clk.BlockUntil(1)
+	//	clk.Advance(time.Minute)
+	Fake struct {
+		mu      sync.Mutex
+		cond    *sync.Cond
+		now     time.Time
+		waiters []*fakeWaiter
+	}
+
+	// fakeWaiter a timer, or a ticker when period is not zero
+	fakeWaiter struct {
+		clock    *Fake
+		c        chan time.Time
+		deadline time.Time
+		period   time.Duration
+	}
+
+	fakeTimer struct {
+		*fakeWaiter
+	}
+
+	fakeTicker struct {
+		*fakeWaiter
+	}
+)
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+)
+
+const (
+	Usage = `gokit is the toolkit command line
+
+Usage:
+	gokit new service <name> [-module <module path>] [-dir <dir>]
+		scaffold a service wired to the toolkit: env, logger, sql, messaging, http server, health and graceful shutdown
+
+	gokit topology apply [-file <topology.json>]
+		declare the messaging topology described in the file, the broker is configured through the env
+
+	gokit env keygen
+		generate a base64 key to the ENV_ENCRYPTION_KEY env
+
+	gokit env encrypt -file <.env.production>
+		write the .env.production.enc encrypted with the ENV_ENCRYPTION_KEY, it is decrypted in memory by env.New
+
+	gokit env decrypt -file <.env.production.enc>
+		print the plain content of the encrypted env file
+
+	gokit env docs [-format markdown|json] [-out <ENV.md>]
+		print the env vars read by the env builders with their types, defaults and the required ones
+
+	gokit registry generate [-dir <dir>]
+		write the registry.gen.go registering the protobuf messages of the *.pb.go files in the messaging registry,
+		use it with //go:generate gokit registry generate
+
+	gokit sql generate -file <model.go> -type <Model> [-table <table>]
+		write the <model>_repository.gen.go with the GetByID, List, Insert, Update and Delete of the db tagged struct,
+		use it with //go:generate gokit sql generate -file $GOFILE -type <Model>
+`
+
+	DefaultTopologyFile = "topology.json"
+
+	// DefaultGokitVersion the toolkit modules version required by the scaffolded services when the CLI build info has no
+	// toolkit version, e.g: go run inside the workspace, it is a commit where every module requires its siblings
+	DefaultGokitVersion = "v0.0.0-20261016185646-98c72fca1ff5"
+
+	gokitModulePrefix = "github.com/ralvescosta/gokit/"
+)
+
+var (
+	ErrorUsage       = errors.New("invalid command, run gokit help")
+	ErrorServiceName = errors.New("the service name must start with a letter and contain only lower case letters, numbers and -")
+	ErrorDirNotEmpty = errors.New("the service dir already exists and it is not empty")
+
+	serviceNamePattern   = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+	pseudoVersionPattern = regexp.MustCompile(`^v0\.0\.0-\d{14}-[0-9a-f]{12}$`)
+)
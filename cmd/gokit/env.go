@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ralvescosta/gokit/env"
+)
+
+// encryptEnv write the <file>.enc next to the plain env file, the plain file should not be committed
+func encryptEnv(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("env encrypt", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	path := flags.String("file", "", "the plain env file, e.g: .env.production")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *path == "" {
+		return ErrorUsage
+	}
+
+	key, err := env.EncryptionKeyProvider()
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := os.ReadFile(*path)
+	if err != nil {
+		return err
+	}
+
+	encrypted, err := env.EncryptEnv(plaintext, key)
+	if err != nil {
+		return err
+	}
+
+	target := *path + env.ENCRYPTED_ENV_FILE_EXT
+	if err := os.WriteFile(target, encrypted, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%s encrypted to %s\n", *path, target)
+	return nil
+}
+
+// decryptEnv print the plain content of the encrypted env file
+func decryptEnv(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("env decrypt", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	path := flags.String("file", "", "the encrypted env file, e.g: .env.production.enc")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if !strings.HasSuffix(*path, env.ENCRYPTED_ENV_FILE_EXT) {
+		return ErrorUsage
+	}
+
+	key, err := env.EncryptionKeyProvider()
+	if err != nil {
+		return err
+	}
+
+	content, err := os.ReadFile(*path)
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := env.DecryptEnv(content, key)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(plaintext)
+	return err
+}
+
+func envKeygen(stdout io.Writer) error {
+	key, err := env.NewEncryptionKey()
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(stdout, key)
+	return nil
+}
+
+// envDocs write the env catalog, the output could be committed and checked in the CI, e.g: gokit env docs -out ENV.md
+func envDocs(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("env docs", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	format := flags.String("format", "markdown", "markdown or json")
+	out := flags.String("out", "", "the output file, default the stdout")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var content []byte
+	switch *format {
+	case "markdown":
+		content = env.CatalogMarkdown()
+	case "json":
+		byt, err := env.CatalogJSON()
+		if err != nil {
+			return err
+		}
+		content = append(byt, '\n')
+	default:
+		return ErrorUsage
+	}
+
+	if *out == "" {
+		_, err := stdout.Write(content)
+		return err
+	}
+
+	if err := os.WriteFile(*out, content, 0o644); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "env docs written to %s\n", *out)
+	return nil
+}
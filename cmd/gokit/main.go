@@ -0,0 +1,46 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		fmt.Fprint(stdout, Usage)
+		return ErrorUsage
+	}
+
+	switch {
+	case args[0] == "help" || args[0] == "-h" || args[0] == "--help":
+		fmt.Fprint(stdout, Usage)
+		return nil
+	case len(args) >= 2 && args[0] == "new" && args[1] == "service":
+		return newService(args[2:], stdout)
+	case len(args) >= 2 && args[0] == "topology" && args[1] == "apply":
+		return applyTopology(args[2:], stdout)
+	case len(args) >= 2 && args[0] == "env" && args[1] == "encrypt":
+		return encryptEnv(args[2:], stdout)
+	case len(args) >= 2 && args[0] == "env" && args[1] == "decrypt":
+		return decryptEnv(args[2:], stdout)
+	case len(args) >= 2 && args[0] == "env" && args[1] == "keygen":
+		return envKeygen(stdout)
+	case len(args) >= 2 && args[0] == "env" && args[1] == "docs":
+		return envDocs(args[2:], stdout)
+	case len(args) >= 2 && args[0] == "registry" && args[1] == "generate":
+		return generateRegistry(args[2:], stdout)
+	case len(args) >= 2 && args[0] == "sql" && args[1] == "generate":
+		return generateRepository(args[2:], stdout)
+	default:
+		fmt.Fprint(stdout, Usage)
+		return ErrorUsage
+	}
+}
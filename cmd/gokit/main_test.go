@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/ralvescosta/gokit/messaging/registry"
+	gokitSQL "github.com/ralvescosta/gokit/sql"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CLITestSuite struct {
+	suite.Suite
+
+	dir    string
+	stdout *bytes.Buffer
+}
+
+func TestCLITestSuite(t *testing.T) {
+	suite.Run(t, new(CLITestSuite))
+}
+
+func (s *CLITestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+	s.stdout = &bytes.Buffer{}
+}
+
+func (s *CLITestSuite) TestUsage() {
+	s.ErrorIs(run(nil, s.stdout), ErrorUsage)
+	s.ErrorIs(run([]string{"new"}, s.stdout), ErrorUsage)
+	s.NoError(run([]string{"help"}, s.stdout))
+	s.Contains(s.stdout.String(), "gokit new service")
+}
+
+func (s *CLITestSuite) TestNewService() {
+	dir := filepath.Join(s.dir, "orders")
+
+	err := run([]string{"new", "service", "orders", "-module", "github.com/acme/orders", "-dir", dir}, s.stdout)
+	s.NoError(err)
+
+	for _, file := range []string{"go.mod", "main.go", "topology.json", ".env.development", "README.md", "internal/routes/routes.go", "internal/consumers/consumers.go"} {
+		s.FileExists(filepath.Join(dir, file))
+	}
+
+	main, _ := os.ReadFile(filepath.Join(dir, "main.go"))
+	s.Contains(string(main), `"github.com/acme/orders/internal/routes"`)
+
+	goMod, _ := os.ReadFile(filepath.Join(dir, "go.mod"))
+	s.Contains(string(goMod), "module github.com/acme/orders")
+	s.Contains(string(goMod), "github.com/ralvescosta/gokit/app "+gokitVersion())
+
+	_, err = rabbitmq.LoadTopologyFile(filepath.Join(dir, "topology.json"))
+	s.NoError(err)
+
+	s.ErrorIs(run([]string{"new", "service", "orders", "-dir", dir}, s.stdout), ErrorDirNotEmpty)
+}
+
+func (s *CLITestSuite) TestNewServiceBuild() {
+	goBin, err := exec.LookPath("go")
+	if testing.Short() || err != nil {
+		s.T().Skip("the scaffolded service build needs the go toolchain")
+	}
+
+	dir := filepath.Join(s.dir, "orders")
+	s.Require().NoError(run([]string{"new", "service", "orders", "-module", "github.com/acme/orders", "-dir", dir}, s.stdout))
+
+	// the toolkit modules are replaced by this tree, so the templates are built against the current toolkit
+	goMod, err := os.OpenFile(filepath.Join(dir, "go.mod"), os.O_APPEND|os.O_WRONLY, 0)
+	s.Require().NoError(err)
+
+	modules, _ := filepath.Glob(filepath.Join("..", "..", "*", "go.mod"))
+	for _, path := range modules {
+		content, _ := os.ReadFile(path)
+		module := strings.TrimSpace(strings.TrimPrefix(strings.SplitN(string(content), "\n", 2)[0], "module"))
+		root, _ := filepath.Abs(filepath.Dir(path))
+		fmt.Fprintf(goMod, "\nreplace %s => %s\n", module, root)
+	}
+	s.Require().NoError(goMod.Close())
+
+	cmd := exec.Command(goBin, "build", "./...")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GOWORK=off", "GOFLAGS=-mod=mod")
+
+	output, err := cmd.CombinedOutput()
+	s.NoError(err, string(output))
+}
+
+func (s *CLITestSuite) TestNewServiceName() {
+	s.ErrorIs(run([]string{"new", "service"}, s.stdout), ErrorServiceName)
+	s.ErrorIs(run([]string{"new", "service", "Orders"}, s.stdout), ErrorServiceName)
+}
+
+func (s *CLITestSuite) TestTopologyApply() {
+	path := filepath.Join(s.dir, "topology.json")
+	s.NoError(os.WriteFile(path, []byte(`{"topologies": [{"exchange": "orders", "queue": "orders.created"}]}`), 0o644))
+
+	messaging := rabbitmq.NewMockRabbitMQMessaging()
+	messaging.On("Declare", mock.Anything).Return(messaging)
+	messaging.On("ApplyBinds", nil).Return(messaging)
+	messaging.On("Build", nil).Return(messaging, nil)
+	messaging.On("Shutdown", mock.Anything).Return(nil)
+
+	newConfigs = func() (*env.Configs, error) { return &env.Configs{}, nil }
+	newMessaging = func(cfg *env.Configs, logger logging.ILogger) rabbitmq.IRabbitMQMessaging { return messaging }
+
+	s.NoError(run([]string{"topology", "apply", "-file", path}, s.stdout))
+	s.Contains(s.stdout.String(), "1 topologies and 0 retry topologies applied")
+	messaging.AssertExpectations(s.T())
+
+	s.Error(run([]string{"topology", "apply", "-file", filepath.Join(s.dir, "missing.json")}, s.stdout))
+}
+
+func (s *CLITestSuite) TestEnvEncryption() {
+	s.NoError(run([]string{"env", "keygen"}, s.stdout))
+	os.Setenv(env.ENV_ENCRYPTION_KEY_ENV_KEY, strings.TrimSpace(s.stdout.String()))
+	defer os.Unsetenv(env.ENV_ENCRYPTION_KEY_ENV_KEY)
+
+	path := filepath.Join(s.dir, ".env.production")
+	s.NoError(os.WriteFile(path, []byte("SQL_DB_PASSWORD=secret\n"), 0o600))
+
+	s.NoError(run([]string{"env", "encrypt", "-file", path}, s.stdout))
+
+	encrypted, _ := os.ReadFile(path + env.ENCRYPTED_ENV_FILE_EXT)
+	s.NotContains(string(encrypted), "secret")
+
+	s.stdout.Reset()
+	s.NoError(run([]string{"env", "decrypt", "-file", path + env.ENCRYPTED_ENV_FILE_EXT}, s.stdout))
+	s.Equal("SQL_DB_PASSWORD=secret\n", s.stdout.String())
+
+	s.ErrorIs(run([]string{"env", "decrypt", "-file", path}, s.stdout), ErrorUsage)
+}
+
+func (s *CLITestSuite) TestEnvDocs() {
+	s.NoError(run([]string{"env", "docs"}, s.stdout))
+	s.Equal(string(env.CatalogMarkdown()), s.stdout.String())
+
+	path := filepath.Join(s.dir, "env.json")
+	s.NoError(run([]string{"env", "docs", "-format", "json", "-out", path}, s.stdout))
+
+	content, _ := os.ReadFile(path)
+	s.Contains(string(content), `"name": "GO_ENV"`)
+
+	s.ErrorIs(run([]string{"env", "docs", "-format", "yaml"}, s.stdout), ErrorUsage)
+}
+
+func (s *CLITestSuite) TestRegistryGenerate() {
+	pb := "package ordersv1\n\ntype OrderCreated struct{}\n\nfunc (x *OrderCreated) ProtoReflect() protoreflect.Message { return nil }\n"
+	s.NoError(os.WriteFile(filepath.Join(s.dir, "orders.pb.go"), []byte(pb), 0o644))
+
+	s.NoError(run([]string{"registry", "generate", "-dir", s.dir}, s.stdout))
+
+	src, _ := os.ReadFile(filepath.Join(s.dir, registry.GeneratedFileName))
+	s.Contains(string(src), "&OrderCreated{}")
+}
+
+func (s *CLITestSuite) TestSQLGenerate() {
+	model := "package users\n\ntype User struct {\n\tID   int64  `db:\"id,auto\"`\n\tName string `db:\"name\"`\n}\n"
+	s.NoError(os.WriteFile(filepath.Join(s.dir, "user.go"), []byte(model), 0o644))
+
+	s.NoError(run([]string{"sql", "generate", "-file", filepath.Join(s.dir, "user.go"), "-type", "User"}, s.stdout))
+
+	src, _ := os.ReadFile(filepath.Join(s.dir, "user_repository.gen.go"))
+	s.Contains(string(src), "type UserRepository struct")
+
+	s.ErrorIs(run([]string{"sql", "generate", "-file", filepath.Join(s.dir, "user.go")}, s.stdout), ErrorUsage)
+	s.ErrorIs(run([]string{"sql", "generate", "-file", filepath.Join(s.dir, "user.go"), "-type", "Unknown"}, s.stdout), gokitSQL.ErrorModelNotFound)
+}
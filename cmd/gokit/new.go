@@ -0,0 +1,116 @@
+package main
+
+import (
+	"embed"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"text/template"
+)
+
+//go:embed all:templates
+var templates embed.FS
+
+type serviceTemplate struct {
+	Name         string
+	Module       string
+	GokitVersion string
+}
+
+// newService render the templates dir into the service dir, the .tmpl extension is removed from the files
+func newService(args []string, stdout io.Writer) error {
+	name := ""
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	}
+
+	flags := flag.NewFlagSet("new service", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	module := flags.String("module", "", "the go module path, the default is the service name")
+	dir := flags.String("dir", "", "the service dir, the default is ./<name>")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if name == "" {
+		name = flags.Arg(0)
+	}
+
+	if !serviceNamePattern.MatchString(name) {
+		return ErrorServiceName
+	}
+
+	data := &serviceTemplate{Name: name, Module: *module, GokitVersion: gokitVersion()}
+	if data.Module == "" {
+		data.Module = name
+	}
+
+	if *dir == "" {
+		*dir = name
+	}
+
+	if entries, err := os.ReadDir(*dir); err == nil && len(entries) > 0 {
+		return ErrorDirNotEmpty
+	}
+
+	err := fs.WalkDir(templates, "templates", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+
+		target := filepath.Join(*dir, strings.TrimSuffix(strings.TrimPrefix(path, "templates/"), ".tmpl"))
+		return render(path, target, data)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "service %s created in %s\n", name, *dir)
+	return nil
+}
+
+// gokitVersion the pseudo-version of the installed CLI, e.g: go install github.com/ralvescosta/gokit/cmd/gokit@<commit>,
+// points to a commit with every toolkit module, otherwise the toolkit version the CLI was built with is used
+func gokitVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return DefaultGokitVersion
+	}
+
+	if pseudoVersionPattern.MatchString(info.Main.Version) {
+		return info.Main.Version
+	}
+
+	for _, dep := range info.Deps {
+		if strings.HasPrefix(dep.Path, gokitModulePrefix) && dep.Replace == nil && pseudoVersionPattern.MatchString(dep.Version) {
+			return dep.Version
+		}
+	}
+
+	return DefaultGokitVersion
+}
+
+func render(path, target string, data *serviceTemplate) error {
+	tmpl, err := template.ParseFS(templates, path)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return tmpl.Execute(file, data)
+}
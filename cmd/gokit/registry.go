@@ -0,0 +1,28 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ralvescosta/gokit/messaging/registry"
+)
+
+// generateRegistry write the registry.gen.go registering the protobuf messages of the generated package
+func generateRegistry(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("registry generate", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	dir := flags.String("dir", ".", "the dir with the *.pb.go files")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	path, err := registry.WriteRegistration(*dir)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%s generated\n", path)
+	return nil
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+
+	gokitSQL "github.com/ralvescosta/gokit/sql"
+)
+
+// generateRepository write the typed repository of the db tagged struct next to its file
+func generateRepository(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("sql generate", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	file := flags.String("file", "", "the go file with the model struct, $GOFILE in the go:generate")
+	typ := flags.String("type", "", "the model struct name")
+	table := flags.String("table", "", "the table name, the default is the snake case plural of the type")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if *file == "" || *typ == "" {
+		return ErrorUsage
+	}
+
+	path, err := gokitSQL.WriteRepository(*file, *typ, *table)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%s generated\n", path)
+	return nil
+}
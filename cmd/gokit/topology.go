@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+var newConfigs = func() (*env.Configs, error) {
+	return env.New().Messaging().Build()
+}
+
+var newMessaging = rabbitmq.New
+
+// applyTopology declare the topology file using the broker configured in the env
+func applyTopology(args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("topology apply", flag.ContinueOnError)
+	flags.SetOutput(stdout)
+	path := flags.String("file", DefaultTopologyFile, "the declarative topology file")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	file, err := rabbitmq.LoadTopologyFile(*path)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := newConfigs()
+	if err != nil {
+		return err
+	}
+
+	logger, err := logging.NewDefaultLogger(cfg)
+	if err != nil {
+		return err
+	}
+
+	messaging, err := file.Apply(newMessaging(cfg, logger))
+	if err != nil {
+		return err
+	}
+
+	if err := messaging.Shutdown(context.Background()); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "%d topologies and %d retry topologies applied\n", len(file.Topologies), len(file.RetryTopologies))
+	return nil
+}
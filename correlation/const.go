@@ -0,0 +1,15 @@
+package correlation
+
+const (
+	HTTPHeader = "X-Correlation-Id"
+	AMQPHeader = "x-correlation-id"
+	// GRPCMetadataKey the grpc metadata keys are lower case
+	GRPCMetadataKey = "x-correlation-id"
+
+	LogFieldKey      = "correlationId"
+	SpanAttributeKey = "correlation.id"
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::correlation] " + msg
+}
@@ -0,0 +1,45 @@
+package correlation
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/guid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type correlationCtxKey struct{}
+
+// NewID generate a new correlation id, the ids are ULIDs
+func NewID() string {
+	return guid.NewRequestID()
+}
+
+// ContextWithID store the correlation id in the context and set it in the span carried by the context
+func ContextWithID(ctx context.Context, id string) context.Context {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String(SpanAttributeKey, id))
+	return context.WithValue(ctx, correlationCtxKey{}, id)
+}
+
+// FromContext get the correlation id from the context
+func FromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+
+	id, ok := ctx.Value(correlationCtxKey{}).(string)
+	return id, ok && id != ""
+}
+
+// Ensure return the context with the received correlation id, a new id is generated when the received id is empty
+func Ensure(ctx context.Context, id string) (context.Context, string) {
+	if id == "" {
+		if current, ok := FromContext(ctx); ok {
+			return ctx, current
+		}
+
+		id = NewID()
+	}
+
+	return ContextWithID(ctx, id), id
+}
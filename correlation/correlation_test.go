@@ -0,0 +1,135 @@
+package correlation
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type CorrelationTestSuite struct {
+	suite.Suite
+}
+
+func TestCorrelationTestSuite(t *testing.T) {
+	suite.Run(t, new(CorrelationTestSuite))
+}
+
+func (s *CorrelationTestSuite) TestEnsure() {
+	ctx, id := Ensure(context.Background(), "")
+	s.NotEmpty(id)
+
+	current, ok := FromContext(ctx)
+	s.True(ok)
+	s.Equal(id, current)
+
+	_, same := Ensure(ctx, "")
+	s.Equal(id, same)
+
+	_, received := Ensure(ctx, "received")
+	s.Equal("received", received)
+}
+
+func (s *CorrelationTestSuite) TestHTTPMiddleware() {
+	var id string
+	handler := HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, _ = FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(HTTPHeader, "abc")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.Equal("abc", id)
+	s.Equal("abc", rec.Header().Get(HTTPHeader))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.NotEmpty(id)
+	s.Equal(id, rec.Header().Get(HTTPHeader))
+}
+
+func (s *CorrelationTestSuite) TestTransport() {
+	var received string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(HTTPHeader)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequestWithContext(ContextWithID(context.Background(), "abc"), http.MethodGet, srv.URL, nil)
+	res, err := (&http.Client{Transport: Transport(nil)}).Do(req)
+
+	s.NoError(err)
+	res.Body.Close()
+	s.Equal("abc", received)
+	s.Empty(req.Header.Get(HTTPHeader))
+}
+
+func (s *CorrelationTestSuite) TestGRPC() {
+	var id string
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		id, _ = FromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(GRPCMetadataKey, "abc"))
+	_, err := UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+	s.NoError(err)
+	s.Equal("abc", id)
+
+	var outgoing metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		outgoing, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	s.NoError(UnaryClientInterceptor()(ContextWithID(context.Background(), "abc"), "/svc/method", nil, nil, nil, invoker))
+	s.Equal([]string{"abc"}, outgoing.Get(GRPCMetadataKey))
+}
+
+func (s *CorrelationTestSuite) TestMessaging() {
+	opts := PublishOpts(ContextWithID(context.Background(), "abc"), struct{}{}, nil)
+	s.Equal("abc", opts.Headers[AMQPHeader])
+	s.NotEmpty(opts.MessageId)
+
+	var id string
+	handler := ConsumerHandler(func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		id, _ = FromContext(metadata.Ctx)
+		return nil
+	})
+
+	s.NoError(handler(nil, &rabbitmq.DeliveryMetadata{Headers: opts.Headers}))
+	s.Equal("abc", id)
+}
+
+func (s *CorrelationTestSuite) TestLogger() {
+	core, logs := observer.New(zap.DebugLevel)
+
+	Logger(ContextWithID(context.Background(), "abc"), zap.New(core)).Info("message")
+
+	s.Equal(1, logs.Len())
+	s.Equal("abc", logs.All()[0].ContextMap()[LogFieldKey])
+}
+
+func (s *CorrelationTestSuite) TestSpanProcessor() {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(NewSpanProcessor()), sdkTrace.WithSpanProcessor(recorder))
+
+	_, span := provider.Tracer("test").Start(ContextWithID(context.Background(), "abc"), "span")
+	span.End()
+
+	s.Contains(recorder.Ended()[0].Attributes(), attribute.String(SpanAttributeKey, "abc"))
+}
@@ -0,0 +1,77 @@
+package correlation
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// UnaryServerInterceptor propagate the x-correlation-id metadata or generate a new id, the id is stored in the context and returned in the response headers
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, id := Ensure(ctx, fromIncomingMetadata(ctx))
+		_ = grpc.SetHeader(ctx, metadata.Pairs(GRPCMetadataKey, id))
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor the same as UnaryServerInterceptor to the streams
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, id := Ensure(ss.Context(), fromIncomingMetadata(ss.Context()))
+		_ = ss.SetHeader(metadata.Pairs(GRPCMetadataKey, id))
+
+		return handler(srv, &serverStream{ss, ctx})
+	}
+}
+
+// UnaryClientInterceptor set the x-correlation-id metadata from the context in the outgoing calls
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor set the x-correlation-id metadata from the context in the outgoing streams
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+func fromIncomingMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(GRPCMetadataKey); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(GRPCMetadataKey)) > 0 {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, GRPCMetadataKey, id)
+}
@@ -0,0 +1,37 @@
+package correlation
+
+import (
+	"net/http"
+)
+
+// HTTPMiddleware propagate the X-Correlation-Id header or generate a new id, the id is stored in the request context and returned in the response headers
+func HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, id := Ensure(r.Context(), r.Header.Get(HTTPHeader))
+
+		w.Header().Set(HTTPHeader, id)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Transport decorate the http client transport setting the X-Correlation-Id header from the request context, base is optional
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{base}
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	id, ok := FromContext(r.Context())
+	if !ok || r.Header.Get(HTTPHeader) != "" {
+		return t.base.RoundTrip(r)
+	}
+
+	// RoundTrip must not modify the received request
+	r = r.Clone(r.Context())
+	r.Header.Set(HTTPHeader, id)
+
+	return t.base.RoundTrip(r)
+}
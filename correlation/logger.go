@@ -0,0 +1,48 @@
+package correlation
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/logging"
+	"go.uber.org/zap"
+)
+
+// Field the correlationId log field, the field is skipped when the context is not correlated
+func Field(ctx context.Context) zap.Field {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return zap.Skip()
+	}
+
+	return logging.MessageField(LogFieldKey, id)
+}
+
+// Logger decorate the logger appending the correlation id of ctx in all the logs
+func Logger(ctx context.Context, l logging.ILogger) logging.ILogger {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return l
+	}
+
+	return &logger{l, logging.MessageField(LogFieldKey, id)}
+}
+
+func (l *logger) Debug(msg string, fields ...zap.Field) {
+	l.logger.Debug(msg, append(fields, l.field)...)
+}
+
+func (l *logger) Info(msg string, fields ...zap.Field) {
+	l.logger.Info(msg, append(fields, l.field)...)
+}
+
+func (l *logger) Warn(msg string, fields ...zap.Field) {
+	l.logger.Warn(msg, append(fields, l.field)...)
+}
+
+func (l *logger) Error(msg string, fields ...zap.Field) {
+	l.logger.Error(msg, append(fields, l.field)...)
+}
+
+func (l *logger) Fatal(msg string, fields ...zap.Field) {
+	l.logger.Fatal(msg, append(fields, l.field)...)
+}
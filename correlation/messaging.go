@@ -0,0 +1,63 @@
+package correlation
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+// PublishOpts set the x-correlation-id header and the Ctx of the publish opts, opts is optional
+//
+//	err := messaging.Publisher(exchange, routingKey, msg, correlation.PublishOpts(metadata.Ctx, msg, nil))
+func PublishOpts(ctx context.Context, msg any, opts *rabbitmq.PublishOpts) *rabbitmq.PublishOpts {
+	if opts == nil {
+		opts = rabbitmq.NewPublishOpts(msg)
+	}
+
+	if opts.Ctx == nil {
+		opts.Ctx = ctx
+	}
+
+	id, ok := FromContext(ctx)
+	if !ok {
+		return opts
+	}
+
+	headers := make(map[string]interface{}, len(opts.Headers)+1)
+	for k, v := range opts.Headers {
+		headers[k] = v
+	}
+	headers[AMQPHeader] = id
+	opts.Headers = headers
+
+	return opts
+}
+
+// FromMetadata get the correlation id from the x-correlation-id header of the received message
+func FromMetadata(metadata *rabbitmq.DeliveryMetadata) (string, bool) {
+	if metadata == nil {
+		return "", false
+	}
+
+	id, ok := metadata.Headers[AMQPHeader].(string)
+	return id, ok && id != ""
+}
+
+// ConsumerHandler decorate a messaging handler storing the x-correlation-id header in the metadata Ctx, a new id is generated when the message is not correlated
+func ConsumerHandler(handler rabbitmq.ConsumerHandler) rabbitmq.ConsumerHandler {
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		if metadata == nil {
+			return handler(msg, metadata)
+		}
+
+		ctx := metadata.Ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		id, _ := FromMetadata(metadata)
+		metadata.Ctx, _ = Ensure(ctx, id)
+
+		return handler(msg, metadata)
+	}
+}
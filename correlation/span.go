@@ -0,0 +1,25 @@
+package correlation
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewSpanProcessor set the correlation.id attribute in every span started with a correlated context, register it with trace.WithSpanProcessors
+func NewSpanProcessor() sdkTrace.SpanProcessor {
+	return &spanProcessor{}
+}
+
+func (p *spanProcessor) OnStart(parent context.Context, s sdkTrace.ReadWriteSpan) {
+	if id, ok := FromContext(parent); ok {
+		s.SetAttributes(attribute.String(SpanAttributeKey, id))
+	}
+}
+
+func (p *spanProcessor) OnEnd(sdkTrace.ReadOnlySpan) {}
+
+func (p *spanProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *spanProcessor) ForceFlush(context.Context) error { return nil }
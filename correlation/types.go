@@ -0,0 +1,24 @@
+package correlation
+
+import (
+	"net/http"
+
+	"github.com/ralvescosta/gokit/logging"
+	"go.uber.org/zap"
+)
+
+type (
+	// logger append the correlation id field to all the logs
+	logger struct {
+		logger logging.ILogger
+		field  zap.Field
+	}
+
+	// transport set the X-Correlation-Id header in the outgoing requests
+	transport struct {
+		base http.RoundTripper
+	}
+
+	// spanProcessor set the correlation.id attribute in the spans started with a correlated context
+	spanProcessor struct{}
+)
@@ -0,0 +1,32 @@
+package crash
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	HTTPKind      Kind = "http"
+	GRPCKind      Kind = "grpc"
+	MessagingKind Kind = "messaging"
+	GoroutineKind Kind = "goroutine"
+
+	DefaultSinkTimeout = 5 * time.Second
+
+	sentryClient  = "gokit-crash/1.0"
+	sentryVersion = "7"
+)
+
+var (
+	// ErrorPanic returned to the messaging consumer and to the gRPC client when the handler panicked, the message is nacked
+	ErrorPanic = errors.New("handler panicked")
+
+	ErrorInvalidSentryDSN = errors.New("invalid sentry dsn")
+	ErrorSinkStatus       = errors.New("crash report rejected by the sink")
+
+	timeNow = time.Now
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::crash] " + msg
+}
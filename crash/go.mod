@@ -0,0 +1,20 @@
+module github.com/ralvescosta/gokit/crash
+
+go 1.18
+
+require (
+	github.com/ralvescosta/gokit/correlation v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/guid v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/messaging v0.0.0-20261016183433-cb393702ac94
+	github.com/stretchr/testify v1.8.0
+	go.uber.org/zap v1.21.0
+	google.golang.org/grpc v1.65.0
+)
+
+require (
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/errors v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/pool v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/requestid v0.0.0-20261016183433-cb393702ac94 // indirect
+)
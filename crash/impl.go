@@ -0,0 +1,80 @@
+package crash
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"github.com/ralvescosta/gokit/correlation"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// New create the crash handler, without sinks the reports are only logged
+func New(logger logging.ILogger, sinks ...Sink) ICrashHandler {
+	if len(sinks) == 0 {
+		sinks = []Sink{NewLogSink(logger)}
+	}
+
+	return &crashHandler{logger, sinks}
+}
+
+func (h *crashHandler) Recover(ctx context.Context, kind Kind, fields map[string]string) {
+	if rvr := recover(); rvr != nil {
+		h.report(ctx, kind, rvr, fields)
+	}
+}
+
+func (h *crashHandler) Go(ctx context.Context, name string, fn func(ctx context.Context)) {
+	go func() {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				h.report(ctx, GoroutineKind, rvr, map[string]string{"goroutine": name})
+			}
+		}()
+
+		fn(ctx)
+	}()
+}
+
+// report send the report to all the sinks, the sinks failures are only logged
+//
+// The sinks receive a new context, the request context is usually canceled when the handler panicked
+func (h *crashHandler) report(ctx context.Context, kind Kind, rvr any, fields map[string]string) *Report {
+	if fields == nil {
+		fields = map[string]string{}
+	}
+
+	if id, ok := correlation.FromContext(ctx); ok {
+		fields["correlationId"] = id
+	}
+
+	report := &Report{
+		Kind:    kind,
+		Panic:   stringify(rvr),
+		Stack:   string(debug.Stack()),
+		Time:    timeNow(),
+		Context: fields,
+	}
+
+	sinkCtx, cancel := context.WithTimeout(context.Background(), DefaultSinkTimeout)
+	defer cancel()
+
+	for _, sink := range h.sinks {
+		if err := sink.Report(sinkCtx, report); err != nil {
+			h.logger.Error(LogMessage("failure to report the panic"), logging.ErrorField(err), logging.MessageField("panic", report.Panic))
+		}
+	}
+
+	return report
+}
+
+func stringify(v any) string {
+	switch t := v.(type) {
+	case error:
+		return t.Error()
+	case string:
+		return t
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
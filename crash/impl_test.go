@@ -0,0 +1,131 @@
+package crash
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/correlation"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type CrashTestSuite struct {
+	suite.Suite
+
+	sink    *MockSink
+	handler ICrashHandler
+	report  *Report
+}
+
+func TestCrashTestSuite(t *testing.T) {
+	suite.Run(t, new(CrashTestSuite))
+}
+
+func (s *CrashTestSuite) SetupTest() {
+	s.report = nil
+	s.sink = NewMockSink()
+	s.sink.On("Report", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		s.report = args.Get(1).(*Report)
+	}).Return(nil)
+
+	s.handler = New(logging.NewMockLogger(), s.sink)
+}
+
+func (s *CrashTestSuite) TestHTTPMiddleware() {
+	handler := s.handler.HTTPMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req = req.WithContext(correlation.ContextWithID(req.Context(), "abc"))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.Equal(HTTPKind, s.report.Kind)
+	s.Equal("boom", s.report.Panic)
+	s.Equal("/orders", s.report.Context["path"])
+	s.Equal("abc", s.report.Context["correlationId"])
+	s.Contains(s.report.Stack, "goroutine")
+}
+
+func (s *CrashTestSuite) TestUnaryServerInterceptor() {
+	_, err := s.handler.UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/svc/method"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+
+	s.Equal(codes.Internal, status.Code(err))
+	s.Equal("/svc/method", s.report.Context["method"])
+}
+
+func (s *CrashTestSuite) TestConsumerHandler() {
+	handler := s.handler.ConsumerHandler("orders", func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		panic("boom")
+	})
+
+	err := handler(nil, &rabbitmq.DeliveryMetadata{MessageId: "id"})
+
+	s.ErrorIs(err, ErrorPanic)
+	s.Equal(MessagingKind, s.report.Kind)
+	s.Equal("id", s.report.Context["messageId"])
+	s.Equal("orders", s.report.Context["queue"])
+}
+
+func (s *CrashTestSuite) TestGo() {
+	done := make(chan struct{})
+	handler := New(logging.NewMockLogger(), SinkFunc(func(ctx context.Context, report *Report) error {
+		s.Equal(GoroutineKind, report.Kind)
+		s.Equal("job", report.Context["goroutine"])
+		close(done)
+		return nil
+	}))
+
+	handler.Go(context.Background(), "job", func(ctx context.Context) { panic("boom") })
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		s.Fail("panic not reported")
+	}
+}
+
+func (s *CrashTestSuite) TestWebhookSink() {
+	received := &Report{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(received)
+	}))
+	defer srv.Close()
+
+	err := NewWebhookSink(srv.URL, nil).Report(context.Background(), &Report{Kind: HTTPKind, Panic: "boom"})
+
+	s.NoError(err)
+	s.Equal("boom", received.Panic)
+}
+
+func (s *CrashTestSuite) TestSentrySink() {
+	var auth, path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("X-Sentry-Auth")
+		path = r.URL.Path
+	}))
+	defer srv.Close()
+
+	_, err := NewSentrySink("invalid", nil)
+	s.ErrorIs(err, ErrorInvalidSentryDSN)
+
+	sink, err := NewSentrySink("http://key@"+srv.Listener.Addr().String()+"/42", nil)
+	s.NoError(err)
+
+	s.NoError(sink.Report(context.Background(), &Report{Kind: HTTPKind, Panic: "boom", Time: time.Now()}))
+	s.Equal("/api/42/store/", path)
+	s.Contains(auth, "sentry_key=key")
+}
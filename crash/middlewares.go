@@ -0,0 +1,84 @@
+package crash
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *crashHandler) HTTPMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				if rvr == http.ErrAbortHandler {
+					panic(rvr)
+				}
+
+				h.report(r.Context(), HTTPKind, rvr, map[string]string{
+					"method":    r.Method,
+					"path":      r.URL.Path,
+					"requestId": r.Header.Get("X-Request-Id"),
+				})
+
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (h *crashHandler) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				h.report(ctx, GRPCKind, rvr, map[string]string{"method": info.FullMethod})
+				err = status.Error(codes.Internal, ErrorPanic.Error())
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func (h *crashHandler) StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				h.report(ss.Context(), GRPCKind, rvr, map[string]string{"method": info.FullMethod})
+				err = status.Error(codes.Internal, ErrorPanic.Error())
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+func (h *crashHandler) ConsumerHandler(queue string, handler rabbitmq.ConsumerHandler) rabbitmq.ConsumerHandler {
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) (err error) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				ctx := context.Background()
+				fields := map[string]string{"queue": queue}
+
+				if metadata != nil {
+					fields["messageId"] = metadata.MessageId
+					fields["type"] = metadata.Type
+
+					if metadata.Ctx != nil {
+						ctx = metadata.Ctx
+					}
+				}
+
+				h.report(ctx, MessagingKind, rvr, fields)
+				err = ErrorPanic
+			}
+		}()
+
+		return handler(msg, metadata)
+	}
+}
@@ -0,0 +1,21 @@
+package crash
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockSink struct {
+	mock.Mock
+}
+
+func (m *MockSink) Report(ctx context.Context, report *Report) error {
+	args := m.Called(ctx, report)
+
+	return args.Error(0)
+}
+
+func NewMockSink() *MockSink {
+	return new(MockSink)
+}
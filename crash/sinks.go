@@ -0,0 +1,126 @@
+package crash
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ralvescosta/gokit/guid"
+	"github.com/ralvescosta/gokit/logging"
+	"go.uber.org/zap"
+)
+
+// NewLogSink log the reports with the error level
+func NewLogSink(logger logging.ILogger) Sink {
+	return &logSink{logger}
+}
+
+func (s *logSink) Report(_ context.Context, report *Report) error {
+	fields := []zap.Field{
+		logging.MessageField("kind", string(report.Kind)),
+		logging.MessageField("panic", report.Panic),
+		logging.MessageField("stack", report.Stack),
+	}
+
+	for k, v := range report.Context {
+		fields = append(fields, logging.MessageField(k, v))
+	}
+
+	s.logger.Error(LogMessage("recovered from panic"), fields...)
+
+	return nil
+}
+
+// NewWebhookSink post the report as json to the url, client is optional
+func NewWebhookSink(url string, client *http.Client) Sink {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultSinkTimeout}
+	}
+
+	return &webhookSink{url, client}
+}
+
+func (s *webhookSink) Report(ctx context.Context, report *Report) error {
+	byt, err := json.Marshal(report)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(byt))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return send(s.client, req)
+}
+
+// NewSentrySink send the reports to the sentry store endpoint, the dsn format is https://<key>@<host>/<project>, client is optional
+func NewSentrySink(dsn string, client *http.Client) (Sink, error) {
+	u, err := url.Parse(dsn)
+	if err != nil || u.User == nil || u.User.Username() == "" || u.Host == "" {
+		return nil, ErrorInvalidSentryDSN
+	}
+
+	project := strings.Trim(u.Path, "/")
+	if project == "" {
+		return nil, ErrorInvalidSentryDSN
+	}
+
+	if client == nil {
+		client = &http.Client{Timeout: DefaultSinkTimeout}
+	}
+
+	endpoint := fmt.Sprintf("%s://%s/api/%s/store/", u.Scheme, u.Host, project)
+
+	return &sentrySink{endpoint, u.User.Username(), client}, nil
+}
+
+func (s *sentrySink) Report(ctx context.Context, report *Report) error {
+	event := map[string]any{
+		"event_id":  strings.ReplaceAll(guid.NewUUIDv7().String(), "-", ""),
+		"timestamp": report.Time.UTC().Format("2006-01-02T15:04:05"),
+		"level":     "fatal",
+		"platform":  "go",
+		"logger":    "gokit.crash",
+		"message":   report.Panic,
+		"tags":      map[string]string{"kind": string(report.Kind)},
+		"extra":     map[string]any{"context": report.Context, "stack": report.Stack},
+		"exception": map[string]any{
+			"values": []map[string]string{{"type": "panic", "value": report.Panic}},
+		},
+	}
+
+	byt, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(byt))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Sentry-Auth", fmt.Sprintf("Sentry sentry_version=%s, sentry_client=%s, sentry_key=%s", sentryVersion, sentryClient, s.key))
+
+	return send(s.client, req)
+}
+
+func send(client *http.Client, req *http.Request) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%w: %d", ErrorSinkStatus, res.StatusCode)
+	}
+
+	return nil
+}
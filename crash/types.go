@@ -0,0 +1,75 @@
+package crash
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"google.golang.org/grpc"
+)
+
+type (
+	Kind string
+
+	// Report the recovered panic with the request or message context
+	Report struct {
+		Kind  Kind      `json:"kind"`
+		Panic string    `json:"panic"`
+		Stack string    `json:"stack"`
+		Time  time.Time `json:"time"`
+		// Context request or message context, e.g: method, path, requestId, correlationId, queue, messageId
+		Context map[string]string `json:"context,omitempty"`
+	}
+
+	// Sink the crash reports destination, e.g: LogSink, WebhookSink, SentrySink
+	Sink interface {
+		Report(ctx context.Context, report *Report) error
+	}
+
+	// SinkFunc adapter to use functions as Sink
+	SinkFunc func(ctx context.Context, report *Report) error
+
+	// ICrashHandler recover from the panics, report them to the sinks and respond with 500, codes.Internal or nack
+	ICrashHandler interface {
+		// HTTPMiddleware recover the handler panics and respond with 500
+		HTTPMiddleware(next http.Handler) http.Handler
+		// UnaryServerInterceptor recover the handler panics and respond with codes.Internal
+		UnaryServerInterceptor() grpc.UnaryServerInterceptor
+		// StreamServerInterceptor recover the handler panics and respond with codes.Internal
+		StreamServerInterceptor() grpc.StreamServerInterceptor
+		// ConsumerHandler recover the handler panics and return ErrorPanic, so the message is nacked
+		ConsumerHandler(queue string, handler rabbitmq.ConsumerHandler) rabbitmq.ConsumerHandler
+		// Go run fn in a goroutine reporting the panics instead of crashing the process
+		Go(ctx context.Context, name string, fn func(ctx context.Context))
+		// Recover report the panic, must be called with defer
+		//
+		//	defer crashHandler.Recover(ctx, crash.GoroutineKind, map[string]string{"job": "sync"})
+		Recover(ctx context.Context, kind Kind, fields map[string]string)
+	}
+
+	crashHandler struct {
+		logger logging.ILogger
+		sinks  []Sink
+	}
+
+	logSink struct {
+		logger logging.ILogger
+	}
+
+	webhookSink struct {
+		url    string
+		client *http.Client
+	}
+
+	sentrySink struct {
+		endpoint string
+		key      string
+		client   *http.Client
+	}
+)
+
+func (f SinkFunc) Report(ctx context.Context, report *Report) error {
+	return f(ctx, report)
+}
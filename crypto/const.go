@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+)
+
+const (
+	ARGON2ID_ALGORITHM PasswordAlgorithm = "argon2id"
+	BCRYPT_ALGORITHM   PasswordAlgorithm = "bcrypt"
+)
+
+const (
+	// DefaultBcryptCost see bcrypt.DefaultCost
+	DefaultBcryptCost = 12
+
+	// KeyLength the AES-256 key length
+	KeyLength = 32
+
+	// WebhookSignatureHeader the header of the SignWebhook signature
+	WebhookSignatureHeader = "X-Signature"
+	// DefaultWebhookTolerance the max age of the webhook signature
+	DefaultWebhookTolerance = 5 * time.Minute
+
+	// ciphertextVersion the first byte of the KeyRing ciphertext, it allows changing the format without breaking the stored values
+	ciphertextVersion byte = 1
+	webhookScheme          = "v1"
+)
+
+var (
+	ErrorPasswordMismatch  = errors.New("crypto password mismatch")
+	ErrorInvalidHash       = errors.New("crypto invalid password hash")
+	ErrorUnknownAlgorithm  = errors.New("crypto unknown password algorithm")
+	ErrorInvalidSignature  = errors.New("crypto invalid signature")
+	ErrorExpiredSignature  = errors.New("crypto the signature timestamp is out of the tolerance")
+	ErrorInvalidKey        = errors.New("crypto the key must have 32 bytes")
+	ErrorUnknownKey        = errors.New("crypto the key version is not in the key ring")
+	ErrorNoPrimaryKey      = errors.New("crypto the key ring has no primary key")
+	ErrorInvalidCiphertext = errors.New("crypto invalid ciphertext")
+)
+
+// DefaultArgon2Params the RFC 9106 second recommended option, 64 MiB of memory
+var DefaultArgon2Params = Argon2Params{
+	Memory:      64 * 1024,
+	Iterations:  3,
+	Parallelism: 4,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+// systemClock is replaced in the tests
+var systemClock = clock.New()
@@ -0,0 +1,17 @@
+module github.com/ralvescosta/gokit/crypto
+
+go 1.18
+
+require (
+	github.com/ralvescosta/gokit/clock v0.0.0-20261016183433-cb393702ac94
+	github.com/stretchr/testify v1.8.0
+	golang.org/x/crypto v0.0.0-20220525230936-793ad666bf5e
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/objx v0.4.0 // indirect
+	golang.org/x/sys v0.0.0-20220712014510-0a85c31ab51e // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
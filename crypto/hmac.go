@@ -0,0 +1,119 @@
+package crypto
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SHA256 returns the hex encoded sha256 of the data
+func SHA256(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// HMAC the HMAC-SHA256 of the message
+func HMAC(key, message []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write(message)
+
+	return mac.Sum(nil)
+}
+
+// VerifyHMAC compare the HMAC-SHA256 of the message in constant time
+func VerifyHMAC(key, message, signature []byte) bool {
+	return hmac.Equal(HMAC(key, message), signature)
+}
+
+// Equal constant time comparison, use it to compare secrets, tokens and signatures
+func Equal(a, b []byte) bool {
+	return subtle.ConstantTimeCompare(a, b) == 1
+}
+
+// EqualString see Equal
+func EqualString(a, b string) bool {
+	return Equal([]byte(a), []byte(b))
+}
+
+// RandomBytes n bytes of the crypto/rand reader
+func RandomBytes(n int) ([]byte, error) {
+	b := make([]byte, n)
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// RandomToken the base64 url encoded RandomBytes, e.g: the API keys and the CSRF tokens
+func RandomToken(n int) (string, error) {
+	b, err := RandomBytes(n)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// SignWebhook returns the WebhookSignatureHeader value: t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<payload>">
+func SignWebhook(secret, payload []byte) string {
+	timestamp := strconv.FormatInt(systemClock.Now().Unix(), 10)
+	return fmt.Sprintf("t=%s,%s=%s", timestamp, webhookScheme, hex.EncodeToString(HMAC(secret, webhookMessage(timestamp, payload))))
+}
+
+// VerifyWebhook verify the SignWebhook header, the tolerance avoid the replay of old requests, default DefaultWebhookTolerance.
+// More than one secret can be given while the secret is rotated
+func VerifyWebhook(header string, payload []byte, tolerance time.Duration, secrets ...[]byte) error {
+	if tolerance <= 0 {
+		tolerance = DefaultWebhookTolerance
+	}
+
+	timestamp := ""
+	signatures := [][]byte{}
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+
+		switch key {
+		case "t":
+			timestamp = value
+		case webhookScheme:
+			if signature, err := hex.DecodeString(value); err == nil {
+				signatures = append(signatures, signature)
+			}
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || len(signatures) == 0 {
+		return ErrorInvalidSignature
+	}
+
+	age := systemClock.Since(time.Unix(unix, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrorExpiredSignature
+	}
+
+	message := webhookMessage(timestamp, payload)
+	for _, secret := range secrets {
+		for _, signature := range signatures {
+			if VerifyHMAC(secret, message, signature) {
+				return nil
+			}
+		}
+	}
+
+	return ErrorInvalidSignature
+}
+
+func webhookMessage(timestamp string, payload []byte) []byte {
+	return append([]byte(timestamp+"."), payload...)
+}
@@ -0,0 +1,62 @@
+package crypto
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+	"github.com/stretchr/testify/suite"
+)
+
+type HMACTestSuite struct {
+	suite.Suite
+
+	clock *clock.Fake
+}
+
+func TestHMACTestSuite(t *testing.T) {
+	suite.Run(t, new(HMACTestSuite))
+}
+
+func (s *HMACTestSuite) SetupTest() {
+	s.clock = clock.NewFake(time.Unix(1700000000, 0))
+	systemClock = s.clock
+}
+
+func (s *HMACTestSuite) TearDownTest() {
+	systemClock = clock.New()
+}
+
+func (s *HMACTestSuite) TestHMAC() {
+	mac := HMAC([]byte("key"), []byte("message"))
+
+	s.True(VerifyHMAC([]byte("key"), []byte("message"), mac))
+	s.False(VerifyHMAC([]byte("other"), []byte("message"), mac))
+	s.True(EqualString("token", "token"))
+	s.False(Equal([]byte("token"), []byte("tokens")))
+	s.Equal("2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824", SHA256([]byte("hello")))
+
+	token, err := RandomToken(32)
+	s.NoError(err)
+	s.Len(token, 43)
+}
+
+func (s *HMACTestSuite) TestWebhook() {
+	payload := []byte(`{"event":"paid"}`)
+	header := SignWebhook([]byte("secret"), payload)
+	s.Contains(header, "t=1700000000,v1=")
+
+	s.NoError(VerifyWebhook(header, payload, 0, []byte("secret")))
+	s.NoError(VerifyWebhook(header, payload, 0, []byte("new"), []byte("secret")))
+	s.ErrorIs(VerifyWebhook(header, []byte("{}"), 0, []byte("secret")), ErrorInvalidSignature)
+	s.ErrorIs(VerifyWebhook(header, payload, 0, []byte("other")), ErrorInvalidSignature)
+	s.ErrorIs(VerifyWebhook("v1=abc", payload, 0, []byte("secret")), ErrorInvalidSignature)
+
+	s.clock.Advance(DefaultWebhookTolerance + time.Second)
+	s.ErrorIs(VerifyWebhook(header, payload, 0, []byte("secret")), ErrorExpiredSignature)
+	s.NoError(VerifyWebhook(header, payload, time.Hour, []byte("secret")))
+
+	rotated := fmt.Sprintf("%s,v1=%x", header, HMAC([]byte("new"), []byte("1700000000.{}")))
+	s.NoError(VerifyWebhook(rotated, []byte("{}"), time.Hour, []byte("new")))
+}
@@ -0,0 +1,122 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+)
+
+// NewKey a random AES-256 key
+func NewKey(version uint32) (Key, error) {
+	secret, err := RandomBytes(KeyLength)
+	if err != nil {
+		return Key{}, err
+	}
+
+	return Key{version, secret}, nil
+}
+
+// NewKeyRing the keys encrypt with AES-256-GCM, the primary version encrypt the new values and all the keys decrypt.
+// To rotate add the new key as the primary and keep the old ones until the values are re-encrypted, see NeedsRotation
+func NewKeyRing(primary uint32, keys ...Key) (IKeyRing, error) {
+	ring := &KeyRing{primary: primary, keys: map[uint32]cipher.AEAD{}}
+
+	for _, k := range keys {
+		if len(k.Secret) != KeyLength {
+			return nil, ErrorInvalidKey
+		}
+
+		block, err := aes.NewCipher(k.Secret)
+		if err != nil {
+			return nil, err
+		}
+
+		gcm, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, err
+		}
+
+		ring.keys[k.Version] = gcm
+	}
+
+	if _, ok := ring.keys[primary]; !ok {
+		return nil, ErrorNoPrimaryKey
+	}
+
+	return ring, nil
+}
+
+// Encrypt the ciphertext is: format version (1 byte) | key version (4 bytes) | nonce | sealed
+func (r *KeyRing) Encrypt(plaintext, aad []byte) ([]byte, error) {
+	version, gcm := r.primary, r.keys[r.primary]
+
+	nonce, err := RandomBytes(gcm.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 5, 5+len(nonce)+len(plaintext)+gcm.Overhead())
+	out[0] = ciphertextVersion
+	binary.BigEndian.PutUint32(out[1:5], version)
+	out = append(out, nonce...)
+
+	return gcm.Seal(out, nonce, plaintext, aad), nil
+}
+
+func (r *KeyRing) Decrypt(ciphertext, aad []byte) ([]byte, error) {
+	if len(ciphertext) < 5 || ciphertext[0] != ciphertextVersion {
+		return nil, ErrorInvalidCiphertext
+	}
+
+	version := binary.BigEndian.Uint32(ciphertext[1:5])
+
+	gcm, ok := r.keys[version]
+	if !ok {
+		return nil, ErrorUnknownKey
+	}
+
+	sealed := ciphertext[5:]
+	if len(sealed) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, ErrorInvalidCiphertext
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], aad)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorInvalidCiphertext, err)
+	}
+
+	return plaintext, nil
+}
+
+func (r *KeyRing) EncryptString(plaintext string, aad []byte) (string, error) {
+	ciphertext, err := r.Encrypt([]byte(plaintext), aad)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(ciphertext), nil
+}
+
+func (r *KeyRing) DecryptString(ciphertext string, aad []byte) (string, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return "", ErrorInvalidCiphertext
+	}
+
+	plaintext, err := r.Decrypt(decoded, aad)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+func (r *KeyRing) NeedsRotation(ciphertext []byte) bool {
+	if len(ciphertext) < 5 {
+		return false
+	}
+
+	return binary.BigEndian.Uint32(ciphertext[1:5]) != r.primary
+}
@@ -0,0 +1,77 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type KeyRingTestSuite struct {
+	suite.Suite
+
+	v1, v2 Key
+}
+
+func TestKeyRingTestSuite(t *testing.T) {
+	suite.Run(t, new(KeyRingTestSuite))
+}
+
+func (s *KeyRingTestSuite) SetupTest() {
+	s.v1, _ = NewKey(1)
+	s.v2, _ = NewKey(2)
+}
+
+func (s *KeyRingTestSuite) TestNewKeyRing() {
+	_, err := NewKeyRing(1, Key{1, []byte("short")})
+	s.ErrorIs(err, ErrorInvalidKey)
+
+	_, err = NewKeyRing(2, s.v1)
+	s.ErrorIs(err, ErrorNoPrimaryKey)
+}
+
+func (s *KeyRingTestSuite) TestEncrypt() {
+	ring, err := NewKeyRing(1, s.v1)
+	s.NoError(err)
+
+	ciphertext, err := ring.Encrypt([]byte("card"), []byte("user-1"))
+	s.NoError(err)
+
+	plaintext, err := ring.Decrypt(ciphertext, []byte("user-1"))
+	s.NoError(err)
+	s.Equal("card", string(plaintext))
+
+	_, err = ring.Decrypt(ciphertext, []byte("user-2"))
+	s.ErrorIs(err, ErrorInvalidCiphertext)
+
+	_, err = ring.Decrypt(ciphertext[:10], nil)
+	s.ErrorIs(err, ErrorInvalidCiphertext)
+
+	encoded, err := ring.EncryptString("card", nil)
+	s.NoError(err)
+
+	decoded, err := ring.DecryptString(encoded, nil)
+	s.NoError(err)
+	s.Equal("card", decoded)
+
+	_, err = ring.DecryptString("%%", nil)
+	s.ErrorIs(err, ErrorInvalidCiphertext)
+}
+
+func (s *KeyRingTestSuite) TestRotation() {
+	old, _ := NewKeyRing(1, s.v1)
+	ciphertext, _ := old.Encrypt([]byte("card"), nil)
+
+	ring, err := NewKeyRing(2, s.v1, s.v2)
+	s.NoError(err)
+	s.True(ring.NeedsRotation(ciphertext))
+
+	plaintext, err := ring.Decrypt(ciphertext, nil)
+	s.NoError(err)
+
+	reencrypted, _ := ring.Encrypt(plaintext, nil)
+	s.False(ring.NeedsRotation(reencrypted))
+
+	onlyNew, _ := NewKeyRing(2, s.v2)
+	_, err = onlyNew.Decrypt(ciphertext, nil)
+	s.ErrorIs(err, ErrorUnknownKey)
+}
@@ -0,0 +1,58 @@
+package crypto
+
+import "github.com/stretchr/testify/mock"
+
+type MockPasswordHasher struct {
+	mock.Mock
+}
+
+func (m *MockPasswordHasher) Hash(password string) (string, error) {
+	args := m.Called(password)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockPasswordHasher) Verify(password, hash string) (bool, error) {
+	args := m.Called(password, hash)
+	return args.Bool(0), args.Error(1)
+}
+
+func NewMockPasswordHasher() *MockPasswordHasher {
+	return new(MockPasswordHasher)
+}
+
+type MockKeyRing struct {
+	mock.Mock
+}
+
+func (m *MockKeyRing) Encrypt(plaintext, aad []byte) ([]byte, error) {
+	args := m.Called(plaintext, aad)
+
+	ciphertext, _ := args.Get(0).([]byte)
+	return ciphertext, args.Error(1)
+}
+
+func (m *MockKeyRing) Decrypt(ciphertext, aad []byte) ([]byte, error) {
+	args := m.Called(ciphertext, aad)
+
+	plaintext, _ := args.Get(0).([]byte)
+	return plaintext, args.Error(1)
+}
+
+func (m *MockKeyRing) EncryptString(plaintext string, aad []byte) (string, error) {
+	args := m.Called(plaintext, aad)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockKeyRing) DecryptString(ciphertext string, aad []byte) (string, error) {
+	args := m.Called(ciphertext, aad)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockKeyRing) NeedsRotation(ciphertext []byte) bool {
+	args := m.Called(ciphertext)
+	return args.Bool(0)
+}
+
+func NewMockKeyRing() *MockKeyRing {
+	return new(MockKeyRing)
+}
@@ -0,0 +1,128 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// NewPasswordHasher the opts define the new hashes, the hashes created with the old algorithm or parameters are still verified
+func NewPasswordHasher(opts *PasswordOpts) IPasswordHasher {
+	if opts == nil {
+		opts = &PasswordOpts{}
+	}
+
+	if opts.Algorithm == "" {
+		opts.Algorithm = ARGON2ID_ALGORITHM
+	}
+
+	if opts.Argon2 == nil {
+		params := DefaultArgon2Params
+		opts.Argon2 = &params
+	}
+
+	if opts.BcryptCost == 0 {
+		opts.BcryptCost = DefaultBcryptCost
+	}
+
+	return &PasswordHasher{opts}
+}
+
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	switch h.opts.Algorithm {
+	case ARGON2ID_ALGORITHM:
+		return hashArgon2id(password, h.opts.Argon2)
+	case BCRYPT_ALGORITHM:
+		hash, err := bcrypt.GenerateFromPassword([]byte(password), h.opts.BcryptCost)
+		return string(hash), err
+	default:
+		return "", ErrorUnknownAlgorithm
+	}
+}
+
+func (h *PasswordHasher) Verify(password, hash string) (bool, error) {
+	if strings.HasPrefix(hash, "$"+string(ARGON2ID_ALGORITHM)+"$") {
+		params, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+
+		derived := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		if subtle.ConstantTimeCompare(derived, key) != 1 {
+			return false, ErrorPasswordMismatch
+		}
+
+		return h.opts.Algorithm != ARGON2ID_ALGORITHM || *params != *h.opts.Argon2, nil
+	}
+
+	cost, err := bcrypt.Cost([]byte(hash))
+	if err != nil {
+		return false, ErrorInvalidHash
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+	if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+		return false, ErrorPasswordMismatch
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return h.opts.Algorithm != BCRYPT_ALGORITHM || cost != h.opts.BcryptCost, nil
+}
+
+func hashArgon2id(password string, params *Argon2Params) (string, error) {
+	salt := make([]byte, params.SaltLength)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, params.KeyLength)
+
+	return fmt.Sprintf(
+		"$%s$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		ARGON2ID_ALGORITHM, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// decodeArgon2id the PHC string format used by the reference implementation
+func decodeArgon2id(hash string) (*Argon2Params, []byte, []byte, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return nil, nil, nil, ErrorInvalidHash
+	}
+
+	version := 0
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, ErrorInvalidHash
+	}
+
+	params := &Argon2Params{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, ErrorInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, ErrorInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, ErrorInvalidHash
+	}
+
+	params.SaltLength = uint32(len(salt))
+	params.KeyLength = uint32(len(key))
+
+	return params, salt, key, nil
+}
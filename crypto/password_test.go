@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type PasswordTestSuite struct {
+	suite.Suite
+
+	params *Argon2Params
+}
+
+func TestPasswordTestSuite(t *testing.T) {
+	suite.Run(t, new(PasswordTestSuite))
+}
+
+func (s *PasswordTestSuite) SetupTest() {
+	s.params = &Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}
+}
+
+func (s *PasswordTestSuite) TestArgon2id() {
+	h := NewPasswordHasher(&PasswordOpts{Argon2: s.params})
+
+	hash, err := h.Hash("secret")
+	s.NoError(err)
+	s.Contains(hash, "$argon2id$v=19$m=1024,t=1,p=1$")
+
+	other, _ := h.Hash("secret")
+	s.NotEqual(hash, other)
+
+	rehash, err := h.Verify("secret", hash)
+	s.NoError(err)
+	s.False(rehash)
+
+	_, err = h.Verify("wrong", hash)
+	s.ErrorIs(err, ErrorPasswordMismatch)
+
+	_, err = h.Verify("secret", "$argon2id$v=19$m=1024$salt$key")
+	s.ErrorIs(err, ErrorInvalidHash)
+
+	_, err = h.Verify("secret", "plain")
+	s.ErrorIs(err, ErrorInvalidHash)
+}
+
+func (s *PasswordTestSuite) TestMigration() {
+	legacy := NewPasswordHasher(&PasswordOpts{Algorithm: BCRYPT_ALGORITHM, BcryptCost: bcrypt.MinCost})
+	bcryptHash, err := legacy.Hash("secret")
+	s.NoError(err)
+
+	rehash, err := legacy.Verify("secret", bcryptHash)
+	s.NoError(err)
+	s.False(rehash)
+
+	h := NewPasswordHasher(&PasswordOpts{Argon2: s.params})
+
+	rehash, err = h.Verify("secret", bcryptHash)
+	s.NoError(err)
+	s.True(rehash)
+
+	_, err = h.Verify("wrong", bcryptHash)
+	s.ErrorIs(err, ErrorPasswordMismatch)
+
+	argonHash, _ := h.Hash("secret")
+	stronger := *s.params
+	stronger.Iterations = 2
+
+	rehash, err = NewPasswordHasher(&PasswordOpts{Argon2: &stronger}).Verify("secret", argonHash)
+	s.NoError(err)
+	s.True(rehash)
+
+	_, err = NewPasswordHasher(&PasswordOpts{Algorithm: "md5"}).Hash("secret")
+	s.ErrorIs(err, ErrorUnknownAlgorithm)
+}
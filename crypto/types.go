@@ -0,0 +1,61 @@
+package crypto
+
+import "crypto/cipher"
+
+type (
+	PasswordAlgorithm string
+
+	// Argon2Params the memory is in KiB
+	Argon2Params struct {
+		Memory      uint32
+		Iterations  uint32
+		Parallelism uint8
+		SaltLength  uint32
+		KeyLength   uint32
+	}
+
+	PasswordOpts struct {
+		// Algorithm the algorithm of the new hashes, default ARGON2ID_ALGORITHM
+		Algorithm PasswordAlgorithm
+		// Argon2 default DefaultArgon2Params
+		Argon2 *Argon2Params
+		// BcryptCost default DefaultBcryptCost
+		BcryptCost int
+	}
+
+	IPasswordHasher interface {
+		// Hash returns the encoded hash with the algorithm and the parameters, e.g: $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>
+		Hash(password string) (string, error)
+		// Verify returns ErrorPasswordMismatch when the password does not match, both the argon2id and the bcrypt hashes are verified.
+		// rehash is true when the hash was created with other algorithm or parameters, the caller should store a new Hash of the password
+		Verify(password, hash string) (rehash bool, err error)
+	}
+
+	PasswordHasher struct {
+		opts *PasswordOpts
+	}
+
+	// Key the versioned AES-256 key of the KeyRing, the version is written in the ciphertext
+	Key struct {
+		Version uint32
+		Secret  []byte
+	}
+
+	IKeyRing interface {
+		// Encrypt with the primary key, the aad is authenticated but not encrypted, e.g: the record id
+		Encrypt(plaintext, aad []byte) ([]byte, error)
+		// Decrypt with the key version written in the ciphertext
+		Decrypt(ciphertext, aad []byte) ([]byte, error)
+		// EncryptString the base64 url encoded Encrypt
+		EncryptString(plaintext string, aad []byte) (string, error)
+		DecryptString(ciphertext string, aad []byte) (string, error)
+		// NeedsRotation returns true when the ciphertext was not encrypted with the primary key
+		NeedsRotation(ciphertext []byte) bool
+	}
+
+	// KeyRing is immutable, so it is safe to be shared by the goroutines
+	KeyRing struct {
+		primary uint32
+		keys    map[uint32]cipher.AEAD
+	}
+)
@@ -0,0 +1,11 @@
+package di
+
+import "time"
+
+const (
+	DefaultShutdownTimeout = 30 * time.Second
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::di] " + msg
+}
@@ -0,0 +1,93 @@
+// Package difx exposes the toolkit providers as Uber fx modules, the resources are released in the fx OnStop hooks
+//
+// e.g: fx.New(difx.Configs(di.DatabaseArea), difx.Logger(), difx.Postgres(), fx.Invoke(register)).Run()
+package difx
+
+import (
+	"context"
+	"database/sql"
+
+	"go.uber.org/fx"
+
+	"github.com/ralvescosta/gokit/di"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+// Configs provides *env.Configs reading the areas, use fx.Supply to provide configs already built
+func Configs(areas ...di.ConfigArea) fx.Option {
+	return fx.Module("gokit-configs",
+		fx.Provide(func() (*env.Configs, error) {
+			return di.ProvideConfigs(areas)
+		}),
+	)
+}
+
+// Logger provides logging.ILogger
+func Logger() fx.Option {
+	return fx.Module("gokit-logger",
+		fx.Provide(di.ProvideLogger),
+	)
+}
+
+// Postgres provides *sql.DB
+func Postgres() fx.Option {
+	return fx.Module("gokit-postgres",
+		fx.Provide(func(lc fx.Lifecycle, cfg *env.Configs, logger logging.ILogger) (*sql.DB, error) {
+			db, cleanup, err := di.ProvidePostgres(cfg, logger)
+			if err != nil {
+				return nil, err
+			}
+
+			lc.Append(fx.Hook{OnStop: cleanupHook(cleanup)})
+
+			return db, nil
+		}),
+	)
+}
+
+// RabbitMQ provides rabbitmq.IRabbitMQMessaging
+func RabbitMQ() fx.Option {
+	return fx.Module("gokit-rabbitmq",
+		fx.Provide(func(lc fx.Lifecycle, cfg *env.Configs, logger logging.ILogger) (rabbitmq.IRabbitMQMessaging, error) {
+			messaging, _, err := di.ProvideRabbitMQ(cfg, logger)
+			if err != nil {
+				return nil, err
+			}
+
+			lc.Append(fx.Hook{OnStop: messaging.Shutdown})
+
+			return messaging, nil
+		}),
+	)
+}
+
+// Tracing configure the tracer provider in the application start even if no one depends on di.Tracing
+func Tracing() fx.Option {
+	return fx.Module("gokit-tracing",
+		fx.Provide(func(lc fx.Lifecycle, cfg *env.Configs, logger logging.ILogger) (di.Tracing, error) {
+			tracing, _, err := di.ProvideTracing(cfg, logger)
+			if err != nil {
+				return nil, err
+			}
+
+			lc.Append(fx.Hook{OnStop: tracing})
+
+			return tracing, nil
+		}),
+		fx.Invoke(func(di.Tracing) {}),
+	)
+}
+
+// Toolkit groups the logger, postgres, rabbitmq and tracing modules, the configs must be provided
+func Toolkit() fx.Option {
+	return fx.Options(Logger(), Postgres(), RabbitMQ(), Tracing())
+}
+
+func cleanupHook(cleanup func()) func(context.Context) error {
+	return func(ctx context.Context) error {
+		cleanup()
+		return nil
+	}
+}
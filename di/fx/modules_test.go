@@ -0,0 +1,44 @@
+package difx
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+
+	"github.com/ralvescosta/gokit/di"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type ModulesTestSuite struct {
+	suite.Suite
+}
+
+func TestModulesTestSuite(t *testing.T) {
+	suite.Run(t, new(ModulesTestSuite))
+}
+
+func (s *ModulesTestSuite) TestLoggerAndTracing() {
+	var logger logging.ILogger
+	var tracing di.Tracing
+
+	app := fxtest.New(s.T(),
+		fx.NopLogger,
+		fx.Supply(&env.Configs{}),
+		Logger(),
+		Tracing(),
+		fx.Populate(&logger, &tracing),
+	)
+	app.RequireStart().RequireStop()
+
+	s.NotNil(logger)
+	s.NotNil(tracing)
+}
+
+func (s *ModulesTestSuite) TestConfigsErr() {
+	app := fx.New(fx.NopLogger, Configs(di.DatabaseArea), fx.Invoke(func(*env.Configs) {}))
+
+	s.Error(app.Err())
+}
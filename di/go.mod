@@ -0,0 +1,38 @@
+module github.com/ralvescosta/gokit/di
+
+go 1.18
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/google/wire v0.5.0
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/messaging v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/sql v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/telemetry v0.0.0-20261016183433-cb393702ac94
+	github.com/stretchr/testify v1.8.0
+	go.uber.org/fx v1.18.2
+)
+
+require (
+	github.com/benbjohnson/clock v1.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/lib/pq v1.10.6 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/ralvescosta/dotenv v1.0.4 // indirect
+	github.com/ralvescosta/gokit/clock v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/errors v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/guid v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/pool v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/requestid v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/version v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/streadway/amqp v1.0.0 // indirect
+	github.com/stretchr/objx v0.4.0 // indirect
+	go.opentelemetry.io/otel v1.28.0 // indirect
+	go.uber.org/atomic v1.9.0 // indirect
+	go.uber.org/dig v1.15.0 // indirect
+	go.uber.org/multierr v1.8.0 // indirect
+	go.uber.org/zap v1.21.0 // indirect
+	golang.org/x/sys v0.0.0-20220712014510-0a85c31ab51e // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
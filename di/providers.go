@@ -0,0 +1,93 @@
+package di
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	pg "github.com/ralvescosta/gokit/sql/postgres"
+	"github.com/ralvescosta/gokit/telemetry/trace"
+)
+
+var (
+	newPostgres = func(logger logging.ILogger, cfg *env.Configs) (*sql.DB, error) {
+		return pg.New(logger, cfg, nil).Connect().Build()
+	}
+
+	newRabbitMQ = rabbitmq.New
+
+	newTracing = func(ctx context.Context, cfg *env.Configs, logger logging.ILogger) (func(context.Context) error, error) {
+		return trace.NewOTLP(cfg, logger).WithApiKeyHeader().Build(ctx)
+	}
+)
+
+// ProvideConfigs read the env file and the required areas
+func ProvideConfigs(areas ConfigAreas) (*env.Configs, error) {
+	builder := env.New()
+	for _, area := range areas {
+		builder = area(builder)
+	}
+
+	return builder.Build()
+}
+
+func ProvideLogger(cfg *env.Configs) (logging.ILogger, error) {
+	return logging.NewDefaultLogger(cfg)
+}
+
+// ProvidePostgres connect to the database, the cleanup closes the pool
+func ProvidePostgres(cfg *env.Configs, logger logging.ILogger) (*sql.DB, func(), error) {
+	db, err := newPostgres(logger, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return db, func() {
+		if err := db.Close(); err != nil {
+			logger.Error(LogMessage("failure to close the database"), logging.ErrorField(err))
+		}
+	}, nil
+}
+
+// ProvideRabbitMQ connect to the broker, the topologies and dispatchers can be declared before calling Build again
+//
+// The cleanup shutdown the consumers and the connection
+func ProvideRabbitMQ(cfg *env.Configs, logger logging.ILogger) (rabbitmq.IRabbitMQMessaging, func(), error) {
+	messaging, err := newRabbitMQ(cfg, logger).Build()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return messaging, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+		defer cancel()
+
+		if err := messaging.Shutdown(ctx); err != nil {
+			logger.Error(LogMessage("failure to shutdown the messaging"), logging.ErrorField(err))
+		}
+	}, nil
+}
+
+// ProvideTracing configure the OTLP tracer provider when the tracing is enabled, otherwise a no-op Tracing is returned
+func ProvideTracing(cfg *env.Configs, logger logging.ILogger) (Tracing, func(), error) {
+	if !cfg.IS_TRACING_ENABLED {
+		logger.Debug(LogMessage("tracing disabled"))
+		return func(ctx context.Context) error { return nil }, func() {}, nil
+	}
+
+	shutdown, err := newTracing(context.Background(), cfg, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return shutdown, func() {
+		ctx, cancel := context.WithTimeout(context.Background(), DefaultShutdownTimeout)
+		defer cancel()
+
+		if err := shutdown(ctx); err != nil {
+			logger.Error(LogMessage("failure to shutdown the tracer"), logging.ErrorField(err))
+		}
+	}, nil
+}
@@ -0,0 +1,140 @@
+package di
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+type ProvidersTestSuite struct {
+	suite.Suite
+
+	cfg    *env.Configs
+	logger logging.ILogger
+}
+
+func TestProvidersTestSuite(t *testing.T) {
+	suite.Run(t, new(ProvidersTestSuite))
+}
+
+func (s *ProvidersTestSuite) SetupTest() {
+	s.cfg = &env.Configs{}
+	s.logger = logging.NewMockLogger()
+}
+
+func (s *ProvidersTestSuite) TestProvideConfigsErr() {
+	os.Setenv(env.GO_ENV_KEY, "")
+
+	cfg, err := ProvideConfigs(ConfigAreas{DatabaseArea})
+
+	s.Error(err)
+	s.NotNil(cfg)
+}
+
+func (s *ProvidersTestSuite) TestProvideLogger() {
+	logger, err := ProvideLogger(s.cfg)
+
+	s.NoError(err)
+	s.NotNil(logger)
+}
+
+func (s *ProvidersTestSuite) TestProvidePostgres() {
+	db, sqlMock, _ := sqlmock.New()
+	sqlMock.ExpectClose()
+	newPostgres = func(logger logging.ILogger, cfg *env.Configs) (*sql.DB, error) {
+		return db, nil
+	}
+
+	provided, cleanup, err := ProvidePostgres(s.cfg, s.logger)
+
+	s.NoError(err)
+	s.Equal(db, provided)
+
+	cleanup()
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *ProvidersTestSuite) TestProvidePostgresErr() {
+	newPostgres = func(logger logging.ILogger, cfg *env.Configs) (*sql.DB, error) {
+		return nil, errors.New("some error")
+	}
+
+	db, cleanup, err := ProvidePostgres(s.cfg, s.logger)
+
+	s.Error(err)
+	s.Nil(db)
+	s.Nil(cleanup)
+}
+
+func (s *ProvidersTestSuite) TestProvideRabbitMQ() {
+	messaging := rabbitmq.NewMockRabbitMQMessaging()
+	messaging.On("Build", nil).Return(messaging, nil)
+	messaging.On("Shutdown", mock.Anything).Return(nil).Once()
+	newRabbitMQ = func(cfg *env.Configs, logger logging.ILogger) rabbitmq.IRabbitMQMessaging {
+		return messaging
+	}
+
+	provided, cleanup, err := ProvideRabbitMQ(s.cfg, s.logger)
+
+	s.NoError(err)
+	s.Equal(messaging, provided)
+
+	cleanup()
+	messaging.AssertExpectations(s.T())
+}
+
+func (s *ProvidersTestSuite) TestProvideRabbitMQErr() {
+	messaging := rabbitmq.NewMockRabbitMQMessaging()
+	messaging.On("Build", nil).Return(messaging, errors.New("some error"))
+	newRabbitMQ = func(cfg *env.Configs, logger logging.ILogger) rabbitmq.IRabbitMQMessaging {
+		return messaging
+	}
+
+	provided, cleanup, err := ProvideRabbitMQ(s.cfg, s.logger)
+
+	s.Error(err)
+	s.Nil(provided)
+	s.Nil(cleanup)
+}
+
+func (s *ProvidersTestSuite) TestProvideTracingDisabled() {
+	tracing, cleanup, err := ProvideTracing(s.cfg, s.logger)
+
+	s.NoError(err)
+	s.NoError(tracing(context.Background()))
+	cleanup()
+}
+
+func (s *ProvidersTestSuite) TestProvideTracing() {
+	s.cfg.IS_TRACING_ENABLED = true
+	shutdownCalled := false
+	newTracing = func(ctx context.Context, cfg *env.Configs, logger logging.ILogger) (func(context.Context) error, error) {
+		return func(ctx context.Context) error {
+			shutdownCalled = true
+			return nil
+		}, nil
+	}
+
+	_, cleanup, err := ProvideTracing(s.cfg, s.logger)
+
+	s.NoError(err)
+	cleanup()
+	s.True(shutdownCalled)
+
+	newTracing = func(ctx context.Context, cfg *env.Configs, logger logging.ILogger) (func(context.Context) error, error) {
+		return nil, errors.New("some error")
+	}
+
+	_, _, err = ProvideTracing(s.cfg, s.logger)
+	s.Error(err)
+}
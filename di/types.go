@@ -0,0 +1,26 @@
+package di
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/env"
+)
+
+type (
+	// ConfigArea an env area loaded by the configs provider, e.g: env.IConfigs.Database
+	ConfigArea = func(env.IConfigs) env.IConfigs
+
+	// ConfigAreas the env areas required by the application
+	ConfigAreas []ConfigArea
+
+	// Tracing the shutdown of the tracer provider, it flushes the pending spans
+	Tracing func(ctx context.Context) error
+)
+
+var (
+	DatabaseArea   ConfigArea = env.IConfigs.Database
+	MessagingArea  ConfigArea = env.IConfigs.Messaging
+	TracingArea    ConfigArea = env.IConfigs.Tracing
+	HTTPServerArea ConfigArea = env.IConfigs.HTTPServer
+	GRPCServerArea ConfigArea = env.IConfigs.GRPCServer
+)
@@ -0,0 +1,26 @@
+// Package diwire exposes the toolkit providers as google/wire provider sets
+//
+// e.g:
+//
+//	func initialize(areas di.ConfigAreas) (*Service, func(), error) {
+//		wire.Build(diwire.ToolkitSet, NewService)
+//		return nil, nil, nil
+//	}
+package diwire
+
+import (
+	"github.com/google/wire"
+
+	"github.com/ralvescosta/gokit/di"
+)
+
+var (
+	ConfigsSet  = wire.NewSet(di.ProvideConfigs)
+	LoggerSet   = wire.NewSet(di.ProvideLogger)
+	PostgresSet = wire.NewSet(di.ProvidePostgres)
+	RabbitMQSet = wire.NewSet(di.ProvideRabbitMQ)
+	TracingSet  = wire.NewSet(di.ProvideTracing)
+
+	// ToolkitSet provides the configs, logger, database, messaging and tracing
+	ToolkitSet = wire.NewSet(ConfigsSet, LoggerSet, PostgresSet, RabbitMQSet, TracingSet)
+)
@@ -0,0 +1,58 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	RequiredAuthErrorMessage = "[ConfigBuilder::Auth] %s is required"
+	InvalidAuthErrorMessage  = "[ConfigBuilder::Auth] %s is invalid"
+)
+
+func (c *Configs) Auth() IConfigs {
+	if c.Err != nil {
+		return c
+	}
+
+	c.AUTH_ISSUER = os.Getenv(AUTH_ISSUER_ENV_KEY)
+	if c.AUTH_ISSUER == "" {
+		c.Err = fmt.Errorf(RequiredAuthErrorMessage, AUTH_ISSUER_ENV_KEY)
+		return c
+	}
+
+	c.AUTH_AUDIENCE = os.Getenv(AUTH_AUDIENCE_ENV_KEY)
+	if c.AUTH_AUDIENCE == "" {
+		c.Err = fmt.Errorf(RequiredAuthErrorMessage, AUTH_AUDIENCE_ENV_KEY)
+		return c
+	}
+
+	c.AUTH_JWKS_URL = os.Getenv(AUTH_JWKS_URL_ENV_KEY)
+	if c.AUTH_JWKS_URL == "" {
+		c.Err = fmt.Errorf(RequiredAuthErrorMessage, AUTH_JWKS_URL_ENV_KEY)
+		return c
+	}
+
+	if raw := os.Getenv(AUTH_JWKS_REFRESH_INTERVAL_ENV_KEY); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil {
+			c.Err = fmt.Errorf(InvalidAuthErrorMessage, AUTH_JWKS_REFRESH_INTERVAL_ENV_KEY)
+			return c
+		}
+
+		c.AUTH_JWKS_REFRESH_INTERVAL = interval
+	}
+
+	// client credentials are optional, only services that call other services need them
+	c.AUTH_CLIENT_ID = os.Getenv(AUTH_CLIENT_ID_ENV_KEY)
+	c.AUTH_CLIENT_SECRET = os.Getenv(AUTH_CLIENT_SECRET_ENV_KEY)
+	c.AUTH_TOKEN_URL = os.Getenv(AUTH_TOKEN_URL_ENV_KEY)
+
+	if scopes := os.Getenv(AUTH_SCOPES_ENV_KEY); scopes != "" {
+		c.AUTH_SCOPES = strings.Split(strings.ReplaceAll(scopes, " ", ""), ",")
+	}
+
+	return c
+}
@@ -0,0 +1,57 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AuthTestSuite struct {
+	suite.Suite
+}
+
+func TestAuthTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthTestSuite))
+}
+
+func (s *AuthTestSuite) SetupTest() {
+	os.Setenv(AUTH_ISSUER_ENV_KEY, "issuer")
+	os.Setenv(AUTH_AUDIENCE_ENV_KEY, "audience")
+	os.Setenv(AUTH_JWKS_URL_ENV_KEY, "http://localhost/.well-known/jwks.json")
+	os.Setenv(AUTH_JWKS_REFRESH_INTERVAL_ENV_KEY, "")
+}
+
+func (s *AuthTestSuite) TestAuth() {
+	os.Setenv(AUTH_JWKS_REFRESH_INTERVAL_ENV_KEY, "1m")
+	os.Setenv(AUTH_SCOPES_ENV_KEY, "read, write")
+
+	c := &Configs{}
+	c.Auth()
+
+	s.NoError(c.Err)
+	s.Equal("issuer", c.AUTH_ISSUER)
+	s.Equal(time.Minute, c.AUTH_JWKS_REFRESH_INTERVAL)
+	s.Equal([]string{"read", "write"}, c.AUTH_SCOPES)
+}
+
+func (s *AuthTestSuite) TestAuthErr() {
+	os.Setenv(AUTH_JWKS_REFRESH_INTERVAL_ENV_KEY, "invalid")
+
+	c := &Configs{}
+	c.Auth()
+	s.Error(c.Err)
+
+	os.Setenv(AUTH_JWKS_URL_ENV_KEY, "")
+
+	c = &Configs{}
+	c.Auth()
+	s.Error(c.Err)
+
+	os.Setenv(AUTH_ISSUER_ENV_KEY, "")
+
+	c = &Configs{}
+	c.Auth()
+	s.Error(c.Err)
+}
@@ -0,0 +1,252 @@
+package env
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type (
+	// EnvVar an environment variable read by the Configs builders
+	EnvVar struct {
+		Name string `json:"name"`
+		// Subsystem the IConfigs builder that reads it, e.g: Database
+		Subsystem string `json:"subsystem"`
+		// Field the Configs field, empty when it is not stored in the Configs
+		Field string `json:"field,omitempty"`
+		// Type derived from the Configs field, e.g: string, int, bool, duration, list or map
+		Type     string `json:"type"`
+		Default  string `json:"default,omitempty"`
+		Required bool   `json:"required"`
+		// RequiredWhen the condition of the conditionally required vars
+		RequiredWhen string `json:"requiredWhen,omitempty"`
+		Description  string `json:"description,omitempty"`
+	}
+)
+
+const (
+	CatalogMarkdownContentType = "text/markdown; charset=utf-8"
+	CatalogJSONContentType     = "application/json"
+)
+
+var envCatalog = []*EnvVar{
+	{Name: GO_ENV_KEY, Subsystem: "New", Field: "GO_ENV", Required: true, Description: "development, staging, qa or production, selects the .env.<environment> file"},
+	{Name: ENV_ENCRYPTION_KEY_ENV_KEY, Subsystem: "New", RequiredWhen: "the .env.<environment>.enc file exists", Description: "the base64 AES-256 key of the encrypted env file"},
+	{Name: LOG_LEVEL_ENV_KEY, Subsystem: "Build", Field: "LOG_LEVEL", Default: "info", Description: "debug, info, warn, error or panic"},
+	{Name: APP_NAME_ENV_KEY, Subsystem: "Build", Field: "APP_NAME", Default: DEFAULT_APP_NAME},
+	{Name: LOG_PATH_ENV_KEY, Subsystem: "Build", Field: "LOG_PATH", Default: "." + DEFAULT_LOG_PATH + "<APP_NAME>.log", Description: "relative to the working directory"},
+
+	{Name: SQL_DB_HOST_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_HOST", Required: true},
+	{Name: SQL_DB_PORT_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_PORT", Required: true},
+	{Name: SQL_DB_USER_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_USER", Required: true},
+	{Name: SQL_DB_PASSWORD_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_PASSWORD", Required: true},
+	{Name: SQL_DB_NAME_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_NAME", Required: true},
+	{Name: SQL_DB_SECONDS_TO_PING_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_SECONDS_TO_PING", Required: true},
+	{Name: SQL_DB_SSL_MODE_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_SSL_MODE"},
+	{Name: SQL_DB_STATEMENT_TIMEOUT_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_STATEMENT_TIMEOUT"},
+	{Name: SQL_DB_SEARCH_PATH_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_SEARCH_PATH"},
+	{Name: SQL_DB_OPTIONS_ENV_KEY, Subsystem: "Database", Field: "SQL_DB_OPTIONS", Description: "extra connection parameters, e.g: connect_timeout=5,target_session_attrs=read-write"},
+
+	{Name: MESSAGING_ENGINES_ENV_KEY, Subsystem: "Messaging", Field: "MESSAGING_ENGINES", Required: true, Description: "RabbitMQ, Kafka or AzureServiceBus, comma separated"},
+	{Name: RABBIT_HOST_ENV_KEY, Subsystem: "Messaging", Field: "RABBIT_HOST", RequiredWhen: "the RabbitMQ engine"},
+	{Name: RABBIT_PORT_ENV_KEY, Subsystem: "Messaging", Field: "RABBIT_PORT", RequiredWhen: "the RabbitMQ engine"},
+	{Name: RABBIT_USER_ENV_KEY, Subsystem: "Messaging", Field: "RABBIT_USER", RequiredWhen: "the RabbitMQ engine"},
+	{Name: RABBIT_PASSWORD_ENV_KEY, Subsystem: "Messaging", Field: "RABBIT_PASSWORD", RequiredWhen: "the RabbitMQ engine"},
+	{Name: RABBIT_VHOST_ENV_KEY, Subsystem: "Messaging", Field: "RABBIT_VHOST", RequiredWhen: "the RabbitMQ engine"},
+	{Name: RABBIT_MANAGEMENT_URL_ENV_KEY, Subsystem: "Messaging", Field: "RABBIT_MANAGEMENT_URL", Description: "the HTTP management API url, e.g: http://localhost:15672"},
+	{Name: AZURE_SERVICE_BUS_CONNECTION_STRING_ENV_KEY, Subsystem: "Messaging", Field: "AZURE_SERVICE_BUS_CONNECTION_STRING", RequiredWhen: "the AzureServiceBus engine"},
+	{Name: AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT_ENV_KEY, Subsystem: "Messaging", Field: "AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT", Description: "the messages delivered more times are dead-lettered by the consumer"},
+	{Name: AZURE_SERVICE_BUS_MAX_MESSAGES_ENV_KEY, Subsystem: "Messaging", Field: "AZURE_SERVICE_BUS_MAX_MESSAGES", Description: "the number of messages received in each batch"},
+
+	{Name: IS_TRACING_ENABLED_ENV_KEY, Subsystem: "Tracing", Field: "IS_TRACING_ENABLED", Required: true},
+	{Name: OTLP_ENDPOINT_ENV_KEY, Subsystem: "Tracing", Field: "OTLP_ENDPOINT", Required: true},
+	{Name: OTLP_API_KEY_ENV_KEY, Subsystem: "Tracing", Field: "OTLP_API_KEY"},
+	{Name: TRACING_SAMPLER_ENV_KEY, Subsystem: "Tracing", Field: "TRACING_SAMPLER", Default: ALWAYS_ON_SAMPLER, Description: "always_on, always_off, ratio or rate_limiting"},
+	{Name: TRACING_SAMPLER_PARENT_BASED_ENV_KEY, Subsystem: "Tracing", Field: "TRACING_SAMPLER_PARENT_BASED", Default: "true"},
+	{Name: TRACING_SAMPLER_RATIO_ENV_KEY, Subsystem: "Tracing", Field: "TRACING_SAMPLER_RATIO", Default: "1", Description: "between 0 and 1"},
+	{Name: TRACING_SAMPLER_RATE_ENV_KEY, Subsystem: "Tracing", Field: "TRACING_SAMPLER_RATE", Default: fmt.Sprint(DEFAULT_TRACING_SAMPLER_RATE), Description: "the spans per second of the rate_limiting sampler"},
+	{Name: TRACING_SAMPLER_TAIL_HINT_ENV_KEY, Subsystem: "Tracing", Field: "TRACING_SAMPLER_TAIL_HINT", Default: "false"},
+	{Name: TRACING_SAMPLER_OVERRIDES_ENV_KEY, Subsystem: "Tracing", Field: "TRACING_SAMPLER_OVERRIDES", Description: "the ratio by span name or queue, e.g: GET /health=0,heartbeat=0"},
+
+	{Name: HTTP_HOST_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_HOST", Required: true},
+	{Name: HTTP_PORT_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_PORT", Required: true},
+	{Name: HTTP_READ_TIMEOUT_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_READ_TIMEOUT"},
+	{Name: HTTP_WRITE_TIMEOUT_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_WRITE_TIMEOUT"},
+	{Name: HTTP_IDLE_TIMEOUT_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_IDLE_TIMEOUT"},
+	{Name: HTTP_SHUTDOWN_TIMEOUT_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_SHUTDOWN_TIMEOUT"},
+	{Name: HTTP_DRAIN_DELAY_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_DRAIN_DELAY", Description: "the time the readiness fails before the server stops accepting connections"},
+	{Name: HTTP_REQUEST_TIMEOUT_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_REQUEST_TIMEOUT", Description: "the handlers context is cancelled and 504 is returned after it"},
+	{Name: HTTP_MAX_BODY_SIZE_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_MAX_BODY_SIZE", Description: "the max request body size in bytes"},
+	{Name: HTTP_TLS_CERT_PATH_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_TLS_CERT_PATH", RequiredWhen: HTTP_TLS_KEY_PATH_ENV_KEY + " is set"},
+	{Name: HTTP_TLS_KEY_PATH_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_TLS_KEY_PATH", RequiredWhen: HTTP_TLS_CERT_PATH_ENV_KEY + " is set"},
+	{Name: HTTP_PROFILING_ENABLED_ENV_KEY, Subsystem: "HTTPServer", Field: "IS_HTTP_PROFILING_ENABLED", Default: "false"},
+	{Name: HTTP_METRICS_ENABLED_ENV_KEY, Subsystem: "HTTPServer", Field: "IS_HTTP_METRICS_ENABLED", Default: "false"},
+	{Name: HTTP_HEALTH_ENABLED_ENV_KEY, Subsystem: "HTTPServer", Field: "IS_HTTP_HEALTH_ENABLED", Default: "false"},
+	{Name: HTTP_OPENAPI_ENABLED_ENV_KEY, Subsystem: "HTTPServer", Field: "IS_HTTP_OPENAPI_ENABLED", Default: "false"},
+	{Name: HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_CORS_ALLOWED_ORIGINS", Description: "exact, wildcard subdomains, e.g: https://*.example.com, or regex starting with ^"},
+	{Name: HTTP_CORS_ALLOWED_METHODS_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_CORS_ALLOWED_METHODS"},
+	{Name: HTTP_CORS_ALLOWED_HEADERS_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_CORS_ALLOWED_HEADERS"},
+	{Name: HTTP_CORS_EXPOSED_HEADERS_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_CORS_EXPOSED_HEADERS"},
+	{Name: HTTP_CORS_ALLOW_CREDENTIALS_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_CORS_ALLOW_CREDENTIALS", Default: "false"},
+	{Name: HTTP_CORS_MAX_AGE_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_CORS_MAX_AGE"},
+	{Name: HTTP_CORS_STRICT_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_CORS_STRICT", Default: "true", Description: "in the production the * origin with credentials is rejected"},
+	{Name: HTTP_ADMIN_ENABLED_ENV_KEY, Subsystem: "HTTPServer", Field: "IS_HTTP_ADMIN_ENABLED", Default: "false"},
+	{Name: HTTP_ADMIN_PORT_ENV_KEY, Subsystem: "HTTPServer", Default: DEFAULT_HTTP_ADMIN_PORT, Description: "the admin server listen in the HTTP_HOST"},
+	{Name: HTTP_ADMIN_USER_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_ADMIN_USER", RequiredWhen: HTTP_ADMIN_ENABLED_ENV_KEY + "=true"},
+	{Name: HTTP_ADMIN_PASSWORD_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_ADMIN_PASSWORD", RequiredWhen: HTTP_ADMIN_ENABLED_ENV_KEY + "=true"},
+	{Name: HTTP_ADDITIONAL_ADDRS_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_ADDITIONAL_ADDRS", Description: "served by the same router, e.g: 0.0.0.0:8443"},
+	{Name: HTTP_UNIX_SOCKET_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_UNIX_SOCKET", Description: "the socket path for the sidecars, e.g: /var/run/app.sock"},
+	{Name: HTTP_H2C_ENABLED_ENV_KEY, Subsystem: "HTTPServer", Field: "IS_HTTP_H2C_ENABLED", Default: "false"},
+	{Name: HTTP_AUTOCERT_DOMAINS_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_AUTOCERT_DOMAINS", Description: "the tls certificates are requested to the Let's Encrypt, it can not be used with the static certs"},
+	{Name: HTTP_AUTOCERT_CACHE_DIR_ENV_KEY, Subsystem: "HTTPServer", Field: "HTTP_AUTOCERT_CACHE_DIR"},
+
+	{Name: AUTH_ISSUER_ENV_KEY, Subsystem: "Auth", Field: "AUTH_ISSUER", Required: true},
+	{Name: AUTH_AUDIENCE_ENV_KEY, Subsystem: "Auth", Field: "AUTH_AUDIENCE", Required: true},
+	{Name: AUTH_JWKS_URL_ENV_KEY, Subsystem: "Auth", Field: "AUTH_JWKS_URL", Required: true},
+	{Name: AUTH_JWKS_REFRESH_INTERVAL_ENV_KEY, Subsystem: "Auth", Field: "AUTH_JWKS_REFRESH_INTERVAL"},
+	{Name: AUTH_CLIENT_ID_ENV_KEY, Subsystem: "Auth", Field: "AUTH_CLIENT_ID", Description: "the client credentials of the services that call other services"},
+	{Name: AUTH_CLIENT_SECRET_ENV_KEY, Subsystem: "Auth", Field: "AUTH_CLIENT_SECRET"},
+	{Name: AUTH_TOKEN_URL_ENV_KEY, Subsystem: "Auth", Field: "AUTH_TOKEN_URL"},
+	{Name: AUTH_SCOPES_ENV_KEY, Subsystem: "Auth", Field: "AUTH_SCOPES"},
+
+	{Name: RATE_LIMIT_ALGORITHM_ENV_KEY, Subsystem: "RateLimit", Field: "RATE_LIMIT_ALGORITHM", Default: DEFAULT_RATE_LIMIT_ALGORITHM},
+	{Name: RATE_LIMIT_LIMIT_ENV_KEY, Subsystem: "RateLimit", Field: "RATE_LIMIT_LIMIT", Required: true},
+	{Name: RATE_LIMIT_WINDOW_ENV_KEY, Subsystem: "RateLimit", Field: "RATE_LIMIT_WINDOW", Default: DEFAULT_RATE_LIMIT_WINDOW.String()},
+	{Name: RATE_LIMIT_BURST_ENV_KEY, Subsystem: "RateLimit", Field: "RATE_LIMIT_BURST", Default: RATE_LIMIT_LIMIT_ENV_KEY, Description: "the token bucket capacity"},
+
+	{Name: STORAGE_PROVIDER_ENV_KEY, Subsystem: "Storage", Field: "STORAGE_PROVIDER", Default: S3_STORAGE_PROVIDER, Description: "s3, gcs or minio"},
+	{Name: STORAGE_ENDPOINT_ENV_KEY, Subsystem: "Storage", Field: "STORAGE_ENDPOINT", RequiredWhen: "the minio provider"},
+	{Name: STORAGE_REGION_ENV_KEY, Subsystem: "Storage", Field: "STORAGE_REGION"},
+	{Name: STORAGE_ACCESS_KEY_ENV_KEY, Subsystem: "Storage", Field: "STORAGE_ACCESS_KEY", Required: true},
+	{Name: STORAGE_SECRET_KEY_ENV_KEY, Subsystem: "Storage", Field: "STORAGE_SECRET_KEY", Required: true},
+	{Name: STORAGE_BUCKET_ENV_KEY, Subsystem: "Storage", Field: "STORAGE_BUCKET", Required: true},
+	{Name: STORAGE_SSL_ENABLED_ENV_KEY, Subsystem: "Storage", Field: "IS_STORAGE_SSL_ENABLED", Default: "true"},
+
+	{Name: MAILER_PROVIDER_ENV_KEY, Subsystem: "Mailer", Field: "MAILER_PROVIDER", Default: SMTP_MAILER_PROVIDER, Description: "smtp or ses"},
+	{Name: MAILER_FROM_ENV_KEY, Subsystem: "Mailer", Field: "MAILER_FROM", Required: true},
+	{Name: SMTP_HOST_ENV_KEY, Subsystem: "Mailer", Field: "SMTP_HOST", RequiredWhen: "the smtp provider"},
+	{Name: SMTP_PORT_ENV_KEY, Subsystem: "Mailer", Field: "SMTP_PORT", Default: fmt.Sprint(DEFAULT_SMTP_PORT)},
+	{Name: SMTP_USERNAME_ENV_KEY, Subsystem: "Mailer", Field: "SMTP_USERNAME"},
+	{Name: SMTP_PASSWORD_ENV_KEY, Subsystem: "Mailer", Field: "SMTP_PASSWORD"},
+	{Name: SES_REGION_ENV_KEY, Subsystem: "Mailer", Field: "SES_REGION", Description: "it could be omitted when it is defined by the aws shared config"},
+
+	{Name: GRPC_LOGGING_ENABLED_ENV_KEY, Subsystem: "GRPCServer", Field: "IS_GRPC_LOGGING_ENABLED", Default: "true"},
+	{Name: GRPC_TRACING_ENABLED_ENV_KEY, Subsystem: "GRPCServer", Field: "IS_GRPC_TRACING_ENABLED", Default: "false"},
+	{Name: GRPC_VALIDATION_ENABLED_ENV_KEY, Subsystem: "GRPCServer", Field: "IS_GRPC_VALIDATION_ENABLED", Default: "false"},
+	{Name: GRPC_DEFAULT_TIMEOUT_ENV_KEY, Subsystem: "GRPCServer", Field: "GRPC_DEFAULT_TIMEOUT"},
+	{Name: GRPC_MAX_TIMEOUT_ENV_KEY, Subsystem: "GRPCServer", Field: "GRPC_MAX_TIMEOUT"},
+
+	{Name: PROFILING_ENABLED_ENV_KEY, Subsystem: "Profiling", Field: "IS_PROFILING_ENABLED", Default: "false"},
+	{Name: PROFILING_SERVER_URL_ENV_KEY, Subsystem: "Profiling", Field: "PROFILING_SERVER_URL", RequiredWhen: PROFILING_ENABLED_ENV_KEY + "=true", Description: "the Pyroscope server, e.g: http://pyroscope:4040"},
+	{Name: PROFILING_AUTH_TOKEN_ENV_KEY, Subsystem: "Profiling", Field: "PROFILING_AUTH_TOKEN", Description: "sent as the bearer token"},
+	{Name: PROFILING_UPLOAD_INTERVAL_ENV_KEY, Subsystem: "Profiling", Field: "PROFILING_UPLOAD_INTERVAL", Default: DEFAULT_PROFILING_UPLOAD_INTERVAL.String(), Description: "at least 1s"},
+	{Name: PROFILING_TYPES_ENV_KEY, Subsystem: "Profiling", Field: "PROFILING_TYPES", Default: CPU_PROFILE + "," + HEAP_PROFILE, Description: "cpu, heap, allocs, goroutine, mutex or block"},
+	{Name: PROFILING_TAGS_ENV_KEY, Subsystem: "Profiling", Field: "PROFILING_TAGS", Description: "added to the service tags, e.g: region=us-east-1,team=payments"},
+}
+
+// Catalog the env vars of each subsystem in the builders order, the types are taken from the Configs fields
+func Catalog() []*EnvVar {
+	configs := reflect.TypeOf(Configs{})
+
+	catalog := make([]*EnvVar, 0, len(envCatalog))
+	for _, v := range envCatalog {
+		entry := *v
+		entry.Type = "string"
+
+		if field, ok := configs.FieldByName(v.Field); ok {
+			entry.Type = envType(field.Type)
+		}
+
+		catalog = append(catalog, &entry)
+	}
+
+	return catalog
+}
+
+// CatalogJSON the machine-readable catalog
+func CatalogJSON() ([]byte, error) {
+	return json.MarshalIndent(Catalog(), "", "  ")
+}
+
+// CatalogMarkdown one table per subsystem, e.g: to commit the ENV.md generated by gokit env docs
+func CatalogMarkdown() []byte {
+	buf := &bytes.Buffer{}
+	buf.WriteString("# Environment variables\n")
+
+	subsystem := ""
+	for _, v := range Catalog() {
+		if v.Subsystem != subsystem {
+			subsystem = v.Subsystem
+			fmt.Fprintf(buf, "\n## %s\n\n", subsystem)
+			buf.WriteString("| Name | Type | Default | Required | Description |\n")
+			buf.WriteString("|------|------|---------|----------|-------------|\n")
+		}
+
+		required := ""
+		switch {
+		case v.Required:
+			required = "yes"
+		case v.RequiredWhen != "":
+			required = "when " + v.RequiredWhen
+		}
+
+		fmt.Fprintf(buf, "| `%s` | %s | %s | %s | %s |\n", v.Name, v.Type, markdownCell(v.Default), markdownCell(required), markdownCell(v.Description))
+	}
+
+	return buf.Bytes()
+}
+
+// CatalogHandler serve the catalog in JSON, or in Markdown with ?format=markdown or Accept: text/markdown
+func CatalogHandler(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Get("format") == "markdown" || strings.Contains(r.Header.Get("Accept"), "text/markdown") {
+		w.Header().Set("Content-Type", CatalogMarkdownContentType)
+		w.Write(CatalogMarkdown())
+		return
+	}
+
+	byt, err := CatalogJSON()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", CatalogJSONContentType)
+	w.Write(byt)
+}
+
+// envType the format of the env value parsed to the field type
+func envType(t reflect.Type) string {
+	if t == reflect.TypeOf(time.Duration(0)) {
+		return "duration"
+	}
+
+	switch t.Kind() {
+	case reflect.Slice:
+		return "list"
+	case reflect.Map:
+		// the sets are written as lists
+		if t.Elem().Kind() == reflect.Bool {
+			return "list"
+		}
+		return "map"
+	case reflect.Bool:
+		return "bool"
+	case reflect.Int, reflect.Int64:
+		return "int"
+	case reflect.Float64:
+		return "float"
+	case reflect.Int8:
+		// the named enums, e.g: Environment and LogLevel
+		return "enum"
+	default:
+		return "string"
+	}
+}
+
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
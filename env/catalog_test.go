@@ -0,0 +1,92 @@
+package env
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CatalogTestSuite struct {
+	suite.Suite
+}
+
+func TestCatalogTestSuite(t *testing.T) {
+	suite.Run(t, new(CatalogTestSuite))
+}
+
+func (s *CatalogTestSuite) TestCatalogCoversConfigs() {
+	// the fields that are not read from an env var of their own, the KAFKA ones are not read by the Messaging yet
+	skipped := map[string]bool{"Err": true, "HTTP_ADDR": true, "HTTP_ADMIN_ADDR": true, "KAFKA_HOST": true, "KAFKA_PORT": true, "KAFKA_USER": true, "KAFKA_PASSWORD": true}
+
+	documented := map[string]bool{}
+	names := map[string]bool{}
+	for _, v := range Catalog() {
+		s.False(names[v.Name], v.Name)
+		names[v.Name] = true
+		documented[v.Field] = true
+	}
+
+	configs := reflect.TypeOf(Configs{})
+	for i := 0; i < configs.NumField(); i++ {
+		field := configs.Field(i).Name
+		if !skipped[field] {
+			s.True(documented[field], field)
+		}
+	}
+}
+
+func (s *CatalogTestSuite) TestCatalogTypes() {
+	types := map[string]string{}
+	for _, v := range Catalog() {
+		types[v.Name] = v.Type
+	}
+
+	s.Equal("enum", types[GO_ENV_KEY])
+	s.Equal("string", types[ENV_ENCRYPTION_KEY_ENV_KEY])
+	s.Equal("int", types[SQL_DB_SECONDS_TO_PING_ENV_KEY])
+	s.Equal("list", types[MESSAGING_ENGINES_ENV_KEY])
+	s.Equal("bool", types[IS_TRACING_ENABLED_ENV_KEY])
+	s.Equal("float", types[TRACING_SAMPLER_RATIO_ENV_KEY])
+	s.Equal("map", types[TRACING_SAMPLER_OVERRIDES_ENV_KEY])
+	s.Equal("duration", types[RATE_LIMIT_WINDOW_ENV_KEY])
+	s.Equal("list", types[HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY])
+}
+
+func (s *CatalogTestSuite) TestCatalogJSON() {
+	byt, err := CatalogJSON()
+	s.Require().NoError(err)
+
+	catalog := []*EnvVar{}
+	s.Require().NoError(json.Unmarshal(byt, &catalog))
+	s.Equal(Catalog(), catalog)
+}
+
+func (s *CatalogTestSuite) TestCatalogMarkdown() {
+	md := string(CatalogMarkdown())
+
+	s.True(strings.HasPrefix(md, "# Environment variables\n"))
+	s.Contains(md, "\n## Database\n")
+	s.Contains(md, "| `SQL_DB_HOST` | string |  | yes |  |\n")
+	s.Contains(md, "| `STORAGE_ENDPOINT` | string |  | when the minio provider |  |\n")
+	s.Contains(md, "| `RATE_LIMIT_WINDOW` | duration | 1s |  |  |\n")
+}
+
+func (s *CatalogTestSuite) TestCatalogHandler() {
+	rec := httptest.NewRecorder()
+	CatalogHandler(rec, httptest.NewRequest(http.MethodGet, "/env", nil))
+
+	s.Equal(CatalogJSONContentType, rec.Header().Get("Content-Type"))
+	byt, _ := CatalogJSON()
+	s.Equal(string(byt), rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	CatalogHandler(rec, httptest.NewRequest(http.MethodGet, "/env?format=markdown", nil))
+
+	s.Equal(CatalogMarkdownContentType, rec.Header().Get("Content-Type"))
+	s.Equal(string(CatalogMarkdown()), rec.Body.String())
+}
@@ -4,8 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
-
-	"github.com/ralvescosta/dotenv"
+	"time"
 )
 
 const (
@@ -14,6 +13,11 @@ const (
 	LOG_PATH_ENV_KEY  = "LOG_PATH"
 	APP_NAME_ENV_KEY  = "APP_NAME"
 
+	// ENV_ENCRYPTION_KEY_ENV_KEY the base64 AES-256 key used to decrypt the .env.<environment>.enc file
+	ENV_ENCRYPTION_KEY_ENV_KEY = "ENV_ENCRYPTION_KEY"
+	ENCRYPTED_ENV_FILE_EXT     = ".enc"
+	ENCRYPTED_ENV_HEADER       = "GOKIT-AES-256-GCM-V1:"
+
 	SQL_DB_HOST_ENV_KEY            = "SQL_DB_HOST"
 	SQL_DB_PORT_ENV_KEY            = "SQL_DB_PORT"
 	SQL_DB_USER_ENV_KEY            = "SQL_DB_USER"
@@ -21,6 +25,12 @@ const (
 	SQL_DB_NAME_ENV_KEY            = "SQL_DB_NAME"
 	SQL_DB_SECONDS_TO_PING_ENV_KEY = "SQL_DB_SECONDS_TO_PING"
 
+	SQL_DB_SSL_MODE_ENV_KEY          = "SQL_DB_SSL_MODE"
+	SQL_DB_STATEMENT_TIMEOUT_ENV_KEY = "SQL_DB_STATEMENT_TIMEOUT"
+	SQL_DB_SEARCH_PATH_ENV_KEY       = "SQL_DB_SEARCH_PATH"
+	// SQL_DB_OPTIONS_ENV_KEY extra connection parameters, e.g: connect_timeout=5,target_session_attrs=read-write
+	SQL_DB_OPTIONS_ENV_KEY = "SQL_DB_OPTIONS"
+
 	MESSAGING_ENGINES_ENV_KEY = "MESSAGING_ENGINE_ENV_KEY"
 	RABBIT_HOST_ENV_KEY       = "RABBIT_HOST_ENV_KEY"
 	RABBIT_PORT_ENV_KEY       = "RABBIT_PORT_ENV_KEY"
@@ -33,6 +43,16 @@ const (
 	KAFKA_PASSWORD_ENV_KEY    = "KAFKA_PASSWORD_ENV_KEY"
 	RABBITMQ_ENGINE           = "RabbitMQ"
 	KAFKA_ENGINE              = "Kafka"
+	AZURE_SERVICE_BUS_ENGINE  = "AzureServiceBus"
+
+	AZURE_SERVICE_BUS_CONNECTION_STRING_ENV_KEY = "AZURE_SERVICE_BUS_CONNECTION_STRING"
+	// AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT_ENV_KEY optional, the messages delivered more times are dead-lettered by the consumer
+	AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT_ENV_KEY = "AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT"
+	// AZURE_SERVICE_BUS_MAX_MESSAGES_ENV_KEY optional, the number of messages received in each batch
+	AZURE_SERVICE_BUS_MAX_MESSAGES_ENV_KEY = "AZURE_SERVICE_BUS_MAX_MESSAGES"
+
+	// RABBIT_MANAGEMENT_URL_ENV_KEY optional, the HTTP management API url e.g: http://localhost:15672
+	RABBIT_MANAGEMENT_URL_ENV_KEY = "RABBIT_MANAGEMENT_URL"
 
 	UNKNOWN_ENV     Environment = 0
 	DEVELOPMENT_ENV Environment = 1
@@ -53,8 +73,115 @@ const (
 	OTLP_ENDPOINT_ENV_KEY      = "OTLP_ENDPOINT"
 	OTLP_API_KEY_ENV_KEY       = "OTLP_API_KEY"
 
-	HTTP_PORT_ENV_KEY = "HTTP_PORT"
-	HTTP_HOST_ENV_KEY = "HTTP_HOST"
+	TRACING_SAMPLER_ENV_KEY              = "TRACING_SAMPLER"
+	TRACING_SAMPLER_PARENT_BASED_ENV_KEY = "TRACING_SAMPLER_PARENT_BASED"
+	TRACING_SAMPLER_RATIO_ENV_KEY        = "TRACING_SAMPLER_RATIO"
+	TRACING_SAMPLER_RATE_ENV_KEY         = "TRACING_SAMPLER_RATE"
+	TRACING_SAMPLER_TAIL_HINT_ENV_KEY    = "TRACING_SAMPLER_TAIL_HINT"
+	// TRACING_SAMPLER_OVERRIDES_ENV_KEY the ratio by span name or queue, e.g: GET /health=0,heartbeat=0,POST /orders=1
+	TRACING_SAMPLER_OVERRIDES_ENV_KEY = "TRACING_SAMPLER_OVERRIDES"
+
+	ALWAYS_ON_SAMPLER     = "always_on"
+	ALWAYS_OFF_SAMPLER    = "always_off"
+	RATIO_SAMPLER         = "ratio"
+	RATE_LIMITING_SAMPLER = "rate_limiting"
+
+	DEFAULT_TRACING_SAMPLER_RATE = 10
+
+	HTTP_PORT_ENV_KEY                 = "HTTP_PORT"
+	HTTP_HOST_ENV_KEY                 = "HTTP_HOST"
+	HTTP_READ_TIMEOUT_ENV_KEY         = "HTTP_READ_TIMEOUT"
+	HTTP_WRITE_TIMEOUT_ENV_KEY        = "HTTP_WRITE_TIMEOUT"
+	HTTP_IDLE_TIMEOUT_ENV_KEY         = "HTTP_IDLE_TIMEOUT"
+	HTTP_TLS_CERT_PATH_ENV_KEY        = "HTTP_TLS_CERT_PATH"
+	HTTP_TLS_KEY_PATH_ENV_KEY         = "HTTP_TLS_KEY_PATH"
+	HTTP_PROFILING_ENABLED_ENV_KEY    = "HTTP_PROFILING_ENABLED"
+	HTTP_METRICS_ENABLED_ENV_KEY      = "HTTP_METRICS_ENABLED"
+	HTTP_HEALTH_ENABLED_ENV_KEY       = "HTTP_HEALTH_ENABLED"
+	HTTP_OPENAPI_ENABLED_ENV_KEY      = "HTTP_OPENAPI_ENABLED"
+	HTTP_SHUTDOWN_TIMEOUT_ENV_KEY     = "HTTP_SHUTDOWN_TIMEOUT"
+	HTTP_DRAIN_DELAY_ENV_KEY          = "HTTP_DRAIN_DELAY"
+	HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY = "HTTP_CORS_ALLOWED_ORIGINS"
+
+	HTTP_CORS_ALLOWED_METHODS_ENV_KEY   = "HTTP_CORS_ALLOWED_METHODS"
+	HTTP_CORS_ALLOWED_HEADERS_ENV_KEY   = "HTTP_CORS_ALLOWED_HEADERS"
+	HTTP_CORS_EXPOSED_HEADERS_ENV_KEY   = "HTTP_CORS_EXPOSED_HEADERS"
+	HTTP_CORS_ALLOW_CREDENTIALS_ENV_KEY = "HTTP_CORS_ALLOW_CREDENTIALS"
+	HTTP_CORS_MAX_AGE_ENV_KEY           = "HTTP_CORS_MAX_AGE"
+	HTTP_CORS_STRICT_ENV_KEY            = "HTTP_CORS_STRICT"
+
+	HTTP_ADMIN_ENABLED_ENV_KEY  = "HTTP_ADMIN_ENABLED"
+	HTTP_ADMIN_PORT_ENV_KEY     = "HTTP_ADMIN_PORT"
+	HTTP_ADMIN_USER_ENV_KEY     = "HTTP_ADMIN_USER"
+	HTTP_ADMIN_PASSWORD_ENV_KEY = "HTTP_ADMIN_PASSWORD"
+	DEFAULT_HTTP_ADMIN_PORT     = "9090"
+
+	HTTP_ADDITIONAL_ADDRS_ENV_KEY   = "HTTP_ADDITIONAL_ADDRS"
+	HTTP_UNIX_SOCKET_ENV_KEY        = "HTTP_UNIX_SOCKET"
+	HTTP_H2C_ENABLED_ENV_KEY        = "HTTP_H2C_ENABLED"
+	HTTP_AUTOCERT_DOMAINS_ENV_KEY   = "HTTP_AUTOCERT_DOMAINS"
+	HTTP_AUTOCERT_CACHE_DIR_ENV_KEY = "HTTP_AUTOCERT_CACHE_DIR"
+
+	HTTP_REQUEST_TIMEOUT_ENV_KEY = "HTTP_REQUEST_TIMEOUT"
+	HTTP_MAX_BODY_SIZE_ENV_KEY   = "HTTP_MAX_BODY_SIZE"
+
+	AUTH_ISSUER_ENV_KEY                = "AUTH_ISSUER"
+	AUTH_AUDIENCE_ENV_KEY              = "AUTH_AUDIENCE"
+	AUTH_JWKS_URL_ENV_KEY              = "AUTH_JWKS_URL"
+	AUTH_JWKS_REFRESH_INTERVAL_ENV_KEY = "AUTH_JWKS_REFRESH_INTERVAL"
+	AUTH_CLIENT_ID_ENV_KEY             = "AUTH_CLIENT_ID"
+	AUTH_CLIENT_SECRET_ENV_KEY         = "AUTH_CLIENT_SECRET"
+	AUTH_TOKEN_URL_ENV_KEY             = "AUTH_TOKEN_URL"
+	AUTH_SCOPES_ENV_KEY                = "AUTH_SCOPES"
+
+	RATE_LIMIT_ALGORITHM_ENV_KEY = "RATE_LIMIT_ALGORITHM"
+	RATE_LIMIT_LIMIT_ENV_KEY     = "RATE_LIMIT_LIMIT"
+	RATE_LIMIT_WINDOW_ENV_KEY    = "RATE_LIMIT_WINDOW"
+	RATE_LIMIT_BURST_ENV_KEY     = "RATE_LIMIT_BURST"
+	DEFAULT_RATE_LIMIT_ALGORITHM = "token_bucket"
+	DEFAULT_RATE_LIMIT_WINDOW    = time.Second
+
+	STORAGE_PROVIDER_ENV_KEY    = "STORAGE_PROVIDER"
+	STORAGE_ENDPOINT_ENV_KEY    = "STORAGE_ENDPOINT"
+	STORAGE_REGION_ENV_KEY      = "STORAGE_REGION"
+	STORAGE_ACCESS_KEY_ENV_KEY  = "STORAGE_ACCESS_KEY"
+	STORAGE_SECRET_KEY_ENV_KEY  = "STORAGE_SECRET_KEY"
+	STORAGE_BUCKET_ENV_KEY      = "STORAGE_BUCKET"
+	STORAGE_SSL_ENABLED_ENV_KEY = "STORAGE_SSL_ENABLED"
+	S3_STORAGE_PROVIDER         = "s3"
+	GCS_STORAGE_PROVIDER        = "gcs"
+	MINIO_STORAGE_PROVIDER      = "minio"
+
+	MAILER_PROVIDER_ENV_KEY = "MAILER_PROVIDER"
+	MAILER_FROM_ENV_KEY     = "MAILER_FROM"
+	SMTP_HOST_ENV_KEY       = "SMTP_HOST"
+	SMTP_PORT_ENV_KEY       = "SMTP_PORT"
+	SMTP_USERNAME_ENV_KEY   = "SMTP_USERNAME"
+	SMTP_PASSWORD_ENV_KEY   = "SMTP_PASSWORD"
+	SES_REGION_ENV_KEY      = "SES_REGION"
+	SMTP_MAILER_PROVIDER    = "smtp"
+	SES_MAILER_PROVIDER     = "ses"
+	DEFAULT_SMTP_PORT       = 587
+
+	GRPC_LOGGING_ENABLED_ENV_KEY    = "GRPC_LOGGING_ENABLED"
+	GRPC_TRACING_ENABLED_ENV_KEY    = "GRPC_TRACING_ENABLED"
+	GRPC_VALIDATION_ENABLED_ENV_KEY = "GRPC_VALIDATION_ENABLED"
+	GRPC_DEFAULT_TIMEOUT_ENV_KEY    = "GRPC_DEFAULT_TIMEOUT"
+	GRPC_MAX_TIMEOUT_ENV_KEY        = "GRPC_MAX_TIMEOUT"
+
+	PROFILING_ENABLED_ENV_KEY         = "PROFILING_ENABLED"
+	PROFILING_SERVER_URL_ENV_KEY      = "PROFILING_SERVER_URL"
+	PROFILING_AUTH_TOKEN_ENV_KEY      = "PROFILING_AUTH_TOKEN"
+	PROFILING_UPLOAD_INTERVAL_ENV_KEY = "PROFILING_UPLOAD_INTERVAL"
+	PROFILING_TYPES_ENV_KEY           = "PROFILING_TYPES"
+	PROFILING_TAGS_ENV_KEY            = "PROFILING_TAGS"
+	DEFAULT_PROFILING_UPLOAD_INTERVAL = 15 * time.Second
+	CPU_PROFILE                       = "cpu"
+	HEAP_PROFILE                      = "heap"
+	ALLOCS_PROFILE                    = "allocs"
+	GOROUTINE_PROFILE                 = "goroutine"
+	MUTEX_PROFILE                     = "mutex"
+	BLOCK_PROFILE                     = "block"
 )
 
 var (
@@ -73,6 +200,12 @@ type (
 		Messaging() IConfigs
 		Tracing() IConfigs
 		HTTPServer() IConfigs
+		Auth() IConfigs
+		RateLimit() IConfigs
+		Storage() IConfigs
+		Mailer() IConfigs
+		GRPCServer() IConfigs
+		Profiling() IConfigs
 		Build() (*Configs, error)
 	}
 
@@ -93,6 +226,11 @@ type (
 		SQL_DB_NAME            string
 		SQL_DB_SECONDS_TO_PING int
 
+		SQL_DB_SSL_MODE          string
+		SQL_DB_STATEMENT_TIMEOUT time.Duration
+		SQL_DB_SEARCH_PATH       string
+		SQL_DB_OPTIONS           map[string]string
+
 		MESSAGING_ENGINES map[string]bool
 		RABBIT_HOST       string
 		RABBIT_PORT       string
@@ -104,17 +242,113 @@ type (
 		KAFKA_USER        string
 		KAFKA_PASSWORD    string
 
+		RABBIT_MANAGEMENT_URL string
+
+		AZURE_SERVICE_BUS_CONNECTION_STRING  string
+		AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT int
+		AZURE_SERVICE_BUS_MAX_MESSAGES       int
+
 		IS_TRACING_ENABLED bool
 		OTLP_ENDPOINT      string
 		OTLP_API_KEY       string
 
-		HTTP_PORT string
-		HTTP_HOST string
-		HTTP_ADDR string
+		TRACING_SAMPLER              string
+		TRACING_SAMPLER_PARENT_BASED bool
+		TRACING_SAMPLER_RATIO        float64
+		TRACING_SAMPLER_RATE         float64
+		TRACING_SAMPLER_TAIL_HINT    bool
+		TRACING_SAMPLER_OVERRIDES    map[string]float64
+
+		HTTP_PORT                 string
+		HTTP_HOST                 string
+		HTTP_ADDR                 string
+		HTTP_READ_TIMEOUT         time.Duration
+		HTTP_WRITE_TIMEOUT        time.Duration
+		HTTP_IDLE_TIMEOUT         time.Duration
+		HTTP_TLS_CERT_PATH        string
+		HTTP_TLS_KEY_PATH         string
+		IS_HTTP_PROFILING_ENABLED bool
+		IS_HTTP_METRICS_ENABLED   bool
+		IS_HTTP_HEALTH_ENABLED    bool
+		IS_HTTP_OPENAPI_ENABLED   bool
+		HTTP_SHUTDOWN_TIMEOUT     time.Duration
+		// HTTP_DRAIN_DELAY the time the readiness fails before the server stops accepting connections
+		HTTP_DRAIN_DELAY          time.Duration
+		HTTP_CORS_ALLOWED_ORIGINS []string
+
+		HTTP_CORS_ALLOWED_METHODS   []string
+		HTTP_CORS_ALLOWED_HEADERS   []string
+		HTTP_CORS_EXPOSED_HEADERS   []string
+		HTTP_CORS_ALLOW_CREDENTIALS bool
+		HTTP_CORS_MAX_AGE           time.Duration
+		// HTTP_CORS_STRICT default true, in the production the "*" origin with credentials is rejected
+		HTTP_CORS_STRICT bool
+
+		IS_HTTP_ADMIN_ENABLED bool
+		HTTP_ADMIN_ADDR       string
+		HTTP_ADMIN_USER       string
+		HTTP_ADMIN_PASSWORD   string
+
+		// HTTP_ADDITIONAL_ADDRS served by the same router, e.g: 0.0.0.0:8443
+		HTTP_ADDITIONAL_ADDRS []string
+		// HTTP_UNIX_SOCKET the socket path for the sidecars, e.g: /var/run/app.sock
+		HTTP_UNIX_SOCKET    string
+		IS_HTTP_H2C_ENABLED bool
+		// HTTP_AUTOCERT_DOMAINS the tls certificates are requested to the Let's Encrypt, it can not be used with the static certs
+		HTTP_AUTOCERT_DOMAINS   []string
+		HTTP_AUTOCERT_CACHE_DIR string
+
+		// HTTP_REQUEST_TIMEOUT the handlers context is cancelled and 504 is returned after it
+		HTTP_REQUEST_TIMEOUT time.Duration
+		// HTTP_MAX_BODY_SIZE the max request body size in bytes, 413 is returned above it
+		HTTP_MAX_BODY_SIZE int64
+
+		AUTH_ISSUER                string
+		AUTH_AUDIENCE              string
+		AUTH_JWKS_URL              string
+		AUTH_JWKS_REFRESH_INTERVAL time.Duration
+		AUTH_CLIENT_ID             string
+		AUTH_CLIENT_SECRET         string
+		AUTH_TOKEN_URL             string
+		AUTH_SCOPES                []string
+
+		RATE_LIMIT_ALGORITHM string
+		RATE_LIMIT_LIMIT     int
+		RATE_LIMIT_WINDOW    time.Duration
+		RATE_LIMIT_BURST     int
+
+		STORAGE_PROVIDER       string
+		STORAGE_ENDPOINT       string
+		STORAGE_REGION         string
+		STORAGE_ACCESS_KEY     string
+		STORAGE_SECRET_KEY     string
+		STORAGE_BUCKET         string
+		IS_STORAGE_SSL_ENABLED bool
+
+		MAILER_PROVIDER string
+		MAILER_FROM     string
+		SMTP_HOST       string
+		SMTP_PORT       int
+		SMTP_USERNAME   string
+		SMTP_PASSWORD   string
+		SES_REGION      string
+
+		IS_GRPC_LOGGING_ENABLED    bool
+		IS_GRPC_TRACING_ENABLED    bool
+		IS_GRPC_VALIDATION_ENABLED bool
+		GRPC_DEFAULT_TIMEOUT       time.Duration
+		GRPC_MAX_TIMEOUT           time.Duration
+
+		IS_PROFILING_ENABLED      bool
+		PROFILING_SERVER_URL      string
+		PROFILING_AUTH_TOKEN      string
+		PROFILING_UPLOAD_INTERVAL time.Duration
+		PROFILING_TYPES           []string
+		PROFILING_TAGS            map[string]string
 	}
 )
 
-var dotEnvConfig = dotenv.Configure
+var dotEnvConfig = LoadEnv
 
 func New() IConfigs {
 	c := &Configs{}
@@ -126,12 +360,25 @@ func New() IConfigs {
 		return c
 	}
 
-	err := dotEnvConfig(".env." + EnvironmentMapping[c.GO_ENV])
-	if err != nil {
+	path := ".env." + EnvironmentMapping[c.GO_ENV]
+	encryptedPath := path + ENCRYPTED_ENV_FILE_EXT
+	_, statErr := os.Stat(encryptedPath)
+	hasEncrypted := statErr == nil
+
+	// the plain file is optional when the environment has an encrypted file
+	err := dotEnvConfig(path)
+	if err != nil && !hasEncrypted {
 		c.Err = err
 		return c
 	}
 
+	if hasEncrypted {
+		if err := LoadEncryptedEnv(encryptedPath); err != nil {
+			c.Err = err
+			return c
+		}
+	}
+
 	return c
 }
 
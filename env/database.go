@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	RequiredDatabaseErrorMessage = "[ConfigBuilder::Messaging] %s is required"
+	InvalidDatabaseErrorMessage  = "[ConfigBuilder::Database] %s is invalid"
 )
 
 func (c *Configs) Database() IConfigs {
@@ -53,5 +56,36 @@ func (c *Configs) Database() IConfigs {
 
 	c.SQL_DB_SECONDS_TO_PING = p
 
+	c.getDatabaseOptionalConfigs()
+
 	return c
 }
+
+func (c *Configs) getDatabaseOptionalConfigs() {
+	c.SQL_DB_SSL_MODE = os.Getenv(SQL_DB_SSL_MODE_ENV_KEY)
+	c.SQL_DB_SEARCH_PATH = os.Getenv(SQL_DB_SEARCH_PATH_ENV_KEY)
+
+	if raw := os.Getenv(SQL_DB_STATEMENT_TIMEOUT_ENV_KEY); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil {
+			c.Err = fmt.Errorf(InvalidDatabaseErrorMessage, SQL_DB_STATEMENT_TIMEOUT_ENV_KEY)
+			return
+		}
+
+		c.SQL_DB_STATEMENT_TIMEOUT = timeout
+	}
+
+	if raw := os.Getenv(SQL_DB_OPTIONS_ENV_KEY); raw != "" {
+		c.SQL_DB_OPTIONS = map[string]string{}
+
+		for _, opt := range strings.Split(raw, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(opt), "=")
+			if !ok || key == "" {
+				c.Err = fmt.Errorf(InvalidDatabaseErrorMessage, SQL_DB_OPTIONS_ENV_KEY)
+				return
+			}
+
+			c.SQL_DB_OPTIONS[key] = value
+		}
+	}
+}
@@ -3,6 +3,7 @@ package env
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 )
@@ -83,3 +84,41 @@ func (s *DatabaseTestSuite) TestDatabaseErr() {
 	_, err = New().Database().Build()
 	s.Error(err)
 }
+
+func (s *DatabaseTestSuite) TestDatabaseOptionalConfigs() {
+	os.Setenv(GO_ENV_KEY, "dev")
+	os.Setenv(SQL_DB_HOST_ENV_KEY, "host")
+	os.Setenv(SQL_DB_PORT_ENV_KEY, "port")
+	os.Setenv(SQL_DB_USER_ENV_KEY, "user")
+	os.Setenv(SQL_DB_PASSWORD_ENV_KEY, "password")
+	os.Setenv(SQL_DB_NAME_ENV_KEY, "name")
+	os.Setenv(SQL_DB_SECONDS_TO_PING_ENV_KEY, "1")
+	os.Setenv(SQL_DB_SSL_MODE_ENV_KEY, "require")
+	os.Setenv(SQL_DB_STATEMENT_TIMEOUT_ENV_KEY, "5s")
+	os.Setenv(SQL_DB_SEARCH_PATH_ENV_KEY, "app,public")
+	os.Setenv(SQL_DB_OPTIONS_ENV_KEY, "connect_timeout=5, target_session_attrs=read-write")
+	defer func() {
+		os.Unsetenv(SQL_DB_SSL_MODE_ENV_KEY)
+		os.Unsetenv(SQL_DB_STATEMENT_TIMEOUT_ENV_KEY)
+		os.Unsetenv(SQL_DB_SEARCH_PATH_ENV_KEY)
+		os.Unsetenv(SQL_DB_OPTIONS_ENV_KEY)
+	}()
+
+	cfg, err := New().Database().Build()
+
+	s.NoError(err)
+	s.Equal("require", cfg.SQL_DB_SSL_MODE)
+	s.Equal(5*time.Second, cfg.SQL_DB_STATEMENT_TIMEOUT)
+	s.Equal("app,public", cfg.SQL_DB_SEARCH_PATH)
+	s.Equal(map[string]string{"connect_timeout": "5", "target_session_attrs": "read-write"}, cfg.SQL_DB_OPTIONS)
+
+	os.Setenv(SQL_DB_OPTIONS_ENV_KEY, "connect_timeout")
+
+	_, err = New().Database().Build()
+	s.Error(err)
+
+	os.Setenv(SQL_DB_STATEMENT_TIMEOUT_ENV_KEY, "5")
+
+	_, err = New().Database().Build()
+	s.Error(err)
+}
@@ -0,0 +1,331 @@
+package env
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type (
+	// EnvLookup the envs used by the interpolation that are not defined in the file, e.g: os.LookupEnv
+	EnvLookup = func(key string) (string, bool)
+
+	envParser struct {
+		vars   map[string]string
+		lookup EnvLookup
+		line   int
+	}
+)
+
+var ErrorEnvFileSyntax = errors.New("[ConfigBuilder::New] invalid env file")
+
+// LoadEnv parse the env file and set the envs, the process envs take precedence over the values defined in the file and
+// the empty values are ignored
+func LoadEnv(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return setEnvs(content)
+}
+
+// ParseEnv parse the env file with the docker-compose semantics, so the same .env works in both:
+//
+//	# comments and blank lines are ignored
+//	export APP_NAME=orders         # the export prefix and the inline comments are accepted
+//	LOG_PATH=/logs/${APP_NAME}.log # ${VAR} and $VAR are replaced by the lookup or by the previous vars of the file
+//	SQL_DB_HOST=${DB_HOST:-localhost}
+//	OTLP_API_KEY=${OTLP_API_KEY:?the api key is required}
+//	PRICE='$literal ${NOT_REPLACED}'
+//	HTTP_TLS_CERT="-----BEGIN CERTIFICATE-----
+//	MIIB...
+//	-----END CERTIFICATE-----"
+//
+// the double quoted values accept the \n, \r, \t, \", \\ and \$ escapes, the single quoted values are literal and both
+// may span multiple lines, $$ is a literal $ in the unquoted and double quoted values
+func ParseEnv(content []byte, lookup EnvLookup) (map[string]string, error) {
+	p := &envParser{vars: map[string]string{}, lookup: lookup}
+	lines := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+
+	for i := 0; i < len(lines); i++ {
+		p.line = i + 1
+
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if rest := strings.TrimPrefix(line, "export"); rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			line = strings.TrimSpace(rest)
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		key = strings.TrimSpace(key)
+		if !ok || !validEnvName(key) {
+			return nil, p.errorf("expected KEY=VALUE")
+		}
+
+		value = strings.TrimLeft(value, " \t")
+
+		var err error
+		switch {
+		case strings.HasPrefix(value, `'`):
+			value, i, err = p.quoted(lines, i, value[1:], '\'')
+		case strings.HasPrefix(value, `"`):
+			value, i, err = p.quoted(lines, i, value[1:], '"')
+			if err == nil {
+				value, err = p.expand(value, true)
+			}
+		default:
+			value, err = p.expand(unquoted(value), false)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.vars[key] = value
+	}
+
+	return p.vars, nil
+}
+
+// setEnvs parse the env file content and set the envs, the keys already defined in the process are kept
+func setEnvs(content []byte) error {
+	vars, err := ParseEnv(content, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range vars {
+		if _, ok := os.LookupEnv(key); ok || value == "" {
+			continue
+		}
+
+		if err := os.Setenv(key, value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// quoted the value until the closing quote, joining the next lines while it is not found
+func (p *envParser) quoted(lines []string, i int, value string, quote byte) (string, int, error) {
+	start := p.line
+
+	for {
+		if end := closingQuote(value, quote); end != -1 {
+			if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				return "", i, p.errorf("unexpected %q after the quoted value", rest)
+			}
+
+			return value[:end], i, nil
+		}
+
+		i++
+		if i >= len(lines) {
+			p.line = start
+			return "", i, p.errorf("unterminated quoted value")
+		}
+
+		p.line = i + 1
+		value += "\n" + lines[i]
+	}
+}
+
+// expand replace the ${VAR} and $VAR references, and the escapes of the double quoted values
+func (p *envParser) expand(value string, escapes bool) (string, error) {
+	b := strings.Builder{}
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		next := byte(0)
+		if i+1 < len(value) {
+			next = value[i+1]
+		}
+
+		switch {
+		case escapes && c == '\\' && next != 0:
+			i++
+			switch next {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(next)
+			default:
+				b.WriteByte(c)
+				b.WriteByte(next)
+			}
+		case c == '$' && next == '$':
+			b.WriteByte('$')
+			i++
+		case c == '$' && next == '{':
+			end := closingBrace(value, i+2)
+			if end == -1 {
+				return "", p.errorf("unclosed ${ in the value")
+			}
+
+			resolved, err := p.resolve(value[i+2 : end])
+			if err != nil {
+				return "", err
+			}
+
+			b.WriteString(resolved)
+			i = end
+		case c == '$' && isEnvNameStart(next):
+			end := i + 1
+			for end < len(value) && isEnvNameChar(value[end]) {
+				end++
+			}
+
+			resolved, _ := p.get(value[i+1 : end])
+			b.WriteString(resolved)
+			i = end - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String(), nil
+}
+
+// resolve the ${VAR}, ${VAR:-default}, ${VAR-default}, ${VAR:?error}, ${VAR?error}, ${VAR:+replacement} and
+// ${VAR+replacement} expressions, the colon forms also treat the empty values as unset
+func (p *envParser) resolve(expr string) (string, error) {
+	end := 0
+	for end < len(expr) && isEnvNameChar(expr[end]) {
+		end++
+	}
+
+	name, op := expr[:end], expr[end:]
+	if !validEnvName(name) {
+		return "", p.errorf("invalid variable name in ${%s}", expr)
+	}
+
+	value, set := p.get(name)
+	if strings.HasPrefix(op, ":") {
+		set = set && value != ""
+		op = op[1:]
+	}
+
+	if op == "" {
+		return value, nil
+	}
+
+	arg := op[1:]
+	switch op[0] {
+	case '-':
+		if set {
+			return value, nil
+		}
+		return p.expand(arg, false)
+	case '+':
+		if !set {
+			return "", nil
+		}
+		return p.expand(arg, false)
+	case '?':
+		if set {
+			return value, nil
+		}
+		if arg == "" {
+			arg = "required variable is not set"
+		}
+		return "", p.errorf("%s: %s", name, arg)
+	default:
+		return "", p.errorf("invalid expression ${%s}", expr)
+	}
+}
+
+// get the lookup takes precedence over the vars defined before in the file, the same way setEnvs keeps the process envs
+func (p *envParser) get(name string) (string, bool) {
+	if p.lookup != nil {
+		if value, ok := p.lookup(name); ok {
+			return value, true
+		}
+	}
+
+	value, ok := p.vars[name]
+	return value, ok
+}
+
+func (p *envParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrorEnvFileSyntax, p.line, fmt.Sprintf(format, args...))
+}
+
+// unquoted the inline comments must be preceded by a whitespace, e.g: URL=http://host/#anchor keeps the anchor
+func unquoted(value string) string {
+	if strings.HasPrefix(value, "#") {
+		return ""
+	}
+
+	if i := strings.Index(value, " #"); i != -1 {
+		value = value[:i]
+	}
+
+	if i := strings.Index(value, "\t#"); i != -1 {
+		value = value[:i]
+	}
+
+	return strings.TrimSpace(value)
+}
+
+func closingQuote(value string, quote byte) int {
+	for i := 0; i < len(value); i++ {
+		switch {
+		case quote == '"' && value[i] == '\\':
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+
+	return -1
+}
+
+// closingBrace the nested expressions are allowed in the defaults, e.g: ${DB_HOST:-${HOST}}
+func closingBrace(value string, start int) int {
+	depth := 1
+	for i := start; i < len(value); i++ {
+		switch value[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func validEnvName(name string) bool {
+	if name == "" || !isEnvNameStart(name[0]) {
+		return false
+	}
+
+	for i := 1; i < len(name); i++ {
+		if !isEnvNameChar(name[i]) && name[i] != '.' && name[i] != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isEnvNameStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isEnvNameChar(c byte) bool {
+	return isEnvNameStart(c) || c >= '0' && c <= '9'
+}
@@ -0,0 +1,160 @@
+package env
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DotEnvTestSuite struct {
+	suite.Suite
+
+	lookup EnvLookup
+}
+
+func TestDotEnvTestSuite(t *testing.T) {
+	suite.Run(t, new(DotEnvTestSuite))
+}
+
+func (s *DotEnvTestSuite) SetupTest() {
+	process := map[string]string{"HOST": "db.internal", "EMPTY": ""}
+	s.lookup = func(key string) (string, bool) {
+		value, ok := process[key]
+		return value, ok
+	}
+}
+
+func (s *DotEnvTestSuite) TestParseEnv() {
+	content := `
+# the service configs
+export APP_NAME=orders
+LOG_LEVEL = debug # inline comment
+SQL_DB_HOST=${HOST}
+SQL_DB_NAME=$APP_NAME-db
+LOG_PATH=/logs/${APP_NAME}.log
+URL=http://localhost/#anchor
+PRICE=$$10
+SINGLE='$APP_NAME ${HOST} \n' # literal
+DOUBLE="${APP_NAME}\t\"quoted\" \$HOST"
+EMPTY_VALUE=
+`
+
+	vars, err := ParseEnv([]byte(content), s.lookup)
+
+	s.Require().NoError(err)
+	s.Equal(map[string]string{
+		"APP_NAME":    "orders",
+		"LOG_LEVEL":   "debug",
+		"SQL_DB_HOST": "db.internal",
+		"SQL_DB_NAME": "orders-db",
+		"LOG_PATH":    "/logs/orders.log",
+		"URL":         "http://localhost/#anchor",
+		"PRICE":       "$10",
+		"SINGLE":      `$APP_NAME ${HOST} \n`,
+		"DOUBLE":      "orders\t\"quoted\" $HOST",
+		"EMPTY_VALUE": "",
+	}, vars)
+}
+
+func (s *DotEnvTestSuite) TestParseEnvMultiLine() {
+	content := "HTTP_TLS_CERT=\"-----BEGIN CERTIFICATE-----\r\nMIIB\r\n-----END CERTIFICATE-----\"\r\nKEY='line 1\nline 2' # comment\nNEXT=1\n"
+
+	vars, err := ParseEnv([]byte(content), nil)
+
+	s.Require().NoError(err)
+	s.Equal("-----BEGIN CERTIFICATE-----\nMIIB\n-----END CERTIFICATE-----", vars["HTTP_TLS_CERT"])
+	s.Equal("line 1\nline 2", vars["KEY"])
+	s.Equal("1", vars["NEXT"])
+}
+
+func (s *DotEnvTestSuite) TestParseEnvDefaults() {
+	content := `
+A=${MISSING:-default}
+B=${EMPTY:-default}
+C=${EMPTY-default}
+D=${MISSING:-${HOST}}
+E=${HOST:+replaced}
+F=${MISSING:+replaced}
+G=${HOST:?required}
+`
+
+	vars, err := ParseEnv([]byte(content), s.lookup)
+
+	s.Require().NoError(err)
+	s.Equal("default", vars["A"])
+	s.Equal("default", vars["B"])
+	s.Equal("", vars["C"])
+	s.Equal("db.internal", vars["D"])
+	s.Equal("replaced", vars["E"])
+	s.Equal("", vars["F"])
+	s.Equal("db.internal", vars["G"])
+}
+
+func (s *DotEnvTestSuite) TestParseEnvLookupPrecedence() {
+	content := "HOST=localhost\nSQL_DB_HOST=${HOST}\nSQL_DB_URL=postgres://$HOST/orders\n"
+
+	vars, err := ParseEnv([]byte(content), s.lookup)
+
+	s.Require().NoError(err)
+	s.Equal("localhost", vars["HOST"])
+	s.Equal("db.internal", vars["SQL_DB_HOST"])
+	s.Equal("postgres://db.internal/orders", vars["SQL_DB_URL"])
+}
+
+func (s *DotEnvTestSuite) TestParseEnvErrors() {
+	cases := map[string]string{
+		"A=1\nINVALID LINE":             "line 2: expected KEY=VALUE",
+		"A=\"unterminated\nB=1":         "line 1: unterminated quoted value",
+		"A='value' trailing":            `line 1: unexpected "trailing" after the quoted value`,
+		"A=${MISSING:?the A is needed}": "line 1: MISSING: the A is needed",
+		"A=${MISSING":                   "line 1: unclosed ${ in the value",
+		"A=${1INVALID}":                 "line 1: invalid variable name in ${1INVALID}",
+		"A=${HOST%suffix}":              "line 1: invalid expression ${HOST%suffix}",
+	}
+
+	for content, msg := range cases {
+		_, err := ParseEnv([]byte(content), s.lookup)
+
+		s.ErrorIs(err, ErrorEnvFileSyntax, content)
+		s.EqualError(err, ErrorEnvFileSyntax.Error()+": "+msg, content)
+	}
+}
+
+func (s *DotEnvTestSuite) TestLoadEnv() {
+	path := filepath.Join(s.T().TempDir(), ".env.test")
+	s.Require().NoError(os.WriteFile(path, []byte("DOTENV_TEST_NAME=orders\nDOTENV_TEST_PATH=/logs/${DOTENV_TEST_NAME}.log\nDOTENV_TEST_EMPTY=\nDOTENV_TEST_LEVEL=debug\n"), 0o600))
+	os.Setenv("DOTENV_TEST_EMPTY", "kept")
+	os.Setenv("DOTENV_TEST_LEVEL", "")
+	defer func() {
+		os.Unsetenv("DOTENV_TEST_NAME")
+		os.Unsetenv("DOTENV_TEST_PATH")
+		os.Unsetenv("DOTENV_TEST_EMPTY")
+		os.Unsetenv("DOTENV_TEST_LEVEL")
+	}()
+
+	s.NoError(LoadEnv(path))
+
+	s.Equal("orders", os.Getenv("DOTENV_TEST_NAME"))
+	s.Equal("/logs/orders.log", os.Getenv("DOTENV_TEST_PATH"))
+	s.Equal("kept", os.Getenv("DOTENV_TEST_EMPTY"))
+	s.Equal("", os.Getenv("DOTENV_TEST_LEVEL"))
+
+	s.Error(LoadEnv(filepath.Join(s.T().TempDir(), ".env.missing")))
+}
+
+func (s *DotEnvTestSuite) TestLoadEnvProcessPrecedence() {
+	path := filepath.Join(s.T().TempDir(), ".env.test")
+	s.Require().NoError(os.WriteFile(path, []byte("DOTENV_TEST_NAME=orders\nDOTENV_TEST_PATH=/logs/${DOTENV_TEST_NAME}.log\n"), 0o600))
+	os.Setenv("DOTENV_TEST_NAME", "billing")
+	defer func() {
+		os.Unsetenv("DOTENV_TEST_NAME")
+		os.Unsetenv("DOTENV_TEST_PATH")
+	}()
+
+	s.NoError(LoadEnv(path))
+
+	s.Equal("billing", os.Getenv("DOTENV_TEST_NAME"))
+	s.Equal("/logs/billing.log", os.Getenv("DOTENV_TEST_PATH"))
+}
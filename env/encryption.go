@@ -0,0 +1,128 @@
+package env
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// KeyProvider returns the key to decrypt the env files, e.g: a data key decrypted by the cloud KMS
+type KeyProvider = func() ([]byte, error)
+
+// EncryptionKeyProvider the key provider used by New, the default reads the ENV_ENCRYPTION_KEY env
+var EncryptionKeyProvider KeyProvider = EncryptionKeyFromEnv
+
+var (
+	ErrorEncryptionKey    = errors.New("[ConfigBuilder::New] the env encryption key must be a base64 32 bytes key")
+	ErrorEncryptedEnvFile = errors.New("[ConfigBuilder::New] invalid encrypted env file")
+)
+
+// EncryptionKeyFromEnv read the base64 key from ENV_ENCRYPTION_KEY
+func EncryptionKeyFromEnv() ([]byte, error) {
+	return decodeKey(os.Getenv(ENV_ENCRYPTION_KEY_ENV_KEY))
+}
+
+// NewEncryptionKey generate a random base64 key
+func NewEncryptionKey() (string, error) {
+	key := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, key); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// EncryptEnv encrypt the env file content with AES-256-GCM, the result is safe to be committed
+func EncryptEnv(plaintext, key []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, plaintext, nil)
+
+	return []byte(ENCRYPTED_ENV_HEADER + base64.StdEncoding.EncodeToString(sealed) + "\n"), nil
+}
+
+// DecryptEnv decrypt the content created by EncryptEnv
+func DecryptEnv(content, key []byte) ([]byte, error) {
+	encoded := strings.TrimSpace(string(content))
+	if !strings.HasPrefix(encoded, ENCRYPTED_ENV_HEADER) {
+		return nil, ErrorEncryptedEnvFile
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encoded, ENCRYPTED_ENV_HEADER))
+	if err != nil {
+		return nil, ErrorEncryptedEnvFile
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sealed) < gcm.NonceSize() {
+		return nil, ErrorEncryptedEnvFile
+	}
+
+	plaintext, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("[ConfigBuilder::New] failure to decrypt the env file: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// LoadEncryptedEnv decrypt the file in memory with the EncryptionKeyProvider key and set the envs,
+// the plaintext is never written to the disk
+func LoadEncryptedEnv(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	key, err := EncryptionKeyProvider()
+	if err != nil {
+		return err
+	}
+
+	plaintext, err := DecryptEnv(content, key)
+	if err != nil {
+		return err
+	}
+
+	return setEnvs(plaintext)
+}
+
+func decodeKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != 32 {
+		return nil, ErrorEncryptionKey
+	}
+
+	return key, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != 32 {
+		return nil, ErrorEncryptionKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
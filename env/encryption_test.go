@@ -0,0 +1,82 @@
+package env
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EncryptionTestSuite struct {
+	suite.Suite
+
+	key []byte
+}
+
+func TestEncryptionTestSuite(t *testing.T) {
+	suite.Run(t, new(EncryptionTestSuite))
+}
+
+func (s *EncryptionTestSuite) SetupTest() {
+	encoded, err := NewEncryptionKey()
+	s.NoError(err)
+
+	s.key, _ = base64.StdEncoding.DecodeString(encoded)
+	os.Setenv(ENV_ENCRYPTION_KEY_ENV_KEY, encoded)
+}
+
+func (s *EncryptionTestSuite) TearDownTest() {
+	os.Unsetenv(ENV_ENCRYPTION_KEY_ENV_KEY)
+	EncryptionKeyProvider = EncryptionKeyFromEnv
+}
+
+func (s *EncryptionTestSuite) TestEncryptAndDecrypt() {
+	encrypted, err := EncryptEnv([]byte("SQL_DB_PASSWORD=secret"), s.key)
+	s.NoError(err)
+	s.NotContains(string(encrypted), "secret")
+
+	plaintext, err := DecryptEnv(encrypted, s.key)
+	s.NoError(err)
+	s.Equal("SQL_DB_PASSWORD=secret", string(plaintext))
+
+	other, _ := NewEncryptionKey()
+	otherKey, _ := base64.StdEncoding.DecodeString(other)
+	_, err = DecryptEnv(encrypted, otherKey)
+	s.Error(err)
+
+	_, err = DecryptEnv([]byte("SQL_DB_PASSWORD=secret"), s.key)
+	s.ErrorIs(err, ErrorEncryptedEnvFile)
+
+	_, err = EncryptEnv([]byte(""), []byte("short"))
+	s.ErrorIs(err, ErrorEncryptionKey)
+}
+
+func (s *EncryptionTestSuite) TestNewLoadsTheEncryptedFile() {
+	wd, _ := os.Getwd()
+	s.NoError(os.Chdir(s.T().TempDir()))
+	defer os.Chdir(wd)
+
+	encrypted, _ := EncryptEnv([]byte("# credentials\nENC_TEST_PASSWORD=\"pa=ss\"\nENC_TEST_USER=user # comment\n"), s.key)
+	s.NoError(os.WriteFile(".env.staging.enc", encrypted, 0o600))
+	defer os.Unsetenv("ENC_TEST_PASSWORD")
+	defer os.Unsetenv("ENC_TEST_USER")
+
+	original := dotEnvConfig
+	dotEnvConfig = func(path string) error { return errors.New("file not found") }
+	defer func() { dotEnvConfig = original }()
+
+	os.Setenv(GO_ENV_KEY, "staging")
+	defer os.Unsetenv(GO_ENV_KEY)
+
+	_, err := New().Build()
+	s.NoError(err)
+	s.Equal("pa=ss", os.Getenv("ENC_TEST_PASSWORD"))
+	s.Equal("user", os.Getenv("ENC_TEST_USER"))
+
+	EncryptionKeyProvider = func() ([]byte, error) { return nil, ErrorEncryptionKey }
+
+	_, err = New().Build()
+	s.ErrorIs(err, ErrorEncryptionKey)
+}
@@ -3,7 +3,6 @@ module github.com/ralvescosta/gokit/env
 go 1.18
 
 require (
-	github.com/ralvescosta/dotenv v1.0.4
 	github.com/stretchr/testify v1.8.0
 )
 
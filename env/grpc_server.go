@@ -0,0 +1,46 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+const (
+	InvalidGRPCServerErrorMessage = "[ConfigBuilder::GRPCServer] %s is invalid"
+)
+
+// GRPCServer the toggles of the grpc interceptors chain, the logging is enabled by default
+func (c *Configs) GRPCServer() IConfigs {
+	if c.Err != nil {
+		return c
+	}
+
+	c.IS_GRPC_LOGGING_ENABLED = os.Getenv(GRPC_LOGGING_ENABLED_ENV_KEY) != "false"
+	c.IS_GRPC_TRACING_ENABLED = os.Getenv(GRPC_TRACING_ENABLED_ENV_KEY) == "true"
+	c.IS_GRPC_VALIDATION_ENABLED = os.Getenv(GRPC_VALIDATION_ENABLED_ENV_KEY) == "true"
+
+	for key, dest := range map[string]*time.Duration{
+		GRPC_DEFAULT_TIMEOUT_ENV_KEY: &c.GRPC_DEFAULT_TIMEOUT,
+		GRPC_MAX_TIMEOUT_ENV_KEY:     &c.GRPC_MAX_TIMEOUT,
+	} {
+		raw := os.Getenv(key)
+		if raw == "" {
+			continue
+		}
+
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			c.Err = fmt.Errorf(InvalidGRPCServerErrorMessage, key)
+			return c
+		}
+
+		*dest = d
+	}
+
+	if c.GRPC_MAX_TIMEOUT > 0 && c.GRPC_DEFAULT_TIMEOUT > c.GRPC_MAX_TIMEOUT {
+		c.Err = fmt.Errorf(InvalidGRPCServerErrorMessage, GRPC_DEFAULT_TIMEOUT_ENV_KEY)
+	}
+
+	return c
+}
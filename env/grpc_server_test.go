@@ -0,0 +1,51 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GRPCServerTestSuite struct {
+	suite.Suite
+}
+
+func TestGRPCServerTestSuite(t *testing.T) {
+	suite.Run(t, new(GRPCServerTestSuite))
+}
+
+func (s *GRPCServerTestSuite) SetupTest() {
+	os.Setenv(GRPC_LOGGING_ENABLED_ENV_KEY, "")
+	os.Setenv(GRPC_TRACING_ENABLED_ENV_KEY, "true")
+	os.Setenv(GRPC_VALIDATION_ENABLED_ENV_KEY, "")
+	os.Setenv(GRPC_DEFAULT_TIMEOUT_ENV_KEY, "5s")
+	os.Setenv(GRPC_MAX_TIMEOUT_ENV_KEY, "30s")
+}
+
+func (s *GRPCServerTestSuite) TestGRPCServer() {
+	c := &Configs{}
+	c.GRPCServer()
+
+	s.NoError(c.Err)
+	s.True(c.IS_GRPC_LOGGING_ENABLED)
+	s.True(c.IS_GRPC_TRACING_ENABLED)
+	s.False(c.IS_GRPC_VALIDATION_ENABLED)
+	s.Equal(5*time.Second, c.GRPC_DEFAULT_TIMEOUT)
+	s.Equal(30*time.Second, c.GRPC_MAX_TIMEOUT)
+}
+
+func (s *GRPCServerTestSuite) TestGRPCServerErr() {
+	os.Setenv(GRPC_DEFAULT_TIMEOUT_ENV_KEY, "invalid")
+
+	c := &Configs{}
+	c.GRPCServer()
+	s.Error(c.Err)
+
+	os.Setenv(GRPC_DEFAULT_TIMEOUT_ENV_KEY, "1m")
+
+	c = &Configs{}
+	c.GRPCServer()
+	s.Error(c.Err)
+}
@@ -3,10 +3,15 @@ package env
 import (
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 )
 
 const (
 	RequiredHTTPServerErrorMessage = "[ConfigBuilder::HTTPServer] %s is required"
+	InvalidHTTPServerErrorMessage  = "[ConfigBuilder::HTTPServer] %s is invalid"
 )
 
 func (c *Configs) HTTPServer() IConfigs {
@@ -28,5 +33,134 @@ func (c *Configs) HTTPServer() IConfigs {
 
 	c.HTTP_ADDR = fmt.Sprintf("%s:%s", c.HTTP_HOST, c.HTTP_PORT)
 
+	c.HTTP_READ_TIMEOUT = c.getDuration(HTTP_READ_TIMEOUT_ENV_KEY)
+	c.HTTP_WRITE_TIMEOUT = c.getDuration(HTTP_WRITE_TIMEOUT_ENV_KEY)
+	c.HTTP_IDLE_TIMEOUT = c.getDuration(HTTP_IDLE_TIMEOUT_ENV_KEY)
+	c.HTTP_SHUTDOWN_TIMEOUT = c.getDuration(HTTP_SHUTDOWN_TIMEOUT_ENV_KEY)
+	c.HTTP_DRAIN_DELAY = c.getDuration(HTTP_DRAIN_DELAY_ENV_KEY)
+	c.HTTP_REQUEST_TIMEOUT = c.getDuration(HTTP_REQUEST_TIMEOUT_ENV_KEY)
+	if c.Err != nil {
+		return c
+	}
+
+	if raw := os.Getenv(HTTP_MAX_BODY_SIZE_ENV_KEY); raw != "" {
+		size, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil || size <= 0 {
+			c.Err = fmt.Errorf(InvalidHTTPServerErrorMessage, HTTP_MAX_BODY_SIZE_ENV_KEY)
+			return c
+		}
+
+		c.HTTP_MAX_BODY_SIZE = size
+	}
+
+	c.HTTP_TLS_CERT_PATH = os.Getenv(HTTP_TLS_CERT_PATH_ENV_KEY)
+	c.HTTP_TLS_KEY_PATH = os.Getenv(HTTP_TLS_KEY_PATH_ENV_KEY)
+	if (c.HTTP_TLS_CERT_PATH == "") != (c.HTTP_TLS_KEY_PATH == "") {
+		c.Err = fmt.Errorf(RequiredHTTPServerErrorMessage, HTTP_TLS_CERT_PATH_ENV_KEY+" and "+HTTP_TLS_KEY_PATH_ENV_KEY)
+		return c
+	}
+
+	c.IS_HTTP_PROFILING_ENABLED = os.Getenv(HTTP_PROFILING_ENABLED_ENV_KEY) == "true"
+	c.IS_HTTP_METRICS_ENABLED = os.Getenv(HTTP_METRICS_ENABLED_ENV_KEY) == "true"
+	c.IS_HTTP_HEALTH_ENABLED = os.Getenv(HTTP_HEALTH_ENABLED_ENV_KEY) == "true"
+	c.IS_HTTP_OPENAPI_ENABLED = os.Getenv(HTTP_OPENAPI_ENABLED_ENV_KEY) == "true"
+
+	if c.getHTTPCORSConfigs(); c.Err != nil {
+		return c
+	}
+
+	if c.getHTTPListenersConfigs(); c.Err != nil {
+		return c
+	}
+
+	c.getHTTPAdminConfigs()
+
 	return c
 }
+
+// getHTTPListenersConfigs the additional addresses, the unix socket, the h2c and the autocert domains
+func (c *Configs) getHTTPListenersConfigs() {
+	c.HTTP_ADDITIONAL_ADDRS = splitList(os.Getenv(HTTP_ADDITIONAL_ADDRS_ENV_KEY))
+	c.HTTP_UNIX_SOCKET = os.Getenv(HTTP_UNIX_SOCKET_ENV_KEY)
+	c.IS_HTTP_H2C_ENABLED = os.Getenv(HTTP_H2C_ENABLED_ENV_KEY) == "true"
+	c.HTTP_AUTOCERT_DOMAINS = splitList(os.Getenv(HTTP_AUTOCERT_DOMAINS_ENV_KEY))
+	c.HTTP_AUTOCERT_CACHE_DIR = os.Getenv(HTTP_AUTOCERT_CACHE_DIR_ENV_KEY)
+
+	if len(c.HTTP_AUTOCERT_DOMAINS) > 0 && c.HTTP_TLS_CERT_PATH != "" {
+		c.Err = fmt.Errorf("[ConfigBuilder::HTTPServer] %s can not be used with %s", HTTP_AUTOCERT_DOMAINS_ENV_KEY, HTTP_TLS_CERT_PATH_ENV_KEY)
+	}
+}
+
+// getHTTPCORSConfigs the origins could be exact, wildcard subdomains, e.g: https://*.example.com, or regex starting with ^
+func (c *Configs) getHTTPCORSConfigs() {
+	c.HTTP_CORS_ALLOWED_ORIGINS = splitList(os.Getenv(HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY))
+	c.HTTP_CORS_ALLOWED_METHODS = splitList(os.Getenv(HTTP_CORS_ALLOWED_METHODS_ENV_KEY))
+	c.HTTP_CORS_ALLOWED_HEADERS = splitList(os.Getenv(HTTP_CORS_ALLOWED_HEADERS_ENV_KEY))
+	c.HTTP_CORS_EXPOSED_HEADERS = splitList(os.Getenv(HTTP_CORS_EXPOSED_HEADERS_ENV_KEY))
+	c.HTTP_CORS_ALLOW_CREDENTIALS = os.Getenv(HTTP_CORS_ALLOW_CREDENTIALS_ENV_KEY) == "true"
+	c.HTTP_CORS_STRICT = os.Getenv(HTTP_CORS_STRICT_ENV_KEY) != "false"
+
+	if c.HTTP_CORS_MAX_AGE = c.getDuration(HTTP_CORS_MAX_AGE_ENV_KEY); c.Err != nil {
+		return
+	}
+
+	for _, origin := range c.HTTP_CORS_ALLOWED_ORIGINS {
+		if strings.HasPrefix(origin, "^") {
+			if _, err := regexp.Compile(origin); err != nil {
+				c.Err = fmt.Errorf(InvalidHTTPServerErrorMessage, HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY)
+				return
+			}
+		}
+
+		if origin == "*" && c.HTTP_CORS_ALLOW_CREDENTIALS && c.HTTP_CORS_STRICT && c.GO_ENV == PRODUCTION_ENV {
+			c.Err = fmt.Errorf("[ConfigBuilder::HTTPServer] %s can not allow * with %s in production", HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY, HTTP_CORS_ALLOW_CREDENTIALS_ENV_KEY)
+			return
+		}
+	}
+}
+
+// getHTTPAdminConfigs the admin server listen in the same host with its own port and requires basic auth credentials
+func (c *Configs) getHTTPAdminConfigs() {
+	c.IS_HTTP_ADMIN_ENABLED = os.Getenv(HTTP_ADMIN_ENABLED_ENV_KEY) == "true"
+	if !c.IS_HTTP_ADMIN_ENABLED {
+		return
+	}
+
+	port := os.Getenv(HTTP_ADMIN_PORT_ENV_KEY)
+	if port == "" {
+		port = DEFAULT_HTTP_ADMIN_PORT
+	}
+
+	c.HTTP_ADMIN_ADDR = fmt.Sprintf("%s:%s", c.HTTP_HOST, port)
+
+	c.HTTP_ADMIN_USER = os.Getenv(HTTP_ADMIN_USER_ENV_KEY)
+	c.HTTP_ADMIN_PASSWORD = os.Getenv(HTTP_ADMIN_PASSWORD_ENV_KEY)
+	if c.HTTP_ADMIN_USER == "" || c.HTTP_ADMIN_PASSWORD == "" {
+		c.Err = fmt.Errorf(RequiredHTTPServerErrorMessage, HTTP_ADMIN_USER_ENV_KEY+" and "+HTTP_ADMIN_PASSWORD_ENV_KEY)
+	}
+}
+
+// splitList the comma separated values without spaces
+func splitList(raw string) []string {
+	if raw == "" {
+		return nil
+	}
+
+	return strings.Split(strings.ReplaceAll(raw, " ", ""), ",")
+}
+
+// getDuration parse an optional duration env, e.g: 5s, 1m
+func (c *Configs) getDuration(key string) time.Duration {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		c.Err = fmt.Errorf(InvalidHTTPServerErrorMessage, key)
+		return 0
+	}
+
+	return d
+}
@@ -0,0 +1,173 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HTTPServerTestSuite struct {
+	suite.Suite
+}
+
+func TestHTTPServerTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPServerTestSuite))
+}
+
+func (s *HTTPServerTestSuite) SetupTest() {
+	os.Setenv(HTTP_PORT_ENV_KEY, "3000")
+	os.Setenv(HTTP_HOST_ENV_KEY, "0.0.0.0")
+	os.Setenv(HTTP_READ_TIMEOUT_ENV_KEY, "")
+	os.Setenv(HTTP_TLS_CERT_PATH_ENV_KEY, "")
+	os.Setenv(HTTP_TLS_KEY_PATH_ENV_KEY, "")
+}
+
+func (s *HTTPServerTestSuite) TestHTTPServer() {
+	os.Setenv(HTTP_READ_TIMEOUT_ENV_KEY, "2s")
+	os.Setenv(HTTP_PROFILING_ENABLED_ENV_KEY, "true")
+	os.Setenv(HTTP_OPENAPI_ENABLED_ENV_KEY, "true")
+	os.Setenv(HTTP_DRAIN_DELAY_ENV_KEY, "5s")
+	defer os.Unsetenv(HTTP_DRAIN_DELAY_ENV_KEY)
+	os.Setenv(HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY, "http://a.com, http://b.com")
+
+	c := &Configs{}
+	c.HTTPServer()
+
+	s.NoError(c.Err)
+	s.Equal("0.0.0.0:3000", c.HTTP_ADDR)
+	s.Equal(2*time.Second, c.HTTP_READ_TIMEOUT)
+	s.True(c.IS_HTTP_PROFILING_ENABLED)
+	s.True(c.IS_HTTP_OPENAPI_ENABLED)
+	s.Equal(5*time.Second, c.HTTP_DRAIN_DELAY)
+	s.Equal([]string{"http://a.com", "http://b.com"}, c.HTTP_CORS_ALLOWED_ORIGINS)
+}
+
+func (s *HTTPServerTestSuite) TestHTTPServerErr() {
+	os.Setenv(HTTP_READ_TIMEOUT_ENV_KEY, "invalid")
+
+	c := &Configs{}
+	c.HTTPServer()
+	s.Error(c.Err)
+
+	os.Setenv(HTTP_READ_TIMEOUT_ENV_KEY, "")
+	os.Setenv(HTTP_TLS_CERT_PATH_ENV_KEY, "cert.pem")
+
+	c = &Configs{}
+	c.HTTPServer()
+	s.Error(c.Err)
+
+	os.Setenv(HTTP_PORT_ENV_KEY, "")
+
+	c = &Configs{}
+	c.HTTPServer()
+	s.Error(c.Err)
+}
+
+func (s *HTTPServerTestSuite) TestHTTPAdmin() {
+	os.Setenv(HTTP_ADMIN_ENABLED_ENV_KEY, "true")
+	defer os.Unsetenv(HTTP_ADMIN_ENABLED_ENV_KEY)
+
+	c := &Configs{}
+	c.HTTPServer()
+	s.Error(c.Err)
+
+	os.Setenv(HTTP_ADMIN_USER_ENV_KEY, "admin")
+	os.Setenv(HTTP_ADMIN_PASSWORD_ENV_KEY, "secret")
+	defer os.Unsetenv(HTTP_ADMIN_USER_ENV_KEY)
+	defer os.Unsetenv(HTTP_ADMIN_PASSWORD_ENV_KEY)
+
+	c = &Configs{}
+	c.HTTPServer()
+
+	s.NoError(c.Err)
+	s.True(c.IS_HTTP_ADMIN_ENABLED)
+	s.Equal("0.0.0.0:9090", c.HTTP_ADMIN_ADDR)
+}
+
+func (s *HTTPServerTestSuite) TestHTTPCORS() {
+	os.Setenv(HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY, "*")
+	os.Setenv(HTTP_CORS_ALLOWED_METHODS_ENV_KEY, "GET, POST")
+	os.Setenv(HTTP_CORS_ALLOW_CREDENTIALS_ENV_KEY, "true")
+	os.Setenv(HTTP_CORS_MAX_AGE_ENV_KEY, "10m")
+	defer os.Unsetenv(HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY)
+	defer os.Unsetenv(HTTP_CORS_ALLOWED_METHODS_ENV_KEY)
+	defer os.Unsetenv(HTTP_CORS_ALLOW_CREDENTIALS_ENV_KEY)
+	defer os.Unsetenv(HTTP_CORS_MAX_AGE_ENV_KEY)
+
+	c := &Configs{GO_ENV: DEVELOPMENT_ENV}
+	c.HTTPServer()
+
+	s.NoError(c.Err)
+	s.Equal([]string{"GET", "POST"}, c.HTTP_CORS_ALLOWED_METHODS)
+	s.True(c.HTTP_CORS_ALLOW_CREDENTIALS)
+	s.True(c.HTTP_CORS_STRICT)
+	s.Equal(10*time.Minute, c.HTTP_CORS_MAX_AGE)
+
+	c = &Configs{GO_ENV: PRODUCTION_ENV}
+	c.HTTPServer()
+	s.Error(c.Err)
+
+	os.Setenv(HTTP_CORS_STRICT_ENV_KEY, "false")
+	defer os.Unsetenv(HTTP_CORS_STRICT_ENV_KEY)
+
+	c = &Configs{GO_ENV: PRODUCTION_ENV}
+	c.HTTPServer()
+	s.NoError(c.Err)
+
+	os.Setenv(HTTP_CORS_ALLOWED_ORIGINS_ENV_KEY, "^https://(.example.com")
+
+	c = &Configs{GO_ENV: DEVELOPMENT_ENV}
+	c.HTTPServer()
+	s.Error(c.Err)
+}
+
+func (s *HTTPServerTestSuite) TestHTTPListeners() {
+	os.Setenv(HTTP_ADDITIONAL_ADDRS_ENV_KEY, "0.0.0.0:8443, 0.0.0.0:8444")
+	os.Setenv(HTTP_UNIX_SOCKET_ENV_KEY, "/var/run/app.sock")
+	os.Setenv(HTTP_H2C_ENABLED_ENV_KEY, "true")
+	os.Setenv(HTTP_AUTOCERT_DOMAINS_ENV_KEY, "api.example.com")
+	defer os.Unsetenv(HTTP_ADDITIONAL_ADDRS_ENV_KEY)
+	defer os.Unsetenv(HTTP_UNIX_SOCKET_ENV_KEY)
+	defer os.Unsetenv(HTTP_H2C_ENABLED_ENV_KEY)
+	defer os.Unsetenv(HTTP_AUTOCERT_DOMAINS_ENV_KEY)
+
+	c := &Configs{}
+	c.HTTPServer()
+
+	s.NoError(c.Err)
+	s.Equal([]string{"0.0.0.0:8443", "0.0.0.0:8444"}, c.HTTP_ADDITIONAL_ADDRS)
+	s.Equal("/var/run/app.sock", c.HTTP_UNIX_SOCKET)
+	s.True(c.IS_HTTP_H2C_ENABLED)
+	s.Equal([]string{"api.example.com"}, c.HTTP_AUTOCERT_DOMAINS)
+
+	os.Setenv(HTTP_TLS_CERT_PATH_ENV_KEY, "cert.pem")
+	os.Setenv(HTTP_TLS_KEY_PATH_ENV_KEY, "key.pem")
+	defer os.Unsetenv(HTTP_TLS_CERT_PATH_ENV_KEY)
+	defer os.Unsetenv(HTTP_TLS_KEY_PATH_ENV_KEY)
+
+	c = &Configs{}
+	c.HTTPServer()
+	s.Error(c.Err)
+}
+
+func (s *HTTPServerTestSuite) TestHTTPRequestLimits() {
+	os.Setenv(HTTP_REQUEST_TIMEOUT_ENV_KEY, "10s")
+	os.Setenv(HTTP_MAX_BODY_SIZE_ENV_KEY, "1048576")
+	defer os.Unsetenv(HTTP_REQUEST_TIMEOUT_ENV_KEY)
+	defer os.Unsetenv(HTTP_MAX_BODY_SIZE_ENV_KEY)
+
+	c := &Configs{}
+	c.HTTPServer()
+
+	s.NoError(c.Err)
+	s.Equal(10*time.Second, c.HTTP_REQUEST_TIMEOUT)
+	s.Equal(int64(1048576), c.HTTP_MAX_BODY_SIZE)
+
+	os.Setenv(HTTP_MAX_BODY_SIZE_ENV_KEY, "1MB")
+
+	c = &Configs{}
+	c.HTTPServer()
+	s.Error(c.Err)
+}
@@ -0,0 +1,62 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+)
+
+const (
+	RequiredMailerErrorMessage = "[ConfigBuilder::Mailer] %s is required"
+	InvalidMailerErrorMessage  = "[ConfigBuilder::Mailer] %s is invalid"
+)
+
+func (c *Configs) Mailer() IConfigs {
+	if c.Err != nil {
+		return c
+	}
+
+	c.MAILER_PROVIDER = os.Getenv(MAILER_PROVIDER_ENV_KEY)
+	if c.MAILER_PROVIDER == "" {
+		c.MAILER_PROVIDER = SMTP_MAILER_PROVIDER
+	}
+
+	c.MAILER_FROM = os.Getenv(MAILER_FROM_ENV_KEY)
+	if c.MAILER_FROM == "" {
+		c.Err = fmt.Errorf(RequiredMailerErrorMessage, MAILER_FROM_ENV_KEY)
+		return c
+	}
+
+	switch c.MAILER_PROVIDER {
+	case SMTP_MAILER_PROVIDER:
+		c.smtp()
+	case SES_MAILER_PROVIDER:
+		// the region could be omitted when it is defined by the aws shared config
+		c.SES_REGION = os.Getenv(SES_REGION_ENV_KEY)
+	default:
+		c.Err = fmt.Errorf(InvalidMailerErrorMessage, MAILER_PROVIDER_ENV_KEY)
+	}
+
+	return c
+}
+
+func (c *Configs) smtp() {
+	c.SMTP_HOST = os.Getenv(SMTP_HOST_ENV_KEY)
+	if c.SMTP_HOST == "" {
+		c.Err = fmt.Errorf(RequiredMailerErrorMessage, SMTP_HOST_ENV_KEY)
+		return
+	}
+
+	c.SMTP_PORT = DEFAULT_SMTP_PORT
+	if raw := os.Getenv(SMTP_PORT_ENV_KEY); raw != "" {
+		port, err := strconv.Atoi(raw)
+		if err != nil || port <= 0 {
+			c.Err = fmt.Errorf(InvalidMailerErrorMessage, SMTP_PORT_ENV_KEY)
+			return
+		}
+		c.SMTP_PORT = port
+	}
+
+	c.SMTP_USERNAME = os.Getenv(SMTP_USERNAME_ENV_KEY)
+	c.SMTP_PASSWORD = os.Getenv(SMTP_PASSWORD_ENV_KEY)
+}
@@ -0,0 +1,65 @@
+package env
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MailerTestSuite struct {
+	suite.Suite
+}
+
+func TestMailerTestSuite(t *testing.T) {
+	suite.Run(t, new(MailerTestSuite))
+}
+
+func (s *MailerTestSuite) SetupTest() {
+	os.Setenv(MAILER_PROVIDER_ENV_KEY, "")
+	os.Setenv(MAILER_FROM_ENV_KEY, "no-reply@gokit.com")
+	os.Setenv(SMTP_HOST_ENV_KEY, "localhost")
+	os.Setenv(SMTP_PORT_ENV_KEY, "")
+}
+
+func (s *MailerTestSuite) TestMailer() {
+	c := &Configs{}
+	c.Mailer()
+
+	s.NoError(c.Err)
+	s.Equal(SMTP_MAILER_PROVIDER, c.MAILER_PROVIDER)
+	s.Equal(DEFAULT_SMTP_PORT, c.SMTP_PORT)
+
+	os.Setenv(MAILER_PROVIDER_ENV_KEY, SES_MAILER_PROVIDER)
+	os.Setenv(SMTP_HOST_ENV_KEY, "")
+	os.Setenv(SES_REGION_ENV_KEY, "us-east-1")
+
+	c = &Configs{}
+	c.Mailer()
+
+	s.NoError(c.Err)
+	s.Equal("us-east-1", c.SES_REGION)
+}
+
+func (s *MailerTestSuite) TestMailerErr() {
+	os.Setenv(SMTP_PORT_ENV_KEY, "port")
+	c := &Configs{}
+	c.Mailer()
+	s.Error(c.Err)
+
+	os.Setenv(SMTP_HOST_ENV_KEY, "")
+	c = &Configs{}
+	c.Mailer()
+	s.Error(c.Err)
+
+	os.Setenv(MAILER_FROM_ENV_KEY, "")
+	c = &Configs{}
+	c.Mailer()
+	s.Error(c.Err)
+
+	os.Setenv(MAILER_FROM_ENV_KEY, "no-reply@gokit.com")
+	os.Setenv(MAILER_PROVIDER_ENV_KEY, "sendgrid")
+	c = &Configs{}
+	c.Mailer()
+	s.Error(c.Err)
+}
@@ -4,11 +4,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 )
 
 const (
 	RequiredMessagingErrorMessage = "[ConfigBuilder::Messaging] %s is required"
+	InvalidMessagingErrorMessage  = "[ConfigBuilder::Messaging] %s is invalid"
 )
 
 func (c *Configs) Messaging() IConfigs {
@@ -31,6 +33,11 @@ func (c *Configs) Messaging() IConfigs {
 		return c
 	}
 
+	c.getAzureServiceBusConfigs()
+	if c.Err != nil {
+		return c
+	}
+
 	return c
 }
 
@@ -49,6 +56,8 @@ func (c *Configs) getEngines() {
 			result[RABBITMQ_ENGINE] = true
 		case KAFKA_ENGINE:
 			result[KAFKA_ENGINE] = true
+		case AZURE_SERVICE_BUS_ENGINE:
+			result[AZURE_SERVICE_BUS_ENGINE] = true
 		default:
 			c.Err = errors.New("[ConfigBuilder::Messaging] invalid engine")
 			return
@@ -90,6 +99,8 @@ func (c *Configs) getRabbitMQConfigs() {
 	if c.RABBIT_VHOST == "" {
 		c.Err = fmt.Errorf(RequiredMessagingErrorMessage, RABBIT_VHOST_ENV_KEY)
 	}
+
+	c.RABBIT_MANAGEMENT_URL = os.Getenv(RABBIT_MANAGEMENT_URL_ENV_KEY)
 }
 
 func (c *Configs) getKafkaConfigs() {
@@ -97,3 +108,33 @@ func (c *Configs) getKafkaConfigs() {
 		return
 	}
 }
+
+func (c *Configs) getAzureServiceBusConfigs() {
+	if _, ok := c.MESSAGING_ENGINES[AZURE_SERVICE_BUS_ENGINE]; !ok {
+		return
+	}
+
+	c.AZURE_SERVICE_BUS_CONNECTION_STRING = os.Getenv(AZURE_SERVICE_BUS_CONNECTION_STRING_ENV_KEY)
+	if c.AZURE_SERVICE_BUS_CONNECTION_STRING == "" {
+		c.Err = fmt.Errorf(RequiredMessagingErrorMessage, AZURE_SERVICE_BUS_CONNECTION_STRING_ENV_KEY)
+		return
+	}
+
+	for key, value := range map[string]*int{
+		AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT_ENV_KEY: &c.AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT,
+		AZURE_SERVICE_BUS_MAX_MESSAGES_ENV_KEY:       &c.AZURE_SERVICE_BUS_MAX_MESSAGES,
+	} {
+		raw := os.Getenv(key)
+		if raw == "" {
+			continue
+		}
+
+		v, err := strconv.Atoi(raw)
+		if err != nil || v <= 0 {
+			c.Err = fmt.Errorf(InvalidMessagingErrorMessage, key)
+			return
+		}
+
+		*value = v
+	}
+}
@@ -30,10 +30,12 @@ func (s *MessagingTestSuite) TestMessaging() {
 	os.Setenv(RABBIT_USER_ENV_KEY, "user")
 	os.Setenv(RABBIT_PASSWORD_ENV_KEY, "password")
 	os.Setenv(RABBIT_VHOST_ENV_KEY, "/")
+	os.Setenv(RABBIT_MANAGEMENT_URL_ENV_KEY, "http://localhost:15672")
 
 	c.Messaging()
 
 	s.NoError(c.Err)
+	s.Equal("http://localhost:15672", c.RABBIT_MANAGEMENT_URL)
 }
 
 func (s *MessagingTestSuite) TestMessagingErr() {
@@ -156,3 +158,28 @@ func (s *MessagingTestSuite) TestTetKafkaConfigs() {
 
 	s.Equal(c.KAFKA_HOST, "")
 }
+
+func (s *MessagingTestSuite) TestGetAzureServiceBusConfigs() {
+	c := &Configs{MESSAGING_ENGINES: map[string]bool{AZURE_SERVICE_BUS_ENGINE: true}}
+	os.Setenv(AZURE_SERVICE_BUS_CONNECTION_STRING_ENV_KEY, "")
+
+	c.getAzureServiceBusConfigs()
+	s.Error(c.Err)
+
+	c.Err = nil
+	os.Setenv(AZURE_SERVICE_BUS_CONNECTION_STRING_ENV_KEY, "Endpoint=sb://ns.servicebus.windows.net/")
+	os.Setenv(AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT_ENV_KEY, "invalid")
+	defer os.Unsetenv(AZURE_SERVICE_BUS_CONNECTION_STRING_ENV_KEY)
+	defer os.Unsetenv(AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT_ENV_KEY)
+
+	c.getAzureServiceBusConfigs()
+	s.Error(c.Err)
+
+	c.Err = nil
+	os.Setenv(AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT_ENV_KEY, "5")
+
+	c.getAzureServiceBusConfigs()
+	s.NoError(c.Err)
+	s.Equal("Endpoint=sb://ns.servicebus.windows.net/", c.AZURE_SERVICE_BUS_CONNECTION_STRING)
+	s.Equal(5, c.AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT)
+}
@@ -0,0 +1,82 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	RequiredProfilingErrorMessage = "[ConfigBuilder::Profiling] %s is required"
+	InvalidProfilingErrorMessage  = "[ConfigBuilder::Profiling] %s is invalid"
+)
+
+var profileTypes = map[string]bool{
+	CPU_PROFILE:       true,
+	HEAP_PROFILE:      true,
+	ALLOCS_PROFILE:    true,
+	GOROUTINE_PROFILE: true,
+	MUTEX_PROFILE:     true,
+	BLOCK_PROFILE:     true,
+}
+
+// Profiling the continuous profiler is disabled by default, when it is enabled the server url is required
+func (c *Configs) Profiling() IConfigs {
+	if c.Err != nil {
+		return c
+	}
+
+	c.IS_PROFILING_ENABLED = os.Getenv(PROFILING_ENABLED_ENV_KEY) == "true"
+	if !c.IS_PROFILING_ENABLED {
+		return c
+	}
+
+	c.PROFILING_SERVER_URL = os.Getenv(PROFILING_SERVER_URL_ENV_KEY)
+	if c.PROFILING_SERVER_URL == "" {
+		c.Err = fmt.Errorf(RequiredProfilingErrorMessage, PROFILING_SERVER_URL_ENV_KEY)
+		return c
+	}
+
+	c.PROFILING_AUTH_TOKEN = os.Getenv(PROFILING_AUTH_TOKEN_ENV_KEY)
+
+	c.PROFILING_UPLOAD_INTERVAL = DEFAULT_PROFILING_UPLOAD_INTERVAL
+	if raw := os.Getenv(PROFILING_UPLOAD_INTERVAL_ENV_KEY); raw != "" {
+		interval, err := time.ParseDuration(raw)
+		if err != nil || interval < time.Second {
+			c.Err = fmt.Errorf(InvalidProfilingErrorMessage, PROFILING_UPLOAD_INTERVAL_ENV_KEY)
+			return c
+		}
+		c.PROFILING_UPLOAD_INTERVAL = interval
+	}
+
+	c.PROFILING_TYPES = []string{CPU_PROFILE, HEAP_PROFILE}
+	if raw := os.Getenv(PROFILING_TYPES_ENV_KEY); raw != "" {
+		c.PROFILING_TYPES = []string{}
+		for _, t := range strings.Split(raw, ",") {
+			t = strings.TrimSpace(t)
+			if !profileTypes[t] {
+				c.Err = fmt.Errorf(InvalidProfilingErrorMessage, PROFILING_TYPES_ENV_KEY)
+				return c
+			}
+			c.PROFILING_TYPES = append(c.PROFILING_TYPES, t)
+		}
+	}
+
+	c.PROFILING_TAGS = map[string]string{}
+	for _, tag := range strings.Split(os.Getenv(PROFILING_TAGS_ENV_KEY), ",") {
+		if strings.TrimSpace(tag) == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(tag, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			c.Err = fmt.Errorf(InvalidProfilingErrorMessage, PROFILING_TAGS_ENV_KEY)
+			return c
+		}
+
+		c.PROFILING_TAGS[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	return c
+}
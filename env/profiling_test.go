@@ -0,0 +1,81 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfilingTestSuite struct {
+	suite.Suite
+}
+
+func TestProfilingTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfilingTestSuite))
+}
+
+func (s *ProfilingTestSuite) SetupTest() {
+	os.Setenv(PROFILING_ENABLED_ENV_KEY, "true")
+	os.Setenv(PROFILING_SERVER_URL_ENV_KEY, "http://pyroscope:4040")
+	os.Setenv(PROFILING_UPLOAD_INTERVAL_ENV_KEY, "")
+	os.Setenv(PROFILING_TYPES_ENV_KEY, "")
+	os.Setenv(PROFILING_TAGS_ENV_KEY, "")
+}
+
+func (s *ProfilingTestSuite) TearDownTest() {
+	os.Unsetenv(PROFILING_ENABLED_ENV_KEY)
+	os.Unsetenv(PROFILING_SERVER_URL_ENV_KEY)
+}
+
+func (s *ProfilingTestSuite) TestProfiling() {
+	c := &Configs{}
+	c.Profiling()
+
+	s.NoError(c.Err)
+	s.True(c.IS_PROFILING_ENABLED)
+	s.Equal("http://pyroscope:4040", c.PROFILING_SERVER_URL)
+	s.Equal(DEFAULT_PROFILING_UPLOAD_INTERVAL, c.PROFILING_UPLOAD_INTERVAL)
+	s.Equal([]string{CPU_PROFILE, HEAP_PROFILE}, c.PROFILING_TYPES)
+	s.Empty(c.PROFILING_TAGS)
+
+	os.Setenv(PROFILING_UPLOAD_INTERVAL_ENV_KEY, "1m")
+	os.Setenv(PROFILING_TYPES_ENV_KEY, "cpu, goroutine,mutex")
+	os.Setenv(PROFILING_TAGS_ENV_KEY, "region=us-east-1, team=payments")
+
+	c = &Configs{}
+	c.Profiling()
+
+	s.NoError(c.Err)
+	s.Equal(time.Minute, c.PROFILING_UPLOAD_INTERVAL)
+	s.Equal([]string{CPU_PROFILE, GOROUTINE_PROFILE, MUTEX_PROFILE}, c.PROFILING_TYPES)
+	s.Equal(map[string]string{"region": "us-east-1", "team": "payments"}, c.PROFILING_TAGS)
+}
+
+func (s *ProfilingTestSuite) TestProfilingDisabled() {
+	os.Setenv(PROFILING_ENABLED_ENV_KEY, "")
+	os.Setenv(PROFILING_SERVER_URL_ENV_KEY, "")
+
+	c := &Configs{}
+	c.Profiling()
+
+	s.NoError(c.Err)
+	s.False(c.IS_PROFILING_ENABLED)
+}
+
+func (s *ProfilingTestSuite) TestProfilingErr() {
+	for key, value := range map[string]string{
+		PROFILING_SERVER_URL_ENV_KEY:      "",
+		PROFILING_UPLOAD_INTERVAL_ENV_KEY: "100ms",
+		PROFILING_TYPES_ENV_KEY:           "cpu,threads",
+		PROFILING_TAGS_ENV_KEY:            "region",
+	} {
+		s.SetupTest()
+		os.Setenv(key, value)
+
+		c := &Configs{}
+		c.Profiling()
+		s.Error(c.Err, key)
+	}
+}
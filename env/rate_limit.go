@@ -0,0 +1,60 @@
+package env
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+const (
+	RequiredRateLimitErrorMessage = "[ConfigBuilder::RateLimit] %s is required"
+	InvalidRateLimitErrorMessage  = "[ConfigBuilder::RateLimit] %s is invalid"
+)
+
+func (c *Configs) RateLimit() IConfigs {
+	if c.Err != nil {
+		return c
+	}
+
+	c.RATE_LIMIT_ALGORITHM = os.Getenv(RATE_LIMIT_ALGORITHM_ENV_KEY)
+	if c.RATE_LIMIT_ALGORITHM == "" {
+		c.RATE_LIMIT_ALGORITHM = DEFAULT_RATE_LIMIT_ALGORITHM
+	}
+
+	raw := os.Getenv(RATE_LIMIT_LIMIT_ENV_KEY)
+	if raw == "" {
+		c.Err = fmt.Errorf(RequiredRateLimitErrorMessage, RATE_LIMIT_LIMIT_ENV_KEY)
+		return c
+	}
+
+	limit, err := strconv.Atoi(raw)
+	if err != nil || limit <= 0 {
+		c.Err = fmt.Errorf(InvalidRateLimitErrorMessage, RATE_LIMIT_LIMIT_ENV_KEY)
+		return c
+	}
+	c.RATE_LIMIT_LIMIT = limit
+
+	c.RATE_LIMIT_WINDOW = DEFAULT_RATE_LIMIT_WINDOW
+	if raw := os.Getenv(RATE_LIMIT_WINDOW_ENV_KEY); raw != "" {
+		window, err := time.ParseDuration(raw)
+		if err != nil || window <= 0 {
+			c.Err = fmt.Errorf(InvalidRateLimitErrorMessage, RATE_LIMIT_WINDOW_ENV_KEY)
+			return c
+		}
+		c.RATE_LIMIT_WINDOW = window
+	}
+
+	// the burst is the token bucket capacity, by default the same as the limit
+	c.RATE_LIMIT_BURST = limit
+	if raw := os.Getenv(RATE_LIMIT_BURST_ENV_KEY); raw != "" {
+		burst, err := strconv.Atoi(raw)
+		if err != nil || burst <= 0 {
+			c.Err = fmt.Errorf(InvalidRateLimitErrorMessage, RATE_LIMIT_BURST_ENV_KEY)
+			return c
+		}
+		c.RATE_LIMIT_BURST = burst
+	}
+
+	return c
+}
@@ -0,0 +1,63 @@
+package env
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (s *RateLimitTestSuite) SetupTest() {
+	os.Setenv(RATE_LIMIT_ALGORITHM_ENV_KEY, "")
+	os.Setenv(RATE_LIMIT_LIMIT_ENV_KEY, "10")
+	os.Setenv(RATE_LIMIT_WINDOW_ENV_KEY, "")
+	os.Setenv(RATE_LIMIT_BURST_ENV_KEY, "")
+}
+
+func (s *RateLimitTestSuite) TestRateLimit() {
+	c := &Configs{}
+	c.RateLimit()
+
+	s.NoError(c.Err)
+	s.Equal(DEFAULT_RATE_LIMIT_ALGORITHM, c.RATE_LIMIT_ALGORITHM)
+	s.Equal(10, c.RATE_LIMIT_LIMIT)
+	s.Equal(time.Second, c.RATE_LIMIT_WINDOW)
+	s.Equal(10, c.RATE_LIMIT_BURST)
+
+	os.Setenv(RATE_LIMIT_WINDOW_ENV_KEY, "1m")
+	os.Setenv(RATE_LIMIT_BURST_ENV_KEY, "20")
+
+	c = &Configs{}
+	c.RateLimit()
+
+	s.NoError(c.Err)
+	s.Equal(time.Minute, c.RATE_LIMIT_WINDOW)
+	s.Equal(20, c.RATE_LIMIT_BURST)
+}
+
+func (s *RateLimitTestSuite) TestRateLimitErr() {
+	os.Setenv(RATE_LIMIT_LIMIT_ENV_KEY, "")
+	c := &Configs{}
+	c.RateLimit()
+	s.Error(c.Err)
+
+	os.Setenv(RATE_LIMIT_LIMIT_ENV_KEY, "-1")
+	c = &Configs{}
+	c.RateLimit()
+	s.Error(c.Err)
+
+	os.Setenv(RATE_LIMIT_LIMIT_ENV_KEY, "10")
+	os.Setenv(RATE_LIMIT_WINDOW_ENV_KEY, "invalid")
+	c = &Configs{}
+	c.RateLimit()
+	s.Error(c.Err)
+}
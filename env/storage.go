@@ -0,0 +1,64 @@
+package env
+
+import (
+	"fmt"
+	"os"
+)
+
+const (
+	RequiredStorageErrorMessage = "[ConfigBuilder::Storage] %s is required"
+	InvalidStorageErrorMessage  = "[ConfigBuilder::Storage] %s is invalid"
+)
+
+var storageProviders = map[string]bool{
+	S3_STORAGE_PROVIDER:    true,
+	GCS_STORAGE_PROVIDER:   true,
+	MINIO_STORAGE_PROVIDER: true,
+}
+
+func (c *Configs) Storage() IConfigs {
+	if c.Err != nil {
+		return c
+	}
+
+	c.STORAGE_PROVIDER = os.Getenv(STORAGE_PROVIDER_ENV_KEY)
+	if c.STORAGE_PROVIDER == "" {
+		c.STORAGE_PROVIDER = S3_STORAGE_PROVIDER
+	}
+
+	if !storageProviders[c.STORAGE_PROVIDER] {
+		c.Err = fmt.Errorf(InvalidStorageErrorMessage, STORAGE_PROVIDER_ENV_KEY)
+		return c
+	}
+
+	// the s3 and gcs endpoints are known, minio requires the endpoint
+	c.STORAGE_ENDPOINT = os.Getenv(STORAGE_ENDPOINT_ENV_KEY)
+	if c.STORAGE_ENDPOINT == "" && c.STORAGE_PROVIDER == MINIO_STORAGE_PROVIDER {
+		c.Err = fmt.Errorf(RequiredStorageErrorMessage, STORAGE_ENDPOINT_ENV_KEY)
+		return c
+	}
+
+	c.STORAGE_REGION = os.Getenv(STORAGE_REGION_ENV_KEY)
+
+	c.STORAGE_ACCESS_KEY = os.Getenv(STORAGE_ACCESS_KEY_ENV_KEY)
+	if c.STORAGE_ACCESS_KEY == "" {
+		c.Err = fmt.Errorf(RequiredStorageErrorMessage, STORAGE_ACCESS_KEY_ENV_KEY)
+		return c
+	}
+
+	c.STORAGE_SECRET_KEY = os.Getenv(STORAGE_SECRET_KEY_ENV_KEY)
+	if c.STORAGE_SECRET_KEY == "" {
+		c.Err = fmt.Errorf(RequiredStorageErrorMessage, STORAGE_SECRET_KEY_ENV_KEY)
+		return c
+	}
+
+	c.STORAGE_BUCKET = os.Getenv(STORAGE_BUCKET_ENV_KEY)
+	if c.STORAGE_BUCKET == "" {
+		c.Err = fmt.Errorf(RequiredStorageErrorMessage, STORAGE_BUCKET_ENV_KEY)
+		return c
+	}
+
+	c.IS_STORAGE_SSL_ENABLED = os.Getenv(STORAGE_SSL_ENABLED_ENV_KEY) != "false"
+
+	return c
+}
@@ -0,0 +1,63 @@
+package env
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageTestSuite struct {
+	suite.Suite
+}
+
+func TestStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(StorageTestSuite))
+}
+
+func (s *StorageTestSuite) SetupTest() {
+	os.Setenv(STORAGE_PROVIDER_ENV_KEY, "")
+	os.Setenv(STORAGE_ENDPOINT_ENV_KEY, "")
+	os.Setenv(STORAGE_ACCESS_KEY_ENV_KEY, "access")
+	os.Setenv(STORAGE_SECRET_KEY_ENV_KEY, "secret")
+	os.Setenv(STORAGE_BUCKET_ENV_KEY, "bucket")
+	os.Setenv(STORAGE_SSL_ENABLED_ENV_KEY, "")
+}
+
+func (s *StorageTestSuite) TestStorage() {
+	c := &Configs{}
+	c.Storage()
+
+	s.NoError(c.Err)
+	s.Equal(S3_STORAGE_PROVIDER, c.STORAGE_PROVIDER)
+	s.Equal("bucket", c.STORAGE_BUCKET)
+	s.True(c.IS_STORAGE_SSL_ENABLED)
+
+	os.Setenv(STORAGE_PROVIDER_ENV_KEY, MINIO_STORAGE_PROVIDER)
+	os.Setenv(STORAGE_ENDPOINT_ENV_KEY, "localhost:9000")
+	os.Setenv(STORAGE_SSL_ENABLED_ENV_KEY, "false")
+
+	c = &Configs{}
+	c.Storage()
+
+	s.NoError(c.Err)
+	s.False(c.IS_STORAGE_SSL_ENABLED)
+}
+
+func (s *StorageTestSuite) TestStorageErr() {
+	os.Setenv(STORAGE_PROVIDER_ENV_KEY, "azure")
+	c := &Configs{}
+	c.Storage()
+	s.Error(c.Err)
+
+	os.Setenv(STORAGE_PROVIDER_ENV_KEY, MINIO_STORAGE_PROVIDER)
+	c = &Configs{}
+	c.Storage()
+	s.Error(c.Err)
+
+	os.Setenv(STORAGE_PROVIDER_ENV_KEY, "")
+	os.Setenv(STORAGE_BUCKET_ENV_KEY, "")
+	c = &Configs{}
+	c.Storage()
+	s.Error(c.Err)
+}
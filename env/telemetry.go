@@ -3,10 +3,13 @@ package env
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 )
 
 const (
 	RequiredTelemetryErrorMessage = "[ConfigBuilder::Telemetry] %s is required"
+	InvalidTelemetryErrorMessage  = "[ConfigBuilder::Telemetry] %s is invalid"
 )
 
 func (c *Configs) Tracing() IConfigs {
@@ -32,5 +35,64 @@ func (c *Configs) Tracing() IConfigs {
 
 	c.OTLP_API_KEY = os.Getenv(OTLP_API_KEY_ENV_KEY)
 
+	c.getSamplerConfigs()
+
 	return c
 }
+
+// getSamplerConfigs the default sampler is the parent based always on
+func (c *Configs) getSamplerConfigs() {
+	c.TRACING_SAMPLER = os.Getenv(TRACING_SAMPLER_ENV_KEY)
+	switch c.TRACING_SAMPLER {
+	case "":
+		c.TRACING_SAMPLER = ALWAYS_ON_SAMPLER
+	case ALWAYS_ON_SAMPLER, ALWAYS_OFF_SAMPLER, RATIO_SAMPLER, RATE_LIMITING_SAMPLER:
+	default:
+		c.Err = fmt.Errorf(InvalidTelemetryErrorMessage, TRACING_SAMPLER_ENV_KEY)
+		return
+	}
+
+	c.TRACING_SAMPLER_PARENT_BASED = os.Getenv(TRACING_SAMPLER_PARENT_BASED_ENV_KEY) != "false"
+	c.TRACING_SAMPLER_TAIL_HINT = os.Getenv(TRACING_SAMPLER_TAIL_HINT_ENV_KEY) == "true"
+
+	c.TRACING_SAMPLER_RATIO = 1
+	if ratio := os.Getenv(TRACING_SAMPLER_RATIO_ENV_KEY); ratio != "" {
+		parsed, err := strconv.ParseFloat(ratio, 64)
+		if err != nil || parsed < 0 || parsed > 1 {
+			c.Err = fmt.Errorf(InvalidTelemetryErrorMessage, TRACING_SAMPLER_RATIO_ENV_KEY)
+			return
+		}
+		c.TRACING_SAMPLER_RATIO = parsed
+	}
+
+	c.TRACING_SAMPLER_RATE = DEFAULT_TRACING_SAMPLER_RATE
+	if rate := os.Getenv(TRACING_SAMPLER_RATE_ENV_KEY); rate != "" {
+		parsed, err := strconv.ParseFloat(rate, 64)
+		if err != nil || parsed <= 0 {
+			c.Err = fmt.Errorf(InvalidTelemetryErrorMessage, TRACING_SAMPLER_RATE_ENV_KEY)
+			return
+		}
+		c.TRACING_SAMPLER_RATE = parsed
+	}
+
+	c.TRACING_SAMPLER_OVERRIDES = map[string]float64{}
+	for _, override := range strings.Split(os.Getenv(TRACING_SAMPLER_OVERRIDES_ENV_KEY), ",") {
+		if strings.TrimSpace(override) == "" {
+			continue
+		}
+
+		i := strings.LastIndex(override, "=")
+		if i <= 0 {
+			c.Err = fmt.Errorf(InvalidTelemetryErrorMessage, TRACING_SAMPLER_OVERRIDES_ENV_KEY)
+			return
+		}
+
+		ratio, err := strconv.ParseFloat(strings.TrimSpace(override[i+1:]), 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			c.Err = fmt.Errorf(InvalidTelemetryErrorMessage, TRACING_SAMPLER_OVERRIDES_ENV_KEY)
+			return
+		}
+
+		c.TRACING_SAMPLER_OVERRIDES[strings.TrimSpace(override[:i])] = ratio
+	}
+}
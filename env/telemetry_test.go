@@ -45,3 +45,48 @@ func (s *TracingTestSuite) TestTracingErr() {
 	c.Tracing()
 	s.Error(c.Err)
 }
+
+func (s *TracingTestSuite) TestSampler() {
+	os.Setenv(IS_TRACING_ENABLED_ENV_KEY, "true")
+	os.Setenv(OTLP_ENDPOINT_ENV_KEY, "endpoint")
+
+	c := &Configs{}
+	c.Tracing()
+
+	s.NoError(c.Err)
+	s.Equal(ALWAYS_ON_SAMPLER, c.TRACING_SAMPLER)
+	s.True(c.TRACING_SAMPLER_PARENT_BASED)
+	s.Equal(float64(1), c.TRACING_SAMPLER_RATIO)
+
+	os.Setenv(TRACING_SAMPLER_ENV_KEY, RATIO_SAMPLER)
+	os.Setenv(TRACING_SAMPLER_RATIO_ENV_KEY, "0.25")
+	os.Setenv(TRACING_SAMPLER_PARENT_BASED_ENV_KEY, "false")
+	os.Setenv(TRACING_SAMPLER_OVERRIDES_ENV_KEY, "GET /health=0, heartbeat=0.5")
+	defer os.Unsetenv(TRACING_SAMPLER_ENV_KEY)
+	defer os.Unsetenv(TRACING_SAMPLER_RATIO_ENV_KEY)
+	defer os.Unsetenv(TRACING_SAMPLER_PARENT_BASED_ENV_KEY)
+	defer os.Unsetenv(TRACING_SAMPLER_OVERRIDES_ENV_KEY)
+
+	c = &Configs{}
+	c.Tracing()
+
+	s.NoError(c.Err)
+	s.Equal(0.25, c.TRACING_SAMPLER_RATIO)
+	s.False(c.TRACING_SAMPLER_PARENT_BASED)
+	s.Equal(map[string]float64{"GET /health": 0, "heartbeat": 0.5}, c.TRACING_SAMPLER_OVERRIDES)
+
+	os.Setenv(TRACING_SAMPLER_OVERRIDES_ENV_KEY, "heartbeat")
+	c = &Configs{}
+	c.Tracing()
+	s.Error(c.Err)
+
+	os.Setenv(TRACING_SAMPLER_RATIO_ENV_KEY, "2")
+	c = &Configs{}
+	c.Tracing()
+	s.Error(c.Err)
+
+	os.Setenv(TRACING_SAMPLER_ENV_KEY, "invalid")
+	c = &Configs{}
+	c.Tracing()
+	s.Error(c.Err)
+}
@@ -0,0 +1,36 @@
+package errors
+
+const (
+	INTERNAL_KIND     Kind = 0
+	NOT_FOUND_KIND    Kind = 1
+	CONFLICT_KIND     Kind = 2
+	INVALID_KIND      Kind = 3
+	UNAUTHORIZED_KIND Kind = 4
+	FORBIDDEN_KIND    Kind = 5
+	UNAVAILABLE_KIND  Kind = 6
+
+	ACK_DECISION         RetryDecision = 0
+	RETRY_DECISION       RetryDecision = 1
+	DEAD_LETTER_DECISION RetryDecision = 2
+)
+
+var (
+	KindMapping = map[Kind]string{
+		INTERNAL_KIND:     "internal",
+		NOT_FOUND_KIND:    "not_found",
+		CONFLICT_KIND:     "conflict",
+		INVALID_KIND:      "invalid",
+		UNAUTHORIZED_KIND: "unauthorized",
+		FORBIDDEN_KIND:    "forbidden",
+		UNAVAILABLE_KIND:  "unavailable",
+	}
+
+	// Sentinels to be used with errors.Is, e.g: errors.Is(err, errors.ErrNotFound)
+	ErrInternal     = &AppError{Kind: INTERNAL_KIND}
+	ErrNotFound     = &AppError{Kind: NOT_FOUND_KIND}
+	ErrConflict     = &AppError{Kind: CONFLICT_KIND}
+	ErrInvalid      = &AppError{Kind: INVALID_KIND}
+	ErrUnauthorized = &AppError{Kind: UNAUTHORIZED_KIND}
+	ErrForbidden    = &AppError{Kind: FORBIDDEN_KIND}
+	ErrUnavailable  = &AppError{Kind: UNAVAILABLE_KIND}
+)
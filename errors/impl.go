@@ -0,0 +1,161 @@
+package errors
+
+import (
+	stdErrors "errors"
+	"fmt"
+)
+
+// New create an error as the standard library errors.New
+func New(msg string) error {
+	return stdErrors.New(msg)
+}
+
+// Is is the standard library errors.Is
+func Is(err, target error) bool {
+	return stdErrors.Is(err, target)
+}
+
+// As is the standard library errors.As
+func As(err error, target any) bool {
+	return stdErrors.As(err, target)
+}
+
+// Unwrap is the standard library errors.Unwrap
+func Unwrap(err error) error {
+	return stdErrors.Unwrap(err)
+}
+
+func newAppError(kind Kind, format string, args ...any) *AppError {
+	return &AppError{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+func Internal(format string, args ...any) *AppError {
+	return newAppError(INTERNAL_KIND, format, args...)
+}
+
+func NotFound(format string, args ...any) *AppError {
+	return newAppError(NOT_FOUND_KIND, format, args...)
+}
+
+func Conflict(format string, args ...any) *AppError {
+	return newAppError(CONFLICT_KIND, format, args...)
+}
+
+func Invalid(format string, args ...any) *AppError {
+	return newAppError(INVALID_KIND, format, args...)
+}
+
+func Unauthorized(format string, args ...any) *AppError {
+	return newAppError(UNAUTHORIZED_KIND, format, args...)
+}
+
+func Forbidden(format string, args ...any) *AppError {
+	return newAppError(FORBIDDEN_KIND, format, args...)
+}
+
+func Unavailable(format string, args ...any) *AppError {
+	return newAppError(UNAVAILABLE_KIND, format, args...)
+}
+
+// Wrap wraps err with a kind and message, when err is nil returns nil
+func Wrap(err error, kind Kind, format string, args ...any) error {
+	if err == nil {
+		return nil
+	}
+
+	e := newAppError(kind, format, args...)
+	e.Err = err
+
+	return e
+}
+
+// WithCode returns a copy with a machine readable code, e.g: USER_NOT_FOUND
+//
+// The With helpers and Retryable do not change the receiver, so they are safe to use with the sentinels
+func (e *AppError) WithCode(code string) *AppError {
+	cp := e.copy()
+	cp.Code = code
+	return cp
+}
+
+// WithMeta returns a copy with the metadata that can be logged or sent in the response details
+func (e *AppError) WithMeta(key string, value any) *AppError {
+	cp := e.copy()
+	cp.Metadata[key] = value
+	return cp
+}
+
+// WithCause returns a copy wrapping the error
+func (e *AppError) WithCause(err error) *AppError {
+	cp := e.copy()
+	cp.Err = err
+	return cp
+}
+
+// Retryable returns a copy overriding the retry behavior defined by the kind
+func (e *AppError) Retryable(retryable bool) *AppError {
+	cp := e.copy()
+	cp.retryable = &retryable
+	return cp
+}
+
+func (e *AppError) copy() *AppError {
+	cp := *e
+	cp.Metadata = make(map[string]any, len(e.Metadata))
+	for k, v := range e.Metadata {
+		cp.Metadata[k] = v
+	}
+
+	return &cp
+}
+
+func (e *AppError) Error() string {
+	msg := KindMapping[e.Kind]
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+
+	return msg
+}
+
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches AppErrors of the same kind, when the target has a code the code must match too
+func (e *AppError) Is(target error) bool {
+	t, ok := target.(*AppError)
+	if !ok {
+		return false
+	}
+
+	return t.Kind == e.Kind && (t.Code == "" || t.Code == e.Code)
+}
+
+// KindOf returns the kind of the first AppError in the chain, INTERNAL_KIND when there is none
+func KindOf(err error) Kind {
+	var appErr *AppError
+	if As(err, &appErr) {
+		return appErr.Kind
+	}
+
+	return INTERNAL_KIND
+}
+
+// IsRetryable returns true when the operation could succeed if it is tried again
+func IsRetryable(err error) bool {
+	var appErr *AppError
+	if !As(err, &appErr) {
+		return false
+	}
+
+	if appErr.retryable != nil {
+		return *appErr.retryable
+	}
+
+	return appErr.Kind == UNAVAILABLE_KIND
+}
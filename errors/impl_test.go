@@ -0,0 +1,87 @@
+package errors
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ErrorsTestSuite struct {
+	suite.Suite
+}
+
+func TestErrorsTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsTestSuite))
+}
+
+func (s *ErrorsTestSuite) TestIs() {
+	err := fmt.Errorf("repository: %w", NotFound("user %s", "1").WithCode("USER_NOT_FOUND"))
+
+	s.True(Is(err, ErrNotFound))
+	s.True(Is(err, &AppError{Kind: NOT_FOUND_KIND, Code: "USER_NOT_FOUND"}))
+	s.False(Is(err, &AppError{Kind: NOT_FOUND_KIND, Code: "OTHER"}))
+	s.False(Is(err, ErrConflict))
+}
+
+func (s *ErrorsTestSuite) TestWrap() {
+	cause := New("connection refused")
+	err := Wrap(cause, UNAVAILABLE_KIND, "database")
+
+	s.True(Is(err, cause))
+	s.Equal("unavailable: database: connection refused", err.Error())
+	s.Nil(Wrap(nil, INTERNAL_KIND, "nothing"))
+}
+
+func (s *ErrorsTestSuite) TestWithMeta() {
+	err := Invalid("invalid payload").WithMeta("field", "email")
+
+	s.Equal("email", err.Metadata["field"])
+}
+
+func (s *ErrorsTestSuite) TestSentinelsNotMutated() {
+	err := ErrNotFound.WithCode("USER_NOT_FOUND").WithMeta("id", 1).WithCause(New("no rows")).Retryable(true)
+
+	s.Equal("USER_NOT_FOUND", err.Code)
+	s.Equal(1, err.Metadata["id"])
+	s.True(IsRetryable(err))
+	s.Empty(ErrNotFound.Code)
+	s.Nil(ErrNotFound.Metadata)
+	s.Nil(ErrNotFound.Err)
+	s.False(IsRetryable(ErrNotFound))
+
+	base := Invalid("invalid payload").WithMeta("field", "email")
+	other := base.WithMeta("field", "name")
+	s.Equal("email", base.Metadata["field"])
+	s.Equal("name", other.Metadata["field"])
+}
+
+func (s *ErrorsTestSuite) TestHTTPStatus() {
+	s.Equal(http.StatusOK, HTTPStatus(nil))
+	s.Equal(http.StatusNotFound, HTTPStatus(NotFound("")))
+	s.Equal(http.StatusConflict, HTTPStatus(Conflict("")))
+	s.Equal(http.StatusBadRequest, HTTPStatus(Invalid("")))
+	s.Equal(http.StatusUnauthorized, HTTPStatus(Unauthorized("")))
+	s.Equal(http.StatusInternalServerError, HTTPStatus(New("unknown")))
+	s.Equal(http.StatusInternalServerError, HTTPStatus(&AppError{Kind: Kind(99)}))
+}
+
+func (s *ErrorsTestSuite) TestGRPC() {
+	s.Equal(codes.OK, GRPCCode(nil))
+	s.Equal(codes.NotFound, GRPCCode(NotFound("")))
+	s.Equal(codes.InvalidArgument, status.Code(Invalid("invalid")))
+	s.Equal(codes.Internal, GRPCCode(New("unknown")))
+	s.Equal(codes.Internal, GRPCCode(&AppError{Kind: Kind(99)}))
+	s.Equal(codes.Internal, status.Code(&AppError{Kind: Kind(99)}))
+}
+
+func (s *ErrorsTestSuite) TestDecision() {
+	s.Equal(ACK_DECISION, Decision(nil))
+	s.Equal(RETRY_DECISION, Decision(Unavailable("broker")))
+	s.Equal(RETRY_DECISION, Decision(Internal("timeout").Retryable(true)))
+	s.Equal(DEAD_LETTER_DECISION, Decision(Invalid("payload")))
+	s.Equal(DEAD_LETTER_DECISION, Decision(New("unknown")))
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"net/http"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	httpStatusMapping = map[Kind]int{
+		INTERNAL_KIND:     http.StatusInternalServerError,
+		NOT_FOUND_KIND:    http.StatusNotFound,
+		CONFLICT_KIND:     http.StatusConflict,
+		INVALID_KIND:      http.StatusBadRequest,
+		UNAUTHORIZED_KIND: http.StatusUnauthorized,
+		FORBIDDEN_KIND:    http.StatusForbidden,
+		UNAVAILABLE_KIND:  http.StatusServiceUnavailable,
+	}
+
+	grpcCodeMapping = map[Kind]codes.Code{
+		INTERNAL_KIND:     codes.Internal,
+		NOT_FOUND_KIND:    codes.NotFound,
+		CONFLICT_KIND:     codes.AlreadyExists,
+		INVALID_KIND:      codes.InvalidArgument,
+		UNAUTHORIZED_KIND: codes.Unauthenticated,
+		FORBIDDEN_KIND:    codes.PermissionDenied,
+		UNAVAILABLE_KIND:  codes.Unavailable,
+	}
+)
+
+// HTTPStatus map the error into a http status code, nil errors are 200 and the unknown kinds are 500
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+
+	if code, ok := httpStatusMapping[KindOf(err)]; ok {
+		return code
+	}
+
+	return http.StatusInternalServerError
+}
+
+// GRPCCode map the error into a gRPC code, nil errors are OK and the unknown kinds are Internal
+func GRPCCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+
+	return grpcCode(KindOf(err))
+}
+
+func grpcCode(kind Kind) codes.Code {
+	if code, ok := grpcCodeMapping[kind]; ok {
+		return code
+	}
+
+	return codes.Internal
+}
+
+// GRPCStatus allow status.FromError and status.Code to convert AppErrors returned by gRPC handlers
+func (e *AppError) GRPCStatus() *status.Status {
+	msg := e.Message
+	if msg == "" {
+		msg = e.Error()
+	}
+
+	return status.New(grpcCode(e.Kind), msg)
+}
+
+// Decision returns what a messaging consumer should do with the message after the handler returns err
+func Decision(err error) RetryDecision {
+	if err == nil {
+		return ACK_DECISION
+	}
+
+	if IsRetryable(err) {
+		return RETRY_DECISION
+	}
+
+	return DEAD_LETTER_DECISION
+}
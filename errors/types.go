@@ -0,0 +1,20 @@
+package errors
+
+type (
+	// Kind classifies the application errors and drives the transport mappings
+	Kind int8
+
+	// RetryDecision what the messaging consumer should do with a message that failed
+	RetryDecision int8
+
+	// AppError typed application error
+	AppError struct {
+		Kind     Kind
+		Code     string
+		Message  string
+		Metadata map[string]any
+		Err      error
+
+		retryable *bool
+	}
+)
@@ -0,0 +1,29 @@
+package client
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// ROUND_ROBIN_POLICY spread the calls across all the ready backends
+	ROUND_ROBIN_POLICY BalancingPolicy = "round_robin"
+	// PICK_FIRST_POLICY send all the calls to the first backend that connects, the next ones are used when it fails
+	PICK_FIRST_POLICY BalancingPolicy = "pick_first"
+
+	// KubernetesScheme the resolver scheme of the headless services, the pods are re-resolved periodically
+	KubernetesScheme       = "k8s"
+	DefaultClusterDomain   = "cluster.local"
+	DefaultRefreshInterval = 30 * time.Second
+)
+
+var (
+	ErrorTargetRequired = errors.New("grpc client requires a target")
+	ErrorInvalidPolicy  = errors.New("unknown grpc load balancing policy")
+	ErrorInvalidTarget  = errors.New("invalid grpc target, expected host:port")
+	ErrorNoAddresses    = errors.New("the target was resolved without addresses")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::grpc::client] " + msg
+}
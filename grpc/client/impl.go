@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	// registers the client side health checking used by the HealthCheck eviction
+	_ "google.golang.org/grpc/health"
+)
+
+// New create the gRPC client builder, the calls are balanced across the resolved backends
+//
+//	conn, err := client.New(logger).
+//		Target(client.KubernetesTarget("orders", "default", 50051)).
+//		HealthCheck("").
+//		Build(ctx)
+func New(logger logging.ILogger) ClientBuilder {
+	return &grpcClientBuilder{
+		logger:          logger,
+		policy:          ROUND_ROBIN_POLICY,
+		refreshInterval: DefaultRefreshInterval,
+		lookup:          net.DefaultResolver.LookupHost,
+		tracing:         true,
+	}
+}
+
+// DNSTarget the target resolved by the grpc dns resolver, the records are re-resolved when the connections fail
+func DNSTarget(host string, port int) string {
+	return "dns:///" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+// KubernetesTarget the target of a headless service, resolved to the pod IPs each RefreshInterval
+func KubernetesTarget(service, namespace string, port int) string {
+	host := fmt.Sprintf("%s.%s.svc.%s", service, namespace, DefaultClusterDomain)
+	return KubernetesScheme + ":///" + net.JoinHostPort(host, strconv.Itoa(port))
+}
+
+func (b *grpcClientBuilder) Target(target string) ClientBuilder {
+	b.target = target
+	return b
+}
+
+func (b *grpcClientBuilder) Balancing(policy BalancingPolicy) ClientBuilder {
+	b.policy = policy
+	return b
+}
+
+func (b *grpcClientBuilder) HealthCheck(service string) ClientBuilder {
+	b.healthCheck = true
+	b.healthService = service
+	return b
+}
+
+func (b *grpcClientBuilder) RefreshInterval(interval time.Duration) ClientBuilder {
+	if interval > 0 {
+		b.refreshInterval = interval
+	}
+	return b
+}
+
+func (b *grpcClientBuilder) Lookup(lookup LookupFunc) ClientBuilder {
+	b.lookup = lookup
+	return b
+}
+
+func (b *grpcClientBuilder) DialOptions(opts ...grpc.DialOption) ClientBuilder {
+	b.dialOpts = append(b.dialOpts, opts...)
+	return b
+}
+
+func (b *grpcClientBuilder) WithoutTracing() ClientBuilder {
+	b.tracing = false
+	return b
+}
+
+func (b *grpcClientBuilder) Build(ctx context.Context) (*grpc.ClientConn, error) {
+	if b.target == "" {
+		return nil, ErrorTargetRequired
+	}
+
+	if b.policy != ROUND_ROBIN_POLICY && b.policy != PICK_FIRST_POLICY {
+		return nil, ErrorInvalidPolicy
+	}
+
+	opts := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultServiceConfig(b.serviceConfig()),
+		grpc.WithDisableServiceConfig(),
+		grpc.WithResolvers(&kubernetesBuilder{logger: b.logger, interval: b.refreshInterval, lookup: b.lookup}),
+	}
+
+	if b.tracing {
+		opts = append(opts,
+			grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+			grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+		)
+	}
+
+	conn, err := grpc.DialContext(ctx, b.target, append(opts, b.dialOpts...)...)
+	if err != nil {
+		b.logger.Error(LogMessage("failure to dial the grpc target"), logging.MessageField("target", b.target), logging.ErrorField(err))
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// serviceConfig the balancing policy and the health checking are configured by the default service config, the service
+// configs published by the resolvers, e.g: the dns TXT records, are ignored
+func (b *grpcClientBuilder) serviceConfig() string {
+	config := fmt.Sprintf(`{"loadBalancingConfig":[{%q:{}}]`, b.policy)
+	if b.healthCheck {
+		config += fmt.Sprintf(`,"healthCheckConfig":{"serviceName":%q}`, b.healthService)
+	}
+
+	return config + "}"
+}
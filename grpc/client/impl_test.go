@@ -0,0 +1,182 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+type (
+	ClientTestSuite struct {
+		suite.Suite
+
+		port     int
+		backends map[string]*backend
+		mu       sync.Mutex
+		ips      []string
+	}
+
+	// backend a grpc server listening in a loopback ip, all the backends share the port like the pods of a service
+	backend struct {
+		server *grpc.Server
+		health *health.Server
+		calls  int64
+	}
+)
+
+func TestClientTestSuite(t *testing.T) {
+	suite.Run(t, new(ClientTestSuite))
+}
+
+func (s *ClientTestSuite) SetupTest() {
+	s.backends = map[string]*backend{}
+	s.port = 0
+
+	for _, ip := range []string{"127.0.0.1", "127.0.0.2"} {
+		lis, err := net.Listen("tcp", net.JoinHostPort(ip, strconv.Itoa(s.port)))
+		if err != nil {
+			s.T().Skipf("loopback ip %s not available: %s", ip, err)
+		}
+
+		s.port = lis.Addr().(*net.TCPAddr).Port
+
+		b := &backend{health: health.NewServer()}
+		b.server = grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			atomic.AddInt64(&b.calls, 1)
+			return handler(ctx, req)
+		}))
+		healthpb.RegisterHealthServer(b.server, b.health)
+
+		go b.server.Serve(lis)
+		s.backends[ip] = b
+	}
+
+	s.ips = []string{"127.0.0.1", "127.0.0.2"}
+}
+
+func (s *ClientTestSuite) TearDownTest() {
+	for _, b := range s.backends {
+		b.server.Stop()
+	}
+}
+
+func (s *ClientTestSuite) lookup(ctx context.Context, host string) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if host != "orders.default.svc.cluster.local" {
+		return nil, errors.New("unknown host")
+	}
+
+	return append([]string{}, s.ips...), nil
+}
+
+func (s *ClientTestSuite) setIPs(ips ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.ips = ips
+}
+
+func (s *ClientTestSuite) conn(builder ClientBuilder) *grpc.ClientConn {
+	conn, err := builder.
+		Target(KubernetesTarget("orders", "default", s.port)).
+		Lookup(s.lookup).
+		RefreshInterval(10 * time.Millisecond).
+		WithoutTracing().
+		Build(context.Background())
+	s.Require().NoError(err)
+
+	return conn
+}
+
+// calls wait both backends to receive calls or only the expected one
+func (s *ClientTestSuite) calls(conn *grpc.ClientConn, expected ...string) {
+	client := healthpb.NewHealthClient(conn)
+
+	s.Eventually(func() bool {
+		for _, b := range s.backends {
+			atomic.StoreInt64(&b.calls, 0)
+		}
+
+		for i := 0; i < 20; i++ {
+			if _, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{}); err != nil {
+				return false
+			}
+		}
+
+		for ip, b := range s.backends {
+			called := atomic.LoadInt64(&b.calls) > 0
+			if called != contains(expected, ip) {
+				return false
+			}
+		}
+
+		return true
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func (s *ClientTestSuite) TestRoundRobin() {
+	conn := s.conn(New(logging.NewMockLogger()))
+	defer conn.Close()
+
+	s.calls(conn, "127.0.0.1", "127.0.0.2")
+
+	// the pod removed from the headless service is evicted in the next refresh
+	s.setIPs("127.0.0.2")
+	s.calls(conn, "127.0.0.2")
+}
+
+func (s *ClientTestSuite) TestPickFirst() {
+	conn := s.conn(New(logging.NewMockLogger()).Balancing(PICK_FIRST_POLICY))
+	defer conn.Close()
+
+	s.calls(conn, "127.0.0.1")
+}
+
+func (s *ClientTestSuite) TestHealthEviction() {
+	conn := s.conn(New(logging.NewMockLogger()).HealthCheck(""))
+	defer conn.Close()
+
+	s.calls(conn, "127.0.0.1", "127.0.0.2")
+
+	s.backends["127.0.0.1"].health.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	s.calls(conn, "127.0.0.2")
+
+	s.backends["127.0.0.1"].health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.calls(conn, "127.0.0.1", "127.0.0.2")
+}
+
+func (s *ClientTestSuite) TestBuildErr() {
+	_, err := New(logging.NewMockLogger()).Build(context.Background())
+	s.ErrorIs(err, ErrorTargetRequired)
+
+	_, err = New(logging.NewMockLogger()).Target("dns:///orders:50051").Balancing("random").Build(context.Background())
+	s.ErrorIs(err, ErrorInvalidPolicy)
+}
+
+func (s *ClientTestSuite) TestTargets() {
+	s.Equal("dns:///orders:50051", DNSTarget("orders", 50051))
+	s.Equal("k8s:///orders.default.svc.cluster.local:50051", KubernetesTarget("orders", "default", 50051))
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
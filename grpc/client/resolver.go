@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"google.golang.org/grpc/resolver"
+)
+
+func (b *kubernetesBuilder) Scheme() string {
+	return KubernetesScheme
+}
+
+// Build the target is k8s:///host:port, e.g: KubernetesTarget
+func (b *kubernetesBuilder) Build(target resolver.Target, cc resolver.ClientConn, opts resolver.BuildOptions) (resolver.Resolver, error) {
+	host, port, err := net.SplitHostPort(strings.TrimPrefix(target.URL.Path, "/"))
+	if err != nil || host == "" || port == "" {
+		return nil, ErrorInvalidTarget
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r := &kubernetesResolver{
+		logger:   b.logger,
+		cc:       cc,
+		host:     host,
+		port:     port,
+		interval: b.interval,
+		lookup:   b.lookup,
+		ctx:      ctx,
+		cancel:   cancel,
+		now:      make(chan struct{}, 1),
+	}
+
+	r.wg.Add(1)
+	go r.watch()
+
+	return r, nil
+}
+
+// ResolveNow called by the grpc when a connection fails, the resolution is not waited
+func (r *kubernetesResolver) ResolveNow(resolver.ResolveNowOptions) {
+	select {
+	case r.now <- struct{}{}:
+	default:
+	}
+}
+
+func (r *kubernetesResolver) Close() {
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *kubernetesResolver) watch() {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		r.resolve()
+
+		select {
+		case <-r.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-r.now:
+		}
+	}
+}
+
+// resolve the previous addresses are kept when the lookup fails, the pods removed from the service are evicted in the
+// next successful resolution
+func (r *kubernetesResolver) resolve() {
+	ips, err := r.lookup(r.ctx, r.host)
+	if err == nil && len(ips) == 0 {
+		err = ErrorNoAddresses
+	}
+
+	if err != nil {
+		if r.ctx.Err() == nil {
+			r.logger.Warn(LogMessage("failure to resolve the headless service"), logging.MessageField("host", r.host), logging.ErrorField(err))
+			r.cc.ReportError(err)
+		}
+		return
+	}
+
+	sort.Strings(ips)
+
+	addresses := make([]resolver.Address, 0, len(ips))
+	for _, ip := range ips {
+		addresses = append(addresses, resolver.Address{Addr: net.JoinHostPort(ip, r.port)})
+	}
+
+	if err := r.cc.UpdateState(resolver.State{Addresses: addresses}); err != nil {
+		r.logger.Debug(LogMessage("the resolved addresses were not accepted"), logging.ErrorField(err))
+	}
+}
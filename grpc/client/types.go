@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/resolver"
+)
+
+type (
+	BalancingPolicy string
+
+	// LookupFunc resolve the host into the backend IPs, e.g: net.DefaultResolver.LookupHost
+	LookupFunc = func(ctx context.Context, host string) ([]string, error)
+
+	ClientBuilder interface {
+		// Target the server address, e.g: DNSTarget("orders", 50051) or KubernetesTarget("orders", "default", 50051)
+		Target(target string) ClientBuilder
+		// Balancing the load balancing policy, default ROUND_ROBIN_POLICY
+		Balancing(policy BalancingPolicy) ClientBuilder
+		// HealthCheck evict the backends reporting NOT_SERVING in the grpc.health.v1 service until they serve again, the
+		// empty service checks the server overall status, only the ROUND_ROBIN_POLICY checks the backends health
+		HealthCheck(service string) ClientBuilder
+		// RefreshInterval how often the KubernetesScheme targets are re-resolved, default DefaultRefreshInterval
+		RefreshInterval(interval time.Duration) ClientBuilder
+		// Lookup the host resolution of the KubernetesScheme targets, default net.DefaultResolver.LookupHost
+		Lookup(lookup LookupFunc) ClientBuilder
+		// DialOptions the options applied after the defaults, e.g: the transport credentials, by default the connection
+		// is insecure and traced
+		DialOptions(opts ...grpc.DialOption) ClientBuilder
+		// WithoutTracing disable the otelgrpc interceptors
+		WithoutTracing() ClientBuilder
+		// Build dial the target without blocking, the backends are connected in background
+		Build(ctx context.Context) (*grpc.ClientConn, error)
+	}
+
+	grpcClientBuilder struct {
+		logger          logging.ILogger
+		target          string
+		policy          BalancingPolicy
+		healthCheck     bool
+		healthService   string
+		refreshInterval time.Duration
+		lookup          LookupFunc
+		dialOpts        []grpc.DialOption
+		tracing         bool
+	}
+
+	// kubernetesBuilder build the resolvers of the headless services, the grpc dns resolver only re-resolves when a
+	// connection fails, so the new pods would not receive calls
+	kubernetesBuilder struct {
+		logger   logging.ILogger
+		interval time.Duration
+		lookup   LookupFunc
+	}
+
+	kubernetesResolver struct {
+		logger   logging.ILogger
+		cc       resolver.ClientConn
+		host     string
+		port     string
+		interval time.Duration
+		lookup   LookupFunc
+
+		ctx    context.Context
+		cancel context.CancelFunc
+		now    chan struct{}
+		wg     sync.WaitGroup
+	}
+)
@@ -0,0 +1,16 @@
+package gateway
+
+import "errors"
+
+const (
+	RequestIDHeader = "X-Request-Id"
+)
+
+var (
+	ErrorConnRequired     = errors.New("gateway requires an endpoint or a grpc client connection")
+	ErrorHandlersRequired = errors.New("gateway requires at least one handler registration")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::grpc::gateway] " + msg
+}
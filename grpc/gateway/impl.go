@@ -0,0 +1,124 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/ralvescosta/gokit/logging"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// New create the gateway builder translating REST calls into gRPC calls
+func New(logger logging.ILogger) GatewayBuilder {
+	return &gateway{
+		logger:  logger,
+		headers: map[string]bool{textproto.CanonicalMIMEHeaderKey(RequestIDHeader): true},
+	}
+}
+
+func (g *gateway) Endpoint(addr string, opts ...grpc.DialOption) GatewayBuilder {
+	g.endpoint = addr
+	g.dialOpts = opts
+	return g
+}
+
+func (g *gateway) Conn(conn *grpc.ClientConn) GatewayBuilder {
+	g.conn = conn
+	return g
+}
+
+func (g *gateway) Handlers(fns ...RegisterFunc) GatewayBuilder {
+	g.handlers = append(g.handlers, fns...)
+	return g
+}
+
+func (g *gateway) ForwardHeaders(headers ...string) GatewayBuilder {
+	for _, h := range headers {
+		g.headers[textproto.CanonicalMIMEHeaderKey(h)] = true
+	}
+	return g
+}
+
+func (g *gateway) MuxOptions(opts ...runtime.ServeMuxOption) GatewayBuilder {
+	g.muxOpts = append(g.muxOpts, opts...)
+	return g
+}
+
+func (g *gateway) Build(ctx context.Context) (IGateway, error) {
+	if len(g.handlers) == 0 {
+		return nil, ErrorHandlersRequired
+	}
+
+	if g.conn == nil {
+		if g.endpoint == "" {
+			return nil, ErrorConnRequired
+		}
+
+		opts := append([]grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(otelgrpc.UnaryClientInterceptor()),
+			grpc.WithStreamInterceptor(otelgrpc.StreamClientInterceptor()),
+		}, g.dialOpts...)
+
+		conn, err := grpc.DialContext(ctx, g.endpoint, opts...)
+		if err != nil {
+			g.logger.Error(LogMessage("failure to dial the grpc server"), logging.ErrorField(err))
+			return nil, err
+		}
+
+		g.conn = conn
+		g.ownConn = true
+	}
+
+	muxOpts := append([]runtime.ServeMuxOption{
+		runtime.WithIncomingHeaderMatcher(g.headerMatcher),
+		runtime.WithErrorHandler(g.errorHandler),
+	}, g.muxOpts...)
+
+	g.mux = runtime.NewServeMux(muxOpts...)
+
+	for _, register := range g.handlers {
+		if err := register(ctx, g.mux, g.conn); err != nil {
+			g.logger.Error(LogMessage("failure to register the handler"), logging.ErrorField(err))
+			g.Close()
+			return nil, err
+		}
+	}
+
+	return g, nil
+}
+
+func (g *gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.mux.ServeHTTP(w, r)
+}
+
+func (g *gateway) Close() error {
+	if !g.ownConn {
+		return nil
+	}
+
+	return g.conn.Close()
+}
+
+// headerMatcher forward the configured headers with the lower case name, as the gRPC metadata keys
+func (g *gateway) headerMatcher(key string) (string, bool) {
+	if g.headers[textproto.CanonicalMIMEHeaderKey(key)] {
+		return strings.ToLower(key), true
+	}
+
+	return runtime.DefaultHeaderMatcher(key)
+}
+
+func (g *gateway) errorHandler(ctx context.Context, mux *runtime.ServeMux, marshaler runtime.Marshaler, w http.ResponseWriter, r *http.Request, err error) {
+	if s, ok := status.FromError(err); ok && runtime.HTTPStatusFromCode(s.Code()) >= http.StatusInternalServerError {
+		g.logger.Error(LogMessage("grpc call failure"), logging.ErrorField(err))
+	}
+
+	runtime.DefaultHTTPErrorHandler(ctx, mux, marshaler, w, r, err)
+}
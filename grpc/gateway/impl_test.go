@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type GatewayTestSuite struct {
+	suite.Suite
+
+	addr     string
+	server   *grpc.Server
+	metadata chan metadata.MD
+}
+
+func TestGatewayTestSuite(t *testing.T) {
+	suite.Run(t, new(GatewayTestSuite))
+}
+
+func (s *GatewayTestSuite) SetupTest() {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+
+	s.metadata = make(chan metadata.MD, 1)
+	s.server = grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		md, _ := metadata.FromIncomingContext(ctx)
+		s.metadata <- md
+		return handler(ctx, req)
+	}))
+
+	checker := health.NewServer()
+	checker.SetServingStatus("orders", healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(s.server, checker)
+
+	go s.server.Serve(lis)
+	s.addr = lis.Addr().String()
+}
+
+func (s *GatewayTestSuite) TearDownTest() {
+	s.server.Stop()
+}
+
+// registerHealth is what the generated RegisterXxxHandler does, translating GET /v1/health/{service}
+func registerHealth(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error {
+	client := healthpb.NewHealthClient(conn)
+
+	return mux.HandlePath(http.MethodGet, "/v1/health/{service}", func(w http.ResponseWriter, r *http.Request, params map[string]string) {
+		ctx, err := runtime.AnnotateContext(r.Context(), mux, r, "/grpc.health.v1.Health/Check")
+		if err != nil {
+			runtime.HTTPError(ctx, mux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
+
+		res, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: params["service"]})
+		if err != nil {
+			runtime.HTTPError(ctx, mux, &runtime.JSONPb{}, w, r, err)
+			return
+		}
+
+		runtime.ForwardResponseMessage(ctx, mux, &runtime.JSONPb{}, w, r, res)
+	})
+}
+
+func (s *GatewayTestSuite) TestGateway() {
+	gw, err := New(logging.NewMockLogger()).
+		Endpoint(s.addr).
+		Handlers(registerHealth).
+		ForwardHeaders("X-Tenant-Id").
+		Build(context.Background())
+	s.Require().NoError(err)
+	defer gw.Close()
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/health/orders", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set(RequestIDHeader, "request-id")
+	req.Header.Set("X-Tenant-Id", "tenant")
+
+	rec := httptest.NewRecorder()
+	gw.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.JSONEq(`{"status":"SERVING"}`, rec.Body.String())
+
+	md := <-s.metadata
+	s.Equal([]string{"Bearer token"}, md.Get("authorization"))
+	s.Equal([]string{"request-id"}, md.Get("x-request-id"))
+	s.Equal([]string{"tenant"}, md.Get("x-tenant-id"))
+
+	rec = httptest.NewRecorder()
+	gw.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/health/unknown", nil))
+	<-s.metadata
+
+	s.Equal(runtime.HTTPStatusFromCode(status.Code(status.Error(codes.NotFound, ""))), rec.Code)
+}
+
+func (s *GatewayTestSuite) TestBuildErr() {
+	_, err := New(logging.NewMockLogger()).Endpoint(s.addr).Build(context.Background())
+	s.ErrorIs(err, ErrorHandlersRequired)
+
+	_, err = New(logging.NewMockLogger()).Handlers(registerHealth).Build(context.Background())
+	s.ErrorIs(err, ErrorConnRequired)
+}
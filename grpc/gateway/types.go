@@ -0,0 +1,46 @@
+package gateway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/ralvescosta/gokit/logging"
+	"google.golang.org/grpc"
+)
+
+type (
+	// RegisterFunc the generated RegisterXxxHandler functions have this signature
+	RegisterFunc = func(ctx context.Context, mux *runtime.ServeMux, conn *grpc.ClientConn) error
+
+	GatewayBuilder interface {
+		// Endpoint the gRPC server address, by default the connection is insecure and traced
+		Endpoint(addr string, opts ...grpc.DialOption) GatewayBuilder
+		// Conn use an existing connection instead of dialing the endpoint, it is not closed by the gateway
+		Conn(conn *grpc.ClientConn) GatewayBuilder
+		// Handlers register the generated gateway handlers
+		Handlers(fns ...RegisterFunc) GatewayBuilder
+		// ForwardHeaders http headers forwarded as gRPC metadata, X-Request-Id and Authorization are always forwarded
+		ForwardHeaders(headers ...string) GatewayBuilder
+		MuxOptions(opts ...runtime.ServeMuxOption) GatewayBuilder
+		Build(ctx context.Context) (IGateway, error)
+	}
+
+	// IGateway the REST handler, mount it in the http server to share the middlewares
+	IGateway interface {
+		http.Handler
+		Close() error
+	}
+
+	gateway struct {
+		logger   logging.ILogger
+		endpoint string
+		dialOpts []grpc.DialOption
+		conn     *grpc.ClientConn
+		ownConn  bool
+		handlers []RegisterFunc
+		headers  map[string]bool
+		muxOpts  []runtime.ServeMuxOption
+		mux      *runtime.ServeMux
+	}
+)
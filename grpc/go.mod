@@ -0,0 +1,26 @@
+module github.com/ralvescosta/gokit/grpc
+
+go 1.18
+
+require (
+	github.com/grpc-ecosystem/grpc-gateway/v2 v2.11.0
+	github.com/ralvescosta/gokit/auth v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/ratelimit v0.0.0-20261016183433-cb393702ac94
+	github.com/stretchr/testify v1.8.0
+	go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc v0.53.0
+	go.uber.org/zap v1.21.0
+	google.golang.org/grpc v1.65.0
+)
+
+require (
+	github.com/ralvescosta/gokit/clock v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/crypto v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/errors v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/guid v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/messaging v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/pool v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/requestid v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/sql v0.0.0-20261016183433-cb393702ac94 // indirect
+)
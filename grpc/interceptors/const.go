@@ -0,0 +1,17 @@
+package interceptors
+
+import "errors"
+
+const (
+	RequestIDMeta  = "x-request-id"
+	RetryAfterMeta = "retry-after"
+)
+
+var (
+	ErrorRateLimited = errors.New("rate limit exceeded")
+	ErrorInternal    = errors.New("internal error")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::grpc::interceptors] " + msg
+}
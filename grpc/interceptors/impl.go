@@ -0,0 +1,128 @@
+package interceptors
+
+import (
+	"time"
+
+	"github.com/ralvescosta/gokit/auth"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/ratelimit"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// New create the interceptors chain builder, the recovery is always enabled and the
+// logging, tracing, validation and deadlines toggles come from the env.Configs GRPCServer area
+func New(cfg *env.Configs, logger logging.ILogger) ChainBuilder {
+	return &chainBuilder{
+		cfg:            cfg,
+		logger:         logger,
+		withTracing:    cfg.IS_GRPC_TRACING_ENABLED,
+		withLogging:    cfg.IS_GRPC_LOGGING_ENABLED,
+		withValidation: cfg.IS_GRPC_VALIDATION_ENABLED,
+		defaultTimeout: cfg.GRPC_DEFAULT_TIMEOUT,
+		maxTimeout:     cfg.GRPC_MAX_TIMEOUT,
+	}
+}
+
+func (b *chainBuilder) WithTracing() ChainBuilder {
+	b.withTracing = true
+	return b
+}
+
+func (b *chainBuilder) WithLogging() ChainBuilder {
+	b.withLogging = true
+	return b
+}
+
+func (b *chainBuilder) WithValidation() ChainBuilder {
+	b.withValidation = true
+	return b
+}
+
+func (b *chainBuilder) WithDeadline(defaultTimeout, maxTimeout time.Duration) ChainBuilder {
+	b.defaultTimeout = defaultTimeout
+	b.maxTimeout = maxTimeout
+	return b
+}
+
+func (b *chainBuilder) WithRateLimit(limiter ratelimit.ILimiter, keyFunc KeyFunc) ChainBuilder {
+	b.limiter = limiter
+	b.keyFunc = keyFunc
+	return b
+}
+
+func (b *chainBuilder) WithAuth(validator auth.ITokenValidator, publicMethods ...string) ChainBuilder {
+	b.tokenValidator = validator
+	b.publicMethods = publicMethods
+	return b
+}
+
+func (b *chainBuilder) WithUnary(interceptors ...grpc.UnaryServerInterceptor) ChainBuilder {
+	b.unary = append(b.unary, interceptors...)
+	return b
+}
+
+func (b *chainBuilder) WithStream(interceptors ...grpc.StreamServerInterceptor) ChainBuilder {
+	b.stream = append(b.stream, interceptors...)
+	return b
+}
+
+func (b *chainBuilder) Build() IChain {
+	c := &chain{}
+
+	if b.withTracing {
+		c.unary = append(c.unary, otelgrpc.UnaryServerInterceptor())
+		c.stream = append(c.stream, otelgrpc.StreamServerInterceptor())
+	}
+
+	c.unary = append(c.unary, UnaryRecovery(b.logger))
+	c.stream = append(c.stream, StreamRecovery(b.logger))
+
+	if b.withLogging {
+		c.unary = append(c.unary, UnaryLogging(b.logger))
+		c.stream = append(c.stream, StreamLogging(b.logger))
+	}
+
+	if b.defaultTimeout > 0 || b.maxTimeout > 0 {
+		c.unary = append(c.unary, UnaryDeadline(b.defaultTimeout, b.maxTimeout))
+		c.stream = append(c.stream, StreamDeadline(b.defaultTimeout, b.maxTimeout))
+	}
+
+	if b.limiter != nil {
+		c.unary = append(c.unary, UnaryRateLimit(b.logger, b.limiter, b.keyFunc))
+		c.stream = append(c.stream, StreamRateLimit(b.logger, b.limiter, b.keyFunc))
+	}
+
+	if b.tokenValidator != nil {
+		c.unary = append(c.unary, skipUnary(auth.UnaryServerInterceptor(b.tokenValidator), b.publicMethods))
+		c.stream = append(c.stream, skipStream(auth.StreamServerInterceptor(b.tokenValidator), b.publicMethods))
+	}
+
+	if b.withValidation {
+		c.unary = append(c.unary, UnaryValidation())
+		c.stream = append(c.stream, StreamValidation())
+	}
+
+	c.unary = append(c.unary, b.unary...)
+	c.stream = append(c.stream, b.stream...)
+
+	b.logger.Debug(LogMessage("interceptors chain created"))
+
+	return c
+}
+
+func (c *chain) Unary() []grpc.UnaryServerInterceptor {
+	return c.unary
+}
+
+func (c *chain) Stream() []grpc.StreamServerInterceptor {
+	return c.stream
+}
+
+func (c *chain) ServerOptions() []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(c.unary...),
+		grpc.ChainStreamInterceptor(c.stream...),
+	}
+}
@@ -0,0 +1,189 @@
+package interceptors
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/auth"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/ratelimit"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type InterceptorsTestSuite struct {
+	suite.Suite
+
+	logger *logging.MockLogger
+	info   *grpc.UnaryServerInfo
+}
+
+type validatedRequest struct {
+	err error
+}
+
+func (r *validatedRequest) Validate() error {
+	return r.err
+}
+
+type mockLimiter struct {
+	mock.Mock
+}
+
+func (m *mockLimiter) Allow(ctx context.Context, key string) (*ratelimit.Result, error) {
+	called := m.Called(ctx, key)
+	result, _ := called.Get(0).(*ratelimit.Result)
+	return result, called.Error(1)
+}
+
+type mockTokenValidator struct {
+	mock.Mock
+}
+
+func (m *mockTokenValidator) Validate(ctx context.Context, token string) (*auth.Claims, error) {
+	called := m.Called(ctx, token)
+	claims, _ := called.Get(0).(*auth.Claims)
+	return claims, called.Error(1)
+}
+
+func TestInterceptorsTestSuite(t *testing.T) {
+	suite.Run(t, new(InterceptorsTestSuite))
+}
+
+func (s *InterceptorsTestSuite) SetupTest() {
+	s.logger = logging.NewMockLogger()
+	s.info = &grpc.UnaryServerInfo{FullMethod: "/gokit.Service/Method"}
+}
+
+func (s *InterceptorsTestSuite) TestUnaryRecovery() {
+	_, err := UnaryRecovery(s.logger)(context.Background(), nil, s.info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	})
+
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+func (s *InterceptorsTestSuite) TestUnaryDeadline() {
+	interceptor := UnaryDeadline(time.Second, 5*time.Second)
+
+	interceptor(context.Background(), nil, s.info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, ok := ctx.Deadline()
+		s.True(ok)
+		s.WithinDuration(time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+		return nil, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+
+	interceptor(ctx, nil, s.info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		deadline, _ := ctx.Deadline()
+		s.WithinDuration(time.Now().Add(5*time.Second), deadline, 100*time.Millisecond)
+		return nil, nil
+	})
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	_, err := interceptor(expired, nil, s.info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Fail("handler should not be called")
+		return nil, nil
+	})
+	s.Equal(codes.DeadlineExceeded, status.Code(err))
+}
+
+func (s *InterceptorsTestSuite) TestUnaryRateLimit() {
+	limiter := &mockLimiter{}
+	limiter.On("Allow", mock.Anything, "10.0.0.1").Return(&ratelimit.Result{Allowed: false, RetryAfter: time.Second}, nil).Once()
+	limiter.On("Allow", mock.Anything, "10.0.0.1").Return(nil, errors.New("redis down")).Once()
+
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.1"), Port: 5000}})
+	interceptor := UnaryRateLimit(s.logger, limiter, nil)
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	_, err := interceptor(ctx, nil, s.info, handler)
+	s.Equal(codes.ResourceExhausted, status.Code(err))
+
+	resp, err := interceptor(ctx, nil, s.info, handler)
+	s.NoError(err)
+	s.Equal("ok", resp)
+	limiter.AssertExpectations(s.T())
+}
+
+func (s *InterceptorsTestSuite) TestUnaryValidation() {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	_, err := UnaryValidation()(context.Background(), &validatedRequest{err: errors.New("name is required")}, s.info, handler)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+
+	_, err = UnaryValidation()(context.Background(), &validatedRequest{}, s.info, handler)
+	s.NoError(err)
+
+	_, err = UnaryValidation()(context.Background(), "not generated", s.info, handler)
+	s.NoError(err)
+}
+
+func (s *InterceptorsTestSuite) TestChainAuthPublicMethods() {
+	validator := &mockTokenValidator{}
+	validator.On("Validate", mock.Anything, "token").Return(&auth.Claims{}, nil)
+
+	chain := New(&env.Configs{}, s.logger).WithAuth(validator, "/grpc.health.v1.Health/Check").Build()
+	client := s.serve(chain)
+
+	_, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	s.NoError(err)
+
+	stream, _ := client.Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	_, err = stream.Recv()
+	s.Equal(codes.Unauthenticated, status.Code(err))
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), auth.AuthorizationMeta, "Bearer token")
+	stream, _ = client.Watch(ctx, &healthpb.HealthCheckRequest{})
+	_, err = stream.Recv()
+	s.NoError(err)
+}
+
+func (s *InterceptorsTestSuite) TestChainFromEnv() {
+	chain := New(&env.Configs{IS_GRPC_LOGGING_ENABLED: true, GRPC_DEFAULT_TIMEOUT: time.Second}, s.logger).Build()
+
+	// recovery, logging and deadline
+	s.Len(chain.Unary(), 3)
+	s.Len(chain.Stream(), 3)
+
+	chain = New(&env.Configs{}, s.logger).WithTracing().WithValidation().WithUnary(UnaryValidation()).Build()
+
+	// tracing, recovery, validation and the custom one
+	s.Len(chain.Unary(), 4)
+	s.Len(chain.ServerOptions(), 2)
+}
+
+func (s *InterceptorsTestSuite) serve(chain IChain) healthpb.HealthClient {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(chain.ServerOptions()...)
+	healthpb.RegisterHealthServer(server, health.NewServer())
+
+	go server.Serve(listener)
+	s.T().Cleanup(server.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { conn.Close() })
+
+	return healthpb.NewHealthClient(conn)
+}
@@ -0,0 +1,306 @@
+package interceptors
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/ratelimit"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryRecovery recover from panics, log the stack trace and return Internal
+func UnaryRecovery(logger logging.ILogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				err = recovered(logger, info.FullMethod, rvr)
+			}
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRecovery see UnaryRecovery
+func StreamRecovery(logger logging.ILogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if rvr := recover(); rvr != nil {
+				err = recovered(logger, info.FullMethod, rvr)
+			}
+		}()
+
+		return handler(srv, ss)
+	}
+}
+
+// UnaryLogging log the method, the status code and the duration of each call, the server failures are logged as error and the client failures as warn
+func UnaryLogging(logger logging.ILogger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(logger, ctx, info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamLogging see UnaryLogging, the stream is logged when it finishes
+func StreamLogging(logger logging.ILogger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(logger, ss.Context(), info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+// UnaryDeadline the calls without deadline receive the defaultTimeout and the deadlines longer than the maxTimeout are reduced, zero disable each one
+func UnaryDeadline(defaultTimeout, maxTimeout time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, cancel, err := enforceDeadline(ctx, defaultTimeout, maxTimeout)
+		if err != nil {
+			return nil, err
+		}
+		defer cancel()
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamDeadline see UnaryDeadline
+func StreamDeadline(defaultTimeout, maxTimeout time.Duration) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel, err := enforceDeadline(ss.Context(), defaultTimeout, maxTimeout)
+		if err != nil {
+			return err
+		}
+		defer cancel()
+
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// UnaryRateLimit reject the calls above the limit with ResourceExhausted and the retry-after header, the limiter failures are logged and the call is allowed
+func UnaryRateLimit(logger logging.ILogger, limiter ratelimit.ILimiter, keyFunc KeyFunc) grpc.UnaryServerInterceptor {
+	if keyFunc == nil {
+		keyFunc = KeyByPeer
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := rateLimit(ctx, logger, limiter, keyFunc(ctx, info.FullMethod)); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamRateLimit see UnaryRateLimit, the limit is applied when the stream is opened
+func StreamRateLimit(logger logging.ILogger, limiter ratelimit.ILimiter, keyFunc KeyFunc) grpc.StreamServerInterceptor {
+	if keyFunc == nil {
+		keyFunc = KeyByPeer
+	}
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := rateLimit(ss.Context(), logger, limiter, keyFunc(ss.Context(), info.FullMethod)); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// UnaryValidation reject with InvalidArgument the requests generated by protoc-gen-validate that are invalid
+func UnaryValidation() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validate(req); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamValidation see UnaryValidation, each received message is validated
+func StreamValidation() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &wrappedServerStream{ServerStream: ss, ctx: ss.Context(), validate: true})
+	}
+}
+
+// KeyByPeer use the peer address without the port as the rate limit key
+func KeyByPeer(ctx context.Context, _ string) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+
+	return host
+}
+
+func (s *wrappedServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *wrappedServerStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if s.validate {
+		return validate(m)
+	}
+
+	return nil
+}
+
+func recovered(logger logging.ILogger, method string, rvr interface{}) error {
+	logger.Error(
+		LogMessage("recovered from panic"),
+		logging.MessageField("method", method),
+		logging.MessageField("panic", fmt.Sprintf("%v", rvr)),
+		logging.MessageField("stack", string(debug.Stack())),
+	)
+
+	return status.Error(codes.Internal, ErrorInternal.Error())
+}
+
+func logCall(logger logging.ILogger, ctx context.Context, method string, start time.Time, err error) {
+	code := status.Code(err)
+
+	fields := []zap.Field{
+		logging.MessageField("method", method),
+		logging.MessageField("code", code.String()),
+		zap.Duration("duration", time.Since(start)),
+	}
+
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(RequestIDMeta)) > 0 {
+		fields = append(fields, logging.MessageField("requestId", md.Get(RequestIDMeta)[0]))
+	}
+
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		fields = append(fields, logging.MessageField("peer", p.Addr.String()))
+	}
+
+	if err != nil {
+		fields = append(fields, logging.ErrorField(err))
+	}
+
+	switch code {
+	case codes.OK:
+		logger.Info(LogMessage("call"), fields...)
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.DeadlineExceeded, codes.Unimplemented:
+		logger.Error(LogMessage("call"), fields...)
+	default:
+		logger.Warn(LogMessage("call"), fields...)
+	}
+}
+
+func enforceDeadline(ctx context.Context, defaultTimeout, maxTimeout time.Duration) (context.Context, context.CancelFunc, error) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		if defaultTimeout <= 0 {
+			return ctx, func() {}, nil
+		}
+
+		ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+		return ctx, cancel, nil
+	}
+
+	remaining := time.Until(deadline)
+	if remaining <= 0 {
+		return nil, nil, status.Error(codes.DeadlineExceeded, context.DeadlineExceeded.Error())
+	}
+
+	if maxTimeout > 0 && remaining > maxTimeout {
+		ctx, cancel := context.WithTimeout(ctx, maxTimeout)
+		return ctx, cancel, nil
+	}
+
+	return ctx, func() {}, nil
+}
+
+func rateLimit(ctx context.Context, logger logging.ILogger, limiter ratelimit.ILimiter, key string) error {
+	result, err := limiter.Allow(ctx, key)
+	if err != nil {
+		logger.Error(LogMessage("limiter failure"), logging.ErrorField(err))
+		return nil
+	}
+
+	if result.Allowed {
+		return nil
+	}
+
+	retryAfter := strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds())))
+	grpc.SetHeader(ctx, metadata.Pairs(RetryAfterMeta, retryAfter))
+
+	return status.Error(codes.ResourceExhausted, ErrorRateLimited.Error())
+}
+
+func validate(m interface{}) error {
+	var err error
+
+	switch v := m.(type) {
+	case messageAllValidator:
+		err = v.ValidateAll()
+	case messageValidator:
+		err = v.Validate()
+	}
+
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return nil
+}
+
+func skipUnary(interceptor grpc.UnaryServerInterceptor, methods []string) grpc.UnaryServerInterceptor {
+	skip := methodsSet(methods)
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if skip[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		return interceptor(ctx, req, info, handler)
+	}
+}
+
+func skipStream(interceptor grpc.StreamServerInterceptor, methods []string) grpc.StreamServerInterceptor {
+	skip := methodsSet(methods)
+
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if skip[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		return interceptor(srv, ss, info, handler)
+	}
+}
+
+func methodsSet(methods []string) map[string]bool {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[m] = true
+	}
+
+	return set
+}
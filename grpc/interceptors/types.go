@@ -0,0 +1,79 @@
+package interceptors
+
+import (
+	"context"
+	"time"
+
+	"github.com/ralvescosta/gokit/auth"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/ratelimit"
+	"google.golang.org/grpc"
+)
+
+type (
+	// KeyFunc extract the rate limit key of the call, e.g: the peer address or the tenant
+	KeyFunc = func(ctx context.Context, fullMethod string) string
+
+	// ChainBuilder assemble the interceptors in the order: tracing, recovery, logging, deadline, rate limit, auth, validation and the custom interceptors
+	ChainBuilder interface {
+		WithTracing() ChainBuilder
+		WithLogging() ChainBuilder
+		// WithValidation validate the messages generated by protoc-gen-validate, the invalid messages are rejected with InvalidArgument
+		WithValidation() ChainBuilder
+		// WithDeadline the calls without deadline receive the defaultTimeout and the longer deadlines are reduced to the maxTimeout, zero disable each one
+		WithDeadline(defaultTimeout, maxTimeout time.Duration) ChainBuilder
+		// WithRateLimit reject the calls above the limit with ResourceExhausted, when keyFunc is nil the peer address is used
+		WithRateLimit(limiter ratelimit.ILimiter, keyFunc KeyFunc) ChainBuilder
+		// WithAuth validate the bearer token, the public methods, e.g: /grpc.health.v1.Health/Check, are not authenticated
+		WithAuth(validator auth.ITokenValidator, publicMethods ...string) ChainBuilder
+		// WithUnary append custom unary interceptors after the standard chain
+		WithUnary(interceptors ...grpc.UnaryServerInterceptor) ChainBuilder
+		// WithStream append custom stream interceptors after the standard chain
+		WithStream(interceptors ...grpc.StreamServerInterceptor) ChainBuilder
+		Build() IChain
+	}
+
+	IChain interface {
+		Unary() []grpc.UnaryServerInterceptor
+		Stream() []grpc.StreamServerInterceptor
+		// ServerOptions the chain as grpc.NewServer options
+		ServerOptions() []grpc.ServerOption
+	}
+
+	chainBuilder struct {
+		cfg            *env.Configs
+		logger         logging.ILogger
+		withTracing    bool
+		withLogging    bool
+		withValidation bool
+		defaultTimeout time.Duration
+		maxTimeout     time.Duration
+		limiter        ratelimit.ILimiter
+		keyFunc        KeyFunc
+		tokenValidator auth.ITokenValidator
+		publicMethods  []string
+		unary          []grpc.UnaryServerInterceptor
+		stream         []grpc.StreamServerInterceptor
+	}
+
+	chain struct {
+		unary  []grpc.UnaryServerInterceptor
+		stream []grpc.StreamServerInterceptor
+	}
+
+	// messageValidator the messages generated by protoc-gen-validate implement Validate, and ValidateAll in the recent versions
+	messageValidator interface {
+		Validate() error
+	}
+
+	messageAllValidator interface {
+		ValidateAll() error
+	}
+
+	wrappedServerStream struct {
+		grpc.ServerStream
+		ctx      context.Context
+		validate bool
+	}
+)
@@ -0,0 +1,7 @@
+package guid
+
+import "errors"
+
+var (
+	ErrorInvalidID = errors.New("invalid sortable id")
+)
@@ -0,0 +1,58 @@
+package guid
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+var (
+	mu      sync.Mutex
+	entropy = ulid.Monotonic(rand.Reader, 0)
+)
+
+// NewUUIDv7 time ordered UUID (RFC 9562), ids created in the same millisecond are monotonic
+func NewUUIDv7() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
+
+// NewULID monotonic ULID, ids created in the same millisecond are strictly increasing
+func NewULID() ulid.ULID {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return ulid.MustNew(ulid.Timestamp(time.Now()), entropy)
+}
+
+// NewRequestID sortable id used as http request id and messaging message id
+func NewRequestID() string {
+	return NewULID().String()
+}
+
+// ToSortableString encode the UUID in the 26 chars Crockford base32, the lexical order is the same as the binary order
+func ToSortableString(id uuid.UUID) string {
+	return ulid.ULID(id).String()
+}
+
+// FromSortableString decode an id encoded by ToSortableString or a ULID string
+func FromSortableString(s string) (uuid.UUID, error) {
+	id, err := ulid.ParseStrict(s)
+	if err != nil {
+		return uuid.Nil, ErrorInvalidID
+	}
+
+	return uuid.UUID(id), nil
+}
+
+// Time returns the timestamp of a UUIDv7 or ULID stored as uuid
+func Time(id uuid.UUID) time.Time {
+	if id.Version() == 7 {
+		sec, nsec := id.Time().UnixTime()
+		return time.Unix(sec, nsec)
+	}
+
+	return ulid.Time(ulid.ULID(id).Time())
+}
@@ -0,0 +1,55 @@
+package guid
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+)
+
+type GuidTestSuite struct {
+	suite.Suite
+}
+
+func TestGuidTestSuite(t *testing.T) {
+	suite.Run(t, new(GuidTestSuite))
+}
+
+func (s *GuidTestSuite) TestULIDMonotonic() {
+	ids := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		ids = append(ids, NewRequestID())
+	}
+
+	s.True(sort.StringsAreSorted(ids))
+	s.Len(ids[0], 26)
+}
+
+func (s *GuidTestSuite) TestUUIDv7() {
+	id := NewUUIDv7()
+
+	s.Equal(uuid.Version(7), id.Version())
+	s.WithinDuration(time.Now(), Time(id), time.Second)
+
+	ids := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		ids = append(ids, ToSortableString(NewUUIDv7()))
+	}
+	s.True(sort.StringsAreSorted(ids))
+}
+
+func (s *GuidTestSuite) TestSortableString() {
+	id := NewUUIDv7()
+
+	decoded, err := FromSortableString(ToSortableString(id))
+	s.NoError(err)
+	s.Equal(id, decoded)
+
+	ulid := NewULID()
+	s.WithinDuration(time.Now(), Time(uuid.UUID(ulid)), time.Second)
+
+	_, err = FromSortableString("invalid")
+	s.ErrorIs(err, ErrorInvalidID)
+}
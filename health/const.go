@@ -0,0 +1,23 @@
+package health
+
+import "time"
+
+const (
+	UP_STATUS   Status = "up"
+	DOWN_STATUS Status = "down"
+
+	LIVENESS_KIND  ProbeKind = "liveness"
+	READINESS_KIND ProbeKind = "readiness"
+
+	LivenessPath  = "/healthz"
+	ReadinessPath = "/readyz"
+
+	DefaultProbeTimeout = 2 * time.Second
+	DefaultCacheTTL     = 1 * time.Second
+
+	JsonContentType = "application/json"
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::health] " + msg
+}
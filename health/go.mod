@@ -0,0 +1,20 @@
+module github.com/ralvescosta/gokit/health
+
+go 1.18
+
+require (
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/stretchr/testify v1.8.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/ralvescosta/dotenv v1.0.4 // indirect
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/stretchr/objx v0.4.0 // indirect
+	go.uber.org/atomic v1.9.0 // indirect
+	go.uber.org/multierr v1.8.0 // indirect
+	go.uber.org/zap v1.21.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
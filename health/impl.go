@@ -0,0 +1,188 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// New create a builder for IHealthChecker
+func New(logger logging.ILogger) HealthBuilder {
+	return &HealthChecker{
+		logger:   logger,
+		timeout:  DefaultProbeTimeout,
+		cacheTTL: DefaultCacheTTL,
+		probes: map[ProbeKind][]*Probe{
+			LIVENESS_KIND:  {},
+			READINESS_KIND: {},
+		},
+		cache: map[ProbeKind]*cachedReport{
+			LIVENESS_KIND:  {},
+			READINESS_KIND: {},
+		},
+		timeNow: time.Now,
+	}
+}
+
+func (h *HealthChecker) Timeout(t time.Duration) HealthBuilder {
+	h.timeout = t
+	return h
+}
+
+func (h *HealthChecker) CacheTTL(t time.Duration) HealthBuilder {
+	h.cacheTTL = t
+	return h
+}
+
+func (h *HealthChecker) Liveness(probe *Probe) HealthBuilder {
+	h.probes[LIVENESS_KIND] = append(h.probes[LIVENESS_KIND], probe)
+	return h
+}
+
+func (h *HealthChecker) Readiness(probe *Probe) HealthBuilder {
+	h.probes[READINESS_KIND] = append(h.probes[READINESS_KIND], probe)
+	return h
+}
+
+func (h *HealthChecker) Build() IHealthChecker {
+	h.logger.Debug(LogMessage("health checker was created"))
+	return &healthChecker{h}
+}
+
+// healthChecker wraps the builder so the registration methods are not exposed after Build
+type healthChecker struct {
+	*HealthChecker
+}
+
+func (h *healthChecker) Liveness(ctx context.Context) *Report {
+	return h.report(ctx, LIVENESS_KIND)
+}
+
+func (h *healthChecker) Readiness(ctx context.Context) *Report {
+	return h.report(ctx, READINESS_KIND)
+}
+
+func (h *healthChecker) LivenessHandler() http.HandlerFunc {
+	return h.handler(LIVENESS_KIND)
+}
+
+func (h *healthChecker) ReadinessHandler() http.HandlerFunc {
+	return h.handler(READINESS_KIND)
+}
+
+func (h *HealthChecker) handler(kind ProbeKind) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report := h.report(r.Context(), kind)
+
+		byt, err := json.Marshal(report)
+		if err != nil {
+			h.logger.Error(LogMessage("failure to marshal the health report"), logging.ErrorField(err))
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		status := http.StatusOK
+		if report.Status == DOWN_STATUS {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", JsonContentType)
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		w.Write(byt)
+	}
+}
+
+func (h *HealthChecker) report(ctx context.Context, kind ProbeKind) *Report {
+	cached := h.cache[kind]
+
+	cached.mu.Lock()
+	defer cached.mu.Unlock()
+
+	now := h.timeNow()
+	if cached.report != nil && now.Before(cached.expiresAt) {
+		return cached.report
+	}
+
+	report := h.runProbes(ctx, h.probes[kind])
+	report.Timestamp = now
+
+	cached.report = report
+	cached.expiresAt = now.Add(h.cacheTTL)
+
+	return report
+}
+
+func (h *HealthChecker) runProbes(ctx context.Context, probes []*Probe) *Report {
+	report := &Report{
+		Status: UP_STATUS,
+		Checks: make(map[string]*CheckResult, len(probes)),
+	}
+
+	results := make([]*CheckResult, len(probes))
+
+	wg := sync.WaitGroup{}
+	for i, p := range probes {
+		wg.Add(1)
+		go func(i int, p *Probe) {
+			defer wg.Done()
+			results[i] = h.runProbe(ctx, p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	for i, p := range probes {
+		report.Checks[p.Name] = results[i]
+		if results[i].Status == DOWN_STATUS {
+			report.Status = DOWN_STATUS
+		}
+	}
+
+	return report
+}
+
+func (h *HealthChecker) runProbe(ctx context.Context, p *Probe) *CheckResult {
+	timeout := p.Timeout
+	if timeout == 0 {
+		timeout = h.timeout
+	}
+
+	probeCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	errCh := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				errCh <- ErrorProbePanic
+			}
+		}()
+		errCh <- p.Check(probeCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-probeCtx.Done():
+		err = ErrorProbeTimeout
+	}
+
+	result := &CheckResult{
+		Status:   UP_STATUS,
+		Duration: time.Since(start).String(),
+	}
+
+	if err != nil {
+		h.logger.Warn(LogMessage("probe failure"), logging.MessageField("probe", p.Name), logging.ErrorField(err))
+		result.Status = DOWN_STATUS
+		result.Error = err.Error()
+	}
+
+	return result
+}
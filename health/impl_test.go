@@ -0,0 +1,143 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthCheckerTestSuite struct {
+	suite.Suite
+}
+
+func TestHealthCheckerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthCheckerTestSuite))
+}
+
+type amqpConnState struct {
+	closed bool
+}
+
+func (c *amqpConnState) IsClosed() bool {
+	return c.closed
+}
+
+type amqpFlowState struct {
+	blocked bool
+}
+
+func (f *amqpFlowState) IsBlocked() bool {
+	return f.blocked
+}
+
+type rabbitMQNode struct {
+	err error
+}
+
+func (n *rabbitMQNode) NodeHealth(ctx context.Context) error {
+	return n.err
+}
+
+func (s *HealthCheckerTestSuite) TestLiveness() {
+	checker := New(logging.NewMockLogger()).
+		Liveness(CustomProbe("custom", func(ctx context.Context) error { return nil })).
+		Build()
+
+	report := checker.Liveness(context.Background())
+
+	s.Equal(UP_STATUS, report.Status)
+	s.Equal(UP_STATUS, report.Checks["custom"].Status)
+}
+
+func (s *HealthCheckerTestSuite) TestReadinessDown() {
+	checker := New(logging.NewMockLogger()).
+		Readiness(RabbitMQProbe("rabbitmq", &amqpConnState{closed: true})).
+		Readiness(RedisProbe("redis", func(ctx context.Context) error { return nil })).
+		Readiness(RabbitMQNodeProbe("rabbitmq-node", &rabbitMQNode{err: errors.New("memory alarm")})).
+		Readiness(RabbitMQFlowProbe("rabbitmq-flow", &amqpFlowState{blocked: true})).
+		Build()
+
+	report := checker.Readiness(context.Background())
+
+	s.Equal(DOWN_STATUS, report.Status)
+	s.Equal(DOWN_STATUS, report.Checks["rabbitmq"].Status)
+	s.Equal(ErrorConnectionClose.Error(), report.Checks["rabbitmq"].Error)
+	s.Equal(UP_STATUS, report.Checks["redis"].Status)
+	s.Equal(DOWN_STATUS, report.Checks["rabbitmq-node"].Status)
+	s.Equal(ErrorBrokerBlocked.Error(), report.Checks["rabbitmq-flow"].Error)
+}
+
+func (s *HealthCheckerTestSuite) TestProbeTimeout() {
+	checker := New(logging.NewMockLogger()).
+		Readiness(&Probe{
+			Name:    "slow",
+			Timeout: time.Millisecond,
+			Check: func(ctx context.Context) error {
+				time.Sleep(50 * time.Millisecond)
+				return nil
+			},
+		}).
+		Build()
+
+	report := checker.Readiness(context.Background())
+
+	s.Equal(DOWN_STATUS, report.Status)
+	s.Equal(ErrorProbeTimeout.Error(), report.Checks["slow"].Error)
+}
+
+func (s *HealthCheckerTestSuite) TestProbePanic() {
+	checker := New(logging.NewMockLogger()).
+		Liveness(CustomProbe("panic", func(ctx context.Context) error { panic("some panic") })).
+		Build()
+
+	report := checker.Liveness(context.Background())
+
+	s.Equal(DOWN_STATUS, report.Status)
+	s.Equal(ErrorProbePanic.Error(), report.Checks["panic"].Error)
+}
+
+func (s *HealthCheckerTestSuite) TestCachedReport() {
+	calls := 0
+	checker := New(logging.NewMockLogger()).
+		CacheTTL(time.Minute).
+		Liveness(CustomProbe("counter", func(ctx context.Context) error {
+			calls++
+			return nil
+		})).
+		Build()
+
+	checker.Liveness(context.Background())
+	checker.Liveness(context.Background())
+
+	s.Equal(1, calls)
+}
+
+func (s *HealthCheckerTestSuite) TestHandlers() {
+	checker := New(logging.NewMockLogger()).
+		CacheTTL(0).
+		Liveness(CustomProbe("ok", func(ctx context.Context) error { return nil })).
+		Readiness(CustomProbe("err", func(ctx context.Context) error { return errors.New("some error") })).
+		Build()
+
+	rec := httptest.NewRecorder()
+	checker.LivenessHandler()(rec, httptest.NewRequest(http.MethodGet, LivenessPath, nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(JsonContentType, rec.Header().Get("Content-Type"))
+
+	rec = httptest.NewRecorder()
+	checker.ReadinessHandler()(rec, httptest.NewRequest(http.MethodGet, ReadinessPath, nil))
+
+	report := &Report{}
+	s.NoError(json.Unmarshal(rec.Body.Bytes(), report))
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	s.Equal(DOWN_STATUS, report.Status)
+	s.Equal("some error", report.Checks["err"].Error)
+}
@@ -0,0 +1,40 @@
+package health
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHealthChecker struct {
+	mock.Mock
+}
+
+func (m *MockHealthChecker) Liveness(ctx context.Context) *Report {
+	args := m.Called(ctx)
+
+	return args.Get(0).(*Report)
+}
+
+func (m *MockHealthChecker) Readiness(ctx context.Context) *Report {
+	args := m.Called(ctx)
+
+	return args.Get(0).(*Report)
+}
+
+func (m *MockHealthChecker) LivenessHandler() http.HandlerFunc {
+	args := m.Called()
+
+	return args.Get(0).(http.HandlerFunc)
+}
+
+func (m *MockHealthChecker) ReadinessHandler() http.HandlerFunc {
+	args := m.Called()
+
+	return args.Get(0).(http.HandlerFunc)
+}
+
+func NewMockHealthChecker() *MockHealthChecker {
+	return new(MockHealthChecker)
+}
@@ -0,0 +1,95 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+var (
+	ErrorProbeTimeout    = errors.New("probe timed out")
+	ErrorProbePanic      = errors.New("probe panicked")
+	ErrorConnectionClose = errors.New("connection is closed")
+	ErrorBrokerBlocked   = errors.New("broker is applying flow control")
+)
+
+type (
+	// AMQPConnectionState is satisfied by *amqp.Connection
+	AMQPConnectionState interface {
+		IsClosed() bool
+	}
+
+	// AMQPFlowControlState is satisfied by the rabbitmq messaging with WatchConnections or FlowControl
+	AMQPFlowControlState interface {
+		IsBlocked() bool
+	}
+
+	// RabbitMQNodeHealth is satisfied by the rabbitmq management client
+	RabbitMQNodeHealth interface {
+		NodeHealth(ctx context.Context) error
+	}
+)
+
+// SqlProbe checks the database pool using PingContext
+func SqlProbe(name string, db *sql.DB) *Probe {
+	return &Probe{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			return db.PingContext(ctx)
+		},
+	}
+}
+
+// RabbitMQProbe checks if the amqp connection still open
+func RabbitMQProbe(name string, conn AMQPConnectionState) *Probe {
+	return &Probe{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			if conn.IsClosed() {
+				return ErrorConnectionClose
+			}
+
+			return nil
+		},
+	}
+}
+
+// RabbitMQFlowProbe checks if the broker is blocking the publishers, e.g: to leave the load balancer while the alarm lasts
+func RabbitMQFlowProbe(name string, flow AMQPFlowControlState) *Probe {
+	return &Probe{
+		Name: name,
+		Check: func(ctx context.Context) error {
+			if flow.IsBlocked() {
+				return ErrorBrokerBlocked
+			}
+
+			return nil
+		},
+	}
+}
+
+// RabbitMQNodeProbe checks the broker node alarms through the management API
+func RabbitMQNodeProbe(name string, node RabbitMQNodeHealth) *Probe {
+	return &Probe{
+		Name:  name,
+		Check: node.NodeHealth,
+	}
+}
+
+// RedisProbe checks a redis client through its ping command
+//
+// e.g: health.RedisProbe("redis", func(ctx context.Context) error { return client.Ping(ctx).Err() })
+func RedisProbe(name string, ping CheckFunc) *Probe {
+	return &Probe{
+		Name:  name,
+		Check: ping,
+	}
+}
+
+// CustomProbe create a probe to any custom check
+func CustomProbe(name string, check CheckFunc) *Probe {
+	return &Probe{
+		Name:  name,
+		Check: check,
+	}
+}
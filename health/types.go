@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	Status    string
+	ProbeKind string
+
+	// CheckFunc verify if a component is healthy, a nil error means healthy
+	CheckFunc = func(ctx context.Context) error
+
+	// Probe a named check registered in the HealthChecker
+	Probe struct {
+		Name    string
+		Check   CheckFunc
+		Timeout time.Duration
+	}
+
+	// CheckResult the result of one probe execution
+	CheckResult struct {
+		Status   Status `json:"status"`
+		Duration string `json:"duration"`
+		Error    string `json:"error,omitempty"`
+	}
+
+	// Report the aggregated result of all probes of the same kind
+	Report struct {
+		Status    Status                  `json:"status"`
+		Timestamp time.Time               `json:"timestamp"`
+		Checks    map[string]*CheckResult `json:"checks"`
+	}
+
+	HealthBuilder interface {
+		// Default timeout used to the probes registered without timeout
+		Timeout(t time.Duration) HealthBuilder
+
+		// CacheTTL the time that a report will be reused before run the probes again
+		CacheTTL(t time.Duration) HealthBuilder
+
+		// Liveness register a probe that will be checked in /healthz
+		Liveness(probe *Probe) HealthBuilder
+
+		// Readiness register a probe that will be checked in /readyz
+		Readiness(probe *Probe) HealthBuilder
+
+		Build() IHealthChecker
+	}
+
+	IHealthChecker interface {
+		// Liveness execute all the liveness probes
+		Liveness(ctx context.Context) *Report
+
+		// Readiness execute all the readiness probes
+		Readiness(ctx context.Context) *Report
+
+		// LivenessHandler http handler that exposes the liveness report
+		LivenessHandler() http.HandlerFunc
+
+		// ReadinessHandler http handler that exposes the readiness report
+		ReadinessHandler() http.HandlerFunc
+	}
+
+	cachedReport struct {
+		mu        sync.Mutex
+		report    *Report
+		expiresAt time.Time
+	}
+
+	HealthChecker struct {
+		logger   logging.ILogger
+		timeout  time.Duration
+		cacheTTL time.Duration
+		probes   map[ProbeKind][]*Probe
+		cache    map[ProbeKind]*cachedReport
+		timeNow  func() time.Time
+	}
+)
@@ -0,0 +1,45 @@
+package client
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+const (
+	JsonContentType = "application/json"
+
+	DefaultTimeout             = 30 * time.Second
+	DefaultAttemptTimeout      = 10 * time.Second
+	DefaultMaxRetries          = 3
+	DefaultBackoffBase         = 100 * time.Millisecond
+	DefaultBackoffMax          = 2 * time.Second
+	DefaultMaxIdleConns        = 100
+	DefaultMaxIdleConnsPerHost = 10
+	DefaultIdleConnTimeout     = 90 * time.Second
+)
+
+var (
+	// errServerFailure records the 5xx responses as circuit breaker failures
+	errServerFailure = errors.New("server failure")
+
+	idempotentMethods = map[string]bool{
+		http.MethodGet:     true,
+		http.MethodHead:    true,
+		http.MethodOptions: true,
+		http.MethodTrace:   true,
+		http.MethodPut:     true,
+		http.MethodDelete:  true,
+	}
+
+	retryableStatus = map[int]bool{
+		http.StatusTooManyRequests:    true,
+		http.StatusBadGateway:         true,
+		http.StatusServiceUnavailable: true,
+		http.StatusGatewayTimeout:     true,
+	}
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::httpclient] " + msg
+}
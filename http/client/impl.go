@@ -1,13 +1,124 @@
 package client
 
 import (
+	"net"
 	"net/http"
+	"time"
 
+	"github.com/ralvescosta/gokit/circuitbreaker"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/requestid"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
 
+// New create a client with the default configuration: timeouts, retries, circuit breaker and tracing
 func New() *http.Client {
+	return NewBuilder(nil).Build()
+}
+
+// NewBuilder create a client builder, the logger is optional
+func NewBuilder(logger logging.ILogger) ClientBuilder {
+	return &httpClientBuilder{
+		logger:         logger,
+		timeout:        DefaultTimeout,
+		attemptTimeout: DefaultAttemptTimeout,
+		retry: &RetryOpts{
+			MaxRetries:  DefaultMaxRetries,
+			BackoffBase: DefaultBackoffBase,
+			BackoffMax:  DefaultBackoffMax,
+		},
+		breaker: &circuitbreaker.Config{},
+		pool: &PoolOpts{
+			MaxIdleConns:        DefaultMaxIdleConns,
+			MaxIdleConnsPerHost: DefaultMaxIdleConnsPerHost,
+			IdleConnTimeout:     DefaultIdleConnTimeout,
+		},
+		tracing: true,
+	}
+}
+
+func (b *httpClientBuilder) Timeout(timeout time.Duration) ClientBuilder {
+	b.timeout = timeout
+	return b
+}
+
+func (b *httpClientBuilder) AttemptTimeout(timeout time.Duration) ClientBuilder {
+	b.attemptTimeout = timeout
+	return b
+}
+
+// Retry nil disable the retries
+func (b *httpClientBuilder) Retry(opts *RetryOpts) ClientBuilder {
+	b.retry = opts
+	return b
+}
+
+// CircuitBreaker nil disable the circuit breaker, 5xx responses and transport errors are failures
+func (b *httpClientBuilder) CircuitBreaker(cfg *circuitbreaker.Config) ClientBuilder {
+	b.breaker = cfg
+	return b
+}
+
+func (b *httpClientBuilder) Pool(opts *PoolOpts) ClientBuilder {
+	b.pool = opts
+	return b
+}
+
+func (b *httpClientBuilder) WithoutTracing() ClientBuilder {
+	b.tracing = false
+	return b
+}
+
+// Build the transports are chained as: tracing -> request id -> retry -> circuit breaker -> pool
+func (b *httpClientBuilder) Build() *http.Client {
+	var transport http.RoundTripper = b.newPoolTransport()
+
+	if b.breaker != nil {
+		transport = &breakerTransport{
+			next:    transport,
+			breaker: circuitbreaker.New(b.breaker),
+		}
+	}
+
+	if b.retry != nil || b.attemptTimeout > 0 {
+		retry := b.retry
+		if retry == nil {
+			retry = &RetryOpts{}
+		}
+
+		transport = &retryTransport{
+			next:           transport,
+			logger:         b.logger,
+			opts:           retry,
+			attemptTimeout: b.attemptTimeout,
+		}
+	}
+
+	transport = requestid.Transport(transport)
+
+	if b.tracing {
+		transport = otelhttp.NewTransport(transport)
+	}
+
 	return &http.Client{
-		Transport: otelhttp.NewTransport(http.DefaultTransport),
+		Timeout:   b.timeout,
+		Transport: transport,
 	}
 }
+
+func (b *httpClientBuilder) newPoolTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{
+		Timeout:   5 * time.Second,
+		KeepAlive: 30 * time.Second,
+	}).DialContext
+
+	if b.pool != nil {
+		transport.MaxIdleConns = b.pool.MaxIdleConns
+		transport.MaxIdleConnsPerHost = b.pool.MaxIdleConnsPerHost
+		transport.MaxConnsPerHost = b.pool.MaxConnsPerHost
+		transport.IdleConnTimeout = b.pool.IdleConnTimeout
+	}
+
+	return transport
+}
@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/circuitbreaker"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/requestid"
+	"github.com/stretchr/testify/suite"
+)
+
+type HTTPClientTestSuite struct {
+	suite.Suite
+}
+
+type resBody struct {
+	Name string `json:"name"`
+}
+
+func TestHTTPClientTestSuite(t *testing.T) {
+	suite.Run(t, new(HTTPClientTestSuite))
+}
+
+func (s *HTTPClientTestSuite) server(failures int32, status int) (*httptest.Server, *int32) {
+	var calls int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(status)
+			return
+		}
+
+		json.NewEncoder(w).Encode(&resBody{Name: "name"})
+	}))
+
+	return srv, &calls
+}
+
+func (s *HTTPClientTestSuite) client() *http.Client {
+	return NewBuilder(logging.NewMockLogger()).
+		Retry(&RetryOpts{MaxRetries: 2, BackoffBase: time.Millisecond, BackoffMax: time.Millisecond}).
+		Build()
+}
+
+func (s *HTTPClientTestSuite) TestGetJSONWithRetry() {
+	srv, calls := s.server(2, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	res, err := GetJSON[resBody](context.Background(), s.client(), srv.URL)
+
+	s.NoError(err)
+	s.Equal("name", res.Name)
+	s.Equal(int32(3), atomic.LoadInt32(calls))
+}
+
+func (s *HTTPClientTestSuite) TestNotIdempotentMethod() {
+	srv, calls := s.server(1, http.StatusServiceUnavailable)
+	defer srv.Close()
+
+	_, err := PostJSON[resBody](context.Background(), s.client(), srv.URL, &resBody{})
+
+	httpErr := &HTTPError{}
+	s.True(errors.As(err, &httpErr))
+	s.Equal(http.StatusServiceUnavailable, httpErr.StatusCode)
+	s.Equal(int32(1), atomic.LoadInt32(calls))
+}
+
+func (s *HTTPClientTestSuite) TestCircuitBreaker() {
+	srv, calls := s.server(100, http.StatusInternalServerError)
+	defer srv.Close()
+
+	client := NewBuilder(nil).
+		Retry(nil).
+		CircuitBreaker(&circuitbreaker.Config{WindowSize: 2, MinimumCalls: 2, OpenTimeout: time.Minute}).
+		WithoutTracing().
+		Build()
+
+	for i := 0; i < 2; i++ {
+		_, err := GetJSON[resBody](context.Background(), client, srv.URL)
+		s.Error(err)
+	}
+
+	_, err := GetJSON[resBody](context.Background(), client, srv.URL)
+	s.ErrorIs(err, circuitbreaker.ErrorOpenState)
+	s.Equal(int32(2), atomic.LoadInt32(calls))
+}
+
+func (s *HTTPClientTestSuite) TestAttemptTimeout() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+	}))
+	defer srv.Close()
+
+	client := NewBuilder(nil).Retry(nil).AttemptTimeout(10 * time.Millisecond).Build()
+
+	_, err := GetJSON[resBody](context.Background(), client, srv.URL)
+	s.Error(err)
+}
+
+func (s *HTTPClientTestSuite) TestRequestIDPropagation() {
+	var received string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(requestid.HTTPHeader)
+		json.NewEncoder(w).Encode(&resBody{Name: "name"})
+	}))
+	defer srv.Close()
+
+	_, err := GetJSON[resBody](requestid.ContextWithID(context.Background(), "id"), s.client(), srv.URL)
+
+	s.NoError(err)
+	s.Equal("id", received)
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("unexpected http status: %d", e.StatusCode)
+}
+
+// GetJSON send a GET request and decode the JSON response
+func GetJSON[T any](ctx context.Context, client *http.Client, url string) (*T, error) {
+	return DoJSON[T](ctx, client, http.MethodGet, url, nil)
+}
+
+// PostJSON send a POST request with the JSON encoded body and decode the JSON response
+func PostJSON[T any](ctx context.Context, client *http.Client, url string, body any) (*T, error) {
+	return DoJSON[T](ctx, client, http.MethodPost, url, body)
+}
+
+// DoJSON send a request with the JSON encoded body and decode the JSON response, non 2xx responses returns *HTTPError
+func DoJSON[T any](ctx context.Context, client *http.Client, method, url string, body any) (*T, error) {
+	var reader io.Reader
+	if body != nil {
+		byt, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(byt)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", JsonContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", JsonContentType)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		byt, _ := io.ReadAll(res.Body)
+		return nil, &HTTPError{StatusCode: res.StatusCode, Body: byt}
+	}
+
+	result := new(T)
+	if res.StatusCode == http.StatusNoContent {
+		return result, nil
+	}
+
+	if err := json.NewDecoder(res.Body).Decode(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
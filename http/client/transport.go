@@ -0,0 +1,124 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ralvescosta/gokit/circuitbreaker"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	maxRetries := t.opts.MaxRetries
+	if !idempotentMethods[req.Method] || (req.Body != nil && req.Body != http.NoBody && req.GetBody == nil) {
+		maxRetries = 0
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		res, err := t.attempt(req)
+		if attempt >= maxRetries || !shouldRetry(res, err) {
+			return res, err
+		}
+
+		delay := t.backoff(attempt+1, res)
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		if t.logger != nil {
+			t.logger.Warn(LogMessage("retrying request"), logging.MessageField("url", req.URL.String()), logging.MessageField("attempt", strconv.Itoa(attempt+1)))
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (t *retryTransport) attempt(req *http.Request) (*http.Response, error) {
+	if t.attemptTimeout <= 0 {
+		return t.next.RoundTrip(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), t.attemptTimeout)
+	res, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// the context must live until the body is read
+	res.Body = &cancelBody{res.Body, cancel}
+	return res, nil
+}
+
+// backoff exponential backoff with full jitter, the Retry-After header is respected when present
+func (t *retryTransport) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if seconds, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+	}
+
+	delay := t.opts.BackoffBase << (attempt - 1)
+	if delay > t.opts.BackoffMax || delay <= 0 {
+		delay = t.opts.BackoffMax
+	}
+
+	if delay <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(delay)))
+}
+
+func shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, circuitbreaker.ErrorOpenState) && !errors.Is(err, circuitbreaker.ErrorTooManyRequests) && err != context.Canceled
+	}
+
+	return retryableStatus[res.StatusCode]
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func (t *breakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	done, err := t.breaker.Allow()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.next.RoundTrip(req)
+	if err == nil && res.StatusCode >= http.StatusInternalServerError {
+		done(errServerFailure)
+		return res, nil
+	}
+
+	done(err)
+	return res, err
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/ralvescosta/gokit/circuitbreaker"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// RetryOpts retry configuration, only idempotent methods are retried
+	RetryOpts struct {
+		MaxRetries  int
+		BackoffBase time.Duration
+		BackoffMax  time.Duration
+	}
+
+	// PoolOpts connection pool tuning
+	PoolOpts struct {
+		MaxIdleConns        int
+		MaxIdleConnsPerHost int
+		MaxConnsPerHost     int
+		IdleConnTimeout     time.Duration
+	}
+
+	ClientBuilder interface {
+		// Timeout the total timeout, including the retries
+		Timeout(timeout time.Duration) ClientBuilder
+		// AttemptTimeout the timeout of each attempt
+		AttemptTimeout(timeout time.Duration) ClientBuilder
+		Retry(opts *RetryOpts) ClientBuilder
+		CircuitBreaker(cfg *circuitbreaker.Config) ClientBuilder
+		Pool(opts *PoolOpts) ClientBuilder
+		// WithoutTracing disable the otelhttp transport
+		WithoutTracing() ClientBuilder
+		Build() *http.Client
+	}
+
+	// HTTPError returned by the JSON helpers when the response status is not 2xx
+	HTTPError struct {
+		StatusCode int
+		Body       []byte
+	}
+
+	httpClientBuilder struct {
+		logger         logging.ILogger
+		timeout        time.Duration
+		attemptTimeout time.Duration
+		retry          *RetryOpts
+		breaker        *circuitbreaker.Config
+		pool           *PoolOpts
+		tracing        bool
+	}
+
+	retryTransport struct {
+		next           http.RoundTripper
+		logger         logging.ILogger
+		opts           *RetryOpts
+		attemptTimeout time.Duration
+	}
+
+	breakerTransport struct {
+		next    http.RoundTripper
+		breaker circuitbreaker.ICircuitBreaker
+	}
+)
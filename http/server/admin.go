@@ -0,0 +1,151 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"runtime/pprof"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// AdminOpts the diagnostics server listen on a separate address protected with basic auth
+	AdminOpts struct {
+		Addr     string
+		User     string
+		Password string
+	}
+
+	// BuildInfo the binary build information
+	BuildInfo struct {
+		GoVersion string            `json:"goVersion"`
+		Path      string            `json:"path"`
+		Version   string            `json:"version"`
+		Settings  map[string]string `json:"settings"`
+	}
+)
+
+// sensitiveConfigs the configs with these words in the name are redacted in the config dump
+var sensitiveConfigs = []string{"PASSWORD", "SECRET", "KEY", "TOKEN"}
+
+func (s *HTTPServer) WithAdmin(opts *AdminOpts) HTTPServerBuilder {
+	if opts == nil {
+		opts = &AdminOpts{Addr: s.cfg.HTTP_ADMIN_ADDR, User: s.cfg.HTTP_ADMIN_USER, Password: s.cfg.HTTP_ADMIN_PASSWORD}
+	}
+
+	s.admin = opts
+	return s
+}
+
+// newAdminRouter mount pprof, expvar, build info, config dump, env catalog and goroutine dump endpoints
+func (s *HTTPServer) newAdminRouter() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Use(Recovery(s.logger))
+	router.Use(middleware.BasicAuth("admin", map[string]string{s.admin.User: s.admin.Password}))
+
+	router.Mount(ProfilingPath, middleware.Profiler())
+	router.Get(AdminBuildInfoPath, buildInfoHandler)
+	router.Get(AdminConfigPath, s.configHandler)
+	router.Get(AdminGoroutinesPath, goroutinesHandler)
+	router.Get(AdminEnvCatalogPath, env.CatalogHandler)
+
+	return router
+}
+
+// runAdmin the admin server failures are logged and do not stop the main server
+func (s *HTTPServer) runAdmin() {
+	if s.adminRouter == nil {
+		return
+	}
+
+	s.adminServer = &http.Server{
+		Addr:        s.admin.Addr,
+		ReadTimeout: s.readTimeout,
+		IdleTimeout: s.idleTimeout,
+		Handler:     s.adminRouter,
+	}
+
+	go func() {
+		s.logger.Info(LogMessage(fmt.Sprintf("admin server %s started", s.admin.Addr)))
+
+		if err := s.adminServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error(LogMessage("admin server error"), logging.ErrorField(err))
+		}
+	}()
+}
+
+func buildInfoHandler(w http.ResponseWriter, r *http.Request) {
+	info := &BuildInfo{GoVersion: runtime.Version(), Settings: map[string]string{}}
+
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.Path = bi.Main.Path
+		info.Version = bi.Main.Version
+
+		for _, setting := range bi.Settings {
+			info.Settings[setting.Key] = setting.Value
+		}
+	}
+
+	writeJSON(w, info)
+}
+
+func (s *HTTPServer) configHandler(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, RedactedConfigs(s.cfg))
+}
+
+func goroutinesHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_ = pprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// RedactedConfigs returns the configs with the credentials replaced, safe to be exposed
+func RedactedConfigs(cfg *env.Configs) map[string]any {
+	result := map[string]any{}
+	if cfg == nil {
+		return result
+	}
+
+	v := reflect.ValueOf(*cfg)
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() || field.Name == "Err" {
+			continue
+		}
+
+		value := v.Field(i).Interface()
+		if isSensitive(field.Name) && !v.Field(i).IsZero() {
+			value = RedactedValue
+		}
+
+		result[field.Name] = value
+	}
+
+	return result
+}
+
+func isSensitive(name string) bool {
+	for _, word := range sensitiveConfigs {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", JsonContentType)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,110 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/version"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdminTestSuite struct {
+	suite.Suite
+
+	server *HTTPServer
+}
+
+func TestAdminTestSuite(t *testing.T) {
+	suite.Run(t, new(AdminTestSuite))
+}
+
+func (s *AdminTestSuite) SetupTest() {
+	cfg := &env.Configs{
+		APP_NAME:              "app",
+		SQL_DB_PASSWORD:       "password",
+		IS_HTTP_ADMIN_ENABLED: true,
+		HTTP_ADMIN_ADDR:       "0.0.0.0:9090",
+		HTTP_ADMIN_USER:       "admin",
+		HTTP_ADMIN_PASSWORD:   "secret",
+	}
+
+	s.server = New(cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).(*HTTPServer)
+	s.server.adminRouter = s.server.newAdminRouter()
+}
+
+func (s *AdminTestSuite) request(path string, auth bool) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if auth {
+		req.SetBasicAuth("admin", "secret")
+	}
+
+	rec := httptest.NewRecorder()
+	s.server.adminRouter.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *AdminTestSuite) TestBasicAuth() {
+	s.Equal(http.StatusUnauthorized, s.request(AdminBuildInfoPath, false).Code)
+	s.Equal(http.StatusOK, s.request(AdminBuildInfoPath, true).Code)
+}
+
+func (s *AdminTestSuite) TestBuildInfo() {
+	rec := s.request(AdminBuildInfoPath, true)
+
+	info := BuildInfo{}
+	s.NoError(json.Unmarshal(rec.Body.Bytes(), &info))
+	s.NotEmpty(info.GoVersion)
+}
+
+func (s *AdminTestSuite) TestVersion() {
+	built, err := s.server.Build()
+	s.Require().NoError(err)
+	server := built.(*HTTPServer)
+
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, VersionPath, nil))
+
+	info := &version.Info{}
+	s.Equal(http.StatusOK, rec.Code)
+	s.NoError(json.Unmarshal(rec.Body.Bytes(), info))
+	s.Equal(version.Get(), info)
+}
+
+func (s *AdminTestSuite) TestConfigIsRedacted() {
+	rec := s.request(AdminConfigPath, true)
+
+	configs := map[string]any{}
+	s.NoError(json.Unmarshal(rec.Body.Bytes(), &configs))
+	s.Equal("app", configs["APP_NAME"])
+	s.Equal(RedactedValue, configs["SQL_DB_PASSWORD"])
+	s.Equal(RedactedValue, configs["HTTP_ADMIN_PASSWORD"])
+	s.NotContains(rec.Body.String(), "secret")
+}
+
+func (s *AdminTestSuite) TestEnvCatalog() {
+	rec := s.request(AdminEnvCatalogPath, true)
+
+	catalog := []*env.EnvVar{}
+	s.NoError(json.Unmarshal(rec.Body.Bytes(), &catalog))
+	s.Equal(env.Catalog(), catalog)
+}
+
+func (s *AdminTestSuite) TestGoroutinesAndProfiling() {
+	rec := s.request(AdminGoroutinesPath, true)
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), "goroutine")
+
+	s.Equal(http.StatusOK, s.request(ProfilingPath+"/pprof/", true).Code)
+	s.Equal(http.StatusOK, s.request(ProfilingPath+"/vars", true).Code)
+}
+
+func (s *AdminTestSuite) TestRunWithoutCredentials() {
+	s.server.admin.Password = ""
+	s.ErrorIs(s.server.Run(), ErrorAdminCredentials)
+}
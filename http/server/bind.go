@@ -0,0 +1,202 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/ralvescosta/gokit/validation"
+)
+
+const (
+	PathTag  = "path"
+	QueryTag = "query"
+)
+
+var (
+	validate     *validator.Validate
+	validateOnce sync.Once
+)
+
+// Validator returns the shared validator with the validation package rules, use it to register custom validations
+func Validator() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = validation.New()
+		validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+			for _, tag := range []string{"json", QueryTag, PathTag} {
+				name := strings.SplitN(f.Tag.Get(tag), ",", 2)[0]
+				if name != "" && name != "-" {
+					return name
+				}
+			}
+			return f.Name
+		})
+	})
+
+	return validate
+}
+
+// Bind decode the request into T and write a problem details response when something goes wrong
+//
+// The body is decoded as JSON, the fields tagged with `path:"name"` are filled with the route params
+// and the fields tagged with `query:"name"` are filled with the query string. After that the `validate` tags are checked.
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//		req, ok := server.Bind[CreateUserRequest](w, r)
+//		if !ok {
+//			return
+//		}
+//	}
+func Bind[T any](w http.ResponseWriter, r *http.Request) (*T, bool) {
+	v, err := Decode[T](r)
+	if err != nil {
+		WriteProblem(w, r, err)
+		return nil, false
+	}
+
+	return v, true
+}
+
+// Decode decode and validate the request into T returning a ProblemDetails as error
+func Decode[T any](r *http.Request) (*T, *ProblemDetails) {
+	v := new(T)
+
+	if r.Body != nil && r.ContentLength != 0 {
+		err := json.NewDecoder(r.Body).Decode(v)
+		if errors.Is(err, ErrorBodyTooLarge) {
+			return nil, ProblemFromError(err)
+		}
+
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, NewProblem(http.StatusBadRequest, "malformed json body")
+		}
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() == reflect.Struct {
+		if p := bindTagged(r, rv); p != nil {
+			return nil, p
+		}
+	}
+
+	if err := Validator().Struct(v); err != nil {
+		var vErrs validator.ValidationErrors
+		if !errors.As(err, &vErrs) {
+			return v, nil
+		}
+
+		problem := NewProblem(http.StatusUnprocessableEntity, "request validation failure")
+		for _, fe := range vErrs {
+			problem.InvalidParams = append(problem.InvalidParams, InvalidParam{
+				Name:   fe.Field(),
+				Reason: validation.Message(fe),
+			})
+		}
+
+		return nil, problem
+	}
+
+	return v, nil
+}
+
+func bindTagged(r *http.Request, rv reflect.Value) *ProblemDetails {
+	query := r.URL.Query()
+	rt := rv.Type()
+
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		var values []string
+		var name string
+
+		if name = field.Tag.Get(PathTag); name != "" {
+			if p := chi.URLParam(r, name); p != "" {
+				values = []string{p}
+			}
+		} else if name = field.Tag.Get(QueryTag); name != "" {
+			values = query[name]
+		}
+
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := setField(rv.Field(i), values); err != nil {
+			problem := NewProblem(http.StatusBadRequest, "invalid parameter")
+			problem.InvalidParams = []InvalidParam{{Name: name, Reason: err.Error()}}
+			return problem
+		}
+	}
+
+	return nil
+}
+
+func setField(f reflect.Value, values []string) error {
+	if f.Kind() == reflect.Pointer {
+		ptr := reflect.New(f.Type().Elem())
+		if err := setField(ptr.Elem(), values); err != nil {
+			return err
+		}
+		f.Set(ptr)
+		return nil
+	}
+
+	if f.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(f.Type(), len(values), len(values))
+		for i, v := range values {
+			if err := setValue(slice.Index(i), v); err != nil {
+				return err
+			}
+		}
+		f.Set(slice)
+		return nil
+	}
+
+	return setValue(f, values[0])
+}
+
+func setValue(f reflect.Value, raw string) error {
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(raw)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("expected a boolean")
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, f.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected an integer")
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(raw, 10, f.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected a positive integer")
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(raw, f.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("expected a number")
+		}
+		f.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported type %s", f.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/ralvescosta/gokit/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type BindTestSuite struct {
+	suite.Suite
+}
+
+func TestBindTestSuite(t *testing.T) {
+	suite.Run(t, new(BindTestSuite))
+}
+
+type bindRequest struct {
+	ID    int      `path:"id" validate:"required"`
+	Page  *int     `query:"page"`
+	Tags  []string `query:"tag"`
+	Name  string   `json:"name" validate:"required,min=3"`
+	Email string   `json:"email" validate:"omitempty,email"`
+}
+
+func (s *BindTestSuite) request(body string, target string, id string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("id", id)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func (s *BindTestSuite) TestBind() {
+	rec := httptest.NewRecorder()
+	req, ok := Bind[bindRequest](rec, s.request(`{"name":"gokit"}`, "/users/10?page=2&tag=a&tag=b", "10"))
+
+	s.True(ok)
+	s.Equal(10, req.ID)
+	s.Equal(2, *req.Page)
+	s.Equal([]string{"a", "b"}, req.Tags)
+	s.Equal("gokit", req.Name)
+}
+
+func (s *BindTestSuite) TestBindValidationProblem() {
+	rec := httptest.NewRecorder()
+	_, ok := Bind[bindRequest](rec, s.request(`{"name":"go","email":"invalid"}`, "/users/10", "10"))
+
+	problem := &ProblemDetails{}
+	json.Unmarshal(rec.Body.Bytes(), problem)
+
+	s.False(ok)
+	s.Equal(http.StatusUnprocessableEntity, rec.Code)
+	s.Equal(ProblemContentType, rec.Header().Get("Content-Type"))
+	s.Len(problem.InvalidParams, 2)
+	s.Equal("name", problem.InvalidParams[0].Name)
+	s.Equal("must be at least 3", problem.InvalidParams[0].Reason)
+}
+
+func (s *BindTestSuite) TestBindMalformed() {
+	rec := httptest.NewRecorder()
+	_, ok := Bind[bindRequest](rec, s.request(`{"name":`, "/users/10", "10"))
+
+	s.False(ok)
+	s.Equal(http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	_, ok = Bind[bindRequest](rec, s.request(`{"name":"gokit"}`, "/users/abc", "abc"))
+
+	s.False(ok)
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *BindTestSuite) TestWriteError() {
+	rec := httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodGet, "/users/1", nil), errors.NotFound("user not found").WithCode("USER_NOT_FOUND"))
+
+	problem := &ProblemDetails{}
+	json.Unmarshal(rec.Body.Bytes(), problem)
+
+	s.Equal(http.StatusNotFound, rec.Code)
+	s.Equal("USER_NOT_FOUND", problem.Type)
+	s.Equal("user not found", problem.Detail)
+	s.Equal("/users/1", problem.Instance)
+
+	rec = httptest.NewRecorder()
+	WriteError(rec, httptest.NewRequest(http.MethodGet, "/", nil), errors.New("database password leaked"))
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+	s.NotContains(rec.Body.String(), "password")
+}
@@ -0,0 +1,274 @@
+package server
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+type (
+	// CompressionOpts the responses are buffered until MinSize to decide if they are compressed, the already encoded
+	// responses, e.g: the precompressed static assets, are never compressed again
+	CompressionOpts struct {
+		// MinSize default DefaultCompressionMinSize
+		MinSize int
+		// ContentTypes the compressible content types, the entries ending with / match the prefix, e.g: text/,
+		// default DefaultCompressibleTypes
+		ContentTypes []string
+		// GzipLevel default gzip.DefaultCompression
+		GzipLevel int
+		// BrotliLevel default DefaultBrotliLevel, the higher levels are too slow to the dynamic responses
+		BrotliLevel int
+		// DisableBrotli only gzip is negotiated
+		DisableBrotli bool
+	}
+
+	compressWriter struct {
+		http.ResponseWriter
+		opts     *CompressionOpts
+		encoding string
+		status   int
+		buf      []byte
+		decided  bool
+		encoder  io.WriteCloser
+	}
+)
+
+const (
+	DefaultCompressionMinSize = 1024
+	DefaultBrotliLevel        = 4
+)
+
+var DefaultCompressibleTypes = []string{
+	"text/",
+	JsonContentType,
+	ProblemContentType,
+	"application/javascript",
+	"application/xml",
+	"application/x-ndjson",
+	"image/svg+xml",
+}
+
+var gzipWriters sync.Pool
+
+func (s *HTTPServer) WithCompression(opts *CompressionOpts) HTTPServerBuilder {
+	if opts == nil {
+		opts = &CompressionOpts{}
+	}
+
+	s.compression = opts
+	return s
+}
+
+// Compression negotiate the br or gzip encoding with the Accept-Encoding and compress the responses bigger than the MinSize
+// with an allowed content type, the event streams are not in the default content types so they are never buffered
+func Compression(opts *CompressionOpts) Middleware {
+	if opts == nil {
+		opts = &CompressionOpts{}
+	}
+
+	if opts.MinSize == 0 {
+		opts.MinSize = DefaultCompressionMinSize
+	}
+
+	if len(opts.ContentTypes) == 0 {
+		opts.ContentTypes = DefaultCompressibleTypes
+	}
+
+	if opts.GzipLevel == 0 {
+		opts.GzipLevel = gzip.DefaultCompression
+	}
+
+	if opts.BrotliLevel == 0 {
+		opts.BrotliLevel = DefaultBrotliLevel
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			encoding := negotiateEncoding(r, opts)
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Add("Vary", "Accept-Encoding")
+
+			cw := &compressWriter{ResponseWriter: w, opts: opts, encoding: encoding, status: http.StatusOK}
+			defer cw.Close()
+
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+func (opts *CompressionOpts) compressible(contentType string) bool {
+	contentType, _, _ = strings.Cut(contentType, ";")
+	contentType = strings.TrimSpace(strings.ToLower(contentType))
+
+	for _, allowed := range opts.ContentTypes {
+		if strings.HasSuffix(allowed, "/") && strings.HasPrefix(contentType, allowed) || contentType == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func negotiateEncoding(r *http.Request, opts *CompressionOpts) string {
+	if !opts.DisableBrotli && acceptsEncoding(r, "br") {
+		return "br"
+	}
+
+	if acceptsEncoding(r, "gzip") {
+		return "gzip"
+	}
+
+	return ""
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.decided {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.status = status
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(p)
+		}
+
+		return w.ResponseWriter.Write(p)
+	}
+
+	w.buf = append(w.buf, p...)
+	if len(w.buf) < w.opts.MinSize {
+		return len(p), nil
+	}
+
+	if err := w.flushBuffer(); err != nil {
+		return 0, err
+	}
+
+	return len(p), nil
+}
+
+// flushBuffer decide the encoding with the buffered content and write it
+func (w *compressWriter) flushBuffer() error {
+	w.decided = true
+	header := w.Header()
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" && len(w.buf) > 0 {
+		contentType = http.DetectContentType(w.buf)
+		header.Set("Content-Type", contentType)
+	}
+
+	if header.Get("Content-Encoding") == "" &&
+		len(w.buf) >= w.opts.MinSize &&
+		w.status != http.StatusNoContent && w.status != http.StatusNotModified &&
+		w.opts.compressible(contentType) {
+		header.Set("Content-Encoding", w.encoding)
+		header.Del("Content-Length")
+
+		// the compressed representation is not byte-for-byte equal to the strong ETag one
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.encoder = w.newEncoder()
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+
+	if len(buf) == 0 {
+		return nil
+	}
+
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+
+	return err
+}
+
+func (w *compressWriter) newEncoder() io.WriteCloser {
+	if w.encoding == "br" {
+		return brotli.NewWriterLevel(w.ResponseWriter, w.opts.BrotliLevel)
+	}
+
+	// only the default level writers are pooled
+	if w.opts.GzipLevel == gzip.DefaultCompression {
+		if gz, ok := gzipWriters.Get().(*gzip.Writer); ok {
+			gz.Reset(w.ResponseWriter)
+			return gz
+		}
+	}
+
+	gz, _ := gzip.NewWriterLevel(w.ResponseWriter, w.opts.GzipLevel)
+	return gz
+}
+
+// Flush write the buffered content, so the streamed responses are not held until the MinSize
+func (w *compressWriter) Flush() {
+	if !w.decided {
+		w.flushBuffer()
+	}
+
+	if f, ok := w.encoder.(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *compressWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	w.decided = true
+	return hijacker.Hijack()
+}
+
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close write the responses smaller than the MinSize and finish the compressed stream
+func (w *compressWriter) Close() error {
+	if !w.decided {
+		if err := w.flushBuffer(); err != nil {
+			return err
+		}
+	}
+
+	if w.encoder == nil {
+		return nil
+	}
+
+	err := w.encoder.Close()
+	if gz, ok := w.encoder.(*gzip.Writer); ok && w.opts.GzipLevel == gzip.DefaultCompression {
+		gzipWriters.Put(gz)
+	}
+
+	w.encoder = nil
+	return err
+}
@@ -0,0 +1,103 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/suite"
+)
+
+type CompressionTestSuite struct {
+	suite.Suite
+
+	body string
+}
+
+func TestCompressionTestSuite(t *testing.T) {
+	suite.Run(t, new(CompressionTestSuite))
+}
+
+func (s *CompressionTestSuite) SetupTest() {
+	s.body = strings.Repeat(`{"name":"gokit"}`, 100)
+}
+
+func (s *CompressionTestSuite) serve(handler http.HandlerFunc, acceptEncoding string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", acceptEncoding)
+
+	rec := httptest.NewRecorder()
+	Compression(nil)(handler).ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *CompressionTestSuite) jsonHandler(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", JsonContentType)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(body))
+	}
+}
+
+func (s *CompressionTestSuite) TestGzip() {
+	rec := s.serve(s.jsonHandler(s.body), "gzip")
+
+	s.Equal(http.StatusCreated, rec.Code)
+	s.Equal("gzip", rec.Header().Get("Content-Encoding"))
+	s.Equal("Accept-Encoding", rec.Header().Get("Vary"))
+
+	gz, err := gzip.NewReader(rec.Body)
+	s.Require().NoError(err)
+	content, _ := io.ReadAll(gz)
+	s.Equal(s.body, string(content))
+}
+
+func (s *CompressionTestSuite) TestBrotli() {
+	rec := s.serve(s.jsonHandler(s.body), "gzip, br")
+
+	s.Equal("br", rec.Header().Get("Content-Encoding"))
+
+	content, _ := io.ReadAll(brotli.NewReader(rec.Body))
+	s.Equal(s.body, string(content))
+}
+
+func (s *CompressionTestSuite) TestSkipped() {
+	rec := s.serve(s.jsonHandler(`{"name":"gokit"}`), "gzip")
+	s.Empty(rec.Header().Get("Content-Encoding"))
+	s.Equal(http.StatusCreated, rec.Code)
+	s.Equal(`{"name":"gokit"}`, rec.Body.String())
+
+	rec = s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte(s.body))
+	}, "gzip")
+	s.Empty(rec.Header().Get("Content-Encoding"))
+
+	rec = s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", JsonContentType)
+		w.Header().Set("Content-Encoding", "br")
+		w.Write([]byte(s.body))
+	}, "gzip")
+	s.Equal("br", rec.Header().Get("Content-Encoding"))
+	s.Equal(s.body, rec.Body.String())
+
+	rec = s.serve(s.jsonHandler(s.body), "identity")
+	s.Empty(rec.Header().Get("Content-Encoding"))
+}
+
+func (s *CompressionTestSuite) TestFlush() {
+	rec := s.serve(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Write([]byte("data: 1\n\n"))
+		w.(http.Flusher).Flush()
+	}, "gzip")
+
+	s.True(rec.Flushed)
+	s.Empty(rec.Header().Get("Content-Encoding"))
+	s.Equal("data: 1\n\n", rec.Body.String())
+}
@@ -4,11 +4,42 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
+)
+
+const (
+	RequestIDHeader = "X-Request-Id"
+	MetricsPath     = "/metrics"
+	ProfilingPath   = "/debug"
+	HeartbeatPath   = "/heartbeat"
+	VersionPath     = "/version"
+	JsonContentType = "application/json"
+
+	AdminBuildInfoPath  = "/buildinfo"
+	AdminConfigPath     = "/config"
+	AdminGoroutinesPath = "/goroutines"
+	AdminEnvCatalogPath = "/env"
+	RedactedValue       = "xxxxx"
+
+	DefaultReadTimeout     = 5 * time.Second
+	DefaultWriteTimeout    = 10 * time.Second
+	DefaultIdleTimeout     = 30 * time.Second
+	DefaultShutdownTimeout = 30 * time.Second
 )
 
 var (
 	ErrorInvalidHttpMethod = errors.New("invalid http method")
-	allowedHTTPMethods     = map[string]bool{http.MethodGet: true, http.MethodPost: true, http.MethodPut: true, http.MethodPatch: true, http.MethodDelete: true}
+	ErrorTLSFilesRequired  = errors.New("tls cert and key paths are required")
+	ErrorAdminCredentials  = errors.New("admin server requires the addr, user and password")
+	ErrorStaticIndex       = errors.New("the spa static assets require the index")
+	ErrorAutocertDomains   = errors.New("autocert requires the domains")
+	ErrorBodyTooLarge      = errors.New("request body too large")
+	ErrorMetricsHandler    = errors.New("metrics enabled without a metrics handler")
+	ErrorSSEInvalidField   = errors.New("sse event id and event fields can not contain line breaks")
+
+	ErrorCORSOrigin              = errors.New("invalid cors origin")
+	ErrorCORSWildcardCredentials = errors.New("cors can not allow the * origin with credentials in production")
+	allowedHTTPMethods           = map[string]bool{http.MethodGet: true, http.MethodPost: true, http.MethodPut: true, http.MethodPatch: true, http.MethodDelete: true}
 )
 
 func OTLPOperationName(method, path string) string {
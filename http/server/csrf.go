@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+type (
+	CSRFMode int8
+
+	// CSRFTokenStore keep the synchronizer token bound to the user session, e.g: sessions.CSRFStore
+	CSRFTokenStore interface {
+		Get(r *http.Request) (string, error)
+		Set(r *http.Request, token string) error
+	}
+
+	// CSRFOpts the tokens are sent by the clients in the HeaderName header or in the FormField form field
+	CSRFOpts struct {
+		Mode CSRFMode
+		// Store required to the SYNCHRONIZER_TOKEN mode
+		Store CSRFTokenStore
+		// Secret when set the DOUBLE_SUBMIT_COOKIE tokens are signed with HMAC-SHA256, so a cookie injected by a subdomain is rejected
+		Secret []byte
+		// CookieName default DefaultCSRFCookieName, the cookie is readable by the javascript
+		CookieName string
+		// HeaderName default CSRFHeader
+		HeaderName string
+		// FormField default DefaultCSRFFormField
+		FormField string
+		// TokenPath the SPA token endpoint, default CSRFTokenPath
+		TokenPath string
+		// Exempt the paths that are not verified, e.g: the webhooks, the patterns ending with /* match the prefix
+		Exempt []string
+		// Insecure allows the cookie over http, only to the local development
+		Insecure bool
+	}
+
+	csrfCtxKey struct{}
+)
+
+const (
+	// DOUBLE_SUBMIT_COOKIE the token is sent in a cookie and must be echoed in the header, no server state is required
+	DOUBLE_SUBMIT_COOKIE CSRFMode = iota
+	// SYNCHRONIZER_TOKEN the token is kept in the user session and must be sent in the header or in the form
+	SYNCHRONIZER_TOKEN
+)
+
+const (
+	CSRFHeader            = "X-CSRF-Token"
+	CSRFTokenPath         = "/csrf-token"
+	DefaultCSRFCookieName = "csrf_token"
+	DefaultCSRFFormField  = "csrf_token"
+
+	csrfTokenBytes = 32
+)
+
+var (
+	ErrorCSRFToken      = errors.New("invalid csrf token")
+	ErrorCSRFStore      = errors.New("the csrf synchronizer token mode requires a store")
+	csrfSafeHTTPMethods = map[string]bool{http.MethodGet: true, http.MethodHead: true, http.MethodOptions: true, http.MethodTrace: true}
+)
+
+// WithCSRF enable the CSRF middleware and the token endpoint in the opts.TokenPath
+func (s *HTTPServer) WithCSRF(opts *CSRFOpts) HTTPServerBuilder {
+	s.csrf = csrfDefaults(opts)
+	return s
+}
+
+// CSRF the safe methods receive the token, the other methods are rejected with 403 when the token is missing or invalid.
+// The token of the request is available through CSRFToken, e.g: to render it in the forms
+//
+// The SYNCHRONIZER_TOKEN mode without a store responds 500, the server Run returns ErrorCSRFStore in this case
+func CSRF(opts *CSRFOpts) Middleware {
+	opts = csrfDefaults(opts)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if csrfExempt(opts.Exempt, r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if opts.Mode == SYNCHRONIZER_TOKEN && opts.Store == nil {
+				WriteProblem(w, r, NewProblem(http.StatusInternalServerError, ErrorCSRFStore.Error()))
+				return
+			}
+
+			token, err := csrfCurrentToken(opts, r)
+			if err != nil {
+				WriteProblem(w, r, NewProblem(http.StatusInternalServerError, ""))
+				return
+			}
+
+			if !csrfSafeHTTPMethods[r.Method] && !csrfValid(token, csrfSubmittedToken(opts, r)) {
+				WriteProblem(w, r, NewProblem(http.StatusForbidden, ErrorCSRFToken.Error()))
+				return
+			}
+
+			if token == "" {
+				if token, err = csrfNewToken(opts, w, r); err != nil {
+					WriteProblem(w, r, NewProblem(http.StatusInternalServerError, ""))
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), csrfCtxKey{}, token)))
+		})
+	}
+}
+
+// CSRFToken the token of the request, empty when the CSRF middleware did not run
+func CSRFToken(ctx context.Context) string {
+	token, _ := ctx.Value(csrfCtxKey{}).(string)
+	return token
+}
+
+// CSRFTokenHandler respond {"token": "..."} to the SPAs that can not read the cookie, it must run after the CSRF middleware
+func CSRFTokenHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", JsonContentType)
+	w.Header().Set("Cache-Control", "no-store")
+
+	json.NewEncoder(w).Encode(map[string]string{"token": CSRFToken(r.Context())})
+}
+
+func csrfDefaults(opts *CSRFOpts) *CSRFOpts {
+	if opts == nil {
+		opts = &CSRFOpts{}
+	}
+
+	if opts.CookieName == "" {
+		opts.CookieName = DefaultCSRFCookieName
+	}
+
+	if opts.HeaderName == "" {
+		opts.HeaderName = CSRFHeader
+	}
+
+	if opts.FormField == "" {
+		opts.FormField = DefaultCSRFFormField
+	}
+
+	if opts.TokenPath == "" {
+		opts.TokenPath = CSRFTokenPath
+	}
+
+	return opts
+}
+
+func csrfCurrentToken(opts *CSRFOpts, r *http.Request) (string, error) {
+	if opts.Mode == SYNCHRONIZER_TOKEN {
+		return opts.Store.Get(r)
+	}
+
+	cookie, err := r.Cookie(opts.CookieName)
+	if err != nil || (opts.Secret != nil && !csrfSigned(opts.Secret, cookie.Value)) {
+		return "", nil
+	}
+
+	return cookie.Value, nil
+}
+
+func csrfNewToken(opts *CSRFOpts, w http.ResponseWriter, r *http.Request) (string, error) {
+	nonce := make([]byte, csrfTokenBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(nonce)
+
+	if opts.Mode == SYNCHRONIZER_TOKEN {
+		return token, opts.Store.Set(r, token)
+	}
+
+	if opts.Secret != nil {
+		token += "." + csrfSignature(opts.Secret, token)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     opts.CookieName,
+		Value:    token,
+		Path:     "/",
+		Secure:   !opts.Insecure,
+		SameSite: http.SameSiteStrictMode,
+	})
+
+	return token, nil
+}
+
+func csrfSubmittedToken(opts *CSRFOpts, r *http.Request) string {
+	if token := r.Header.Get(opts.HeaderName); token != "" {
+		return token
+	}
+
+	contentType := r.Header.Get("Content-Type")
+	if strings.HasPrefix(contentType, "application/x-www-form-urlencoded") || strings.HasPrefix(contentType, "multipart/form-data") {
+		return r.PostFormValue(opts.FormField)
+	}
+
+	return ""
+}
+
+func csrfValid(expected, submitted string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(submitted)) == 1
+}
+
+func csrfSignature(secret []byte, nonce string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(nonce))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func csrfSigned(secret []byte, token string) bool {
+	nonce, signature, ok := strings.Cut(token, ".")
+	return ok && hmac.Equal([]byte(csrfSignature(secret, nonce)), []byte(signature))
+}
+
+func csrfExempt(patterns []string, path string) bool {
+	for _, p := range patterns {
+		if strings.HasSuffix(p, "/*") {
+			prefix := strings.TrimSuffix(p, "/*")
+			if path == prefix || strings.HasPrefix(path, prefix+"/") {
+				return true
+			}
+
+			continue
+		}
+
+		if p == path {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,111 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CSRFTestSuite struct {
+	suite.Suite
+
+	handler http.Handler
+}
+
+type memoryCSRFStore struct {
+	token string
+}
+
+func (m *memoryCSRFStore) Get(r *http.Request) (string, error) {
+	return m.token, nil
+}
+
+func (m *memoryCSRFStore) Set(r *http.Request, token string) error {
+	m.token = token
+	return nil
+}
+
+func TestCSRFTestSuite(t *testing.T) {
+	suite.Run(t, new(CSRFTestSuite))
+}
+
+func (s *CSRFTestSuite) serve(opts *CSRFOpts, req *http.Request) *httptest.ResponseRecorder {
+	mux := http.NewServeMux()
+	mux.HandleFunc(CSRFTokenPath, CSRFTokenHandler)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusNoContent) })
+
+	rec := httptest.NewRecorder()
+	CSRF(opts)(mux).ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *CSRFTestSuite) TestDoubleSubmitCookie() {
+	opts := &CSRFOpts{Secret: []byte("secret")}
+
+	rec := s.serve(opts, httptest.NewRequest(http.MethodGet, CSRFTokenPath, nil))
+	s.Equal(http.StatusOK, rec.Code)
+
+	cookie := rec.Result().Cookies()[0]
+	s.Equal(DefaultCSRFCookieName, cookie.Name)
+	s.False(cookie.HttpOnly)
+	s.True(cookie.Secure)
+	s.Contains(rec.Body.String(), cookie.Value)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.AddCookie(cookie)
+	req.Header.Set(CSRFHeader, cookie.Value)
+	s.Equal(http.StatusNoContent, s.serve(opts, req).Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.AddCookie(cookie)
+	s.Equal(http.StatusForbidden, s.serve(opts, req).Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.AddCookie(&http.Cookie{Name: DefaultCSRFCookieName, Value: "forged.signature"})
+	req.Header.Set(CSRFHeader, "forged.signature")
+	s.Equal(http.StatusForbidden, s.serve(opts, req).Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/orders", nil)
+	req.Header.Set(CSRFHeader, cookie.Value)
+	s.Equal(http.StatusForbidden, s.serve(opts, req).Code)
+}
+
+func (s *CSRFTestSuite) TestSynchronizerToken() {
+	store := &memoryCSRFStore{}
+	opts := &CSRFOpts{Mode: SYNCHRONIZER_TOKEN, Store: store}
+
+	rec := s.serve(opts, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal(http.StatusNoContent, rec.Code)
+	s.Empty(rec.Result().Cookies())
+	s.NotEmpty(store.token)
+
+	form := url.Values{DefaultCSRFFormField: {store.token}}
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	s.Equal(http.StatusNoContent, s.serve(opts, req).Code)
+
+	req = httptest.NewRequest(http.MethodDelete, "/orders/1", nil)
+	req.Header.Set(CSRFHeader, "other")
+	s.Equal(http.StatusForbidden, s.serve(opts, req).Code)
+
+	s.Equal(http.StatusInternalServerError, s.serve(&CSRFOpts{Mode: SYNCHRONIZER_TOKEN}, req).Code)
+}
+
+func (s *CSRFTestSuite) TestExempt() {
+	opts := &CSRFOpts{Exempt: []string{"/webhooks/*", "/login"}}
+
+	for path, expected := range map[string]int{
+		"/webhooks":         http.StatusNoContent,
+		"/webhooks/payment": http.StatusNoContent,
+		"/login":            http.StatusNoContent,
+		"/webhooksx":        http.StatusForbidden,
+		"/login/other":      http.StatusForbidden,
+	} {
+		s.Equal(expected, s.serve(opts, httptest.NewRequest(http.MethodPost, path, nil)).Code, path)
+	}
+}
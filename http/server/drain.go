@@ -0,0 +1,132 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/ralvescosta/gokit/health"
+	"github.com/ralvescosta/gokit/logging"
+	"go.uber.org/zap"
+)
+
+func (s *HTTPServer) WithDraining(delay, timeout time.Duration) HTTPServerBuilder {
+	s.drainDelay = delay
+
+	if timeout != 0 {
+		s.shutdownTimeout = timeout
+	}
+
+	return s
+}
+
+// Shutdown could be called more than once, e.g: by the signal and by the app coordinator, the drain is executed only once
+func (s *HTTPServer) Shutdown(ctx context.Context) error {
+	s.shutdownOnce.Do(func() {
+		s.shutdownErr = s.drain(ctx)
+		close(s.drained)
+	})
+
+	return s.shutdownErr
+}
+
+func (s *HTTPServer) isDraining() bool {
+	return atomic.LoadInt32(&s.draining) == 1
+}
+
+// drain fail the readiness, wait the drain delay so the load balancers remove the instance, then stop accepting
+// connections and wait the in-flight requests, the connections still open after the timeout are closed
+func (s *HTTPServer) drain(ctx context.Context) error {
+	atomic.StoreInt32(&s.draining, 1)
+	s.logger.Info(LogMessage("draining the http server..."), zap.Duration("delay", s.drainDelay))
+
+	if s.drainDelay > 0 {
+		timer := time.NewTimer(s.drainDelay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, s.shutdownTimeout)
+	defer cancel()
+
+	for _, hook := range s.shutdownHooks {
+		if err := hook(shutdownCtx); err != nil {
+			s.logger.Error(LogMessage("shutdown hook failure"), logging.ErrorField(err))
+		}
+	}
+
+	if s.adminServer != nil {
+		if err := s.adminServer.Shutdown(shutdownCtx); err != nil {
+			s.logger.Error(LogMessage("admin server shutdown failure"), logging.ErrorField(err))
+		}
+	}
+
+	s.mu.Lock()
+	server := s.server
+	s.mu.Unlock()
+
+	if server == nil {
+		return nil
+	}
+
+	err := server.Shutdown(shutdownCtx)
+	if err != nil {
+		s.logger.Error(
+			LogMessage("graceful shutdown failure, closing the remaining connections"),
+			logging.ErrorField(err),
+			zap.Int64("connections", atomic.LoadInt64(&s.connections)),
+			zap.Int64("inflight", atomic.LoadInt64(&s.inflight)),
+		)
+		server.Close()
+		return err
+	}
+
+	s.logger.Info(LogMessage("http server drained"))
+	return nil
+}
+
+// trackConnection the http.Server ConnState hook counting the open connections
+func (s *HTTPServer) trackConnection(_ net.Conn, state http.ConnState) {
+	switch state {
+	case http.StateNew:
+		atomic.AddInt64(&s.connections, 1)
+	case http.StateHijacked, http.StateClosed:
+		atomic.AddInt64(&s.connections, -1)
+	}
+}
+
+// drainMiddleware count the in-flight requests and ask the clients to close the keep-alive connections while draining
+func (s *HTTPServer) drainMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt64(&s.inflight, 1)
+		defer atomic.AddInt64(&s.inflight, -1)
+
+		if s.isDraining() {
+			w.Header().Set("Connection", "close")
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// readinessHandler report down while draining, so the load balancers stop sending traffic before the listener is closed
+func (s *HTTPServer) readinessHandler(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !s.isDraining() {
+			next(w, r)
+			return
+		}
+
+		byt, _ := json.Marshal(&health.Report{Status: health.DOWN_STATUS, Timestamp: time.Now(), Checks: map[string]*health.CheckResult{}})
+
+		w.Header().Set("Content-Type", JsonContentType)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write(byt)
+	}
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/health"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type DrainTestSuite struct {
+	suite.Suite
+
+	cfg *env.Configs
+}
+
+func TestDrainTestSuite(t *testing.T) {
+	suite.Run(t, new(DrainTestSuite))
+}
+
+func (s *DrainTestSuite) SetupTest() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	s.Require().NoError(err)
+	listener.Close()
+
+	s.cfg = &env.Configs{HTTP_ADDR: listener.Addr().String()}
+}
+
+func (s *DrainTestSuite) run(server IHTTPServer) chan error {
+	runErr := make(chan error, 1)
+	go func() { runErr <- server.Run() }()
+
+	s.Eventually(func() bool {
+		conn, err := net.Dial("tcp", s.cfg.HTTP_ADDR)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	return runErr
+}
+
+func (s *DrainTestSuite) TestReadinessFailsWhileDraining() {
+	built, err := New(s.cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).
+		WithHealth(health.New(logging.NewMockLogger()).Build()).
+		Build()
+	s.Require().NoError(err)
+	server := built.(*HTTPServer)
+
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, health.ReadinessPath, nil))
+	s.Equal(http.StatusOK, rec.Code)
+
+	s.NoError(server.Shutdown(context.Background()))
+
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, health.ReadinessPath, nil))
+	s.Equal(http.StatusServiceUnavailable, rec.Code)
+	s.Contains(rec.Body.String(), `"status":"down"`)
+	s.Equal("close", rec.Header().Get("Connection"))
+
+	rec = httptest.NewRecorder()
+	server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, health.LivenessPath, nil))
+	s.Equal(http.StatusOK, rec.Code)
+
+	s.NoError(server.Run())
+}
+
+func (s *DrainTestSuite) TestShutdownWaitsInFlightRequests() {
+	started, release := make(chan struct{}), make(chan struct{})
+
+	server, err := New(s.cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).WithDraining(20*time.Millisecond, time.Second).Build()
+	s.Require().NoError(err)
+	server.RegisterRoute(http.MethodGet, "/slow", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	runErr := s.run(server)
+
+	status := make(chan int, 1)
+	go func() {
+		res, err := http.Get("http://" + s.cfg.HTTP_ADDR + "/slow")
+		s.NoError(err)
+		res.Body.Close()
+		status <- res.StatusCode
+	}()
+	<-started
+
+	shutdownErr := make(chan error, 1)
+	go func() { shutdownErr <- server.Shutdown(context.Background()) }()
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	s.Equal(http.StatusNoContent, <-status)
+	s.NoError(<-shutdownErr)
+	s.NoError(<-runErr)
+}
+
+func (s *DrainTestSuite) TestShutdownTimeout() {
+	started := make(chan struct{})
+
+	server, err := New(s.cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).WithDraining(0, 50*time.Millisecond).Build()
+	s.Require().NoError(err)
+	server.RegisterRoute(http.MethodGet, "/stuck", func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	})
+
+	runErr := s.run(server)
+
+	go http.Get("http://" + s.cfg.HTTP_ADDR + "/stuck")
+	<-started
+
+	s.ErrorIs(server.Shutdown(context.Background()), context.DeadlineExceeded)
+	s.ErrorIs(<-runErr, context.DeadlineExceeded)
+	s.Eventually(func() bool { return atomic.LoadInt64(&server.(*HTTPServer).inflight) == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func (s *DrainTestSuite) TestShutdownBySignal() {
+	sig := make(chan os.Signal, 1)
+	server, err := New(s.cfg, logging.NewMockLogger(), sig).Build()
+	s.Require().NoError(err)
+
+	runErr := s.run(server)
+	sig <- os.Interrupt
+
+	s.NoError(<-runErr)
+	s.NoError(server.Shutdown(context.Background()))
+}
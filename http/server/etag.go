@@ -0,0 +1,213 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type (
+	// conditionalWriter buffer the response to generate the ETag, the flushed responses, e.g: the event streams, are written
+	// without the ETag
+	conditionalWriter struct {
+		http.ResponseWriter
+		status    int
+		buf       bytes.Buffer
+		streaming bool
+	}
+)
+
+func (s *HTTPServer) WithConditionalRequests() HTTPServerBuilder {
+	s.conditionalRequests = true
+	return s
+}
+
+// ETag strong ETag of the content, the representations that are not byte-for-byte equal must have different strong ETags
+func ETag(content []byte) string {
+	sum := sha256.Sum256(content)
+	return `"` + hex.EncodeToString(sum[:8]) + `"`
+}
+
+// WeakETag weak ETag of the content, e.g: to the representations that only change in the encoding or in irrelevant fields
+func WeakETag(content []byte) string {
+	return "W/" + ETag(content)
+}
+
+// CheckNotModified set the ETag and the Last-Modified headers, when they are not empty, and write 304 when the
+// If-None-Match or, without it, the If-Modified-Since headers match, in that case the handler must not write the body
+//
+//	if server.CheckNotModified(w, r, server.ETag(byt), product.UpdatedAt) {
+//		return
+//	}
+func CheckNotModified(w http.ResponseWriter, r *http.Request, etag string, lastModified time.Time) bool {
+	header := w.Header()
+	if etag != "" {
+		header.Set("ETag", etag)
+	}
+
+	if !lastModified.IsZero() {
+		header.Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if !notModified(r, etag, lastModified) {
+		return false
+	}
+
+	writeNotModified(w)
+	return true
+}
+
+// ConditionalGet generate the ETag of the successful GET and HEAD responses without one and write 304 to the conditional
+// requests that match it, the whole response is buffered so the routes with big payloads should use CheckNotModified
+func ConditionalGet(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &conditionalWriter{ResponseWriter: w}
+		next.ServeHTTP(cw, r)
+
+		if cw.streaming {
+			return
+		}
+
+		if cw.status == 0 {
+			cw.status = http.StatusOK
+		}
+
+		if cw.status != http.StatusOK {
+			cw.writeBuffer()
+			return
+		}
+
+		header := w.Header()
+		etag := header.Get("ETag")
+		if etag == "" {
+			etag = ETag(cw.buf.Bytes())
+			header.Set("ETag", etag)
+		}
+
+		lastModified, _ := http.ParseTime(header.Get("Last-Modified"))
+		if notModified(r, etag, lastModified) {
+			writeNotModified(w)
+			return
+		}
+
+		cw.writeBuffer()
+	})
+}
+
+// notModified the If-None-Match is evaluated with the weak comparison, the If-Modified-Since is ignored when it is present
+func notModified(r *http.Request, etag string, lastModified time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etag != "" && etagMatch(inm, etag)
+	}
+
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || lastModified.IsZero() {
+		return false
+	}
+
+	t, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+
+	// the http dates have the second precision
+	return !lastModified.Truncate(time.Second).After(t)
+}
+
+func etagMatch(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func writeNotModified(w http.ResponseWriter) {
+	header := w.Header()
+	header.Del("Content-Type")
+	header.Del("Content-Length")
+	header.Del("Content-Encoding")
+
+	if header.Get("ETag") != "" {
+		header.Del("Last-Modified")
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+}
+
+func (w *conditionalWriter) WriteHeader(status int) {
+	if w.streaming {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *conditionalWriter) Write(p []byte) (int, error) {
+	if w.streaming {
+		return w.ResponseWriter.Write(p)
+	}
+
+	return w.buf.Write(p)
+}
+
+func (w *conditionalWriter) writeBuffer() {
+	w.ResponseWriter.WriteHeader(w.status)
+	if w.buf.Len() > 0 {
+		w.ResponseWriter.Write(w.buf.Bytes())
+	}
+
+	w.buf.Reset()
+}
+
+// Flush give up the ETag and write the buffered content, so the streamed responses are not held
+func (w *conditionalWriter) Flush() {
+	if !w.streaming {
+		w.streaming = true
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+
+		w.writeBuffer()
+	}
+
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *conditionalWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	w.streaming = true
+	return hijacker.Hijack()
+}
+
+func (w *conditionalWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,141 @@
+package server
+
+import (
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ETagTestSuite struct {
+	suite.Suite
+
+	body string
+}
+
+func TestETagTestSuite(t *testing.T) {
+	suite.Run(t, new(ETagTestSuite))
+}
+
+func (s *ETagTestSuite) SetupTest() {
+	s.body = `{"name":"gokit"}`
+}
+
+func (s *ETagTestSuite) handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", JsonContentType)
+	w.Write([]byte(s.body))
+}
+
+func (s *ETagTestSuite) TestETag() {
+	s.Equal(ETag([]byte(s.body)), ETag([]byte(s.body)))
+	s.NotEqual(ETag([]byte(s.body)), ETag([]byte(`{}`)))
+	s.True(strings.HasPrefix(ETag([]byte(s.body)), `"`))
+	s.Equal("W/"+ETag([]byte(s.body)), WeakETag([]byte(s.body)))
+}
+
+func (s *ETagTestSuite) TestConditionalGet() {
+	handler := ConditionalGet(http.HandlerFunc(s.handler))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	etag := rec.Header().Get("ETag")
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(ETag([]byte(s.body)), etag)
+	s.Equal(s.body, rec.Body.String())
+
+	for _, inm := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("If-None-Match", inm)
+
+		rec = httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		s.Equal(http.StatusNotModified, rec.Code, inm)
+		s.Empty(rec.Body.String())
+		s.Empty(rec.Header().Get("Content-Type"))
+		s.Equal(etag, rec.Header().Get("ETag"))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-None-Match", `"other"`)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *ETagTestSuite) TestConditionalGetSkipped() {
+	handler := ConditionalGet(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		WriteProblem(w, r, NewProblem(http.StatusNotFound, ""))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal(http.StatusNotFound, rec.Code)
+	s.Empty(rec.Header().Get("ETag"))
+
+	rec = httptest.NewRecorder()
+	ConditionalGet(http.HandlerFunc(s.handler)).ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+	s.Empty(rec.Header().Get("ETag"))
+
+	rec = httptest.NewRecorder()
+	ConditionalGet(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("data: 1\n\n"))
+		w.(http.Flusher).Flush()
+	})).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.True(rec.Flushed)
+	s.Empty(rec.Header().Get("ETag"))
+	s.Equal("data: 1\n\n", rec.Body.String())
+}
+
+func (s *ETagTestSuite) TestCheckNotModified() {
+	updatedAt := time.Date(2022, 7, 21, 10, 0, 0, 500, time.UTC)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("If-Modified-Since", updatedAt.Format(http.TimeFormat))
+
+	rec := httptest.NewRecorder()
+	s.True(CheckNotModified(rec, req, "", updatedAt))
+	s.Equal(http.StatusNotModified, rec.Code)
+
+	rec = httptest.NewRecorder()
+	s.False(CheckNotModified(rec, req, "", updatedAt.Add(time.Second)))
+	s.Equal(updatedAt.Add(time.Second).Format(http.TimeFormat), rec.Header().Get("Last-Modified"))
+
+	// the If-None-Match takes precedence over the If-Modified-Since
+	req.Header.Set("If-None-Match", `"other"`)
+	rec = httptest.NewRecorder()
+	s.False(CheckNotModified(rec, req, `"current"`, updatedAt))
+	s.Equal(`"current"`, rec.Header().Get("ETag"))
+}
+
+func (s *ETagTestSuite) TestCompressedWeakETag() {
+	body := strings.Repeat(s.body, 100)
+	handler := Compression(nil)(ConditionalGet(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", JsonContentType)
+		w.Write([]byte(body))
+	})))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	etag := rec.Header().Get("ETag")
+	s.Equal(WeakETag([]byte(body)), etag)
+
+	gz, err := gzip.NewReader(rec.Body)
+	s.Require().NoError(err)
+	gz.Close()
+
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	s.Equal(http.StatusNotModified, rec.Code)
+}
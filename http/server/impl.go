@@ -2,14 +2,16 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
-	"log"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/health"
 	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/version"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -21,14 +23,54 @@ func New(
 	logger logging.ILogger,
 	sig chan os.Signal,
 ) HTTPServerBuilder {
-	return &HTTPServer{
-		cfg:          cfg,
-		logger:       logger,
-		readTimeout:  5 * time.Second,
-		writeTimeout: 10 * time.Second,
-		idleTimeout:  30 * time.Second,
-		sig:          sig,
+	s := &HTTPServer{
+		cfg:           cfg,
+		logger:        logger,
+		readTimeout:   DefaultReadTimeout,
+		writeTimeout:  DefaultWriteTimeout,
+		idleTimeout:   DefaultIdleTimeout,
+		withTLS:       cfg.HTTP_TLS_CERT_PATH != "",
+		withProfiling: cfg.IS_HTTP_PROFILING_ENABLED,
+		withTracing:   cfg.IS_TRACING_ENABLED,
+		sig:           sig,
+
+		requestTimeout: cfg.HTTP_REQUEST_TIMEOUT,
+		maxBodySize:    cfg.HTTP_MAX_BODY_SIZE,
+
+		shutdownTimeout: DefaultShutdownTimeout,
+		drainDelay:      cfg.HTTP_DRAIN_DELAY,
+		drained:         make(chan struct{}),
+	}
+
+	if cfg.HTTP_SHUTDOWN_TIMEOUT != 0 {
+		s.shutdownTimeout = cfg.HTTP_SHUTDOWN_TIMEOUT
 	}
+
+	if cfg.HTTP_READ_TIMEOUT != 0 {
+		s.readTimeout = cfg.HTTP_READ_TIMEOUT
+	}
+
+	if cfg.HTTP_WRITE_TIMEOUT != 0 {
+		s.writeTimeout = cfg.HTTP_WRITE_TIMEOUT
+	}
+
+	if cfg.HTTP_IDLE_TIMEOUT != 0 {
+		s.idleTimeout = cfg.HTTP_IDLE_TIMEOUT
+	}
+
+	if len(cfg.HTTP_CORS_ALLOWED_ORIGINS) > 0 {
+		s.cors = CORSOptsFromEnv(cfg)
+	}
+
+	if cfg.IS_HTTP_OPENAPI_ENABLED {
+		s.openapi = newOpenAPI(nil, cfg.APP_NAME)
+	}
+
+	if cfg.IS_HTTP_ADMIN_ENABLED {
+		s.admin = &AdminOpts{Addr: cfg.HTTP_ADMIN_ADDR, User: cfg.HTTP_ADMIN_USER, Password: cfg.HTTP_ADMIN_PASSWORD}
+	}
+
+	return s
 }
 
 func (s *HTTPServer) WithTLS() HTTPServerBuilder {
@@ -53,23 +95,115 @@ func (s *HTTPServer) WithTracing() HTTPServerBuilder {
 	return s
 }
 
-func (s *HTTPServer) Build() IHTTPServer {
+func (s *HTTPServer) WithCORS(opts *CORSOpts) HTTPServerBuilder {
+	if opts == nil {
+		opts = CORSOptsFromEnv(s.cfg)
+	}
+
+	s.cors = opts
+	return s
+}
+
+func (s *HTTPServer) WithMiddleware(middlewares ...Middleware) HTTPServerBuilder {
+	s.middlewares = append(s.middlewares, middlewares...)
+	return s
+}
+
+func (s *HTTPServer) WithHealth(checker health.IHealthChecker) HTTPServerBuilder {
+	s.healthChecker = checker
+	return s
+}
+
+func (s *HTTPServer) WithMetrics(handler http.Handler) HTTPServerBuilder {
+	s.metricsHandler = handler
+	return s
+}
+
+func (s *HTTPServer) OnShutdown(hooks ...ShutdownHook) HTTPServerBuilder {
+	s.shutdownHooks = append(s.shutdownHooks, hooks...)
+	return s
+}
+
+func (s *HTTPServer) Build() (IHTTPServer, error) {
+	if s.metricsHandler == nil && s.cfg.IS_HTTP_METRICS_ENABLED {
+		s.logger.Error(LogMessage("metrics enabled without a metrics handler, use WithMetrics"))
+		return nil, ErrorMetricsHandler
+	}
+
 	s.logger.Debug(LogMessage("creating the server..."))
 	s.router = chi.NewRouter()
 
-	s.router.Use(middleware.RequestID)
+	s.router.Use(s.drainMiddleware)
+	s.router.Use(RequestID)
 	s.router.Use(middleware.RealIP)
-	s.router.Use(middleware.Recoverer)
-	s.router.Use(middleware.DefaultLogger)
-	s.router.Use(middleware.Heartbeat("/heartbeat"))
-	s.router.Use(middleware.AllowContentType("application/json"))
+	s.router.Use(Recovery(s.logger))
+	s.router.Use(RequestLogging(s.logger, s.requestLogging))
+
+	if s.compression != nil {
+		s.router.Use(Compression(s.compression))
+	}
+
+	if s.conditionalRequests {
+		s.router.Use(ConditionalGet)
+	}
+
+	if s.maxBodySize > 0 {
+		s.router.Use(maxBodySize(s.maxBodySize, false))
+	}
+
+	if s.requestTimeout > 0 {
+		s.router.Use(Timeout(s.requestTimeout))
+	}
+
+	if s.cors != nil {
+		s.router.Use(CORS(s.cors))
+	}
+
+	s.router.Use(middleware.Heartbeat(HeartbeatPath))
+
+	if len(s.contentTypes) > 0 {
+		s.router.Use(AllowContentType(s.contentTypes...))
+	}
+
+	if s.csrf != nil {
+		s.router.Use(CSRF(s.csrf))
+		s.router.Get(s.csrf.TokenPath, CSRFTokenHandler)
+	}
+
+	for _, m := range s.middlewares {
+		s.router.Use(m)
+	}
+
+	s.router.Get(VersionPath, version.Handler)
 
 	if s.withProfiling {
-		s.router.Mount("/debug", middleware.Profiler())
+		s.router.Mount(ProfilingPath, middleware.Profiler())
+	}
+
+	if s.metricsHandler != nil {
+		s.router.Method(http.MethodGet, MetricsPath, s.metricsHandler)
+	}
+
+	if s.openapi != nil {
+		s.router.Get(OpenAPIPath, s.openapi.specHandler)
+		s.router.Get(SwaggerUIPath, s.openapi.swaggerUIHandler)
+	}
+
+	if s.healthChecker == nil && s.cfg.IS_HTTP_HEALTH_ENABLED {
+		s.healthChecker = health.New(s.logger).Build()
+	}
+
+	if s.healthChecker != nil {
+		s.router.Get(health.LivenessPath, s.healthChecker.LivenessHandler())
+		s.router.Get(health.ReadinessPath, s.readinessHandler(s.healthChecker.ReadinessHandler()))
+	}
+
+	if s.admin != nil {
+		s.adminRouter = s.newAdminRouter()
 	}
 
 	s.logger.Debug(LogMessage("server was created"))
-	return s
+	return s, nil
 }
 
 func (s *HTTPServer) RegisterRoute(method string, path string, handler http.HandlerFunc) error {
@@ -90,47 +224,107 @@ func (s *HTTPServer) RegisterRoute(method string, path string, handler http.Hand
 	return nil
 }
 
+func (s *HTTPServer) Mount(pattern string, handler http.Handler) {
+	s.logger.Debug(LogMessage(fmt.Sprintf("mounting handler: %s", pattern)))
+
+	if s.withTracing {
+		handler = otelhttp.NewHandler(handler, pattern, otelhttp.WithSpanNameFormatter(func(_ string, r *http.Request) string {
+			return OTLPOperationName(r.Method, r.URL.Path)
+		}))
+	}
+
+	s.router.Mount(pattern, handler)
+}
+
 func (s *HTTPServer) Run() error {
 	s.logger.Debug(LogMessage("starting http server..."))
 
+	listeners := listenersFromEnv(s)
+
+	var tlsConfig *tls.Config
+	for _, l := range listeners {
+		if !l.TLS {
+			continue
+		}
+
+		var err error
+		if tlsConfig, err = s.tlsConfig(); err != nil {
+			s.logger.Error(LogMessage("tls enabled without cert and key or autocert domains"), logging.ErrorField(err))
+			return err
+		}
+
+		break
+	}
+
+	if s.admin != nil && (s.admin.Addr == "" || s.admin.User == "" || s.admin.Password == "") {
+		s.logger.Error(LogMessage("admin server enabled without addr and credentials"))
+		return ErrorAdminCredentials
+	}
+
+	if s.cors != nil {
+		if err := validateCORS(s.cors, s.cfg.GO_ENV); err != nil {
+			s.logger.Error(LogMessage("invalid cors configuration"), logging.ErrorField(err))
+			return err
+		}
+	}
+
+	if s.csrf != nil && s.csrf.Mode == SYNCHRONIZER_TOKEN && s.csrf.Store == nil {
+		s.logger.Error(LogMessage("csrf synchronizer token mode enabled without a store"))
+		return ErrorCSRFStore
+	}
+
+	s.mu.Lock()
+	select {
+	case <-s.drained:
+		s.mu.Unlock()
+		s.logger.Warn(LogMessage("http server was shut down before running"))
+		return s.shutdownErr
+	default:
+	}
+
 	s.server = &http.Server{
 		Addr:         s.cfg.HTTP_ADDR,
 		ReadTimeout:  s.readTimeout,
 		WriteTimeout: s.writeTimeout,
 		IdleTimeout:  s.idleTimeout,
-		Handler:      s.router,
+		Handler:      s.handler(),
+		ConnState:    s.trackConnection,
+		TLSConfig:    tlsConfig,
+	}
+	s.mu.Unlock()
+
+	opened, err := s.listen(listeners)
+	if err != nil {
+		s.logger.Error(LogMessage("failure to listen"), logging.ErrorField(err))
+		return err
 	}
 
 	s.logger.Debug(LogMessage("configuring graceful shutdown..."))
 	ctx, ctxCancelFunc := context.WithCancel(context.Background())
-	go s.shutdown(ctx, ctxCancelFunc)
+	defer ctxCancelFunc()
+	go s.shutdown(ctx)
 
-	s.logger.Info(LogMessage(fmt.Sprintf("%s started", s.cfg.HTTP_ADDR)))
-	if err := s.server.ListenAndServe(); err != nil {
-		s.logger.Error(LogMessage("http server error"), logging.ErrorField(err))
+	s.runAdmin()
+
+	if err := s.serve(listeners, opened); err != nil {
 		return err
 	}
 
-	<-ctx.Done()
+	<-s.drained
 
-	return nil
+	return s.shutdownErr
 }
 
-func (s *HTTPServer) shutdown(ctx context.Context, ctxCancelFunc context.CancelFunc) {
-	<-s.sig
-
-	shutdownCtx, _ := context.WithTimeout(ctx, 30*time.Second)
-	go func() {
-		<-shutdownCtx.Done()
-		if shutdownCtx.Err() == context.DeadlineExceeded {
-			s.logger.Fatal("graceful shutdown timed out.. forcing exit.")
-		}
-	}()
-
-	err := s.server.Shutdown(shutdownCtx)
-	if err != nil {
-		log.Fatal(err)
+// shutdown drain the server when the signal is received, the Shutdown could also be called directly
+func (s *HTTPServer) shutdown(ctx context.Context) {
+	select {
+	case <-s.sig:
+	case <-s.drained:
+		return
+	case <-ctx.Done():
+		return
 	}
 
-	ctxCancelFunc()
+	s.logger.Info(LogMessage("shutting down the http server..."))
+	s.Shutdown(context.Background())
 }
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// timeoutWriter the handler writes in its own headers, so the 504 response never races with the timed out handler
+	timeoutWriter struct {
+		w           http.ResponseWriter
+		mu          sync.Mutex
+		header      http.Header
+		timedOut    bool
+		wroteHeader bool
+	}
+
+	// maxBodyReader translate the http.MaxBytesReader error into ErrorBodyTooLarge
+	maxBodyReader struct {
+		body   io.ReadCloser
+		reader io.ReadCloser
+		limit  int64
+		read   int64
+
+		exceeded bool
+	}
+)
+
+func (s *HTTPServer) WithRequestLimits(timeout time.Duration, maxBodySize int64) HTTPServerBuilder {
+	s.requestTimeout = timeout
+	s.maxBodySize = maxBodySize
+	return s
+}
+
+func (s *HTTPServer) WithContentTypes(contentTypes ...string) HTTPServerBuilder {
+	s.contentTypes = contentTypes
+	return s
+}
+
+// AllowContentType respond 415 when the request body has a Content-Type that is not allowed, the requests without body
+// are not checked, e.g: a JSON-only route
+//
+//	server.RegisterRoute(http.MethodPost, "/orders", server.AllowContentType(server.JsonContentType)(handler).ServeHTTP)
+func AllowContentType(contentTypes ...string) Middleware {
+	allowed := map[string]bool{}
+	for _, ct := range contentTypes {
+		allowed[strings.ToLower(strings.TrimSpace(ct))] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ct := strings.ToLower(strings.TrimSpace(strings.Split(r.Header.Get("Content-Type"), ";")[0]))
+			if !allowed[ct] {
+				WriteProblem(w, r, NewProblem(http.StatusUnsupportedMediaType, fmt.Sprintf("the content type %q is not supported", ct)))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Timeout cancel the handler context after the timeout and respond 504, the writes after the timeout are discarded.
+// When the handler has already written the headers the response is only interrupted.
+// Nested timeouts can only shorten the deadline, e.g: a route Timeout shorter than the server one
+//
+//	server.RegisterRoute(http.MethodGet, "/report", server.Timeout(30*time.Second)(handler).ServeHTTP)
+func Timeout(timeout time.Duration) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer cancel()
+
+			tw := &timeoutWriter{w: w, header: http.Header{}}
+			done := make(chan struct{})
+			panicked := make(chan interface{}, 1)
+
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+						return
+					}
+
+					close(done)
+				}()
+
+				next.ServeHTTP(tw, r.WithContext(ctx))
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+			case <-done:
+				return
+			case <-ctx.Done():
+			}
+
+			tw.mu.Lock()
+			defer tw.mu.Unlock()
+
+			tw.timedOut = true
+			if tw.wroteHeader || !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return
+			}
+
+			WriteProblem(w, r, NewProblem(http.StatusGatewayTimeout, fmt.Sprintf("the request exceeded %s", timeout)))
+		})
+	}
+}
+
+// MaxBodySize respond 413 when the Content-Length is bigger than the limit, the bodies without Content-Length fail
+// with ErrorBodyTooLarge while they are read, Bind, Decode and WriteError write it as 413.
+// A route MaxBodySize replaces the server one, e.g: to accept bigger uploads
+func MaxBodySize(limit int64) Middleware {
+	return maxBodySize(limit, true)
+}
+
+// maxBodySize the server limit is only checked while the body is read, so the routes can replace it with a bigger one
+func maxBodySize(limit int64, checkContentLength bool) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if checkContentLength && r.ContentLength > limit {
+				WriteProblem(w, r, bodyTooLargeProblem(limit))
+				return
+			}
+
+			if r.Body != nil && r.Body != http.NoBody {
+				body := r.Body
+				if limited, ok := body.(*maxBodyReader); ok {
+					body = limited.body
+				}
+
+				r.Body = &maxBodyReader{
+					body:   body,
+					reader: http.MaxBytesReader(w, body, limit),
+					limit:  limit,
+					// http.MaxBytesReader only fails after reading the limit
+					exceeded: r.ContentLength > limit,
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func bodyTooLargeProblem(limit int64) *ProblemDetails {
+	return NewProblem(http.StatusRequestEntityTooLarge, fmt.Sprintf("the request body exceeds %d bytes", limit))
+}
+
+func (b *maxBodyReader) Read(p []byte) (int, error) {
+	if b.exceeded {
+		return 0, ErrorBodyTooLarge
+	}
+
+	n, err := b.reader.Read(p)
+	b.read += int64(n)
+
+	if err != nil && err != io.EOF && b.read >= b.limit {
+		b.exceeded = true
+		return n, ErrorBodyTooLarge
+	}
+
+	return n, err
+}
+
+func (b *maxBodyReader) Close() error {
+	return b.reader.Close()
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) WriteHeader(status int) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+
+	tw.writeHeader(status)
+}
+
+func (tw *timeoutWriter) writeHeader(status int) {
+	tw.wroteHeader = true
+
+	header := tw.w.Header()
+	for k, v := range tw.header {
+		header[k] = v
+	}
+
+	tw.w.WriteHeader(status)
+}
+
+func (tw *timeoutWriter) Write(p []byte) (int, error) {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+
+	if !tw.wroteHeader {
+		tw.writeHeader(http.StatusOK)
+	}
+
+	return tw.w.Write(p)
+}
+
+func (tw *timeoutWriter) Flush() {
+	tw.mu.Lock()
+	defer tw.mu.Unlock()
+
+	if tw.timedOut {
+		return
+	}
+
+	if !tw.wroteHeader {
+		tw.writeHeader(http.StatusOK)
+	}
+
+	if flusher, ok := tw.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.w
+}
@@ -0,0 +1,196 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type LimitsTestSuite struct {
+	suite.Suite
+}
+
+type limitsRequest struct {
+	Name string `json:"name"`
+}
+
+func TestLimitsTestSuite(t *testing.T) {
+	suite.Run(t, new(LimitsTestSuite))
+}
+
+func (s *LimitsTestSuite) TestTimeout() {
+	cancelled := make(chan struct{})
+	handler := Timeout(10 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Partial", "true")
+		<-r.Context().Done()
+		close(cancelled)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/report", nil))
+
+	s.Equal(http.StatusGatewayTimeout, rec.Code)
+	s.Equal(ProblemContentType, rec.Header().Get("Content-Type"))
+	s.Empty(rec.Header().Get("X-Partial"))
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		s.Fail("the handler context was not cancelled")
+	}
+}
+
+func (s *LimitsTestSuite) TestTimeoutNotExceeded() {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", JsonContentType)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{}`))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal(http.StatusCreated, rec.Code)
+	s.Equal(JsonContentType, rec.Header().Get("Content-Type"))
+	s.Equal(`{}`, rec.Body.String())
+}
+
+func (s *LimitsTestSuite) TestTimeoutPanic() {
+	handler := Timeout(time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	s.PanicsWithValue("boom", func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+func (s *LimitsTestSuite) TestMaxBodySizeContentLength() {
+	called := false
+	handler := MaxBodySize(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"gokit"}`)))
+
+	s.False(called)
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+
+	problem := ProblemDetails{}
+	s.NoError(json.NewDecoder(rec.Body).Decode(&problem))
+	s.Equal("the request body exceeds 8 bytes", problem.Detail)
+}
+
+func (s *LimitsTestSuite) TestMaxBodySizeChunked() {
+	handler := MaxBodySize(8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := Bind[limitsRequest](w, r); ok {
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", io.NopCloser(strings.NewReader(`{"name":"gokit"}`)))
+	req.Header.Set("Content-Type", JsonContentType)
+	req.ContentLength = -1
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+}
+
+func (s *LimitsTestSuite) TestAllowContentType() {
+	handler := AllowContentType(JsonContentType)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("name=gokit"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	s.Equal(http.StatusUnsupportedMediaType, rec.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"name":"gokit"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	s.Equal(http.StatusOK, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal(http.StatusOK, rec.Code)
+}
+
+func (s *LimitsTestSuite) TestServerContentTypes() {
+	form := func(server IHTTPServer) int {
+		req := httptest.NewRequest(http.MethodPost, "/forms", strings.NewReader("name=gokit"))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		rec := httptest.NewRecorder()
+		server.(*HTTPServer).router.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) }
+
+	server, err := New(&env.Configs{}, logging.NewMockLogger(), make(chan os.Signal, 1)).Build()
+	s.Require().NoError(err)
+	s.Require().NoError(server.RegisterRoute(http.MethodPost, "/forms", handler))
+	s.Equal(http.StatusOK, form(server))
+
+	server, err = New(&env.Configs{}, logging.NewMockLogger(), make(chan os.Signal, 1)).WithContentTypes(JsonContentType).Build()
+	s.Require().NoError(err)
+	s.Require().NoError(server.RegisterRoute(http.MethodPost, "/forms", handler))
+	s.Equal(http.StatusUnsupportedMediaType, form(server))
+}
+
+func (s *LimitsTestSuite) TestMetricsWithoutHandler() {
+	_, err := New(&env.Configs{IS_HTTP_METRICS_ENABLED: true}, logging.NewMockLogger(), make(chan os.Signal, 1)).Build()
+	s.ErrorIs(err, ErrorMetricsHandler)
+
+	_, err = New(&env.Configs{IS_HTTP_METRICS_ENABLED: true}, logging.NewMockLogger(), make(chan os.Signal, 1)).WithMetrics(http.NotFoundHandler()).Build()
+	s.NoError(err)
+}
+
+func (s *LimitsTestSuite) TestServerLimits() {
+	server, err := New(&env.Configs{HTTP_MAX_BODY_SIZE: 8, HTTP_REQUEST_TIMEOUT: time.Second}, logging.NewMockLogger(), make(chan os.Signal, 1)).Build()
+	s.Require().NoError(err)
+
+	bigger := Route(http.MethodPost, "/uploads", func(ctx context.Context, req *limitsRequest) (*limitsRequest, error) {
+		return req, nil
+	}).MaxBodySize(1024)
+
+	small := Route(http.MethodPost, "/orders", func(ctx context.Context, req *limitsRequest) (*limitsRequest, error) {
+		return req, nil
+	})
+
+	s.Require().NoError(server.RegisterTypedRoutes(bigger, small))
+
+	handler := server.(*HTTPServer).router
+	request := func(path string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(`{"name":"gokit"}`))
+		req.Header.Set("Content-Type", JsonContentType)
+		return req
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, request("/orders"))
+	s.Equal(http.StatusRequestEntityTooLarge, rec.Code)
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, request("/uploads"))
+	s.Equal(http.StatusOK, rec.Code)
+	s.JSONEq(`{"name":"gokit"}`, rec.Body.String())
+}
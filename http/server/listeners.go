@@ -0,0 +1,175 @@
+package server
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+
+	"github.com/ralvescosta/gokit/logging"
+	"golang.org/x/crypto/acme/autocert"
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+)
+
+const (
+	TCP_NETWORK  = "tcp"
+	UNIX_NETWORK = "unix"
+)
+
+type (
+	// Listener an address served by the same router and middlewares, e.g: a unix socket for a sidecar
+	Listener struct {
+		// Network TCP_NETWORK or UNIX_NETWORK, default TCP_NETWORK
+		Network string
+		// Addr the tcp address, e.g: 0.0.0.0:8443, or the socket path, e.g: /var/run/app.sock
+		Addr string
+		// TLS serve https with the static certs or the autocert
+		TLS bool
+	}
+
+	// AutocertOpts the certificates are requested to the Let's Encrypt using the TLS-ALPN challenge
+	AutocertOpts struct {
+		Domains []string
+		// CacheDir keep the certificates between the restarts, without it the certificates are requested in every start
+		CacheDir string
+		Email    string
+	}
+)
+
+func (s *HTTPServer) WithListener(listeners ...Listener) HTTPServerBuilder {
+	s.listeners = append(s.listeners, listeners...)
+	return s
+}
+
+func (s *HTTPServer) WithAutocert(opts *AutocertOpts) HTTPServerBuilder {
+	if opts == nil {
+		opts = &AutocertOpts{Domains: s.cfg.HTTP_AUTOCERT_DOMAINS, CacheDir: s.cfg.HTTP_AUTOCERT_CACHE_DIR}
+	}
+
+	s.autocert = opts
+	s.withTLS = true
+	return s
+}
+
+func (s *HTTPServer) WithH2C() HTTPServerBuilder {
+	s.withH2C = true
+	return s
+}
+
+// listenersFromEnv the HTTP_ADDR listener and the additional ones configured in the env
+func listenersFromEnv(s *HTTPServer) []Listener {
+	listeners := []Listener{{Network: TCP_NETWORK, Addr: s.cfg.HTTP_ADDR, TLS: s.withTLS}}
+
+	for _, addr := range s.cfg.HTTP_ADDITIONAL_ADDRS {
+		listeners = append(listeners, Listener{Network: TCP_NETWORK, Addr: addr, TLS: s.withTLS})
+	}
+
+	if s.cfg.HTTP_UNIX_SOCKET != "" {
+		listeners = append(listeners, Listener{Network: UNIX_NETWORK, Addr: s.cfg.HTTP_UNIX_SOCKET})
+	}
+
+	return append(listeners, s.listeners...)
+}
+
+// tlsConfig the autocert or the static certs configuration, the static certs are loaded by the ServeTLS
+func (s *HTTPServer) tlsConfig() (*tls.Config, error) {
+	if s.autocert == nil {
+		if s.cfg.HTTP_TLS_CERT_PATH == "" || s.cfg.HTTP_TLS_KEY_PATH == "" {
+			return nil, ErrorTLSFilesRequired
+		}
+
+		return nil, nil
+	}
+
+	if len(s.autocert.Domains) == 0 {
+		return nil, ErrorAutocertDomains
+	}
+
+	manager := &autocert.Manager{
+		Prompt:     autocert.AcceptTOS,
+		HostPolicy: autocert.HostWhitelist(s.autocert.Domains...),
+		Email:      s.autocert.Email,
+	}
+
+	if s.autocert.CacheDir != "" {
+		manager.Cache = autocert.DirCache(s.autocert.CacheDir)
+	}
+
+	return manager.TLSConfig(), nil
+}
+
+// handler the router, wrapped to accept the HTTP/2 without TLS when h2c is enabled, e.g: grpc-gateway or gRPC-web clients
+func (s *HTTPServer) handler() http.Handler {
+	if !s.withH2C && !s.cfg.IS_HTTP_H2C_ENABLED {
+		return s.router
+	}
+
+	return h2c.NewHandler(s.router, &http2.Server{IdleTimeout: s.idleTimeout})
+}
+
+// listen open all listeners, the stale unix sockets of a previous execution are removed
+func (s *HTTPServer) listen(listeners []Listener) ([]net.Listener, error) {
+	opened := make([]net.Listener, 0, len(listeners))
+
+	for i, l := range listeners {
+		network := l.Network
+		if network == "" {
+			network = TCP_NETWORK
+			listeners[i].Network = network
+		}
+
+		if network == UNIX_NETWORK {
+			if info, err := os.Stat(l.Addr); err == nil && info.Mode()&os.ModeSocket != 0 {
+				os.Remove(l.Addr)
+			}
+		}
+
+		listener, err := net.Listen(network, l.Addr)
+		if err != nil {
+			for _, o := range opened {
+				o.Close()
+			}
+
+			return nil, fmt.Errorf("%s %s: %w", network, l.Addr, err)
+		}
+
+		opened = append(opened, listener)
+	}
+
+	return opened, nil
+}
+
+// serve block until the server is shut down or one listener fails, in that case the other listeners are closed
+func (s *HTTPServer) serve(listeners []Listener, opened []net.Listener) error {
+	errs := make(chan error, len(opened))
+
+	for i, listener := range opened {
+		s.logger.Info(LogMessage(fmt.Sprintf("%s %s started", listeners[i].Network, listener.Addr())))
+
+		go func(l Listener, listener net.Listener) {
+			if l.TLS {
+				errs <- s.server.ServeTLS(listener, s.cfg.HTTP_TLS_CERT_PATH, s.cfg.HTTP_TLS_KEY_PATH)
+				return
+			}
+
+			errs <- s.server.Serve(listener)
+		}(listeners[i], listener)
+	}
+
+	var serveErr error
+	for range opened {
+		err := <-errs
+		if err == nil || errors.Is(err, http.ErrServerClosed) || serveErr != nil {
+			continue
+		}
+
+		s.logger.Error(LogMessage("http server error"), logging.ErrorField(err))
+		serveErr = err
+		s.server.Close()
+	}
+
+	return serveErr
+}
@@ -0,0 +1,144 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/http2"
+)
+
+type ListenersTestSuite struct {
+	suite.Suite
+
+	cfg *env.Configs
+}
+
+func TestListenersTestSuite(t *testing.T) {
+	suite.Run(t, new(ListenersTestSuite))
+}
+
+func (s *ListenersTestSuite) SetupTest() {
+	s.cfg = &env.Configs{HTTP_ADDR: s.freeAddr(), HTTP_ADDITIONAL_ADDRS: []string{s.freeAddr()}}
+}
+
+func (s *ListenersTestSuite) freeAddr() string {
+	listener, err := net.Listen(TCP_NETWORK, "127.0.0.1:0")
+	s.Require().NoError(err)
+	listener.Close()
+
+	return listener.Addr().String()
+}
+
+func (s *ListenersTestSuite) start(server IHTTPServer, network, addr string) {
+	runErr := make(chan error, 1)
+	go func() { runErr <- server.Run() }()
+
+	s.Eventually(func() bool {
+		conn, err := net.Dial(network, addr)
+		if err == nil {
+			conn.Close()
+		}
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+
+	s.T().Cleanup(func() {
+		server.Shutdown(context.Background())
+		s.NoError(<-runErr)
+	})
+}
+
+func (s *ListenersTestSuite) route(server IHTTPServer) {
+	server.RegisterRoute(http.MethodGet, "/proto", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Proto))
+	})
+}
+
+func (s *ListenersTestSuite) TestMultipleListeners() {
+	socket := filepath.Join(s.T().TempDir(), "app.sock")
+
+	// stale socket of a previous execution
+	stale, err := net.Listen(UNIX_NETWORK, socket)
+	s.Require().NoError(err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	stale.Close()
+
+	server, err := New(s.cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).
+		WithListener(Listener{Network: UNIX_NETWORK, Addr: socket}).
+		Build()
+	s.Require().NoError(err)
+	s.route(server)
+	s.start(server, UNIX_NETWORK, socket)
+
+	for _, addr := range []string{s.cfg.HTTP_ADDR, s.cfg.HTTP_ADDITIONAL_ADDRS[0]} {
+		res, err := http.Get("http://" + addr + "/proto")
+		s.Require().NoError(err)
+		res.Body.Close()
+		s.Equal(http.StatusOK, res.StatusCode)
+	}
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, UNIX_NETWORK, socket)
+		},
+	}}
+
+	res, err := client.Get("http://sidecar/proto")
+	s.Require().NoError(err)
+	res.Body.Close()
+	s.Equal(http.StatusOK, res.StatusCode)
+}
+
+func (s *ListenersTestSuite) TestH2C() {
+	server, err := New(s.cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).WithH2C().Build()
+	s.Require().NoError(err)
+	s.route(server)
+	s.start(server, TCP_NETWORK, s.cfg.HTTP_ADDR)
+
+	client := &http.Client{Transport: &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return net.Dial(network, addr)
+		},
+	}}
+
+	res, err := client.Get("http://" + s.cfg.HTTP_ADDR + "/proto")
+	s.Require().NoError(err)
+	defer res.Body.Close()
+
+	s.Equal(2, res.ProtoMajor)
+}
+
+func (s *ListenersTestSuite) TestListenFailure() {
+	listener, err := net.Listen(TCP_NETWORK, s.cfg.HTTP_ADDITIONAL_ADDRS[0])
+	s.Require().NoError(err)
+	defer listener.Close()
+
+	server, err := New(s.cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).Build()
+	s.Require().NoError(err)
+	s.Error(server.Run())
+
+	conn, err := net.Dial(TCP_NETWORK, s.cfg.HTTP_ADDR)
+	if err == nil {
+		conn.Close()
+	}
+	s.Error(err)
+}
+
+func (s *ListenersTestSuite) TestTLSWithoutCerts() {
+	server, err := New(s.cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).WithTLS().Build()
+	s.Require().NoError(err)
+	s.ErrorIs(server.Run(), ErrorTLSFilesRequired)
+
+	server, err = New(s.cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).WithAutocert(&AutocertOpts{}).Build()
+	s.Require().NoError(err)
+	s.ErrorIs(server.Run(), ErrorAutocertDomains)
+}
@@ -0,0 +1,241 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/requestid"
+)
+
+// recoveryWriter track whether the response was started, the 500 can only be written before the headers
+type recoveryWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+// RequestID generate or propagate the request id and return it in the response headers
+//
+// The generated ids are ULIDs, the id is available through middleware.GetReqID and requestid.FromContext,
+// so the clients built with the http/client and the rabbitmq publishers propagate it
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, id := requestid.Ensure(r.Context(), r.Header.Get(RequestIDHeader))
+
+		w.Header().Set(RequestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, middleware.RequestIDKey, id)))
+	})
+}
+
+// Recovery recover from panics, log the stack trace and respond with 500, when the handler has already written the
+// headers the response is only interrupted
+func Recovery(logger logging.ILogger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := &recoveryWriter{ResponseWriter: w}
+
+			defer func() {
+				if rvr := recover(); rvr != nil {
+					if rvr == http.ErrAbortHandler {
+						panic(rvr)
+					}
+
+					logger.Error(
+						LogMessage("recovered from panic"),
+						logging.MessageField("panic", stringify(rvr)),
+						logging.MessageField("stack", string(debug.Stack())),
+						logging.MessageField("requestId", middleware.GetReqID(r.Context())),
+					)
+
+					if !rw.wroteHeader {
+						w.WriteHeader(http.StatusInternalServerError)
+					}
+				}
+			}()
+
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// Logging log each request using the gokit logger, see RequestLogging to capture the bodies and the headers
+func Logging(logger logging.ILogger) Middleware {
+	return RequestLogging(logger, nil)
+}
+
+// CORS handle preflight requests and set the cross-origin headers, the invalid regex origins are ignored, see validateCORS
+func CORS(opts *CORSOpts) Middleware {
+	methods := opts.AllowedMethods
+	if len(methods) == 0 {
+		methods = []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions}
+	}
+
+	headers := opts.AllowedHeaders
+	if len(headers) == 0 {
+		headers = []string{"Accept", "Authorization", "Content-Type", RequestIDHeader}
+	}
+
+	allowAll := false
+	for _, o := range opts.AllowedOrigins {
+		if o == "*" {
+			allowAll = true
+		}
+	}
+
+	allowed := corsOriginMatcher(opts.AllowedOrigins)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" || !allowed(origin) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h := w.Header()
+			h.Add("Vary", "Origin")
+			h.Set("Access-Control-Allow-Origin", origin)
+			if allowAll && !opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Origin", "*")
+			}
+
+			if opts.AllowCredentials {
+				h.Set("Access-Control-Allow-Credentials", "true")
+			}
+
+			if len(opts.ExposedHeaders) > 0 {
+				h.Set("Access-Control-Expose-Headers", strings.Join(opts.ExposedHeaders, ", "))
+			}
+
+			if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			h.Set("Access-Control-Allow-Methods", strings.Join(methods, ", "))
+			h.Set("Access-Control-Allow-Headers", strings.Join(headers, ", "))
+			if opts.MaxAge > 0 {
+				h.Set("Access-Control-Max-Age", strconv.Itoa(int(opts.MaxAge.Seconds())))
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		})
+	}
+}
+
+// CORSOptsFromEnv the HTTP_CORS_* configuration
+func CORSOptsFromEnv(cfg *env.Configs) *CORSOpts {
+	return &CORSOpts{
+		AllowedOrigins:   cfg.HTTP_CORS_ALLOWED_ORIGINS,
+		AllowedMethods:   cfg.HTTP_CORS_ALLOWED_METHODS,
+		AllowedHeaders:   cfg.HTTP_CORS_ALLOWED_HEADERS,
+		ExposedHeaders:   cfg.HTTP_CORS_EXPOSED_HEADERS,
+		AllowCredentials: cfg.HTTP_CORS_ALLOW_CREDENTIALS,
+		MaxAge:           cfg.HTTP_CORS_MAX_AGE,
+		Strict:           cfg.HTTP_CORS_STRICT,
+	}
+}
+
+// validateCORS the regex origins must compile, and in the strict mode the production can not allow "*" with credentials
+func validateCORS(opts *CORSOpts, goEnv env.Environment) error {
+	for _, o := range opts.AllowedOrigins {
+		if strings.HasPrefix(o, "^") {
+			if _, err := regexp.Compile(o); err != nil {
+				return fmt.Errorf("%w: %s", ErrorCORSOrigin, o)
+			}
+		}
+
+		if o == "*" && opts.AllowCredentials && opts.Strict && goEnv == env.PRODUCTION_ENV {
+			return ErrorCORSWildcardCredentials
+		}
+	}
+
+	return nil
+}
+
+func corsOriginMatcher(origins []string) func(origin string) bool {
+	exact := map[string]bool{}
+	patterns := []*regexp.Regexp{}
+
+	for _, o := range origins {
+		switch {
+		case o == "*":
+			return func(string) bool { return true }
+		case strings.HasPrefix(o, "^"):
+			if re, err := regexp.Compile(o); err == nil {
+				patterns = append(patterns, re)
+			}
+		case strings.Contains(o, "*"):
+			// https://*.example.com matches only the subdomains, the dots are not matched by the wildcard
+			quoted := strings.ReplaceAll(regexp.QuoteMeta(o), `\*`, `[^./]+`)
+			patterns = append(patterns, regexp.MustCompile("^"+quoted+"$"))
+		default:
+			exact[o] = true
+		}
+	}
+
+	return func(origin string) bool {
+		if exact[origin] {
+			return true
+		}
+
+		for _, re := range patterns {
+			if re.MatchString(origin) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func (w *recoveryWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recoveryWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(p)
+}
+
+func (w *recoveryWriter) Flush() {
+	w.wroteHeader = true
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *recoveryWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	w.wroteHeader = true
+	return hijacker.Hijack()
+}
+
+func (w *recoveryWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func stringify(v any) string {
+	switch t := v.(type) {
+	case error:
+		return t.Error()
+	case string:
+		return t
+	default:
+		return fmt.Sprintf("%v", t)
+	}
+}
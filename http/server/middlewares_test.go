@@ -0,0 +1,158 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/requestid"
+	"github.com/stretchr/testify/suite"
+)
+
+type MiddlewaresTestSuite struct {
+	suite.Suite
+}
+
+type writeHeaderRecorder struct {
+	*httptest.ResponseRecorder
+	statuses []int
+}
+
+func (r *writeHeaderRecorder) WriteHeader(status int) {
+	r.statuses = append(r.statuses, status)
+	r.ResponseRecorder.WriteHeader(status)
+}
+
+func TestMiddlewaresTestSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewaresTestSuite))
+}
+
+func (s *MiddlewaresTestSuite) TestRequestID() {
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Len(rec.Header().Get(RequestIDHeader), 26)
+
+	var propagated, fromContext string
+	handler = RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		propagated = middleware.GetReqID(r.Context())
+		fromContext, _ = requestid.FromContext(r.Context())
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(RequestIDHeader, "id")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	s.Equal("id", propagated)
+	s.Equal("id", fromContext)
+}
+
+func (s *MiddlewaresTestSuite) TestRecovery() {
+	handler := Recovery(logging.NewMockLogger())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("some panic")
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal(http.StatusInternalServerError, rec.Code)
+}
+
+func (s *MiddlewaresTestSuite) TestRecoveryAfterHeaders() {
+	handler := Recovery(logging.NewMockLogger())(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("some panic")
+	}))
+
+	rec := &writeHeaderRecorder{ResponseRecorder: httptest.NewRecorder()}
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal([]int{http.StatusAccepted}, rec.statuses)
+}
+
+func (s *MiddlewaresTestSuite) TestCORSPreflight() {
+	handler := CORS(&CORSOpts{AllowedOrigins: []string{"http://localhost"}})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.Fail("handler should not be called in preflight")
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/", nil)
+	req.Header.Set("Origin", "http://localhost")
+	req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.Equal(http.StatusNoContent, rec.Code)
+	s.Equal("http://localhost", rec.Header().Get("Access-Control-Allow-Origin"))
+	s.NotEmpty(rec.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func (s *MiddlewaresTestSuite) TestCORSNotAllowedOrigin() {
+	called := false
+	handler := CORS(&CORSOpts{AllowedOrigins: []string{"http://localhost"}})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "http://other")
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	s.True(called)
+	s.Empty(rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (s *MiddlewaresTestSuite) TestCORSWildcardAndRegexOrigins() {
+	handler := CORS(&CORSOpts{AllowedOrigins: []string{"https://*.example.com", `^https://pr-[0-9]+\.preview\.dev$`}})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	for origin, allowed := range map[string]bool{
+		"https://app.example.com":      true,
+		"https://a.b.example.com":      false,
+		"https://example.com":          false,
+		"https://app.example.com.evil": false,
+		"https://pr-42.preview.dev":    true,
+		"https://pr-x.preview.dev":     false,
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Origin", origin)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		s.Equal(allowed, rec.Header().Get("Access-Control-Allow-Origin") == origin, origin)
+	}
+}
+
+func (s *MiddlewaresTestSuite) TestCORSOptsFromEnv() {
+	opts := CORSOptsFromEnv(&env.Configs{
+		HTTP_CORS_ALLOWED_ORIGINS:   []string{"http://localhost"},
+		HTTP_CORS_ALLOWED_METHODS:   []string{http.MethodGet},
+		HTTP_CORS_ALLOW_CREDENTIALS: true,
+		HTTP_CORS_MAX_AGE:           time.Minute,
+		HTTP_CORS_STRICT:            true,
+	})
+
+	s.Equal([]string{http.MethodGet}, opts.AllowedMethods)
+	s.True(opts.AllowCredentials)
+	s.True(opts.Strict)
+	s.Equal(time.Minute, opts.MaxAge)
+}
+
+func (s *MiddlewaresTestSuite) TestValidateCORS() {
+	wildcard := &CORSOpts{AllowedOrigins: []string{"*"}, AllowCredentials: true, Strict: true}
+
+	s.ErrorIs(validateCORS(wildcard, env.PRODUCTION_ENV), ErrorCORSWildcardCredentials)
+	s.NoError(validateCORS(wildcard, env.DEVELOPMENT_ENV))
+
+	wildcard.Strict = false
+	s.NoError(validateCORS(wildcard, env.PRODUCTION_ENV))
+
+	s.ErrorIs(validateCORS(&CORSOpts{AllowedOrigins: []string{"^https://(.example.com"}}, env.DEVELOPMENT_ENV), ErrorCORSOrigin)
+}
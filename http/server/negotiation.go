@@ -0,0 +1,140 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	MsgpackContentType  = "application/msgpack"
+	ProtobufContentType = "application/x-protobuf"
+)
+
+// Negotiate the offer with the highest quality in the Accept header, the wildcards match the first offer, which is also
+// returned when the Accept is empty, an empty string means that none of the offers is acceptable
+func Negotiate(r *http.Request, offers ...string) string {
+	accept := r.Header.Get("Accept")
+	if accept == "" && len(offers) > 0 {
+		return offers[0]
+	}
+
+	parts := strings.Split(accept, ",")
+
+	// the offers explicitly refused with q=0 are not matched by the wildcards
+	refused := map[string]bool{}
+	for _, part := range parts {
+		if mediaType, q := parseAcceptPart(part); q <= 0 {
+			refused[mediaType] = true
+		}
+	}
+
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	for _, part := range parts {
+		mediaType, q := parseAcceptPart(part)
+		if q <= 0 {
+			continue
+		}
+
+		for _, offer := range offers {
+			specificity := acceptSpecificity(mediaType, offer)
+			if specificity < 0 || refused[offer] {
+				continue
+			}
+
+			if q > bestQ || q == bestQ && specificity > bestSpecificity {
+				best, bestQ, bestSpecificity = offer, q, specificity
+			}
+
+			break
+		}
+	}
+
+	return best
+}
+
+// Respond write the body encoded with the content type negotiated between JSON, msgpack and, to the proto.Message
+// bodies, protobuf. A 406 problem is written when none of them is acceptable
+//
+//	server.Respond(w, r, http.StatusOK, order)
+func Respond(w http.ResponseWriter, r *http.Request, status int, body any) error {
+	offers := []string{JsonContentType, MsgpackContentType}
+
+	message, isProto := body.(proto.Message)
+	if isProto {
+		offers = append(offers, ProtobufContentType)
+	}
+
+	var (
+		byt []byte
+		err error
+	)
+
+	contentType := Negotiate(r, offers...)
+	switch {
+	case contentType == "":
+		WriteProblem(w, r, NewProblem(http.StatusNotAcceptable, "supported: "+strings.Join(offers, ", ")))
+		return nil
+	case contentType == ProtobufContentType:
+		byt, err = proto.Marshal(message)
+	case contentType == MsgpackContentType:
+		byt, err = msgpack.Marshal(body)
+	case isProto:
+		byt, err = protojson.Marshal(message)
+	default:
+		byt, err = json.Marshal(body)
+	}
+
+	if err != nil {
+		WriteProblem(w, r, NewProblem(http.StatusInternalServerError, ""))
+		return err
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Add("Vary", "Accept")
+	w.WriteHeader(status)
+	_, err = w.Write(byt)
+
+	return err
+}
+
+func parseAcceptPart(part string) (string, float64) {
+	mediaType, params, _ := strings.Cut(part, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+
+	q := 1.0
+	for _, param := range strings.Split(params, ";") {
+		key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+		if key != "q" {
+			continue
+		}
+
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return mediaType, 0
+		}
+
+		q = parsed
+	}
+
+	return mediaType, q
+}
+
+// acceptSpecificity -1 when the accepted media type does not match the offer, 0 to */*, 1 to type/* and 2 to the exact match
+func acceptSpecificity(accepted, offer string) int {
+	switch {
+	case accepted == offer:
+		return 2
+	case accepted == "*/*":
+		return 0
+	case strings.HasSuffix(accepted, "/*") && strings.HasPrefix(offer, strings.TrimSuffix(accepted, "*")):
+		return 1
+	default:
+		return -1
+	}
+}
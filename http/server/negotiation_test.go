@@ -0,0 +1,67 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type NegotiationTestSuite struct {
+	suite.Suite
+}
+
+func TestNegotiationTestSuite(t *testing.T) {
+	suite.Run(t, new(NegotiationTestSuite))
+}
+
+func (s *NegotiationTestSuite) TestNegotiate() {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	s.Equal(JsonContentType, Negotiate(req, JsonContentType, MsgpackContentType))
+
+	req.Header.Set("Accept", "application/json;q=0.5, application/msgpack")
+	s.Equal(MsgpackContentType, Negotiate(req, JsonContentType, MsgpackContentType))
+
+	req.Header.Set("Accept", "text/html, application/*;q=0.8")
+	s.Equal(JsonContentType, Negotiate(req, JsonContentType, MsgpackContentType))
+
+	req.Header.Set("Accept", "application/json;q=0, */*")
+	s.Equal(MsgpackContentType, Negotiate(req, JsonContentType, MsgpackContentType))
+
+	req.Header.Set("Accept", "text/html")
+	s.Empty(Negotiate(req, JsonContentType, MsgpackContentType))
+}
+
+func (s *NegotiationTestSuite) TestRespond() {
+	respond := func(accept string, body any) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Accept", accept)
+
+		rec := httptest.NewRecorder()
+		s.NoError(Respond(rec, req, http.StatusOK, body))
+
+		return rec
+	}
+
+	rec := respond("", map[string]string{"name": "gokit"})
+	s.Equal(JsonContentType, rec.Header().Get("Content-Type"))
+	s.JSONEq(`{"name":"gokit"}`, rec.Body.String())
+
+	rec = respond(MsgpackContentType, map[string]string{"name": "gokit"})
+	decoded := map[string]string{}
+	s.NoError(msgpack.Unmarshal(rec.Body.Bytes(), &decoded))
+	s.Equal("gokit", decoded["name"])
+
+	rec = respond(ProtobufContentType, wrapperspb.String("gokit"))
+	message := &wrapperspb.StringValue{}
+	s.NoError(proto.Unmarshal(rec.Body.Bytes(), message))
+	s.Equal("gokit", message.Value)
+
+	rec = respond(ProtobufContentType, map[string]string{"name": "gokit"})
+	s.Equal(http.StatusNotAcceptable, rec.Code)
+	s.Equal(ProblemContentType, rec.Header().Get("Content-Type"))
+}
@@ -0,0 +1,466 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// TypedHandler the request is decoded and validated with Decode, the errors are written with WriteError
+	TypedHandler[I, O any] func(ctx context.Context, req *I) (*O, error)
+
+	// TypedRoute a route documented in the OpenAPI document, create it with Route
+	TypedRoute struct {
+		method      string
+		path        string
+		handler     http.HandlerFunc
+		summary     string
+		description string
+		tags        []string
+		status      int
+		request     reflect.Type
+		response    reflect.Type
+		middlewares []Middleware
+	}
+
+	// OpenAPIInfo the info object of the document, the defaults are the APP_NAME and DefaultOpenAPIVersion
+	OpenAPIInfo struct {
+		Title       string `json:"title"`
+		Version     string `json:"version"`
+		Description string `json:"description,omitempty"`
+	}
+
+	openAPI struct {
+		info   *OpenAPIInfo
+		mu     sync.RWMutex
+		routes []*TypedRoute
+	}
+
+	openAPIDocument struct {
+		OpenAPI    string                                  `json:"openapi"`
+		Info       *OpenAPIInfo                            `json:"info"`
+		Paths      map[string]map[string]*openAPIOperation `json:"paths"`
+		Components openAPIComponents                       `json:"components"`
+	}
+
+	openAPIComponents struct {
+		Schemas map[string]*openAPISchema `json:"schemas"`
+	}
+
+	openAPIOperation struct {
+		Summary     string                      `json:"summary,omitempty"`
+		Description string                      `json:"description,omitempty"`
+		Tags        []string                    `json:"tags,omitempty"`
+		Parameters  []openAPIParameter          `json:"parameters,omitempty"`
+		RequestBody *openAPIRequestBody         `json:"requestBody,omitempty"`
+		Responses   map[string]*openAPIResponse `json:"responses"`
+	}
+
+	openAPIParameter struct {
+		Name     string         `json:"name"`
+		In       string         `json:"in"`
+		Required bool           `json:"required,omitempty"`
+		Schema   *openAPISchema `json:"schema"`
+	}
+
+	openAPIRequestBody struct {
+		Required bool                        `json:"required"`
+		Content  map[string]openAPIMediaType `json:"content"`
+	}
+
+	openAPIResponse struct {
+		Description string                      `json:"description"`
+		Content     map[string]openAPIMediaType `json:"content,omitempty"`
+	}
+
+	openAPIMediaType struct {
+		Schema *openAPISchema `json:"schema"`
+	}
+
+	openAPISchema struct {
+		Ref                  string                    `json:"$ref,omitempty"`
+		Type                 string                    `json:"type,omitempty"`
+		Format               string                    `json:"format,omitempty"`
+		Nullable             bool                      `json:"nullable,omitempty"`
+		Items                *openAPISchema            `json:"items,omitempty"`
+		Properties           map[string]*openAPISchema `json:"properties,omitempty"`
+		AdditionalProperties *openAPISchema            `json:"additionalProperties,omitempty"`
+		Required             []string                  `json:"required,omitempty"`
+	}
+)
+
+const (
+	OpenAPIPath           = "/openapi.json"
+	SwaggerUIPath         = "/docs"
+	DefaultOpenAPIVersion = "1.0.0"
+
+	openAPISpecVersion = "3.0.3"
+	swaggerUIPage      = `<!DOCTYPE html>
+<html>
+<head>
+<title>%s</title>
+<link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@4/swagger-ui.css">
+</head>
+<body>
+<div id="swagger-ui"></div>
+<script src="https://unpkg.com/swagger-ui-dist@4/swagger-ui-bundle.js"></script>
+<script>window.ui = SwaggerUIBundle({url: "%s", dom_id: "#swagger-ui"});</script>
+</body>
+</html>`
+)
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	bytesType        = reflect.TypeOf([]byte{})
+	chiPatternRegex  = regexp.MustCompile(`\{([^}:]+):[^}]+\}`)
+	schemaNameRegex  = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+	bodyHTTPMethods  = map[string]bool{http.MethodPost: true, http.MethodPut: true, http.MethodPatch: true}
+	problemDocSchema = &openAPISchema{Ref: "#/components/schemas/ProblemDetails"}
+)
+
+// Route create a typed route, the request I is bound with Decode, so the path, query and json fields are documented.
+// The response O is written as JSON with the route status, when the handler returns nil O the response is 204
+//
+//	server.RegisterTypedRoutes(
+//		server.Route(http.MethodPost, "/users", createUser).Summary("create user").Tags("users").Status(http.StatusCreated),
+//	)
+func Route[I, O any](method, path string, handler TypedHandler[I, O]) *TypedRoute {
+	route := &TypedRoute{
+		method:   method,
+		path:     path,
+		status:   http.StatusOK,
+		request:  reflect.TypeOf((*I)(nil)).Elem(),
+		response: reflect.TypeOf((*O)(nil)).Elem(),
+	}
+
+	route.handler = func(w http.ResponseWriter, r *http.Request) {
+		req, ok := Bind[I](w, r)
+		if !ok {
+			return
+		}
+
+		resp, err := handler(r.Context(), req)
+		if err != nil {
+			WriteError(w, r, err)
+			return
+		}
+
+		if resp == nil {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		w.Header().Set("Content-Type", JsonContentType)
+		w.WriteHeader(route.status)
+		json.NewEncoder(w).Encode(resp)
+	}
+
+	return route
+}
+
+func (r *TypedRoute) Summary(summary string) *TypedRoute {
+	r.summary = summary
+	return r
+}
+
+func (r *TypedRoute) Description(description string) *TypedRoute {
+	r.description = description
+	return r
+}
+
+func (r *TypedRoute) Tags(tags ...string) *TypedRoute {
+	r.tags = append(r.tags, tags...)
+	return r
+}
+
+// Status the success status, default 200
+func (r *TypedRoute) Status(status int) *TypedRoute {
+	r.status = status
+	return r
+}
+
+// Timeout the route timeout, see Timeout
+func (r *TypedRoute) Timeout(timeout time.Duration) *TypedRoute {
+	r.middlewares = append(r.middlewares, Timeout(timeout))
+	return r
+}
+
+// MaxBodySize the route max request body size, replacing the server one, see MaxBodySize
+func (r *TypedRoute) MaxBodySize(limit int64) *TypedRoute {
+	r.middlewares = append(r.middlewares, MaxBodySize(limit))
+	return r
+}
+
+// WithOpenAPI serve the OpenAPI document of the typed routes in OpenAPIPath and the Swagger UI in SwaggerUIPath, when info is nil the defaults are used
+func (s *HTTPServer) WithOpenAPI(info *OpenAPIInfo) HTTPServerBuilder {
+	s.openapi = newOpenAPI(info, s.cfg.APP_NAME)
+	return s
+}
+
+func (s *HTTPServer) RegisterTypedRoutes(routes ...*TypedRoute) error {
+	for _, route := range routes {
+		var handler http.Handler = route.handler
+		for i := len(route.middlewares) - 1; i >= 0; i-- {
+			handler = route.middlewares[i](handler)
+		}
+
+		if err := s.RegisterRoute(route.method, route.path, handler.ServeHTTP); err != nil {
+			return err
+		}
+
+		if s.openapi != nil {
+			s.openapi.mu.Lock()
+			s.openapi.routes = append(s.openapi.routes, route)
+			s.openapi.mu.Unlock()
+		}
+	}
+
+	return nil
+}
+
+// OpenAPISpec the OpenAPI 3 document of the routes, e.g: to commit the document or generate the clients in the build
+func OpenAPISpec(info *OpenAPIInfo, routes ...*TypedRoute) ([]byte, error) {
+	return json.Marshal(newOpenAPI(info, "").document(routes))
+}
+
+func newOpenAPI(info *OpenAPIInfo, appName string) *openAPI {
+	if info == nil {
+		info = &OpenAPIInfo{}
+	}
+
+	if info.Title == "" {
+		info.Title = appName
+	}
+
+	if info.Version == "" {
+		info.Version = DefaultOpenAPIVersion
+	}
+
+	return &openAPI{info: info}
+}
+
+func (o *openAPI) specHandler(w http.ResponseWriter, r *http.Request) {
+	o.mu.RLock()
+	doc := o.document(o.routes)
+	o.mu.RUnlock()
+
+	w.Header().Set("Content-Type", JsonContentType)
+	json.NewEncoder(w).Encode(doc)
+}
+
+func (o *openAPI) swaggerUIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	fmt.Fprintf(w, swaggerUIPage, o.info.Title, OpenAPIPath)
+}
+
+func (o *openAPI) document(routes []*TypedRoute) *openAPIDocument {
+	schemas := map[string]*openAPISchema{}
+	schemaOf(reflect.TypeOf(ProblemDetails{}), schemas)
+
+	doc := &openAPIDocument{
+		OpenAPI:    openAPISpecVersion,
+		Info:       o.info,
+		Paths:      map[string]map[string]*openAPIOperation{},
+		Components: openAPIComponents{Schemas: schemas},
+	}
+
+	for _, route := range routes {
+		path := chiPatternRegex.ReplaceAllString(route.path, "{$1}")
+		if doc.Paths[path] == nil {
+			doc.Paths[path] = map[string]*openAPIOperation{}
+		}
+
+		doc.Paths[path][strings.ToLower(route.method)] = route.operation(schemas)
+	}
+
+	return doc
+}
+
+func (r *TypedRoute) operation(schemas map[string]*openAPISchema) *openAPIOperation {
+	op := &openAPIOperation{
+		Summary:     r.summary,
+		Description: r.description,
+		Tags:        r.tags,
+		Parameters:  parametersOf(r.request),
+		Responses: map[string]*openAPIResponse{
+			"default": {
+				Description: "problem details",
+				Content:     map[string]openAPIMediaType{ProblemContentType: {Schema: problemDocSchema}},
+			},
+		},
+	}
+
+	if bodyHTTPMethods[r.method] && hasJSONFields(r.request) {
+		op.RequestBody = &openAPIRequestBody{
+			Required: true,
+			Content:  map[string]openAPIMediaType{JsonContentType: {Schema: schemaOf(r.request, schemas)}},
+		}
+	}
+
+	op.Responses[strconv.Itoa(r.status)] = &openAPIResponse{
+		Description: http.StatusText(r.status),
+		Content:     map[string]openAPIMediaType{JsonContentType: {Schema: schemaOf(r.response, schemas)}},
+	}
+	op.Responses[strconv.Itoa(http.StatusNoContent)] = &openAPIResponse{Description: http.StatusText(http.StatusNoContent)}
+
+	return op
+}
+
+// parametersOf the fields tagged with path and query, see Decode
+func parametersOf(t reflect.Type) []openAPIParameter {
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	params := []openAPIParameter{}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if name := field.Tag.Get(PathTag); name != "" {
+			params = append(params, openAPIParameter{Name: name, In: "path", Required: true, Schema: schemaOf(field.Type, nil)})
+		} else if name := field.Tag.Get(QueryTag); name != "" {
+			params = append(params, openAPIParameter{Name: name, In: "query", Required: isRequired(field), Schema: schemaOf(field.Type, nil)})
+		}
+	}
+
+	return params
+}
+
+func hasJSONFields(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return t.Kind() != reflect.Interface
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		if _, ok := jsonField(t.Field(i)); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// schemaOf the named structs are stored in the schemas components and referenced, when schemas is nil they are inlined
+func schemaOf(t reflect.Type, schemas map[string]*openAPISchema) *openAPISchema {
+	switch {
+	case t == timeType:
+		return &openAPISchema{Type: "string", Format: "date-time"}
+	case t == bytesType:
+		return &openAPISchema{Type: "string", Format: "byte"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		schema := schemaOf(t.Elem(), schemas)
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+
+		return schema
+	case reflect.String:
+		return &openAPISchema{Type: "string"}
+	case reflect.Bool:
+		return &openAPISchema{Type: "boolean"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &openAPISchema{Type: "integer", Format: "int32"}
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return &openAPISchema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &openAPISchema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &openAPISchema{Type: "number", Format: "double"}
+	case reflect.Slice, reflect.Array:
+		return &openAPISchema{Type: "array", Items: schemaOf(t.Elem(), schemas)}
+	case reflect.Map:
+		return &openAPISchema{Type: "object", AdditionalProperties: schemaOf(t.Elem(), schemas)}
+	case reflect.Struct:
+		if t.Name() == "" || schemas == nil {
+			return structSchema(t, schemas)
+		}
+
+		name := schemaNameRegex.ReplaceAllString(t.Name(), "_")
+		ref := &openAPISchema{Ref: "#/components/schemas/" + name}
+
+		if _, ok := schemas[name]; !ok {
+			// the placeholder stops the recursive types
+			schemas[name] = &openAPISchema{}
+			*schemas[name] = *structSchema(t, schemas)
+		}
+
+		return ref
+	default:
+		return &openAPISchema{}
+	}
+}
+
+func structSchema(t reflect.Type, schemas map[string]*openAPISchema) *openAPISchema {
+	schema := &openAPISchema{Type: "object", Properties: map[string]*openAPISchema{}}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+			embedded := structSchema(field.Type, schemas)
+			for name, property := range embedded.Properties {
+				schema.Properties[name] = property
+			}
+			schema.Required = append(schema.Required, embedded.Required...)
+			continue
+		}
+
+		name, ok := jsonField(field)
+		if !ok {
+			continue
+		}
+
+		schema.Properties[name] = schemaOf(field.Type, schemas)
+		if isRequired(field) {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+
+	return schema
+}
+
+// jsonField the json name of the field, the path and query fields without json tag are not part of the body
+func jsonField(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+
+	if tag == "" && (field.Tag.Get(PathTag) != "" || field.Tag.Get(QueryTag) != "") {
+		return "", false
+	}
+
+	if name := strings.SplitN(tag, ",", 2)[0]; name != "" {
+		return name, true
+	}
+
+	return field.Name, true
+}
+
+func isRequired(field reflect.StructField) bool {
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/errors"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type OpenAPITestSuite struct {
+	suite.Suite
+
+	server *HTTPServer
+}
+
+type (
+	createOrderRequest struct {
+		TenantID string      `path:"tenant"`
+		DryRun   bool        `query:"dryRun"`
+		Customer string      `json:"customer" validate:"required"`
+		Items    []orderItem `json:"items" validate:"required"`
+		Notes    *string     `json:"notes,omitempty"`
+	}
+
+	orderItem struct {
+		SKU      string `json:"sku" validate:"required"`
+		Quantity int    `json:"quantity"`
+	}
+
+	order struct {
+		ID        string    `json:"id"`
+		Customer  string    `json:"customer"`
+		CreatedAt time.Time `json:"createdAt"`
+		Parent    *order    `json:"parent,omitempty"`
+	}
+
+	getOrderRequest struct {
+		ID string `path:"id"`
+	}
+)
+
+func TestOpenAPITestSuite(t *testing.T) {
+	suite.Run(t, new(OpenAPITestSuite))
+}
+
+func (s *OpenAPITestSuite) SetupTest() {
+	cfg := &env.Configs{APP_NAME: "orders", IS_HTTP_OPENAPI_ENABLED: true}
+	built, err := New(cfg, logging.NewMockLogger(), make(chan os.Signal, 1)).Build()
+	s.Require().NoError(err)
+	s.server = built.(*HTTPServer)
+
+	err = s.server.RegisterTypedRoutes(
+		Route(http.MethodPost, "/tenants/{tenant}/orders", func(ctx context.Context, req *createOrderRequest) (*order, error) {
+			return &order{ID: "1", Customer: req.Customer}, nil
+		}).Summary("create order").Tags("orders").Status(http.StatusCreated),
+		Route(http.MethodGet, "/orders/{id:[0-9]+}", func(ctx context.Context, req *getOrderRequest) (*order, error) {
+			return nil, errors.NotFound("order not found")
+		}),
+	)
+	s.Require().NoError(err)
+}
+
+func (s *OpenAPITestSuite) serve(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", JsonContentType)
+	}
+
+	rec := httptest.NewRecorder()
+	s.server.router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *OpenAPITestSuite) TestTypedRoute() {
+	rec := s.serve(http.MethodPost, "/tenants/t1/orders", `{"customer":"gokit","items":[{"sku":"a"}]}`)
+	s.Equal(http.StatusCreated, rec.Code)
+	s.JSONEq(`{"id":"1","customer":"gokit","createdAt":"0001-01-01T00:00:00Z"}`, rec.Body.String())
+
+	rec = s.serve(http.MethodPost, "/tenants/t1/orders", `{}`)
+	s.Equal(http.StatusUnprocessableEntity, rec.Code)
+
+	rec = s.serve(http.MethodGet, "/orders/10", "")
+	s.Equal(http.StatusNotFound, rec.Code)
+	s.Equal(ProblemContentType, rec.Header().Get("Content-Type"))
+}
+
+func (s *OpenAPITestSuite) TestOpenAPIDocument() {
+	rec := s.serve(http.MethodGet, OpenAPIPath, "")
+	s.Equal(http.StatusOK, rec.Code)
+
+	doc := &openAPIDocument{}
+	s.Require().NoError(json.Unmarshal(rec.Body.Bytes(), doc))
+
+	s.Equal("orders", doc.Info.Title)
+	s.Equal(DefaultOpenAPIVersion, doc.Info.Version)
+
+	create := doc.Paths["/tenants/{tenant}/orders"]["post"]
+	s.Require().NotNil(create)
+	s.Equal("create order", create.Summary)
+	s.Equal([]string{"orders"}, create.Tags)
+	s.Equal([]openAPIParameter{
+		{Name: "tenant", In: "path", Required: true, Schema: &openAPISchema{Type: "string"}},
+		{Name: "dryRun", In: "query", Schema: &openAPISchema{Type: "boolean"}},
+	}, create.Parameters)
+	s.Equal("#/components/schemas/createOrderRequest", create.RequestBody.Content[JsonContentType].Schema.Ref)
+	s.Equal("#/components/schemas/order", create.Responses["201"].Content[JsonContentType].Schema.Ref)
+
+	get := doc.Paths["/orders/{id}"]["get"]
+	s.Require().NotNil(get)
+	s.Nil(get.RequestBody)
+
+	request := doc.Components.Schemas["createOrderRequest"]
+	s.Equal([]string{"customer", "items"}, request.Required)
+	s.NotContains(request.Properties, "TenantID")
+	s.Equal("#/components/schemas/orderItem", request.Properties["items"].Items.Ref)
+	s.True(request.Properties["notes"].Nullable)
+
+	response := doc.Components.Schemas["order"]
+	s.Equal("date-time", response.Properties["createdAt"].Format)
+	s.Equal("#/components/schemas/order", response.Properties["parent"].Ref)
+	s.Contains(doc.Components.Schemas, "ProblemDetails")
+}
+
+func (s *OpenAPITestSuite) TestSwaggerUI() {
+	rec := s.serve(http.MethodGet, SwaggerUIPath, "")
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Contains(rec.Body.String(), OpenAPIPath)
+}
+
+func (s *OpenAPITestSuite) TestOpenAPIDisabled() {
+	built, err := New(&env.Configs{}, logging.NewMockLogger(), make(chan os.Signal, 1)).Build()
+	s.Require().NoError(err)
+	server := built.(*HTTPServer)
+
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, OpenAPIPath, nil))
+
+	s.Equal(http.StatusNotFound, rec.Code)
+}
+
+func (s *OpenAPITestSuite) TestOpenAPISpec() {
+	spec, err := OpenAPISpec(&OpenAPIInfo{Title: "orders", Version: "2.0.0"}, Route(http.MethodGet, "/orders/{id}", func(ctx context.Context, req *getOrderRequest) (*order, error) {
+		return nil, nil
+	}))
+
+	s.NoError(err)
+	s.Contains(string(spec), `"version":"2.0.0"`)
+	s.Contains(string(spec), `"/orders/{id}"`)
+}
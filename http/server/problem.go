@@ -0,0 +1,95 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ralvescosta/gokit/errors"
+)
+
+const (
+	ProblemContentType = "application/problem+json"
+	ProblemDefaultType = "about:blank"
+)
+
+type (
+	// InvalidParam detail of a field that failed in the validation
+	InvalidParam struct {
+		Name   string `json:"name"`
+		Reason string `json:"reason"`
+	}
+
+	// ProblemDetails error response as described in RFC 7807
+	ProblemDetails struct {
+		Type          string         `json:"type"`
+		Title         string         `json:"title"`
+		Status        int            `json:"status"`
+		Detail        string         `json:"detail,omitempty"`
+		Instance      string         `json:"instance,omitempty"`
+		InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+	}
+)
+
+// NewProblem create a problem with the status text as title
+func NewProblem(status int, detail string) *ProblemDetails {
+	return &ProblemDetails{
+		Type:   ProblemDefaultType,
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+func (p *ProblemDetails) Error() string {
+	if p.Detail != "" {
+		return p.Title + ": " + p.Detail
+	}
+
+	return p.Title
+}
+
+// WriteProblem write the problem as application/problem+json
+func WriteProblem(w http.ResponseWriter, r *http.Request, p *ProblemDetails) {
+	if p.Instance == "" && r != nil {
+		p.Instance = r.URL.Path
+	}
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
+
+// ProblemFromError map the error into a problem, AppErrors use their kind to define the status
+func ProblemFromError(err error) *ProblemDetails {
+	var problem *ProblemDetails
+	if errors.As(err, &problem) {
+		return problem
+	}
+
+	if errors.Is(err, ErrorBodyTooLarge) {
+		return NewProblem(http.StatusRequestEntityTooLarge, "")
+	}
+
+	status := errors.HTTPStatus(err)
+	if status == http.StatusInternalServerError {
+		// internal errors are not exposed to the client
+		return NewProblem(status, "")
+	}
+
+	p := NewProblem(status, "")
+
+	var appErr *errors.AppError
+	if errors.As(err, &appErr) {
+		p.Detail = appErr.Message
+		if appErr.Code != "" {
+			p.Type = appErr.Code
+		}
+	}
+
+	return p
+}
+
+// WriteError write the error as a problem details response
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	WriteProblem(w, r, ProblemFromError(err))
+}
@@ -0,0 +1,213 @@
+package server
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/ralvescosta/gokit/logging"
+	"go.uber.org/zap"
+)
+
+type (
+	// RequestLoggingOpts the bodies are not logged by default, enable them carefully since they could contain personal data
+	RequestLoggingOpts struct {
+		RequestBody  bool
+		ResponseBody bool
+		// MaxBodySize the captured bodies are truncated, default DefaultMaxLoggedBodySize
+		MaxBodySize int
+		// BodySampleRate the fraction, between 0 and 1, of the requests that have the bodies captured, default 1
+		BodySampleRate float64
+		// Headers log the request headers, the sensitive headers are redacted
+		Headers bool
+		// RedactHeaders the headers redacted in addition to the DefaultRedactedHeaders
+		RedactHeaders []string
+		// SkipPaths the paths not logged, e.g: the probes
+		SkipPaths []string
+	}
+
+	// bodyCapture keep up to max bytes, the writes never fail so the request and the response are not affected
+	bodyCapture struct {
+		buf       bytes.Buffer
+		max       int
+		size      int64
+		truncated bool
+	}
+
+	captureReadCloser struct {
+		io.Reader
+		io.Closer
+	}
+)
+
+const DefaultMaxLoggedBodySize = 4 * 1024
+
+var (
+	DefaultRedactedHeaders = []string{"Authorization", "Cookie", "Set-Cookie", "Proxy-Authorization", "X-Api-Key", CSRFHeader}
+	defaultBodySampler     = rand.Float64
+	// bodySampler test seam to the BodySampleRate
+	bodySampler = defaultBodySampler
+)
+
+// WithRequestLogging configure the request logging middleware, the default logs the request without the bodies
+func (s *HTTPServer) WithRequestLogging(opts *RequestLoggingOpts) HTTPServerBuilder {
+	s.requestLogging = opts
+	return s
+}
+
+// RequestLogging log the method, the route template, the status, the latency and the sizes of each request.
+// The 5xx responses are logged as error and the 4xx as warn
+func RequestLogging(logger logging.ILogger, opts *RequestLoggingOpts) Middleware {
+	opts = requestLoggingDefaults(opts)
+
+	redact := map[string]bool{}
+	for _, h := range append(DefaultRedactedHeaders, opts.RedactHeaders...) {
+		redact[http.CanonicalHeaderKey(h)] = true
+	}
+
+	skip := map[string]bool{}
+	for _, p := range opts.SkipPaths {
+		skip[p] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if skip[r.URL.Path] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			sampled := (opts.RequestBody || opts.ResponseBody) && bodySampler() < opts.BodySampleRate
+
+			var reqBody, resBody *bodyCapture
+			if sampled && opts.RequestBody && r.Body != nil && r.Body != http.NoBody {
+				reqBody = &bodyCapture{max: opts.MaxBodySize}
+				r.Body = captureReadCloser{io.TeeReader(r.Body, reqBody), r.Body}
+			}
+
+			if sampled && opts.ResponseBody {
+				resBody = &bodyCapture{max: opts.MaxBodySize}
+				ww.Tee(resBody)
+			}
+
+			defer func() {
+				status := ww.Status()
+				if status == 0 {
+					status = http.StatusOK
+				}
+
+				requestSize := r.ContentLength
+				if reqBody != nil && requestSize < 0 {
+					requestSize = reqBody.size
+				}
+
+				fields := []zap.Field{
+					logging.MessageField("method", r.Method),
+					logging.MessageField("route", routePattern(r)),
+					logging.MessageField("path", r.URL.Path),
+					zap.Int("status", status),
+					zap.Duration("duration", time.Since(start)),
+					zap.Int64("requestSize", requestSize),
+					zap.Int("responseSize", ww.BytesWritten()),
+					logging.MessageField("requestId", middleware.GetReqID(r.Context())),
+				}
+
+				if opts.Headers {
+					fields = append(fields, zap.Any("headers", redactHeaders(r.Header, redact)))
+				}
+
+				if reqBody != nil {
+					fields = append(fields, logging.MessageField("requestBody", reqBody.String()))
+				}
+
+				if resBody != nil {
+					fields = append(fields, logging.MessageField("responseBody", resBody.String()))
+				}
+
+				switch {
+				case status >= http.StatusInternalServerError:
+					logger.Error(LogMessage("request"), fields...)
+				case status >= http.StatusBadRequest:
+					logger.Warn(LogMessage("request"), fields...)
+				default:
+					logger.Info(LogMessage("request"), fields...)
+				}
+			}()
+
+			next.ServeHTTP(ww, r)
+		})
+	}
+}
+
+func requestLoggingDefaults(opts *RequestLoggingOpts) *RequestLoggingOpts {
+	if opts == nil {
+		opts = &RequestLoggingOpts{}
+	}
+
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultMaxLoggedBodySize
+	}
+
+	if opts.BodySampleRate <= 0 {
+		opts.BodySampleRate = 1
+	}
+
+	return opts
+}
+
+// routePattern the chi route template, e.g: /users/{id}, so the logs could be grouped by route
+func routePattern(r *http.Request) string {
+	if rctx := chi.RouteContext(r.Context()); rctx != nil {
+		if pattern := rctx.RoutePattern(); pattern != "" {
+			return pattern
+		}
+	}
+
+	return r.URL.Path
+}
+
+func redactHeaders(header http.Header, redact map[string]bool) map[string]string {
+	out := make(map[string]string, len(header))
+	for k, v := range header {
+		if redact[http.CanonicalHeaderKey(k)] {
+			out[k] = RedactedValue
+			continue
+		}
+
+		out[k] = strings.Join(v, ", ")
+	}
+
+	return out
+}
+
+func (c *bodyCapture) Write(p []byte) (int, error) {
+	c.size += int64(len(p))
+
+	if room := c.max - c.buf.Len(); room > 0 {
+		if len(p) > room {
+			c.buf.Write(p[:room])
+			c.truncated = true
+		} else {
+			c.buf.Write(p)
+		}
+	} else if len(p) > 0 {
+		c.truncated = true
+	}
+
+	return len(p), nil
+}
+
+func (c *bodyCapture) String() string {
+	if c.truncated {
+		return c.buf.String() + "...(truncated)"
+	}
+
+	return c.buf.String()
+}
@@ -0,0 +1,121 @@
+package server
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/suite"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type RequestLoggingTestSuite struct {
+	suite.Suite
+
+	logs   *observer.ObservedLogs
+	logger *zap.Logger
+}
+
+func TestRequestLoggingTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestLoggingTestSuite))
+}
+
+func (s *RequestLoggingTestSuite) SetupTest() {
+	core, logs := observer.New(zapcore.DebugLevel)
+	s.logs = logs
+	s.logger = zap.New(core)
+	bodySampler = func() float64 { return 0 }
+}
+
+func (s *RequestLoggingTestSuite) TearDownTest() {
+	bodySampler = defaultBodySampler
+}
+
+func (s *RequestLoggingTestSuite) serve(opts *RequestLoggingOpts, req *http.Request, status int, body string) map[string]interface{} {
+	router := chi.NewRouter()
+	router.Use(RequestLogging(s.logger, opts))
+	router.HandleFunc("/users/{id}", func(w http.ResponseWriter, r *http.Request) {
+		io.ReadAll(r.Body)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := s.logs.TakeAll()
+	s.Len(entries, 1)
+
+	return entries[0].ContextMap()
+}
+
+func (s *RequestLoggingTestSuite) TestRequestLogging() {
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+
+	fields := s.serve(nil, req, http.StatusOK, "ok")
+
+	s.Equal("/users/{id}", fields["route"])
+	s.Equal("/users/1", fields["path"])
+	s.Equal(int64(http.StatusOK), fields["status"])
+	s.Equal(int64(2), fields["responseSize"])
+	s.NotContains(fields, "requestBody")
+	s.NotContains(fields, "responseBody")
+	s.NotContains(fields, "headers")
+}
+
+func (s *RequestLoggingTestSuite) TestRequestLoggingLevel() {
+	s.serve(nil, httptest.NewRequest(http.MethodGet, "/users/1", nil), http.StatusNotFound, "")
+	s.serve(nil, httptest.NewRequest(http.MethodGet, "/users/1", nil), http.StatusBadGateway, "")
+
+	router := chi.NewRouter()
+	router.Use(RequestLogging(s.logger, nil))
+	router.Get("/", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) })
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal(zapcore.ErrorLevel, s.logs.TakeAll()[0].Level)
+}
+
+func (s *RequestLoggingTestSuite) TestRequestLoggingBodies() {
+	req := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader(`{"name":"gokit"}`))
+
+	fields := s.serve(&RequestLoggingOpts{RequestBody: true, ResponseBody: true, MaxBodySize: 8}, req, http.StatusCreated, "created")
+
+	s.Equal(`{"name":...(truncated)`, fields["requestBody"])
+	s.Equal("created", fields["responseBody"])
+	s.Equal(int64(16), fields["requestSize"])
+}
+
+func (s *RequestLoggingTestSuite) TestRequestLoggingBodySampling() {
+	bodySampler = func() float64 { return 0.9 }
+	req := httptest.NewRequest(http.MethodPost, "/users/1", strings.NewReader(`{}`))
+
+	fields := s.serve(&RequestLoggingOpts{RequestBody: true, BodySampleRate: 0.5}, req, http.StatusOK, "")
+
+	s.NotContains(fields, "requestBody")
+}
+
+func (s *RequestLoggingTestSuite) TestRequestLoggingHeadersRedaction() {
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("X-Tenant-Secret", "secret")
+	req.Header.Set("Accept", "application/json")
+
+	fields := s.serve(&RequestLoggingOpts{Headers: true, RedactHeaders: []string{"x-tenant-secret"}}, req, http.StatusOK, "")
+
+	headers := fields["headers"].(map[string]string)
+	s.Equal(RedactedValue, headers["Authorization"])
+	s.Equal(RedactedValue, headers["X-Tenant-Secret"])
+	s.Equal("application/json", headers["Accept"])
+}
+
+func (s *RequestLoggingTestSuite) TestRequestLoggingSkipPaths() {
+	handler := RequestLogging(s.logger, &RequestLoggingOpts{SkipPaths: []string{"/healthz"}})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	s.Equal(0, s.logs.Len())
+}
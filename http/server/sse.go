@@ -0,0 +1,274 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+const (
+	SSEContentType      = "text/event-stream"
+	LastEventIDHeader   = "Last-Event-ID"
+	LastEventIDQuery    = "lastEventId"
+	DefaultSSEBuffer    = 32
+	DefaultSSEHeartbeat = 15 * time.Second
+)
+
+var (
+	ErrorStreamingUnsupported = errors.New("the response writer does not support streaming")
+	ErrorStreamClosed         = errors.New("sse stream closed")
+)
+
+type (
+	// SSEEvent a server-sent event, Data strings and []byte are sent as is, other values are JSON encoded
+	SSEEvent struct {
+		ID    string
+		Event string
+		Data  any
+		// Retry tell the browser how long to wait before reconnecting
+		Retry time.Duration
+	}
+
+	SSEOpts struct {
+		// Heartbeat interval of the comment lines that keep proxies from closing idle streams
+		Heartbeat time.Duration
+		// Buffer how many events can be queued before Send blocks
+		Buffer int
+		// Retry the reconnection delay sent when the stream starts
+		Retry time.Duration
+	}
+
+	// SSEProducer push the events to the stream until ctx is done, the stream ends when it returns
+	SSEProducer = func(ctx context.Context, stream *SSEStream) error
+
+	SSEStream struct {
+		// LastEventID the last event received by the client before reconnecting, used to resume the stream
+		LastEventID string
+		Request     *http.Request
+
+		events chan *SSEEvent
+		done   chan struct{}
+	}
+)
+
+// SSE create a handler streaming the events pushed by the producer. Events are written by the handler goroutine,
+// so a slow client blocks Send instead of growing the memory, use TrySend to drop events instead.
+// The server write timeout is disabled to the stream when supported by the runtime
+func SSE(logger logging.ILogger, opts *SSEOpts, producer SSEProducer) http.HandlerFunc {
+	if opts == nil {
+		opts = &SSEOpts{}
+	}
+
+	if opts.Heartbeat == 0 {
+		opts.Heartbeat = DefaultSSEHeartbeat
+	}
+
+	if opts.Buffer == 0 {
+		opts.Buffer = DefaultSSEBuffer
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			logger.Error(LogMessage("sse"), logging.ErrorField(ErrorStreamingUnsupported))
+			http.Error(w, ErrorStreamingUnsupported.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		disableWriteDeadline(w)
+
+		w.Header().Set("Content-Type", SSEContentType)
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		if opts.Retry > 0 {
+			fmt.Fprintf(w, "retry: %d\n\n", opts.Retry.Milliseconds())
+		}
+		flusher.Flush()
+
+		lastEventID := r.Header.Get(LastEventIDHeader)
+		if lastEventID == "" {
+			lastEventID = r.URL.Query().Get(LastEventIDQuery)
+		}
+
+		stream := &SSEStream{
+			LastEventID: lastEventID,
+			Request:     r,
+			events:      make(chan *SSEEvent, opts.Buffer),
+			done:        make(chan struct{}),
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+
+		produced := make(chan error, 1)
+		go func() {
+			defer close(stream.events)
+			produced <- producer(ctx, stream)
+		}()
+
+		heartbeat := time.NewTicker(opts.Heartbeat)
+		defer heartbeat.Stop()
+		defer close(stream.done)
+
+		for {
+			select {
+			case evt, ok := <-stream.events:
+				if !ok {
+					if err := <-produced; err != nil {
+						logger.Error(LogMessage("sse producer failure"), logging.ErrorField(err))
+					}
+					return
+				}
+
+				err := writeSSEEvent(w, evt)
+				if errors.Is(err, ErrorSSEInvalidField) {
+					logger.Warn(LogMessage("sse event discarded"), logging.ErrorField(err))
+					continue
+				}
+
+				if err != nil {
+					logger.Warn(LogMessage("sse write failure"), logging.ErrorField(err))
+					return
+				}
+				flusher.Flush()
+
+			case <-heartbeat.C:
+				if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+					return
+				}
+				flusher.Flush()
+
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
+
+// Send queue the event, blocking while the buffer is full until ctx is done or the client disconnects
+func (s *SSEStream) Send(ctx context.Context, evt *SSEEvent) error {
+	select {
+	case <-s.done:
+		return ErrorStreamClosed
+	default:
+	}
+
+	select {
+	case s.events <- evt:
+		return nil
+	case <-s.done:
+		return ErrorStreamClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// TrySend queue the event without blocking, returns false when the buffer is full or the stream closed
+func (s *SSEStream) TrySend(evt *SSEEvent) bool {
+	select {
+	case <-s.done:
+		return false
+	default:
+	}
+
+	select {
+	case s.events <- evt:
+		return true
+	default:
+		return false
+	}
+}
+
+// SSEChannel a producer that forwards the channel events, e.g: filled by a broker consumer
+func SSEChannel(events <-chan *SSEEvent) SSEProducer {
+	return func(ctx context.Context, stream *SSEStream) error {
+		for {
+			select {
+			case evt, ok := <-events:
+				if !ok {
+					return nil
+				}
+
+				if err := stream.Send(ctx, evt); err != nil {
+					return nil
+				}
+			case <-ctx.Done():
+				return nil
+			}
+		}
+	}
+}
+
+// writeSSEEvent the line breaks would start a new field, so the ID and Event with CR or LF are rejected and the data is
+// split in the \r\n, \r and \n line endings
+func writeSSEEvent(w http.ResponseWriter, evt *SSEEvent) error {
+	if strings.ContainsAny(evt.ID, "\r\n") || strings.ContainsAny(evt.Event, "\r\n") {
+		return ErrorSSEInvalidField
+	}
+
+	var data []byte
+	switch v := evt.Data.(type) {
+	case nil:
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		byt, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		data = byt
+	}
+
+	buf := bytes.Buffer{}
+	if evt.ID != "" {
+		fmt.Fprintf(&buf, "id: %s\n", evt.ID)
+	}
+
+	if evt.Event != "" {
+		fmt.Fprintf(&buf, "event: %s\n", evt.Event)
+	}
+
+	if evt.Retry > 0 {
+		fmt.Fprintf(&buf, "retry: %d\n", evt.Retry.Milliseconds())
+	}
+
+	// multiline data is sent as multiple data fields, the browser join them with \n
+	for _, line := range strings.Split(sseLineEndings.Replace(string(data)), "\n") {
+		fmt.Fprintf(&buf, "data: %s\n", line)
+	}
+	buf.WriteString("\n")
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// sseLineEndings normalize the line endings to \n, the \r\n is replaced before the lone \r
+var sseLineEndings = strings.NewReplacer("\r\n", "\n", "\r", "\n")
+
+// disableWriteDeadline clear the server write timeout to long lived responses, unwrapping the middlewares writers.
+// The deadline is only exposed by the runtime since go 1.20, in older versions it is a no-op
+func disableWriteDeadline(w http.ResponseWriter) {
+	for {
+		switch rw := w.(type) {
+		case interface{ SetWriteDeadline(time.Time) error }:
+			rw.SetWriteDeadline(time.Time{})
+			return
+		case interface{ Unwrap() http.ResponseWriter }:
+			w = rw.Unwrap()
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package server
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type SSETestSuite struct {
+	suite.Suite
+}
+
+func TestSSETestSuite(t *testing.T) {
+	suite.Run(t, new(SSETestSuite))
+}
+
+func (s *SSETestSuite) TestWriteEvent() {
+	rec := httptest.NewRecorder()
+
+	err := writeSSEEvent(rec, &SSEEvent{ID: "1", Event: "order", Data: "line1\nline2\r\nline3\rline4"})
+	s.NoError(err)
+	s.Equal("id: 1\nevent: order\ndata: line1\ndata: line2\ndata: line3\ndata: line4\n\n", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	s.ErrorIs(writeSSEEvent(rec, &SSEEvent{Event: "order\ndata: injected", Data: "x"}), ErrorSSEInvalidField)
+	s.ErrorIs(writeSSEEvent(rec, &SSEEvent{ID: "1\r", Data: "x"}), ErrorSSEInvalidField)
+	s.Empty(rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	err = writeSSEEvent(rec, &SSEEvent{Data: map[string]int{"id": 1}, Retry: time.Second})
+	s.NoError(err)
+	s.Equal("retry: 1000\ndata: {\"id\":1}\n\n", rec.Body.String())
+}
+
+func (s *SSETestSuite) TestStream() {
+	events := make(chan *SSEEvent, 2)
+	events <- &SSEEvent{ID: "2", Data: "resumed"}
+	close(events)
+
+	var lastEventID string
+	server := httptest.NewServer(SSE(logging.NewMockLogger(), &SSEOpts{Retry: time.Second}, func(ctx context.Context, stream *SSEStream) error {
+		lastEventID = stream.LastEventID
+		return SSEChannel(events)(ctx, stream)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	req.Header.Set(LastEventIDHeader, "1")
+
+	res, err := http.DefaultClient.Do(req)
+	s.Require().NoError(err)
+	defer res.Body.Close()
+
+	s.Equal(SSEContentType, res.Header.Get("Content-Type"))
+
+	body := strings.Builder{}
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		body.WriteString(scanner.Text() + "\n")
+	}
+
+	s.Equal("1", lastEventID)
+	s.Equal("retry: 1000\n\nid: 2\ndata: resumed\n\n", body.String())
+}
+
+func (s *SSETestSuite) TestHeartbeat() {
+	server := httptest.NewServer(SSE(logging.NewMockLogger(), &SSEOpts{Heartbeat: 10 * time.Millisecond}, func(ctx context.Context, stream *SSEStream) error {
+		<-ctx.Done()
+		return nil
+	}))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	s.Require().NoError(err)
+	defer res.Body.Close()
+
+	line, err := bufio.NewReader(res.Body).ReadString('\n')
+	s.NoError(err)
+	s.Equal(": heartbeat\n", line)
+}
+
+func (s *SSETestSuite) TestBackpressure() {
+	stream := &SSEStream{events: make(chan *SSEEvent, 1), done: make(chan struct{})}
+
+	s.True(stream.TrySend(&SSEEvent{Data: "1"}))
+	s.False(stream.TrySend(&SSEEvent{Data: "2"}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.ErrorIs(stream.Send(ctx, &SSEEvent{Data: "2"}), context.DeadlineExceeded)
+
+	close(stream.done)
+	s.ErrorIs(stream.Send(context.Background(), &SSEEvent{Data: "2"}), ErrorStreamClosed)
+}
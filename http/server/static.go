@@ -0,0 +1,257 @@
+package server
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// StaticOpts the assets are read once and kept in memory, so the fs is expected to be immutable, e.g: an embed.FS
+	StaticOpts struct {
+		// Root the directory of the fs with the assets, e.g: "dist"
+		Root string
+		// Prefix the route prefix removed from the request path, ServeStatic set it with the mount prefix
+		Prefix string
+		// Index default DefaultStaticIndex
+		Index string
+		// SPA serve the Index for the unknown paths without extension, so the client router handles them
+		SPA bool
+		// MaxAge the cache max age of the assets, default DefaultStaticMaxAge, the Index is always revalidated
+		MaxAge time.Duration
+		// Immutable the asset names contain the content hash, e.g: app.3f2a1c.js, so the browsers never revalidate them
+		Immutable bool
+	}
+
+	staticAsset struct {
+		content     []byte
+		contentType string
+		etag        string
+		index       bool
+		// encoded the precompressed, .br and .gz, or the gzip compressed content by the encoding
+		encoded map[string][]byte
+	}
+
+	staticHandler struct {
+		fsys   fs.FS
+		opts   *StaticOpts
+		mu     sync.RWMutex
+		assets map[string]*staticAsset
+	}
+)
+
+const (
+	DefaultStaticIndex  = "index.html"
+	DefaultStaticMaxAge = time.Hour
+
+	staticMinCompressSize = 1024
+)
+
+var staticEncodings = []struct{ name, ext string }{{"br", ".br"}, {"gzip", ".gz"}}
+
+// Static serve the assets of the fs with the cache headers, the ETag, the precompressed or gzip compressed content
+// and optionally the SPA history fallback
+//
+//	//go:embed dist
+//	var dashboard embed.FS
+//
+//	handler, err := server.Static(dashboard, &server.StaticOpts{Root: "dist", SPA: true})
+func Static(fsys fs.FS, opts *StaticOpts) (http.Handler, error) {
+	if opts == nil {
+		opts = &StaticOpts{}
+	}
+
+	if opts.Index == "" {
+		opts.Index = DefaultStaticIndex
+	}
+
+	if opts.MaxAge == 0 {
+		opts.MaxAge = DefaultStaticMaxAge
+	}
+
+	if opts.Root != "" && opts.Root != "." {
+		sub, err := fs.Sub(fsys, opts.Root)
+		if err != nil {
+			return nil, err
+		}
+
+		fsys = sub
+	}
+
+	if opts.SPA {
+		if _, err := fs.Stat(fsys, opts.Index); err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrorStaticIndex, err)
+		}
+	}
+
+	return &staticHandler{fsys: fsys, opts: opts, assets: map[string]*staticAsset{}}, nil
+}
+
+// ServeStatic mount the Static handler in the prefix, e.g: /dashboard
+func (s *HTTPServer) ServeStatic(prefix string, fsys fs.FS, opts *StaticOpts) error {
+	if opts == nil {
+		opts = &StaticOpts{}
+	}
+
+	opts.Prefix = strings.TrimSuffix(prefix, "/")
+
+	handler, err := Static(fsys, opts)
+	if err != nil {
+		s.logger.Error(LogMessage("failure to create the static handler"))
+		return err
+	}
+
+	s.Mount(prefix, handler)
+	return nil
+}
+
+func (h *staticHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		WriteProblem(w, r, NewProblem(http.StatusMethodNotAllowed, ""))
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+strings.TrimPrefix(r.URL.Path, h.opts.Prefix)), "/")
+	if name == "" {
+		name = h.opts.Index
+	}
+
+	asset, err := h.asset(name)
+	if errors.Is(err, fs.ErrNotExist) && h.opts.SPA && path.Ext(name) == "" {
+		name = h.opts.Index
+		asset, err = h.asset(name)
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		WriteProblem(w, r, NewProblem(http.StatusNotFound, ""))
+		return
+	}
+
+	if err != nil {
+		WriteProblem(w, r, NewProblem(http.StatusInternalServerError, ""))
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", asset.contentType)
+	header.Add("Vary", "Accept-Encoding")
+
+	switch {
+	case asset.index:
+		header.Set("Cache-Control", "no-cache")
+	case h.opts.Immutable:
+		header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", int(h.opts.MaxAge.Seconds())))
+	default:
+		header.Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.opts.MaxAge.Seconds())))
+	}
+
+	content, etag := asset.content, asset.etag
+	for _, enc := range staticEncodings {
+		if encoded, ok := asset.encoded[enc.name]; ok && acceptsEncoding(r, enc.name) {
+			content = encoded
+			etag = strings.TrimSuffix(etag, `"`) + "-" + enc.name + `"`
+			header.Set("Content-Encoding", enc.name)
+			break
+		}
+	}
+
+	header.Set("ETag", etag)
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(content))
+}
+
+// asset read the file, or the index of the directory, and cache it with the ETag and the compressed variants
+func (h *staticHandler) asset(name string) (*staticAsset, error) {
+	h.mu.RLock()
+	asset, ok := h.assets[name]
+	h.mu.RUnlock()
+
+	if ok {
+		return asset, nil
+	}
+
+	info, err := fs.Stat(h.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	file := name
+	if info.IsDir() {
+		file = path.Join(name, h.opts.Index)
+	}
+
+	content, err := fs.ReadFile(h.fsys, file)
+	if err != nil {
+		return nil, err
+	}
+
+	asset = &staticAsset{
+		content:     content,
+		contentType: mime.TypeByExtension(path.Ext(file)),
+		etag:        ETag(content),
+		index:       path.Base(file) == h.opts.Index,
+		encoded:     map[string][]byte{},
+	}
+
+	if asset.contentType == "" {
+		asset.contentType = http.DetectContentType(content)
+	}
+
+	for _, enc := range staticEncodings {
+		if encoded, err := fs.ReadFile(h.fsys, file+enc.ext); err == nil {
+			asset.encoded[enc.name] = encoded
+		}
+	}
+
+	if _, ok := asset.encoded["gzip"]; !ok && len(content) >= staticMinCompressSize && compressible(asset.contentType) {
+		if compressed := gzipBytes(content); len(compressed) < len(content) {
+			asset.encoded["gzip"] = compressed
+		}
+	}
+
+	h.mu.Lock()
+	h.assets[name] = asset
+	h.mu.Unlock()
+
+	return asset, nil
+}
+
+func compressible(contentType string) bool {
+	return strings.HasPrefix(contentType, "text/") ||
+		strings.Contains(contentType, "javascript") ||
+		strings.Contains(contentType, "json") ||
+		strings.Contains(contentType, "xml") ||
+		strings.Contains(contentType, "wasm")
+}
+
+func gzipBytes(content []byte) []byte {
+	buf := &bytes.Buffer{}
+	gz, _ := gzip.NewWriterLevel(buf, gzip.BestCompression)
+	gz.Write(content)
+	gz.Close()
+
+	return buf.Bytes()
+}
+
+// acceptsEncoding the encoding is in the Accept-Encoding and it was not refused with q=0
+func acceptsEncoding(r *http.Request, encoding string) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(name, encoding) && name != "*" {
+			continue
+		}
+
+		q := strings.ReplaceAll(params, " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+
+	return false
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type StaticTestSuite struct {
+	suite.Suite
+
+	fsys    fstest.MapFS
+	handler http.Handler
+}
+
+func TestStaticTestSuite(t *testing.T) {
+	suite.Run(t, new(StaticTestSuite))
+}
+
+func (s *StaticTestSuite) SetupTest() {
+	s.fsys = fstest.MapFS{
+		"dist/index.html":        {Data: []byte("<html>dashboard</html>")},
+		"dist/assets/app.js":     {Data: []byte(strings.Repeat("console.log('gokit');", 100))},
+		"dist/assets/app.css":    {Data: []byte("body{}")},
+		"dist/assets/app.css.br": {Data: []byte("brotli")},
+		"dist/docs/index.html":   {Data: []byte("<html>docs</html>")},
+	}
+
+	handler, err := Static(s.fsys, &StaticOpts{Root: "dist", Prefix: "/dashboard", SPA: true, Immutable: true})
+	s.Require().NoError(err)
+
+	s.handler = handler
+}
+
+func (s *StaticTestSuite) request(path string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *StaticTestSuite) TestServeAsset() {
+	rec := s.request("/dashboard/assets/app.css", nil)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("body{}", rec.Body.String())
+	s.Contains(rec.Header().Get("Content-Type"), "text/css")
+	s.Equal("public, max-age=3600, immutable", rec.Header().Get("Cache-Control"))
+	s.NotEmpty(rec.Header().Get("ETag"))
+}
+
+func (s *StaticTestSuite) TestETag() {
+	etag := s.request("/dashboard/assets/app.css", nil).Header().Get("ETag")
+
+	rec := s.request("/dashboard/assets/app.css", map[string]string{"If-None-Match": etag})
+
+	s.Equal(http.StatusNotModified, rec.Code)
+	s.Empty(rec.Body.String())
+}
+
+func (s *StaticTestSuite) TestCompression() {
+	rec := s.request("/dashboard/assets/app.js", map[string]string{"Accept-Encoding": "gzip, deflate"})
+
+	s.Equal("gzip", rec.Header().Get("Content-Encoding"))
+	s.Contains(rec.Header().Get("ETag"), "-gzip")
+
+	gz, err := gzip.NewReader(rec.Body)
+	s.Require().NoError(err)
+	content, _ := io.ReadAll(gz)
+	s.Equal(string(s.fsys["dist/assets/app.js"].Data), string(content))
+
+	rec = s.request("/dashboard/assets/app.css", map[string]string{"Accept-Encoding": "gzip, br"})
+	s.Equal("br", rec.Header().Get("Content-Encoding"))
+	s.Equal("brotli", rec.Body.String())
+
+	rec = s.request("/dashboard/assets/app.js", map[string]string{"Accept-Encoding": "gzip;q=0"})
+	s.Empty(rec.Header().Get("Content-Encoding"))
+}
+
+func (s *StaticTestSuite) TestSPAFallback() {
+	rec := s.request("/dashboard/orders/10", nil)
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("<html>dashboard</html>", rec.Body.String())
+	s.Equal("no-cache", rec.Header().Get("Cache-Control"))
+
+	rec = s.request("/dashboard/assets/unknown.js", nil)
+	s.Equal(http.StatusNotFound, rec.Code)
+
+	rec = s.request("/dashboard/docs/", nil)
+	s.Equal("<html>docs</html>", rec.Body.String())
+
+	rec = s.request("/dashboard/../../etc/passwd", nil)
+	s.Equal("<html>dashboard</html>", rec.Body.String())
+}
+
+func (s *StaticTestSuite) TestMethodNotAllowed() {
+	rec := httptest.NewRecorder()
+	s.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/dashboard/", nil))
+
+	s.Equal(http.StatusMethodNotAllowed, rec.Code)
+}
+
+func (s *StaticTestSuite) TestSPAWithoutIndex() {
+	_, err := Static(fstest.MapFS{"app.js": {}}, &StaticOpts{SPA: true})
+
+	s.ErrorIs(err, ErrorStaticIndex)
+}
+
+func (s *StaticTestSuite) TestServeStatic() {
+	built, err := New(&env.Configs{}, logging.NewMockLogger(), make(chan os.Signal, 1)).Build()
+	s.Require().NoError(err)
+	server := built.(*HTTPServer)
+	s.Require().NoError(server.ServeStatic("/dashboard", s.fsys, &StaticOpts{Root: "dist"}))
+
+	rec := httptest.NewRecorder()
+	server.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/dashboard/assets/app.css", nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("public, max-age=3600", rec.Header().Get("Cache-Control"))
+}
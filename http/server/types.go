@@ -1,40 +1,141 @@
 package server
 
 import (
+	"context"
+	"io/fs"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/health"
 	"github.com/ralvescosta/gokit/logging"
 )
 
 type (
+	// Middleware standard net/http middleware signature
+	Middleware = func(http.Handler) http.Handler
+
+	// ShutdownHook executed in the graceful shutdown before the server stops, e.g: closing websocket connections
+	ShutdownHook = func(ctx context.Context) error
+
+	// CORSOpts cross-origin configuration, the origins could be exact, "*", wildcard subdomains, e.g: https://*.example.com,
+	// or regex starting with ^, e.g: ^https://pr-[0-9]+\.example\.com$
+	CORSOpts struct {
+		AllowedOrigins   []string
+		AllowedMethods   []string
+		AllowedHeaders   []string
+		ExposedHeaders   []string
+		AllowCredentials bool
+		MaxAge           time.Duration
+		// Strict the server Run fails in the production when "*" is allowed with credentials
+		Strict bool
+	}
+
 	HTTPServerBuilder interface {
 		WithTLS() HTTPServerBuilder
 		Timeouts(read, write, idle time.Duration) HTTPServerBuilder
 		WithProfiling() HTTPServerBuilder
 		WithTracing() HTTPServerBuilder
-		Build() IHTTPServer
+		// WithCORS enable the CORS middleware, when opts is nil the env configuration is used
+		WithCORS(opts *CORSOpts) HTTPServerBuilder
+		// WithMiddleware append custom middlewares after the default chain
+		WithMiddleware(middlewares ...Middleware) HTTPServerBuilder
+		// WithHealth expose /healthz and /readyz using the health checker
+		WithHealth(checker health.IHealthChecker) HTTPServerBuilder
+		// WithMetrics expose the metrics handler in /metrics
+		WithMetrics(handler http.Handler) HTTPServerBuilder
+		// WithCSRF enable the CSRF middleware and the SPA token endpoint, see CSRFOpts
+		WithCSRF(opts *CSRFOpts) HTTPServerBuilder
+		// WithOpenAPI serve the OpenAPI document of the typed routes and the Swagger UI, when info is nil the defaults are used
+		WithOpenAPI(info *OpenAPIInfo) HTTPServerBuilder
+		// WithListener serve the router in more addresses, e.g: a unix socket for a sidecar, the HTTP_ADDR listener is always created
+		WithListener(listeners ...Listener) HTTPServerBuilder
+		// WithAutocert request the tls certificates to the Let's Encrypt, when opts is nil the env configuration is used
+		WithAutocert(opts *AutocertOpts) HTTPServerBuilder
+		// WithH2C accept the HTTP/2 without TLS, e.g: grpc-gateway or gRPC-web colocated behind a proxy terminating the TLS
+		WithH2C() HTTPServerBuilder
+		// WithDraining the readiness fails during the delay before the server stops accepting connections, so the load balancers
+		// stop sending traffic, and the in-flight requests are waited until the timeout, default HTTP_DRAIN_DELAY and HTTP_SHUTDOWN_TIMEOUT
+		WithDraining(delay, timeout time.Duration) HTTPServerBuilder
+		// WithRequestLimits the handlers timeout and the max request body size of all the routes, zero disables them,
+		// default HTTP_REQUEST_TIMEOUT and HTTP_MAX_BODY_SIZE, see Timeout and MaxBodySize to the route limits
+		WithRequestLimits(timeout time.Duration, maxBodySize int64) HTTPServerBuilder
+		// WithContentTypes respond 415 to the request bodies of other content types on all the routes, by default all the
+		// content types are accepted, see AllowContentType to the route content types
+		WithContentTypes(contentTypes ...string) HTTPServerBuilder
+		// WithCompression compress the responses with br or gzip, when opts is nil the defaults are used, see CompressionOpts
+		WithCompression(opts *CompressionOpts) HTTPServerBuilder
+		// WithConditionalRequests generate the ETag of the GET responses and write 304 to the matching conditional requests, see ConditionalGet
+		WithConditionalRequests() HTTPServerBuilder
+		// WithRequestLogging configure the request logging, e.g: the bodies capture and the headers redaction, see RequestLoggingOpts
+		WithRequestLogging(opts *RequestLoggingOpts) HTTPServerBuilder
+		// WithAdmin start a diagnostics server with pprof, expvar, build info, redacted config dump and goroutine dump, when opts is nil the env configuration is used
+		WithAdmin(opts *AdminOpts) HTTPServerBuilder
+		// OnShutdown register hooks executed in the graceful shutdown, hijacked connections are not closed by the server
+		OnShutdown(hooks ...ShutdownHook) HTTPServerBuilder
+		// Build fails with ErrorMetricsHandler when HTTP_METRICS_ENABLED is set without WithMetrics
+		Build() (IHTTPServer, error)
 	}
 
 	IHTTPServer interface {
 		RegisterRoute(method string, path string, handler http.HandlerFunc) error
+		// RegisterTypedRoutes register the routes created with Route, they are documented in the OpenAPI document
+		RegisterTypedRoutes(routes ...*TypedRoute) error
+		// Mount attach a handler under the pattern sharing the server middlewares, e.g: a grpc-gateway mux
+		Mount(pattern string, handler http.Handler)
+		// ServeStatic mount the static assets, e.g: an embed.FS with a dashboard, see StaticOpts
+		ServeStatic(prefix string, fsys fs.FS, opts *StaticOpts) error
 		Run() error
+		// Shutdown drain the server: the readiness fails, after the drain delay the server stops accepting connections and
+		// waits the in-flight requests until the ctx or the shutdown timeout is done, the remaining connections are closed
+		Shutdown(ctx context.Context) error
 	}
 
 	HTTPServer struct {
-		cfg           *env.Configs
-		logger        logging.ILogger
-		router        *chi.Mux
-		server        *http.Server
-		readTimeout   time.Duration
-		writeTimeout  time.Duration
-		idleTimeout   time.Duration
-		withTLS       bool
-		withProfiling bool
-		withTracing   bool
-		sig           chan os.Signal
+		cfg            *env.Configs
+		logger         logging.ILogger
+		router         *chi.Mux
+		server         *http.Server
+		readTimeout    time.Duration
+		writeTimeout   time.Duration
+		idleTimeout    time.Duration
+		withTLS        bool
+		withProfiling  bool
+		withTracing    bool
+		withH2C        bool
+		listeners      []Listener
+		autocert       *AutocertOpts
+		cors           *CORSOpts
+		csrf           *CSRFOpts
+		requestLogging *RequestLoggingOpts
+		compression    *CompressionOpts
+		requestTimeout time.Duration
+		maxBodySize    int64
+		contentTypes   []string
+		openapi        *openAPI
+		middlewares    []Middleware
+		healthChecker  health.IHealthChecker
+		metricsHandler http.Handler
+		shutdownHooks  []ShutdownHook
+		sig            chan os.Signal
+		admin          *AdminOpts
+		adminRouter    *chi.Mux
+		adminServer    *http.Server
+
+		conditionalRequests bool
+
+		// draining, see Shutdown
+		mu              sync.Mutex
+		shutdownTimeout time.Duration
+		drainDelay      time.Duration
+		draining        int32
+		connections     int64
+		inflight        int64
+		shutdownOnce    sync.Once
+		shutdownErr     error
+		drained         chan struct{}
 	}
 )
@@ -0,0 +1,30 @@
+package websocket
+
+import (
+	"encoding/json"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+// TopicFunc choose the hub topic to a broker message
+type TopicFunc = func(msg any, metadata *rabbitmq.DeliveryMetadata) string
+
+// Bridge forward the broker messages as JSON to the topic subscribers, the handler can be registered in
+// the rabbitmq dispatchers or in the eventbus
+func Bridge(hub IHub, topic string) rabbitmq.ConsumerHandler {
+	return BridgeFunc(hub, func(any, *rabbitmq.DeliveryMetadata) string { return topic })
+}
+
+// BridgeFunc same as Bridge choosing the topic by message, e.g: using the metadata type
+func BridgeFunc(hub IHub, topicFunc TopicFunc) rabbitmq.ConsumerHandler {
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		byt, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+
+		hub.Broadcast(topicFunc(msg, metadata), byt)
+
+		return nil
+	}
+}
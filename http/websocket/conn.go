@@ -0,0 +1,168 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/ralvescosta/gokit/guid"
+)
+
+func newConn(hub *Hub, ws *gorilla.Conn, r *http.Request) *Conn {
+	ctx, cancel := context.WithCancel(r.Context())
+
+	return &Conn{
+		ID:      guid.NewRequestID(),
+		Request: r,
+		ws:      ws,
+		hub:     hub,
+		ctx:     ctx,
+		cancel:  cancel,
+		send:    make(chan []byte, hub.opts.SendBuffer),
+		closeCh: make(chan gorilla.CloseError, 1),
+		topics:  map[string]struct{}{},
+	}
+}
+
+// Context is canceled when the connection is closed
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// Send enqueue a text message, when the send buffer is full the connection is closed
+func (c *Conn) Send(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return ErrorConnClosed
+	}
+
+	select {
+	case c.send <- msg:
+		return nil
+	default:
+		c.closeLocked(gorilla.CloseTryAgainLater, "slow consumer")
+		return ErrorSlowConsumer
+	}
+}
+
+func (c *Conn) SendJSON(v any) error {
+	byt, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return c.Send(byt)
+}
+
+// Close send a normal closure frame after the pending messages
+func (c *Conn) Close() {
+	c.CloseWith(gorilla.CloseNormalClosure, "")
+}
+
+// CloseWith send a close frame with the code after the pending messages
+func (c *Conn) CloseWith(code int, text string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closeLocked(code, text)
+}
+
+func (c *Conn) closeLocked(code int, text string) {
+	if c.closed {
+		return
+	}
+
+	c.closed = true
+	c.closeCh <- gorilla.CloseError{Code: code, Text: text}
+}
+
+func (c *Conn) readPump(onMessage MessageHandler) {
+	defer c.terminate()
+
+	c.ws.SetReadLimit(c.hub.opts.ReadLimit)
+	c.extendReadDeadline()
+	c.ws.SetPongHandler(func(string) error {
+		c.extendReadDeadline()
+		return nil
+	})
+
+	for {
+		_, msg, err := c.ws.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		c.extendReadDeadline()
+
+		if onMessage != nil {
+			onMessage(c.ctx, c, msg)
+		}
+	}
+}
+
+func (c *Conn) writePump() {
+	ticker := time.NewTicker(c.hub.opts.PingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg := <-c.send:
+			if err := c.write(msg); err != nil {
+				c.ws.Close()
+				return
+			}
+
+		case <-ticker.C:
+			if err := c.ws.WriteControl(gorilla.PingMessage, nil, time.Now().Add(c.hub.opts.WriteTimeout)); err != nil {
+				c.ws.Close()
+				return
+			}
+
+		case closeErr := <-c.closeCh:
+			c.flush()
+			c.ws.WriteControl(gorilla.CloseMessage, gorilla.FormatCloseMessage(closeErr.Code, closeErr.Text), time.Now().Add(c.hub.opts.WriteTimeout))
+			// the read pump finishes when the peer answer the close frame or after the grace period
+			c.ws.SetReadDeadline(time.Now().Add(closeGracePeriod))
+			return
+
+		case <-c.ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Conn) write(msg []byte) error {
+	c.ws.SetWriteDeadline(time.Now().Add(c.hub.opts.WriteTimeout))
+	return c.ws.WriteMessage(gorilla.TextMessage, msg)
+}
+
+// flush write the buffered messages before closing
+func (c *Conn) flush() {
+	for {
+		select {
+		case msg := <-c.send:
+			if err := c.write(msg); err != nil {
+				return
+			}
+		default:
+			return
+		}
+	}
+}
+
+func (c *Conn) extendReadDeadline() {
+	c.ws.SetReadDeadline(time.Now().Add(c.hub.opts.PongTimeout))
+}
+
+func (c *Conn) terminate() {
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	c.cancel()
+	c.ws.Close()
+}
@@ -0,0 +1,26 @@
+package websocket
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultReadLimit    = 64 * 1024
+	DefaultPingInterval = 30 * time.Second
+	// DefaultPongTimeout must be greater than the ping interval
+	DefaultPongTimeout  = 60 * time.Second
+	DefaultWriteTimeout = 10 * time.Second
+	DefaultSendBuffer   = 64
+	// closeGracePeriod how long the peer has to answer the close frame
+	closeGracePeriod = 5 * time.Second
+)
+
+var (
+	ErrorConnClosed   = errors.New("websocket connection closed")
+	ErrorSlowConsumer = errors.New("websocket send buffer is full, connection closed")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::websocket] " + msg
+}
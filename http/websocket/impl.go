@@ -0,0 +1,221 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/ralvescosta/gokit/logging"
+	"go.uber.org/zap"
+)
+
+// NewHub create the hub that keeps the connections and the topic subscriptions, opts could be nil
+func NewHub(logger logging.ILogger, opts *Opts) IHub {
+	if opts == nil {
+		opts = &Opts{}
+	}
+
+	if opts.ReadLimit == 0 {
+		opts.ReadLimit = DefaultReadLimit
+	}
+
+	if opts.PingInterval == 0 {
+		opts.PingInterval = DefaultPingInterval
+	}
+
+	if opts.PongTimeout == 0 {
+		opts.PongTimeout = DefaultPongTimeout
+	}
+
+	if opts.WriteTimeout == 0 {
+		opts.WriteTimeout = DefaultWriteTimeout
+	}
+
+	if opts.SendBuffer == 0 {
+		opts.SendBuffer = DefaultSendBuffer
+	}
+
+	return &Hub{
+		logger:   logger,
+		opts:     opts,
+		upgrader: gorilla.Upgrader{CheckOrigin: opts.CheckOrigin},
+		conns:    map[*Conn]struct{}{},
+		topics:   map[string]map[*Conn]struct{}{},
+	}
+}
+
+func (h *Hub) Handler(onConnect ConnectHandler, onMessage MessageHandler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		h.mu.RLock()
+		closing := h.closing
+		h.mu.RUnlock()
+
+		if closing {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+
+		// the upgrader writes the http error response
+		ws, err := h.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			h.logger.Warn(LogMessage("upgrade failure"), logging.ErrorField(err))
+			return
+		}
+
+		conn := newConn(h, ws, r)
+		if !h.add(conn) {
+			ws.Close()
+			return
+		}
+		defer h.remove(conn)
+
+		h.logger.Debug(LogMessage("connection opened"), logging.MessageField("connId", conn.ID))
+
+		go conn.writePump()
+
+		if onConnect != nil {
+			if err := onConnect(conn.ctx, conn); err != nil {
+				h.logger.Warn(LogMessage("connection rejected"), logging.MessageField("connId", conn.ID), logging.ErrorField(err))
+				conn.CloseWith(gorilla.ClosePolicyViolation, err.Error())
+				onMessage = nil
+			}
+		}
+
+		conn.readPump(onMessage)
+
+		h.logger.Debug(LogMessage("connection closed"), logging.MessageField("connId", conn.ID))
+	}
+}
+
+func (h *Hub) Subscribe(conn *Conn, topics ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.conns[conn]; !ok {
+		return
+	}
+
+	for _, topic := range topics {
+		if h.topics[topic] == nil {
+			h.topics[topic] = map[*Conn]struct{}{}
+		}
+
+		h.topics[topic][conn] = struct{}{}
+		conn.topics[topic] = struct{}{}
+	}
+}
+
+func (h *Hub) Unsubscribe(conn *Conn, topics ...string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, topic := range topics {
+		h.unsubscribe(conn, topic)
+	}
+}
+
+func (h *Hub) Broadcast(topic string, msg []byte) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	sent := 0
+	for conn := range h.topics[topic] {
+		if conn.Send(msg) == nil {
+			sent++
+		}
+	}
+
+	return sent
+}
+
+func (h *Hub) BroadcastAll(msg []byte) int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	sent := 0
+	for conn := range h.conns {
+		if conn.Send(msg) == nil {
+			sent++
+		}
+	}
+
+	return sent
+}
+
+func (h *Hub) Count() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.conns)
+}
+
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	h.closing = true
+	conns := make([]*Conn, 0, len(h.conns))
+	for conn := range h.conns {
+		conns = append(conns, conn)
+	}
+	h.mu.Unlock()
+
+	h.logger.Info(LogMessage("closing the connections..."), zap.Int("connections", len(conns)))
+
+	for _, conn := range conns {
+		conn.CloseWith(gorilla.CloseGoingAway, "server shutdown")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		h.handlers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		for _, conn := range conns {
+			conn.ws.Close()
+		}
+		return ctx.Err()
+	}
+}
+
+// add register the connection, the WaitGroup is incremented under the lock so Shutdown never waits a stale counter
+func (h *Hub) add(conn *Conn) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.closing {
+		return false
+	}
+
+	h.conns[conn] = struct{}{}
+	h.handlers.Add(1)
+
+	return true
+}
+
+func (h *Hub) remove(conn *Conn) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for topic := range conn.topics {
+		h.unsubscribe(conn, topic)
+	}
+
+	delete(h.conns, conn)
+	h.handlers.Done()
+}
+
+// unsubscribe must be called holding the lock
+func (h *Hub) unsubscribe(conn *Conn, topic string) {
+	delete(conn.topics, topic)
+
+	subscribers := h.topics[topic]
+	delete(subscribers, conn)
+
+	if len(subscribers) == 0 {
+		delete(h.topics, topic)
+	}
+}
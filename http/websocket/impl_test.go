@@ -0,0 +1,152 @@
+package websocket
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/suite"
+)
+
+type WebSocketTestSuite struct {
+	suite.Suite
+
+	hub    IHub
+	server *httptest.Server
+}
+
+func TestWebSocketTestSuite(t *testing.T) {
+	suite.Run(t, new(WebSocketTestSuite))
+}
+
+func (s *WebSocketTestSuite) SetupTest() {
+	s.hub = NewHub(logging.NewMockLogger(), &Opts{PingInterval: 50 * time.Millisecond, PongTimeout: time.Second})
+}
+
+func (s *WebSocketTestSuite) TearDownTest() {
+	if s.server != nil {
+		s.server.Close()
+	}
+}
+
+func (s *WebSocketTestSuite) serve(onConnect ConnectHandler, onMessage MessageHandler) {
+	s.server = httptest.NewServer(s.hub.Handler(onConnect, onMessage))
+}
+
+func (s *WebSocketTestSuite) dial() *gorilla.Conn {
+	ws, _, err := gorilla.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.server.URL, "http"), nil)
+	s.Require().NoError(err)
+	ws.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	return ws
+}
+
+func (s *WebSocketTestSuite) waitConnections(n int) {
+	s.Eventually(func() bool { return s.hub.Count() == n }, time.Second, 5*time.Millisecond)
+}
+
+func (s *WebSocketTestSuite) TestEcho() {
+	s.serve(nil, func(ctx context.Context, conn *Conn, msg []byte) {
+		conn.Send(append([]byte("echo: "), msg...))
+	})
+
+	ws := s.dial()
+	defer ws.Close()
+
+	s.NoError(ws.WriteMessage(gorilla.TextMessage, []byte("hello")))
+
+	_, msg, err := ws.ReadMessage()
+	s.NoError(err)
+	s.Equal("echo: hello", string(msg))
+}
+
+func (s *WebSocketTestSuite) TestBroadcastByTopic() {
+	s.serve(func(ctx context.Context, conn *Conn) error {
+		s.hub.Subscribe(conn, conn.Request.URL.Query().Get("topic"))
+		return nil
+	}, nil)
+
+	orders, _, err := gorilla.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.server.URL, "http")+"?topic=orders", nil)
+	s.Require().NoError(err)
+	defer orders.Close()
+
+	users := s.dial()
+	defer users.Close()
+
+	s.waitConnections(2)
+	s.Eventually(func() bool { return s.hub.Broadcast("orders", []byte("ping")) == 1 }, time.Second, 5*time.Millisecond)
+
+	_, msg, err := orders.ReadMessage()
+	s.NoError(err)
+	s.Equal("ping", string(msg))
+
+	err = Bridge(s.hub, "orders")(map[string]string{"id": "1"}, &rabbitmq.DeliveryMetadata{})
+	s.NoError(err)
+
+	_, msg, err = orders.ReadMessage()
+	s.NoError(err)
+	s.JSONEq(`{"id":"1"}`, string(msg))
+
+	s.Equal(2, s.hub.BroadcastAll([]byte("all")))
+	_, msg, err = users.ReadMessage()
+	s.NoError(err)
+	s.Equal("all", string(msg))
+}
+
+func (s *WebSocketTestSuite) TestConnectRejected() {
+	s.serve(func(ctx context.Context, conn *Conn) error {
+		return errors.New("unauthorized")
+	}, nil)
+
+	ws := s.dial()
+	defer ws.Close()
+
+	_, _, err := ws.ReadMessage()
+	s.True(gorilla.IsCloseError(err, gorilla.ClosePolicyViolation))
+	s.waitConnections(0)
+}
+
+func (s *WebSocketTestSuite) TestShutdown() {
+	ctxDone := make(chan struct{})
+	s.serve(func(ctx context.Context, conn *Conn) error {
+		go func() {
+			<-ctx.Done()
+			close(ctxDone)
+		}()
+		return conn.Send([]byte("welcome"))
+	}, nil)
+
+	ws := s.dial()
+	defer ws.Close()
+	s.waitConnections(1)
+
+	go func() {
+		// the client answer the close frame while reading
+		for {
+			if _, _, err := ws.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	s.NoError(s.hub.Shutdown(ctx))
+	s.Equal(0, s.hub.Count())
+
+	select {
+	case <-ctxDone:
+	case <-time.After(time.Second):
+		s.Fail("connection context was not canceled")
+	}
+
+	_, _, err := gorilla.DefaultDialer.Dial("ws"+strings.TrimPrefix(s.server.URL, "http"), nil)
+	s.ErrorIs(err, gorilla.ErrBadHandshake)
+}
@@ -0,0 +1,51 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockHub struct {
+	mock.Mock
+}
+
+func (m *MockHub) Handler(onConnect ConnectHandler, onMessage MessageHandler) http.HandlerFunc {
+	args := m.Called(onConnect, onMessage)
+
+	handler, _ := args.Get(0).(http.HandlerFunc)
+	return handler
+}
+
+func (m *MockHub) Subscribe(conn *Conn, topics ...string) {
+	m.Called(conn, topics)
+}
+
+func (m *MockHub) Unsubscribe(conn *Conn, topics ...string) {
+	m.Called(conn, topics)
+}
+
+func (m *MockHub) Broadcast(topic string, msg []byte) int {
+	args := m.Called(topic, msg)
+	return args.Int(0)
+}
+
+func (m *MockHub) BroadcastAll(msg []byte) int {
+	args := m.Called(msg)
+	return args.Int(0)
+}
+
+func (m *MockHub) Count() int {
+	args := m.Called()
+	return args.Int(0)
+}
+
+func (m *MockHub) Shutdown(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func NewMockHub() *MockHub {
+	return new(MockHub)
+}
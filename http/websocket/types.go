@@ -0,0 +1,75 @@
+package websocket
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	gorilla "github.com/gorilla/websocket"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	Opts struct {
+		// ReadLimit the max size of the received messages in bytes
+		ReadLimit int64
+		// PingInterval how often a ping is sent to keep the connection alive
+		PingInterval time.Duration
+		// PongTimeout the connection is closed when nothing is received in this time
+		PongTimeout  time.Duration
+		WriteTimeout time.Duration
+		// SendBuffer how many outgoing messages are buffered per connection, slow clients are disconnected
+		SendBuffer int
+		// CheckOrigin by default only the same origin is allowed
+		CheckOrigin func(r *http.Request) bool
+	}
+
+	// ConnectHandler called after the upgrade, returning an error closes the connection
+	ConnectHandler = func(ctx context.Context, conn *Conn) error
+
+	// MessageHandler called to each message received from the client
+	MessageHandler = func(ctx context.Context, conn *Conn, msg []byte)
+
+	IHub interface {
+		// Handler upgrade the requests to websocket, blocking until the connection is closed
+		Handler(onConnect ConnectHandler, onMessage MessageHandler) http.HandlerFunc
+		Subscribe(conn *Conn, topics ...string)
+		Unsubscribe(conn *Conn, topics ...string)
+		// Broadcast send the message to the topic subscribers, returns how many connections received it
+		Broadcast(topic string, msg []byte) int
+		BroadcastAll(msg []byte) int
+		Count() int
+		// Shutdown send a going away close frame to all the connections and waits them to finish until ctx is done
+		Shutdown(ctx context.Context) error
+	}
+
+	Hub struct {
+		logger   logging.ILogger
+		opts     *Opts
+		upgrader gorilla.Upgrader
+
+		mu       sync.RWMutex
+		conns    map[*Conn]struct{}
+		topics   map[string]map[*Conn]struct{}
+		closing  bool
+		handlers sync.WaitGroup
+	}
+
+	// Conn a client connection, messages are written by a single goroutine
+	Conn struct {
+		ID      string
+		Request *http.Request
+
+		ws     *gorilla.Conn
+		hub    *Hub
+		ctx    context.Context
+		cancel context.CancelFunc
+		send   chan []byte
+
+		mu      sync.Mutex
+		closed  bool
+		closeCh chan gorilla.CloseError
+		topics  map[string]struct{}
+	}
+)
@@ -0,0 +1,54 @@
+package idempotency
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	ReplayedHeader       = "Idempotent-Replayed"
+
+	DefaultTTL          = 24 * time.Hour
+	DefaultLockTTL      = time.Minute
+	DefaultMaxBodySize  = 1 << 20
+	DefaultRedisPrefix  = "gokit:idempotency:"
+	DefaultTableName    = "idempotency_keys"
+	inProgressRetryHint = "1"
+	setCookieHeader     = "Set-Cookie"
+	storeTimeout        = 5 * time.Second
+
+	DefaultProcessedMessagesTable = "processed_messages"
+
+	PostgresSchema = `CREATE TABLE IF NOT EXISTS %s (
+	key          TEXT PRIMARY KEY,
+	request_hash TEXT NOT NULL,
+	status_code  INTEGER,
+	header       TEXT,
+	body         BYTEA,
+	body_hash    TEXT,
+	expires_at   TIMESTAMPTZ NOT NULL,
+	created_at   TIMESTAMPTZ NOT NULL
+)`
+
+	ProcessedMessagesSchema = `CREATE TABLE IF NOT EXISTS %s (
+	consumer     TEXT NOT NULL,
+	message_id   TEXT NOT NULL,
+	processed_at TIMESTAMPTZ NOT NULL,
+	PRIMARY KEY (consumer, message_id)
+)`
+)
+
+var (
+	ErrorKeyRequired  = errors.New("the Idempotency-Key header is required")
+	ErrorKeyReused    = errors.New("the Idempotency-Key was used with a different request")
+	ErrorInProgress   = errors.New("a request with the same Idempotency-Key is in progress")
+	ErrorBodyTooLarge = errors.New("request body too large")
+	ErrorBodyHash     = errors.New("stored response body does not match the body hash")
+
+	ErrorMessageIDRequired = errors.New("the message id is required to deduplicate the message")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::idempotency] " + msg
+}
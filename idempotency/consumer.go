@@ -0,0 +1,90 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	gokitSql "github.com/ralvescosta/gokit/sql"
+)
+
+// TransactionalHandler wraps the handler in a transaction that records the message id before the handler runs.
+// The handler writes using the transaction in metadata.Ctx (e.g. gokit sql.Repository), so both are committed together.
+// A duplicated message finds the id already recorded and it is acked without calling the handler,
+// giving effective exactly-once processing to the DB-writing consumers. The table could be created with MigrateProcessedMessages
+func TransactionalHandler(logger logging.ILogger, db *sql.DB, opts *DedupOpts, handler rabbitmq.ConsumerHandler) rabbitmq.ConsumerHandler {
+	opts = opts.withDefaults()
+	query := fmt.Sprintf(`INSERT INTO %s (consumer, message_id, processed_at) VALUES ($1, $2, $3)
+		ON CONFLICT (consumer, message_id) DO NOTHING`, opts.Table)
+
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		if metadata.MessageId == "" {
+			logger.Error(LogMessage("the message id is required to the transactional handler"))
+			return ErrorMessageIDRequired
+		}
+
+		ctx := metadata.Ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		return gokitSql.WithTx(ctx, db, func(ctx context.Context) error {
+			tx, _ := gokitSql.TxFromCtx(ctx)
+
+			// a concurrent duplicate waits on the primary key until this transaction finishes
+			result, err := tx.ExecContext(ctx, query, opts.Consumer, metadata.MessageId, time.Now())
+			if err != nil {
+				logger.Error(LogMessage("failure to record the message id"), logging.ErrorField(err))
+				return err
+			}
+
+			if affected, _ := result.RowsAffected(); affected == 0 {
+				logger.Warn(LogMessage(fmt.Sprintf("skipping the duplicated message: %s", metadata.MessageId)))
+				return nil
+			}
+
+			metadata.Ctx = ctx
+			return handler(msg, metadata)
+		})
+	}
+}
+
+// MigrateProcessedMessages create the processed messages table if it does not exist
+func MigrateProcessedMessages(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultProcessedMessagesTable
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(ProcessedMessagesSchema, table))
+	return err
+}
+
+// PurgeProcessedMessages remove the message ids recorded before the olderThan, the broker must not redeliver them anymore
+func PurgeProcessedMessages(ctx context.Context, db *sql.DB, table string, olderThan time.Duration) (int64, error) {
+	if table == "" {
+		table = DefaultProcessedMessagesTable
+	}
+
+	result, err := db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE processed_at < $1", table), time.Now().Add(-olderThan))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (o *DedupOpts) withDefaults() *DedupOpts {
+	opts := DedupOpts{}
+	if o != nil {
+		opts = *o
+	}
+
+	if opts.Table == "" {
+		opts.Table = DefaultProcessedMessagesTable
+	}
+
+	return &opts
+}
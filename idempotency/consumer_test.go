@@ -0,0 +1,100 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	gokitSql "github.com/ralvescosta/gokit/sql"
+	"github.com/stretchr/testify/suite"
+)
+
+type TransactionalHandlerTestSuite struct {
+	suite.Suite
+
+	logger *logging.MockLogger
+}
+
+func TestTransactionalHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(TransactionalHandlerTestSuite))
+}
+
+func (s *TransactionalHandlerTestSuite) SetupTest() {
+	s.logger = logging.NewMockLogger()
+}
+
+func (s *TransactionalHandlerTestSuite) TestProcessInTheSameTransaction() {
+	db, sqlMock, _ := sqlmock.New()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO processed_messages (consumer, message_id, processed_at)")).
+		WithArgs("billing", "id", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO invoices")).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	handler := TransactionalHandler(s.logger, db, &DedupOpts{Consumer: "billing"}, func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		_, ok := gokitSql.TxFromCtx(metadata.Ctx)
+		s.True(ok)
+
+		_, err := gokitSql.NewRepository(db).Querier(metadata.Ctx).ExecContext(metadata.Ctx, "INSERT INTO invoices")
+		return err
+	})
+
+	s.NoError(handler(nil, &rabbitmq.DeliveryMetadata{MessageId: "id"}))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *TransactionalHandlerTestSuite) TestSkipDuplicates() {
+	db, sqlMock, _ := sqlmock.New()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO processed_messages")).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectCommit()
+
+	handler := TransactionalHandler(s.logger, db, nil, func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		s.Fail("duplicated message should not be handled")
+		return nil
+	})
+
+	s.NoError(handler(nil, &rabbitmq.DeliveryMetadata{MessageId: "id", Ctx: context.Background()}))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *TransactionalHandlerTestSuite) TestRollbackOnHandlerFailure() {
+	db, sqlMock, _ := sqlmock.New()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO processed_messages")).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectRollback()
+
+	handler := TransactionalHandler(s.logger, db, nil, func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		return errors.New("some error")
+	})
+
+	s.Error(handler(nil, &rabbitmq.DeliveryMetadata{MessageId: "id"}))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *TransactionalHandlerTestSuite) TestMessageIDRequired() {
+	db, _, _ := sqlmock.New()
+
+	handler := TransactionalHandler(s.logger, db, nil, nil)
+
+	s.ErrorIs(handler(nil, &rabbitmq.DeliveryMetadata{}), ErrorMessageIDRequired)
+}
+
+func (s *TransactionalHandlerTestSuite) TestPurgeProcessedMessages() {
+	db, sqlMock, _ := sqlmock.New()
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM processed_messages WHERE processed_at < $1")).WillReturnResult(sqlmock.NewResult(0, 3))
+
+	purged, err := PurgeProcessedMessages(context.Background(), db, "", time.Hour)
+	s.NoError(err)
+	s.Equal(int64(3), purged)
+}
@@ -0,0 +1,24 @@
+module github.com/ralvescosta/gokit/idempotency
+
+go 1.18
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/alicebob/miniredis/v2 v2.22.0
+	github.com/ralvescosta/gokit/auth v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/messaging v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/sql v0.0.0-20261016183433-cb393702ac94
+	github.com/redis/go-redis/v9 v9.0.2
+	github.com/stretchr/testify v1.8.0
+)
+
+require (
+	github.com/ralvescosta/gokit/clock v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/crypto v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/errors v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/guid v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/pool v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/requestid v0.0.0-20261016183433-cb393702ac94 // indirect
+)
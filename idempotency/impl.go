@@ -0,0 +1,241 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/ralvescosta/gokit/auth"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// Middleware replay the first response to the requests with the same Idempotency-Key. It could be used in all the
+// routes or opted-in per route wrapping only the route handlers. The store failures are logged and the request is executed
+func Middleware(logger logging.ILogger, store Store, opts *Opts) func(next http.Handler) http.Handler {
+	opts = opts.withDefaults()
+
+	methods := map[string]bool{}
+	for _, m := range opts.Methods {
+		methods[strings.ToUpper(m)] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !methods[r.Method] {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				if opts.Required {
+					http.Error(w, ErrorKeyRequired.Error(), http.StatusBadRequest)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			requestHash, err := hashRequest(r, opts.MaxBodySize)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+				return
+			}
+
+			storeKey := opts.Scope(r) + ":" + r.Method + ":" + r.URL.Path + ":" + key
+
+			record, created, err := store.Reserve(r.Context(), storeKey, requestHash, opts.LockTTL)
+			if err != nil {
+				logger.Error(LogMessage("failure to reserve the key"), logging.ErrorField(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !created {
+				replay(logger, w, record, requestHash)
+				return
+			}
+
+			rec := &responseRecorder{ResponseWriter: w}
+			completed := false
+			defer func() {
+				// panics and server errors release the key, so the client can retry
+				if !completed {
+					ctx, cancel := detachedCtx()
+					defer cancel()
+
+					if err := store.Release(ctx, storeKey); err != nil {
+						logger.Error(LogMessage("failure to release the key"), logging.ErrorField(err))
+					}
+				}
+			}()
+
+			next.ServeHTTP(rec, r)
+
+			if rec.statusCode() >= http.StatusInternalServerError {
+				return
+			}
+
+			completed = true
+
+			ctx, cancel := detachedCtx()
+			defer cancel()
+
+			if err := store.Complete(ctx, storeKey, rec.response(), opts.TTL); err != nil {
+				logger.Error(LogMessage("failure to store the response"), logging.ErrorField(err))
+			}
+		})
+	}
+}
+
+func replay(logger logging.ILogger, w http.ResponseWriter, record *Record, requestHash string) {
+	if record.RequestHash != requestHash {
+		http.Error(w, ErrorKeyReused.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+
+	if record.Response == nil {
+		w.Header().Set("Retry-After", inProgressRetryHint)
+		http.Error(w, ErrorInProgress.Error(), http.StatusConflict)
+		return
+	}
+
+	res := record.Response
+	if hashBody(res.Body) != res.BodyHash {
+		logger.Error(LogMessage("replay"), logging.ErrorField(ErrorBodyHash))
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+
+	for k, v := range res.Header {
+		if http.CanonicalHeaderKey(k) == setCookieHeader {
+			continue
+		}
+		w.Header()[k] = v
+	}
+	w.Header().Set(ReplayedHeader, "true")
+	w.WriteHeader(res.StatusCode)
+	w.Write(res.Body)
+}
+
+func (o *Opts) withDefaults() *Opts {
+	opts := Opts{}
+	if o != nil {
+		opts = *o
+	}
+
+	if opts.TTL == 0 {
+		opts.TTL = DefaultTTL
+	}
+
+	if opts.LockTTL == 0 {
+		opts.LockTTL = DefaultLockTTL
+	}
+
+	if len(opts.Methods) == 0 {
+		opts.Methods = []string{http.MethodPost, http.MethodPatch}
+	}
+
+	if opts.MaxBodySize == 0 {
+		opts.MaxBodySize = DefaultMaxBodySize
+	}
+
+	if opts.Scope == nil {
+		opts.Scope = ScopeByClient
+	}
+
+	return &opts
+}
+
+// ScopeByClient the default scope, the subject of the token validated by the auth.HTTPMiddleware or the client ip
+func ScopeByClient(r *http.Request) string {
+	if claims, ok := auth.ClaimsFromContext(r.Context()); ok && claims.Subject != "" {
+		return "sub:" + claims.Subject
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+
+	return "ip:" + host
+}
+
+// detachedCtx the store calls after the handler do not use the request ctx, it is canceled when the client disconnects
+// and the key would be held until the LockTTL
+func detachedCtx() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), storeTimeout)
+}
+
+// hashRequest fingerprint the method, path and body, the body is restored to the handler
+func hashRequest(r *http.Request, maxBodySize int64) (string, error) {
+	body := []byte{}
+	if r.Body != nil {
+		byt, err := io.ReadAll(io.LimitReader(r.Body, maxBodySize+1))
+		if err != nil {
+			return "", err
+		}
+
+		if int64(len(byt)) > maxBodySize {
+			return "", ErrorBodyTooLarge
+		}
+
+		body = byt
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+
+	r.body = append(r.body, b...)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) statusCode() int {
+	if r.status == 0 {
+		return http.StatusOK
+	}
+
+	return r.status
+}
+
+// response the cookies are not stored, the session of the first client must not be replayed
+func (r *responseRecorder) response() *Response {
+	header := r.Header().Clone()
+	header.Del(setCookieHeader)
+
+	return &Response{
+		StatusCode: r.statusCode(),
+		Header:     header,
+		Body:       r.body,
+		BodyHash:   hashBody(r.body),
+	}
+}
@@ -0,0 +1,174 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ralvescosta/gokit/auth"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type IdempotencyTestSuite struct {
+	suite.Suite
+
+	calls int
+}
+
+func TestIdempotencyTestSuite(t *testing.T) {
+	suite.Run(t, new(IdempotencyTestSuite))
+}
+
+func (s *IdempotencyTestSuite) SetupTest() {
+	s.calls = 0
+}
+
+func (s *IdempotencyTestSuite) stores() []Store {
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(s.T()).Addr()})
+	return []Store{NewMemoryStore(), NewRedisStore(client, "")}
+}
+
+func (s *IdempotencyTestSuite) handler(store Store, status int, opts *Opts) http.Handler {
+	return Middleware(logging.NewMockLogger(), store, opts)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.calls++
+		w.Header().Set("Location", "/orders/1")
+		w.WriteHeader(status)
+		w.Write([]byte(`{"id":1}`))
+	}))
+}
+
+func (s *IdempotencyTestSuite) do(handler http.Handler, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *IdempotencyTestSuite) TestReplay() {
+	for _, store := range s.stores() {
+		s.SetupTest()
+		handler := s.handler(store, http.StatusCreated, nil)
+
+		first := s.do(handler, "key", `{"item":1}`)
+		s.Equal(http.StatusCreated, first.Code)
+		s.Empty(first.Header().Get(ReplayedHeader))
+
+		second := s.do(handler, "key", `{"item":1}`)
+		s.Equal(http.StatusCreated, second.Code)
+		s.Equal("true", second.Header().Get(ReplayedHeader))
+		s.Equal("/orders/1", second.Header().Get("Location"))
+		s.Equal(`{"id":1}`, second.Body.String())
+		s.Equal(1, s.calls)
+
+		reused := s.do(handler, "key", `{"item":2}`)
+		s.Equal(http.StatusUnprocessableEntity, reused.Code)
+	}
+}
+
+func (s *IdempotencyTestSuite) TestInProgress() {
+	for _, store := range s.stores() {
+		_, _, err := store.Reserve(context.Background(), "ip:192.0.2.1:POST:/orders:key", mustHash(`{}`), DefaultLockTTL)
+		s.NoError(err)
+
+		rec := s.do(s.handler(store, http.StatusCreated, nil), "key", `{}`)
+		s.Equal(http.StatusConflict, rec.Code)
+	}
+}
+
+func (s *IdempotencyTestSuite) TestServerErrorReleaseTheKey() {
+	for _, store := range s.stores() {
+		s.SetupTest()
+		handler := s.handler(store, http.StatusServiceUnavailable, nil)
+
+		s.do(handler, "key", `{}`)
+		s.do(handler, "key", `{}`)
+
+		s.Equal(2, s.calls)
+	}
+}
+
+func (s *IdempotencyTestSuite) TestWithoutKey() {
+	handler := s.handler(NewMemoryStore(), http.StatusCreated, nil)
+	s.do(handler, "", `{}`)
+	s.do(handler, "", `{}`)
+	s.Equal(2, s.calls)
+
+	rec := s.do(s.handler(NewMemoryStore(), http.StatusCreated, &Opts{Required: true}), "", `{}`)
+	s.Equal(http.StatusBadRequest, rec.Code)
+}
+
+func (s *IdempotencyTestSuite) TestScopeByClient() {
+	handler := s.handler(NewMemoryStore(), http.StatusCreated, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{}`))
+	req.Header.Set(IdempotencyKeyHeader, "key")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	// other client with the same key
+	req = httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{}`))
+	req.Header.Set(IdempotencyKeyHeader, "key")
+	req.RemoteAddr = "192.0.2.2:1234"
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	s.Empty(rec.Header().Get(ReplayedHeader))
+
+	claims := &auth.Claims{}
+	claims.Subject = "user"
+	req = httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{}`))
+	req.Header.Set(IdempotencyKeyHeader, "key")
+	req = req.WithContext(auth.ContextWithClaims(req.Context(), claims))
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	s.Equal(3, s.calls)
+	s.Equal("sub:user", ScopeByClient(req))
+}
+
+func (s *IdempotencyTestSuite) TestReplayWithoutCookies() {
+	store := NewMemoryStore()
+	handler := Middleware(logging.NewMockLogger(), store, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "first-client"})
+		w.WriteHeader(http.StatusCreated)
+	}))
+
+	first := s.do(handler, "key", `{}`)
+	s.NotEmpty(first.Header().Get("Set-Cookie"))
+
+	second := s.do(handler, "key", `{}`)
+	s.Equal("true", second.Header().Get(ReplayedHeader))
+	s.Empty(second.Header().Get("Set-Cookie"))
+}
+
+func (s *IdempotencyTestSuite) TestReleaseDetachedCtx() {
+	for _, store := range s.stores() {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		handler := Middleware(logging.NewMockLogger(), store, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// the client disconnected
+			cancel()
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{}`)).WithContext(ctx)
+		req.Header.Set(IdempotencyKeyHeader, "key")
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+
+		_, created, err := store.Reserve(context.Background(), "ip:192.0.2.1:POST:/orders:key", mustHash(`{}`), DefaultLockTTL)
+		s.NoError(err)
+		s.True(created)
+	}
+}
+
+func mustHash(body string) string {
+	hash, _ := hashRequest(httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body)), DefaultMaxBodySize)
+	return hash
+}
@@ -0,0 +1,33 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStore struct {
+	mock.Mock
+}
+
+func (m *MockStore) Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*Record, bool, error) {
+	args := m.Called(ctx, key, requestHash, ttl)
+
+	record, _ := args.Get(0).(*Record)
+	return record, args.Bool(1), args.Error(2)
+}
+
+func (m *MockStore) Complete(ctx context.Context, key string, response *Response, ttl time.Duration) error {
+	args := m.Called(ctx, key, response, ttl)
+	return args.Error(0)
+}
+
+func (m *MockStore) Release(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func NewMockStore() *MockStore {
+	return new(MockStore)
+}
@@ -0,0 +1,208 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewMemoryStore in memory store, the keys are not shared between the instances
+func NewMemoryStore() Store {
+	return &memoryStore{records: map[string]*Record{}, timeNow: time.Now}
+}
+
+func (s *memoryStore) Reserve(_ context.Context, key, requestHash string, ttl time.Duration) (*Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timeNow()
+	if record, ok := s.records[key]; ok && record.ExpiresAt.After(now) {
+		cp := *record
+		return &cp, false, nil
+	}
+
+	record := &Record{Key: key, RequestHash: requestHash, ExpiresAt: now.Add(ttl)}
+	s.records[key] = record
+
+	cp := *record
+	return &cp, true, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, key string, response *Response, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[key]
+	if !ok {
+		return nil
+	}
+
+	record.Response = response
+	record.ExpiresAt = s.timeNow().Add(ttl)
+
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+	return nil
+}
+
+// NewRedisStore store the records as JSON with the redis key expiration
+func NewRedisStore(client redis.UniversalClient, prefix string) Store {
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+
+	return &redisStore{client, prefix}
+}
+
+func (s *redisStore) Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*Record, bool, error) {
+	record := &Record{Key: key, RequestHash: requestHash, ExpiresAt: time.Now().Add(ttl)}
+
+	byt, err := json.Marshal(record)
+	if err != nil {
+		return nil, false, err
+	}
+
+	created, err := s.client.SetNX(ctx, s.prefix+key, byt, ttl).Result()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if created {
+		return record, true, nil
+	}
+
+	byt, err = s.client.Get(ctx, s.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// expired between the SETNX and the GET
+		return s.Reserve(ctx, key, requestHash, ttl)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing := &Record{}
+	return existing, false, json.Unmarshal(byt, existing)
+}
+
+func (s *redisStore) Complete(ctx context.Context, key string, response *Response, ttl time.Duration) error {
+	byt, err := s.client.Get(ctx, s.prefix+key).Bytes()
+	if err != nil {
+		return err
+	}
+
+	record := &Record{}
+	if err := json.Unmarshal(byt, record); err != nil {
+		return err
+	}
+
+	record.Response = response
+	record.ExpiresAt = time.Now().Add(ttl)
+
+	if byt, err = json.Marshal(record); err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, s.prefix+key, byt, ttl).Err()
+}
+
+func (s *redisStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
+
+// NewSqlStore PostgreSQL store, the table could be created with Migrate
+func NewSqlStore(db *sql.DB, table string) Store {
+	if table == "" {
+		table = DefaultTableName
+	}
+
+	return &sqlStore{db, table, time.Now}
+}
+
+// Migrate create the idempotency table if it does not exist
+func Migrate(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultTableName
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(PostgresSchema, table))
+	return err
+}
+
+func (s *sqlStore) Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (*Record, bool, error) {
+	now := s.timeNow()
+
+	// the expired keys are removed lazily, allowing them to be reused
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE key = $1 AND expires_at <= $2", s.table)
+	if _, err := s.db.ExecContext(ctx, deleteQuery, key, now); err != nil {
+		return nil, false, err
+	}
+
+	insertQuery := fmt.Sprintf(`INSERT INTO %s (key, request_hash, expires_at, created_at) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key) DO NOTHING`, s.table)
+
+	result, err := s.db.ExecContext(ctx, insertQuery, key, requestHash, now.Add(ttl), now)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 1 {
+		return &Record{Key: key, RequestHash: requestHash, ExpiresAt: now.Add(ttl)}, true, nil
+	}
+
+	record, err := s.get(ctx, key)
+	return record, false, err
+}
+
+func (s *sqlStore) Complete(ctx context.Context, key string, response *Response, ttl time.Duration) error {
+	header, err := json.Marshal(response.Header)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET status_code = $2, header = $3, body = $4, body_hash = $5, expires_at = $6 WHERE key = $1", s.table)
+
+	_, err = s.db.ExecContext(ctx, query, key, response.StatusCode, string(header), response.Body, response.BodyHash, s.timeNow().Add(ttl))
+	return err
+}
+
+func (s *sqlStore) Release(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE key = $1", s.table), key)
+	return err
+}
+
+func (s *sqlStore) get(ctx context.Context, key string) (*Record, error) {
+	query := fmt.Sprintf("SELECT key, request_hash, status_code, header, body, body_hash, expires_at FROM %s WHERE key = $1", s.table)
+
+	record := &Record{}
+	var status sql.NullInt64
+	var header, bodyHash sql.NullString
+	var body []byte
+
+	err := s.db.QueryRowContext(ctx, query, key).Scan(&record.Key, &record.RequestHash, &status, &header, &body, &bodyHash, &record.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if status.Valid {
+		record.Response = &Response{StatusCode: int(status.Int64), Header: http.Header{}, Body: body, BodyHash: bodyHash.String}
+		if header.String != "" {
+			if err := json.Unmarshal([]byte(header.String), &record.Response.Header); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return record, nil
+}
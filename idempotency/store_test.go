@@ -0,0 +1,66 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SqlStoreTestSuite struct {
+	suite.Suite
+}
+
+func TestSqlStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlStoreTestSuite))
+}
+
+func (s *SqlStoreTestSuite) TestReserve() {
+	db, sqlMock, _ := sqlmock.New()
+	store := NewSqlStore(db, "")
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_keys WHERE key = $1 AND expires_at")).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_keys")).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	record, created, err := store.Reserve(context.Background(), "key", "hash", time.Minute)
+	s.NoError(err)
+	s.True(created)
+	s.Equal("hash", record.RequestHash)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *SqlStoreTestSuite) TestReserveExisting() {
+	db, sqlMock, _ := sqlmock.New()
+	store := NewSqlStore(db, "")
+	columns := []string{"key", "request_hash", "status_code", "header", "body", "body_hash", "expires_at"}
+
+	sqlMock.ExpectExec("DELETE FROM idempotency_keys").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec("INSERT INTO idempotency_keys").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery("SELECT (.+) FROM idempotency_keys WHERE key").
+		WithArgs("key").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("key", "hash", 201, `{"Location":["/orders/1"]}`, []byte("{}"), hashBody([]byte("{}")), time.Now()))
+
+	record, created, err := store.Reserve(context.Background(), "key", "hash", time.Minute)
+	s.NoError(err)
+	s.False(created)
+	s.Equal(http.StatusCreated, record.Response.StatusCode)
+	s.Equal("/orders/1", record.Response.Header.Get("Location"))
+}
+
+func (s *SqlStoreTestSuite) TestCompleteAndRelease() {
+	db, sqlMock, _ := sqlmock.New()
+	store := NewSqlStore(db, "")
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE idempotency_keys SET status_code = $2")).
+		WithArgs("key", 200, sqlmock.AnyArg(), []byte("{}"), hashBody([]byte("{}")), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_keys WHERE key = $1")).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.NoError(store.Complete(context.Background(), "key", &Response{StatusCode: 200, Body: []byte("{}"), BodyHash: hashBody([]byte("{}"))}, time.Hour))
+	s.NoError(store.Release(context.Background(), "key"))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
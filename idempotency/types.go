@@ -0,0 +1,88 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	// Response the first response to the key, replayed to the duplicated requests
+	Response struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header"`
+		Body       []byte      `json:"body"`
+		// BodyHash sha256 of the body, verified before the replay
+		BodyHash string `json:"body_hash"`
+	}
+
+	// Record the stored state of a key, Response is nil while the first request is in progress
+	Record struct {
+		Key         string    `json:"key"`
+		RequestHash string    `json:"request_hash"`
+		Response    *Response `json:"response,omitempty"`
+		ExpiresAt   time.Time `json:"expires_at"`
+	}
+
+	Store interface {
+		// Reserve create an in progress record when the key does not exist, otherwise returns the existing record
+		Reserve(ctx context.Context, key, requestHash string, ttl time.Duration) (record *Record, created bool, err error)
+		// Complete store the response keeping it for the ttl
+		Complete(ctx context.Context, key string, response *Response, ttl time.Duration) error
+		// Release remove the key allowing a new attempt, used when the request failed
+		Release(ctx context.Context, key string) error
+	}
+
+	// ScopeFunc isolate the keys by client, e.g: the authenticated user id
+	ScopeFunc = func(r *http.Request) string
+
+	Opts struct {
+		// TTL how long the responses are replayed
+		TTL time.Duration
+		// LockTTL how long a request in progress holds the key, it should be greater than the request timeout
+		LockTTL time.Duration
+		// Methods the methods handled by the middleware, default POST and PATCH
+		Methods []string
+		// Required reject the requests without the Idempotency-Key header
+		Required bool
+		// MaxBodySize the max request body read to compute the request hash
+		MaxBodySize int64
+		// Scope the keys of different clients do not collide, the default is ScopeByClient
+		Scope ScopeFunc
+	}
+
+	// DedupOpts the TransactionalHandler configuration
+	DedupOpts struct {
+		// Table where the processed message ids are recorded, the default is DefaultProcessedMessagesTable
+		Table string
+		// Consumer isolate the message ids when many consumers process the same message
+		Consumer string
+	}
+
+	memoryStore struct {
+		mu      sync.Mutex
+		records map[string]*Record
+		timeNow func() time.Time
+	}
+
+	redisStore struct {
+		client redis.UniversalClient
+		prefix string
+	}
+
+	sqlStore struct {
+		db      *sql.DB
+		table   string
+		timeNow func() time.Time
+	}
+
+	responseRecorder struct {
+		http.ResponseWriter
+		status int
+		body   []byte
+	}
+)
@@ -0,0 +1,40 @@
+package jobs
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PENDING_STATUS   Status = "PENDING"
+	RUNNING_STATUS   Status = "RUNNING"
+	SUCCEEDED_STATUS Status = "SUCCEEDED"
+	FAILED_STATUS    Status = "FAILED"
+
+	// AMQPHeader the job id of the messages processed by the workers, see Handler
+	AMQPHeader = "x-job-id"
+
+	// Path the route of the job status, e.g: server.RegisterRoute(http.MethodGet, jobs.Path, jobs.HTTPHandler(tracker))
+	Path = "/jobs/{id}"
+
+	DefaultTTL         = 24 * time.Hour
+	DefaultRedisPrefix = "gokit:jobs:"
+	DefaultTableName   = "jobs"
+	retryAfterHint     = "1"
+
+	PostgresSchema = `CREATE TABLE IF NOT EXISTS %s (
+	id         TEXT PRIMARY KEY,
+	job        TEXT NOT NULL,
+	expires_at TIMESTAMPTZ NOT NULL
+)`
+)
+
+var (
+	ErrorNotFound      = errors.New("job not found")
+	ErrorFinished      = errors.New("the job is already finished")
+	ErrorJobIDRequired = errors.New("the job id is required")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::jobs] " + msg
+}
@@ -0,0 +1,65 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	gokitErrors "github.com/ralvescosta/gokit/errors"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+type jobIDKey struct{}
+
+// ContextWithJobID store the job id, so the workers report the progress of the job being processed
+func ContextWithJobID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, jobIDKey{}, id)
+}
+
+// JobIDFromContext the job id of the message being processed, see Handler
+func JobIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	id, _ := ctx.Value(jobIDKey{}).(string)
+	return id
+}
+
+// Handler mark the job of the AMQPHeader as RUNNING before the handler and, after it, as SUCCEEDED or FAILED, the errors
+// that will be retried keep the job RUNNING. The handler reports the progress with the JobIDFromContext(metadata.Ctx)
+// and could finish the job with its result, the messages without the header are processed without tracking
+func Handler(logger logging.ILogger, tracker ITracker, handler rabbitmq.ConsumerHandler) rabbitmq.ConsumerHandler {
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		id, _ := metadata.Headers[AMQPHeader].(string)
+		if id == "" {
+			return handler(msg, metadata)
+		}
+
+		ctx := metadata.Ctx
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		// the tracking failures are logged by the tracker and do not stop the processing
+		if err := tracker.Start(ctx, id); errors.Is(err, ErrorFinished) {
+			logger.Warn(LogMessage(fmt.Sprintf("skipping the finished job: %s", id)))
+			return nil
+		}
+
+		metadata.Ctx = ContextWithJobID(ctx, id)
+		err := handler(msg, metadata)
+
+		switch {
+		case err == nil:
+			tracker.Succeed(ctx, id, nil)
+			return nil
+		case gokitErrors.Decision(err) == gokitErrors.RETRY_DECISION:
+			return err
+		default:
+			tracker.Fail(ctx, id, err)
+			return err
+		}
+	}
+}
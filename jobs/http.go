@@ -0,0 +1,33 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+)
+
+// HTTPHandler respond the job of the last path segment, e.g: GET /jobs/{id}, the unfinished jobs respond the
+// Retry-After header to the clients polling them
+func HTTPHandler(tracker ITracker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		job, err := tracker.Get(r.Context(), path.Base(r.URL.Path))
+		if errors.Is(err, ErrorNotFound) {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+
+		if !job.Finished() {
+			w.Header().Set("Retry-After", retryAfterHint)
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(job)
+	}
+}
@@ -0,0 +1,136 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ralvescosta/gokit/guid"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// NewTracker the jobs are shared through the store, so the memory store only fits the jobs processed by the same instance
+func NewTracker(logger logging.ILogger, store Store, opts *Opts) ITracker {
+	ttl := DefaultTTL
+	if opts != nil && opts.TTL > 0 {
+		ttl = opts.TTL
+	}
+
+	return &tracker{logger, store, ttl, time.Now}
+}
+
+func (t *tracker) Create(ctx context.Context, jobType string, total int64, metadata map[string]string) (*Job, error) {
+	now := t.timeNow()
+	job := &Job{
+		ID:        guid.NewUUIDv7().String(),
+		Type:      jobType,
+		Status:    PENDING_STATUS,
+		Total:     total,
+		Metadata:  metadata,
+		CreatedAt: now,
+		UpdatedAt: now,
+		ExpiresAt: now.Add(t.ttl),
+	}
+
+	if err := t.store.Save(ctx, job, t.ttl); err != nil {
+		t.logger.Error(LogMessage("failure to create the job"), logging.ErrorField(err))
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (t *tracker) Start(ctx context.Context, id string) error {
+	return t.update(ctx, id, func(job *Job) {
+		job.Status = RUNNING_STATUS
+	})
+}
+
+func (t *tracker) Progress(ctx context.Context, id string, current int64, message string) error {
+	return t.update(ctx, id, func(job *Job) {
+		job.Status = RUNNING_STATUS
+		job.Current = current
+		job.Message = message
+	})
+}
+
+func (t *tracker) Succeed(ctx context.Context, id string, result any) error {
+	var byt []byte
+	if result != nil {
+		var err error
+		if byt, err = json.Marshal(result); err != nil {
+			return err
+		}
+	}
+
+	return t.update(ctx, id, func(job *Job) {
+		job.Status = SUCCEEDED_STATUS
+		job.Result = byt
+		if job.Total > 0 {
+			job.Current = job.Total
+		}
+
+		job.FinishedAt = &job.UpdatedAt
+	})
+}
+
+func (t *tracker) Fail(ctx context.Context, id string, err error) error {
+	return t.update(ctx, id, func(job *Job) {
+		job.Status = FAILED_STATUS
+		if err != nil {
+			job.Error = err.Error()
+		}
+
+		job.FinishedAt = &job.UpdatedAt
+	})
+}
+
+func (t *tracker) Get(ctx context.Context, id string) (*Job, error) {
+	if id == "" {
+		return nil, ErrorJobIDRequired
+	}
+
+	return t.store.Get(ctx, id)
+}
+
+// update apply the change and save the job, the finished jobs are not changed
+func (t *tracker) update(ctx context.Context, id string, change func(job *Job)) error {
+	job, err := t.Get(ctx, id)
+	if err != nil {
+		if !errors.Is(err, ErrorNotFound) {
+			t.logger.Error(LogMessage("failure to get the job"), logging.ErrorField(err))
+		}
+
+		return err
+	}
+
+	if job.Finished() {
+		return ErrorFinished
+	}
+
+	job.UpdatedAt = t.timeNow()
+	job.ExpiresAt = job.UpdatedAt.Add(t.ttl)
+	change(job)
+
+	if err := t.store.Save(ctx, job, t.ttl); err != nil {
+		t.logger.Error(LogMessage("failure to update the job"), logging.ErrorField(err))
+		return err
+	}
+
+	return nil
+}
+
+// Finished the job SUCCEEDED or FAILED
+func (j *Job) Finished() bool {
+	return j.Status == SUCCEEDED_STATUS || j.Status == FAILED_STATUS
+}
+
+// Percent the progress percentage, zero when the total is unknown
+func (j *Job) Percent() float64 {
+	if j.Total <= 0 {
+		return 0
+	}
+
+	return float64(j.Current) * 100 / float64(j.Total)
+}
@@ -0,0 +1,129 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	gokitErrors "github.com/ralvescosta/gokit/errors"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type JobsTestSuite struct {
+	suite.Suite
+
+	logger *logging.MockLogger
+}
+
+func TestJobsTestSuite(t *testing.T) {
+	suite.Run(t, new(JobsTestSuite))
+}
+
+func (s *JobsTestSuite) SetupTest() {
+	s.logger = logging.NewMockLogger()
+}
+
+func (s *JobsTestSuite) stores() []Store {
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(s.T()).Addr()})
+	return []Store{NewMemoryStore(), NewRedisStore(client, "")}
+}
+
+func (s *JobsTestSuite) TestTracker() {
+	ctx := context.Background()
+
+	for _, store := range s.stores() {
+		tracker := NewTracker(s.logger, store, nil)
+
+		job, err := tracker.Create(ctx, "export", 200, map[string]string{"format": "csv"})
+		s.Require().NoError(err)
+		s.Equal(PENDING_STATUS, job.Status)
+
+		s.NoError(tracker.Progress(ctx, job.ID, 50, "exporting the orders"))
+
+		job, err = tracker.Get(ctx, job.ID)
+		s.Require().NoError(err)
+		s.Equal(RUNNING_STATUS, job.Status)
+		s.Equal("exporting the orders", job.Message)
+		s.Equal(25.0, job.Percent())
+
+		s.NoError(tracker.Succeed(ctx, job.ID, map[string]string{"url": "https://files/export.csv"}))
+
+		job, err = tracker.Get(ctx, job.ID)
+		s.Require().NoError(err)
+		s.Equal(SUCCEEDED_STATUS, job.Status)
+		s.Equal(int64(200), job.Current)
+		s.NotNil(job.FinishedAt)
+		s.JSONEq(`{"url":"https://files/export.csv"}`, string(job.Result))
+		s.Equal("csv", job.Metadata["format"])
+
+		s.ErrorIs(tracker.Fail(ctx, job.ID, errors.New("late")), ErrorFinished)
+		s.ErrorIs(tracker.Start(ctx, "unknown"), ErrorNotFound)
+	}
+}
+
+func (s *JobsTestSuite) TestHTTPHandler() {
+	tracker := NewTracker(s.logger, NewMemoryStore(), nil)
+	job, _ := tracker.Create(context.Background(), "import", 0, nil)
+
+	rec := httptest.NewRecorder()
+	HTTPHandler(tracker)(rec, httptest.NewRequest(http.MethodGet, "/jobs/"+job.ID, nil))
+
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal(retryAfterHint, rec.Header().Get("Retry-After"))
+
+	body := &Job{}
+	s.NoError(json.NewDecoder(rec.Body).Decode(body))
+	s.Equal(job.ID, body.ID)
+	s.Equal(PENDING_STATUS, body.Status)
+
+	rec = httptest.NewRecorder()
+	HTTPHandler(tracker)(rec, httptest.NewRequest(http.MethodGet, "/jobs/unknown", nil))
+	s.Equal(http.StatusNotFound, rec.Code)
+}
+
+func (s *JobsTestSuite) TestHandler() {
+	tracker := NewTracker(s.logger, NewMemoryStore(), nil)
+	ctx := context.Background()
+
+	succeeded, _ := tracker.Create(ctx, "export", 10, nil)
+	retried, _ := tracker.Create(ctx, "export", 10, nil)
+	failed, _ := tracker.Create(ctx, "export", 10, nil)
+
+	handler := Handler(s.logger, tracker, func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		id := JobIDFromContext(metadata.Ctx)
+		tracker.Progress(metadata.Ctx, id, 5, "")
+
+		switch id {
+		case retried.ID:
+			return gokitErrors.Unavailable("database")
+		case failed.ID:
+			return gokitErrors.Invalid("payload")
+		default:
+			return nil
+		}
+	})
+
+	metadata := func(id string) *rabbitmq.DeliveryMetadata {
+		return &rabbitmq.DeliveryMetadata{Headers: map[string]interface{}{AMQPHeader: id}}
+	}
+
+	s.NoError(handler(nil, metadata(succeeded.ID)))
+	s.Error(handler(nil, metadata(retried.ID)))
+	s.Error(handler(nil, metadata(failed.ID)))
+
+	for id, status := range map[string]Status{succeeded.ID: SUCCEEDED_STATUS, retried.ID: RUNNING_STATUS, failed.ID: FAILED_STATUS} {
+		job, err := tracker.Get(ctx, id)
+		s.Require().NoError(err)
+		s.Equal(status, job.Status)
+	}
+
+	// the redelivered message of a finished job is skipped
+	s.NoError(handler(nil, metadata(failed.ID)))
+}
@@ -0,0 +1,72 @@
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockTracker struct {
+		mock.Mock
+	}
+
+	MockStore struct {
+		mock.Mock
+	}
+)
+
+func (m *MockTracker) Create(ctx context.Context, jobType string, total int64, metadata map[string]string) (*Job, error) {
+	args := m.Called(ctx, jobType, total, metadata)
+
+	job, _ := args.Get(0).(*Job)
+	return job, args.Error(1)
+}
+
+func (m *MockTracker) Start(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockTracker) Progress(ctx context.Context, id string, current int64, message string) error {
+	args := m.Called(ctx, id, current, message)
+	return args.Error(0)
+}
+
+func (m *MockTracker) Succeed(ctx context.Context, id string, result any) error {
+	args := m.Called(ctx, id, result)
+	return args.Error(0)
+}
+
+func (m *MockTracker) Fail(ctx context.Context, id string, err error) error {
+	args := m.Called(ctx, id, err)
+	return args.Error(0)
+}
+
+func (m *MockTracker) Get(ctx context.Context, id string) (*Job, error) {
+	args := m.Called(ctx, id)
+
+	job, _ := args.Get(0).(*Job)
+	return job, args.Error(1)
+}
+
+func (m *MockStore) Save(ctx context.Context, job *Job, ttl time.Duration) error {
+	args := m.Called(ctx, job, ttl)
+	return args.Error(0)
+}
+
+func (m *MockStore) Get(ctx context.Context, id string) (*Job, error) {
+	args := m.Called(ctx, id)
+
+	job, _ := args.Get(0).(*Job)
+	return job, args.Error(1)
+}
+
+func NewMockTracker() *MockTracker {
+	return new(MockTracker)
+}
+
+func NewMockStore() *MockStore {
+	return new(MockStore)
+}
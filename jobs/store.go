@@ -0,0 +1,140 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewMemoryStore in memory store, the jobs are not shared between the instances
+func NewMemoryStore() Store {
+	return &memoryStore{jobs: map[string]*Job{}, timeNow: time.Now}
+}
+
+func (s *memoryStore) Save(_ context.Context, job *Job, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp := *job
+	s.jobs[job.ID] = &cp
+
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, id string) (*Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job, ok := s.jobs[id]
+	if !ok {
+		return nil, ErrorNotFound
+	}
+
+	if !job.ExpiresAt.After(s.timeNow()) {
+		delete(s.jobs, id)
+		return nil, ErrorNotFound
+	}
+
+	cp := *job
+	return &cp, nil
+}
+
+// NewRedisStore store the jobs as JSON with the redis key expiration
+func NewRedisStore(client redis.UniversalClient, prefix string) Store {
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+
+	return &redisStore{client, prefix}
+}
+
+func (s *redisStore) Save(ctx context.Context, job *Job, ttl time.Duration) error {
+	byt, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, s.prefix+job.ID, byt, ttl).Err()
+}
+
+func (s *redisStore) Get(ctx context.Context, id string) (*Job, error) {
+	byt, err := s.client.Get(ctx, s.prefix+id).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrorNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Job{}
+	return job, json.Unmarshal(byt, job)
+}
+
+// NewSqlStore PostgreSQL store, the table could be created with Migrate and the expired jobs removed with Purge
+func NewSqlStore(db *sql.DB, table string) Store {
+	if table == "" {
+		table = DefaultTableName
+	}
+
+	return &sqlStore{db, table, time.Now}
+}
+
+// Migrate create the jobs table if it does not exist
+func Migrate(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultTableName
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(PostgresSchema, table))
+	return err
+}
+
+// Purge delete the expired jobs of the sql store, e.g: in a scheduled job
+func Purge(ctx context.Context, db *sql.DB, table string) (int64, error) {
+	if table == "" {
+		table = DefaultTableName
+	}
+
+	result, err := db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE expires_at <= $1", table), time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (s *sqlStore) Save(ctx context.Context, job *Job, ttl time.Duration) error {
+	byt, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (id, job, expires_at) VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO UPDATE SET job = EXCLUDED.job, expires_at = EXCLUDED.expires_at`, s.table)
+
+	_, err = s.db.ExecContext(ctx, query, job.ID, string(byt), s.timeNow().Add(ttl))
+	return err
+}
+
+func (s *sqlStore) Get(ctx context.Context, id string) (*Job, error) {
+	query := fmt.Sprintf("SELECT job FROM %s WHERE id = $1 AND expires_at > $2", s.table)
+
+	var byt string
+	err := s.db.QueryRowContext(ctx, query, id, s.timeNow()).Scan(&byt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrorNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	job := &Job{}
+	return job, json.Unmarshal([]byte(byt), job)
+}
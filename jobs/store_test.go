@@ -0,0 +1,45 @@
+package jobs
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SqlStoreTestSuite struct {
+	suite.Suite
+}
+
+func TestSqlStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlStoreTestSuite))
+}
+
+func (s *SqlStoreTestSuite) TestSaveAndGet() {
+	db, sqlMock, _ := sqlmock.New()
+	store := NewSqlStore(db, "")
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO jobs (id, job, expires_at)")).
+		WithArgs("id", sqlmock.AnyArg(), sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT job FROM jobs WHERE id = $1 AND expires_at > $2")).
+		WithArgs("id", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"job"}).AddRow(`{"id":"id","status":"RUNNING","current":5}`))
+	sqlMock.ExpectQuery("SELECT job FROM jobs").
+		WithArgs("expired", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"job"}))
+
+	s.NoError(store.Save(context.Background(), &Job{ID: "id", Status: RUNNING_STATUS}, time.Hour))
+
+	job, err := store.Get(context.Background(), "id")
+	s.NoError(err)
+	s.Equal(RUNNING_STATUS, job.Status)
+	s.Equal(int64(5), job.Current)
+
+	_, err = store.Get(context.Background(), "expired")
+	s.ErrorIs(err, ErrorNotFound)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,85 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	Status string
+
+	// Job the progress of an async operation, e.g: an export, the Result is set when the job succeeds
+	Job struct {
+		ID       string            `json:"id"`
+		Type     string            `json:"type"`
+		Status   Status            `json:"status"`
+		Current  int64             `json:"current"`
+		Total    int64             `json:"total,omitempty"`
+		Message  string            `json:"message,omitempty"`
+		Result   json.RawMessage   `json:"result,omitempty"`
+		Error    string            `json:"error,omitempty"`
+		Metadata map[string]string `json:"metadata,omitempty"`
+
+		CreatedAt  time.Time  `json:"created_at"`
+		UpdatedAt  time.Time  `json:"updated_at"`
+		FinishedAt *time.Time `json:"finished_at,omitempty"`
+		ExpiresAt  time.Time  `json:"expires_at"`
+	}
+
+	// Store persist the jobs until the ttl, the last write wins, so each job should be updated by only one worker
+	Store interface {
+		Save(ctx context.Context, job *Job, ttl time.Duration) error
+		// Get returns ErrorNotFound when the job does not exist or is expired
+		Get(ctx context.Context, id string) (*Job, error)
+	}
+
+	// ITracker register and update the jobs progress, it is shared by the api creating the jobs and the workers processing them
+	ITracker interface {
+		// Create a PENDING job, total zero when it is unknown
+		Create(ctx context.Context, jobType string, total int64, metadata map[string]string) (*Job, error)
+		// Start mark the job as RUNNING
+		Start(ctx context.Context, id string) error
+		// Progress update the current progress and the message, e.g: "exporting the orders"
+		Progress(ctx context.Context, id string, current int64, message string) error
+		// Succeed finish the job with the result, encoded as JSON
+		Succeed(ctx context.Context, id string, result any) error
+		// Fail finish the job with the error message
+		Fail(ctx context.Context, id string, err error) error
+		Get(ctx context.Context, id string) (*Job, error)
+	}
+
+	Opts struct {
+		// TTL how long the jobs are kept after the last update, default DefaultTTL
+		TTL time.Duration
+	}
+
+	tracker struct {
+		logger  logging.ILogger
+		store   Store
+		ttl     time.Duration
+		timeNow func() time.Time
+	}
+
+	memoryStore struct {
+		mu      sync.Mutex
+		jobs    map[string]*Job
+		timeNow func() time.Time
+	}
+
+	redisStore struct {
+		client redis.UniversalClient
+		prefix string
+	}
+
+	sqlStore struct {
+		db      *sql.DB
+		table   string
+		timeNow func() time.Time
+	}
+)
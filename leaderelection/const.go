@@ -0,0 +1,22 @@
+package leaderelection
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultLeaseTTL      = 15 * time.Second
+	DefaultRenewInterval = 5 * time.Second
+	DefaultRetryInterval = 5 * time.Second
+	DefaultRedisPrefix   = "gokit:leader:"
+)
+
+var (
+	ErrorKeyRequired   = errors.New("leader election key is required")
+	ErrorRenewInterval = errors.New("leader election renew interval must be lower than the lease ttl")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::leaderelection] " + msg
+}
@@ -0,0 +1,174 @@
+package leaderelection
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ralvescosta/gokit/guid"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// New create the elector, the zero values in opts are replaced by the defaults
+func New(logger logging.ILogger, lease Lease, opts *Opts) (IElector, error) {
+	if opts == nil || opts.Key == "" {
+		return nil, ErrorKeyRequired
+	}
+
+	cp := *opts
+	if cp.LeaseTTL == 0 {
+		cp.LeaseTTL = DefaultLeaseTTL
+	}
+
+	if cp.RenewInterval == 0 {
+		cp.RenewInterval = cp.LeaseTTL / 3
+	}
+
+	if cp.RetryInterval == 0 {
+		cp.RetryInterval = DefaultRetryInterval
+	}
+
+	if cp.RenewInterval >= cp.LeaseTTL {
+		return nil, ErrorRenewInterval
+	}
+
+	if cp.Holder == "" {
+		hostname, _ := os.Hostname()
+		cp.Holder = fmt.Sprintf("%s-%s", hostname, guid.NewULID())
+	}
+
+	return &Elector{
+		logger:  logger,
+		lease:   lease,
+		opts:    &cp,
+		timeNow: time.Now,
+	}, nil
+}
+
+func (e *Elector) RunWhenLeader(ctx context.Context, fn func(ctx context.Context) error) error {
+	for {
+		acquired, err := e.lease.Acquire(ctx, e.opts.Key, e.opts.Holder, e.opts.LeaseTTL)
+		if err != nil && ctx.Err() == nil {
+			e.logger.Error(LogMessage("failure to acquire the lease"), logging.MessageField("key", e.opts.Key), logging.ErrorField(err))
+		}
+
+		if acquired {
+			done, err := e.lead(ctx, fn)
+			if done {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.opts.RetryInterval):
+		}
+	}
+}
+
+// lead run fn renewing the lease, done is false when the lease was lost and the election must continue
+func (e *Elector) lead(ctx context.Context, fn func(ctx context.Context) error) (done bool, err error) {
+	e.setLeader(true)
+	defer e.setLeader(false)
+
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	result := make(chan error, 1)
+	go func() { result <- fn(leaderCtx) }()
+
+	ticker := time.NewTicker(e.opts.RenewInterval)
+	defer ticker.Stop()
+
+	// stop wait fn and release the lease, so the next candidate does not wait the ttl
+	stop := func() {
+		cancel()
+		<-result
+		e.release()
+	}
+
+	for {
+		select {
+		case err := <-result:
+			e.release()
+			return true, err
+
+		case <-ctx.Done():
+			stop()
+			return true, nil
+
+		case <-ticker.C:
+			renewed, err := e.lease.Renew(ctx, e.opts.Key, e.opts.Holder, e.opts.LeaseTTL)
+			if ctx.Err() != nil {
+				// the renew failed because of the shutdown, the lease is still held
+				stop()
+				return true, nil
+			}
+
+			if err != nil {
+				e.logger.Error(LogMessage("failure to renew the lease"), logging.MessageField("key", e.opts.Key), logging.ErrorField(err))
+			}
+
+			if renewed {
+				continue
+			}
+
+			e.logger.Warn(LogMessage("leadership lost"), logging.MessageField("key", e.opts.Key))
+			e.mu.Lock()
+			e.metrics.Lost++
+			e.mu.Unlock()
+
+			cancel()
+			<-result
+			return false, nil
+		}
+	}
+}
+
+// release with a fresh ctx, the RunWhenLeader ctx is already done on shutdown
+func (e *Elector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.opts.RenewInterval)
+	defer cancel()
+
+	if err := e.lease.Release(ctx, e.opts.Key, e.opts.Holder); err != nil {
+		e.logger.Warn(LogMessage("failure to release the lease"), logging.MessageField("key", e.opts.Key), logging.ErrorField(err))
+	}
+}
+
+func (e *Elector) setLeader(leader bool) {
+	e.mu.Lock()
+	e.metrics.IsLeader = leader
+	if leader {
+		e.metrics.LeaderSince = e.timeNow()
+		e.metrics.Transitions++
+	} else {
+		e.metrics.LeaderSince = time.Time{}
+	}
+	e.mu.Unlock()
+
+	if leader {
+		e.logger.Info(LogMessage("started leading"), logging.MessageField("key", e.opts.Key), logging.MessageField("holder", e.opts.Holder))
+	} else {
+		e.logger.Info(LogMessage("stopped leading"), logging.MessageField("key", e.opts.Key), logging.MessageField("holder", e.opts.Holder))
+	}
+
+	if e.opts.OnLeadershipChange != nil {
+		e.opts.OnLeadershipChange(e.opts.Key, leader)
+	}
+}
+
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.metrics.IsLeader
+}
+
+func (e *Elector) Metrics() Metrics {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.metrics
+}
@@ -0,0 +1,192 @@
+package leaderelection
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ralvescosta/gokit/logging"
+	pg "github.com/ralvescosta/gokit/sql/postgres"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LeaderElectionTestSuite struct {
+	suite.Suite
+}
+
+func TestLeaderElectionTestSuite(t *testing.T) {
+	suite.Run(t, new(LeaderElectionTestSuite))
+}
+
+func (s *LeaderElectionTestSuite) opts(holder string) *Opts {
+	return &Opts{
+		Key:           "outbox",
+		Holder:        holder,
+		LeaseTTL:      300 * time.Millisecond,
+		RenewInterval: 50 * time.Millisecond,
+		RetryInterval: 20 * time.Millisecond,
+	}
+}
+
+func (s *LeaderElectionTestSuite) TestNew() {
+	_, err := New(logging.NewMockLogger(), NewMockLease(), &Opts{})
+	s.ErrorIs(err, ErrorKeyRequired)
+
+	_, err = New(logging.NewMockLogger(), NewMockLease(), &Opts{Key: "key", LeaseTTL: time.Second, RenewInterval: time.Second})
+	s.ErrorIs(err, ErrorRenewInterval)
+
+	elector, err := New(logging.NewMockLogger(), NewMockLease(), &Opts{Key: "key"})
+	s.NoError(err)
+	s.NotEmpty(elector.(*Elector).opts.Holder)
+	s.Equal(DefaultLeaseTTL/3, elector.(*Elector).opts.RenewInterval)
+}
+
+func (s *LeaderElectionTestSuite) TestRedisHandover() {
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(s.T()).Addr()})
+
+	first, _ := New(logging.NewMockLogger(), NewRedisLease(client, ""), s.opts("first"))
+	second, _ := New(logging.NewMockLogger(), NewRedisLease(client, ""), s.opts("second"))
+
+	var running int32
+	work := func(name string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			s.Equal(int32(1), atomic.AddInt32(&running, 1), name)
+			<-ctx.Done()
+			atomic.AddInt32(&running, -1)
+			return nil
+		}
+	}
+
+	firstCtx, stopFirst := context.WithCancel(context.Background())
+	firstDone := make(chan error)
+	go func() { firstDone <- first.RunWhenLeader(firstCtx, work("first")) }()
+	time.Sleep(100 * time.Millisecond)
+
+	secondCtx, stopSecond := context.WithCancel(context.Background())
+	secondDone := make(chan error)
+	go func() { secondDone <- second.RunWhenLeader(secondCtx, work("second")) }()
+	time.Sleep(100 * time.Millisecond)
+
+	s.True(first.IsLeader())
+	s.False(second.IsLeader())
+
+	stopFirst()
+	s.NoError(<-firstDone)
+	time.Sleep(100 * time.Millisecond)
+
+	s.False(first.IsLeader())
+	s.True(second.IsLeader())
+	s.Equal(uint64(1), second.Metrics().Transitions)
+
+	stopSecond()
+	s.NoError(<-secondDone)
+}
+
+func (s *LeaderElectionTestSuite) TestLeaseLost() {
+	lease := NewMockLease()
+	lease.On("Acquire", mock.Anything, "outbox", "holder", 300*time.Millisecond).Return(true, nil)
+	lease.On("Renew", mock.Anything, "outbox", "holder", 300*time.Millisecond).Return(false, nil).Once()
+	lease.On("Release", mock.Anything, "outbox", "holder").Return(nil).Once()
+
+	changes := make(chan bool, 10)
+	opts := s.opts("holder")
+	opts.OnLeadershipChange = func(key string, leader bool) { changes <- leader }
+
+	elector, _ := New(logging.NewMockLogger(), lease, opts)
+
+	runs := 0
+	err := elector.RunWhenLeader(context.Background(), func(ctx context.Context) error {
+		runs++
+		if runs == 1 {
+			<-ctx.Done()
+			return nil
+		}
+		return errors.New("some error")
+	})
+
+	s.Error(err)
+	s.Equal(2, runs)
+	s.Equal(Metrics{Transitions: 2, Lost: 1}, elector.Metrics())
+	s.Equal([]bool{true, false, true, false}, []bool{<-changes, <-changes, <-changes, <-changes})
+	lease.AssertExpectations(s.T())
+}
+
+func (s *LeaderElectionTestSuite) TestRenewCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	lease := NewMockLease()
+	lease.On("Acquire", mock.Anything, "outbox", "holder", 300*time.Millisecond).Return(true, nil)
+	lease.On("Renew", mock.Anything, "outbox", "holder", 300*time.Millisecond).
+		Run(func(args mock.Arguments) { cancel() }).
+		Return(false, context.Canceled).Once()
+	lease.On("Release", mock.Anything, "outbox", "holder").
+		Run(func(args mock.Arguments) { s.NoError(args.Get(0).(context.Context).Err()) }).
+		Return(nil).Once()
+
+	elector, _ := New(logging.NewMockLogger(), lease, s.opts("holder"))
+
+	err := elector.RunWhenLeader(ctx, func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal(Metrics{Transitions: 1}, elector.Metrics())
+	lease.AssertExpectations(s.T())
+}
+
+func (s *LeaderElectionTestSuite) TestPostgresLease() {
+	db, sqlMock, _ := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	defer db.Close()
+
+	key := pg.AdvisoryKey("outbox")
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(true))
+	sqlMock.ExpectPing()
+	sqlMock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(key).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	lease := NewPostgresLease(db)
+
+	acquired, err := lease.Acquire(context.Background(), "outbox", "holder", time.Second)
+	s.NoError(err)
+	s.True(acquired)
+
+	renewed, err := lease.Renew(context.Background(), "outbox", "holder", time.Second)
+	s.NoError(err)
+	s.True(renewed)
+
+	s.NoError(lease.Release(context.Background(), "outbox", "holder"))
+
+	renewed, err = lease.Renew(context.Background(), "outbox", "holder", time.Second)
+	s.NoError(err)
+	s.False(renewed)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *LeaderElectionTestSuite) TestPostgresLeaseRenewErr() {
+	db, sqlMock, _ := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	defer db.Close()
+
+	key := pg.AdvisoryKey("outbox")
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(true))
+	sqlMock.ExpectPing().WillReturnError(errors.New("connection lost"))
+	sqlMock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(key).WillReturnError(errors.New("connection lost"))
+
+	lease := NewPostgresLease(db)
+
+	acquired, err := lease.Acquire(context.Background(), "outbox", "holder", time.Second)
+	s.NoError(err)
+	s.True(acquired)
+
+	renewed, err := lease.Renew(context.Background(), "outbox", "holder", time.Second)
+	s.Error(err)
+	s.False(renewed)
+	s.Equal(0, db.Stats().OpenConnections)
+	s.NoError(lease.Release(context.Background(), "outbox", "holder"))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
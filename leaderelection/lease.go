@@ -0,0 +1,103 @@
+package leaderelection
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	pg "github.com/ralvescosta/gokit/sql/postgres"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// NewRedisLease the lease is a key with the holder as value and the ttl as expiration
+func NewRedisLease(client redis.UniversalClient, prefix string) Lease {
+	if prefix == "" {
+		prefix = DefaultRedisPrefix
+	}
+
+	return &redisLease{client, prefix}
+}
+
+func (l *redisLease) Acquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	return l.client.SetNX(ctx, l.prefix+key, holder, ttl).Result()
+}
+
+func (l *redisLease) Renew(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	renewed, err := renewScript.Run(ctx, l.client, []string{l.prefix + key}, holder, ttl.Milliseconds()).Int()
+	return renewed == 1, err
+}
+
+func (l *redisLease) Release(ctx context.Context, key, holder string) error {
+	return releaseScript.Run(ctx, l.client, []string{l.prefix + key}, holder).Err()
+}
+
+// NewPostgresLease the lease is a session advisory lock, it is held while the dedicated connection is alive
+//
+// The ttl is not used, postgres releases the lock when the session ends. Each elector needs its own lease
+func NewPostgresLease(db *sql.DB) Lease {
+	return &postgresLease{db: db}
+}
+
+func (l *postgresLease) Acquire(ctx context.Context, key, _ string, _ time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lock != nil {
+		return true, nil
+	}
+
+	lock, acquired, err := pg.TryAdvisoryLock(ctx, l.db, key)
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	l.lock = lock
+	return true, nil
+}
+
+// Renew check if the session that holds the lock is alive
+func (l *postgresLease) Renew(ctx context.Context, _, _ string, _ time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lock == nil {
+		return false, nil
+	}
+
+	// the unlock of the broken session fails and the connection is discarded
+	if err := l.lock.Ping(ctx); err != nil {
+		_ = l.lock.Unlock(ctx)
+		l.lock = nil
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (l *postgresLease) Release(ctx context.Context, _, _ string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lock == nil {
+		return nil
+	}
+
+	err := l.lock.Unlock(ctx)
+	l.lock = nil
+
+	return err
+}
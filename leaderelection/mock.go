@@ -0,0 +1,62 @@
+package leaderelection
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockLease struct {
+		mock.Mock
+	}
+
+	MockElector struct {
+		mock.Mock
+	}
+)
+
+func (m *MockLease) Acquire(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, key, holder, ttl)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLease) Renew(ctx context.Context, key, holder string, ttl time.Duration) (bool, error) {
+	args := m.Called(ctx, key, holder, ttl)
+
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockLease) Release(ctx context.Context, key, holder string) error {
+	args := m.Called(ctx, key, holder)
+
+	return args.Error(0)
+}
+
+func (m *MockElector) RunWhenLeader(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx, fn)
+
+	return args.Error(0)
+}
+
+func (m *MockElector) IsLeader() bool {
+	args := m.Called()
+
+	return args.Bool(0)
+}
+
+func (m *MockElector) Metrics() Metrics {
+	args := m.Called()
+
+	return args.Get(0).(Metrics)
+}
+
+func NewMockLease() *MockLease {
+	return new(MockLease)
+}
+
+func NewMockElector() *MockElector {
+	return new(MockElector)
+}
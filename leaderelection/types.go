@@ -0,0 +1,82 @@
+package leaderelection
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	pg "github.com/ralvescosta/gokit/sql/postgres"
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	// Lease the backend that holds the leadership, only one holder owns the key at a time
+	Lease interface {
+		// Acquire returns acquired false when the key is held by other holder
+		Acquire(ctx context.Context, key, holder string, ttl time.Duration) (acquired bool, err error)
+		// Renew extend the lease, returns false when the lease was lost
+		Renew(ctx context.Context, key, holder string, ttl time.Duration) (renewed bool, err error)
+		// Release give up the lease allowing other holder to acquire it without waiting the ttl
+		Release(ctx context.Context, key, holder string) error
+	}
+
+	Opts struct {
+		// Key identify the election, the instances competing for the same work must use the same key
+		Key string
+		// Holder identify this instance, the default is the hostname with a random suffix
+		Holder string
+		// LeaseTTL how long the lease is valid without renewal
+		LeaseTTL time.Duration
+		// RenewInterval must be lower than the LeaseTTL
+		RenewInterval time.Duration
+		// RetryInterval how long the followers wait between the acquire attempts
+		RetryInterval time.Duration
+		// OnLeadershipChange optional, called when this instance starts or stops leading
+		OnLeadershipChange func(key string, leader bool)
+	}
+
+	// Metrics snapshot of the leadership status
+	Metrics struct {
+		IsLeader    bool
+		LeaderSince time.Time
+		// Transitions how many times this instance became the leader
+		Transitions uint64
+		// Lost how many times the lease was lost while leading
+		Lost uint64
+	}
+
+	IElector interface {
+		// RunWhenLeader block competing for the leadership and run fn while this instance is the leader
+		//
+		// The fn ctx is canceled when the lease is lost, fn is started again if the leadership is reacquired.
+		// When ctx is done fn ctx is canceled, RunWhenLeader waits fn to return and releases the lease to a graceful handover.
+		// The fn error is returned when fn returns while leading, nil is returned when ctx is done
+		RunWhenLeader(ctx context.Context, fn func(ctx context.Context) error) error
+		IsLeader() bool
+		Metrics() Metrics
+	}
+
+	Elector struct {
+		logger logging.ILogger
+		lease  Lease
+		opts   *Opts
+
+		mu      sync.Mutex
+		metrics Metrics
+		timeNow func() time.Time
+	}
+
+	redisLease struct {
+		client redis.UniversalClient
+		prefix string
+	}
+
+	// postgresLease hold a session advisory lock in a dedicated connection
+	postgresLease struct {
+		db   *sql.DB
+		mu   sync.Mutex
+		lock *pg.AdvisoryLock
+	}
+)
@@ -3,7 +3,7 @@ module github.com/ralvescosta/gokit/logging
 go 1.18
 
 require (
-	github.com/ralvescosta/gokit/env v0.0.0-20220717193252-2f9449cd88d1
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94
 	github.com/stretchr/testify v1.8.0
 	go.uber.org/zap v1.21.0
 )
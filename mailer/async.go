@@ -0,0 +1,40 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/worker"
+	"go.uber.org/zap"
+)
+
+// NewAsync enqueue the messages as SEND_TASK_TYPE tasks, the worker consuming the tasks must register mailer.TaskHandler().
+// The templates are rendered by the worker, so Data must be JSON serializable
+func NewAsync(logger logging.ILogger, mailer IMailer, enqueuer Enqueuer, opts *worker.EnqueueOpts) IMailer {
+	return &asyncMailer{
+		logger:   logger,
+		mailer:   mailer,
+		enqueuer: enqueuer,
+		opts:     opts,
+	}
+}
+
+func (m *asyncMailer) Send(ctx context.Context, msg *Message) error {
+	if len(msg.To)+len(msg.Cc)+len(msg.Bcc) == 0 {
+		return ErrorNoRecipients
+	}
+
+	id, err := m.enqueuer.Enqueue(ctx, SEND_TASK_TYPE, msg, m.opts)
+	if err != nil {
+		m.logger.Error(LogMessage("failure to enqueue the message"), zap.Strings("to", msg.To), logging.ErrorField(err))
+		return err
+	}
+
+	m.logger.Debug(LogMessage("message enqueued"), logging.MessageField("taskId", id))
+
+	return nil
+}
+
+func (m *asyncMailer) TaskHandler() worker.TaskHandler {
+	return m.mailer.TaskHandler()
+}
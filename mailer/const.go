@@ -0,0 +1,30 @@
+package mailer
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// SEND_TASK_TYPE the worker task type used by the async mode
+	SEND_TASK_TYPE = "mailer.send"
+
+	HTMLTemplateExt = ".html"
+	TextTemplateExt = ".txt"
+
+	DefaultSMTPTimeout = 30 * time.Second
+)
+
+var (
+	ErrorProviderRequired = errors.New("mailer provider is required")
+	ErrorNoRecipients     = errors.New("message without recipients")
+	ErrorNoSender         = errors.New("message without sender")
+	ErrorEmptyBody        = errors.New("message without text or html body")
+	ErrorTemplateNotFound = errors.New("mail template not found")
+	ErrorInvalidAddress   = errors.New("invalid mail address")
+	ErrorInvalidHeader    = errors.New("mail header with line break")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::mailer] " + msg
+}
@@ -0,0 +1,180 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmlTemplate "html/template"
+	"io/fs"
+	"strings"
+	textTemplate "text/template"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/retry"
+	"github.com/ralvescosta/gokit/worker"
+	"go.uber.org/zap"
+)
+
+// New create a mailer builder delivering the messages through the provider
+func New(logger logging.ILogger, provider Provider) MailerBuilder {
+	return &mailer{
+		logger:   logger,
+		provider: provider,
+		retry:    retry.DefaultPolicy(),
+	}
+}
+
+// NewSMTPConfig create the smtp configuration from the env Mailer() configs
+func NewSMTPConfig(cfg *env.Configs) *SMTPConfig {
+	return &SMTPConfig{
+		Host:     cfg.SMTP_HOST,
+		Port:     cfg.SMTP_PORT,
+		Username: cfg.SMTP_USERNAME,
+		Password: cfg.SMTP_PASSWORD,
+	}
+}
+
+func (m *mailer) From(addr string) MailerBuilder {
+	m.from = addr
+	return m
+}
+
+func (m *mailer) Templates(fsys fs.FS, patterns ...string) MailerBuilder {
+	files := []string{}
+	for _, pattern := range patterns {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			m.buildError = err
+			return m
+		}
+		files = append(files, matches...)
+	}
+
+	m.htmlTmpl = htmlTemplate.New("")
+	m.textTmpl = textTemplate.New("")
+
+	for _, file := range files {
+		byt, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			m.buildError = err
+			return m
+		}
+
+		name := file[strings.LastIndex(file, "/")+1:]
+		switch {
+		case strings.HasSuffix(name, HTMLTemplateExt):
+			_, err = m.htmlTmpl.New(strings.TrimSuffix(name, HTMLTemplateExt)).Parse(string(byt))
+		case strings.HasSuffix(name, TextTemplateExt):
+			_, err = m.textTmpl.New(strings.TrimSuffix(name, TextTemplateExt)).Parse(string(byt))
+		}
+
+		if err != nil {
+			m.buildError = err
+			return m
+		}
+	}
+
+	return m
+}
+
+func (m *mailer) Retry(policy *retry.Policy) MailerBuilder {
+	m.retry = policy
+	return m
+}
+
+func (m *mailer) Build() (IMailer, error) {
+	if m.buildError != nil {
+		m.logger.Error(LogMessage("failure to parse the templates"), logging.ErrorField(m.buildError))
+		return nil, m.buildError
+	}
+
+	if m.provider == nil {
+		return nil, ErrorProviderRequired
+	}
+
+	if m.retry == nil {
+		m.retry = &retry.Policy{MaxAttempts: 1}
+	}
+
+	return m, nil
+}
+
+func (m *mailer) Send(ctx context.Context, msg *Message) error {
+	rendered, err := m.render(msg)
+	if err != nil {
+		m.logger.Error(LogMessage("failure to render the message"), logging.MessageField("template", msg.Template), logging.ErrorField(err))
+		return err
+	}
+
+	err = retry.Run(ctx, m.retry, func(ctx context.Context) error {
+		return m.provider.Send(ctx, rendered)
+	})
+	if err != nil {
+		m.logger.Error(LogMessage("failure to send the message"), zap.Strings("to", rendered.To), logging.ErrorField(err))
+		return err
+	}
+
+	m.logger.Debug(LogMessage("message sent"), zap.Strings("to", rendered.To))
+
+	return nil
+}
+
+func (m *mailer) TaskHandler() worker.TaskHandler {
+	return func(ctx context.Context, task *worker.Task) error {
+		msg := &Message{}
+		if err := task.Decode(msg); err != nil {
+			return err
+		}
+
+		return m.Send(ctx, msg)
+	}
+}
+
+// render validate the message and execute the templates, returning a copy
+func (m *mailer) render(msg *Message) (*Message, error) {
+	rendered := *msg
+	if rendered.From == "" {
+		rendered.From = m.from
+	}
+
+	if rendered.From == "" {
+		return nil, ErrorNoSender
+	}
+
+	if len(rendered.To)+len(rendered.Cc)+len(rendered.Bcc) == 0 {
+		return nil, ErrorNoRecipients
+	}
+
+	if rendered.Template != "" {
+		found := false
+
+		if m.htmlTmpl != nil && m.htmlTmpl.Lookup(rendered.Template) != nil {
+			buf := bytes.Buffer{}
+			if err := m.htmlTmpl.ExecuteTemplate(&buf, rendered.Template, rendered.Data); err != nil {
+				return nil, err
+			}
+			rendered.HTML = buf.String()
+			found = true
+		}
+
+		if m.textTmpl != nil && m.textTmpl.Lookup(rendered.Template) != nil {
+			buf := bytes.Buffer{}
+			if err := m.textTmpl.ExecuteTemplate(&buf, rendered.Template, rendered.Data); err != nil {
+				return nil, err
+			}
+			rendered.Text = buf.String()
+			found = true
+		}
+
+		if !found {
+			return nil, fmt.Errorf("%w: %s", ErrorTemplateNotFound, rendered.Template)
+		}
+	}
+
+	if rendered.Text == "" && rendered.HTML == "" {
+		return nil, ErrorEmptyBody
+	}
+
+	return &rendered, nil
+}
@@ -0,0 +1,306 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/retry"
+	"github.com/ralvescosta/gokit/worker"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MailerTestSuite struct {
+	suite.Suite
+
+	logger    *logging.MockLogger
+	provider  *MockProvider
+	templates fstest.MapFS
+}
+
+func TestMailerTestSuite(t *testing.T) {
+	suite.Run(t, new(MailerTestSuite))
+}
+
+func (s *MailerTestSuite) SetupTest() {
+	s.logger = logging.NewMockLogger()
+	s.provider = NewMockProvider()
+	s.templates = fstest.MapFS{
+		"templates/welcome.html": {Data: []byte(`<h1>Welcome {{.Name}}</h1>`)},
+		"templates/welcome.txt":  {Data: []byte(`Welcome {{.Name}}`)},
+	}
+}
+
+func (s *MailerTestSuite) newMailer() IMailer {
+	m, err := New(s.logger, s.provider).
+		From("no-reply@gokit.com").
+		Templates(s.templates, "templates/*").
+		Retry(&retry.Policy{MaxAttempts: 2, Backoff: retry.Constant(time.Millisecond)}).
+		Build()
+	s.NoError(err)
+
+	return m
+}
+
+func (s *MailerTestSuite) TestSendTemplate() {
+	s.provider.On("Send", mock.Anything, mock.MatchedBy(func(msg *Message) bool {
+		return msg.From == "no-reply@gokit.com" && msg.HTML == "<h1>Welcome &lt;b&gt;</h1>" && msg.Text == "Welcome <b>"
+	})).Return(nil)
+
+	err := s.newMailer().Send(context.Background(), &Message{
+		To:       []string{"user@gokit.com"},
+		Subject:  "welcome",
+		Template: "welcome",
+		Data:     map[string]string{"Name": "<b>"},
+	})
+
+	s.NoError(err)
+	s.provider.AssertExpectations(s.T())
+}
+
+func (s *MailerTestSuite) TestSendRetry() {
+	s.provider.On("Send", mock.Anything, mock.Anything).Return(errors.New("unavailable")).Once()
+	s.provider.On("Send", mock.Anything, mock.Anything).Return(nil).Once()
+
+	err := s.newMailer().Send(context.Background(), &Message{To: []string{"user@gokit.com"}, Text: "hello"})
+
+	s.NoError(err)
+	s.provider.AssertNumberOfCalls(s.T(), "Send", 2)
+}
+
+func (s *MailerTestSuite) TestSendErr() {
+	m := s.newMailer()
+
+	s.ErrorIs(m.Send(context.Background(), &Message{Text: "hello"}), ErrorNoRecipients)
+	s.ErrorIs(m.Send(context.Background(), &Message{To: []string{"user@gokit.com"}}), ErrorEmptyBody)
+	s.ErrorIs(m.Send(context.Background(), &Message{To: []string{"user@gokit.com"}, Template: "unknown"}), ErrorTemplateNotFound)
+
+	_, err := New(s.logger, nil).Build()
+	s.ErrorIs(err, ErrorProviderRequired)
+}
+
+func (s *MailerTestSuite) TestAsync() {
+	sent := make(chan *Message, 1)
+	s.provider.On("Send", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		sent <- args.Get(1).(*Message)
+	}).Return(nil)
+
+	m := s.newMailer()
+	w, err := worker.New(s.logger, worker.NewMemoryBroker(10)).Handle(SEND_TASK_TYPE, m.TaskHandler()).Build()
+	s.NoError(err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+
+	err = NewAsync(s.logger, m, w, nil).Send(ctx, &Message{
+		To:       []string{"user@gokit.com"},
+		Template: "welcome",
+		Data:     map[string]string{"Name": "async"},
+	})
+	s.NoError(err)
+
+	select {
+	case msg := <-sent:
+		s.Equal("Welcome async", msg.Text)
+	case <-time.After(2 * time.Second):
+		s.Fail("message was not delivered")
+	}
+}
+
+func (s *MailerTestSuite) TestBuildMIME() {
+	byt, err := buildMIME(&Message{
+		From:        "no-reply@gokit.com",
+		To:          []string{"user@gokit.com"},
+		Bcc:         []string{"hidden@gokit.com"},
+		Subject:     "Olá",
+		Text:        "hello",
+		HTML:        "<p>hello</p>",
+		Attachments: []Attachment{{Filename: "report.csv", Content: []byte("a,b")}},
+	})
+
+	s.NoError(err)
+	raw := string(byt)
+	s.Contains(raw, "Subject: =?utf-8?q?Ol=C3=A1?=")
+	s.Contains(raw, "multipart/alternative")
+	s.Contains(raw, `attachment; filename=report.csv`)
+	s.Contains(raw, "YSxi")
+	s.NotContains(raw, "hidden@gokit.com")
+}
+
+func (s *MailerTestSuite) TestBuildMIMEHeaderInjection() {
+	for _, msg := range []*Message{
+		{From: "no-reply@gokit.com", To: []string{"user@gokit.com\r\nBcc: other@gokit.com"}, Text: "hello"},
+		{From: "no-reply@gokit.com", To: []string{"user@gokit.com"}, Cc: []string{"cc@gokit.com\nX-Injected: 1"}, Text: "hello"},
+		{From: "no-reply@gokit.com", To: []string{"user@gokit.com"}, ReplyTo: "reply@gokit.com\rX-Injected: 1", Text: "hello"},
+		{From: "no-reply@gokit.com", To: []string{"not an address"}, Text: "hello"},
+	} {
+		_, err := buildMIME(msg)
+		s.ErrorIs(err, ErrorInvalidAddress)
+	}
+
+	_, err := buildMIME(&Message{From: "no-reply@gokit.com", To: []string{"user@gokit.com"}, Subject: "hi\r\nBcc: other@gokit.com", Text: "hello"})
+	s.ErrorIs(err, ErrorInvalidHeader)
+
+	byt, err := buildMIME(&Message{From: "Gokit Olá <no-reply@gokit.com>", To: []string{"user@gokit.com"}, Text: "hello"})
+	s.NoError(err)
+	s.Contains(string(byt), "From: =?utf-8?q?Gokit_Ol=C3=A1?= <no-reply@gokit.com>")
+
+	client := &mockSESClient{}
+	err = NewSESProvider(client).Send(context.Background(), &Message{
+		From:    "no-reply@gokit.com",
+		To:      []string{"user@gokit.com"},
+		Subject: "hi\nBcc: other@gokit.com",
+		Text:    "hello",
+	})
+	s.True(retry.IsPermanent(err))
+	client.AssertNotCalled(s.T(), "SendEmail", mock.Anything, mock.Anything)
+}
+
+func (s *MailerTestSuite) TestSMTPProvider() {
+	server := newFakeSMTPServer(s.T())
+
+	host, port, _ := net.SplitHostPort(server.addr)
+	portN, _ := strconv.Atoi(port)
+	provider := NewSMTPProvider(&SMTPConfig{Host: host, Port: portN})
+
+	err := provider.Send(context.Background(), &Message{
+		From: "no-reply@gokit.com",
+		To:   []string{"user@gokit.com"},
+		Text: "hello",
+	})
+	s.NoError(err)
+	s.Equal([]string{"<user@gokit.com>"}, server.recipients())
+	s.Contains(server.data(), "hello")
+
+	err = provider.Send(context.Background(), &Message{
+		From: "Gokit <no-reply@gokit.com>",
+		To:   []string{"User <named@gokit.com>"},
+		Text: "hello",
+	})
+	s.NoError(err)
+	s.Contains(server.recipients(), "<named@gokit.com>")
+
+	err = provider.Send(context.Background(), &Message{
+		From: "no-reply@gokit.com",
+		To:   []string{"reject@gokit.com"},
+		Text: "hello",
+	})
+	s.True(retry.IsPermanent(err))
+}
+
+type mockSESClient struct {
+	mock.Mock
+}
+
+func (m *mockSESClient) SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error) {
+	args := m.Called(ctx, params)
+	return &sesv2.SendEmailOutput{}, args.Error(0)
+}
+
+func (s *MailerTestSuite) TestSESProvider() {
+	client := &mockSESClient{}
+	client.On("SendEmail", mock.Anything, mock.MatchedBy(func(in *sesv2.SendEmailInput) bool {
+		return *in.FromEmailAddress == "no-reply@gokit.com" &&
+			in.Destination.ToAddresses[0] == "user@gokit.com" &&
+			strings.Contains(string(in.Content.Raw.Data), "hello")
+	})).Return(nil)
+
+	err := NewSESProvider(client).Send(context.Background(), &Message{
+		From: "no-reply@gokit.com",
+		To:   []string{"user@gokit.com"},
+		Text: "hello",
+	})
+
+	s.NoError(err)
+	client.AssertExpectations(s.T())
+}
+
+type fakeSMTPServer struct {
+	mu    sync.Mutex
+	addr  string
+	rcpts []string
+	body  string
+}
+
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	server := &fakeSMTPServer{addr: ln.Addr().String()}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(textproto.NewConn(conn))
+		}
+	}()
+
+	return server
+}
+
+func (f *fakeSMTPServer) serve(conn *textproto.Conn) {
+	defer conn.Close()
+
+	conn.PrintfLine("220 localhost")
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			conn.PrintfLine("250 localhost")
+		case "RCPT":
+			if strings.Contains(line, "reject@") {
+				conn.PrintfLine("550 mailbox unavailable")
+				continue
+			}
+			f.mu.Lock()
+			f.rcpts = append(f.rcpts, strings.TrimPrefix(line, "RCPT TO:"))
+			f.mu.Unlock()
+			conn.PrintfLine("250 OK")
+		case "DATA":
+			conn.PrintfLine("354 go ahead")
+			byt, _ := conn.ReadDotBytes()
+			f.mu.Lock()
+			f.body = string(byt)
+			f.mu.Unlock()
+			conn.PrintfLine("250 OK")
+		case "QUIT":
+			conn.PrintfLine("221 bye")
+			return
+		default:
+			conn.PrintfLine("250 OK")
+		}
+	}
+}
+
+func (f *fakeSMTPServer) recipients() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.rcpts
+}
+
+func (f *fakeSMTPServer) data() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.body
+}
@@ -0,0 +1,204 @@
+package mailer
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// buildMIME encode the message as multipart/mixed with a multipart/alternative body, Bcc is not written
+//
+// The addresses are parsed with net/mail and the header values with CR or LF are rejected, so the message fields can
+// not inject headers in both providers
+func buildMIME(msg *Message) ([]byte, error) {
+	from, err := parseAddress(msg.From)
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := parseAddressList(msg.To)
+	if err != nil {
+		return nil, err
+	}
+
+	cc, err := parseAddressList(msg.Cc)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := parseAddressList(msg.Bcc); err != nil {
+		return nil, err
+	}
+
+	replyTo := ""
+	if msg.ReplyTo != "" {
+		if replyTo, err = parseAddress(msg.ReplyTo); err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, fmt.Errorf("%w: Subject", ErrorInvalidHeader)
+	}
+
+	buf := &bytes.Buffer{}
+
+	header := func(key, value string) {
+		if value != "" {
+			fmt.Fprintf(buf, "%s: %s\r\n", key, value)
+		}
+	}
+
+	header("From", from)
+	header("To", strings.Join(to, ", "))
+	header("Cc", strings.Join(cc, ", "))
+	header("Reply-To", replyTo)
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", time.Now().Format(time.RFC1123Z))
+	header("MIME-Version", "1.0")
+
+	mixed := multipart.NewWriter(buf)
+	header("Content-Type", fmt.Sprintf("multipart/mixed; boundary=%q", mixed.Boundary()))
+	buf.WriteString("\r\n")
+
+	alternative := &bytes.Buffer{}
+	altWriter := multipart.NewWriter(alternative)
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		if part.body == "" {
+			continue
+		}
+
+		w, err := altWriter.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, err
+		}
+		qp.Close()
+	}
+	altWriter.Close()
+
+	w, err := mixed.CreatePart(textproto.MIMEHeader{
+		"Content-Type": {fmt.Sprintf("multipart/alternative; boundary=%q", altWriter.Boundary())},
+	})
+	if err != nil {
+		return nil, err
+	}
+	w.Write(alternative.Bytes())
+
+	for _, attachment := range msg.Attachments {
+		contentType := attachment.ContentType
+		if contentType == "" {
+			contentType = mime.TypeByExtension(filepath.Ext(attachment.Filename))
+		}
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		w, err := mixed.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Transfer-Encoding": {"base64"},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeBase64(w, attachment.Content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mixed.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// writeBase64 split the encoded content in lines of 76 chars as required by RFC 2045
+func writeBase64(w io.Writer, content []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(content)
+	for len(encoded) > 76 {
+		if _, err := w.Write([]byte(encoded[:76] + "\r\n")); err != nil {
+			return err
+		}
+		encoded = encoded[76:]
+	}
+
+	_, err := w.Write([]byte(encoded + "\r\n"))
+	return err
+}
+
+// parseAddress the RFC 5322 address formatted to the header, the display names are encoded
+func parseAddress(addr string) (string, error) {
+	parsed, err := envelopeAddress(addr)
+	if err != nil {
+		return "", err
+	}
+
+	return parsed.String(), nil
+}
+
+func parseAddressList(addrs []string) ([]string, error) {
+	parsed := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		p, err := parseAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+
+	return parsed, nil
+}
+
+func envelopeAddress(addr string) (*mail.Address, error) {
+	if strings.ContainsAny(addr, "\r\n") {
+		return nil, fmt.Errorf("%w: %q", ErrorInvalidAddress, addr)
+	}
+
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrorInvalidAddress, addr)
+	}
+
+	return parsed, nil
+}
+
+// recipients the envelope addresses without the display names
+func recipients(msg *Message) ([]string, error) {
+	all := append([]string{}, msg.To...)
+	all = append(all, msg.Cc...)
+	all = append(all, msg.Bcc...)
+
+	addrs := make([]string, 0, len(all))
+	for _, addr := range all {
+		parsed, err := envelopeAddress(addr)
+		if err != nil {
+			return nil, err
+		}
+		addrs = append(addrs, parsed.Address)
+	}
+
+	return addrs, nil
+}
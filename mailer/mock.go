@@ -0,0 +1,41 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/worker"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockMailer struct {
+	mock.Mock
+}
+
+func (m *MockMailer) Send(ctx context.Context, msg *Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+func (m *MockMailer) TaskHandler() worker.TaskHandler {
+	args := m.Called()
+
+	handler, _ := args.Get(0).(worker.TaskHandler)
+	return handler
+}
+
+func NewMockMailer() *MockMailer {
+	return new(MockMailer)
+}
+
+type MockProvider struct {
+	mock.Mock
+}
+
+func (m *MockProvider) Send(ctx context.Context, msg *Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+func NewMockProvider() *MockProvider {
+	return new(MockProvider)
+}
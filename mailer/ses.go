@@ -0,0 +1,36 @@
+package mailer
+
+import (
+	"context"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/aws/aws-sdk-go-v2/service/sesv2/types"
+	"github.com/ralvescosta/gokit/retry"
+)
+
+// NewSESProvider deliver the messages as raw MIME using the SES v2 API, the client is created with the aws config, e.g:
+// sesv2.NewFromConfig(awsCfg, func(o *sesv2.Options) { o.Region = cfg.SES_REGION })
+func NewSESProvider(client SESClient) Provider {
+	return &sesProvider{client: client}
+}
+
+func (p *sesProvider) Send(ctx context.Context, msg *Message) error {
+	body, err := buildMIME(msg)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+
+	_, err = p.client.SendEmail(ctx, &sesv2.SendEmailInput{
+		FromEmailAddress: &msg.From,
+		Destination: &types.Destination{
+			ToAddresses:  msg.To,
+			CcAddresses:  msg.Cc,
+			BccAddresses: msg.Bcc,
+		},
+		Content: &types.EmailContent{
+			Raw: &types.RawMessage{Data: body},
+		},
+	})
+
+	return err
+}
@@ -0,0 +1,115 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+
+	"github.com/ralvescosta/gokit/retry"
+)
+
+// NewSMTPProvider deliver the messages using SMTP with STARTTLS when the server supports it
+func NewSMTPProvider(cfg *SMTPConfig) Provider {
+	p := &smtpProvider{cfg: cfg}
+	if cfg.Username != "" {
+		p.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+
+	if cfg.Timeout == 0 {
+		cfg.Timeout = DefaultSMTPTimeout
+	}
+
+	return p
+}
+
+func (p *smtpProvider) Send(ctx context.Context, msg *Message) error {
+	body, err := buildMIME(msg)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.cfg.Timeout)
+	defer cancel()
+
+	addr := net.JoinHostPort(p.cfg.Host, strconv.Itoa(p.cfg.Port))
+	conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, p.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if err := p.send(client, msg, body); err != nil {
+		return permanentOnReject(err)
+	}
+
+	return client.Quit()
+}
+
+func (p *smtpProvider) send(client *smtp.Client, msg *Message, body []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: p.cfg.Host}); err != nil {
+			return err
+		}
+	}
+
+	if p.auth != nil {
+		if err := client.Auth(p.auth); err != nil {
+			return err
+		}
+	}
+
+	from, err := envelopeAddress(msg.From)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+
+	rcpts, err := recipients(msg)
+	if err != nil {
+		return retry.Permanent(err)
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return err
+	}
+
+	for _, rcpt := range rcpts {
+		if err := client.Rcpt(rcpt); err != nil {
+			return fmt.Errorf("rcpt %s: %w", rcpt, err)
+		}
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
+// permanentOnReject the 5xx replies are permanent failures, retrying would produce the same result
+func permanentOnReject(err error) error {
+	var protoErr *textproto.Error
+	if errors.As(err, &protoErr) && protoErr.Code >= 500 {
+		return retry.Permanent(err)
+	}
+
+	return err
+}
@@ -0,0 +1,104 @@
+package mailer
+
+import (
+	"context"
+	htmlTemplate "html/template"
+	"io/fs"
+	"net/smtp"
+	textTemplate "text/template"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/sesv2"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/retry"
+	"github.com/ralvescosta/gokit/worker"
+)
+
+type (
+	Attachment struct {
+		Filename    string `json:"filename"`
+		ContentType string `json:"content_type,omitempty"`
+		Content     []byte `json:"content"`
+	}
+
+	// Message when Template is set the Text and HTML bodies are rendered with Data
+	Message struct {
+		From        string       `json:"from,omitempty"`
+		To          []string     `json:"to"`
+		Cc          []string     `json:"cc,omitempty"`
+		Bcc         []string     `json:"bcc,omitempty"`
+		ReplyTo     string       `json:"reply_to,omitempty"`
+		Subject     string       `json:"subject"`
+		Text        string       `json:"text,omitempty"`
+		HTML        string       `json:"html,omitempty"`
+		Template    string       `json:"template,omitempty"`
+		Data        any          `json:"data,omitempty"`
+		Attachments []Attachment `json:"attachments,omitempty"`
+	}
+
+	// Provider deliver the message already rendered
+	Provider interface {
+		Send(ctx context.Context, msg *Message) error
+	}
+
+	// Enqueuer the worker.IWorker Enqueue used in the async mode
+	Enqueuer interface {
+		Enqueue(ctx context.Context, taskType string, payload any, opts *worker.EnqueueOpts) (string, error)
+	}
+
+	MailerBuilder interface {
+		// From the default sender used when the message has no From
+		From(addr string) MailerBuilder
+		// Templates parse the <name>.html and <name>.txt templates matched by the patterns
+		Templates(fsys fs.FS, patterns ...string) MailerBuilder
+		// Retry the delivery retry policy, default retry.DefaultPolicy
+		Retry(policy *retry.Policy) MailerBuilder
+		Build() (IMailer, error)
+	}
+
+	IMailer interface {
+		// Send render and deliver the message, in the async mode the message is enqueued
+		Send(ctx context.Context, msg *Message) error
+		// TaskHandler the worker handler to the SEND_TASK_TYPE tasks, it always delivers synchronously
+		TaskHandler() worker.TaskHandler
+	}
+
+	SMTPConfig struct {
+		Host     string
+		Port     int
+		Username string
+		Password string
+		Timeout  time.Duration
+	}
+
+	// SESClient the sesv2.Client methods used by the provider
+	SESClient interface {
+		SendEmail(ctx context.Context, params *sesv2.SendEmailInput, optFns ...func(*sesv2.Options)) (*sesv2.SendEmailOutput, error)
+	}
+
+	mailer struct {
+		logger     logging.ILogger
+		provider   Provider
+		from       string
+		htmlTmpl   *htmlTemplate.Template
+		textTmpl   *textTemplate.Template
+		retry      *retry.Policy
+		buildError error
+	}
+
+	asyncMailer struct {
+		logger   logging.ILogger
+		mailer   IMailer
+		enqueuer Enqueuer
+		opts     *worker.EnqueueOpts
+	}
+
+	smtpProvider struct {
+		cfg  *SMTPConfig
+		auth smtp.Auth
+	}
+
+	sesProvider struct {
+		client SESClient
+	}
+)
@@ -0,0 +1,37 @@
+package azservicebus
+
+import (
+	"errors"
+)
+
+const (
+	JsonContentType = "application/json"
+
+	DefaultMaxDeliveryCount = 10
+	DefaultMaxMessages      = 10
+
+	// DeadLetterReasonHandler the dead letter reason of the messages rejected by the handler
+	DeadLetterReasonHandler = "HandlerFailure"
+	// DeadLetterReasonUnmarshal the dead letter reason of the messages that could not be unmarshaled
+	DeadLetterReasonUnmarshal = "UnmarshalFailure"
+	// DeadLetterReasonMaxDelivery the dead letter reason of the messages delivered more than the max delivery count
+	DeadLetterReasonMaxDelivery = "MaxDeliveryCountExceeded"
+)
+
+var (
+	ErrorConnection         = errors.New("azure service bus connection failure")
+	ErrorConnectionString   = errors.New("azure service bus connection string is required")
+	ErrorRegisterDispatcher = errors.New("azure service bus unformatted dispatcher params")
+	ErrorDispatcherNotFound = errors.New("azure service bus there is no dispatcher to the message subject")
+	ErrorPublishEntity      = errors.New("azure service bus queue or topic is required")
+	// ErrorRetryable the handler returns it to abandon the message, so it is redelivered instead of dead-lettered
+	ErrorRetryable = errors.New("azure service bus retryable failure")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::azservicebus] " + msg
+}
+
+func LogMsgWithMessageId(msg, msgID string) string {
+	return LogMessage(msg + " - MessageId: " + msgID)
+}
@@ -0,0 +1,348 @@
+package azservicebus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	gokitErrors "github.com/ralvescosta/gokit/errors"
+	"github.com/ralvescosta/gokit/guid"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// New connect to the service bus with the AZURE_SERVICE_BUS_CONNECTION_STRING
+func New(cfg *env.Configs, logger logging.ILogger, connect Connector) (IServiceBusMessaging, error) {
+	if cfg.AZURE_SERVICE_BUS_CONNECTION_STRING == "" {
+		return nil, ErrorConnectionString
+	}
+
+	logger.Debug(LogMessage("connecting to azure service bus..."))
+	client, err := connect(cfg.AZURE_SERVICE_BUS_CONNECTION_STRING)
+	if err != nil {
+		logger.Error(LogMessage("failure to connect to the service bus"), logging.ErrorField(err))
+		return nil, ErrorConnection
+	}
+	logger.Debug(LogMessage("connected to azure service bus"))
+
+	m := &ServiceBusMessaging{
+		logger:           logger,
+		config:           cfg,
+		client:           client,
+		senders:          map[string]Sender{},
+		maxDeliveryCount: DefaultMaxDeliveryCount,
+		maxMessages:      DefaultMaxMessages,
+	}
+
+	if cfg.AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT > 0 {
+		m.maxDeliveryCount = uint32(cfg.AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT)
+	}
+
+	if cfg.AZURE_SERVICE_BUS_MAX_MESSAGES > 0 {
+		m.maxMessages = cfg.AZURE_SERVICE_BUS_MAX_MESSAGES
+	}
+
+	return m, nil
+}
+
+func (m *ServiceBusMessaging) Publisher(queueOrTopic string, msg any, opts *PublishOpts) error {
+	message, sender, err := m.newMessage(queueOrTopic, msg, opts)
+	if err != nil {
+		return err
+	}
+
+	return sender.SendMessage(context.Background(), message)
+}
+
+func (m *ServiceBusMessaging) Schedule(queueOrTopic string, msg any, at time.Time, opts *PublishOpts) (int64, error) {
+	message, sender, err := m.newMessage(queueOrTopic, msg, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	return sender.ScheduleMessage(context.Background(), message, at)
+}
+
+func (m *ServiceBusMessaging) CancelScheduled(queueOrTopic string, sequenceNumber int64) error {
+	sender, err := m.sender(queueOrTopic)
+	if err != nil {
+		return err
+	}
+
+	return sender.CancelScheduledMessage(context.Background(), sequenceNumber)
+}
+
+func (m *ServiceBusMessaging) newMessage(queueOrTopic string, msg any, opts *PublishOpts) (*Message, Sender, error) {
+	byt, err := json.Marshal(msg)
+	if err != nil {
+		m.logger.Error(LogMessage("publisher marshal"), logging.ErrorField(err))
+		return nil, nil, err
+	}
+
+	sender, err := m.sender(queueOrTopic)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if opts == nil {
+		opts = &PublishOpts{}
+	}
+
+	message := &Message{
+		MessageID:     opts.MessageId,
+		Body:          byt,
+		ContentType:   JsonContentType,
+		Subject:       opts.Type,
+		SessionID:     opts.SessionID,
+		CorrelationID: opts.CorrelationID,
+		TimeToLive:    opts.TimeToLive,
+		Properties:    opts.Properties,
+	}
+
+	if message.MessageID == "" {
+		message.MessageID = guid.NewRequestID()
+	}
+
+	if message.Subject == "" {
+		message.Subject = fmt.Sprintf("%T", msg)
+	}
+
+	return message, sender, nil
+}
+
+// sender the senders are created once to each queue or topic
+func (m *ServiceBusMessaging) sender(queueOrTopic string) (Sender, error) {
+	if queueOrTopic == "" {
+		return nil, ErrorPublishEntity
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if sender, ok := m.senders[queueOrTopic]; ok {
+		return sender, nil
+	}
+
+	sender, err := m.client.NewSender(queueOrTopic)
+	if err != nil {
+		m.logger.Error(LogMessage(fmt.Sprintf("failure to create the sender to %s", queueOrTopic)), logging.ErrorField(err))
+		return nil, err
+	}
+
+	m.senders[queueOrTopic] = sender
+	return sender, nil
+}
+
+func (m *ServiceBusMessaging) RegisterDispatcher(source *Source, handler ConsumerHandler, t any) error {
+	if source == nil || handler == nil || t == nil || reflect.TypeOf(t).Kind() != reflect.Ptr {
+		return ErrorRegisterDispatcher
+	}
+
+	if (source.Queue == "") == (source.Topic == "") || (source.Topic != "" && source.Subscription == "") {
+		return ErrorRegisterDispatcher
+	}
+
+	m.dispatchers = append(m.dispatchers, &Dispatcher{
+		Source:        source,
+		MsgType:       fmt.Sprintf("%T", t),
+		ReflectedType: reflect.TypeOf(t).Elem(),
+		Handler:       handler,
+	})
+
+	return nil
+}
+
+func (m *ServiceBusMessaging) Consume(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sources := map[Source][]*Dispatcher{}
+	for _, d := range m.dispatchers {
+		sources[*d.Source] = append(sources[*d.Source], d)
+	}
+
+	errs := make(chan error, len(sources))
+	wg := sync.WaitGroup{}
+
+	for source, dispatchers := range sources {
+		wg.Add(1)
+
+		go func(source Source, dispatchers []*Dispatcher) {
+			defer wg.Done()
+
+			var err error
+			if source.Sessions {
+				err = m.consumeSessions(ctx, &source, dispatchers)
+			} else {
+				err = m.consume(ctx, &source, dispatchers)
+			}
+
+			if err != nil && ctx.Err() == nil {
+				errs <- err
+				cancel()
+			}
+		}(source, dispatchers)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+func (m *ServiceBusMessaging) consume(ctx context.Context, source *Source, dispatchers []*Dispatcher) error {
+	receiver, err := m.client.NewReceiver(entity(source), source.Subscription)
+	if err != nil {
+		m.logger.Error(LogMessage(fmt.Sprintf("failure to create the receiver to %s", entity(source))), logging.ErrorField(err))
+		return err
+	}
+	defer receiver.Close(context.Background())
+
+	for ctx.Err() == nil {
+		if _, err := m.receive(ctx, receiver, dispatchers); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// consumeSessions lock one session at time and handle its messages in order, the session is released when it has no more messages
+func (m *ServiceBusMessaging) consumeSessions(ctx context.Context, source *Source, dispatchers []*Dispatcher) error {
+	for ctx.Err() == nil {
+		receiver, err := m.client.AcceptNextSession(ctx, entity(source), source.Subscription)
+		if err != nil {
+			m.logger.Error(LogMessage(fmt.Sprintf("failure to accept the next session of %s", entity(source))), logging.ErrorField(err))
+			return err
+		}
+
+		for ctx.Err() == nil {
+			received, err := m.receive(ctx, receiver, dispatchers)
+			if err != nil {
+				_ = receiver.Close(context.Background())
+				return err
+			}
+
+			if received == 0 {
+				break
+			}
+		}
+
+		_ = receiver.Close(context.Background())
+	}
+
+	return nil
+}
+
+func (m *ServiceBusMessaging) receive(ctx context.Context, receiver Receiver, dispatchers []*Dispatcher) (int, error) {
+	messages, err := receiver.ReceiveMessages(ctx, m.maxMessages)
+	if err != nil {
+		if ctx.Err() != nil {
+			return 0, nil
+		}
+
+		m.logger.Error(LogMessage("failure to receive the messages"), logging.ErrorField(err))
+		return 0, err
+	}
+
+	for _, msg := range messages {
+		m.handle(ctx, receiver, dispatchers, msg)
+	}
+
+	return len(messages), nil
+}
+
+func (m *ServiceBusMessaging) handle(ctx context.Context, receiver Receiver, dispatchers []*Dispatcher, msg *ReceivedMessage) {
+	d := dispatcher(dispatchers, msg.Subject)
+	if d == nil {
+		m.logger.Warn(LogMsgWithMessageId("there is no dispatcher to the message, sending to dead letter", msg.MessageID))
+		m.settle(receiver.DeadLetterMessage(ctx, msg, DeadLetterReasonHandler, ErrorDispatcherNotFound.Error()), msg)
+		return
+	}
+
+	if msg.DeliveryCount > m.maxDeliveryCount {
+		m.logger.Warn(LogMsgWithMessageId("message reprocessed to many times, sending to dead letter", msg.MessageID))
+		m.settle(receiver.DeadLetterMessage(ctx, msg, DeadLetterReasonMaxDelivery, ""), msg)
+		return
+	}
+
+	ptr := reflect.New(d.ReflectedType).Interface()
+	if err := json.Unmarshal(msg.Body, ptr); err != nil {
+		m.logger.Error(LogMsgWithMessageId("unmarshal error", msg.MessageID))
+		m.settle(receiver.DeadLetterMessage(ctx, msg, DeadLetterReasonUnmarshal, err.Error()), msg)
+		return
+	}
+
+	err := d.Handler(ptr, &DeliveryMetadata{
+		MessageId:      msg.MessageID,
+		Type:           msg.Subject,
+		SequenceNumber: msg.SequenceNumber,
+		DeliveryCount:  msg.DeliveryCount,
+		SessionID:      msg.SessionID,
+		CorrelationID:  msg.CorrelationID,
+		Properties:     msg.Properties,
+		Ctx:            ctx,
+	})
+
+	switch {
+	case err == nil:
+		m.settle(receiver.CompleteMessage(ctx, msg), msg)
+	case isRetryable(err):
+		m.logger.Warn(LogMsgWithMessageId("retryable failure, abandoning the message", msg.MessageID))
+		m.settle(receiver.AbandonMessage(ctx, msg), msg)
+	default:
+		m.logger.Error(LogMsgWithMessageId("failure to handle the message, sending to dead letter", msg.MessageID), logging.ErrorField(err))
+		m.settle(receiver.DeadLetterMessage(ctx, msg, DeadLetterReasonHandler, err.Error()), msg)
+	}
+}
+
+// settle the lock expires when the settlement fails, so the message is redelivered
+func (m *ServiceBusMessaging) settle(err error, msg *ReceivedMessage) {
+	if err != nil {
+		m.logger.Error(LogMsgWithMessageId("failure to settle the message", msg.MessageID), logging.ErrorField(err))
+	}
+}
+
+func (m *ServiceBusMessaging) Close(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for name, sender := range m.senders {
+		if err := sender.Close(ctx); err != nil {
+			m.logger.Warn(LogMessage(fmt.Sprintf("failure to close the sender to %s", name)), logging.ErrorField(err))
+		}
+	}
+
+	return m.client.Close(ctx)
+}
+
+func entity(source *Source) string {
+	if source.Queue != "" {
+		return source.Queue
+	}
+
+	return source.Topic
+}
+
+func dispatcher(dispatchers []*Dispatcher, subject string) *Dispatcher {
+	for _, d := range dispatchers {
+		if d.MsgType == subject {
+			return d
+		}
+	}
+
+	if len(dispatchers) == 1 {
+		return dispatchers[0]
+	}
+
+	return nil
+}
+
+// isRetryable check the ErrorRetryable sentinel and the gokit errors retry decision
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrorRetryable) || gokitErrors.Decision(err) == gokitErrors.RETRY_DECISION
+}
@@ -0,0 +1,147 @@
+package azservicebus
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type ServiceBusTestSuite struct {
+	suite.Suite
+
+	client    *MockServiceBusClient
+	sender    *MockSender
+	receiver  *MockReceiver
+	messaging IServiceBusMessaging
+	ctx       context.Context
+	cancel    context.CancelFunc
+}
+
+type order struct {
+	ID int `json:"id"`
+}
+
+func TestServiceBusTestSuite(t *testing.T) {
+	suite.Run(t, new(ServiceBusTestSuite))
+}
+
+func (s *ServiceBusTestSuite) SetupTest() {
+	s.client = NewMockServiceBusClient()
+	s.sender = NewMockSender()
+	s.receiver = NewMockReceiver()
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	cfg := &env.Configs{AZURE_SERVICE_BUS_CONNECTION_STRING: "Endpoint=sb://ns/", AZURE_SERVICE_BUS_MAX_DELIVERY_COUNT: 3}
+	s.messaging, _ = New(cfg, logging.NewMockLogger(), func(connectionString string) (ServiceBusClient, error) {
+		return s.client, nil
+	})
+}
+
+func (s *ServiceBusTestSuite) receive(messages ...*ReceivedMessage) {
+	s.receiver.On("ReceiveMessages", mock.Anything, DefaultMaxMessages).Return(messages, nil).Once()
+	s.receiver.On("ReceiveMessages", mock.Anything, DefaultMaxMessages).Run(func(args mock.Arguments) { s.cancel() }).Return(nil, context.Canceled)
+	s.receiver.On("Close", mock.Anything).Return(nil)
+}
+
+func (s *ServiceBusTestSuite) TestNew() {
+	_, err := New(&env.Configs{}, logging.NewMockLogger(), nil)
+	s.ErrorIs(err, ErrorConnectionString)
+
+	_, err = New(&env.Configs{AZURE_SERVICE_BUS_CONNECTION_STRING: "invalid"}, logging.NewMockLogger(), func(string) (ServiceBusClient, error) {
+		return nil, errors.New("connection failure")
+	})
+	s.ErrorIs(err, ErrorConnection)
+}
+
+func (s *ServiceBusTestSuite) TestPublisherAndSchedule() {
+	at := time.Now().Add(time.Hour)
+
+	s.client.On("NewSender", "orders").Return(s.sender, nil).Once()
+	s.sender.On("SendMessage", mock.Anything, mock.MatchedBy(func(msg *Message) bool {
+		return msg.Subject == "*azservicebus.order" && msg.SessionID == "customer-1" && string(msg.Body) == `{"id":1}` && msg.MessageID != ""
+	})).Return(nil)
+	s.sender.On("ScheduleMessage", mock.Anything, mock.Anything, at).Return(int64(42), nil)
+	s.sender.On("CancelScheduledMessage", mock.Anything, int64(42)).Return(nil)
+
+	s.NoError(s.messaging.Publisher("orders", &order{ID: 1}, &PublishOpts{SessionID: "customer-1"}))
+
+	seq, err := s.messaging.Schedule("orders", &order{ID: 1}, at, nil)
+	s.NoError(err)
+	s.Equal(int64(42), seq)
+
+	s.NoError(s.messaging.CancelScheduled("orders", seq))
+	s.ErrorIs(s.messaging.Publisher("", &order{}, nil), ErrorPublishEntity)
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *ServiceBusTestSuite) TestRegisterDispatcher() {
+	handler := func(msg any, metadata *DeliveryMetadata) error { return nil }
+
+	s.ErrorIs(s.messaging.RegisterDispatcher(nil, handler, &order{}), ErrorRegisterDispatcher)
+	s.ErrorIs(s.messaging.RegisterDispatcher(&Source{Topic: "orders"}, handler, &order{}), ErrorRegisterDispatcher)
+	s.ErrorIs(s.messaging.RegisterDispatcher(&Source{Queue: "orders"}, handler, order{}), ErrorRegisterDispatcher)
+	s.NoError(s.messaging.RegisterDispatcher(&Source{Topic: "orders", Subscription: "billing"}, handler, &order{}))
+}
+
+func (s *ServiceBusTestSuite) TestConsumeSettlement() {
+	completed := &ReceivedMessage{Message: Message{MessageID: "1", Body: []byte(`{"id":1}`)}, DeliveryCount: 1}
+	retried := &ReceivedMessage{Message: Message{MessageID: "2", Body: []byte(`{"id":2}`)}, DeliveryCount: 1}
+	failed := &ReceivedMessage{Message: Message{MessageID: "3", Body: []byte(`{"id":3}`)}, DeliveryCount: 1}
+	invalid := &ReceivedMessage{Message: Message{MessageID: "4", Body: []byte(`invalid`)}, DeliveryCount: 1}
+	exceeded := &ReceivedMessage{Message: Message{MessageID: "5", Body: []byte(`{"id":5}`)}, DeliveryCount: 4}
+
+	s.client.On("NewReceiver", "orders", "billing").Return(s.receiver, nil)
+	s.receive(completed, retried, failed, invalid, exceeded)
+	s.receiver.On("CompleteMessage", mock.Anything, completed).Return(nil).Once()
+	s.receiver.On("AbandonMessage", mock.Anything, retried).Return(nil).Once()
+	s.receiver.On("DeadLetterMessage", mock.Anything, failed, DeadLetterReasonHandler, "invalid order").Return(nil).Once()
+	s.receiver.On("DeadLetterMessage", mock.Anything, invalid, DeadLetterReasonUnmarshal, mock.Anything).Return(nil).Once()
+	s.receiver.On("DeadLetterMessage", mock.Anything, exceeded, DeadLetterReasonMaxDelivery, "").Return(nil).Once()
+
+	handled := []int{}
+	err := s.messaging.RegisterDispatcher(&Source{Topic: "orders", Subscription: "billing"}, func(msg any, metadata *DeliveryMetadata) error {
+		o := msg.(*order)
+		handled = append(handled, o.ID)
+
+		switch o.ID {
+		case 2:
+			return ErrorRetryable
+		case 3:
+			return errors.New("invalid order")
+		}
+		return nil
+	}, &order{})
+	s.NoError(err)
+
+	s.NoError(s.messaging.Consume(s.ctx))
+	s.Equal([]int{1, 2, 3}, handled)
+	s.receiver.AssertExpectations(s.T())
+}
+
+func (s *ServiceBusTestSuite) TestConsumeSessions() {
+	session := NewMockReceiver()
+	msg := &ReceivedMessage{Message: Message{MessageID: "1", Body: []byte(`{"id":1}`), SessionID: "customer-1"}, DeliveryCount: 1}
+
+	s.client.On("AcceptNextSession", mock.Anything, "orders", "").Return(session, nil).Once()
+	s.client.On("AcceptNextSession", mock.Anything, "orders", "").Run(func(args mock.Arguments) { s.cancel() }).Return(nil, context.Canceled)
+	session.On("ReceiveMessages", mock.Anything, DefaultMaxMessages).Return([]*ReceivedMessage{msg}, nil).Once()
+	session.On("ReceiveMessages", mock.Anything, DefaultMaxMessages).Return(nil, nil).Once()
+	session.On("CompleteMessage", mock.Anything, msg).Return(nil).Once()
+	session.On("Close", mock.Anything).Return(nil).Once()
+
+	sessionID := ""
+	s.NoError(s.messaging.RegisterDispatcher(&Source{Queue: "orders", Sessions: true}, func(msg any, metadata *DeliveryMetadata) error {
+		sessionID = metadata.SessionID
+		return nil
+	}, &order{}))
+
+	s.NoError(s.messaging.Consume(s.ctx))
+	s.Equal("customer-1", sessionID)
+	session.AssertExpectations(s.T())
+}
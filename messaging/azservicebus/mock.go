@@ -0,0 +1,116 @@
+package azservicebus
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockServiceBusClient struct {
+		mock.Mock
+	}
+
+	MockSender struct {
+		mock.Mock
+	}
+
+	MockReceiver struct {
+		mock.Mock
+	}
+)
+
+func (m *MockServiceBusClient) NewSender(queueOrTopic string) (Sender, error) {
+	args := m.Called(queueOrTopic)
+
+	sender, _ := args.Get(0).(Sender)
+	return sender, args.Error(1)
+}
+
+func (m *MockServiceBusClient) NewReceiver(queueOrTopic, subscription string) (Receiver, error) {
+	args := m.Called(queueOrTopic, subscription)
+
+	receiver, _ := args.Get(0).(Receiver)
+	return receiver, args.Error(1)
+}
+
+func (m *MockServiceBusClient) AcceptNextSession(ctx context.Context, queueOrTopic, subscription string) (Receiver, error) {
+	args := m.Called(ctx, queueOrTopic, subscription)
+
+	receiver, _ := args.Get(0).(Receiver)
+	return receiver, args.Error(1)
+}
+
+func (m *MockServiceBusClient) Close(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (m *MockSender) SendMessage(ctx context.Context, msg *Message) error {
+	args := m.Called(ctx, msg)
+
+	return args.Error(0)
+}
+
+func (m *MockSender) ScheduleMessage(ctx context.Context, msg *Message, at time.Time) (int64, error) {
+	args := m.Called(ctx, msg, at)
+
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSender) CancelScheduledMessage(ctx context.Context, sequenceNumber int64) error {
+	args := m.Called(ctx, sequenceNumber)
+
+	return args.Error(0)
+}
+
+func (m *MockSender) Close(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (m *MockReceiver) ReceiveMessages(ctx context.Context, max int) ([]*ReceivedMessage, error) {
+	args := m.Called(ctx, max)
+
+	messages, _ := args.Get(0).([]*ReceivedMessage)
+	return messages, args.Error(1)
+}
+
+func (m *MockReceiver) CompleteMessage(ctx context.Context, msg *ReceivedMessage) error {
+	args := m.Called(ctx, msg)
+
+	return args.Error(0)
+}
+
+func (m *MockReceiver) AbandonMessage(ctx context.Context, msg *ReceivedMessage) error {
+	args := m.Called(ctx, msg)
+
+	return args.Error(0)
+}
+
+func (m *MockReceiver) DeadLetterMessage(ctx context.Context, msg *ReceivedMessage, reason, description string) error {
+	args := m.Called(ctx, msg, reason, description)
+
+	return args.Error(0)
+}
+
+func (m *MockReceiver) Close(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func NewMockServiceBusClient() *MockServiceBusClient {
+	return new(MockServiceBusClient)
+}
+
+func NewMockSender() *MockSender {
+	return new(MockSender)
+}
+
+func NewMockReceiver() *MockReceiver {
+	return new(MockReceiver)
+}
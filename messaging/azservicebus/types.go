@@ -0,0 +1,138 @@
+package azservicebus
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// Message the service bus message sent
+	Message struct {
+		MessageID     string
+		Body          []byte
+		ContentType   string
+		Subject       string
+		SessionID     string
+		CorrelationID string
+		TimeToLive    time.Duration
+		Properties    map[string]any
+	}
+
+	// ReceivedMessage the service bus message received in peek-lock mode
+	ReceivedMessage struct {
+		Message
+		SequenceNumber int64
+		DeliveryCount  uint32
+		EnqueuedTime   time.Time
+	}
+
+	// Sender is an abstraction over the sender of the azure sdk to improve unit tests
+	Sender interface {
+		SendMessage(ctx context.Context, msg *Message) error
+		// ScheduleMessage returns the sequence number used to cancel the scheduled message
+		ScheduleMessage(ctx context.Context, msg *Message, at time.Time) (int64, error)
+		CancelScheduledMessage(ctx context.Context, sequenceNumber int64) error
+		Close(ctx context.Context) error
+	}
+
+	// Receiver is an abstraction over the peek-lock receiver of the azure sdk to improve unit tests
+	Receiver interface {
+		ReceiveMessages(ctx context.Context, max int) ([]*ReceivedMessage, error)
+		CompleteMessage(ctx context.Context, msg *ReceivedMessage) error
+		AbandonMessage(ctx context.Context, msg *ReceivedMessage) error
+		DeadLetterMessage(ctx context.Context, msg *ReceivedMessage, reason, description string) error
+		Close(ctx context.Context) error
+	}
+
+	// ServiceBusClient is an abstraction over the client of the azure sdk to improve unit tests.
+	// The subscription is empty to the queues
+	ServiceBusClient interface {
+		NewSender(queueOrTopic string) (Sender, error)
+		NewReceiver(queueOrTopic, subscription string) (Receiver, error)
+		// AcceptNextSession lock the next session with messages, it blocks until a session is available or ctx is done
+		AcceptNextSession(ctx context.Context, queueOrTopic, subscription string) (Receiver, error)
+		Close(ctx context.Context) error
+	}
+
+	// Connector create the client with the AZURE_SERVICE_BUS_CONNECTION_STRING
+	Connector = func(connectionString string) (ServiceBusClient, error)
+
+	// Source the queue or the topic subscription consumed by the dispatcher
+	Source struct {
+		Queue        string
+		Topic        string
+		Subscription string
+		// Sessions the entity requires sessions, the messages of each session are handled in order
+		Sessions bool
+	}
+
+	PublishOpts struct {
+		// Type the message subject, the default is the msg go type
+		Type          string
+		MessageId     string
+		SessionID     string
+		CorrelationID string
+		TimeToLive    time.Duration
+		Properties    map[string]any
+	}
+
+	// DeliveryMetadata service bus message received
+	DeliveryMetadata struct {
+		MessageId      string
+		Type           string
+		SequenceNumber int64
+		DeliveryCount  uint32
+		SessionID      string
+		CorrelationID  string
+		Properties     map[string]any
+		Ctx            context.Context
+	}
+
+	// ConsumerHandler the message is completed when the handler returns nil, abandoned when it returns a retryable error and dead-lettered otherwise
+	ConsumerHandler = func(msg any, metadata *DeliveryMetadata) error
+
+	// Dispatcher struct to register an message handler
+	Dispatcher struct {
+		Source        *Source
+		MsgType       string
+		ReflectedType reflect.Type
+		Handler       ConsumerHandler
+	}
+
+	IServiceBusMessaging interface {
+		// Publisher send the json of msg to the queue or topic
+		Publisher(queueOrTopic string, msg any, opts *PublishOpts) error
+
+		// Schedule send the message to be enqueued at the time, the sequence number cancel the scheduled message
+		Schedule(queueOrTopic string, msg any, at time.Time, opts *PublishOpts) (int64, error)
+
+		// CancelScheduled cancel a message scheduled with Schedule
+		CancelScheduled(queueOrTopic string, sequenceNumber int64) error
+
+		// RegisterDispatcher add the handler to the source messages, the message subject selects the dispatcher when the source has more than one
+		RegisterDispatcher(source *Source, handler ConsumerHandler, t any) error
+
+		// Consume receive the messages of each source in a goroutine until ctx is done or a receiver fails
+		Consume(ctx context.Context) error
+
+		Close(ctx context.Context) error
+	}
+
+	// ServiceBusMessaging is the implementation for IServiceBusMessaging
+	ServiceBusMessaging struct {
+		Err              error
+		logger           logging.ILogger
+		config           *env.Configs
+		client           ServiceBusClient
+		dispatchers      []*Dispatcher
+		senders          map[string]Sender
+		maxDeliveryCount uint32
+		maxMessages      int
+		mu               sync.Mutex
+	}
+)
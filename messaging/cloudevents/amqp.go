@@ -0,0 +1,84 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/streadway/amqp"
+)
+
+// ToAMQP encode the event as an AMQP publishing, the event type and id are also set in the AMQP properties
+func ToAMQP(evt *Event, mode Mode) (amqp.Publishing, error) {
+	if err := evt.Validate(); err != nil {
+		return amqp.Publishing{}, err
+	}
+
+	pub := amqp.Publishing{
+		Type:      evt.Type,
+		MessageId: evt.ID,
+		Headers:   amqp.Table{},
+	}
+
+	if mode == STRUCTURED_MODE {
+		byt, err := json.Marshal(evt)
+		if err != nil {
+			return pub, err
+		}
+
+		pub.ContentType = StructuredContentType
+		pub.Body = byt
+		return pub, nil
+	}
+
+	for k, v := range evt.Attributes() {
+		pub.Headers[AMQPHeaderPrefix+k] = v
+	}
+
+	pub.ContentType = evt.DataContentType
+	pub.Body = evt.Data
+
+	return pub, nil
+}
+
+// IsAMQPCloudEvent returns true when the delivery is a structured or binary cloudevent
+func IsAMQPCloudEvent(delivery *amqp.Delivery) bool {
+	if strings.HasPrefix(delivery.ContentType, StructuredContentType) {
+		return true
+	}
+
+	_, ok := delivery.Headers[AMQPHeaderPrefix+"specversion"]
+	return ok
+}
+
+// FromAMQP decode the event from a structured or binary delivery
+func FromAMQP(delivery *amqp.Delivery) (*Event, error) {
+	if !IsAMQPCloudEvent(delivery) {
+		return nil, ErrorNotCloudEvent
+	}
+
+	evt := &Event{}
+
+	if strings.HasPrefix(delivery.ContentType, StructuredContentType) {
+		if err := json.Unmarshal(delivery.Body, evt); err != nil {
+			return nil, err
+		}
+
+		return evt, nil
+	}
+
+	for k, v := range delivery.Headers {
+		if !strings.HasPrefix(k, AMQPHeaderPrefix) {
+			continue
+		}
+
+		if err := evt.SetAttribute(strings.TrimPrefix(k, AMQPHeaderPrefix), fmt.Sprint(v)); err != nil {
+			return nil, err
+		}
+	}
+
+	evt.DataContentType = delivery.ContentType
+	evt.Data = delivery.Body
+
+	return evt, evt.Validate()
+}
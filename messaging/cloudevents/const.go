@@ -0,0 +1,35 @@
+package cloudevents
+
+import "errors"
+
+const (
+	// STRUCTURED_MODE the whole event is encoded in the message body
+	STRUCTURED_MODE Mode = 0
+	// BINARY_MODE the attributes are transported as headers and the body has only the data
+	BINARY_MODE Mode = 1
+
+	SpecVersion           = "1.0"
+	StructuredContentType = "application/cloudevents+json"
+	JsonContentType       = "application/json"
+
+	AMQPHeaderPrefix  = "cloudEvents:"
+	KafkaHeaderPrefix = "ce_"
+	HTTPHeaderPrefix  = "Ce-"
+
+	KafkaContentTypeHeader = "content-type"
+	HTTPContentTypeHeader  = "Content-Type"
+)
+
+var (
+	ErrorMissingAttributes = errors.New("cloudevents id, source, specversion and type attributes are required")
+	ErrorNotCloudEvent     = errors.New("message is not a cloudevent")
+	ErrorSpecVersion       = errors.New("unsupported cloudevents specversion")
+
+	// requiredAttributes attributes that all events must have
+	requiredAttributes = []string{"id", "source", "specversion", "type"}
+	// contextAttributes attributes defined by the spec, any other attribute is an extension
+	contextAttributes = map[string]bool{
+		"id": true, "source": true, "specversion": true, "type": true, "subject": true,
+		"time": true, "datacontenttype": true, "dataschema": true, "data": true, "data_base64": true,
+	}
+)
@@ -0,0 +1,65 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/textproto"
+	"strings"
+)
+
+// ToHTTP set the event headers and returns the body, works for requests and responses
+func ToHTTP(header http.Header, evt *Event, mode Mode) ([]byte, error) {
+	if err := evt.Validate(); err != nil {
+		return nil, err
+	}
+
+	if mode == STRUCTURED_MODE {
+		header.Set(HTTPContentTypeHeader, StructuredContentType)
+		return json.Marshal(evt)
+	}
+
+	for k, v := range evt.Attributes() {
+		header.Set(HTTPHeaderPrefix+k, v)
+	}
+
+	if evt.DataContentType != "" {
+		header.Set(HTTPContentTypeHeader, evt.DataContentType)
+	}
+
+	return evt.Data, nil
+}
+
+// FromHTTP decode the event from a structured or binary http message
+func FromHTTP(header http.Header, body []byte) (*Event, error) {
+	contentType := header.Get(HTTPContentTypeHeader)
+
+	if strings.HasPrefix(contentType, StructuredContentType) {
+		evt := &Event{}
+		if err := json.Unmarshal(body, evt); err != nil {
+			return nil, err
+		}
+
+		return evt, nil
+	}
+
+	if header.Get(HTTPHeaderPrefix+"Specversion") == "" {
+		return nil, ErrorNotCloudEvent
+	}
+
+	evt := &Event{}
+	for k := range header {
+		canonical := textproto.CanonicalMIMEHeaderKey(k)
+		if !strings.HasPrefix(canonical, HTTPHeaderPrefix) {
+			continue
+		}
+
+		if err := evt.SetAttribute(strings.TrimPrefix(canonical, HTTPHeaderPrefix), header.Get(k)); err != nil {
+			return nil, err
+		}
+	}
+
+	evt.DataContentType = contentType
+	evt.Data = body
+
+	return evt, evt.Validate()
+}
@@ -0,0 +1,187 @@
+package cloudevents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// NewEvent create an event with a new id and the current time, the data is JSON encoded
+func NewEvent(source, typ string, data any) (*Event, error) {
+	evt := &Event{
+		ID:          uuid.NewString(),
+		Source:      source,
+		SpecVersion: SpecVersion,
+		Type:        typ,
+		Time:        time.Now().UTC(),
+		Extensions:  map[string]string{},
+	}
+
+	if data == nil {
+		return evt, nil
+	}
+
+	return evt, evt.SetData(data)
+}
+
+// SetData JSON encode the data
+func (e *Event) SetData(data any) error {
+	byt, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	e.Data = byt
+	e.DataContentType = JsonContentType
+
+	return nil
+}
+
+// DataAs JSON decode the event data into v
+func (e *Event) DataAs(v any) error {
+	return json.Unmarshal(e.Data, v)
+}
+
+// Validate check the required attributes
+func (e *Event) Validate() error {
+	if e.ID == "" || e.Source == "" || e.SpecVersion == "" || e.Type == "" {
+		return ErrorMissingAttributes
+	}
+
+	if e.SpecVersion != SpecVersion {
+		return ErrorSpecVersion
+	}
+
+	return nil
+}
+
+// Attributes returns the event context attributes and extensions as strings, used by the binary mode
+func (e *Event) Attributes() map[string]string {
+	attrs := map[string]string{
+		"id":          e.ID,
+		"source":      e.Source,
+		"specversion": e.SpecVersion,
+		"type":        e.Type,
+	}
+
+	if e.Subject != "" {
+		attrs["subject"] = e.Subject
+	}
+
+	if !e.Time.IsZero() {
+		attrs["time"] = e.Time.Format(time.RFC3339Nano)
+	}
+
+	if e.DataSchema != "" {
+		attrs["dataschema"] = e.DataSchema
+	}
+
+	for k, v := range e.Extensions {
+		attrs[k] = v
+	}
+
+	return attrs
+}
+
+// SetAttribute set a context attribute or extension from its string representation
+func (e *Event) SetAttribute(name, value string) error {
+	switch strings.ToLower(name) {
+	case "id":
+		e.ID = value
+	case "source":
+		e.Source = value
+	case "specversion":
+		e.SpecVersion = value
+	case "type":
+		e.Type = value
+	case "subject":
+		e.Subject = value
+	case "datacontenttype":
+		e.DataContentType = value
+	case "dataschema":
+		e.DataSchema = value
+	case "time":
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			return err
+		}
+		e.Time = t
+	default:
+		if e.Extensions == nil {
+			e.Extensions = map[string]string{}
+		}
+		e.Extensions[strings.ToLower(name)] = value
+	}
+
+	return nil
+}
+
+// MarshalJSON structured mode JSON format
+func (e *Event) MarshalJSON() ([]byte, error) {
+	obj := map[string]any{}
+	for k, v := range e.Attributes() {
+		obj[k] = v
+	}
+
+	if e.DataContentType != "" {
+		obj["datacontenttype"] = e.DataContentType
+	}
+
+	if len(e.Data) > 0 {
+		if isJson(e.DataContentType) {
+			obj["data"] = json.RawMessage(e.Data)
+		} else {
+			obj["data_base64"] = base64.StdEncoding.EncodeToString(e.Data)
+		}
+	}
+
+	return json.Marshal(obj)
+}
+
+// UnmarshalJSON structured mode JSON format
+func (e *Event) UnmarshalJSON(byt []byte) error {
+	obj := map[string]json.RawMessage{}
+	if err := json.Unmarshal(byt, &obj); err != nil {
+		return err
+	}
+
+	for k, raw := range obj {
+		switch k {
+		case "data":
+			e.Data = raw
+		case "data_base64":
+			var encoded string
+			if err := json.Unmarshal(raw, &encoded); err != nil {
+				return err
+			}
+
+			data, err := base64.StdEncoding.DecodeString(encoded)
+			if err != nil {
+				return err
+			}
+			e.Data = data
+		default:
+			var value any
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return err
+			}
+
+			if str, ok := value.(string); ok {
+				if err := e.SetAttribute(k, str); err != nil {
+					return err
+				}
+			} else if !contextAttributes[k] {
+				e.SetAttribute(k, string(raw))
+			}
+		}
+	}
+
+	return e.Validate()
+}
+
+func isJson(contentType string) bool {
+	return contentType == "" || strings.HasPrefix(contentType, JsonContentType) || strings.HasSuffix(strings.Split(contentType, ";")[0], "+json")
+}
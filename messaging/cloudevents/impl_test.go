@@ -0,0 +1,103 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/suite"
+)
+
+type CloudEventsTestSuite struct {
+	suite.Suite
+
+	evt *Event
+}
+
+type userCreated struct {
+	Name string `json:"name"`
+}
+
+func TestCloudEventsTestSuite(t *testing.T) {
+	suite.Run(t, new(CloudEventsTestSuite))
+}
+
+func (s *CloudEventsTestSuite) SetupTest() {
+	s.evt, _ = NewEvent("/users", "com.example.user.created", &userCreated{Name: "name"})
+	s.evt.Extensions["traceparent"] = "00-trace"
+}
+
+func (s *CloudEventsTestSuite) assertEvent(evt *Event, err error) {
+	s.NoError(err)
+	s.Equal(s.evt.ID, evt.ID)
+	s.Equal(s.evt.Type, evt.Type)
+	s.Equal(s.evt.Source, evt.Source)
+	s.Equal("00-trace", evt.Extensions["traceparent"])
+	s.True(s.evt.Time.Equal(evt.Time))
+
+	data := &userCreated{}
+	s.NoError(evt.DataAs(data))
+	s.Equal("name", data.Name)
+}
+
+func (s *CloudEventsTestSuite) TestJSON() {
+	byt, err := json.Marshal(s.evt)
+	s.NoError(err)
+
+	evt := &Event{}
+	s.assertEvent(evt, json.Unmarshal(byt, evt))
+
+	s.Error(json.Unmarshal([]byte(`{"id":"id"}`), &Event{}))
+}
+
+func (s *CloudEventsTestSuite) TestBinaryData() {
+	s.evt.Data = []byte{0x1, 0x2}
+	s.evt.DataContentType = "application/octet-stream"
+
+	byt, _ := json.Marshal(s.evt)
+	s.Contains(string(byt), "data_base64")
+
+	evt := &Event{}
+	s.NoError(json.Unmarshal(byt, evt))
+	s.Equal([]byte{0x1, 0x2}, evt.Data)
+}
+
+func (s *CloudEventsTestSuite) TestAMQP() {
+	for _, mode := range []Mode{STRUCTURED_MODE, BINARY_MODE} {
+		pub, err := ToAMQP(s.evt, mode)
+		s.NoError(err)
+		s.Equal(s.evt.Type, pub.Type)
+
+		evt, err := FromAMQP(&amqp.Delivery{Headers: pub.Headers, ContentType: pub.ContentType, Body: pub.Body})
+		s.assertEvent(evt, err)
+	}
+
+	_, err := FromAMQP(&amqp.Delivery{ContentType: JsonContentType})
+	s.ErrorIs(err, ErrorNotCloudEvent)
+}
+
+func (s *CloudEventsTestSuite) TestKafka() {
+	for _, mode := range []Mode{STRUCTURED_MODE, BINARY_MODE} {
+		headers, value, err := ToKafka(s.evt, mode)
+		s.NoError(err)
+
+		s.assertEvent(FromKafka(headers, value))
+	}
+
+	_, err := FromKafka(nil, []byte("{}"))
+	s.ErrorIs(err, ErrorNotCloudEvent)
+}
+
+func (s *CloudEventsTestSuite) TestHTTP() {
+	for _, mode := range []Mode{STRUCTURED_MODE, BINARY_MODE} {
+		header := http.Header{}
+		body, err := ToHTTP(header, s.evt, mode)
+		s.NoError(err)
+
+		s.assertEvent(FromHTTP(header, body))
+	}
+
+	_, err := ToHTTP(http.Header{}, &Event{}, BINARY_MODE)
+	s.ErrorIs(err, ErrorMissingAttributes)
+}
@@ -0,0 +1,73 @@
+package cloudevents
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// ToKafka encode the event as kafka record headers and value
+func ToKafka(evt *Event, mode Mode) ([]KafkaHeader, []byte, error) {
+	if err := evt.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	if mode == STRUCTURED_MODE {
+		byt, err := json.Marshal(evt)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return []KafkaHeader{{Key: KafkaContentTypeHeader, Value: []byte(StructuredContentType)}}, byt, nil
+	}
+
+	headers := []KafkaHeader{}
+	for k, v := range evt.Attributes() {
+		headers = append(headers, KafkaHeader{Key: KafkaHeaderPrefix + k, Value: []byte(v)})
+	}
+
+	if evt.DataContentType != "" {
+		headers = append(headers, KafkaHeader{Key: KafkaContentTypeHeader, Value: []byte(evt.DataContentType)})
+	}
+
+	return headers, evt.Data, nil
+}
+
+// FromKafka decode the event from a structured or binary kafka record
+func FromKafka(headers []KafkaHeader, value []byte) (*Event, error) {
+	evt := &Event{}
+	isBinary := false
+	contentType := ""
+
+	for _, h := range headers {
+		if h.Key == KafkaContentTypeHeader {
+			contentType = string(h.Value)
+			continue
+		}
+
+		if !strings.HasPrefix(h.Key, KafkaHeaderPrefix) {
+			continue
+		}
+
+		isBinary = true
+		if err := evt.SetAttribute(strings.TrimPrefix(h.Key, KafkaHeaderPrefix), string(h.Value)); err != nil {
+			return nil, err
+		}
+	}
+
+	if strings.HasPrefix(contentType, StructuredContentType) {
+		if err := json.Unmarshal(value, evt); err != nil {
+			return nil, err
+		}
+
+		return evt, nil
+	}
+
+	if !isBinary {
+		return nil, ErrorNotCloudEvent
+	}
+
+	evt.DataContentType = contentType
+	evt.Data = value
+
+	return evt, evt.Validate()
+}
@@ -0,0 +1,29 @@
+package cloudevents
+
+import "time"
+
+type (
+	// Mode CloudEvents content mode
+	Mode int8
+
+	// Event CloudEvents 1.0 event
+	Event struct {
+		ID              string
+		Source          string
+		SpecVersion     string
+		Type            string
+		Subject         string
+		Time            time.Time
+		DataContentType string
+		DataSchema      string
+		Data            []byte
+		// Extensions extra attributes, e.g. traceparent
+		Extensions map[string]string
+	}
+
+	// KafkaHeader kafka record header, avoid coupling this package to a kafka client
+	KafkaHeader struct {
+		Key   string
+		Value []byte
+	}
+)
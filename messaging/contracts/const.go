@@ -0,0 +1,26 @@
+package contracts
+
+import (
+	"errors"
+)
+
+const (
+	STRING_KIND  Kind = "string"
+	NUMBER_KIND  Kind = "number"
+	BOOLEAN_KIND Kind = "boolean"
+	OBJECT_KIND  Kind = "object"
+	ARRAY_KIND   Kind = "array"
+	NULL_KIND    Kind = "null"
+
+	// UpdateContractsEnvKey when "true" the producer assertions rewrite the contracts instead of comparing them
+	UpdateContractsEnvKey = "GOKIT_UPDATE_CONTRACTS"
+
+	DefaultContractsDir = "testdata/contracts"
+
+	contractFileExt = ".json"
+)
+
+var (
+	ErrorContractNotFound = errors.New("contracts there is no contract recorded to the message type")
+	ErrorMessageType      = errors.New("contracts the message type is required")
+)
@@ -0,0 +1,204 @@
+package contracts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func NewRecorder() *Recorder {
+	return &Recorder{contracts: map[string]*Contract{}}
+}
+
+// Record the contract of the message, the latest message of each type is kept
+func (r *Recorder) Record(msgType string, msg any) error {
+	contract, err := NewContract(msgType, msg)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.contracts[msgType] = contract
+	return nil
+}
+
+// Contracts the recorded contracts sorted by type
+func (r *Recorder) Contracts() []*Contract {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	contracts := make([]*Contract, 0, len(r.contracts))
+	for _, c := range r.contracts {
+		contracts = append(contracts, c)
+	}
+
+	sort.Slice(contracts, func(i, j int) bool { return contracts[i].Type < contracts[j].Type })
+	return contracts
+}
+
+// Save write one file per contract in the dir
+func (r *Recorder) Save(dir string) error {
+	for _, c := range r.Contracts() {
+		if err := Save(dir, c); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewRecordingMessaging record the contract of each message published and delegate to the messaging, the messaging could be a mock
+//
+// The type header is the PublishOpts.Type or the message type name, the same default used by the rabbitmq publisher
+func NewRecordingMessaging(messaging rabbitmq.IRabbitMQMessaging, recorder *Recorder) rabbitmq.IRabbitMQMessaging {
+	return &recordingMessaging{messaging, recorder}
+}
+
+func (m *recordingMessaging) Publisher(exchange, routingKey string, msg any, opts *rabbitmq.PublishOpts) error {
+	msgType := fmt.Sprintf("%T", msg)
+	if opts != nil && opts.Type != "" {
+		msgType = opts.Type
+	}
+
+	if err := m.recorder.Record(msgType, msg); err != nil {
+		return err
+	}
+
+	return m.IRabbitMQMessaging.Publisher(exchange, routingKey, msg, opts)
+}
+
+func NewContract(msgType string, msg any) (*Contract, error) {
+	if msgType == "" {
+		return nil, ErrorMessageType
+	}
+
+	sample, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	shape, err := ShapeOf(sample)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Contract{Type: msgType, Shape: shape, Sample: sample}, nil
+}
+
+func Save(dir string, contract *Contract) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	byt, err := json.MarshalIndent(contract, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(contractPath(dir, contract.Type), append(byt, '\n'), 0o644)
+}
+
+func Load(dir, msgType string) (*Contract, error) {
+	byt, err := os.ReadFile(contractPath(dir, msgType))
+	if os.IsNotExist(err) {
+		return nil, ErrorContractNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	contract := &Contract{}
+	if err := json.Unmarshal(byt, contract); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func contractPath(dir, msgType string) string {
+	name := strings.Trim(unsafeFileChars.ReplaceAllString(msgType, "_"), "_")
+	return filepath.Join(dir, name+contractFileExt)
+}
+
+// AssertProducer fails when the message is incompatible with the contract in the dir, removed fields or changed kinds.
+//
+// The contract is written when it does not exist or when GOKIT_UPDATE_CONTRACTS=true, the contracts should be committed so the consumers verify them
+func AssertProducer(t TestingT, dir, msgType string, msg any) bool {
+	t.Helper()
+
+	current, err := NewContract(msgType, msg)
+	if err != nil {
+		t.Errorf("contracts: failure to record %s: %v", msgType, err)
+		return false
+	}
+
+	recorded, err := Load(dir, msgType)
+	if err == ErrorContractNotFound || os.Getenv(UpdateContractsEnvKey) == "true" {
+		return save(t, dir, current)
+	}
+
+	if err != nil {
+		t.Errorf("contracts: failure to load %s: %v", msgType, err)
+		return false
+	}
+
+	if breaking := Compare(recorded.Shape, current.Shape); len(breaking) > 0 {
+		t.Errorf("contracts: %s has incompatible changes:\n\t%s", msgType, strings.Join(breaking, "\n\t"))
+		return false
+	}
+
+	return true
+}
+
+// AssertConsumer fails when the consumer type, the same pointer used in RegisterDispatcher, can not decode the recorded message
+// or when it requires fields the producer does not send
+func AssertConsumer(t TestingT, dir, msgType string, target any) bool {
+	t.Helper()
+
+	contract, err := Load(dir, msgType)
+	if err != nil {
+		t.Errorf("contracts: failure to load %s: %v", msgType, err)
+		return false
+	}
+
+	typ := reflect.TypeOf(target)
+	if typ == nil || typ.Kind() != reflect.Pointer {
+		t.Errorf("contracts: the consumer type of %s must be a pointer", msgType)
+		return false
+	}
+
+	if err := json.Unmarshal(contract.Sample, reflect.New(typ.Elem()).Interface()); err != nil {
+		t.Errorf("contracts: %T can not decode %s: %v", target, msgType, err)
+		return false
+	}
+
+	if missing := MissingFields(contract.Shape, RequiredFields(typ)); len(missing) > 0 {
+		t.Errorf("contracts: %T requires fields %s does not send: %s", target, msgType, strings.Join(missing, ", "))
+		return false
+	}
+
+	return true
+}
+
+func save(t TestingT, dir string, contract *Contract) bool {
+	t.Helper()
+
+	if err := Save(dir, contract); err != nil {
+		t.Errorf("contracts: failure to save %s: %v", contract.Type, err)
+		return false
+	}
+
+	return true
+}
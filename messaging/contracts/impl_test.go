@@ -0,0 +1,170 @@
+package contracts
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	ContractsTestSuite struct {
+		suite.Suite
+
+		dir string
+	}
+
+	fakeT struct {
+		errors []string
+	}
+
+	item struct {
+		ID  string  `json:"id"`
+		Qty float64 `json:"qty"`
+	}
+
+	orderCreatedV1 struct {
+		ID        string    `json:"id"`
+		Total     float64   `json:"total"`
+		Items     []item    `json:"items"`
+		CreatedAt time.Time `json:"createdAt"`
+		Note      *string   `json:"note,omitempty"`
+	}
+
+	orderCreatedV2 struct {
+		ID        string    `json:"id"`
+		Total     string    `json:"total"`
+		Items     []item    `json:"items"`
+		CreatedAt time.Time `json:"createdAt"`
+	}
+
+	orderCreatedV3 struct {
+		ID       string `json:"id"`
+		Total    float64
+		Items    []item `json:"items"`
+		Customer string `json:"customer"`
+	}
+
+	orderCreatedConsumer struct {
+		ID    string  `json:"id"`
+		Total float64 `json:"total"`
+		Items []struct {
+			ID string `json:"id"`
+		} `json:"items"`
+		Coupon string `json:"coupon,omitempty"`
+	}
+)
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Errorf(format string, args ...any) {
+	f.errors = append(f.errors, fmt.Sprintf(format, args...))
+}
+
+func TestContractsTestSuite(t *testing.T) {
+	suite.Run(t, new(ContractsTestSuite))
+}
+
+func (s *ContractsTestSuite) SetupTest() {
+	s.dir = s.T().TempDir()
+}
+
+func (s *ContractsTestSuite) order() *orderCreatedV1 {
+	return &orderCreatedV1{ID: "1", Total: 10, Items: []item{{ID: "a", Qty: 1}}, CreatedAt: time.Now()}
+}
+
+func (s *ContractsTestSuite) TestShapeOf() {
+	shape, err := ShapeOf([]byte(`{"id":"1","items":[{"id":"a","qty":1},null],"tags":[],"meta":null,"ok":true}`))
+
+	s.NoError(err)
+	s.Equal(Shape{
+		"id":          STRING_KIND,
+		"items":       ARRAY_KIND,
+		"items[]":     OBJECT_KIND,
+		"items[].id":  STRING_KIND,
+		"items[].qty": NUMBER_KIND,
+		"tags":        ARRAY_KIND,
+		"meta":        NULL_KIND,
+		"ok":          BOOLEAN_KIND,
+	}, shape)
+}
+
+func (s *ContractsTestSuite) TestProducerCompatibility() {
+	t := &fakeT{}
+
+	s.True(AssertProducer(t, s.dir, "order.created", s.order()))
+	s.True(AssertProducer(t, s.dir, "order.created", s.order()))
+	s.Empty(t.errors)
+
+	s.False(AssertProducer(t, s.dir, "order.created", &orderCreatedV2{ID: "1", Total: "10"}))
+	s.Len(t.errors, 1)
+	s.Contains(t.errors[0], "field total changed from number to string")
+
+	s.False(AssertProducer(t, s.dir, "order.created", &orderCreatedV3{ID: "1", Items: []item{{ID: "a"}}, Customer: "c"}))
+	s.Contains(t.errors[1], "field createdAt was removed")
+	s.Contains(t.errors[1], "field total was removed")
+}
+
+func (s *ContractsTestSuite) TestUpdateContracts() {
+	t := &fakeT{}
+	s.True(AssertProducer(t, s.dir, "order.created", s.order()))
+
+	os.Setenv(UpdateContractsEnvKey, "true")
+	defer os.Unsetenv(UpdateContractsEnvKey)
+
+	s.True(AssertProducer(t, s.dir, "order.created", &orderCreatedV2{ID: "1", Total: "10"}))
+	s.Empty(t.errors)
+
+	contract, err := Load(s.dir, "order.created")
+	s.NoError(err)
+	s.Equal(STRING_KIND, contract.Shape["total"])
+}
+
+func (s *ContractsTestSuite) TestConsumerCompatibility() {
+	t := &fakeT{}
+	s.True(AssertProducer(t, s.dir, "order.created", s.order()))
+
+	s.True(AssertConsumer(t, s.dir, "order.created", &orderCreatedConsumer{}))
+	s.Empty(t.errors)
+
+	s.False(AssertConsumer(t, s.dir, "order.created", &orderCreatedV2{}))
+	s.Contains(t.errors[0], "can not decode")
+
+	s.False(AssertConsumer(t, s.dir, "order.created", &orderCreatedV3{}))
+	s.Contains(t.errors[1], "customer")
+
+	s.False(AssertConsumer(t, s.dir, "order.updated", &orderCreatedConsumer{}))
+	s.False(AssertConsumer(t, s.dir, "order.created", orderCreatedConsumer{}))
+}
+
+func (s *ContractsTestSuite) TestRequiredFields() {
+	s.Equal([]string{"Total", "customer", "id", "items", "items[].id", "items[].qty"}, RequiredFields(reflect.TypeOf(&orderCreatedV3{})))
+	s.Empty(MissingFields(Shape{"id": STRING_KIND, "items": ARRAY_KIND, "Total": NUMBER_KIND, "customer": NULL_KIND}, RequiredFields(reflect.TypeOf(&orderCreatedV3{}))))
+}
+
+func (s *ContractsTestSuite) TestRecordingMessaging() {
+	messaging := rabbitmq.NewMockRabbitMQMessaging()
+	messaging.On("Publisher", "exchange", "key", mock.Anything, mock.Anything).Return(nil)
+
+	recorder := NewRecorder()
+	publisher := NewRecordingMessaging(messaging, recorder)
+
+	s.NoError(publisher.Publisher("exchange", "key", s.order(), nil))
+	s.NoError(publisher.Publisher("exchange", "key", &item{ID: "a"}, &rabbitmq.PublishOpts{Type: "item.added"}))
+	s.NoError(recorder.Save(s.dir))
+
+	contracts := recorder.Contracts()
+	s.Len(contracts, 2)
+	s.Equal("*contracts.orderCreatedV1", contracts[0].Type)
+	s.Equal("item.added", contracts[1].Type)
+
+	t := &fakeT{}
+	s.True(AssertConsumer(t, s.dir, "*contracts.orderCreatedV1", &orderCreatedConsumer{}))
+	s.True(AssertConsumer(t, s.dir, "item.added", &item{}))
+	messaging.AssertNumberOfCalls(s.T(), "Publisher", 2)
+}
@@ -0,0 +1,208 @@
+package contracts
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ShapeOf extract the field kinds of the JSON payload
+func ShapeOf(payload []byte) (Shape, error) {
+	var v any
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return nil, err
+	}
+
+	shape := Shape{}
+	walk(shape, "", v)
+
+	return shape, nil
+}
+
+func walk(shape Shape, path string, v any) {
+	switch value := v.(type) {
+	case map[string]any:
+		if path != "" {
+			shape[path] = OBJECT_KIND
+		}
+
+		for k, field := range value {
+			walk(shape, join(path, k), field)
+		}
+	case []any:
+		if path != "" {
+			shape[path] = ARRAY_KIND
+		}
+
+		for _, item := range value {
+			walk(shape, path+"[]", item)
+		}
+	default:
+		kind := kindOf(value)
+		// a null item does not override the kind found in the other items
+		if current, ok := shape[path]; ok && kind == NULL_KIND && current != NULL_KIND {
+			return
+		}
+
+		shape[path] = kind
+	}
+}
+
+func kindOf(v any) Kind {
+	switch v.(type) {
+	case string:
+		return STRING_KIND
+	case float64:
+		return NUMBER_KIND
+	case bool:
+		return BOOLEAN_KIND
+	default:
+		return NULL_KIND
+	}
+}
+
+func join(path, field string) string {
+	if path == "" {
+		return field
+	}
+
+	return path + "." + field
+}
+
+// Compare returns the breaking changes from the old to the new shape, removed fields and changed kinds.
+// Added fields and null values are compatible
+func Compare(old, new Shape) []string {
+	breaking := []string{}
+
+	for _, path := range sortedPaths(old) {
+		oldKind := old[path]
+
+		newKind, ok := new[path]
+		if !ok {
+			breaking = append(breaking, fmt.Sprintf("field %s was removed", path))
+			continue
+		}
+
+		if oldKind != newKind && oldKind != NULL_KIND && newKind != NULL_KIND {
+			breaking = append(breaking, fmt.Sprintf("field %s changed from %s to %s", path, oldKind, newKind))
+		}
+	}
+
+	return breaking
+}
+
+// RequiredFields the field paths the type decodes that are not omitempty
+func RequiredFields(t reflect.Type) []string {
+	fields := []string{}
+	requiredFields(t, "", &fields)
+	sort.Strings(fields)
+
+	return fields
+}
+
+var unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+var textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+
+func requiredFields(t reflect.Type, path string, fields *[]string) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if isCustomDecoded(t) {
+		return
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		if path != "" {
+			requiredFields(t.Elem(), path+"[]", fields)
+		}
+		return
+	case reflect.Struct:
+	default:
+		return
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		if field.Anonymous && name == "" {
+			requiredFields(field.Type, path, fields)
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fieldPath := join(path, name)
+		if !strings.Contains(opts, "omitempty") {
+			*fields = append(*fields, fieldPath)
+		}
+
+		requiredFields(field.Type, fieldPath, fields)
+	}
+}
+
+func isCustomDecoded(t reflect.Type) bool {
+	ptr := reflect.PointerTo(t)
+	return ptr.Implements(unmarshalerType) || ptr.Implements(textUnmarshalerType)
+}
+
+// MissingFields the required fields not provided by the shape, the fields inside null objects or empty arrays are not verified
+func MissingFields(shape Shape, required []string) []string {
+	missing := []string{}
+
+	for _, path := range required {
+		if _, ok := kindAt(shape, path); ok {
+			continue
+		}
+
+		parent := ""
+		if i := strings.LastIndex(path, "."); i >= 0 {
+			parent = path[:i]
+		}
+
+		if kind, _ := kindAt(shape, parent); parent == "" || kind == OBJECT_KIND {
+			missing = append(missing, path)
+		}
+	}
+
+	return missing
+}
+
+// kindAt json field names are matched case-insensitively like encoding/json does
+func kindAt(shape Shape, path string) (Kind, bool) {
+	if kind, ok := shape[path]; ok {
+		return kind, true
+	}
+
+	for p, kind := range shape {
+		if strings.EqualFold(p, path) {
+			return kind, true
+		}
+	}
+
+	return "", false
+}
+
+func sortedPaths(shape Shape) []string {
+	paths := make([]string, 0, len(shape))
+	for path := range shape {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	return paths
+}
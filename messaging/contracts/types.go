@@ -0,0 +1,42 @@
+package contracts
+
+import (
+	"encoding/json"
+	"sync"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+type (
+	// Kind the JSON type of a field
+	Kind string
+
+	// Shape the kind of each field path, nested fields are joined with "." and the array items are suffixed with "[]", e.g: items[].id
+	Shape map[string]Kind
+
+	// Contract the schema of a published message, identified by the type header
+	Contract struct {
+		Type  string `json:"type"`
+		Shape Shape  `json:"shape"`
+		// Sample the recorded payload, the consumers must be able to decode it
+		Sample json.RawMessage `json:"sample"`
+	}
+
+	// TestingT the subset of testing.TB used by the assertions
+	TestingT interface {
+		Helper()
+		Errorf(format string, args ...any)
+	}
+
+	// Recorder keep the contracts of the published messages
+	Recorder struct {
+		mu        sync.Mutex
+		contracts map[string]*Contract
+	}
+
+	// recordingMessaging record the messages published through the wrapped messaging
+	recordingMessaging struct {
+		rabbitmq.IRabbitMQMessaging
+		recorder *Recorder
+	}
+)
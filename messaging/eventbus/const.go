@@ -0,0 +1,18 @@
+package eventbus
+
+import "errors"
+
+const (
+	DefaultBufferSize = 100
+)
+
+var (
+	ErrorTopicRequired  = errors.New("topic is required")
+	ErrorHandlerPanic   = errors.New("event handler panicked")
+	ErrorBusClosed      = errors.New("event bus closed")
+	ErrorUnexpectedType = errors.New("unexpected message type for the topic")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::eventbus] " + msg
+}
@@ -0,0 +1,195 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+// New create an in process event bus
+func New(logger logging.ILogger) IEventBus {
+	return &EventBus{
+		logger:      logger,
+		subscribers: map[string][]*subscriber{},
+	}
+}
+
+func (b *EventBus) Subscribe(topic string, handler Handler, opts *SubscribeOpts) error {
+	if topic == "" {
+		return ErrorTopicRequired
+	}
+
+	if opts == nil {
+		opts = &SubscribeOpts{}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrorBusClosed
+	}
+
+	sub := &subscriber{topic: topic, handler: handler, async: opts.Async}
+
+	if opts.Async {
+		size := opts.Buffer
+		if size <= 0 {
+			size = DefaultBufferSize
+		}
+
+		sub.buffer = make(chan *envelope, size)
+		b.wg.Add(1)
+		go b.listen(sub)
+	}
+
+	b.subscribers[topic] = append(b.subscribers[topic], sub)
+	b.logger.Debug(LogMessage(fmt.Sprintf("subscriber registered to topic: %s", topic)))
+
+	return nil
+}
+
+// Publish the subscribers are dispatched from a snapshot taken under the lock, so the handlers can subscribe and publish
+// without deadlocking the bus
+func (b *EventBus) Publish(ctx context.Context, topic string, msg any) error {
+	b.mu.RLock()
+	if b.closed {
+		b.mu.RUnlock()
+		return ErrorBusClosed
+	}
+
+	subscribers := append([]*subscriber{}, b.subscribers[topic]...)
+	b.mu.RUnlock()
+
+	metadata := &rabbitmq.DeliveryMetadata{
+		MessageId: uuid.NewString(),
+		Type:      fmt.Sprintf("%T", msg),
+		Headers:   map[string]interface{}{"topic": topic},
+	}
+
+	var firstErr error
+	for _, sub := range subscribers {
+		if !sub.async {
+			if err := b.call(sub, msg, metadata); err != nil && firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if err := sub.send(ctx, &envelope{msg, metadata}); err != nil {
+			return err
+		}
+	}
+
+	return firstErr
+}
+
+func (b *EventBus) Shutdown(ctx context.Context) error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+
+	b.closed = true
+	subscribers := []*subscriber{}
+	for _, subs := range b.subscribers {
+		subscribers = append(subscribers, subs...)
+	}
+	b.mu.Unlock()
+
+	for _, sub := range subscribers {
+		if sub.async {
+			sub.close()
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		b.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		b.logger.Warn(LogMessage("shutdown timed out before the subscribers drain"))
+		return ctx.Err()
+	}
+}
+
+func (b *EventBus) listen(sub *subscriber) {
+	defer b.wg.Done()
+
+	for env := range sub.buffer {
+		b.call(sub, env.msg, env.metadata)
+	}
+}
+
+// send enqueue the envelope to the async subscriber buffer
+func (s *subscriber) send(ctx context.Context, env *envelope) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.closed {
+		return ErrorBusClosed
+	}
+
+	select {
+	case s.buffer <- env:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *subscriber) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	close(s.buffer)
+}
+
+// call execute the handler isolating panics, so one subscriber can not break the publisher or the other subscribers
+func (b *EventBus) call(sub *subscriber, msg any, metadata *rabbitmq.DeliveryMetadata) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			b.logger.Error(LogMessage("handler panicked"), logging.MessageField("topic", sub.topic), logging.MessageField("panic", fmt.Sprint(r)))
+			err = ErrorHandlerPanic
+		}
+	}()
+
+	err = sub.handler(msg, metadata)
+	if err != nil {
+		b.logger.Error(LogMessage("handler failure"), logging.MessageField("topic", sub.topic), logging.ErrorField(err))
+	}
+
+	return err
+}
+
+// NewTopic create a typed topic
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{Name: name}
+}
+
+// Publish typed publish
+func Publish[T any](ctx context.Context, bus IEventBus, topic Topic[T], msg *T) error {
+	return bus.Publish(ctx, topic.Name, msg)
+}
+
+// Subscribe typed subscribe, the handler receives the message already coerced to the topic type
+func Subscribe[T any](bus IEventBus, topic Topic[T], handler func(msg *T, metadata *rabbitmq.DeliveryMetadata) error, opts *SubscribeOpts) error {
+	return bus.Subscribe(topic.Name, func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		typed, ok := msg.(*T)
+		if !ok {
+			return ErrorUnexpectedType
+		}
+
+		return handler(typed, metadata)
+	}, opts)
+}
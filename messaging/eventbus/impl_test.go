@@ -0,0 +1,117 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/suite"
+)
+
+type EventBusTestSuite struct {
+	suite.Suite
+}
+
+type userCreated struct {
+	Name string
+}
+
+func TestEventBusTestSuite(t *testing.T) {
+	suite.Run(t, new(EventBusTestSuite))
+}
+
+func (s *EventBusTestSuite) TestSyncSubscriber() {
+	bus := New(logging.NewMockLogger())
+	topic := NewTopic[userCreated]("user.created")
+
+	var received *userCreated
+	s.NoError(Subscribe(bus, topic, func(msg *userCreated, metadata *rabbitmq.DeliveryMetadata) error {
+		received = msg
+		s.Equal("*eventbus.userCreated", metadata.Type)
+		return nil
+	}, nil))
+
+	s.NoError(Publish(context.Background(), bus, topic, &userCreated{Name: "name"}))
+	s.Equal("name", received.Name)
+}
+
+func (s *EventBusTestSuite) TestReentrantHandler() {
+	bus := New(logging.NewMockLogger())
+	var calls int32
+
+	bus.Subscribe("nested", func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}, nil)
+	bus.Subscribe("topic", func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		s.NoError(bus.Subscribe("other", func(msg any, metadata *rabbitmq.DeliveryMetadata) error { return nil }, nil))
+		return bus.Publish(context.Background(), "nested", msg)
+	}, nil)
+
+	done := make(chan error)
+	go func() { done <- bus.Publish(context.Background(), "topic", "msg") }()
+
+	select {
+	case err := <-done:
+		s.NoError(err)
+		s.Equal(int32(1), atomic.LoadInt32(&calls))
+	case <-time.After(time.Second):
+		s.Fail("publish deadlocked")
+	}
+}
+
+func (s *EventBusTestSuite) TestPanicIsolation() {
+	bus := New(logging.NewMockLogger())
+	calls := 0
+
+	bus.Subscribe("topic", func(msg any, metadata *rabbitmq.DeliveryMetadata) error { panic("boom") }, nil)
+	bus.Subscribe("topic", func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		calls++
+		return errors.New("some error")
+	}, nil)
+
+	err := bus.Publish(context.Background(), "topic", "msg")
+	s.ErrorIs(err, ErrorHandlerPanic)
+	s.Equal(1, calls)
+}
+
+func (s *EventBusTestSuite) TestAsyncSubscriber() {
+	bus := New(logging.NewMockLogger())
+	var calls int32
+
+	bus.Subscribe("topic", func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		atomic.AddInt32(&calls, 1)
+		return nil
+	}, &SubscribeOpts{Async: true, Buffer: 10})
+
+	for i := 0; i < 5; i++ {
+		s.NoError(bus.Publish(context.Background(), "topic", i))
+	}
+
+	s.NoError(bus.Shutdown(context.Background()))
+	s.Equal(int32(5), atomic.LoadInt32(&calls))
+	s.ErrorIs(bus.Publish(context.Background(), "topic", 1), ErrorBusClosed)
+}
+
+func (s *EventBusTestSuite) TestBufferFull() {
+	bus := New(logging.NewMockLogger())
+	block := make(chan struct{})
+
+	bus.Subscribe("topic", func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		<-block
+		return nil
+	}, &SubscribeOpts{Async: true, Buffer: 1})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	bus.Publish(context.Background(), "topic", 1)
+	bus.Publish(context.Background(), "topic", 2)
+	s.ErrorIs(bus.Publish(ctx, "topic", 3), context.Canceled)
+
+	close(block)
+}
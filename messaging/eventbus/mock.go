@@ -0,0 +1,33 @@
+package eventbus
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Subscribe(topic string, handler Handler, opts *SubscribeOpts) error {
+	args := m.Called(topic, handler, opts)
+
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, topic string, msg any) error {
+	args := m.Called(ctx, topic, msg)
+
+	return args.Error(0)
+}
+
+func (m *MockEventBus) Shutdown(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func NewMockEventBus() *MockEventBus {
+	return new(MockEventBus)
+}
@@ -0,0 +1,63 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+type (
+	// Handler same signature used by the messaging consumers, so the handlers could be shared
+	Handler = rabbitmq.ConsumerHandler
+
+	// Topic typed topic, guarantee at compile time that publishers and subscribers agree on the message type
+	Topic[T any] struct {
+		Name string
+	}
+
+	// SubscribeOpts subscriber configuration
+	SubscribeOpts struct {
+		// Async when true the handler runs in its own goroutine and the messages are buffered
+		Async bool
+		// Buffer the async subscriber buffer size
+		Buffer int
+	}
+
+	IEventBus interface {
+		// Subscribe register a handler to the topic
+		Subscribe(topic string, handler Handler, opts *SubscribeOpts) error
+		// Publish deliver the message to all the topic subscribers.
+		//
+		// Sync subscribers are called before Publish returns and its errors are returned,
+		// async subscribers receive the message through its buffer, Publish waits while the buffer is full until ctx is done
+		Publish(ctx context.Context, topic string, msg any) error
+		// Shutdown stop receiving messages and wait the async subscribers drain its buffers
+		Shutdown(ctx context.Context) error
+	}
+
+	envelope struct {
+		msg      any
+		metadata *rabbitmq.DeliveryMetadata
+	}
+
+	// subscriber the mu guards the async buffer, the senders hold the read lock so the Shutdown does not close the
+	// buffer while sending
+	subscriber struct {
+		topic   string
+		handler Handler
+		async   bool
+		mu      sync.RWMutex
+		closed  bool
+		buffer  chan *envelope
+	}
+
+	EventBus struct {
+		logger      logging.ILogger
+		mu          sync.RWMutex
+		subscribers map[string][]*subscriber
+		closed      bool
+		wg          sync.WaitGroup
+	}
+)
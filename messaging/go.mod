@@ -3,23 +3,42 @@ module github.com/ralvescosta/gokit/messaging
 go 1.18
 
 require (
-	github.com/google/uuid v1.3.0
+	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/alicebob/miniredis/v2 v2.22.0
+	github.com/google/uuid v1.6.0
+	github.com/redis/go-redis/v9 v9.0.2
 	github.com/streadway/amqp v1.0.0
 	github.com/stretchr/testify v1.8.0
+	go.opentelemetry.io/otel v1.28.0
+	go.opentelemetry.io/otel/metric v1.28.0
+	go.opentelemetry.io/otel/sdk v1.28.0
+	go.opentelemetry.io/otel/sdk/metric v1.28.0
+	go.opentelemetry.io/otel/trace v1.28.0
 	go.uber.org/zap v1.21.0
+	google.golang.org/protobuf v1.28.0
 )
 
 require (
-	github.com/ralvescosta/gokit/env v0.0.0-20220717193252-2f9449cd88d1
-	github.com/ralvescosta/gokit/logging v0.0.0-20220717193252-2f9449cd88d1
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/errors v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/guid v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/pool v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/requestid v0.0.0-20261016183433-cb393702ac94
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang/protobuf v1.5.2 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/ralvescosta/dotenv v1.0.4 // indirect
 	github.com/stretchr/objx v0.4.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.8.0 // indirect
+	golang.org/x/net v0.0.0-20220624214902-1bab6f366d9e // indirect
+	golang.org/x/sys v0.0.0-20220712014510-0a85c31ab51e // indirect
+	golang.org/x/text v0.3.7 // indirect
+	google.golang.org/genproto v0.0.0-20250603155806-513f23925822 // indirect
+	google.golang.org/grpc v1.65.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,39 @@
+package kafka
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// AUTO_COMMIT the offset is marked after the handler returns without error
+	AUTO_COMMIT CommitMode = 0
+	// MANUAL_COMMIT the handler marks the offsets calling MarkOffset, so it can delay the commit until its state is flushed
+	MANUAL_COMMIT CommitMode = 1
+
+	DefaultCommitInterval  = 5 * time.Second
+	DefaultCommitBatchSize = 100
+
+	DefaultLagInterval = 30 * time.Second
+
+	MeterName = "github.com/ralvescosta/gokit/messaging/kafka"
+
+	// ConsumerLagMetric gauge of the group lag, labeled by group, topic and partition
+	ConsumerLagMetric = "messaging.kafka.consumer.lag"
+)
+
+var (
+	ErrorConsumerGroup    = errors.New("kafka consumer group id and topics are required")
+	ErrorHandlerNotFound  = errors.New("kafka there is no handler registered to the topic")
+	ErrorRegisterHandler  = errors.New("kafka unformatted handler params")
+	ErrorConsumerIsClosed = errors.New("kafka consumer is closed")
+	ErrorProtoHandler     = errors.New("kafka there is no proto handler to the message type")
+	ErrorLagGroup         = errors.New("kafka lag evaluator group id is required")
+)
+
+// timeNow is replaced in the tests
+var timeNow = time.Now
+
+func LogMessage(msg string) string {
+	return "[gokit::kafka] " + msg
+}
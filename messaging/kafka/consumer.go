@@ -0,0 +1,258 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+func NewConsumer(logger logging.ILogger, client KafkaClient, opts *ConsumerOpts) (IKafkaConsumer, error) {
+	if opts == nil || opts.GroupID == "" || len(opts.Topics) == 0 {
+		return nil, ErrorConsumerGroup
+	}
+
+	if opts.CommitInterval <= 0 {
+		opts.CommitInterval = DefaultCommitInterval
+	}
+
+	if opts.CommitBatchSize <= 0 {
+		opts.CommitBatchSize = DefaultCommitBatchSize
+	}
+
+	return &KafkaConsumer{
+		logger:     logger,
+		client:     client,
+		opts:       opts,
+		handlers:   map[string]ConsumerHandler{},
+		assigned:   map[TopicPartition]bool{},
+		pending:    map[TopicPartition]int64{},
+		lastCommit: timeNow(),
+	}, nil
+}
+
+func (c *KafkaConsumer) RegisterHandler(topic string, handler ConsumerHandler) error {
+	if topic == "" || handler == nil {
+		return ErrorRegisterHandler
+	}
+
+	c.handlers[topic] = handler
+	return nil
+}
+
+func (c *KafkaConsumer) Consume(ctx context.Context) error {
+	if c.isClosed() {
+		return ErrorConsumerIsClosed
+	}
+
+	if err := c.client.Subscribe(c.opts.GroupID, c.opts.Topics); err != nil {
+		c.logger.Error(LogMessage("failure to subscribe"), logging.ErrorField(err))
+		return err
+	}
+
+	c.logger.Debug(LogMessage(fmt.Sprintf("consumer group %s started", c.opts.GroupID)))
+
+	for {
+		if ctx.Err() != nil || c.isClosed() {
+			return c.Commit()
+		}
+
+		evt, err := c.client.Poll(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return c.Commit()
+			}
+
+			c.logger.Error(LogMessage("failure to poll"), logging.ErrorField(err))
+			return err
+		}
+
+		if evt != nil {
+			if err := c.handleEvent(ctx, evt); err != nil {
+				return err
+			}
+		}
+
+		if c.shouldCommit() {
+			if err := c.Commit(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (c *KafkaConsumer) handleEvent(ctx context.Context, evt *Event) error {
+	if len(evt.Revoked) > 0 {
+		if err := c.revoke(ctx, evt.Revoked); err != nil {
+			return err
+		}
+	}
+
+	if len(evt.Assigned) > 0 {
+		if err := c.assign(ctx, evt.Assigned); err != nil {
+			return err
+		}
+	}
+
+	if evt.Message != nil {
+		c.handleMessage(ctx, evt.Message)
+	}
+
+	return nil
+}
+
+func (c *KafkaConsumer) assign(ctx context.Context, partitions []TopicPartition) error {
+	c.mu.Lock()
+	for _, tp := range partitions {
+		c.assigned[tp] = true
+	}
+	c.mu.Unlock()
+
+	c.logger.Debug(LogMessage(fmt.Sprintf("%d partitions assigned", len(partitions))))
+
+	if c.opts.OnPartitionsAssigned == nil {
+		return nil
+	}
+
+	if err := c.opts.OnPartitionsAssigned(ctx, partitions); err != nil {
+		c.logger.Error(LogMessage("partitions assigned hook failure"), logging.ErrorField(err))
+		return err
+	}
+
+	return nil
+}
+
+// revoke the hook flushes the consumer state before the offsets of the revoked partitions are committed,
+// then the partitions are removed so late marks do not override the offsets committed by the new owner
+func (c *KafkaConsumer) revoke(ctx context.Context, partitions []TopicPartition) error {
+	c.logger.Debug(LogMessage(fmt.Sprintf("%d partitions revoked", len(partitions))))
+
+	if c.opts.OnPartitionsRevoked != nil {
+		if err := c.opts.OnPartitionsRevoked(ctx, partitions); err != nil {
+			c.logger.Error(LogMessage("partitions revoked hook failure"), logging.ErrorField(err))
+			return err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	offsets := map[TopicPartition]int64{}
+	for _, tp := range partitions {
+		if offset, ok := c.pending[tp]; ok {
+			offsets[tp] = offset
+		}
+	}
+
+	if len(offsets) > 0 {
+		if err := c.client.CommitOffsets(offsets); err != nil {
+			c.logger.Error(LogMessage("failure to commit the revoked partitions"), logging.ErrorField(err))
+			return err
+		}
+	}
+
+	for _, tp := range partitions {
+		delete(c.assigned, tp)
+		delete(c.pending, tp)
+	}
+
+	return nil
+}
+
+func (c *KafkaConsumer) handleMessage(ctx context.Context, msg *Message) {
+	handler, ok := c.handlers[msg.Topic]
+	if !ok {
+		c.logger.Warn(LogMessage(fmt.Sprintf("there is no handler to the topic: %s", msg.Topic)))
+		return
+	}
+
+	if err := handler(ctx, msg); err != nil {
+		c.logger.Error(LogMessage(fmt.Sprintf("failure to handle the message %s[%d]@%d", msg.Topic, msg.Partition, msg.Offset)), logging.ErrorField(err))
+		return
+	}
+
+	if c.opts.CommitMode == AUTO_COMMIT {
+		c.MarkOffset(msg)
+	}
+}
+
+func (c *KafkaConsumer) MarkOffset(msg *Message) {
+	tp := TopicPartition{Topic: msg.Topic, Partition: msg.Partition}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.assigned[tp] {
+		c.logger.Warn(LogMessage(fmt.Sprintf("ignoring the offset of the partition %s[%d], it is not assigned", tp.Topic, tp.Partition)))
+		return
+	}
+
+	if next := msg.Offset + 1; next > c.pending[tp] {
+		c.pending[tp] = next
+	}
+
+	c.marked++
+}
+
+func (c *KafkaConsumer) shouldCommit() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.marked > 0 && (c.marked >= c.opts.CommitBatchSize || timeNow().Sub(c.lastCommit) >= c.opts.CommitInterval)
+}
+
+func (c *KafkaConsumer) Commit() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.pending) == 0 {
+		return nil
+	}
+
+	offsets := make(map[TopicPartition]int64, len(c.pending))
+	for tp, offset := range c.pending {
+		offsets[tp] = offset
+	}
+
+	if err := c.client.CommitOffsets(offsets); err != nil {
+		c.logger.Error(LogMessage("failure to commit the offsets"), logging.ErrorField(err))
+		return err
+	}
+
+	c.pending = map[TopicPartition]int64{}
+	c.marked = 0
+	c.lastCommit = timeNow()
+
+	return nil
+}
+
+func (c *KafkaConsumer) Assignment() []TopicPartition {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	partitions := make([]TopicPartition, 0, len(c.assigned))
+	for tp := range c.assigned {
+		partitions = append(partitions, tp)
+	}
+
+	return partitions
+}
+
+func (c *KafkaConsumer) Close() error {
+	if err := c.Commit(); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+
+	return c.client.Close()
+}
+
+func (c *KafkaConsumer) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
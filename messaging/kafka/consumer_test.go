@@ -0,0 +1,173 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type KafkaConsumerTestSuite struct {
+	suite.Suite
+
+	logger *logging.MockLogger
+	client *MockKafkaClient
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func TestKafkaConsumerTestSuite(t *testing.T) {
+	suite.Run(t, new(KafkaConsumerTestSuite))
+}
+
+func (s *KafkaConsumerTestSuite) SetupTest() {
+	s.logger = logging.NewMockLogger()
+	s.client = NewMockKafkaClient()
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	s.client.On("Subscribe", "group", []string{"orders"}).Return(nil)
+}
+
+func (s *KafkaConsumerTestSuite) TearDownTest() {
+	timeNow = time.Now
+}
+
+func (s *KafkaConsumerTestSuite) poll(events ...*Event) {
+	for _, evt := range events {
+		s.client.On("Poll", s.ctx).Return(evt, nil).Once()
+	}
+
+	s.client.On("Poll", s.ctx).Run(func(args mock.Arguments) { s.cancel() }).Return(nil, context.Canceled).Once()
+}
+
+func (s *KafkaConsumerTestSuite) TestNewConsumer() {
+	_, err := NewConsumer(s.logger, s.client, nil)
+	s.ErrorIs(err, ErrorConsumerGroup)
+
+	_, err = NewConsumer(s.logger, s.client, &ConsumerOpts{GroupID: "group"})
+	s.ErrorIs(err, ErrorConsumerGroup)
+
+	c, err := NewConsumer(s.logger, s.client, &ConsumerOpts{GroupID: "group", Topics: []string{"orders"}})
+	s.NoError(err)
+	s.ErrorIs(c.RegisterHandler("", nil), ErrorRegisterHandler)
+}
+
+func (s *KafkaConsumerTestSuite) TestAutoCommitInBatches() {
+	tp := TopicPartition{Topic: "orders", Partition: 0}
+
+	c, _ := NewConsumer(s.logger, s.client, &ConsumerOpts{GroupID: "group", Topics: []string{"orders"}, CommitBatchSize: 2})
+	_ = c.RegisterHandler("orders", func(ctx context.Context, msg *Message) error {
+		if msg.Offset == 3 {
+			return errors.New("some error")
+		}
+		return nil
+	})
+
+	s.poll(
+		&Event{Assigned: []TopicPartition{tp}},
+		&Event{Message: &Message{Topic: "orders", Offset: 0}},
+		&Event{Message: &Message{Topic: "orders", Offset: 1}},
+		&Event{Message: &Message{Topic: "orders", Offset: 2}},
+		&Event{Message: &Message{Topic: "orders", Offset: 3}},
+	)
+	s.client.On("CommitOffsets", map[TopicPartition]int64{tp: 2}).Return(nil).Once()
+	s.client.On("CommitOffsets", map[TopicPartition]int64{tp: 3}).Return(nil).Once()
+
+	s.NoError(c.Consume(s.ctx))
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *KafkaConsumerTestSuite) TestCommitInterval() {
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+	tp := TopicPartition{Topic: "orders", Partition: 0}
+
+	c, _ := NewConsumer(s.logger, s.client, &ConsumerOpts{GroupID: "group", Topics: []string{"orders"}, CommitInterval: time.Second})
+	_ = c.RegisterHandler("orders", func(ctx context.Context, msg *Message) error {
+		now = now.Add(time.Second)
+		return nil
+	})
+
+	s.client.On("Poll", s.ctx).Return(&Event{Assigned: []TopicPartition{tp}}, nil).Once()
+	s.client.On("Poll", s.ctx).Return(&Event{Message: &Message{Topic: "orders", Offset: 7}}, nil).Once()
+	s.client.On("CommitOffsets", map[TopicPartition]int64{tp: 8}).Run(func(args mock.Arguments) { s.cancel() }).Return(nil).Once()
+
+	s.NoError(c.Consume(s.ctx))
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *KafkaConsumerTestSuite) TestRevokeFlushesBeforeCommit() {
+	p0 := TopicPartition{Topic: "orders", Partition: 0}
+	p1 := TopicPartition{Topic: "orders", Partition: 1}
+	calls := []string{}
+
+	c, _ := NewConsumer(s.logger, s.client, &ConsumerOpts{
+		GroupID:    "group",
+		Topics:     []string{"orders"},
+		CommitMode: MANUAL_COMMIT,
+		OnPartitionsAssigned: func(ctx context.Context, partitions []TopicPartition) error {
+			calls = append(calls, "assigned")
+			return nil
+		},
+		OnPartitionsRevoked: func(ctx context.Context, partitions []TopicPartition) error {
+			s.Equal([]TopicPartition{p0}, partitions)
+			calls = append(calls, "revoked")
+			return nil
+		},
+	})
+
+	var consumer = c
+	_ = c.RegisterHandler("orders", func(ctx context.Context, msg *Message) error {
+		consumer.MarkOffset(msg)
+		return nil
+	})
+
+	s.poll(
+		&Event{Assigned: []TopicPartition{p0, p1}},
+		&Event{Message: &Message{Topic: "orders", Partition: 0, Offset: 5}},
+		&Event{Revoked: []TopicPartition{p0}},
+	)
+	s.client.On("CommitOffsets", map[TopicPartition]int64{p0: 6}).Run(func(args mock.Arguments) {
+		calls = append(calls, "commit")
+	}).Return(nil).Once()
+
+	s.NoError(c.Consume(s.ctx))
+	s.Equal([]string{"assigned", "revoked", "commit"}, calls)
+	s.Equal([]TopicPartition{p1}, c.Assignment())
+
+	//late marks of the revoked partition are ignored
+	c.MarkOffset(&Message{Topic: "orders", Partition: 0, Offset: 6})
+	s.NoError(c.Commit())
+	s.client.AssertExpectations(s.T())
+}
+
+func (s *KafkaConsumerTestSuite) TestRevokeHookFailure() {
+	tp := TopicPartition{Topic: "orders", Partition: 0}
+
+	c, _ := NewConsumer(s.logger, s.client, &ConsumerOpts{
+		GroupID: "group",
+		Topics:  []string{"orders"},
+		OnPartitionsRevoked: func(ctx context.Context, partitions []TopicPartition) error {
+			return errors.New("some error")
+		},
+	})
+
+	s.client.On("Poll", s.ctx).Return(&Event{Assigned: []TopicPartition{tp}}, nil).Once()
+	s.client.On("Poll", s.ctx).Return(&Event{Revoked: []TopicPartition{tp}}, nil).Once()
+
+	s.Error(c.Consume(s.ctx))
+	s.client.AssertNotCalled(s.T(), "CommitOffsets", mock.Anything)
+}
+
+func (s *KafkaConsumerTestSuite) TestClose() {
+	s.client.On("Close").Return(nil)
+
+	c, _ := NewConsumer(s.logger, s.client, &ConsumerOpts{GroupID: "group", Topics: []string{"orders"}})
+
+	s.NoError(c.Close())
+	s.ErrorIs(c.Consume(s.ctx), ErrorConsumerIsClosed)
+}
@@ -0,0 +1,137 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// NewLagEvaluator create the lag evaluator to the partitions returned by partitions, e.g: IKafkaConsumer.Assignment
+func NewLagEvaluator(logger logging.ILogger, client OffsetsClient, partitions func() []TopicPartition, opts *LagOpts) (ILagEvaluator, error) {
+	if opts == nil || opts.GroupID == "" {
+		return nil, ErrorLagGroup
+	}
+
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultLagInterval
+	}
+
+	return &LagEvaluator{
+		logger:     logger,
+		client:     client,
+		partitions: partitions,
+		opts:       opts,
+	}, nil
+}
+
+func (e *LagEvaluator) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if _, err := e.Evaluate(ctx); err != nil {
+			e.logger.Error(LogMessage("failure to evaluate the consumer lag"), logging.ErrorField(err))
+		}
+	}
+}
+
+func (e *LagEvaluator) Evaluate(ctx context.Context) ([]*PartitionLag, error) {
+	partitions := e.partitions()
+	if len(partitions) == 0 {
+		return nil, nil
+	}
+
+	sort.Slice(partitions, func(i, j int) bool {
+		if partitions[i].Topic != partitions[j].Topic {
+			return partitions[i].Topic < partitions[j].Topic
+		}
+
+		return partitions[i].Partition < partitions[j].Partition
+	})
+
+	committed, err := e.client.CommittedOffsets(ctx, e.opts.GroupID, partitions)
+	if err != nil {
+		return nil, err
+	}
+
+	lags := make([]*PartitionLag, 0, len(partitions))
+	for _, tp := range partitions {
+		low, high, err := e.client.Watermarks(ctx, tp)
+		if err != nil {
+			return nil, err
+		}
+
+		// without a commit the group consumes from the oldest available offset
+		offset, ok := committed[tp]
+		if !ok || offset < low {
+			offset = low
+		}
+
+		lag := &PartitionLag{
+			TopicPartition: tp,
+			GroupID:        e.opts.GroupID,
+			Committed:      offset,
+			HighWatermark:  high,
+			Lag:            high - offset,
+			Threshold:      e.threshold(tp),
+		}
+
+		if lag.Lag < 0 {
+			lag.Lag = 0
+		}
+
+		recordLag(ctx, lag)
+		lags = append(lags, lag)
+
+		if lag.Threshold <= 0 || lag.Lag <= lag.Threshold {
+			continue
+		}
+
+		e.logger.Warn(LogMessage(fmt.Sprintf("consumer lag exceeded: %s[%d] lag %d threshold %d", tp.Topic, tp.Partition, lag.Lag, lag.Threshold)))
+
+		if e.opts.OnLag != nil {
+			e.opts.OnLag(ctx, lag)
+		}
+	}
+
+	return lags, nil
+}
+
+func (e *LagEvaluator) threshold(tp TopicPartition) int64 {
+	if t, ok := e.opts.PartitionThresholds[tp]; ok {
+		return t
+	}
+
+	if t, ok := e.opts.TopicThresholds[tp.Topic]; ok {
+		return t
+	}
+
+	return e.opts.Threshold
+}
+
+// recordLag the gauge is created with the global meter provider on each call, so the provider configured after the evaluator start is used
+func recordLag(ctx context.Context, lag *PartitionLag) {
+	gauge, err := otel.Meter(MeterName).Int64Gauge(ConsumerLagMetric, metric.WithDescription("messages produced and not committed by the consumer group"))
+	if err != nil {
+		return
+	}
+
+	gauge.Record(ctx, lag.Lag, metric.WithAttributes(
+		attribute.String("messaging.kafka.consumer.group", lag.GroupID),
+		attribute.String("messaging.destination", lag.Topic),
+		attribute.Int64("messaging.kafka.partition", int64(lag.Partition)),
+	))
+}
@@ -0,0 +1,140 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type LagEvaluatorTestSuite struct {
+	suite.Suite
+
+	client     *MockOffsetsClient
+	partitions []TopicPartition
+	alerts     []*PartitionLag
+}
+
+func TestLagEvaluatorTestSuite(t *testing.T) {
+	suite.Run(t, new(LagEvaluatorTestSuite))
+}
+
+func (s *LagEvaluatorTestSuite) SetupTest() {
+	s.client = NewMockOffsetsClient()
+	s.partitions = []TopicPartition{{"payments", 0}, {"orders", 1}, {"orders", 0}}
+	s.alerts = nil
+}
+
+func (s *LagEvaluatorTestSuite) evaluator(opts *LagOpts) ILagEvaluator {
+	opts.GroupID = "group"
+	opts.OnLag = func(ctx context.Context, lag *PartitionLag) {
+		s.alerts = append(s.alerts, lag)
+	}
+
+	e, err := NewLagEvaluator(logging.NewMockLogger(), s.client, func() []TopicPartition { return s.partitions }, opts)
+	s.NoError(err)
+
+	return e
+}
+
+func (s *LagEvaluatorTestSuite) TestNewLagEvaluator() {
+	_, err := NewLagEvaluator(logging.NewMockLogger(), s.client, nil, nil)
+	s.ErrorIs(err, ErrorLagGroup)
+
+	e, err := NewLagEvaluator(logging.NewMockLogger(), s.client, nil, &LagOpts{GroupID: "group"})
+	s.NoError(err)
+	s.Equal(DefaultLagInterval, e.(*LagEvaluator).opts.Interval)
+}
+
+func (s *LagEvaluatorTestSuite) TestEvaluate() {
+	reader := sdkMetric.NewManualReader()
+	otel.SetMeterProvider(sdkMetric.NewMeterProvider(sdkMetric.WithReader(reader)))
+	defer otel.SetMeterProvider(noop.NewMeterProvider())
+
+	sorted := []TopicPartition{{"orders", 0}, {"orders", 1}, {"payments", 0}}
+	s.client.On("CommittedOffsets", mock.Anything, "group", sorted).Return(map[TopicPartition]int64{{"orders", 0}: 90, {"orders", 1}: 10}, nil)
+	s.client.On("Watermarks", mock.Anything, TopicPartition{"orders", 0}).Return(int64(0), int64(100), nil)
+	s.client.On("Watermarks", mock.Anything, TopicPartition{"orders", 1}).Return(int64(0), int64(100), nil)
+	s.client.On("Watermarks", mock.Anything, TopicPartition{"payments", 0}).Return(int64(50), int64(80), nil)
+
+	lags, err := s.evaluator(&LagOpts{
+		Threshold:           20,
+		TopicThresholds:     map[string]int64{"payments": 100},
+		PartitionThresholds: map[TopicPartition]int64{{"orders", 1}: 0},
+	}).Evaluate(context.Background())
+
+	s.NoError(err)
+	s.Len(lags, 3)
+	s.Equal(int64(10), lags[0].Lag)
+	s.Equal(int64(90), lags[1].Lag)
+	s.Equal(int64(50), lags[2].Committed)
+	s.Equal(int64(30), lags[2].Lag)
+	s.Empty(s.alerts)
+
+	_, err = s.evaluator(&LagOpts{Threshold: 20}).Evaluate(context.Background())
+
+	s.NoError(err)
+	s.Len(s.alerts, 2)
+	s.Equal(TopicPartition{"orders", 1}, s.alerts[0].TopicPartition)
+	s.Equal(int64(20), s.alerts[0].Threshold)
+	s.Equal(TopicPartition{"payments", 0}, s.alerts[1].TopicPartition)
+
+	rm := metricdata.ResourceMetrics{}
+	s.NoError(reader.Collect(context.Background(), &rm))
+	s.Equal(ConsumerLagMetric, rm.ScopeMetrics[0].Metrics[0].Name)
+}
+
+func (s *LagEvaluatorTestSuite) TestEvaluateErr() {
+	s.client.On("CommittedOffsets", mock.Anything, "group", mock.Anything).Return(nil, errors.New("some error")).Once()
+
+	_, err := s.evaluator(&LagOpts{}).Evaluate(context.Background())
+	s.Error(err)
+
+	s.client.On("CommittedOffsets", mock.Anything, "group", mock.Anything).Return(map[TopicPartition]int64{}, nil)
+	s.client.On("Watermarks", mock.Anything, mock.Anything).Return(int64(0), int64(0), errors.New("some error"))
+
+	_, err = s.evaluator(&LagOpts{}).Evaluate(context.Background())
+	s.Error(err)
+
+	s.partitions = nil
+	lags, err := s.evaluator(&LagOpts{}).Evaluate(context.Background())
+	s.NoError(err)
+	s.Empty(lags)
+}
+
+func (s *LagEvaluatorTestSuite) TestRun() {
+	s.client.On("CommittedOffsets", mock.Anything, "group", mock.Anything).Return(map[TopicPartition]int64{}, nil)
+	s.client.On("Watermarks", mock.Anything, mock.Anything).Return(int64(0), int64(100), nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	alerted := make(chan *PartitionLag, 1)
+
+	e, err := NewLagEvaluator(logging.NewMockLogger(), s.client, func() []TopicPartition { return s.partitions }, &LagOpts{
+		GroupID:   "group",
+		Interval:  10 * time.Millisecond,
+		Threshold: 50,
+		OnLag: func(ctx context.Context, lag *PartitionLag) {
+			select {
+			case alerted <- lag:
+			default:
+			}
+		},
+	})
+	s.NoError(err)
+
+	done := make(chan error)
+	go func() { done <- e.Run(ctx) }()
+
+	s.Equal(int64(100), (<-alerted).Lag)
+	cancel()
+
+	s.NoError(<-done)
+}
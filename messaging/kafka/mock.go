@@ -0,0 +1,109 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockKafkaConsumer struct {
+		mock.Mock
+	}
+
+	MockKafkaClient struct {
+		mock.Mock
+	}
+
+	MockOffsetsClient struct {
+		mock.Mock
+	}
+)
+
+func (m *MockKafkaConsumer) RegisterHandler(topic string, handler ConsumerHandler) error {
+	args := m.Called(topic, handler)
+
+	return args.Error(0)
+}
+
+func (m *MockKafkaConsumer) Consume(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (m *MockKafkaConsumer) MarkOffset(msg *Message) {
+	m.Called(msg)
+}
+
+func (m *MockKafkaConsumer) Commit() error {
+	args := m.Called()
+
+	return args.Error(0)
+}
+
+func (m *MockKafkaConsumer) Assignment() []TopicPartition {
+	args := m.Called()
+
+	return args.Get(0).([]TopicPartition)
+}
+
+func (m *MockKafkaConsumer) Close() error {
+	args := m.Called()
+
+	return args.Error(0)
+}
+
+func NewMockKafkaConsumer() *MockKafkaConsumer {
+	return new(MockKafkaConsumer)
+}
+
+func (m *MockKafkaClient) Subscribe(groupID string, topics []string) error {
+	args := m.Called(groupID, topics)
+
+	return args.Error(0)
+}
+
+func (m *MockKafkaClient) Poll(ctx context.Context) (*Event, error) {
+	args := m.Called(ctx)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*Event), args.Error(1)
+}
+
+func (m *MockKafkaClient) CommitOffsets(offsets map[TopicPartition]int64) error {
+	args := m.Called(offsets)
+
+	return args.Error(0)
+}
+
+func (m *MockKafkaClient) Close() error {
+	args := m.Called()
+
+	return args.Error(0)
+}
+
+func NewMockKafkaClient() *MockKafkaClient {
+	return new(MockKafkaClient)
+}
+
+func (m *MockOffsetsClient) Watermarks(ctx context.Context, tp TopicPartition) (int64, int64, error) {
+	args := m.Called(ctx, tp)
+
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockOffsetsClient) CommittedOffsets(ctx context.Context, groupID string, partitions []TopicPartition) (map[TopicPartition]int64, error) {
+	args := m.Called(ctx, groupID, partitions)
+
+	res, _ := args.Get(0).(map[TopicPartition]int64)
+
+	return res, args.Error(1)
+}
+
+func NewMockOffsetsClient() *MockOffsetsClient {
+	return new(MockOffsetsClient)
+}
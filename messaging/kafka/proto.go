@@ -0,0 +1,72 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ralvescosta/gokit/messaging/registry"
+	"google.golang.org/protobuf/proto"
+)
+
+// NewProtoDispatcher dispatch the protobuf records to the handlers of the message type, reg is optional and the default is registry.Default
+//
+//	dispatcher := kafka.NewProtoDispatcher(nil).Register(&ordersv1.OrderCreated{}, handler)
+//	consumer.RegisterHandler("orders", dispatcher.Handle)
+func NewProtoDispatcher(reg registry.IRegistry) *ProtoDispatcher {
+	if reg == nil {
+		reg = registry.Default
+	}
+
+	return &ProtoDispatcher{registry: reg, handlers: map[string]ProtoHandler{}}
+}
+
+// Register add the handler to the t fully-qualified name and register t in the registry
+func (d *ProtoDispatcher) Register(t proto.Message, handler ProtoHandler) *ProtoDispatcher {
+	d.registry.Register(t)
+	d.handlers[registry.Name(t)] = handler
+
+	return d
+}
+
+// Handle the ConsumerHandler registered to the topic
+func (d *ProtoDispatcher) Handle(ctx context.Context, record *Message) error {
+	name := MessageType(record)
+
+	handler, ok := d.handlers[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrorProtoHandler, name)
+	}
+
+	msg, err := d.registry.Unmarshal(name, record.Value)
+	if err != nil {
+		return err
+	}
+
+	return handler(ctx, msg, record)
+}
+
+// NewProtoMessage create the record of the protobuf message with the message-type header
+func NewProtoMessage(topic string, key []byte, msg proto.Message) (*Message, error) {
+	name, byt, err := registry.Default.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		Topic:   topic,
+		Key:     key,
+		Value:   byt,
+		Headers: []Header{{Key: registry.KafkaHeaderMessageType, Value: []byte(name)}},
+	}, nil
+}
+
+// MessageType the message-type header of the record
+func MessageType(record *Message) string {
+	for _, h := range record.Headers {
+		if h.Key == registry.KafkaHeaderMessageType {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,37 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ralvescosta/gokit/messaging/registry"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type ProtoDispatcherTestSuite struct {
+	suite.Suite
+}
+
+func TestProtoDispatcherTestSuite(t *testing.T) {
+	suite.Run(t, new(ProtoDispatcherTestSuite))
+}
+
+func (s *ProtoDispatcherTestSuite) TestHandle() {
+	var received string
+	dispatcher := NewProtoDispatcher(registry.New(true)).Register(&wrapperspb.StringValue{}, func(ctx context.Context, msg proto.Message, record *Message) error {
+		received = msg.(*wrapperspb.StringValue).GetValue()
+		return nil
+	})
+
+	record, err := NewProtoMessage("orders", nil, wrapperspb.String("value"))
+	s.NoError(err)
+	s.Equal("google.protobuf.StringValue", MessageType(record))
+
+	s.NoError(dispatcher.Handle(context.Background(), record))
+	s.Equal("value", received)
+
+	record.Headers = nil
+	s.ErrorIs(dispatcher.Handle(context.Background(), record), ErrorProtoHandler)
+}
@@ -1,5 +1,179 @@
 package kafka
 
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/registry"
+	"google.golang.org/protobuf/proto"
+)
+
 type (
 	IKafkaMessaging interface{}
+
+	CommitMode int8
+
+	// TopicPartition a partition assigned to the consumer
+	TopicPartition struct {
+		Topic     string
+		Partition int32
+	}
+
+	Header struct {
+		Key   string
+		Value []byte
+	}
+
+	// Message kafka record received
+	Message struct {
+		Topic     string
+		Partition int32
+		Offset    int64
+		Key       []byte
+		Value     []byte
+		Headers   []Header
+		Timestamp time.Time
+	}
+
+	// Event returned by the KafkaClient poll, a message or a rebalance
+	Event struct {
+		Message  *Message
+		Assigned []TopicPartition
+		Revoked  []TopicPartition
+	}
+
+	// KafkaClient is an abstraction over the consumer group of the kafka driver to improve unit tests
+	KafkaClient interface {
+		Subscribe(groupID string, topics []string) error
+		// Poll returns the next event, (nil, nil) must be returned when there is no event in the driver poll timeout
+		Poll(ctx context.Context) (*Event, error)
+		// CommitOffsets commit the next offset to be consumed of each partition
+		CommitOffsets(offsets map[TopicPartition]int64) error
+		Close() error
+	}
+
+	// RebalanceHook is called with the partitions assigned or revoked, the revoked hook runs before the pending offsets are committed
+	RebalanceHook = func(ctx context.Context, partitions []TopicPartition) error
+
+	// ConsumerHandler
+	ConsumerHandler = func(ctx context.Context, msg *Message) error
+
+	// ConsumerOpts consumer group configuration
+	ConsumerOpts struct {
+		GroupID string
+		Topics  []string
+		// CommitMode the default is AUTO_COMMIT
+		CommitMode CommitMode
+		// CommitInterval the marked offsets are committed in batches at least once each interval, the default is DefaultCommitInterval
+		CommitInterval time.Duration
+		// CommitBatchSize the marked offsets are committed when the batch size is reached, the default is DefaultCommitBatchSize
+		CommitBatchSize int
+		// OnPartitionsAssigned optional, called when the partitions are assigned to the consumer
+		OnPartitionsAssigned RebalanceHook
+		// OnPartitionsRevoked optional, stateful consumers flush their caches here to avoid duplicate processing
+		OnPartitionsRevoked RebalanceHook
+	}
+
+	// IKafkaConsumer consume the topics as a consumer group
+	IKafkaConsumer interface {
+		// RegisterHandler add the handler to the topic messages
+		RegisterHandler(topic string, handler ConsumerHandler) error
+
+		// Consume subscribe the topics and handle the messages until the ctx is done, the marked offsets are committed before it returns
+		Consume(ctx context.Context) error
+
+		// MarkOffset mark the message as processed, it is committed in the next batch.
+		// Only required in the MANUAL_COMMIT mode
+		MarkOffset(msg *Message)
+
+		// Commit commit the marked offsets right away
+		Commit() error
+
+		// Assignment the partitions currently assigned to the consumer
+		Assignment() []TopicPartition
+
+		Close() error
+	}
+
+	// KafkaConsumer is the implementation for IKafkaConsumer
+	KafkaConsumer struct {
+		logger   logging.ILogger
+		client   KafkaClient
+		opts     *ConsumerOpts
+		handlers map[string]ConsumerHandler
+		// assigned the partitions and the offsets marked and not committed yet, guarded by mu
+		assigned   map[TopicPartition]bool
+		pending    map[TopicPartition]int64
+		marked     int
+		lastCommit time.Time
+		closed     bool
+		mu         sync.Mutex
+	}
+)
+
+type (
+	// OffsetsClient is an abstraction over the kafka offsets queries of the driver to improve unit tests
+	OffsetsClient interface {
+		// Watermarks the low (oldest available) and high (next produced) offsets of the partition
+		Watermarks(ctx context.Context, tp TopicPartition) (low, high int64, err error)
+		// CommittedOffsets the group committed offsets, the partitions without a commit are omitted
+		CommittedOffsets(ctx context.Context, groupID string, partitions []TopicPartition) (map[TopicPartition]int64, error)
+	}
+
+	// PartitionLag the messages produced and not committed by the group yet
+	PartitionLag struct {
+		TopicPartition
+		GroupID       string
+		Committed     int64
+		HighWatermark int64
+		Lag           int64
+		// Threshold the threshold exceeded, zero when no threshold is configured to the partition
+		Threshold int64
+	}
+
+	// LagAlertHandler called with the partitions whose lag exceeded the threshold
+	LagAlertHandler = func(ctx context.Context, lag *PartitionLag)
+
+	// LagOpts the lag evaluator configuration, the partition threshold has precedence over the topic threshold and the topic threshold over the default Threshold
+	LagOpts struct {
+		GroupID string
+		// Interval the evaluation period, the default is DefaultLagInterval
+		Interval time.Duration
+		// Threshold the default threshold, zero disables the alert
+		Threshold           int64
+		TopicThresholds     map[string]int64
+		PartitionThresholds map[TopicPartition]int64
+		// OnLag optional, the exceeded lags are always logged as a warning
+		OnLag LagAlertHandler
+	}
+
+	// ILagEvaluator periodically compares the group committed offsets with the partitions high watermarks
+	ILagEvaluator interface {
+		// Evaluate the lag of each partition, the lag metric is recorded and OnLag is called for the exceeded thresholds
+		Evaluate(ctx context.Context) ([]*PartitionLag, error)
+
+		// Run evaluate the lag each interval until the ctx is done, the evaluation errors are logged
+		Run(ctx context.Context) error
+	}
+
+	// LagEvaluator is the implementation for ILagEvaluator
+	LagEvaluator struct {
+		logger     logging.ILogger
+		client     OffsetsClient
+		partitions func() []TopicPartition
+		opts       *LagOpts
+	}
+)
+
+type (
+	// ProtoHandler receives the protobuf message unmarshaled by the ProtoDispatcher
+	ProtoHandler = func(ctx context.Context, msg proto.Message, record *Message) error
+
+	// ProtoDispatcher dispatch the records on the message-type header, the protobuf fully-qualified name
+	ProtoDispatcher struct {
+		registry registry.IRegistry
+		handlers map[string]ProtoHandler
+	}
 )
@@ -0,0 +1,82 @@
+package kinesis
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// CheckpointSchema the postgres table used by the sql checkpoint store, %s is the table name
+const CheckpointSchema = `CREATE TABLE IF NOT EXISTS %s (
+	app TEXT NOT NULL,
+	shard_id TEXT NOT NULL,
+	sequence_number TEXT NOT NULL,
+	updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	PRIMARY KEY (app, shard_id)
+)`
+
+// NewSQLCheckpointStore store the checkpoints in a postgres table, the default table is DefaultCheckpointTable
+func NewSQLCheckpointStore(db *sql.DB, table string) CheckpointStore {
+	if table == "" {
+		table = DefaultCheckpointTable
+	}
+
+	return &sqlCheckpointStore{db, table}
+}
+
+// MigrateCheckpoints create the sql checkpoint store table
+func MigrateCheckpoints(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultCheckpointTable
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(CheckpointSchema, table))
+	return err
+}
+
+func (s *sqlCheckpointStore) Get(ctx context.Context, app, shardID string) (string, error) {
+	sequenceNumber := ""
+
+	err := s.db.
+		QueryRowContext(ctx, fmt.Sprintf("SELECT sequence_number FROM %s WHERE app = $1 AND shard_id = $2", s.table), app, shardID).
+		Scan(&sequenceNumber)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+
+	return sequenceNumber, err
+}
+
+func (s *sqlCheckpointStore) Set(ctx context.Context, app, shardID, sequenceNumber string) error {
+	_, err := s.db.ExecContext(
+		ctx,
+		fmt.Sprintf(`INSERT INTO %s (app, shard_id, sequence_number) VALUES ($1, $2, $3)
+			ON CONFLICT (app, shard_id) DO UPDATE SET sequence_number = EXCLUDED.sequence_number, updated_at = now()`, s.table),
+		app, shardID, sequenceNumber,
+	)
+
+	return err
+}
+
+// NewDynamoDBCheckpointStore store the checkpoints in a dynamodb table with the partition key "app" and the sort key "shardId"
+func NewDynamoDBCheckpointStore(client DynamoDBClient, table string) CheckpointStore {
+	if table == "" {
+		table = DefaultCheckpointTable
+	}
+
+	return &dynamoDBCheckpointStore{client, table}
+}
+
+func (s *dynamoDBCheckpointStore) Get(ctx context.Context, app, shardID string) (string, error) {
+	item, err := s.client.GetItem(ctx, s.table, map[string]string{"app": app, "shardId": shardID})
+	if err != nil {
+		return "", err
+	}
+
+	return item["sequenceNumber"], nil
+}
+
+func (s *dynamoDBCheckpointStore) Set(ctx context.Context, app, shardID, sequenceNumber string) error {
+	return s.client.PutItem(ctx, s.table, map[string]string{"app": app, "shardId": shardID, "sequenceNumber": sequenceNumber})
+}
@@ -0,0 +1,29 @@
+package kinesis
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	TRIM_HORIZON          IteratorType = "TRIM_HORIZON"
+	LATEST                IteratorType = "LATEST"
+	AFTER_SEQUENCE_NUMBER IteratorType = "AFTER_SEQUENCE_NUMBER"
+
+	DefaultPollInterval = time.Second
+	// DefaultBatchSize the GetRecords limit, kinesis accepts up to 10000
+	DefaultBatchSize = 100
+
+	DefaultCheckpointTable = "kinesis_checkpoints"
+)
+
+var (
+	ErrorConsumerOpts    = errors.New("kinesis stream name and app name are required")
+	ErrorFanOutClient    = errors.New("kinesis enhanced fan-out requires a client implementing FanOutClient")
+	ErrorHandlerRequired = errors.New("kinesis handler is required")
+	ErrorConsumerClosed  = errors.New("kinesis consumer is closed")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::kinesis] " + msg
+}
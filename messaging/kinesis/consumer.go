@@ -0,0 +1,236 @@
+package kinesis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+func NewConsumer(logger logging.ILogger, client KinesisClient, checkpoints CheckpointStore, opts *ConsumerOpts) (IKinesisConsumer, error) {
+	if opts == nil || opts.StreamName == "" || opts.AppName == "" {
+		return nil, ErrorConsumerOpts
+	}
+
+	if _, ok := client.(FanOutClient); opts.ConsumerARN != "" && !ok {
+		return nil, ErrorFanOutClient
+	}
+
+	if opts.IteratorType == "" {
+		opts.IteratorType = TRIM_HORIZON
+	}
+
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = DefaultBatchSize
+	}
+
+	return &KinesisConsumer{logger: logger, client: client, checkpoints: checkpoints, opts: opts}, nil
+}
+
+func (c *KinesisConsumer) Consume(ctx context.Context, handler ConsumerHandler) error {
+	if handler == nil {
+		return ErrorHandlerRequired
+	}
+
+	if c.isClosed() {
+		return ErrorConsumerClosed
+	}
+
+	shards, err := c.client.ListShards(ctx, c.opts.StreamName)
+	if err != nil {
+		c.logger.Error(LogMessage("failure to list the shards"), logging.ErrorField(err))
+		return err
+	}
+
+	c.logger.Debug(LogMessage(fmt.Sprintf("consuming %d shards of %s", len(shards), c.opts.StreamName)))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	errs := make(chan error, len(shards))
+	wg := sync.WaitGroup{}
+
+	for _, shard := range shards {
+		wg.Add(1)
+
+		go func(shardID string) {
+			defer wg.Done()
+
+			if err := c.consumeShard(ctx, shardID, handler); err != nil && ctx.Err() == nil {
+				errs <- err
+				cancel()
+			}
+		}(shard.ID)
+	}
+
+	wg.Wait()
+	close(errs)
+
+	return <-errs
+}
+
+func (c *KinesisConsumer) consumeShard(ctx context.Context, shardID string, handler ConsumerHandler) error {
+	sequenceNumber, err := c.checkpoints.Get(ctx, c.opts.AppName, shardID)
+	if err != nil {
+		c.logger.Error(LogMessage(fmt.Sprintf("failure to get the checkpoint of the shard %s", shardID)), logging.ErrorField(err))
+		return err
+	}
+
+	if c.opts.ConsumerARN != "" {
+		return c.subscribeShard(ctx, shardID, sequenceNumber, handler)
+	}
+
+	iteratorType := c.opts.IteratorType
+	if sequenceNumber != "" {
+		iteratorType = AFTER_SEQUENCE_NUMBER
+	}
+
+	iterator, err := c.client.GetShardIterator(ctx, c.opts.StreamName, shardID, iteratorType, sequenceNumber)
+	if err != nil {
+		c.logger.Error(LogMessage(fmt.Sprintf("failure to get the iterator of the shard %s", shardID)), logging.ErrorField(err))
+		return err
+	}
+
+	for iterator != "" {
+		if ctx.Err() != nil || c.isClosed() {
+			return nil
+		}
+
+		out, err := c.client.GetRecords(ctx, iterator, c.opts.BatchSize)
+		if err != nil {
+			c.logger.Error(LogMessage(fmt.Sprintf("failure to get the records of the shard %s", shardID)), logging.ErrorField(err))
+			return err
+		}
+
+		if err := c.handleRecords(ctx, shardID, out.Records, handler); err != nil {
+			return err
+		}
+
+		iterator = out.NextShardIterator
+
+		if len(out.Records) == 0 && iterator != "" {
+			select {
+			case <-ctx.Done():
+			case <-time.After(c.opts.PollInterval):
+			}
+		}
+	}
+
+	c.logger.Info(LogMessage(fmt.Sprintf("shard %s was closed", shardID)))
+	return nil
+}
+
+// subscribeShard enhanced fan-out, the subscription is renewed from the last sequence number when kinesis ends it
+func (c *KinesisConsumer) subscribeShard(ctx context.Context, shardID, sequenceNumber string, handler ConsumerHandler) error {
+	client := c.client.(FanOutClient)
+
+	for ctx.Err() == nil && !c.isClosed() {
+		iteratorType := c.opts.IteratorType
+		if sequenceNumber != "" {
+			iteratorType = AFTER_SEQUENCE_NUMBER
+		}
+
+		sub, err := client.SubscribeToShard(ctx, c.opts.ConsumerARN, shardID, iteratorType, sequenceNumber)
+		if err != nil {
+			c.logger.Error(LogMessage(fmt.Sprintf("failure to subscribe the shard %s", shardID)), logging.ErrorField(err))
+			return err
+		}
+
+		closed, last, err := c.receive(ctx, sub, shardID, handler)
+		_ = sub.Close()
+
+		if last != "" {
+			sequenceNumber = last
+		}
+
+		if err != nil || closed {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *KinesisConsumer) receive(ctx context.Context, sub ShardSubscription, shardID string, handler ConsumerHandler) (closed bool, last string, err error) {
+	for {
+		evt, err := sub.Recv()
+		if errors.Is(err, io.EOF) {
+			return false, last, nil
+		}
+
+		if err != nil {
+			if ctx.Err() != nil {
+				return false, last, nil
+			}
+
+			c.logger.Error(LogMessage(fmt.Sprintf("failure to receive the records of the shard %s", shardID)), logging.ErrorField(err))
+			return false, last, err
+		}
+
+		if err := c.handleRecords(ctx, shardID, evt.Records, handler); err != nil {
+			return false, last, err
+		}
+
+		if n := len(evt.Records); n > 0 {
+			last = evt.Records[n-1].SequenceNumber
+		}
+
+		if evt.ContinuationSequenceNumber == "" {
+			c.logger.Info(LogMessage(fmt.Sprintf("shard %s was closed", shardID)))
+			return true, last, nil
+		}
+	}
+}
+
+// handleRecords the checkpoint is the last record handled, so a failure in the middle of the batch does not lose the handled records
+func (c *KinesisConsumer) handleRecords(ctx context.Context, shardID string, records []*Record, handler ConsumerHandler) error {
+	last := ""
+
+	for _, record := range records {
+		if err := handler(ctx, record); err != nil {
+			c.logger.Error(LogMessage(fmt.Sprintf("failure to handle the record %s of the shard %s", record.SequenceNumber, shardID)), logging.ErrorField(err))
+			_ = c.checkpoint(ctx, shardID, last)
+			return err
+		}
+
+		last = record.SequenceNumber
+	}
+
+	return c.checkpoint(ctx, shardID, last)
+}
+
+func (c *KinesisConsumer) checkpoint(ctx context.Context, shardID, sequenceNumber string) error {
+	if sequenceNumber == "" {
+		return nil
+	}
+
+	if err := c.checkpoints.Set(ctx, c.opts.AppName, shardID, sequenceNumber); err != nil {
+		c.logger.Error(LogMessage(fmt.Sprintf("failure to checkpoint the shard %s", shardID)), logging.ErrorField(err))
+		return err
+	}
+
+	return nil
+}
+
+func (c *KinesisConsumer) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.closed = true
+	return nil
+}
+
+func (c *KinesisConsumer) isClosed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.closed
+}
@@ -0,0 +1,170 @@
+package kinesis
+
+import (
+	"context"
+	"errors"
+	"io"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type KinesisConsumerTestSuite struct {
+	suite.Suite
+
+	logger      *logging.MockLogger
+	client      *MockKinesisClient
+	checkpoints *MockCheckpointStore
+	opts        *ConsumerOpts
+}
+
+func TestKinesisConsumerTestSuite(t *testing.T) {
+	suite.Run(t, new(KinesisConsumerTestSuite))
+}
+
+func (s *KinesisConsumerTestSuite) SetupTest() {
+	s.logger = logging.NewMockLogger()
+	s.client = NewMockKinesisClient()
+	s.checkpoints = NewMockCheckpointStore()
+	s.opts = &ConsumerOpts{StreamName: "orders", AppName: "app"}
+}
+
+func (s *KinesisConsumerTestSuite) TestNewConsumer() {
+	_, err := NewConsumer(s.logger, s.client, s.checkpoints, nil)
+	s.ErrorIs(err, ErrorConsumerOpts)
+
+	_, err = NewConsumer(s.logger, s.client, s.checkpoints, &ConsumerOpts{StreamName: "orders", AppName: "app", ConsumerARN: "arn"})
+	s.ErrorIs(err, ErrorFanOutClient)
+
+	_, err = NewConsumer(s.logger, NewMockFanOutClient(), s.checkpoints, &ConsumerOpts{StreamName: "orders", AppName: "app", ConsumerARN: "arn"})
+	s.NoError(err)
+}
+
+func (s *KinesisConsumerTestSuite) TestConsumeFromCheckpoint() {
+	s.client.On("ListShards", mock.Anything, "orders").Return([]Shard{{ID: "shard-0"}}, nil)
+	s.checkpoints.On("Get", mock.Anything, "app", "shard-0").Return("10", nil)
+	s.client.On("GetShardIterator", mock.Anything, "orders", "shard-0", AFTER_SEQUENCE_NUMBER, "10").Return("it-1", nil)
+	s.client.On("GetRecords", mock.Anything, "it-1", DefaultBatchSize).Return(&GetRecordsOutput{
+		Records:           []*Record{{SequenceNumber: "11"}, {SequenceNumber: "12"}},
+		NextShardIterator: "it-2",
+	}, nil)
+	s.client.On("GetRecords", mock.Anything, "it-2", DefaultBatchSize).Return(&GetRecordsOutput{Records: []*Record{{SequenceNumber: "13"}}}, nil)
+	s.checkpoints.On("Set", mock.Anything, "app", "shard-0", "12").Return(nil).Once()
+	s.checkpoints.On("Set", mock.Anything, "app", "shard-0", "13").Return(nil).Once()
+
+	c, _ := NewConsumer(s.logger, s.client, s.checkpoints, s.opts)
+
+	handled := []string{}
+	err := c.Consume(context.Background(), func(ctx context.Context, record *Record) error {
+		handled = append(handled, record.SequenceNumber)
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal([]string{"11", "12", "13"}, handled)
+	s.checkpoints.AssertExpectations(s.T())
+}
+
+func (s *KinesisConsumerTestSuite) TestConsumeHandlerFailure() {
+	handlerErr := errors.New("handler failure")
+
+	s.client.On("ListShards", mock.Anything, "orders").Return([]Shard{{ID: "shard-0"}}, nil)
+	s.checkpoints.On("Get", mock.Anything, "app", "shard-0").Return("", nil)
+	s.client.On("GetShardIterator", mock.Anything, "orders", "shard-0", TRIM_HORIZON, "").Return("it-1", nil)
+	s.client.On("GetRecords", mock.Anything, "it-1", DefaultBatchSize).Return(&GetRecordsOutput{
+		Records:           []*Record{{SequenceNumber: "1"}, {SequenceNumber: "2"}},
+		NextShardIterator: "it-2",
+	}, nil)
+	s.checkpoints.On("Set", mock.Anything, "app", "shard-0", "1").Return(nil).Once()
+
+	c, _ := NewConsumer(s.logger, s.client, s.checkpoints, s.opts)
+
+	err := c.Consume(context.Background(), func(ctx context.Context, record *Record) error {
+		if record.SequenceNumber == "2" {
+			return handlerErr
+		}
+		return nil
+	})
+
+	s.ErrorIs(err, handlerErr)
+	s.checkpoints.AssertExpectations(s.T())
+}
+
+func (s *KinesisConsumerTestSuite) TestConsumeEnhancedFanOut() {
+	client := NewMockFanOutClient()
+	first := NewMockShardSubscription()
+	second := NewMockShardSubscription()
+
+	client.On("ListShards", mock.Anything, "orders").Return([]Shard{{ID: "shard-0"}}, nil)
+	s.checkpoints.On("Get", mock.Anything, "app", "shard-0").Return("", nil)
+	client.On("SubscribeToShard", mock.Anything, "arn", "shard-0", LATEST, "").Return(first, nil).Once()
+	client.On("SubscribeToShard", mock.Anything, "arn", "shard-0", AFTER_SEQUENCE_NUMBER, "1").Return(second, nil).Once()
+
+	first.On("Recv").Return(&SubscribeToShardEvent{Records: []*Record{{SequenceNumber: "1"}}, ContinuationSequenceNumber: "1"}, nil).Once()
+	first.On("Recv").Return(nil, io.EOF).Once()
+	first.On("Close").Return(nil)
+	second.On("Recv").Return(&SubscribeToShardEvent{Records: []*Record{{SequenceNumber: "2"}}}, nil).Once()
+	second.On("Close").Return(nil)
+
+	s.checkpoints.On("Set", mock.Anything, "app", "shard-0", "1").Return(nil).Once()
+	s.checkpoints.On("Set", mock.Anything, "app", "shard-0", "2").Return(nil).Once()
+
+	s.opts.ConsumerARN = "arn"
+	s.opts.IteratorType = LATEST
+	c, _ := NewConsumer(s.logger, client, s.checkpoints, s.opts)
+
+	handled := 0
+	err := c.Consume(context.Background(), func(ctx context.Context, record *Record) error {
+		handled++
+		return nil
+	})
+
+	s.NoError(err)
+	s.Equal(2, handled)
+	client.AssertExpectations(s.T())
+}
+
+func (s *KinesisConsumerTestSuite) TestProducer() {
+	s.client.On("PutRecord", mock.Anything, "orders", "key", []byte(`{"id":1}`)).Return("1", nil)
+
+	seq, err := NewProducer(s.logger, s.client, "orders").Publish(context.Background(), "key", map[string]int{"id": 1})
+
+	s.NoError(err)
+	s.Equal("1", seq)
+}
+
+func (s *KinesisConsumerTestSuite) TestSQLCheckpointStore() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT sequence_number FROM kinesis_checkpoints")).
+		WithArgs("app", "shard-0").
+		WillReturnRows(sqlmock.NewRows([]string{"sequence_number"}))
+	sqlMock.ExpectExec("INSERT INTO kinesis_checkpoints").
+		WithArgs("app", "shard-0", "10").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	store := NewSQLCheckpointStore(db, "")
+
+	seq, err := store.Get(context.Background(), "app", "shard-0")
+	s.NoError(err)
+	s.Empty(seq)
+
+	s.NoError(store.Set(context.Background(), "app", "shard-0", "10"))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *KinesisConsumerTestSuite) TestDynamoDBCheckpointStore() {
+	client := NewMockDynamoDBClient()
+	client.On("GetItem", mock.Anything, DefaultCheckpointTable, map[string]string{"app": "app", "shardId": "shard-0"}).
+		Return(map[string]string{"sequenceNumber": "10"}, nil)
+
+	seq, err := NewDynamoDBCheckpointStore(client, "").Get(context.Background(), "app", "shard-0")
+
+	s.NoError(err)
+	s.Equal("10", seq)
+}
@@ -0,0 +1,120 @@
+package kinesis
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockKinesisClient struct {
+		mock.Mock
+	}
+
+	MockFanOutClient struct {
+		MockKinesisClient
+	}
+
+	MockShardSubscription struct {
+		mock.Mock
+	}
+
+	MockCheckpointStore struct {
+		mock.Mock
+	}
+
+	MockDynamoDBClient struct {
+		mock.Mock
+	}
+)
+
+func (m *MockKinesisClient) ListShards(ctx context.Context, stream string) ([]Shard, error) {
+	args := m.Called(ctx, stream)
+
+	shards, _ := args.Get(0).([]Shard)
+	return shards, args.Error(1)
+}
+
+func (m *MockKinesisClient) GetShardIterator(ctx context.Context, stream, shardID string, iteratorType IteratorType, sequenceNumber string) (string, error) {
+	args := m.Called(ctx, stream, shardID, iteratorType, sequenceNumber)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockKinesisClient) GetRecords(ctx context.Context, iterator string, limit int) (*GetRecordsOutput, error) {
+	args := m.Called(ctx, iterator, limit)
+
+	out, _ := args.Get(0).(*GetRecordsOutput)
+	return out, args.Error(1)
+}
+
+func (m *MockKinesisClient) PutRecord(ctx context.Context, stream, partitionKey string, data []byte) (string, error) {
+	args := m.Called(ctx, stream, partitionKey, data)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockFanOutClient) SubscribeToShard(ctx context.Context, consumerARN, shardID string, iteratorType IteratorType, sequenceNumber string) (ShardSubscription, error) {
+	args := m.Called(ctx, consumerARN, shardID, iteratorType, sequenceNumber)
+
+	sub, _ := args.Get(0).(ShardSubscription)
+	return sub, args.Error(1)
+}
+
+func (m *MockShardSubscription) Recv() (*SubscribeToShardEvent, error) {
+	args := m.Called()
+
+	evt, _ := args.Get(0).(*SubscribeToShardEvent)
+	return evt, args.Error(1)
+}
+
+func (m *MockShardSubscription) Close() error {
+	args := m.Called()
+
+	return args.Error(0)
+}
+
+func (m *MockCheckpointStore) Get(ctx context.Context, app, shardID string) (string, error) {
+	args := m.Called(ctx, app, shardID)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockCheckpointStore) Set(ctx context.Context, app, shardID, sequenceNumber string) error {
+	args := m.Called(ctx, app, shardID, sequenceNumber)
+
+	return args.Error(0)
+}
+
+func (m *MockDynamoDBClient) GetItem(ctx context.Context, table string, key map[string]string) (map[string]string, error) {
+	args := m.Called(ctx, table, key)
+
+	item, _ := args.Get(0).(map[string]string)
+	return item, args.Error(1)
+}
+
+func (m *MockDynamoDBClient) PutItem(ctx context.Context, table string, item map[string]string) error {
+	args := m.Called(ctx, table, item)
+
+	return args.Error(0)
+}
+
+func NewMockKinesisClient() *MockKinesisClient {
+	return new(MockKinesisClient)
+}
+
+func NewMockFanOutClient() *MockFanOutClient {
+	return new(MockFanOutClient)
+}
+
+func NewMockShardSubscription() *MockShardSubscription {
+	return new(MockShardSubscription)
+}
+
+func NewMockCheckpointStore() *MockCheckpointStore {
+	return new(MockCheckpointStore)
+}
+
+func NewMockDynamoDBClient() *MockDynamoDBClient {
+	return new(MockDynamoDBClient)
+}
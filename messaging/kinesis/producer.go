@@ -0,0 +1,29 @@
+package kinesis
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+func NewProducer(logger logging.ILogger, client KinesisClient, stream string) IKinesisProducer {
+	return &KinesisProducer{logger, client, stream}
+}
+
+// Publish publish the json of msg, the records with the same partition key are written in the same shard in order
+func (p *KinesisProducer) Publish(ctx context.Context, partitionKey string, msg any) (string, error) {
+	byt, err := json.Marshal(msg)
+	if err != nil {
+		p.logger.Error(LogMessage("publisher marshal"), logging.ErrorField(err))
+		return "", err
+	}
+
+	sequenceNumber, err := p.client.PutRecord(ctx, p.stream, partitionKey, byt)
+	if err != nil {
+		p.logger.Error(LogMessage("failure to put the record"), logging.ErrorField(err))
+		return "", err
+	}
+
+	return sequenceNumber, nil
+}
@@ -0,0 +1,134 @@
+package kinesis
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	IteratorType string
+
+	Shard struct {
+		ID            string
+		ParentShardID string
+	}
+
+	// Record kinesis record received
+	Record struct {
+		StreamName                  string
+		ShardID                     string
+		SequenceNumber              string
+		PartitionKey                string
+		Data                        []byte
+		ApproximateArrivalTimestamp time.Time
+	}
+
+	GetRecordsOutput struct {
+		Records []*Record
+		// NextShardIterator is empty when the shard was closed by a resharding and all its records were read
+		NextShardIterator  string
+		MillisBehindLatest int64
+	}
+
+	// SubscribeToShardEvent enhanced fan-out event pushed by kinesis
+	SubscribeToShardEvent struct {
+		Records []*Record
+		// ContinuationSequenceNumber is empty when the shard was closed by a resharding
+		ContinuationSequenceNumber string
+		MillisBehindLatest         int64
+	}
+
+	// ShardSubscription enhanced fan-out subscription, kinesis ends the subscriptions every 5 minutes and Recv returns io.EOF
+	ShardSubscription interface {
+		Recv() (*SubscribeToShardEvent, error)
+		Close() error
+	}
+
+	// KinesisClient is an abstraction over the kinesis api of the aws sdk to improve unit tests
+	KinesisClient interface {
+		ListShards(ctx context.Context, stream string) ([]Shard, error)
+		GetShardIterator(ctx context.Context, stream, shardID string, iteratorType IteratorType, sequenceNumber string) (string, error)
+		GetRecords(ctx context.Context, iterator string, limit int) (*GetRecordsOutput, error)
+		// PutRecord returns the record sequence number
+		PutRecord(ctx context.Context, stream, partitionKey string, data []byte) (string, error)
+	}
+
+	// FanOutClient the kinesis client with the enhanced fan-out api, the consumer must be registered in the stream
+	FanOutClient interface {
+		KinesisClient
+		SubscribeToShard(ctx context.Context, consumerARN, shardID string, iteratorType IteratorType, sequenceNumber string) (ShardSubscription, error)
+	}
+
+	// CheckpointStore persist the last sequence number processed of each shard
+	CheckpointStore interface {
+		// Get returns an empty sequence number when the shard has no checkpoint
+		Get(ctx context.Context, app, shardID string) (string, error)
+		Set(ctx context.Context, app, shardID, sequenceNumber string) error
+	}
+
+	// DynamoDBClient is an abstraction over the GetItem and PutItem of the aws sdk, the item attributes are strings
+	DynamoDBClient interface {
+		GetItem(ctx context.Context, table string, key map[string]string) (map[string]string, error)
+		PutItem(ctx context.Context, table string, item map[string]string) error
+	}
+
+	// ConsumerHandler the record is checkpointed when the handler returns nil, the shard consumer stops on errors
+	ConsumerHandler = func(ctx context.Context, record *Record) error
+
+	ConsumerOpts struct {
+		StreamName string
+		// AppName the checkpoints namespace, the consumers with the same app name share the checkpoints
+		AppName string
+		// IteratorType where the shards without checkpoint start, the default is TRIM_HORIZON
+		IteratorType IteratorType
+		// PollInterval the wait between GetRecords calls when the shard is idle, the default is DefaultPollInterval
+		PollInterval time.Duration
+		// BatchSize the GetRecords limit, the default is DefaultBatchSize
+		BatchSize int
+		// ConsumerARN optional, when configured the records are pushed with enhanced fan-out, the client must implement FanOutClient
+		ConsumerARN string
+	}
+
+	// IKinesisConsumer consume all the shards of a stream checkpointing the processed records
+	IKinesisConsumer interface {
+		// Consume read the shards until the ctx is done or a handler fails, the shards are listed once when it starts
+		Consume(ctx context.Context, handler ConsumerHandler) error
+		Close() error
+	}
+
+	// IKinesisProducer publish json records
+	IKinesisProducer interface {
+		Publish(ctx context.Context, partitionKey string, msg any) (string, error)
+	}
+
+	// KinesisConsumer is the implementation for IKinesisConsumer
+	KinesisConsumer struct {
+		logger      logging.ILogger
+		client      KinesisClient
+		checkpoints CheckpointStore
+		opts        *ConsumerOpts
+		closed      bool
+		mu          sync.Mutex
+	}
+
+	// KinesisProducer is the implementation for IKinesisProducer
+	KinesisProducer struct {
+		logger logging.ILogger
+		client KinesisClient
+		stream string
+	}
+
+	sqlCheckpointStore struct {
+		db    *sql.DB
+		table string
+	}
+
+	dynamoDBCheckpointStore struct {
+		client DynamoDBClient
+		table  string
+	}
+)
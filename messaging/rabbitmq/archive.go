@@ -0,0 +1,232 @@
+package rabbitmq
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/streadway/amqp"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type sqlArchiveStore struct {
+	db    *sql.DB
+	table string
+}
+
+// Archive a nil opts or a nil store disable the archive
+func (m *RabbitMQMessaging) Archive(opts *ArchiveOpts) IRabbitMQMessaging {
+	if opts == nil || opts.Store == nil {
+		m.archive = nil
+		return m
+	}
+
+	m.archive = opts
+
+	return m
+}
+
+// archiveDelivery only the first delivery is archived, the retries have the same message
+func (m *RabbitMQMessaging) archiveDelivery(queue string, delivery *amqp.Delivery, metadata *DeliveryMetadata) {
+	if m.archive == nil || metadata.XCount > 0 || !m.archive.archives(queue) {
+		return
+	}
+
+	msg := &ArchivedMessage{
+		Queue:       queue,
+		Exchange:    delivery.Exchange,
+		RoutingKey:  delivery.RoutingKey,
+		MessageId:   delivery.MessageId,
+		Type:        delivery.Type,
+		ContentType: delivery.ContentType,
+		Headers:     make(map[string]interface{}, len(delivery.Headers)),
+		Body:        delivery.Body,
+		Timestamp:   time.Now(),
+	}
+
+	for k, v := range delivery.Headers {
+		msg.Headers[k] = v
+	}
+
+	if err := m.archive.Store.Archive(context.Background(), msg); err != nil {
+		m.logger.Warn(LogMessage("failure to archive the message"), logging.ErrorField(err), logging.MessageIdField(delivery.MessageId))
+	}
+}
+
+func (opts *ArchiveOpts) archives(queue string) bool {
+	if len(opts.Queues) == 0 {
+		return true
+	}
+
+	for _, q := range opts.Queues {
+		if q == queue {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (m *RabbitMQMessaging) Replay(ctx context.Context, filter *ReplayFilter) (int, error) {
+	if m.archive == nil {
+		return 0, ErrorArchiveDisabled
+	}
+
+	if filter == nil || filter.Queue == "" {
+		return 0, ErrorReplayQueue
+	}
+
+	messages, err := m.archive.Store.Query(ctx, filter)
+	if err != nil {
+		m.logger.Error(LogMessage("failure to query the archived messages"), logging.ErrorField(err))
+		return 0, err
+	}
+
+	for i, msg := range messages {
+		if err := ctx.Err(); err != nil {
+			return i, err
+		}
+
+		headers := map[string]interface{}{AMQPHeaderReplayed: true}
+		for k, v := range msg.Headers {
+			if !replayDiscardedHeaders[k] {
+				headers[k] = v
+			}
+		}
+
+		traceID, _ := msg.Headers[AMQPHeaderTraceID].(string)
+
+		// the default exchange delivers only to the archived queue, the other subscribers of the exchange are not affected
+		err := m.publish("", msg.Queue, msg.ContentType, msg.Body, &PublishOpts{
+			Type:       msg.Type,
+			MessageId:  msg.MessageId,
+			TraceId:    traceID,
+			Headers:    headers,
+			Ctx:        ctx,
+			Connection: m.queueConnection(msg.Queue),
+		})
+		if err != nil {
+			m.logger.Error(LogMessage("failure to replay the message"), logging.ErrorField(err), logging.MessageIdField(msg.MessageId))
+			return i, err
+		}
+	}
+
+	m.logger.Info(LogMessage(fmt.Sprintf("%d messages replayed to the queue: %s", len(messages), filter.Queue)))
+
+	return len(messages), nil
+}
+
+func (f *ReplayFilter) matchesType(typ string) bool {
+	if len(f.Types) == 0 {
+		return true
+	}
+
+	for _, t := range f.Types {
+		if t == typ {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches the message is in the queue, the time window and the types of the filter, used by the ArchiveStore implementations
+func (f *ReplayFilter) Matches(msg *ArchivedMessage) bool {
+	return msg.Queue == f.Queue &&
+		(f.From.IsZero() || !msg.Timestamp.Before(f.From)) &&
+		(f.To.IsZero() || msg.Timestamp.Before(f.To)) &&
+		f.matchesType(msg.Type)
+}
+
+// NewSqlArchiveStore PostgreSQL archive, the table could be created with MigrateArchive
+func NewSqlArchiveStore(db *sql.DB, table string) ArchiveStore {
+	if table == "" {
+		table = DefaultArchiveTable
+	}
+
+	return &sqlArchiveStore{db, table}
+}
+
+// MigrateArchive create the archive table if it does not exist
+func MigrateArchive(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultArchiveTable
+	}
+
+	_, err := db.ExecContext(ctx, strings.ReplaceAll(ArchivePostgresSchema, "%s", table))
+	return err
+}
+
+func (s *sqlArchiveStore) Archive(ctx context.Context, msg *ArchivedMessage) error {
+	headers, err := json.Marshal(msg.Headers)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (queue, exchange, routing_key, message_id, type, content_type, headers, body, archived_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`, s.table)
+
+	_, err = s.db.ExecContext(ctx, query,
+		msg.Queue, msg.Exchange, msg.RoutingKey, msg.MessageId, msg.Type, msg.ContentType, string(headers), msg.Body, msg.Timestamp)
+	return err
+}
+
+func (s *sqlArchiveStore) Query(ctx context.Context, filter *ReplayFilter) ([]*ArchivedMessage, error) {
+	query := fmt.Sprintf(`SELECT queue, exchange, routing_key, message_id, type, content_type, headers, body, archived_at
+		FROM %s WHERE queue = $1`, s.table)
+	args := []any{filter.Queue}
+
+	if !filter.From.IsZero() {
+		args = append(args, filter.From)
+		query += fmt.Sprintf(" AND archived_at >= $%d", len(args))
+	}
+
+	if !filter.To.IsZero() {
+		args = append(args, filter.To)
+		query += fmt.Sprintf(" AND archived_at < $%d", len(args))
+	}
+
+	if len(filter.Types) > 0 {
+		placeholders := make([]string, len(filter.Types))
+		for i, t := range filter.Types {
+			args = append(args, t)
+			placeholders[i] = fmt.Sprintf("$%d", len(args))
+		}
+
+		query += fmt.Sprintf(" AND type IN (%s)", strings.Join(placeholders, ", "))
+	}
+
+	query += " ORDER BY archived_at, id"
+	if filter.Limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", filter.Limit)
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := []*ArchivedMessage{}
+	for rows.Next() {
+		msg := &ArchivedMessage{}
+		var headers string
+
+		err := rows.Scan(&msg.Queue, &msg.Exchange, &msg.RoutingKey, &msg.MessageId, &msg.Type, &msg.ContentType, &headers, &msg.Body, &msg.Timestamp)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(headers), &msg.Headers); err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, msg)
+	}
+
+	return messages, rows.Err()
+}
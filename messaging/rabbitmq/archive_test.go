@@ -0,0 +1,104 @@
+package rabbitmq
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryArchiveStore keep the archived messages in memory
+type memoryArchiveStore struct {
+	messages []*ArchivedMessage
+}
+
+func (a *memoryArchiveStore) Archive(ctx context.Context, msg *ArchivedMessage) error {
+	a.messages = append(a.messages, msg)
+	return nil
+}
+
+func (a *memoryArchiveStore) Query(ctx context.Context, filter *ReplayFilter) ([]*ArchivedMessage, error) {
+	messages := []*ArchivedMessage{}
+	for _, msg := range a.messages {
+		if filter.Matches(msg) {
+			messages = append(messages, msg)
+		}
+	}
+
+	return messages, nil
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestArchiveAndReplay() {
+	store := &memoryArchiveStore{}
+	s.messaging.Archive(&ArchiveOpts{Store: store})
+
+	d, _, delivery := s.senary(nil)
+	delivery.Headers["x-request-id"] = "request"
+	delivery.Acknowledger = &recordAcknowledger{}
+	s.messaging.handleDelivery(d, &delivery)
+
+	// the retries are not archived again
+	delivery.Headers[AMQPHeaderNumberOfRetry] = int64(1)
+	s.messaging.handleDelivery(d, &delivery)
+
+	s.Require().Len(store.messages, 1)
+	s.Equal("queue", store.messages[0].Queue)
+	s.Equal(delivery.Body, store.messages[0].Body)
+
+	s.amqpChannel.
+		On("Publish", "", "queue", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.MessageId == "id" && pub.Type == "type" &&
+				pub.Headers[AMQPHeaderReplayed] == true &&
+				pub.Headers[AMQPHeaderNumberOfRetry] == int64(0) &&
+				pub.Headers["x-request-id"] == "request"
+		})).
+		Return(nil).
+		Once()
+
+	replayed, err := s.messaging.Replay(context.Background(), &ReplayFilter{Queue: "queue", Types: []string{"type"}})
+	s.NoError(err)
+	s.Equal(1, replayed)
+
+	replayed, err = s.messaging.Replay(context.Background(), &ReplayFilter{Queue: "queue", From: time.Now().Add(time.Hour)})
+	s.NoError(err)
+	s.Equal(0, replayed)
+	s.amqpChannel.AssertExpectations(s.T())
+
+	_, err = s.messaging.Replay(context.Background(), &ReplayFilter{})
+	s.ErrorIs(err, ErrorReplayQueue)
+
+	s.messaging.Archive(nil)
+	_, err = s.messaging.Replay(context.Background(), &ReplayFilter{Queue: "queue"})
+	s.ErrorIs(err, ErrorArchiveDisabled)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestSqlArchiveStore() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	store := NewSqlArchiveStore(db, "")
+	now := time.Now()
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO archived_messages (queue, exchange, routing_key")).
+		WithArgs("queue", "exchange", "key", "id", "type", JsonContentType, `{"x-count":0}`, []byte(`{}`), now).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta("FROM archived_messages WHERE queue = $1 AND archived_at >= $2 AND type IN ($3, $4) ORDER BY archived_at, id LIMIT 10")).
+		WithArgs("queue", now, "created", "updated").
+		WillReturnRows(sqlmock.NewRows([]string{"queue", "exchange", "routing_key", "message_id", "type", "content_type", "headers", "body", "archived_at"}).
+			AddRow("queue", "exchange", "key", "id", "created", JsonContentType, `{"x-trace-id":"trace"}`, []byte(`{}`), now))
+
+	s.NoError(store.Archive(context.Background(), &ArchivedMessage{
+		Queue: "queue", Exchange: "exchange", RoutingKey: "key", MessageId: "id", Type: "type", ContentType: JsonContentType,
+		Headers: map[string]interface{}{AMQPHeaderNumberOfRetry: 0}, Body: []byte(`{}`), Timestamp: now,
+	}))
+
+	messages, err := store.Query(context.Background(), &ReplayFilter{Queue: "queue", From: now, Types: []string{"created", "updated"}, Limit: 10})
+	s.NoError(err)
+	s.Require().Len(messages, 1)
+	s.Equal("trace", messages[0].Headers[AMQPHeaderTraceID])
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,208 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// connectionState the open connection and channel of a named connection and the broker flow control
+	connectionState struct {
+		connections int64
+		channels    int64
+
+		// unblocked closed when the broker removes the flow control, nil when the connection is not blocked
+		unblocked chan struct{}
+		reason    string
+	}
+)
+
+// WatchConnections the connections already open, and the ones added by AddConnection later, are reported as CONNECTED_EVENT
+// and observed by the ConnectionsMetric and ChannelsMetric gauges, the hook is optional
+//
+// There is no automatic reconnection, the CONNECTION_CLOSED_EVENT with an Err means the broker closed the connection
+func (m *RabbitMQMessaging) WatchConnections(hook ConnectionEventHook) IRabbitMQMessaging {
+	if m.Err != nil {
+		return m
+	}
+
+	m.mu.Lock()
+	m.eventHook = hook
+	m.mu.Unlock()
+
+	m.watchConnections()
+
+	return m
+}
+
+// watchConnections start the watchers once, WatchConnections and FlowControl share them
+func (m *RabbitMQMessaging) watchConnections() {
+	m.mu.Lock()
+	if m.watched != nil {
+		m.mu.Unlock()
+		return
+	}
+
+	m.watched = map[string]*connectionState{}
+	m.mu.Unlock()
+
+	if err := m.registerConnectionGauges(); err != nil {
+		m.logger.Warn(LogMessage("failure to register the connection gauges"), logging.ErrorField(err))
+	}
+
+	m.watchConnection(DefaultConnection, m.conn, m.ch)
+	for name, c := range m.connections {
+		m.watchConnection(name, c.conn, c.ch)
+	}
+}
+
+// watchConnection report the connection events until the connection is closed
+func (m *RabbitMQMessaging) watchConnection(name string, conn AMQPConnection, ch AMQPChannel) {
+	m.mu.Lock()
+	m.watched[name] = &connectionState{connections: 1, channels: 1}
+	m.mu.Unlock()
+
+	closed := conn.NotifyClose(make(chan *amqp.Error, 1))
+	blocked := conn.NotifyBlocked(make(chan amqp.Blocking, 1))
+	channelClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+	m.connectionEvent(&ConnectionEvent{Kind: CONNECTED_EVENT, Connection: name})
+
+	go func() {
+		for {
+			select {
+			case err, ok := <-channelClosed:
+				if !ok {
+					channelClosed = nil
+				}
+
+				if m.closeGauge(name, false) {
+					m.connectionEvent(&ConnectionEvent{Kind: CHANNEL_CLOSED_EVENT, Connection: name, Err: amqpError(err)})
+				}
+
+			case b, ok := <-blocked:
+				if !ok {
+					blocked = nil
+					continue
+				}
+
+				m.setBlocked(name, b)
+
+				if b.Active {
+					m.connectionEvent(&ConnectionEvent{Kind: BLOCKED_EVENT, Connection: name, Reason: b.Reason})
+					continue
+				}
+
+				m.connectionEvent(&ConnectionEvent{Kind: UNBLOCKED_EVENT, Connection: name})
+
+			case err := <-closed:
+				// the connection close also closes the channel
+				if m.closeGauge(name, false) {
+					m.connectionEvent(&ConnectionEvent{Kind: CHANNEL_CLOSED_EVENT, Connection: name, Err: amqpError(err)})
+				}
+
+				// the publishers waiting the unblock fail with the closed channel
+				m.setBlocked(name, amqp.Blocking{Active: false})
+				m.closeGauge(name, true)
+				m.connectionEvent(&ConnectionEvent{Kind: CONNECTION_CLOSED_EVENT, Connection: name, Err: amqpError(err)})
+				return
+			}
+		}
+	}()
+}
+
+// closeGauge returns false when it was already closed
+func (m *RabbitMQMessaging) closeGauge(name string, connection bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := m.watched[name]
+	value := &state.channels
+	if connection {
+		value = &state.connections
+	}
+
+	if *value == 0 {
+		return false
+	}
+
+	*value = 0
+	return true
+}
+
+func (m *RabbitMQMessaging) connectionEvent(evt *ConnectionEvent) {
+	if evt.Err != nil {
+		m.logger.Warn(LogMessage(fmt.Sprintf("%s: %s", evt.Kind, evt.Connection)), logging.ErrorField(evt.Err))
+	} else {
+		m.logger.Debug(LogMessage(fmt.Sprintf("%s: %s", evt.Kind, evt.Connection)))
+	}
+
+	recordConnectionEvent(evt)
+
+	m.mu.Lock()
+	hook := m.eventHook
+	m.mu.Unlock()
+
+	if hook != nil {
+		hook(evt)
+	}
+}
+
+// registerConnectionGauges the gauges are observed on each collection of the global meter provider, the instrument
+// callbacks are used since they are delegated when the provider is configured after the watch starts
+func (m *RabbitMQMessaging) registerConnectionGauges() error {
+	meter := otel.Meter(MeterName)
+
+	_, err := meter.Int64ObservableGauge(ConnectionsMetric, metric.WithDescription("open broker connections"),
+		metric.WithInt64Callback(m.observeConnections(func(state *connectionState) int64 { return state.connections })))
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.Int64ObservableGauge(ChannelsMetric, metric.WithDescription("open amqp channels"),
+		metric.WithInt64Callback(m.observeConnections(func(state *connectionState) int64 { return state.channels })))
+
+	return err
+}
+
+func (m *RabbitMQMessaging) observeConnections(value func(state *connectionState) int64) metric.Int64Callback {
+	return func(ctx context.Context, o metric.Int64Observer) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		for name, state := range m.watched {
+			o.Observe(value(state), metric.WithAttributes(attribute.String("messaging.connection", name)))
+		}
+
+		return nil
+	}
+}
+
+// recordConnectionEvent the counter is created on each call like recordHandlerTimeout
+func recordConnectionEvent(evt *ConnectionEvent) {
+	counter, err := otel.Meter(MeterName).Int64Counter(ConnectionEventsMetric, metric.WithDescription("broker connection events"))
+	if err != nil {
+		return
+	}
+
+	counter.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("messaging.connection", evt.Connection),
+		attribute.String("messaging.connection.event", string(evt.Kind)),
+	))
+}
+
+// amqpError the nil *amqp.Error of the graceful close is returned as a nil error
+func amqpError(err *amqp.Error) error {
+	if err == nil {
+		return nil
+	}
+
+	return err
+}
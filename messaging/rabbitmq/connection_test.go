@@ -0,0 +1,96 @@
+package rabbitmq
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/mock"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+func (s *RabbitMQMessagingSuiteTest) TestWatchConnections() {
+	closed, blocked := s.notifications()
+
+	reader := sdkMetric.NewManualReader()
+	otel.SetMeterProvider(sdkMetric.NewMeterProvider(sdkMetric.WithReader(reader)))
+	defer otel.SetMeterProvider(noop.NewMeterProvider())
+
+	mu := sync.Mutex{}
+	events := []*ConnectionEvent{}
+	received := make(chan struct{}, 10)
+	s.messaging.WatchConnections(func(evt *ConnectionEvent) {
+		mu.Lock()
+		events = append(events, evt)
+		mu.Unlock()
+		received <- struct{}{}
+	})
+
+	<-received
+	s.Equal(int64(1), s.gauge(reader, ChannelsMetric))
+
+	blocked <- amqp.Blocking{Active: true, Reason: "low on memory"}
+	<-received
+	blocked <- amqp.Blocking{Active: false}
+	<-received
+
+	closed <- &amqp.Error{Code: amqp.ConnectionForced, Reason: "CONNECTION_FORCED"}
+	<-received
+	<-received
+
+	mu.Lock()
+	s.Require().Len(events, 5)
+	s.Equal(CONNECTED_EVENT, events[0].Kind)
+	s.Equal(DefaultConnection, events[0].Connection)
+	s.Equal(BLOCKED_EVENT, events[1].Kind)
+	s.Equal("low on memory", events[1].Reason)
+	s.Equal(UNBLOCKED_EVENT, events[2].Kind)
+	s.Equal(CHANNEL_CLOSED_EVENT, events[3].Kind)
+	s.Equal(CONNECTION_CLOSED_EVENT, events[4].Kind)
+	s.Error(events[4].Err)
+	mu.Unlock()
+
+	s.Equal(int64(0), s.gauge(reader, ConnectionsMetric))
+	s.Equal(int64(0), s.gauge(reader, ChannelsMetric))
+
+	select {
+	case <-received:
+		s.Fail("unexpected event")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+// notifications mock the broker notifications of the default connection, the gauges are registered in a noop provider
+// unless the test configures one after it
+func (s *RabbitMQMessagingSuiteTest) notifications() (chan *amqp.Error, chan amqp.Blocking) {
+	otel.SetMeterProvider(noop.NewMeterProvider())
+
+	closed := make(chan *amqp.Error, 1)
+	blocked := make(chan amqp.Blocking, 1)
+
+	s.amqpConn.On("NotifyClose", mock.Anything).Return(closed)
+	s.amqpConn.On("NotifyBlocked", mock.Anything).Return(blocked)
+	s.amqpChannel.On("NotifyClose", mock.Anything).Return(make(chan *amqp.Error, 1))
+
+	return closed, blocked
+}
+
+func (s *RabbitMQMessagingSuiteTest) gauge(reader *sdkMetric.ManualReader, name string) int64 {
+	rm := metricdata.ResourceMetrics{}
+	s.Require().NoError(reader.Collect(context.Background(), &rm))
+
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if gauge, ok := m.Data.(metricdata.Gauge[int64]); ok && m.Name == name {
+				return gauge.DataPoints[0].Value
+			}
+		}
+	}
+
+	s.Fail("gauge not found", name)
+	return -1
+}
@@ -2,6 +2,8 @@ package rabbitmq
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"github.com/ralvescosta/gokit/logging"
 	"go.uber.org/zap/zapcore"
@@ -16,6 +18,8 @@ const (
 
 	DLQ_FALLBACK   FallbackType = "dlq"
 	RETRY_FALLBACK FallbackType = "delayed"
+	// RETRY_TIER_FALLBACK the prefix of the tiered retry queues declared by DeclareRetryTopology
+	RETRY_TIER_FALLBACK FallbackType = "retry"
 
 	DeclareErrorMessage = "[RabbitMQ::Connect] failure to declare %s: %s"
 	BindErrorMessage    = "[RabbitMQ::Connect] failure to bind %s: %s"
@@ -25,6 +29,76 @@ const (
 	AMQPHeaderNumberOfRetry = "x-count"
 	AMQPHeaderTraceID       = "x-trace-id"
 	AMQPHeaderDelay         = "x-delay"
+	// AMQPHeaderRequestID set from the publish ctx, the consumers receive it in the metadata Ctx, see requestid.FromContext
+	AMQPHeaderRequestID = "x-request-id"
+	// AMQPHeaderReplayed set in the messages published by Replay, e.g: to bypass the deduplication of the reprocessed messages
+	AMQPHeaderReplayed = "x-replayed"
+	// AMQPHeaderErrors the error history of the retried messages, see RetryEscalation
+	AMQPHeaderErrors = "x-errors"
+
+	// BLOCK_OVERFLOW the consumer waits a free buffer slot, the broker keeps the messages unacked meanwhile
+	BLOCK_OVERFLOW OverflowPolicy = 0
+	// NACK_REQUEUE_OVERFLOW the messages received when the buffer is full are nacked and requeued
+	NACK_REQUEUE_OVERFLOW OverflowPolicy = 1
+
+	DefaultConcurrencyWorkers = 1
+
+	PUBLISHED_TAP TapDirection = "published"
+	CONSUMED_TAP  TapDirection = "consumed"
+
+	DefaultTapMaxBodySize = 1024
+
+	DefaultArchiveTable = "archived_messages"
+
+	DefaultDedupWindow      = 5 * time.Minute
+	DefaultDedupRedisPrefix = "gokit:rabbitmq:dedup:"
+
+	ArchivePostgresSchema = `CREATE TABLE IF NOT EXISTS %s (
+	id           BIGSERIAL PRIMARY KEY,
+	queue        TEXT NOT NULL,
+	exchange     TEXT NOT NULL,
+	routing_key  TEXT NOT NULL,
+	message_id   TEXT NOT NULL,
+	type         TEXT NOT NULL,
+	content_type TEXT NOT NULL,
+	headers      TEXT NOT NULL,
+	body         BYTEA,
+	archived_at  TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX IF NOT EXISTS %s_queue_archived_at ON %s (queue, archived_at)`
+
+	TracerName = "github.com/ralvescosta/gokit/messaging/rabbitmq"
+	MeterName  = "github.com/ralvescosta/gokit/messaging/rabbitmq"
+
+	QUEUE_DRIFT    DriftKind = "queue"
+	EXCHANGE_DRIFT DriftKind = "exchange"
+	BINDING_DRIFT  DriftKind = "binding"
+
+	// DefaultConnection the connection name of the broker configured in New
+	DefaultConnection = "default"
+
+	CONNECTED_EVENT         ConnectionEventKind = "connected"
+	CONNECTION_CLOSED_EVENT ConnectionEventKind = "connection_closed"
+	CHANNEL_CLOSED_EVENT    ConnectionEventKind = "channel_closed"
+	// BLOCKED_EVENT the broker stopped reading the connection, e.g: the memory or the disk alarm, the publishes hang until UNBLOCKED_EVENT
+	BLOCKED_EVENT   ConnectionEventKind = "blocked"
+	UNBLOCKED_EVENT ConnectionEventKind = "unblocked"
+
+	DefaultFlowControlWait = 30 * time.Second
+
+	// ConnectionsMetric gauge of the open connections, labeled by connection
+	ConnectionsMetric = "messaging.connections"
+	// ChannelsMetric gauge of the open channels, labeled by connection
+	ChannelsMetric = "messaging.channels"
+	// ConnectionEventsMetric counter of the connection events, labeled by connection and event
+	ConnectionEventsMetric = "messaging.connection.events"
+
+	// HandlerTimeoutsMetric counter of the handlers that exceeded the HandlerTimeout, labeled by queue and type
+	HandlerTimeoutsMetric = "messaging.handler.timeouts"
+
+	// the pprof labels of the handlers, e.g: to filter the continuous profiler flame graphs by queue
+	QueueProfileLabel   = "queue"
+	MsgTypeProfileLabel = "msg_type"
 )
 
 var (
@@ -34,6 +108,31 @@ var (
 	ErrorRetryable                = errors.New("messaging failure to process send to retry latter")
 	ErrorReceivedMessageValidator = errors.New("messaging unformatted received message")
 	ErrorQueueDeclaration         = errors.New("to use dql feature the bind exchanges must be declared first")
+	ErrorRetryTopologyQueue       = errors.New("retry topology queue name is required")
+	ErrorQueueNotConsumed         = errors.New("messaging there is no consumer started to the queue")
+	ErrorDrainTimeout             = errors.New("messaging drain timeout, there are messages in-flight")
+	ErrorTopologyFile             = errors.New("messaging the topology file requires the exchange and the queue of each topology")
+	ErrorUnknownConnection        = errors.New("messaging there is no connection registered with the name")
+	ErrorTopologyDrift            = errors.New("messaging the broker topology differs from the declared topology")
+	ErrorArchiveDisabled          = errors.New("messaging the archive is not configured")
+	ErrorReplayQueue              = errors.New("messaging the replay queue is required")
+	ErrorRouteNotRegistered       = errors.New("messaging there is no publish route registered to the message type")
+	ErrorRouteType                = errors.New("messaging the publish route message type is required")
+	ErrorBrokerBlocked            = errors.New("messaging the broker is blocking the connection")
+
+	// ErrorHandlerTimeout wraps ErrorRetryable, so the timed out messages follow the queue retry policy
+	ErrorHandlerTimeout = fmt.Errorf("%w: handler timeout", ErrorRetryable)
+
+	DefaultRetryTiers = []time.Duration{5 * time.Second, 30 * time.Second, 2 * time.Minute}
+
+	// replayDiscardedHeaders the delivery state headers, the replayed messages are published as new messages
+	replayDiscardedHeaders = map[string]bool{
+		AMQPHeaderNumberOfRetry: true,
+		AMQPHeaderTraceID:       true,
+		AMQPHeaderDelay:         true,
+		AMQPHeaderErrors:        true,
+		"x-death":               true,
+	}
 )
 
 func LogMessage(msg string) string {
@@ -0,0 +1,79 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"os"
+	"sort"
+)
+
+// ConsumerTag a nil tag restore the DefaultConsumerTag
+func (m *RabbitMQMessaging) ConsumerTag(tag ConsumerTagFunc) IRabbitMQMessaging {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.consumerTag = tag
+
+	return m
+}
+
+// DefaultConsumerTag <service>.<hostname>.<pid>.<queue>.<seq>, e.g: orders.orders-5d9c7-x2kqz.1.orders-created.1
+func DefaultConsumerTag(info *ConsumerInfo, seq int) string {
+	return fmt.Sprintf("%s.%s.%d.%s.%d", info.Service, info.Hostname, info.PID, info.Queue, seq)
+}
+
+// Consumers the started consumers sorted by tag, e.g: to expose in an admin endpoint and find them in the management UI
+func (m *RabbitMQMessaging) Consumers() []*ConsumerInfo {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	consumers := make([]*ConsumerInfo, 0, len(m.consumerStates))
+	for _, state := range m.consumerStates {
+		info := *state.info
+		info.Paused = state.paused
+		consumers = append(consumers, &info)
+	}
+
+	sort.Slice(consumers, func(i, j int) bool { return consumers[i].Tag < consumers[j].Tag })
+
+	return consumers
+}
+
+// newConsumerInfo the tag must be unique in the channel, the seq distinguishes the dispatchers of the same queue,
+// the caller must hold mu
+func (m *RabbitMQMessaging) newConsumerInfo(d *Dispatcher) *ConsumerInfo {
+	hostname, _ := os.Hostname()
+
+	info := &ConsumerInfo{
+		Queue:      d.Queue,
+		MsgType:    d.MsgType,
+		Connection: d.Topology.Connection,
+		Service:    m.config.APP_NAME,
+		Hostname:   hostname,
+		PID:        os.Getpid(),
+	}
+
+	if info.Connection == "" {
+		info.Connection = DefaultConnection
+	}
+
+	tag := m.consumerTag
+	if tag == nil {
+		tag = DefaultConsumerTag
+	}
+
+	m.consumerSeq++
+	info.Tag = tag(info, m.consumerSeq)
+
+	// the amqp short string limit
+	if len(info.Tag) > 255 {
+		info.Tag = info.Tag[:255]
+	}
+
+	return info
+}
+
+// connectionName the client connection name shown in the management UI, e.g: orders@orders-5d9c7-x2kqz:1
+func connectionName(service string) string {
+	hostname, _ := os.Hostname()
+	return fmt.Sprintf("%s@%s:%d", service, hostname, os.Getpid())
+}
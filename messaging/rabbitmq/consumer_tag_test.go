@@ -0,0 +1,75 @@
+package rabbitmq
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *RabbitMQMessagingSuiteTest) TestDefaultConsumerTag() {
+	info := &ConsumerInfo{Service: "orders", Hostname: "host", PID: 10, Queue: "orders-created"}
+
+	s.Equal("orders.host.10.orders-created.2", DefaultConsumerTag(info, 2))
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestConsumers() {
+	s.cfg.APP_NAME = "orders"
+
+	d, rootChan, _ := s.senary(nil)
+	other, otherChan, _ := s.senary(nil)
+	other.MsgType = "other"
+	s.messaging.dispatchers = []*Dispatcher{d, other}
+
+	var deliveryChan <-chan amqp.Delivery = rootChan
+	var otherDeliveryChan <-chan amqp.Delivery = otherChan
+	hostname, _ := os.Hostname()
+	prefix := fmt.Sprintf("orders.%s.%d.queue.", hostname, os.Getpid())
+
+	consumed := make(chan struct{}, 2)
+	cancelled := make(chan struct{}, 2)
+
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
+	s.amqpChannel.
+		On("Consume", d.Queue, prefix+"1", false, false, false, false, amqp.Table(nil)).
+		Run(func(args mock.Arguments) { consumed <- struct{}{} }).
+		Return(deliveryChan, nil).
+		Once()
+	s.amqpChannel.
+		On("Consume", d.Queue, prefix+"2", false, false, false, false, amqp.Table(nil)).
+		Run(func(args mock.Arguments) { consumed <- struct{}{} }).
+		Return(otherDeliveryChan, nil).
+		Once()
+	s.amqpChannel.
+		On("Cancel", mock.AnythingOfType("string"), false).
+		Run(func(args mock.Arguments) {
+			defer func() { cancelled <- struct{}{} }()
+
+			if args.String(0) == prefix+"1" {
+				close(rootChan)
+				return
+			}
+			close(otherChan)
+		}).
+		Return(nil).
+		Twice()
+
+	go func() { _ = s.messaging.Consume() }()
+	<-consumed
+	<-consumed
+
+	// the consumers that are registering the tag are cancelled after the Consume
+	s.NoError(s.messaging.PauseQueue(d.Queue))
+	<-cancelled
+	<-cancelled
+
+	consumers := s.messaging.Consumers()
+	s.Equal(prefix+"1", consumers[0].Tag)
+	s.Equal(prefix+"2", consumers[1].Tag)
+	s.Equal("orders", consumers[0].Service)
+	s.Equal(DefaultConnection, consumers[0].Connection)
+	s.Equal(os.Getpid(), consumers[0].PID)
+	s.True(consumers[0].Paused)
+	s.amqpChannel.AssertExpectations(s.T())
+}
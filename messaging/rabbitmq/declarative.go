@@ -0,0 +1,104 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"os"
+	"time"
+)
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+
+	*d = Duration(parsed)
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(d).String())
+}
+
+// LoadTopologyFile read the declarative topology from a json file
+func LoadTopologyFile(path string) (*TopologyFile, error) {
+	byt, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file := &TopologyFile{}
+	if err := json.Unmarshal(byt, file); err != nil {
+		return nil, err
+	}
+
+	for _, spec := range file.Topologies {
+		if spec.Exchange == "" || spec.Queue == "" {
+			return nil, ErrorTopologyFile
+		}
+	}
+
+	for _, spec := range file.RetryTopologies {
+		if spec.Queue == "" {
+			return nil, ErrorRetryTopologyQueue
+		}
+	}
+
+	return file, nil
+}
+
+// ToTopologies the file topologies to be declared with IRabbitMQMessaging.Declare
+func (f *TopologyFile) ToTopologies() []*Topology {
+	topologies := make([]*Topology, 0, len(f.Topologies))
+
+	for _, spec := range f.Topologies {
+		kind := spec.ExchangeType
+		if kind == "" {
+			kind = DIRECT_EXCHANGE
+		}
+
+		t := &Topology{
+			Queue:    &QueueOpts{Name: spec.Queue, TTL: time.Duration(spec.TTL), WithDeadLatter: spec.DeadLetter, HandlerTimeout: time.Duration(spec.HandlerTimeout)},
+			Exchange: &ExchangeOpts{Name: spec.Exchange, Type: kind, Bindings: spec.ExchangeBindings},
+		}
+
+		if spec.Retry != nil {
+			t.Queue.Retryable = &Retry{NumberOfRetry: spec.Retry.Attempts, DelayBetween: time.Duration(spec.Retry.Delay)}
+		}
+
+		topologies = append(topologies, t)
+	}
+
+	return topologies
+}
+
+// Apply declare the file topologies and build the messaging, the retry topologies are declared after the exchanges they are bound to
+func (f *TopologyFile) Apply(messaging IRabbitMQMessaging) (IRabbitMQMessaging, error) {
+	for _, t := range f.ToTopologies() {
+		messaging.Declare(t)
+	}
+
+	messaging, err := messaging.ApplyBinds().Build()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, spec := range f.RetryTopologies {
+		tiers := make([]time.Duration, 0, len(spec.Tiers))
+		for _, tier := range spec.Tiers {
+			tiers = append(tiers, time.Duration(tier))
+		}
+
+		_, err := messaging.DeclareRetryTopology(&RetryTopologyOpts{Queue: spec.Queue, Exchange: spec.Exchange, RoutingKey: spec.RoutingKey, Tiers: tiers})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return messaging, nil
+}
@@ -0,0 +1,66 @@
+package rabbitmq
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeclarativeTopologyTestSuite struct {
+	suite.Suite
+}
+
+func TestDeclarativeTopologyTestSuite(t *testing.T) {
+	suite.Run(t, new(DeclarativeTopologyTestSuite))
+}
+
+func (s *DeclarativeTopologyTestSuite) write(content string) string {
+	path := filepath.Join(s.T().TempDir(), "topology.json")
+	s.NoError(os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func (s *DeclarativeTopologyTestSuite) TestLoadTopologyFile() {
+	file, err := LoadTopologyFile(s.write(`{
+		"topologies": [{"exchange": "orders", "queue": "orders.created", "ttl": "1m", "handlerTimeout": "30s", "retry": {"attempts": 3, "delay": "5s"}}],
+		"retryTopologies": [{"queue": "payments", "exchange": "orders", "tiers": ["5s", "1m"]}]
+	}`))
+	s.NoError(err)
+
+	topologies := file.ToTopologies()
+	s.Len(topologies, 1)
+	s.Equal(DIRECT_EXCHANGE, topologies[0].Exchange.Type)
+	s.Equal(time.Minute, topologies[0].Queue.TTL)
+	s.Equal(30*time.Second, topologies[0].Queue.HandlerTimeout)
+	s.Equal(&Retry{NumberOfRetry: 3, DelayBetween: 5 * time.Second}, topologies[0].Queue.Retryable)
+	s.Equal([]Duration{Duration(5 * time.Second), Duration(time.Minute)}, file.RetryTopologies[0].Tiers)
+
+	_, err = LoadTopologyFile(s.write(`{"topologies": [{"queue": "orders.created"}]}`))
+	s.ErrorIs(err, ErrorTopologyFile)
+
+	_, err = LoadTopologyFile(s.write(`{"topologies": [{"exchange": "orders", "queue": "orders.created", "ttl": "invalid"}]}`))
+	s.Error(err)
+}
+
+func (s *DeclarativeTopologyTestSuite) TestApply() {
+	file, err := LoadTopologyFile(s.write(`{
+		"topologies": [{"exchange": "orders", "exchangeType": "fanout", "queue": "orders.created"}],
+		"retryTopologies": [{"queue": "payments", "exchange": "orders", "tiers": ["5s"]}]
+	}`))
+	s.NoError(err)
+
+	messaging := NewMockRabbitMQMessaging()
+	messaging.On("Declare", mock.MatchedBy(func(t *Topology) bool { return t.Queue.Name == "orders.created" })).Return(messaging)
+	messaging.On("ApplyBinds", nil).Return(messaging)
+	messaging.On("Build", nil).Return(messaging, nil)
+	messaging.On("DeclareRetryTopology", &RetryTopologyOpts{Queue: "payments", Exchange: "orders", Tiers: []time.Duration{5 * time.Second}}).Return(&RetryTopology{}, nil)
+
+	_, err = file.Apply(messaging)
+	s.NoError(err)
+	messaging.AssertExpectations(s.T())
+}
@@ -0,0 +1,156 @@
+package rabbitmq
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	memoryDedupStore struct {
+		mu      sync.Mutex
+		keys    map[string]time.Time
+		timeNow func() time.Time
+	}
+
+	redisDedupStore struct {
+		client redis.UniversalClient
+		prefix string
+	}
+)
+
+// DeduplicatePublish a nil opts or a nil store disable the deduplication
+func (m *RabbitMQMessaging) DeduplicatePublish(opts *DedupPublishOpts) IRabbitMQMessaging {
+	if opts == nil || opts.Store == nil {
+		m.dedup = nil
+		return m
+	}
+
+	if opts.Window <= 0 {
+		opts.Window = DefaultDedupWindow
+	}
+
+	if opts.Hash == nil {
+		opts.Hash = ContentHash
+	}
+
+	m.dedup = opts
+
+	return m
+}
+
+// ContentHash sha256 of the exchange, the routing key, the type and the body, the message id is not used since the
+// upstream systems usually emit the repeated events with new ids
+func ContentHash(exchange, routingKey, typ string, body []byte) string {
+	h := sha256.New()
+	for _, part := range []string{exchange, routingKey, typ} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	h.Write(body)
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// reserveContent returns false when the same content was published in the window, the store failures publish the message.
+// The replayed messages are never deduplicated
+func (m *RabbitMQMessaging) reserveContent(exchange, routingKey string, body []byte, opts *PublishOpts) (string, bool) {
+	if m.dedup == nil {
+		return "", true
+	}
+
+	if _, replayed := opts.Headers[AMQPHeaderReplayed]; replayed {
+		return "", true
+	}
+
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	key := m.dedup.Hash(exchange, routingKey, opts.Type, body)
+	reserved, err := m.dedup.Store.Reserve(ctx, key, m.dedup.Window)
+	if err != nil {
+		m.logger.Warn(LogMessage("failure to reserve the content hash, publishing the message"), logging.ErrorField(err))
+		return "", true
+	}
+
+	if !reserved {
+		m.logger.Debug(LogMsgWithMessageId("skipping the duplicated message", opts.MessageId))
+		return "", false
+	}
+
+	return key, true
+}
+
+// releaseContent the failed publishes could be retried in the window
+func (m *RabbitMQMessaging) releaseContent(key string, opts *PublishOpts) {
+	if key == "" {
+		return
+	}
+
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if err := m.dedup.Store.Release(ctx, key); err != nil {
+		m.logger.Warn(LogMessage("failure to release the content hash"), logging.ErrorField(err))
+	}
+}
+
+// NewMemoryDedupStore the hashes are not shared between the instances
+func NewMemoryDedupStore() DedupStore {
+	return &memoryDedupStore{keys: map[string]time.Time{}, timeNow: time.Now}
+}
+
+func (s *memoryDedupStore) Reserve(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.timeNow()
+	if expiresAt, ok := s.keys[key]; ok && expiresAt.After(now) {
+		return false, nil
+	}
+
+	// the expired hashes are removed while the new ones are reserved
+	for k, expiresAt := range s.keys {
+		if !expiresAt.After(now) {
+			delete(s.keys, k)
+		}
+	}
+
+	s.keys[key] = now.Add(ttl)
+	return true, nil
+}
+
+func (s *memoryDedupStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.keys, key)
+	return nil
+}
+
+// NewRedisDedupStore reserve the hashes with SET NX and the window as expiration
+func NewRedisDedupStore(client redis.UniversalClient, prefix string) DedupStore {
+	if prefix == "" {
+		prefix = DefaultDedupRedisPrefix
+	}
+
+	return &redisDedupStore{client, prefix}
+}
+
+func (s *redisDedupStore) Reserve(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	return s.client.SetNX(ctx, s.prefix+key, 1, ttl).Result()
+}
+
+func (s *redisDedupStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, s.prefix+key).Err()
+}
@@ -0,0 +1,83 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *RabbitMQMessagingSuiteTest) TestDeduplicatePublish() {
+	s.messaging.DeduplicatePublish(&DedupPublishOpts{Store: NewMemoryDedupStore()})
+
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Twice()
+
+	s.NoError(s.messaging.Publisher("exchange", "key", &MsgBody{}, &PublishOpts{Type: "type", MessageId: "1"}))
+	s.NoError(s.messaging.Publisher("exchange", "key", &MsgBody{}, &PublishOpts{Type: "type", MessageId: "2"}))
+	s.NoError(s.messaging.Publisher("exchange", "key", &MsgBody{}, &PublishOpts{Type: "other", MessageId: "3"}))
+
+	// the replayed messages are not deduplicated
+	s.amqpChannel.
+		On("Publish", "", "queue", false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Twice()
+
+	headers := map[string]interface{}{AMQPHeaderReplayed: true}
+	s.NoError(s.messaging.Publisher("", "queue", &MsgBody{}, &PublishOpts{Type: "type", Headers: headers}))
+	s.NoError(s.messaging.Publisher("", "queue", &MsgBody{}, &PublishOpts{Type: "type", Headers: headers}))
+
+	s.amqpChannel.AssertExpectations(s.T())
+
+	s.messaging.DeduplicatePublish(nil)
+	s.Nil(s.messaging.dedup)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestDeduplicatePublishFailure() {
+	s.messaging.DeduplicatePublish(&DedupPublishOpts{Store: NewMemoryDedupStore()})
+
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool { return pub.MessageId == "1" })).
+		Return(errors.New("closed"))
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool { return pub.MessageId == "2" })).
+		Return(nil)
+
+	// the failed publish releases the hash, so the retry is published
+	s.Error(s.messaging.Publisher("exchange", "key", &MsgBody{}, &PublishOpts{Type: "type", MessageId: "1"}))
+	s.NoError(s.messaging.Publisher("exchange", "key", &MsgBody{}, &PublishOpts{Type: "type", MessageId: "2"}))
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestDedupStores() {
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(s.T()).Addr()})
+	ctx := context.Background()
+
+	for _, store := range []DedupStore{NewMemoryDedupStore(), NewRedisDedupStore(client, "")} {
+		reserved, err := store.Reserve(ctx, "hash", time.Minute)
+		s.NoError(err)
+		s.True(reserved)
+
+		reserved, _ = store.Reserve(ctx, "hash", time.Minute)
+		s.False(reserved)
+
+		s.NoError(store.Release(ctx, "hash"))
+		reserved, _ = store.Reserve(ctx, "hash", time.Minute)
+		s.True(reserved)
+	}
+
+	memory := NewMemoryDedupStore().(*memoryDedupStore)
+	now := time.Now()
+	memory.timeNow = func() time.Time { return now }
+	memory.Reserve(ctx, "hash", time.Minute)
+
+	now = now.Add(2 * time.Minute)
+	reserved, _ := memory.Reserve(ctx, "hash", time.Minute)
+	s.True(reserved)
+}
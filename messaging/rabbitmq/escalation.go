@@ -0,0 +1,59 @@
+package rabbitmq
+
+import (
+	"github.com/streadway/amqp"
+)
+
+// escalate record the handler error in the delivery error history and call the OnAttempt or the OnExhausted hook, the
+// history is sent in the AMQPHeaderErrors header of the retried message so the next attempts receive it
+func (m *RabbitMQMessaging) escalate(escalation *RetryEscalation, exhausted bool, d *Dispatcher, msg any, metadata *DeliveryMetadata, received *amqp.Delivery, err error) {
+	history := errorHistory(received)
+	history = append(history, err.Error())
+
+	if received.Headers == nil {
+		received.Headers = amqp.Table{}
+	}
+
+	values := make([]interface{}, 0, len(history))
+	for _, e := range history {
+		values = append(values, e)
+	}
+	received.Headers[AMQPHeaderErrors] = values
+
+	if escalation == nil {
+		return
+	}
+
+	hook := escalation.OnAttempt
+	if exhausted {
+		hook = escalation.OnExhausted
+	}
+
+	if hook == nil {
+		return
+	}
+
+	hook(&FailedDelivery{
+		Queue:    d.Queue,
+		Msg:      msg,
+		Metadata: metadata,
+		Delivery: received,
+		Attempt:  metadata.XCount + 1,
+		Err:      err,
+		Errors:   history,
+	})
+}
+
+// errorHistory the errors of the previous attempts
+func errorHistory(received *amqp.Delivery) []string {
+	values, _ := received.Headers[AMQPHeaderErrors].([]interface{})
+
+	history := make([]string, 0, len(values)+1)
+	for _, v := range values {
+		if e, ok := v.(string); ok {
+			history = append(history, e)
+		}
+	}
+
+	return history
+}
@@ -0,0 +1,114 @@
+package rabbitmq
+
+import (
+	"errors"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *RabbitMQMessagingSuiteTest) TestRetryEscalationOnAttempt() {
+	d, _, delivery := s.senary(ErrorRetryable)
+
+	var attempt, exhausted *FailedDelivery
+	d.Topology.Queue.Retryable.Escalation = &RetryEscalation{
+		OnAttempt:   func(failure *FailedDelivery) { attempt = failure },
+		OnExhausted: func(failure *FailedDelivery) { exhausted = failure },
+	}
+
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			errs, _ := pub.Headers[AMQPHeaderErrors].([]interface{})
+			return pub.Headers[AMQPHeaderNumberOfRetry] == int64(2) && len(errs) == 2
+		})).
+		Return(nil).
+		Once()
+
+	ack := &recordAcknowledger{}
+	delivery.Acknowledger = ack
+	delivery.Headers[AMQPHeaderNumberOfRetry] = int64(1)
+	delivery.Headers[AMQPHeaderErrors] = []interface{}{"connection refused"}
+
+	s.messaging.handleDelivery(d, &delivery)
+
+	s.Nil(exhausted)
+	s.Require().NotNil(attempt)
+	s.Equal("queue", attempt.Queue)
+	s.Equal(int64(2), attempt.Attempt)
+	s.ErrorIs(attempt.Err, ErrorRetryable)
+	s.Equal([]string{"connection refused", ErrorRetryable.Error()}, attempt.Errors)
+	s.IsType(&MsgBody{}, attempt.Msg)
+	s.Equal(delivery.Body, attempt.Delivery.Body)
+	s.Len(ack.acks, 1)
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestRetryEscalationOnExhausted() {
+	d, _, delivery := s.senary(ErrorRetryable)
+
+	var exhausted *FailedDelivery
+	d.Topology.Queue.Retryable.Escalation = &RetryEscalation{
+		OnExhausted: func(failure *FailedDelivery) { exhausted = failure },
+	}
+
+	ack := &recordAcknowledger{}
+	delivery.Acknowledger = ack
+	delivery.Headers[AMQPHeaderNumberOfRetry] = int64(3)
+
+	s.messaging.handleDelivery(d, &delivery)
+
+	s.Require().NotNil(exhausted)
+	s.Equal(int64(4), exhausted.Attempt)
+	s.Equal([]string{ErrorRetryable.Error()}, exhausted.Errors)
+	s.Equal([]bool{false}, ack.requeues)
+	s.amqpChannel.AssertNotCalled(s.T(), "Publish")
+
+	// the errors that are not retryable are exhausted in the first attempt
+	d, _, delivery = s.senary(errors.New("invalid order"))
+	d.Topology.Queue.Retryable.Escalation = &RetryEscalation{
+		OnExhausted: func(failure *FailedDelivery) { exhausted = failure },
+	}
+	delivery.Acknowledger = &recordAcknowledger{}
+
+	s.messaging.handleDelivery(d, &delivery)
+	s.Equal(int64(1), exhausted.Attempt)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestRetryTopologyEscalation() {
+	d, _, delivery := s.senary(ErrorRetryable)
+
+	var attempts, exhausted int
+	d.Topology.retry = &RetryTopology{
+		Queue:     "queue",
+		DLQ:       "dlq-queue",
+		Tiers:     []*RetryTier{{Queue: "retry-1s-queue", TTL: time.Second}},
+		messaging: s.messaging,
+		escalation: &RetryEscalation{
+			OnAttempt:   func(failure *FailedDelivery) { attempts++ },
+			OnExhausted: func(failure *FailedDelivery) { exhausted++ },
+		},
+	}
+
+	s.amqpChannel.
+		On("Publish", "", "retry-1s-queue", false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Once()
+	s.amqpChannel.
+		On("Publish", "", "dlq-queue", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			errs, _ := pub.Headers[AMQPHeaderErrors].([]interface{})
+			return len(errs) == 2
+		})).
+		Return(nil).
+		Once()
+
+	delivery.Acknowledger = &recordAcknowledger{}
+	s.messaging.handleDelivery(d, &delivery)
+
+	delivery.Headers[AMQPHeaderNumberOfRetry] = int64(1)
+	s.messaging.handleDelivery(d, &delivery)
+
+	s.Equal(1, attempts)
+	s.Equal(1, exhausted)
+	s.amqpChannel.AssertExpectations(s.T())
+}
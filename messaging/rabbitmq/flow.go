@@ -0,0 +1,116 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/streadway/amqp"
+)
+
+// FlowControl a nil opts disable the flow control, the connections are watched like WatchConnections
+func (m *RabbitMQMessaging) FlowControl(opts *FlowControlOpts) IRabbitMQMessaging {
+	if m.Err != nil {
+		return m
+	}
+
+	if opts != nil && opts.Wait == 0 {
+		opts.Wait = DefaultFlowControlWait
+	}
+
+	m.mu.Lock()
+	m.flowControl = opts
+	m.mu.Unlock()
+
+	if opts != nil {
+		m.watchConnections()
+	}
+
+	return m
+}
+
+// IsBlocked any watched connection is blocked by the broker flow control, e.g: to the health.RabbitMQFlowProbe
+func (m *RabbitMQMessaging) IsBlocked() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, state := range m.watched {
+		if state.unblocked != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// setBlocked the unblocked channel is closed to release the waiting publishers
+func (m *RabbitMQMessaging) setBlocked(name string, b amqp.Blocking) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state := m.watched[name]
+	if b.Active {
+		if state.unblocked == nil {
+			state.unblocked = make(chan struct{})
+		}
+		state.reason = b.Reason
+		return
+	}
+
+	if state.unblocked != nil {
+		close(state.unblocked)
+		state.unblocked = nil
+		state.reason = ""
+	}
+}
+
+// awaitFlowControl returns true when the publishing was diverted, while the connection is blocked the publishing is
+// diverted, or the publisher waits the unblock up to the Wait and the publish context deadline
+func (m *RabbitMQMessaging) awaitFlowControl(exchange, routingKey string, pub amqp.Publishing, opts *PublishOpts) (bool, error) {
+	name := opts.Connection
+	if name == "" {
+		name = DefaultConnection
+	}
+
+	m.mu.Lock()
+	flow := m.flowControl
+	var unblocked chan struct{}
+	reason := ""
+	if state, ok := m.watched[name]; ok {
+		unblocked = state.unblocked
+		reason = state.reason
+	}
+	m.mu.Unlock()
+
+	if flow == nil || unblocked == nil {
+		return false, nil
+	}
+
+	if flow.Divert != nil {
+		m.logger.Warn(LogMsgWithMessageId(fmt.Sprintf("broker blocked: %s - diverting the message", reason), pub.MessageId))
+		return true, flow.Divert(exchange, routingKey, pub)
+	}
+
+	if flow.Wait < 0 {
+		return false, ErrorBrokerBlocked
+	}
+
+	ctx := opts.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	m.logger.Warn(LogMsgWithMessageId(fmt.Sprintf("broker blocked: %s - waiting the unblock", reason), pub.MessageId))
+
+	timer := time.NewTimer(flow.Wait)
+	defer timer.Stop()
+
+	select {
+	case <-unblocked:
+		return false, nil
+	case <-timer.C:
+		return false, ErrorBrokerBlocked
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+}
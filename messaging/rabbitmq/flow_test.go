@@ -0,0 +1,84 @@
+package rabbitmq
+
+import (
+	"context"
+	"time"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/mock"
+)
+
+// block send the blocked notification and wait the watcher to apply it
+func (s *RabbitMQMessagingSuiteTest) block(blocked chan amqp.Blocking) {
+	blocked <- amqp.Blocking{Active: true, Reason: "low on memory"}
+	s.Eventually(s.messaging.IsBlocked, time.Second, time.Millisecond)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestFlowControlWait() {
+	_, blocked := s.notifications()
+	s.messaging.FlowControl(&FlowControlOpts{Wait: time.Second})
+	s.block(blocked)
+
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Once()
+
+	published := make(chan error)
+	go func() {
+		published <- s.messaging.Publisher("exchange", "key", &MsgBody{}, nil)
+	}()
+
+	select {
+	case <-published:
+		s.Fail("the publish must wait the unblock")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	blocked <- amqp.Blocking{Active: false}
+	s.NoError(<-published)
+	s.False(s.messaging.IsBlocked())
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestFlowControlTimeout() {
+	_, blocked := s.notifications()
+	s.messaging.FlowControl(&FlowControlOpts{Wait: 10 * time.Millisecond})
+	s.block(blocked)
+
+	s.ErrorIs(s.messaging.Publisher("exchange", "key", &MsgBody{}, nil), ErrorBrokerBlocked)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.messaging.FlowControl(&FlowControlOpts{Wait: time.Second})
+	s.ErrorIs(s.messaging.Publisher("exchange", "key", &MsgBody{}, &PublishOpts{Ctx: ctx}), context.Canceled)
+
+	s.messaging.FlowControl(&FlowControlOpts{Wait: -1})
+	s.ErrorIs(s.messaging.Publisher("exchange", "key", &MsgBody{}, nil), ErrorBrokerBlocked)
+	s.amqpChannel.AssertNotCalled(s.T(), "Publish")
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestFlowControlDivert() {
+	_, blocked := s.notifications()
+
+	diverted := []amqp.Publishing{}
+	s.messaging.FlowControl(&FlowControlOpts{Divert: func(exchange, routingKey string, pub amqp.Publishing) error {
+		diverted = append(diverted, pub)
+		return nil
+	}})
+	s.block(blocked)
+
+	s.NoError(s.messaging.Publisher("exchange", "key", &MsgBody{}, &PublishOpts{MessageId: "id"}))
+	s.Require().Len(diverted, 1)
+	s.Equal("id", diverted[0].MessageId)
+	s.amqpChannel.AssertNotCalled(s.T(), "Publish")
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestFlowControlConnectionClosed() {
+	closed, blocked := s.notifications()
+	s.messaging.FlowControl(&FlowControlOpts{})
+	s.block(blocked)
+
+	closed <- &amqp.Error{Code: amqp.ConnectionForced}
+	s.Eventually(func() bool { return !s.messaging.IsBlocked() }, time.Second, time.Millisecond)
+}
@@ -1,16 +1,29 @@
 package rabbitmq
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/fnv"
 	"reflect"
+	"runtime/pprof"
+	"strconv"
+	"sync"
 	"time"
 
-	"github.com/google/uuid"
 	"github.com/streadway/amqp"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/ralvescosta/gokit/env"
+	gokitErrors "github.com/ralvescosta/gokit/errors"
+	"github.com/ralvescosta/gokit/guid"
 	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/cloudevents"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq/management"
+	"github.com/ralvescosta/gokit/messaging/registry"
+	"github.com/ralvescosta/gokit/pool"
+	"github.com/ralvescosta/gokit/requestid"
 )
 
 // New(...) create a new instance for IRabbitMQMessaging
@@ -24,6 +37,10 @@ func New(cfg *env.Configs, logger logging.ILogger) IRabbitMQMessaging {
 		topologies:  []*Topology{},
 	}
 
+	if cfg.RABBIT_MANAGEMENT_URL != "" {
+		rb.management = management.New(logger, management.NewConfig(cfg))
+	}
+
 	logger.Debug(LogMessage("connecting to rabbitmq..."))
 	conn, err := dial(cfg)
 	if err != nil {
@@ -49,8 +66,90 @@ func New(cfg *env.Configs, logger logging.ILogger) IRabbitMQMessaging {
 	return rb
 }
 
+// dial the amqp.Dial defaults with the connection name, so the connections are identified in the management UI
 var dial = func(cfg *env.Configs) (AMQPConnection, error) {
-	return amqp.Dial(fmt.Sprintf("amqp://%s:%s@%s:%s", cfg.RABBIT_USER, cfg.RABBIT_PASSWORD, cfg.RABBIT_VHOST, cfg.RABBIT_PORT))
+	return amqp.DialConfig(fmt.Sprintf("amqp://%s:%s@%s:%s", cfg.RABBIT_USER, cfg.RABBIT_PASSWORD, cfg.RABBIT_VHOST, cfg.RABBIT_PORT), amqp.Config{
+		Heartbeat:  10 * time.Second,
+		Locale:     "en_US",
+		Properties: amqp.Table{"connection_name": connectionName(cfg.APP_NAME)},
+	})
+}
+
+var openChannel = func(conn AMQPConnection) (AMQPChannel, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+
+	return ch, nil
+}
+
+func (m *RabbitMQMessaging) AddConnection(name string, cfg *env.Configs) IRabbitMQMessaging {
+	if m.Err != nil {
+		return m
+	}
+
+	m.logger.Debug(LogMessage(fmt.Sprintf("connecting to rabbitmq: %s...", name)))
+	conn, err := dial(cfg)
+	if err != nil {
+		m.logger.Error(LogMessage("failure to connect to the broker"), logging.ErrorField(err))
+		m.Err = ErrorConnection
+		return m
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		m.logger.Error(LogMessage("failure to establish the channel"), logging.ErrorField(err))
+		m.Err = ErrorChannel
+		return m
+	}
+	m.logger.Debug(LogMessage(fmt.Sprintf("connected to rabbitmq: %s", name)))
+
+	if m.connections == nil {
+		m.connections = map[string]*namedConnection{}
+	}
+
+	m.connections[name] = &namedConnection{conn, ch}
+
+	m.mu.Lock()
+	watching := m.watched != nil
+	m.mu.Unlock()
+
+	if watching {
+		m.watchConnection(name, conn, ch)
+	}
+
+	return m
+}
+
+// connection returns the named connection, the empty name is the default connection
+func (m *RabbitMQMessaging) connection(name string) (AMQPConnection, error) {
+	if name == "" || name == DefaultConnection {
+		return m.conn, nil
+	}
+
+	c, ok := m.connections[name]
+	if !ok {
+		m.logger.Error(LogMessage(fmt.Sprintf("unknown connection: %s", name)))
+		return nil, ErrorUnknownConnection
+	}
+
+	return c.conn, nil
+}
+
+// channel returns the channel of the named connection, the empty name is the default connection
+func (m *RabbitMQMessaging) channel(name string) (AMQPChannel, error) {
+	if name == "" || name == DefaultConnection {
+		return m.ch, nil
+	}
+
+	c, ok := m.connections[name]
+	if !ok {
+		m.logger.Error(LogMessage(fmt.Sprintf("unknown connection: %s", name)))
+		return nil, ErrorUnknownConnection
+	}
+
+	return c.ch, nil
 }
 
 func (m *RabbitMQMessaging) Declare(opts *Topology) IRabbitMQMessaging {
@@ -129,6 +228,10 @@ func (m *RabbitMQMessaging) Build() (IRabbitMQMessaging, error) {
 	}
 
 	for _, d := range m.topologies {
+		if d.retry != nil {
+			continue
+		}
+
 		m.logger.Debug(LogMessage("declaring exchanges..."))
 		if err := m.declareExchange(d); err != nil {
 			m.logger.Error(LogMessage("declare exchange err"), logging.ErrorField(err))
@@ -161,7 +264,19 @@ func (m *RabbitMQMessaging) Build() (IRabbitMQMessaging, error) {
 	return m, m.Err
 }
 
+func (m *RabbitMQMessaging) ValidatePublish(validate PublishValidator) IRabbitMQMessaging {
+	m.validatePublish = validate
+	return m
+}
+
 func (m *RabbitMQMessaging) Publisher(exchange, routingKey string, msg any, opts *PublishOpts) error {
+	if m.validatePublish != nil {
+		if err := m.validatePublish(msg); err != nil {
+			m.logger.Error(LogMessage("publisher validation"), logging.ErrorField(err))
+			return err
+		}
+	}
+
 	byt, err := json.Marshal(msg)
 	if err != nil {
 		m.logger.Error(LogMessage("publisher marshal"), logging.ErrorField(err))
@@ -169,22 +284,129 @@ func (m *RabbitMQMessaging) Publisher(exchange, routingKey string, msg any, opts
 	}
 
 	if opts == nil {
-		opts = m.newPubOpts(fmt.Sprintf("%T", msg))
+		opts = NewPublishOpts(msg)
+	}
+
+	return m.publish(exchange, routingKey, JsonContentType, byt, opts)
+}
+
+func (m *RabbitMQMessaging) PublishProto(exchange, routingKey string, msg proto.Message, opts *PublishOpts) error {
+	name, byt, err := registry.Default.Marshal(msg)
+	if err != nil {
+		m.logger.Error(LogMessage("publisher proto marshal"), logging.ErrorField(err))
+		return err
+	}
+
+	if opts == nil {
+		opts = NewPublishOpts(msg)
 	}
+	opts.Type = name
+
+	return m.publish(exchange, routingKey, registry.ProtobufContentType, byt, opts)
+}
 
-	return m.ch.Publish(exchange, routingKey, false, false, amqp.Publishing{
+func (m *RabbitMQMessaging) publish(exchange, routingKey, contentType string, byt []byte, opts *PublishOpts) error {
+	pub := amqp.Publishing{
 		Headers: amqp.Table{
 			AMQPHeaderNumberOfRetry: opts.Count,
 			AMQPHeaderTraceID:       opts.TraceId,
 			AMQPHeaderDelay:         opts.Delay.Milliseconds(),
 		},
 		Type:        opts.Type,
-		ContentType: JsonContentType,
+		ContentType: contentType,
 		MessageId:   opts.MessageId,
 		UserId:      m.config.RABBIT_USER,
 		AppId:       m.config.APP_NAME,
 		Body:        byt,
-	})
+	}
+
+	if opts.Expiration > 0 {
+		pub.Expiration = strconv.FormatInt(opts.Expiration.Milliseconds(), 10)
+	}
+
+	for k, v := range opts.Headers {
+		if _, ok := pub.Headers[k]; !ok {
+			pub.Headers[k] = v
+		}
+	}
+
+	if id, ok := requestid.FromContext(opts.Ctx); ok {
+		if _, exists := pub.Headers[AMQPHeaderRequestID]; !exists {
+			pub.Headers[AMQPHeaderRequestID] = id
+		}
+	}
+
+	dedupKey, publish := m.reserveContent(exchange, routingKey, byt, opts)
+	if !publish {
+		return nil
+	}
+
+	span := startProducerSpan(opts.Ctx, exchange, routingKey, pub.Headers)
+
+	ch, err := m.channel(opts.Connection)
+	if err != nil {
+		endSpan(span, err)
+		m.releaseContent(dedupKey, opts)
+		return err
+	}
+
+	diverted, err := m.awaitFlowControl(exchange, routingKey, pub, opts)
+	if diverted || err != nil {
+		endSpan(span, err)
+		if err != nil {
+			m.releaseContent(dedupKey, opts)
+		}
+		return err
+	}
+
+	m.tapPublishing(exchange, routingKey, &pub)
+
+	err = ch.Publish(exchange, routingKey, false, false, pub)
+	endSpan(span, err)
+
+	if err != nil {
+		m.releaseContent(dedupKey, opts)
+	}
+
+	return err
+}
+
+func (m *RabbitMQMessaging) PublishCloudEvent(exchange, routingKey string, evt *cloudevents.Event, mode cloudevents.Mode) error {
+	pub, err := cloudevents.ToAMQP(evt, mode)
+	if err != nil {
+		m.logger.Error(LogMessage("publisher cloudevent"), logging.ErrorField(err))
+		return err
+	}
+
+	traceID, ok := evt.Extensions["traceparent"]
+	if !ok {
+		traceID = "without"
+	}
+
+	pub.Headers[AMQPHeaderNumberOfRetry] = int64(0)
+	pub.Headers[AMQPHeaderTraceID] = traceID
+	pub.UserId = m.config.RABBIT_USER
+	pub.AppId = m.config.APP_NAME
+
+	m.tapPublishing(exchange, routingKey, &pub)
+
+	return m.ch.Publish(exchange, routingKey, false, false, pub)
+}
+
+func (m *RabbitMQMessaging) RegisterCloudEventDispatcher(queue, eventType string, handler ConsumerHandler, t any) error {
+	if eventType == "" {
+		return ErrorRegisterDispatcher
+	}
+
+	if err := m.RegisterDispatcher(queue, handler, t); err != nil {
+		return err
+	}
+
+	d := m.dispatchers[len(m.dispatchers)-1]
+	d.MsgType = eventType
+	d.CloudEvent = true
+
+	return nil
 }
 
 func (m *RabbitMQMessaging) RegisterDispatcher(queue string, handler ConsumerHandler, t any) error {
@@ -209,32 +431,329 @@ func (m *RabbitMQMessaging) RegisterDispatcher(queue string, handler ConsumerHan
 		ReflectedType: reflect.New(reflect.TypeOf(t).Elem()),
 	}
 
+	if conf != nil && conf.Queue != nil {
+		dispatch.HandlerTimeout = conf.Queue.HandlerTimeout
+	}
+
 	m.dispatchers = append(m.dispatchers, dispatch)
 
 	return nil
 }
 
+func (m *RabbitMQMessaging) RegisterProtoDispatcher(queue string, handler ConsumerHandler, t proto.Message) error {
+	if t == nil {
+		return ErrorRegisterDispatcher
+	}
+
+	if err := m.RegisterDispatcher(queue, handler, t); err != nil {
+		return err
+	}
+
+	registry.Default.Register(t)
+
+	d := m.dispatchers[len(m.dispatchers)-1]
+	d.MsgType = registry.Name(t)
+	d.ProtoType = t.ProtoReflect().Type()
+
+	return nil
+}
+
 func (m *RabbitMQMessaging) Consume() error {
 	if m.Err != nil {
 		return m.Err
 	}
 
 	m.shotdown = make(chan error)
+	closing := m.closingChan()
 
 	for _, d := range m.dispatchers {
-		go m.startConsumer(d, m.shotdown)
+		m.launchConsumer(d)
 	}
 
-	e := <-m.shotdown
-	return e
+	select {
+	case e := <-m.shotdown:
+		return e
+	case <-closing:
+		return nil
+	}
 }
 
-func (m *RabbitMQMessaging) newPubOpts(typ string) *PublishOpts {
+func (m *RabbitMQMessaging) QueueStats(queue string) (*management.QueueStats, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	if m.management != nil {
+		return m.management.QueueStats(context.Background(), queue)
+	}
+
+	ch, err := m.channel(m.queueConnection(queue))
+	if err != nil {
+		return nil, err
+	}
+
+	q, err := ch.QueueInspect(queue)
+	if err != nil {
+		return nil, err
+	}
+
+	return &management.QueueStats{
+		Name:      q.Name,
+		Messages:  int64(q.Messages),
+		Consumers: int64(q.Consumers),
+	}, nil
+}
+
+func (m *RabbitMQMessaging) Shutdown(ctx context.Context) error {
+	closing := m.closingChan()
+
+	m.mu.Lock()
+	select {
+	case <-closing:
+		m.mu.Unlock()
+		return nil
+	default:
+		close(closing)
+	}
+	m.mu.Unlock()
+
+	m.logger.Debug(LogMessage("shutting down the consumers..."))
+
+	for _, d := range m.dispatchers {
+		m.mu.Lock()
+		state, ok := m.consumerStates[d]
+		m.mu.Unlock()
+
+		if !ok || m.isPaused(d) {
+			continue
+		}
+
+		if err := m.cancelConsumer(d, state.info.Tag); err != nil {
+			m.logger.Warn(LogMessage(fmt.Sprintf("failure to cancel the consumer: %s - %s", state.info.Tag, err)))
+		}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.consumers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		m.logger.Warn(LogMessage("shutdown timeout, the in-flight messages will be redelivered"))
+	}
+
+	if err := m.ch.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+		return err
+	}
+
+	if err := m.conn.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+		return err
+	}
+
+	for name, c := range m.connections {
+		if err := c.ch.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+			m.logger.Warn(LogMessage(fmt.Sprintf("failure to close the channel: %s - %s", name, err)))
+		}
+
+		if err := c.conn.Close(); err != nil && !errors.Is(err, amqp.ErrClosed) {
+			m.logger.Warn(LogMessage(fmt.Sprintf("failure to close the connection: %s - %s", name, err)))
+		}
+	}
+
+	m.logger.Debug(LogMessage("rabbitmq connection closed"))
+
+	return nil
+}
+
+func (m *RabbitMQMessaging) PauseQueue(queue string) error {
+	dispatchers, err := m.queueDispatchers(queue)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, d := range dispatchers {
+		state := m.consumerStates[d]
+		if state.paused {
+			continue
+		}
+
+		// the consumers that did not register the tag yet are cancelled by startConsumer
+		if state.consuming {
+			if err := m.cancelConsumer(d, state.info.Tag); err != nil {
+				m.logger.Error(LogMessage("failure to pause the consumer"), logging.ErrorField(err))
+				return err
+			}
+		}
+
+		state.paused = true
+	}
+
+	m.logger.Info(LogMessage(fmt.Sprintf("queue %s paused", queue)))
+
+	return nil
+}
+
+func (m *RabbitMQMessaging) ResumeQueue(queue string) error {
+	dispatchers, err := m.queueDispatchers(queue)
+	if err != nil {
+		return err
+	}
+
+	// the check and the state swap under the same lock so the concurrent resumes consume once
+	m.mu.Lock()
+	for _, d := range dispatchers {
+		if state, ok := m.consumerStates[d]; ok && state.paused {
+			m.launchConsumerLocked(d)
+		}
+	}
+	m.mu.Unlock()
+
+	m.logger.Info(LogMessage(fmt.Sprintf("queue %s resumed", queue)))
+
+	return nil
+}
+
+func (m *RabbitMQMessaging) Drain(queue string, timeout time.Duration) error {
+	if err := m.PauseQueue(queue); err != nil {
+		return err
+	}
+
+	dispatchers, _ := m.queueDispatchers(queue)
+
+	m.mu.Lock()
+	done := make([]chan struct{}, 0, len(dispatchers))
+	for _, d := range dispatchers {
+		done = append(done, m.consumerStates[d].done)
+	}
+	m.mu.Unlock()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for _, c := range done {
+		select {
+		case <-c:
+		case <-timer.C:
+			m.logger.Warn(LogMessage(fmt.Sprintf("queue %s drain timeout", queue)))
+			return ErrorDrainTimeout
+		}
+	}
+
+	m.logger.Info(LogMessage(fmt.Sprintf("queue %s drained", queue)))
+
+	return nil
+}
+
+// launchConsumer start the consumer goroutine tracking its state to pause and drain
+func (m *RabbitMQMessaging) launchConsumer(d *Dispatcher) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.launchConsumerLocked(d)
+}
+
+// launchConsumerLocked same as launchConsumer, the caller must hold m.mu
+func (m *RabbitMQMessaging) launchConsumerLocked(d *Dispatcher) {
+	state := &consumerState{done: make(chan struct{})}
+
+	if m.consumerStates == nil {
+		m.consumerStates = map[*Dispatcher]*consumerState{}
+	}
+
+	// the resumed consumers keep the tag
+	if previous, ok := m.consumerStates[d]; ok {
+		state.info = previous.info
+	} else {
+		state.info = m.newConsumerInfo(d)
+	}
+
+	m.consumerStates[d] = state
+
+	m.consumers.Add(1)
+	go func() {
+		defer m.consumers.Done()
+		defer close(state.done)
+
+		m.startConsumer(d, state, m.shotdown)
+	}()
+}
+
+// queueDispatchers returns the dispatchers of the queue that are consuming
+func (m *RabbitMQMessaging) queueDispatchers(queue string) ([]*Dispatcher, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	dispatchers := []*Dispatcher{}
+	for _, d := range m.dispatchers {
+		if _, ok := m.consumerStates[d]; ok && d.Queue == queue {
+			dispatchers = append(dispatchers, d)
+		}
+	}
+
+	if len(dispatchers) == 0 {
+		return nil, ErrorQueueNotConsumed
+	}
+
+	return dispatchers, nil
+}
+
+// cancelConsumer cancel the dispatcher consumer in the topology connection
+func (m *RabbitMQMessaging) cancelConsumer(d *Dispatcher, tag string) error {
+	ch, err := m.channel(d.Topology.Connection)
+	if err != nil {
+		return err
+	}
+
+	return ch.Cancel(tag, false)
+}
+
+// queueConnection the connection name of the topology declaring the queue
+func (m *RabbitMQMessaging) queueConnection(queue string) string {
+	for _, t := range m.topologies {
+		if t.Queue != nil && t.Queue.Name == queue {
+			return t.Connection
+		}
+	}
+
+	return DefaultConnection
+}
+
+func (m *RabbitMQMessaging) isPaused(d *Dispatcher) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	state, ok := m.consumerStates[d]
+	return ok && state.paused
+}
+
+func (m *RabbitMQMessaging) closingChan() chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closing == nil {
+		m.closing = make(chan struct{})
+	}
+
+	return m.closing
+}
+
+// NewPublishOpts the publish opts used when Publisher receive nil opts, the type is the msg go type
+func NewPublishOpts(msg any) *PublishOpts {
 	return &PublishOpts{
-		Type:      typ,
+		Type:      fmt.Sprintf("%T", msg),
 		Count:     0,
 		TraceId:   "without",
-		MessageId: uuid.NewString(),
+		MessageId: guid.NewRequestID(),
 		Delay:     time.Second,
 	}
 }
@@ -248,8 +767,13 @@ func (m *RabbitMQMessaging) newFallbackName(typ FallbackType, name string) strin
 }
 
 func (m *RabbitMQMessaging) declareExchange(opt *Topology) error {
+	ch, err := m.channel(opt.Connection)
+	if err != nil {
+		return err
+	}
+
 	if opt.Exchange != nil {
-		err := m.ch.ExchangeDeclare(opt.Exchange.Name, string(opt.Exchange.Type), true, false, false, false, nil)
+		err := ch.ExchangeDeclare(opt.Exchange.Name, string(opt.Exchange.Type), true, false, false, false, nil)
 		if err != nil {
 			return err
 		}
@@ -259,9 +783,7 @@ func (m *RabbitMQMessaging) declareExchange(opt *Topology) error {
 		return nil
 	}
 
-	err := m.ch.ExchangeDeclare(opt.delayed.ExchangeName, string(DELAY_EXCHANGE), true, false, false, false, amqp.Table{
-		"x-delayed-type": "direct",
-	})
+	err = ch.ExchangeDeclare(opt.delayed.ExchangeName, string(DELAY_EXCHANGE), true, false, false, false, delayedExchangeArguments())
 	if err != nil {
 		return err
 	}
@@ -269,13 +791,22 @@ func (m *RabbitMQMessaging) declareExchange(opt *Topology) error {
 	return nil
 }
 
+func delayedExchangeArguments() amqp.Table {
+	return amqp.Table{"x-delayed-type": "direct"}
+}
+
 func (m *RabbitMQMessaging) bindExchanges(opts *Topology) error {
 	if opts.Exchange.Bindings == nil || len(opts.Exchange.Bindings) == 0 {
 		return nil
 	}
 
+	ch, err := m.channel(opts.Connection)
+	if err != nil {
+		return err
+	}
+
 	for _, e := range opts.Exchange.Bindings {
-		err := m.ch.ExchangeBind(e, m.newRoutingKey(opts.Exchange.Name, e), opts.Exchange.Name, false, nil)
+		err := ch.ExchangeBind(e, m.newRoutingKey(opts.Exchange.Name, e), opts.Exchange.Name, false, nil)
 		if err != nil {
 			return err
 		}
@@ -289,22 +820,19 @@ func (m *RabbitMQMessaging) declareQueue(opts *Topology) error {
 		return nil
 	}
 
-	var amqpTable amqp.Table
-	if opts.deadLetter != nil || opts.delayed != nil {
-		//when we do not specify the exchange and configure in the dlq routing the queue name
-		//when messages was rejected will be sent to dql queue directly
-		amqpTable = amqp.Table{
-			"x-dead-letter-exchange":    "",
-			"x-dead-letter-routing-key": opts.deadLetter.QueueName,
-		}
+	ch, err := m.channel(opts.Connection)
+	if err != nil {
+		return err
+	}
 
-		_, err := m.ch.QueueDeclare(opts.deadLetter.QueueName, true, false, false, false, nil)
+	if opts.deadLetter != nil {
+		_, err := ch.QueueDeclare(opts.deadLetter.QueueName, true, false, false, false, nil)
 		if err != nil {
 			return err
 		}
 	}
 
-	_, err := m.ch.QueueDeclare(opts.Queue.Name, true, false, false, false, amqpTable)
+	_, err = ch.QueueDeclare(opts.Queue.Name, true, false, false, false, queueArguments(opts))
 	if err != nil {
 		return err
 	}
@@ -312,13 +840,45 @@ func (m *RabbitMQMessaging) declareQueue(opts *Topology) error {
 	return nil
 }
 
+// queueArguments the arguments of the topology main queue
+func queueArguments(opts *Topology) amqp.Table {
+	var args amqp.Table
+	if opts.deadLetter != nil {
+		args = deadLetterArguments(opts.deadLetter.QueueName)
+	}
+
+	if opts.Queue.TTL > 0 {
+		if args == nil {
+			args = amqp.Table{}
+		}
+
+		args["x-message-ttl"] = opts.Queue.TTL.Milliseconds()
+	}
+
+	return args
+}
+
+// deadLetterArguments when we do not specify the exchange and configure in the dlq routing the queue name
+// when messages was rejected will be sent to dql queue directly
+func deadLetterArguments(dlq string) amqp.Table {
+	return amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": dlq,
+	}
+}
+
 func (m *RabbitMQMessaging) bindQueue(opts *Topology) error {
-	if err := m.ch.QueueBind(opts.Queue.Name, opts.Binding.RoutingKey, opts.Exchange.Name, false, nil); err != nil {
+	ch, err := m.channel(opts.Connection)
+	if err != nil {
+		return err
+	}
+
+	if err := ch.QueueBind(opts.Queue.Name, opts.Binding.RoutingKey, opts.Exchange.Name, false, nil); err != nil {
 		return err
 	}
 
 	if opts.delayed != nil {
-		if err := m.ch.QueueBind(opts.delayed.QueueName, opts.Binding.delayedRoutingKey, opts.delayed.ExchangeName, false, nil); err != nil {
+		if err := ch.QueueBind(opts.delayed.QueueName, opts.Binding.delayedRoutingKey, opts.delayed.ExchangeName, false, nil); err != nil {
 			return err
 		}
 	}
@@ -326,62 +886,308 @@ func (m *RabbitMQMessaging) bindQueue(opts *Topology) error {
 	return nil
 }
 
-func (m *RabbitMQMessaging) startConsumer(d *Dispatcher, shotdown chan error) {
-	delivery, err := m.ch.Consume(d.Topology.Queue.Name, d.Topology.Binding.RoutingKey, false, false, false, false, nil)
+// startConsumer consume the queue with the state tag, the state is tracked by launchConsumer
+func (m *RabbitMQMessaging) startConsumer(d *Dispatcher, state *consumerState, shotdown chan error) {
+	ch, err := m.channel(d.Topology.Connection)
 	if err != nil {
 		shotdown <- err
+		return
 	}
 
-	for received := range delivery {
-		metadata, err := m.validateAndExtractMetadataFromDeliver(&received, d)
-		if err != nil {
-			received.Nack(true, false)
-			continue
+	delivery, err := m.consume(ch, d, state.info.Tag)
+	if err != nil {
+		shotdown <- err
+		return
+	}
+
+	// the PauseQueue before the tag was registered only marked the state, so the consumer is cancelled here
+	m.mu.Lock()
+	state.consuming = true
+	paused := state.paused
+	m.mu.Unlock()
+
+	if paused {
+		if err := ch.Cancel(state.info.Tag, false); err != nil {
+			m.logger.Error(LogMessage("failure to pause the consumer"), logging.ErrorField(err))
 		}
+	}
 
-		if metadata == nil {
-			m.logger.Debug(LogMsgWithMessageId("skipping amqp delivery - different msg type - send back to queue", received.MessageId))
-			received.Nack(true, true)
-			continue
+	if d.Topology.Queue.Concurrency != nil {
+		m.consumeConcurrently(d, delivery, d.Topology.Queue.Concurrency)
+		return
+	}
+
+	for received := range delivery {
+		m.handleDelivery(d, &received)
+	}
+}
+
+// consume the prefetch bounds the unacked deliveries of the concurrent queues to the workers and the buffer, the other
+// queues keep the unlimited prefetch since the channel prefetch applies to every consumer started after it
+func (m *RabbitMQMessaging) consume(ch AMQPChannel, d *Dispatcher, tag string) (<-chan amqp.Delivery, error) {
+	m.consumeMu.Lock()
+	defer m.consumeMu.Unlock()
+
+	if err := ch.Qos(prefetchCount(d.Topology.Queue.Concurrency), 0, false); err != nil {
+		return nil, err
+	}
+
+	return ch.Consume(d.Topology.Queue.Name, tag, false, false, false, false, nil)
+}
+
+// prefetchCount the deliveries handled by the workers plus the buffered ones, 0 is unlimited
+func prefetchCount(opts *ConcurrencyOpts) int {
+	if opts == nil {
+		return 0
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultConcurrencyWorkers
+	}
+
+	buffers := 1
+	if opts.PartitionKey != "" {
+		buffers = workers
+	}
+
+	return workers + opts.BufferSize*buffers
+}
+
+// consumeConcurrently dispatch the deliveries to the queue worker pool through a bounded buffer, with the PartitionKey
+// each worker has its own buffer and the deliveries with the same key are routed to the same worker
+//
+// It returns after the delivery channel is closed and the workers handled the buffered deliveries
+func (m *RabbitMQMessaging) consumeConcurrently(d *Dispatcher, delivery <-chan amqp.Delivery, opts *ConcurrencyOpts) {
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultConcurrencyWorkers
+	}
+
+	buffers := []chan *bufferedDelivery{make(chan *bufferedDelivery, opts.BufferSize)}
+	if opts.PartitionKey != "" {
+		for i := 1; i < workers; i++ {
+			buffers = append(buffers, make(chan *bufferedDelivery, opts.BufferSize))
 		}
+	}
 
-		ptr := d.ReflectedType.Interface()
-		err = json.Unmarshal(received.Body, ptr)
-		if err != nil {
-			m.logger.Error(LogMsgWithMessageId("unmarshal error", received.MessageId))
-			received.Nack(true, false)
-			continue
+	p := pool.New(d.Queue, &pool.Opts{Kind: pool.MESSAGING_KIND, Workers: workers, Capacity: opts.BufferSize * len(buffers)})
+	defer p.Close()
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(buffer chan *bufferedDelivery) {
+			defer wg.Done()
+
+			for buffered := range buffer {
+				done := p.Start(buffered.ticket)
+				m.handleDelivery(d, &buffered.delivery)
+				done()
+			}
+		}(buffers[i%len(buffers)])
+	}
+
+	next := 0
+	for received := range delivery {
+		buffer := buffers[0]
+		if len(buffers) > 1 {
+			buffer = buffers[partition(&received, opts.PartitionKey, &next, len(buffers))]
 		}
 
-		if d.Topology.Queue.Retryable != nil && metadata.XCount > d.Topology.Queue.Retryable.NumberOfRetry {
-			m.logger.Warn("message reprocessed to many times, sending to dead letter")
-			received.Nack(true, false)
+		buffered := &bufferedDelivery{delivery: received, ticket: p.Enqueue()}
+
+		if opts.Overflow == NACK_REQUEUE_OVERFLOW {
+			select {
+			case buffer <- buffered:
+			default:
+				p.Reject(buffered.ticket)
+				m.logger.Warn(LogMsgWithMessageId(fmt.Sprintf("queue %s buffer is full - send back to queue", d.Queue), received.MessageId))
+				received.Nack(false, true)
+			}
 			continue
 		}
 
-		m.logger.Info(LogMsgWithType("message received ", d.MsgType, received.MessageId))
+		buffer <- buffered
+	}
 
-		err = d.Handler(ptr, metadata)
-		if err != nil {
-			if d.Topology.Queue.Retryable == nil || err != ErrorRetryable {
-				received.Nack(true, false)
-				continue
-			}
+	for _, buffer := range buffers {
+		close(buffer)
+	}
+	wg.Wait()
+}
 
-			m.logger.Warn(LogMessage("send message to process latter"))
+// partition the worker of the delivery key, the deliveries without the key header are distributed round-robin
+func partition(received *amqp.Delivery, key string, next *int, workers int) int {
+	value, ok := received.Headers[key]
+	if !ok || value == nil {
+		*next = (*next + 1) % workers
+		return *next
+	}
 
-			m.publishToDelayed(metadata, d.Topology, &received)
+	h := fnv.New32a()
+	fmt.Fprint(h, value)
 
-			received.Ack(true)
-			continue
+	return int(h.Sum32() % uint32(workers))
+}
+
+func (m *RabbitMQMessaging) handleDelivery(d *Dispatcher, received *amqp.Delivery) {
+	m.tapDelivery(d.Queue, received)
+
+	metadata, err := m.validateAndExtractMetadataFromDeliver(received, d)
+	if err != nil {
+		received.Nack(false, false)
+		return
+	}
+
+	if metadata == nil {
+		m.logger.Debug(LogMsgWithMessageId("skipping amqp delivery - different msg type - send back to queue", received.MessageId))
+		received.Nack(false, true)
+		return
+	}
+
+	m.archiveDelivery(d.Queue, received, metadata)
+
+	body := received.Body
+	if metadata.CloudEvent != nil {
+		body = metadata.CloudEvent.Data
+	}
+
+	// each delivery is unmarshaled in a new value, the workers and the escalation hooks must not share it
+	ptr := reflect.New(d.ReflectedType.Type().Elem()).Interface()
+	if d.ProtoType != nil {
+		msg := d.ProtoType.New().Interface()
+		ptr = msg
+		err = proto.Unmarshal(body, msg)
+	} else {
+		err = json.Unmarshal(body, ptr)
+	}
+
+	if err != nil {
+		m.logger.Error(LogMsgWithMessageId("unmarshal error", received.MessageId))
+		received.Nack(false, false)
+		return
+	}
+
+	if d.Topology.Queue.Retryable != nil && metadata.XCount > d.Topology.Queue.Retryable.NumberOfRetry {
+		m.logger.Warn("message reprocessed to many times, sending to dead letter")
+		received.Nack(false, false)
+		return
+	}
+
+	ctx, span := startConsumerSpan(d.Queue, received)
+	if id, ok := received.Headers[AMQPHeaderRequestID].(string); ok && id != "" {
+		ctx = requestid.ContextWithID(ctx, id)
+	}
+	metadata.Ctx = ctx
+
+	msg, typeField := LogMsgWithType("message received ", d.MsgType, received.MessageId)
+	m.logger.Info(msg, typeField, requestid.Field(ctx))
+
+	err = m.callHandler(d, ptr, metadata)
+	endSpan(span, err)
+	if err != nil && d.Topology.retry != nil {
+		rt := d.Topology.retry
+		m.escalate(rt.escalation, !isRetryable(err) || rt.exhausted(received), d, ptr, metadata, received, err)
+		m.retryTiered(rt, received, err)
+		return
+	}
+
+	if err != nil {
+		retryable := d.Topology.Queue.Retryable
+		if retryable == nil {
+			received.Nack(false, false)
+			return
 		}
 
-		m.logger.Info(LogMsgWithMessageId("message processed properly", received.MessageId))
-		received.Ack(true)
+		// the last attempt is not republished, it would be dead-lettered in the next delivery
+		if !isRetryable(err) || metadata.XCount >= retryable.NumberOfRetry {
+			m.escalate(retryable.Escalation, true, d, ptr, metadata, received, err)
+			received.Nack(false, false)
+			return
+		}
+
+		m.escalate(retryable.Escalation, false, d, ptr, metadata, received, err)
+		m.logger.Warn(LogMessage("send message to process latter"))
+
+		m.publishToDelayed(metadata, d.Topology, received)
+
+		received.Ack(false)
+		return
+	}
+
+	msg, idField := LogMsgWithMessageId("message processed properly", received.MessageId)
+	m.logger.Info(msg, idField, requestid.Field(ctx))
+	received.Ack(false)
+}
+
+// callHandler run the handler under the dispatcher HandlerTimeout deadline, the handler runs with the queue and the
+// message type pprof labels, so the cpu profiles attribute the samples to the consumer
+//
+// The handler is not interrupted on timeout, it keeps running in background and should return when metadata.Ctx is done
+func (m *RabbitMQMessaging) callHandler(d *Dispatcher, msg any, metadata *DeliveryMetadata) error {
+	labels := pprof.Labels(QueueProfileLabel, d.Queue, MsgTypeProfileLabel, d.MsgType)
+	metadata.Ctx = pprof.WithLabels(metadata.Ctx, labels)
+
+	handle := func(ctx context.Context) (err error) {
+		pprof.Do(ctx, labels, func(context.Context) {
+			err = d.Handler(msg, metadata)
+		})
+		return err
+	}
+
+	if d.HandlerTimeout <= 0 {
+		return handle(metadata.Ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(metadata.Ctx, d.HandlerTimeout)
+	defer cancel()
+	metadata.Ctx = ctx
+
+	done := make(chan error, 1)
+	go func() {
+		done <- handle(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		m.logger.Warn(LogMsgWithMessageId(fmt.Sprintf("handler timeout after %s", d.HandlerTimeout), metadata.MessageId))
+		recordHandlerTimeout(d.Queue, d.MsgType)
+		return ErrorHandlerTimeout
 	}
 }
 
+// retryTiered send retryable failures to the next retry tier and the others directly to the DLQ
+func (m *RabbitMQMessaging) retryTiered(rt *RetryTopology, received *amqp.Delivery, handlerErr error) {
+	var err error
+	if isRetryable(handlerErr) {
+		m.logger.Warn(LogMsgWithMessageId("send message to the next retry tier", received.MessageId))
+		err = rt.Retry(received)
+	} else {
+		err = rt.DeadLetter(received)
+	}
+
+	if err != nil {
+		m.logger.Error(LogMsgWithMessageId("failure to republish the message, send back to queue", received.MessageId))
+		received.Nack(false, true)
+		return
+	}
+
+	received.Ack(false)
+}
+
+// isRetryable check the ErrorRetryable sentinel and the gokit errors retry decision
+func isRetryable(err error) bool {
+	return errors.Is(err, ErrorRetryable) || gokitErrors.Decision(err) == gokitErrors.RETRY_DECISION
+}
+
 func (m *RabbitMQMessaging) validateAndExtractMetadataFromDeliver(delivery *amqp.Delivery, d *Dispatcher) (*DeliveryMetadata, error) {
+	if d.CloudEvent {
+		return m.extractCloudEventMetadata(delivery, d)
+	}
+
 	msgID := delivery.MessageId
 	if msgID == "" {
 		m.logger.Error("unformatted amqp delivery - missing messageId parameter - send message to DLQ")
@@ -419,13 +1225,56 @@ func (m *RabbitMQMessaging) validateAndExtractMetadataFromDeliver(delivery *amqp
 	}, nil
 }
 
+// extractCloudEventMetadata the CloudEvents type attribute is used to route the event to the dispatcher
+func (m *RabbitMQMessaging) extractCloudEventMetadata(delivery *amqp.Delivery, d *Dispatcher) (*DeliveryMetadata, error) {
+	if !cloudevents.IsAMQPCloudEvent(delivery) {
+		return nil, nil
+	}
+
+	evt, err := cloudevents.FromAMQP(delivery)
+	if err != nil {
+		m.logger.Error(LogMessage("unformatted cloudevent - send message to DLQ"), logging.MessageIdField(delivery.MessageId), logging.ErrorField(err))
+		return nil, ErrorReceivedMessageValidator
+	}
+
+	if evt.Type != d.MsgType {
+		return nil, nil
+	}
+
+	xCount, _ := delivery.Headers[AMQPHeaderNumberOfRetry].(int64)
+	traceID, _ := delivery.Headers[AMQPHeaderTraceID].(string)
+	if traceID == "" {
+		traceID = evt.Extensions["traceparent"]
+	}
+
+	return &DeliveryMetadata{
+		MessageId:  evt.ID,
+		Type:       evt.Type,
+		XCount:     xCount,
+		TraceId:    traceID,
+		Headers:    delivery.Headers,
+		CloudEvent: evt,
+	}, nil
+}
+
 func (m *RabbitMQMessaging) publishToDelayed(metadata *DeliveryMetadata, t *Topology, received *amqp.Delivery) error {
-	return m.ch.Publish(t.delayed.ExchangeName, t.delayed.RoutingKey, false, false, amqp.Publishing{
-		Headers: amqp.Table{
-			AMQPHeaderNumberOfRetry: metadata.XCount + 1,
-			AMQPHeaderTraceID:       metadata.TraceId,
-			AMQPHeaderDelay:         t.Queue.Retryable.DelayBetween.Milliseconds(),
-		},
+	// keep the received headers, e.g. the CloudEvents binary mode attributes
+	headers := amqp.Table{}
+	for k, v := range received.Headers {
+		headers[k] = v
+	}
+
+	headers[AMQPHeaderNumberOfRetry] = metadata.XCount + 1
+	headers[AMQPHeaderTraceID] = metadata.TraceId
+	headers[AMQPHeaderDelay] = t.Queue.Retryable.DelayBetween.Milliseconds()
+
+	ch, err := m.channel(t.Connection)
+	if err != nil {
+		return err
+	}
+
+	return ch.Publish(t.delayed.ExchangeName, t.delayed.RoutingKey, false, false, amqp.Publishing{
+		Headers:     headers,
 		Type:        received.Type,
 		ContentType: received.ContentType,
 		MessageId:   received.MessageId,
@@ -1,17 +1,39 @@
 package rabbitmq
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math/rand"
 	"reflect"
+	"runtime/pprof"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/ralvescosta/gokit/env"
 	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/cloudevents"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq/management"
+	"github.com/ralvescosta/gokit/messaging/registry"
+	"github.com/ralvescosta/gokit/pool"
+	"github.com/ralvescosta/gokit/requestid"
 	"github.com/streadway/amqp"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type RabbitMQMessagingSuiteTest struct {
@@ -317,6 +339,136 @@ func (s *RabbitMQMessagingSuiteTest) TestPublisher() {
 	s.amqpChannel.AssertExpectations(s.T())
 }
 
+func (s *RabbitMQMessagingSuiteTest) TestPublisherRequestID() {
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.Headers[AMQPHeaderRequestID] == "id"
+		})).
+		Return(nil).
+		Once()
+
+	opts := NewPublishOpts(nil)
+	opts.Ctx = requestid.ContextWithID(context.Background(), "id")
+
+	s.NoError(s.messaging.Publisher("exchange", "key", nil, opts))
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestValidatePublish() {
+	invalid := errors.New("invalid message")
+	s.messaging.ValidatePublish(func(msg any) error {
+		if msg == nil {
+			return invalid
+		}
+
+		return nil
+	})
+	defer s.messaging.ValidatePublish(nil)
+
+	s.ErrorIs(s.messaging.Publisher("exchange", "key", nil, nil), invalid)
+	s.amqpChannel.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	s.amqpChannel.On("Publish", "exchange", "key", false, false, mock.AnythingOfType("amqp.Publishing")).Return(nil).Once()
+	s.NoError(s.messaging.Publisher("exchange", "key", map[string]any{}, nil))
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestPublisherExpiration() {
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.Expiration == "30000"
+		})).
+		Return(nil).
+		Once()
+
+	opts := NewPublishOpts(nil)
+	opts.Expiration = 30 * time.Second
+
+	s.NoError(s.messaging.Publisher("exchange", "key", nil, opts))
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestQueueTTL() {
+	t := &Topology{Queue: &QueueOpts{Name: "notifications", TTL: time.Minute}, Exchange: &ExchangeOpts{Name: "notifications"}}
+	s.messaging.Declare(t).ApplyBinds()
+	s.Equal(amqp.Table{"x-message-ttl": int64(60000)}, queueArguments(t))
+
+	t = &Topology{Queue: &QueueOpts{Name: "notifications", TTL: time.Minute, WithDeadLatter: true}, Exchange: &ExchangeOpts{Name: "notifications"}}
+	s.messaging.Declare(t).ApplyBinds()
+	s.Equal(amqp.Table{
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": "dlq-notifications",
+		"x-message-ttl":             int64(60000),
+	}, queueArguments(t))
+
+	s.Nil(queueArguments(&Topology{Queue: &QueueOpts{Name: "notifications"}}))
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestAddConnection() {
+	s.amqpConn.
+		On("Channel").
+		Return(&amqp.Channel{}, nil)
+
+	s.messaging.AddConnection("bridge", &env.Configs{RABBIT_VHOST: "bridge"})
+
+	s.NoError(s.messaging.Err)
+	s.Contains(s.messaging.connections, "bridge")
+
+	s.amqpConnErr = errors.New("some err")
+	s.messaging.AddConnection("other", &env.Configs{})
+
+	s.ErrorIs(s.messaging.Err, ErrorConnection)
+	s.NotContains(s.messaging.connections, "other")
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestNamedConnection() {
+	bridgeConn := NewMockAMQPConnection()
+	bridgeChannel := NewMockAMQPChannel()
+	s.messaging.connections = map[string]*namedConnection{"bridge": {bridgeConn, bridgeChannel}}
+
+	bridgeChannel.
+		On("Publish", "exchange", "key", false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Once()
+	bridgeChannel.
+		On("ExchangeDeclare", "exchange", string(DIRECT_EXCHANGE), true, false, false, false, amqp.Table(nil)).
+		Return(nil).
+		Once()
+	bridgeChannel.
+		On("QueueDeclare", "queue", true, false, false, false, amqp.Table(nil)).
+		Return(amqp.Queue{}, nil).
+		Once()
+	bridgeChannel.
+		On("QueueBind", "queue", "key", "exchange", false, amqp.Table(nil)).
+		Return(nil).
+		Once()
+
+	opts := NewPublishOpts(nil)
+	opts.Connection = "bridge"
+	s.NoError(s.messaging.Publisher("exchange", "key", nil, opts))
+
+	opts.Connection = "unknown"
+	s.ErrorIs(s.messaging.Publisher("exchange", "key", nil, opts), ErrorUnknownConnection)
+
+	s.messaging.topologies = []*Topology{{
+		Queue:      &QueueOpts{Name: "queue"},
+		Exchange:   &ExchangeOpts{Name: "exchange", Type: DIRECT_EXCHANGE},
+		Binding:    &BindingOpts{RoutingKey: "key"},
+		Connection: "bridge",
+	}}
+	_, err := s.messaging.Build()
+	s.NoError(err)
+
+	s.amqpChannel.On("Close").Return(nil).Once()
+	s.amqpConn.On("Close").Return(nil).Once()
+	bridgeChannel.On("Close").Return(nil).Once()
+	bridgeConn.On("Close").Return(nil).Once()
+
+	s.NoError(s.messaging.Shutdown(context.Background()))
+	s.amqpChannel.AssertNotCalled(s.T(), "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	bridgeChannel.AssertExpectations(s.T())
+	bridgeConn.AssertExpectations(s.T())
+}
+
 // func (s *RabbitMQMessagingSuiteTest) TestPublisherErr() {
 // 	exchange := "exchange"
 // 	routingKey := "key"
@@ -333,6 +485,42 @@ func (s *RabbitMQMessagingSuiteTest) TestPublisher() {
 // 	s.amqpChannel.AssertNotCalled(s.T(), "Publish")
 // }
 
+func (s *RabbitMQMessagingSuiteTest) TestPublisherLinksTheConsumeChain() {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(trace.NewNoopTracerProvider())
+
+	published := []amqp.Publishing{}
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.AnythingOfType("amqp.Publishing")).
+		Run(func(args mock.Arguments) { published = append(published, args.Get(4).(amqp.Publishing)) }).
+		Return(nil)
+
+	member, _ := baggage.NewMember("tenant", "acme")
+	bag, _ := baggage.New(member)
+
+	s.NoError(s.messaging.Publisher("exchange", "key", map[string]string{}, &PublishOpts{Ctx: baggage.ContextWithBaggage(context.Background(), bag)}))
+
+	ctx, span := startConsumerSpan("queue", &amqp.Delivery{Headers: published[0].Headers, MessageId: "id"})
+	s.Equal("acme", baggage.FromContext(ctx).Member("tenant").Value())
+
+	s.NoError(s.messaging.Publisher("exchange", "key", map[string]string{}, &PublishOpts{Ctx: ctx}))
+	endSpan(span, nil)
+
+	spans := recorder.Ended()
+	s.Len(spans, 3)
+
+	producer, consumer, chained := spans[0], spans[2], spans[1]
+	s.Equal(trace.SpanKindProducer, producer.SpanKind())
+	s.Equal(trace.SpanKindConsumer, consumer.SpanKind())
+	s.Equal(producer.SpanContext().SpanID(), consumer.Parent().SpanID())
+	s.Equal(producer.SpanContext().SpanID(), consumer.Links()[0].SpanContext.SpanID())
+	s.Equal(consumer.SpanContext().SpanID(), chained.Parent().SpanID())
+	s.Equal(consumer.SpanContext().SpanID(), chained.Links()[0].SpanContext.SpanID())
+	s.Equal(producer.SpanContext().TraceID(), chained.SpanContext().TraceID())
+	s.Contains(published[1].Headers["baggage"], "tenant=acme")
+}
+
 func (s *RabbitMQMessagingSuiteTest) TestRegisterDispatcher() {
 	queue := "queue"
 	handler := func(msg any, metadata *DeliveryMetadata) error {
@@ -385,8 +573,9 @@ func (s *RabbitMQMessagingSuiteTest) TestConsumer() {
 		MsgType: typ,
 	}}
 
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
 	s.amqpChannel.
-		On("Consume", queue, key, false, false, false, false, amqp.Table(nil)).
+		On("Consume", queue, mock.AnythingOfType("string"), false, false, false, false, amqp.Table(nil)).
 		Return(make(<-chan amqp.Delivery), errors.New("some error"))
 
 	err := s.messaging.Consume()
@@ -402,6 +591,406 @@ func (s *RabbitMQMessagingSuiteTest) TestConsumerErr() {
 	s.Error(err)
 }
 
+func (s *RabbitMQMessagingSuiteTest) TestShutdown() {
+	d, rootChan, _ := s.senary(nil)
+	s.messaging.dispatchers = []*Dispatcher{d}
+	s.messaging.ConsumerTag(func(info *ConsumerInfo, seq int) string { return info.Queue })
+
+	var deliveryChan <-chan amqp.Delivery = rootChan
+
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
+	s.amqpChannel.
+		On("Consume", d.Queue, d.Queue, false, false, false, false, amqp.Table(nil)).
+		Return(deliveryChan, nil)
+	s.amqpChannel.
+		On("Cancel", d.Queue, false).
+		Run(func(args mock.Arguments) { close(rootChan) }).
+		Return(nil).
+		Once()
+	s.amqpChannel.On("Close").Return(nil).Once()
+	s.amqpConn.On("Close").Return(nil).Once()
+
+	consumed := make(chan error)
+	go func() { consumed <- s.messaging.Consume() }()
+	time.Sleep(100 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	s.NoError(s.messaging.Shutdown(ctx))
+	s.NoError(<-consumed)
+	s.NoError(s.messaging.Shutdown(ctx))
+	s.amqpChannel.AssertExpectations(s.T())
+	s.amqpConn.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestPauseResumeAndDrainQueue() {
+	d, rootChan, _ := s.senary(nil)
+	s.messaging.dispatchers = []*Dispatcher{d}
+	s.messaging.ConsumerTag(func(info *ConsumerInfo, seq int) string { return info.Queue })
+
+	s.ErrorIs(s.messaging.PauseQueue(d.Queue), ErrorQueueNotConsumed)
+
+	resumedChan := make(chan amqp.Delivery)
+	var deliveryChan <-chan amqp.Delivery = rootChan
+	var resumedDeliveryChan <-chan amqp.Delivery = resumedChan
+
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
+	s.amqpChannel.
+		On("Consume", d.Queue, d.Queue, false, false, false, false, amqp.Table(nil)).
+		Return(deliveryChan, nil).
+		Once()
+	s.amqpChannel.
+		On("Consume", d.Queue, d.Queue, false, false, false, false, amqp.Table(nil)).
+		Return(resumedDeliveryChan, nil).
+		Once()
+	s.amqpChannel.
+		On("Cancel", d.Queue, false).
+		Run(func(args mock.Arguments) { close(rootChan) }).
+		Return(nil).
+		Once()
+
+	go func() { _ = s.messaging.Consume() }()
+	time.Sleep(100 * time.Millisecond)
+
+	s.ErrorIs(s.messaging.PauseQueue("unknown"), ErrorQueueNotConsumed)
+	s.NoError(s.messaging.Drain(d.Queue, time.Second))
+	s.True(s.messaging.isPaused(d))
+	s.NoError(s.messaging.PauseQueue(d.Queue))
+
+	s.NoError(s.messaging.ResumeQueue(d.Queue))
+	time.Sleep(100 * time.Millisecond)
+	s.False(s.messaging.isPaused(d))
+
+	s.amqpChannel.
+		On("Cancel", d.Queue, false).
+		Return(nil).
+		Once()
+
+	s.ErrorIs(s.messaging.Drain(d.Queue, 50*time.Millisecond), ErrorDrainTimeout)
+	close(resumedChan)
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestResumeQueueConcurrently() {
+	d, rootChan, _ := s.senary(nil)
+	s.messaging.dispatchers = []*Dispatcher{d}
+	s.messaging.consumerStates = map[*Dispatcher]*consumerState{
+		d: {paused: true, done: make(chan struct{}), info: &ConsumerInfo{Queue: d.Queue, Tag: d.Queue}},
+	}
+
+	var deliveryChan <-chan amqp.Delivery = rootChan
+
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
+	s.amqpChannel.
+		On("Consume", d.Queue, d.Queue, false, false, false, false, amqp.Table(nil)).
+		Return(deliveryChan, nil).
+		Once()
+
+	start := make(chan struct{})
+	wg := sync.WaitGroup{}
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			s.NoError(s.messaging.ResumeQueue(d.Queue))
+		}()
+	}
+	close(start)
+	wg.Wait()
+	time.Sleep(100 * time.Millisecond)
+
+	s.False(s.messaging.isPaused(d))
+	s.amqpChannel.AssertNumberOfCalls(s.T(), "Consume", 1)
+
+	close(rootChan)
+	s.messaging.consumers.Wait()
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestPauseBeforeConsume() {
+	d, rootChan, _ := s.senary(nil)
+	s.messaging.dispatchers = []*Dispatcher{d}
+
+	state := &consumerState{done: make(chan struct{}), info: &ConsumerInfo{Tag: "tag"}}
+	s.messaging.consumerStates = map[*Dispatcher]*consumerState{d: state}
+
+	// the tag is not registered yet, so the pause only marks the state
+	s.NoError(s.messaging.PauseQueue(d.Queue))
+	s.True(state.paused)
+
+	var deliveryChan <-chan amqp.Delivery = rootChan
+
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
+	s.amqpChannel.
+		On("Consume", d.Queue, "tag", false, false, false, false, amqp.Table(nil)).
+		Return(deliveryChan, nil).
+		Once()
+	s.amqpChannel.
+		On("Cancel", "tag", false).
+		Run(func(args mock.Arguments) { close(rootChan) }).
+		Return(nil).
+		Once()
+
+	s.messaging.startConsumer(d, state, make(chan error, 1))
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestDeclareRetryTopology() {
+	s.amqpChannel.
+		On("QueueDeclare", "dlq-orders", true, false, false, false, amqp.Table(nil)).
+		Return(amqp.Queue{}, nil).
+		Once()
+	s.amqpChannel.
+		On("QueueDeclare", "orders", true, false, false, false, amqp.Table{
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": "dlq-orders",
+		}).
+		Return(amqp.Queue{}, nil).
+		Once()
+	s.amqpChannel.
+		On("QueueDeclare", "retry-1s-orders", true, false, false, false, amqp.Table{
+			"x-message-ttl":             int64(1000),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": "orders",
+		}).
+		Return(amqp.Queue{}, nil).
+		Once()
+	s.amqpChannel.
+		On("QueueDeclare", "retry-1m0s-orders", true, false, false, false, amqp.Table{
+			"x-message-ttl":             int64(60000),
+			"x-dead-letter-exchange":    "",
+			"x-dead-letter-routing-key": "orders",
+		}).
+		Return(amqp.Queue{}, nil).
+		Once()
+	s.amqpChannel.
+		On("QueueBind", "orders", "orders", "exchange", false, amqp.Table(nil)).
+		Return(nil).
+		Once()
+
+	rt, err := s.messaging.DeclareRetryTopology(&RetryTopologyOpts{
+		Queue:    "orders",
+		Exchange: "exchange",
+		Tiers:    []time.Duration{time.Second, time.Minute},
+	})
+
+	s.NoError(err)
+	s.Equal("dlq-orders", rt.DLQ)
+	s.Len(rt.Tiers, 2)
+	s.Len(s.messaging.topologies, 1)
+
+	_, err = s.messaging.Build()
+	s.NoError(err)
+	s.amqpChannel.AssertExpectations(s.T())
+
+	s.NoError(s.messaging.RegisterDispatcher("orders", func(msg any, metadata *DeliveryMetadata) error { return nil }, &MsgBody{}))
+	s.Equal(rt, s.messaging.dispatchers[0].Topology.retry)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestDeclareRetryTopologyErr() {
+	_, err := s.messaging.DeclareRetryTopology(&RetryTopologyOpts{})
+	s.ErrorIs(err, ErrorRetryTopologyQueue)
+
+	s.amqpChannel.
+		On("QueueDeclare", "dlq-orders", true, false, false, false, amqp.Table(nil)).
+		Return(amqp.Queue{}, errors.New("some error"))
+
+	_, err = s.messaging.DeclareRetryTopology(&RetryTopologyOpts{Queue: "orders"})
+	s.Error(err)
+	s.Empty(s.messaging.topologies)
+
+	s.messaging.Err = errors.New("some error")
+	_, err = s.messaging.DeclareRetryTopology(&RetryTopologyOpts{Queue: "orders"})
+	s.Error(err)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestRetryTopologyRetry() {
+	rt := &RetryTopology{
+		Queue:     "orders",
+		DLQ:       "dlq-orders",
+		Tiers:     []*RetryTier{{Queue: "retry-1s-orders", TTL: time.Second}},
+		messaging: s.messaging,
+	}
+	delivery := &amqp.Delivery{MessageId: "id", Body: []byte("{}"), Headers: amqp.Table{AMQPHeaderNumberOfRetry: int64(0)}}
+
+	s.amqpChannel.
+		On("Publish", "", "retry-1s-orders", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.MessageId == "id" && pub.Headers[AMQPHeaderNumberOfRetry] == int64(1)
+		})).
+		Return(nil).
+		Once()
+	s.amqpChannel.
+		On("Publish", "", "dlq-orders", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.Headers[AMQPHeaderNumberOfRetry] == int64(1)
+		})).
+		Return(nil).
+		Once()
+	s.amqpChannel.
+		On("Publish", "", "orders", false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Once()
+
+	s.NoError(rt.Retry(delivery))
+	s.Equal(int64(0), delivery.Headers[AMQPHeaderNumberOfRetry])
+
+	delivery.Headers[AMQPHeaderNumberOfRetry] = int64(1)
+	s.NoError(rt.Retry(delivery))
+	s.NoError(rt.Publish(&MsgBody{}, nil))
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestStartConsumerTieredRetry() {
+	d, rootChan, fakeDelivery := s.senary(ErrorRetryable)
+	d.Topology.Queue.Retryable = nil
+	d.Topology.retry = &RetryTopology{
+		Queue:     d.Queue,
+		DLQ:       "dlq-" + d.Queue,
+		Tiers:     []*RetryTier{{Queue: "retry-1s-" + d.Queue, TTL: time.Second}},
+		messaging: s.messaging,
+	}
+
+	var deliveryChan <-chan amqp.Delivery = rootChan
+
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
+	s.amqpChannel.
+		On("Consume", d.Queue, "tag", false, false, false, false, amqp.Table(nil)).
+		Return(deliveryChan, nil)
+	s.amqpChannel.
+		On("Publish", "", "retry-1s-"+d.Queue, false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Once()
+
+	go s.messaging.startConsumer(d, &consumerState{info: &ConsumerInfo{Tag: "tag"}}, make(chan error))
+
+	rootChan <- fakeDelivery
+
+	time.Sleep(100 * time.Millisecond)
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestStartConsumerPrefetch() {
+	d, rootChan, _ := s.senary(nil)
+	d.Topology.Queue.Concurrency = &ConcurrencyOpts{Workers: 2, BufferSize: 3}
+
+	var deliveryChan <-chan amqp.Delivery = rootChan
+
+	s.amqpChannel.On("Qos", 5, 0, false).Return(nil).Once()
+	s.amqpChannel.
+		On("Consume", d.Queue, "tag", false, false, false, false, amqp.Table(nil)).
+		Return(deliveryChan, nil).
+		Once()
+
+	done := make(chan struct{})
+	go func() {
+		s.messaging.startConsumer(d, &consumerState{info: &ConsumerInfo{Tag: "tag"}}, make(chan error))
+		close(done)
+	}()
+
+	close(rootChan)
+	<-done
+	s.amqpChannel.AssertExpectations(s.T())
+
+	s.Equal(0, prefetchCount(nil))
+	s.Equal(2+3*2, prefetchCount(&ConcurrencyOpts{Workers: 2, BufferSize: 3, PartitionKey: "x-account-id"}))
+	s.Equal(DefaultConcurrencyWorkers, prefetchCount(&ConcurrencyOpts{}))
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestStartConsumerQosErr() {
+	d, _, _ := s.senary(nil)
+	shotdown := make(chan error, 1)
+
+	s.amqpChannel.On("Qos", 0, 0, false).Return(errors.New("some error")).Once()
+
+	s.messaging.startConsumer(d, &consumerState{info: &ConsumerInfo{Tag: "tag"}}, shotdown)
+
+	s.Error(<-shotdown)
+	s.amqpChannel.AssertNotCalled(s.T(), "Consume", d.Queue, "tag", false, false, false, false, amqp.Table(nil))
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestQueueStats() {
+	s.amqpChannel.
+		On("QueueInspect", "orders").
+		Return(amqp.Queue{Name: "orders", Messages: 10, Consumers: 2}, nil).
+		Once()
+
+	stats, err := s.messaging.QueueStats("orders")
+
+	s.NoError(err)
+	s.Equal(int64(10), stats.Messages)
+	s.Equal(int64(2), stats.Consumers)
+
+	mgmt := management.NewMockManagementClient()
+	mgmt.On("QueueStats", mock.Anything, "orders").Return(&management.QueueStats{Name: "orders", MessagesReady: 5}, nil)
+	s.messaging.management = mgmt
+
+	stats, err = s.messaging.QueueStats("orders")
+
+	s.NoError(err)
+	s.Equal(int64(5), stats.MessagesReady)
+
+	s.messaging.Err = errors.New("some error")
+	_, err = s.messaging.QueueStats("orders")
+	s.Error(err)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestTap() {
+	tapSample = func() float64 { return 0.1 }
+	defer func() { tapSample = rand.Float64 }()
+
+	buf := &bytes.Buffer{}
+	s.messaging.Tap(&TapOpts{Rate: 0.5, MaxBodySize: 5, Sink: NewWriterTapSink(buf)})
+
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Once()
+
+	s.NoError(s.messaging.Publisher("exchange", "key", &MsgBody{Name: "name"}, nil))
+
+	entry := &TapEntry{}
+	s.NoError(json.Unmarshal(buf.Bytes(), entry))
+	s.Equal(PUBLISHED_TAP, entry.Direction)
+	s.Equal("exchange", entry.Exchange)
+	s.Equal(`{"Nam`, entry.Body)
+	s.True(entry.Truncated)
+	s.Equal(15, entry.BodySize)
+
+	buf.Reset()
+	s.messaging.tapDelivery("queue", &amqp.Delivery{MessageId: "id", Body: []byte("{}"), Headers: amqp.Table{"x-count": int64(0)}})
+
+	entry = &TapEntry{}
+	s.NoError(json.Unmarshal(buf.Bytes(), entry))
+	s.Equal(CONSUMED_TAP, entry.Direction)
+	s.Equal("queue", entry.Queue)
+	s.Equal("{}", entry.Body)
+	s.False(entry.Truncated)
+
+	tapSample = func() float64 { return 0.9 }
+	buf.Reset()
+	s.messaging.tapDelivery("queue", &amqp.Delivery{})
+	s.Empty(buf.Bytes())
+
+	s.messaging.Tap(nil)
+	s.Nil(s.messaging.tap)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestTapSinks() {
+	entry := &TapEntry{Direction: CONSUMED_TAP, MessageId: "id"}
+
+	s.NoError(NewLogTapSink(logging.NewMockLogger()).Tap(entry))
+
+	s.amqpChannel.
+		On("Publish", "", "tap", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.MessageId == "id" && pub.Type == string(CONSUMED_TAP)
+		})).
+		Return(nil).
+		Once()
+
+	s.NoError(s.messaging.NewQueueTapSink("tap").Tap(entry))
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
 type MsgBody struct {
 	Name string
 }
@@ -412,11 +1001,12 @@ func (s *RabbitMQMessagingSuiteTest) TestStartConsumer() {
 
 	var deliveryChan <-chan amqp.Delivery = rootChan
 
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
 	s.amqpChannel.
-		On("Consume", d.Queue, d.Topology.Binding.RoutingKey, false, false, false, false, amqp.Table(nil)).
+		On("Consume", d.Queue, "tag", false, false, false, false, amqp.Table(nil)).
 		Return(deliveryChan, nil)
 
-	go s.messaging.startConsumer(d, shotdown)
+	go s.messaging.startConsumer(d, &consumerState{info: &ConsumerInfo{Tag: "tag"}}, shotdown)
 	rootChan <- fakeDelivery
 	rootChan = nil
 
@@ -429,8 +1019,9 @@ func (s *RabbitMQMessagingSuiteTest) TestStartConsumerRetry() {
 
 	var deliveryChan <-chan amqp.Delivery = rootChan
 
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
 	s.amqpChannel.
-		On("Consume", d.Queue, d.Topology.Binding.RoutingKey, false, false, false, false, amqp.Table(nil)).
+		On("Consume", d.Queue, "tag", false, false, false, false, amqp.Table(nil)).
 		Return(deliveryChan, nil)
 
 	s.amqpChannel.
@@ -438,7 +1029,7 @@ func (s *RabbitMQMessagingSuiteTest) TestStartConsumerRetry() {
 		Return(nil)
 
 	shotdown := make(chan error)
-	go s.messaging.startConsumer(d, shotdown)
+	go s.messaging.startConsumer(d, &consumerState{info: &ConsumerInfo{Tag: "tag"}}, shotdown)
 
 	rootChan <- fakeDelivery
 
@@ -446,17 +1037,76 @@ func (s *RabbitMQMessagingSuiteTest) TestStartConsumerRetry() {
 	s.amqpChannel.AssertExpectations(s.T())
 }
 
+func (s *RabbitMQMessagingSuiteTest) TestHandlerTimeout() {
+	reader := sdkMetric.NewManualReader()
+	otel.SetMeterProvider(sdkMetric.NewMeterProvider(sdkMetric.WithReader(reader)))
+	defer otel.SetMeterProvider(noop.NewMeterProvider())
+
+	d, _, delivery := s.senary(nil)
+	d.HandlerTimeout = 10 * time.Millisecond
+	d.Handler = func(msg any, metadata *DeliveryMetadata) error {
+		<-metadata.Ctx.Done()
+		return metadata.Ctx.Err()
+	}
+
+	s.amqpChannel.
+		On("Publish", d.Topology.Exchange.Name, d.Topology.Binding.RoutingKey, false, false, mock.AnythingOfType("amqp.Publishing")).
+		Return(nil).
+		Once()
+
+	ack := &recordAcknowledger{}
+	delivery.Acknowledger = ack
+	s.messaging.handleDelivery(d, &delivery)
+
+	s.Len(ack.acks, 1)
+	s.amqpChannel.AssertExpectations(s.T())
+	s.ErrorIs(ErrorHandlerTimeout, ErrorRetryable)
+
+	rm := metricdata.ResourceMetrics{}
+	s.NoError(reader.Collect(context.Background(), &rm))
+	s.Equal(HandlerTimeoutsMetric, rm.ScopeMetrics[0].Metrics[0].Name)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestREDHandler() {
+	observer := &recordObserver{}
+	failure := errors.New("failure")
+
+	handler := REDHandler(observer, "orders", func(msg any, metadata *DeliveryMetadata) error { return failure })
+	s.ErrorIs(handler(nil, &DeliveryMetadata{Type: "OrderCreated"}), failure)
+
+	s.Equal([]string{"orders"}, observer.operations)
+	s.Equal([]string{"OrderCreated"}, observer.codes)
+	s.Equal([]error{failure}, observer.errs)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestHandlerProfileLabels() {
+	d, _, delivery := s.senary(nil)
+
+	labels := map[string]string{}
+	d.Handler = func(msg any, metadata *DeliveryMetadata) error {
+		labels[QueueProfileLabel], _ = pprof.Label(metadata.Ctx, QueueProfileLabel)
+		labels[MsgTypeProfileLabel], _ = pprof.Label(metadata.Ctx, MsgTypeProfileLabel)
+		return nil
+	}
+
+	delivery.Acknowledger = &recordAcknowledger{}
+	s.messaging.handleDelivery(d, &delivery)
+
+	s.Equal(map[string]string{QueueProfileLabel: d.Queue, MsgTypeProfileLabel: d.MsgType}, labels)
+}
+
 func (s *RabbitMQMessagingSuiteTest) TestStartConsumerRetryExceeded() {
 	d, rootChan, fakeDelivery := s.senary(ErrorRetryable)
 
 	var deliveryChan <-chan amqp.Delivery = rootChan
 
+	s.amqpChannel.On("Qos", 0, 0, false).Return(nil)
 	s.amqpChannel.
-		On("Consume", d.Queue, d.Topology.Binding.RoutingKey, false, false, false, false, amqp.Table(nil)).
+		On("Consume", d.Queue, "tag", false, false, false, false, amqp.Table(nil)).
 		Return(deliveryChan, nil)
 
 	shotdown := make(chan error)
-	go s.messaging.startConsumer(d, shotdown)
+	go s.messaging.startConsumer(d, &consumerState{info: &ConsumerInfo{Tag: "tag"}}, shotdown)
 
 	fakeDelivery.Headers[AMQPHeaderNumberOfRetry] = int64(4)
 	rootChan <- fakeDelivery
@@ -570,3 +1220,310 @@ func (s *RabbitMQMessagingSuiteTest) senary(handlerErr error) (*Dispatcher, chan
 
 	return dispatcher, rootChn, delivery
 }
+
+type recordObserver struct {
+	operations []string
+	codes      []string
+	errs       []error
+}
+
+func (o *recordObserver) Start(ctx context.Context, operation string, attrs ...attribute.KeyValue) func(code string, err error) {
+	o.operations = append(o.operations, operation)
+
+	return func(code string, err error) {
+		o.codes = append(o.codes, code)
+		o.errs = append(o.errs, err)
+	}
+}
+
+type recordAcknowledger struct {
+	mu       sync.Mutex
+	acks     []uint64
+	nacks    []uint64
+	requeues []bool
+}
+
+func (a *recordAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.acks = append(a.acks, tag)
+	return nil
+}
+
+func (a *recordAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.nacks = append(a.nacks, tag)
+	a.requeues = append(a.requeues, requeue)
+	return nil
+}
+
+func (a *recordAcknowledger) Reject(tag uint64, requeue bool) error {
+	return nil
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestConsumeConcurrently() {
+	d, rootChan, delivery := s.senary(nil)
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	d.Handler = func(msg any, metadata *DeliveryMetadata) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	ack := &recordAcknowledger{}
+	delivery.Acknowledger = ack
+
+	done := make(chan struct{})
+	go func() {
+		s.messaging.consumeConcurrently(d, rootChan, &ConcurrencyOpts{Workers: 2})
+		close(done)
+	}()
+
+	for tag := uint64(1); tag <= 2; tag++ {
+		delivery.DeliveryTag = tag
+		rootChan <- delivery
+	}
+
+	// both handlers are running in parallel
+	<-started
+	<-started
+
+	close(release)
+	close(rootChan)
+	<-done
+
+	s.ElementsMatch([]uint64{1, 2}, ack.acks)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestConsumeConcurrentlyNackRequeueOverflow() {
+	d, rootChan, delivery := s.senary(nil)
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	d.Handler = func(msg any, metadata *DeliveryMetadata) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	ack := &recordAcknowledger{}
+	delivery.Acknowledger = ack
+
+	done := make(chan struct{})
+	go func() {
+		s.messaging.consumeConcurrently(d, rootChan, &ConcurrencyOpts{Workers: 1, BufferSize: 1, Overflow: NACK_REQUEUE_OVERFLOW})
+		close(done)
+	}()
+
+	delivery.DeliveryTag = 1
+	rootChan <- delivery
+	<-started
+
+	// the first is in the worker, the second in the buffer and the third overflows
+	for tag := uint64(2); tag <= 3; tag++ {
+		delivery.DeliveryTag = tag
+		rootChan <- delivery
+	}
+
+	close(release)
+	close(rootChan)
+	<-done
+
+	s.Equal([]uint64{1, 2}, ack.acks)
+	s.Equal([]uint64{3}, ack.nacks)
+	s.Equal([]bool{true}, ack.requeues)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestConsumeConcurrentlyPoolStats() {
+	d, rootChan, delivery := s.senary(nil)
+
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+	d.Handler = func(msg any, metadata *DeliveryMetadata) error {
+		started <- struct{}{}
+		<-release
+		return nil
+	}
+
+	delivery.Acknowledger = &recordAcknowledger{}
+
+	done := make(chan struct{})
+	go func() {
+		s.messaging.consumeConcurrently(d, rootChan, &ConcurrencyOpts{Workers: 1, BufferSize: 1, Overflow: NACK_REQUEUE_OVERFLOW})
+		close(done)
+	}()
+
+	for tag := uint64(1); tag <= 3; tag++ {
+		delivery.DeliveryTag = tag
+		rootChan <- delivery
+		if tag == 1 {
+			<-started
+		}
+	}
+
+	expected := pool.Stats{Name: d.Queue, Kind: pool.MESSAGING_KIND, Workers: 1, Busy: 1, Capacity: 1, Queued: 1, Rejected: 1}
+	s.Eventually(func() bool {
+		all := pool.All()
+		return len(all) == 1 && all[0] == expected
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	close(rootChan)
+	<-done
+
+	s.Empty(pool.All())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestConsumeConcurrentlyPartitionKey() {
+	d, rootChan, delivery := s.senary(nil)
+
+	mu := sync.Mutex{}
+	running := map[string]bool{}
+	handled := map[string][]string{}
+	d.Handler = func(msg any, metadata *DeliveryMetadata) error {
+		account := metadata.Headers["x-account-id"].(string)
+
+		mu.Lock()
+		s.False(running[account], "the messages of the same key must be handled serially")
+		running[account] = true
+		mu.Unlock()
+
+		time.Sleep(time.Millisecond)
+
+		mu.Lock()
+		running[account] = false
+		handled[account] = append(handled[account], metadata.MessageId)
+		mu.Unlock()
+		return nil
+	}
+
+	delivery.Acknowledger = &recordAcknowledger{}
+
+	done := make(chan struct{})
+	go func() {
+		s.messaging.consumeConcurrently(d, rootChan, &ConcurrencyOpts{Workers: 4, PartitionKey: "x-account-id"})
+		close(done)
+	}()
+
+	for i := 0; i < 10; i++ {
+		for _, account := range []string{"account-1", "account-2", "account-3"} {
+			delivery.MessageId = fmt.Sprintf("%s-%d", account, i)
+			delivery.Headers = amqp.Table{AMQPHeaderNumberOfRetry: int64(0), AMQPHeaderDelay: "20", AMQPHeaderTraceID: "id", "x-account-id": account}
+			rootChan <- delivery
+		}
+	}
+
+	close(rootChan)
+	<-done
+
+	for _, account := range []string{"account-1", "account-2", "account-3"} {
+		s.Require().Len(handled[account], 10)
+		for i, id := range handled[account] {
+			s.Equal(fmt.Sprintf("%s-%d", account, i), id)
+		}
+	}
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestPartition() {
+	next := 0
+	withKey := &amqp.Delivery{Headers: amqp.Table{"x-account-id": "account-1"}}
+	withoutKey := &amqp.Delivery{}
+
+	s.Equal(partition(withKey, "x-account-id", &next, 4), partition(withKey, "x-account-id", &next, 4))
+	s.Equal(1, partition(withoutKey, "x-account-id", &next, 4))
+	s.Equal(2, partition(withoutKey, "x-account-id", &next, 4))
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestPublishCloudEvent() {
+	evt, _ := cloudevents.NewEvent("/source", "com.example.created", &MsgBody{Name: "name"})
+
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.Type == "com.example.created" && pub.Headers[AMQPHeaderNumberOfRetry] == int64(0)
+		})).
+		Return(nil).
+		Once()
+
+	err := s.messaging.PublishCloudEvent("exchange", "key", evt, cloudevents.BINARY_MODE)
+
+	s.NoError(err)
+	s.amqpChannel.AssertExpectations(s.T())
+	s.Error(s.messaging.PublishCloudEvent("exchange", "key", &cloudevents.Event{}, cloudevents.BINARY_MODE))
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestRegisterCloudEventDispatcher() {
+	handler := func(msg any, metadata *DeliveryMetadata) error {
+		return nil
+	}
+
+	s.NoError(s.messaging.RegisterCloudEventDispatcher("queue", "com.example.created", handler, &MsgBody{}))
+	s.Len(s.messaging.dispatchers, 1)
+	s.Equal("com.example.created", s.messaging.dispatchers[0].MsgType)
+	s.True(s.messaging.dispatchers[0].CloudEvent)
+
+	s.Error(s.messaging.RegisterCloudEventDispatcher("queue", "", handler, &MsgBody{}))
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestPublishProto() {
+	s.amqpChannel.
+		On("Publish", "exchange", "key", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.Type == "google.protobuf.StringValue" && pub.ContentType == registry.ProtobufContentType
+		})).
+		Return(nil).
+		Once()
+
+	s.NoError(s.messaging.PublishProto("exchange", "key", wrapperspb.String("value"), nil))
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestHandleProtoDelivery() {
+	d, _, delivery := s.senary(nil)
+
+	var received string
+	d.Handler = func(msg any, metadata *DeliveryMetadata) error {
+		received = msg.(*wrapperspb.StringValue).GetValue()
+		return nil
+	}
+
+	s.messaging.dispatchers = nil
+	s.NoError(s.messaging.RegisterProtoDispatcher(d.Queue, d.Handler, &wrapperspb.StringValue{}))
+	s.Equal("google.protobuf.StringValue", s.messaging.dispatchers[0].MsgType)
+
+	d.MsgType = s.messaging.dispatchers[0].MsgType
+	d.ProtoType = s.messaging.dispatchers[0].ProtoType
+
+	ack := &recordAcknowledger{}
+	delivery.Acknowledger = ack
+	delivery.Type = d.MsgType
+	delivery.Body, _ = proto.Marshal(wrapperspb.String("value"))
+
+	s.messaging.handleDelivery(d, &delivery)
+
+	s.Equal("value", received)
+	s.Len(ack.acks, 1)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestExtractCloudEventMetadata() {
+	evt, _ := cloudevents.NewEvent("/source", "com.example.created", &MsgBody{Name: "name"})
+	pub, _ := cloudevents.ToAMQP(evt, cloudevents.STRUCTURED_MODE)
+	delivery := &amqp.Delivery{ContentType: pub.ContentType, Body: pub.Body, Headers: amqp.Table{}}
+	dispatcher := &Dispatcher{MsgType: "com.example.created", CloudEvent: true}
+
+	m, err := s.messaging.validateAndExtractMetadataFromDeliver(delivery, dispatcher)
+	s.NoError(err)
+	s.Equal(evt.ID, m.MessageId)
+	s.Equal(evt.Data, m.CloudEvent.Data)
+
+	dispatcher.MsgType = "other"
+	m, err = s.messaging.validateAndExtractMetadataFromDeliver(delivery, dispatcher)
+	s.Nil(m)
+	s.NoError(err)
+
+	delivery.Body = []byte("{}")
+	m, err = s.messaging.validateAndExtractMetadataFromDeliver(delivery, dispatcher)
+	s.Nil(m)
+	s.Error(err)
+}
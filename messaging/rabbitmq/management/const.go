@@ -0,0 +1,28 @@
+package management
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultPort    = "15672"
+	DefaultVHost   = "/"
+	DefaultTimeout = 5 * time.Second
+
+	QueuesPath       = "/api/queues/"
+	ExchangesPath    = "/api/exchanges/"
+	HealthAlarmsPath = "/api/health/checks/alarms"
+)
+
+var (
+	ErrorQueueNotFound    = errors.New("queue not found")
+	ErrorExchangeNotFound = errors.New("exchange not found")
+	ErrorUnauthorized     = errors.New("management api unauthorized")
+	ErrorUnhealthy        = errors.New("rabbitmq node has alarms in effect")
+	ErrorUnexpectedStatus = errors.New("management api unexpected status")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::rabbitmq::management] " + msg
+}
@@ -0,0 +1,136 @@
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// NewConfig create the management configuration from the env Messaging() configs
+//
+// When RABBIT_MANAGEMENT_URL is not set the url is built with the RABBIT_HOST and the DefaultPort
+func NewConfig(cfg *env.Configs) *Config {
+	u := cfg.RABBIT_MANAGEMENT_URL
+	if u == "" {
+		u = fmt.Sprintf("http://%s:%s", cfg.RABBIT_HOST, DefaultPort)
+	}
+
+	return &Config{
+		URL:      u,
+		User:     cfg.RABBIT_USER,
+		Password: cfg.RABBIT_PASSWORD,
+		VHost:    DefaultVHost,
+	}
+}
+
+func New(logger logging.ILogger, cfg *Config) IManagementClient {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	if cfg.VHost == "" {
+		cfg.VHost = DefaultVHost
+	}
+
+	return &managementClient{
+		logger: logger,
+		cfg:    cfg,
+		client: client,
+	}
+}
+
+func (c *managementClient) QueueStats(ctx context.Context, queue string) (*QueueStats, error) {
+	stats := &QueueStats{}
+	if err := c.get(ctx, QueuesPath+url.PathEscape(c.cfg.VHost)+"/"+url.PathEscape(queue), stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (c *managementClient) Queues(ctx context.Context) ([]*QueueStats, error) {
+	stats := []*QueueStats{}
+	if err := c.get(ctx, QueuesPath+url.PathEscape(c.cfg.VHost), &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+func (c *managementClient) Exchange(ctx context.Context, name string) (*Exchange, error) {
+	exchange := &Exchange{}
+	err := c.get(ctx, ExchangesPath+url.PathEscape(c.cfg.VHost)+"/"+url.PathEscape(name), exchange)
+	if err == ErrorQueueNotFound {
+		return nil, ErrorExchangeNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return exchange, nil
+}
+
+func (c *managementClient) ExchangeBindings(ctx context.Context, exchange string) ([]*Binding, error) {
+	bindings := []*Binding{}
+	err := c.get(ctx, ExchangesPath+url.PathEscape(c.cfg.VHost)+"/"+url.PathEscape(exchange)+"/bindings/source", &bindings)
+	if err == ErrorQueueNotFound {
+		return nil, ErrorExchangeNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+func (c *managementClient) NodeHealth(ctx context.Context) error {
+	err := c.get(ctx, HealthAlarmsPath, nil)
+	if err == ErrorUnexpectedStatus {
+		return ErrorUnhealthy
+	}
+
+	return err
+}
+
+func (c *managementClient) get(ctx context.Context, path string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(c.cfg.URL, "/")+path, nil)
+	if err != nil {
+		return err
+	}
+
+	req.SetBasicAuth(c.cfg.User, c.cfg.Password)
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		c.logger.Error(LogMessage("management api request failure"), logging.ErrorField(err))
+		return err
+	}
+	defer res.Body.Close()
+
+	switch {
+	case res.StatusCode == http.StatusNotFound:
+		return ErrorQueueNotFound
+	case res.StatusCode == http.StatusUnauthorized || res.StatusCode == http.StatusForbidden:
+		return ErrorUnauthorized
+	case res.StatusCode != http.StatusOK:
+		io.Copy(io.Discard, res.Body)
+		c.logger.Warn(LogMessage(fmt.Sprintf("management api %s returned %d", path, res.StatusCode)))
+		return ErrorUnexpectedStatus
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
@@ -0,0 +1,143 @@
+package management
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type ManagementTestSuite struct {
+	suite.Suite
+
+	server *httptest.Server
+	status int
+	body   string
+	path   string
+	client IManagementClient
+	user   string
+}
+
+func TestManagementTestSuite(t *testing.T) {
+	suite.Run(t, new(ManagementTestSuite))
+}
+
+func (s *ManagementTestSuite) SetupTest() {
+	s.status = http.StatusOK
+	s.body = "{}"
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.path = r.URL.EscapedPath()
+		s.user, _, _ = r.BasicAuth()
+		w.WriteHeader(s.status)
+		w.Write([]byte(s.body))
+	}))
+
+	s.client = New(logging.NewMockLogger(), &Config{URL: s.server.URL + "/", User: "guest", Password: "guest"})
+}
+
+func (s *ManagementTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ManagementTestSuite) TestNewConfig() {
+	cfg := NewConfig(&env.Configs{RABBIT_HOST: "rabbit", RABBIT_USER: "user", RABBIT_PASSWORD: "password"})
+
+	s.Equal("http://rabbit:15672", cfg.URL)
+	s.Equal(DefaultVHost, cfg.VHost)
+
+	cfg = NewConfig(&env.Configs{RABBIT_MANAGEMENT_URL: "https://management"})
+	s.Equal("https://management", cfg.URL)
+}
+
+func (s *ManagementTestSuite) TestQueueStats() {
+	s.body = `{"name":"orders","vhost":"/","messages":10,"messages_ready":7,"messages_unacknowledged":3,"consumers":2,"message_stats":{"publish":5,"publish_details":{"rate":1.5}}}`
+
+	stats, err := s.client.QueueStats(context.Background(), "orders")
+
+	s.NoError(err)
+	s.Equal("/api/queues/%2F/orders", s.path)
+	s.Equal("guest", s.user)
+	s.Equal(int64(10), stats.Messages)
+	s.Equal(int64(7), stats.MessagesReady)
+	s.Equal(int64(3), stats.MessagesUnacknowledged)
+	s.Equal(int64(2), stats.Consumers)
+	s.Equal(1.5, stats.MessageStats.PublishDetails.Rate)
+}
+
+func (s *ManagementTestSuite) TestQueueStatsErr() {
+	s.status = http.StatusNotFound
+	_, err := s.client.QueueStats(context.Background(), "orders")
+	s.ErrorIs(err, ErrorQueueNotFound)
+
+	s.status = http.StatusUnauthorized
+	_, err = s.client.QueueStats(context.Background(), "orders")
+	s.ErrorIs(err, ErrorUnauthorized)
+
+	s.status = http.StatusInternalServerError
+	_, err = s.client.QueueStats(context.Background(), "orders")
+	s.ErrorIs(err, ErrorUnexpectedStatus)
+
+	s.status = http.StatusOK
+	s.body = "{"
+	_, err = s.client.QueueStats(context.Background(), "orders")
+	s.Error(err)
+}
+
+func (s *ManagementTestSuite) TestQueues() {
+	s.body = `[{"name":"orders","messages":1},{"name":"payments","messages":2}]`
+
+	stats, err := s.client.Queues(context.Background())
+
+	s.NoError(err)
+	s.Equal("/api/queues/%2F", s.path)
+	s.Len(stats, 2)
+	s.Equal("payments", stats[1].Name)
+}
+
+func (s *ManagementTestSuite) TestExchange() {
+	s.body = `{"name":"orders","vhost":"/","type":"direct","durable":true,"arguments":{}}`
+
+	exchange, err := s.client.Exchange(context.Background(), "orders")
+
+	s.NoError(err)
+	s.Equal("/api/exchanges/%2F/orders", s.path)
+	s.Equal("direct", exchange.Type)
+	s.True(exchange.Durable)
+
+	s.status = http.StatusNotFound
+	_, err = s.client.Exchange(context.Background(), "orders")
+	s.ErrorIs(err, ErrorExchangeNotFound)
+}
+
+func (s *ManagementTestSuite) TestExchangeBindings() {
+	s.body = `[{"source":"orders","destination":"orders.created","destination_type":"queue","routing_key":"created"}]`
+
+	bindings, err := s.client.ExchangeBindings(context.Background(), "orders")
+
+	s.NoError(err)
+	s.Equal("/api/exchanges/%2F/orders/bindings/source", s.path)
+	s.Len(bindings, 1)
+	s.Equal("created", bindings[0].RoutingKey)
+
+	s.status = http.StatusNotFound
+	_, err = s.client.ExchangeBindings(context.Background(), "orders")
+	s.ErrorIs(err, ErrorExchangeNotFound)
+}
+
+func (s *ManagementTestSuite) TestNodeHealth() {
+	s.body = `{"status":"ok"}`
+	s.NoError(s.client.NodeHealth(context.Background()))
+	s.Equal(HealthAlarmsPath, s.path)
+
+	s.status = http.StatusServiceUnavailable
+	s.body = `{"status":"failed","reason":"memory alarm"}`
+	s.ErrorIs(s.client.NodeHealth(context.Background()), ErrorUnhealthy)
+
+	s.server.Close()
+	s.Error(s.client.NodeHealth(context.Background()))
+}
@@ -0,0 +1,55 @@
+package management
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockManagementClient struct {
+		mock.Mock
+	}
+)
+
+func (m *MockManagementClient) QueueStats(ctx context.Context, queue string) (*QueueStats, error) {
+	args := m.Called(ctx, queue)
+
+	res, _ := args.Get(0).(*QueueStats)
+
+	return res, args.Error(1)
+}
+
+func (m *MockManagementClient) Queues(ctx context.Context) ([]*QueueStats, error) {
+	args := m.Called(ctx)
+
+	res, _ := args.Get(0).([]*QueueStats)
+
+	return res, args.Error(1)
+}
+
+func (m *MockManagementClient) Exchange(ctx context.Context, name string) (*Exchange, error) {
+	args := m.Called(ctx, name)
+
+	res, _ := args.Get(0).(*Exchange)
+
+	return res, args.Error(1)
+}
+
+func (m *MockManagementClient) ExchangeBindings(ctx context.Context, exchange string) ([]*Binding, error) {
+	args := m.Called(ctx, exchange)
+
+	res, _ := args.Get(0).([]*Binding)
+
+	return res, args.Error(1)
+}
+
+func (m *MockManagementClient) NodeHealth(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func NewMockManagementClient() *MockManagementClient {
+	return new(MockManagementClient)
+}
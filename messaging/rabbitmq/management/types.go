@@ -0,0 +1,94 @@
+package management
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// Config the management API configuration
+	Config struct {
+		// URL e.g: http://localhost:15672
+		URL      string
+		User     string
+		Password string
+		VHost    string
+		// HTTPClient optional, the default has DefaultTimeout
+		HTTPClient *http.Client
+	}
+
+	RateDetails struct {
+		Rate float64 `json:"rate"`
+	}
+
+	MessageStats struct {
+		Publish           int64        `json:"publish"`
+		PublishDetails    *RateDetails `json:"publish_details,omitempty"`
+		DeliverGet        int64        `json:"deliver_get"`
+		DeliverGetDetails *RateDetails `json:"deliver_get_details,omitempty"`
+		Ack               int64        `json:"ack"`
+		AckDetails        *RateDetails `json:"ack_details,omitempty"`
+	}
+
+	// QueueStats the backlog and consumers of a queue
+	QueueStats struct {
+		Name                   string        `json:"name"`
+		VHost                  string        `json:"vhost"`
+		State                  string        `json:"state"`
+		Messages               int64         `json:"messages"`
+		MessagesReady          int64         `json:"messages_ready"`
+		MessagesUnacknowledged int64         `json:"messages_unacknowledged"`
+		Consumers              int64         `json:"consumers"`
+		MessageStats           *MessageStats `json:"message_stats,omitempty"`
+
+		// the queue declaration, see VerifyTopology
+		Durable    bool           `json:"durable"`
+		AutoDelete bool           `json:"auto_delete"`
+		Arguments  map[string]any `json:"arguments"`
+	}
+
+	// Exchange the exchange declared in the broker
+	Exchange struct {
+		Name       string         `json:"name"`
+		VHost      string         `json:"vhost"`
+		Type       string         `json:"type"`
+		Durable    bool           `json:"durable"`
+		AutoDelete bool           `json:"auto_delete"`
+		Internal   bool           `json:"internal"`
+		Arguments  map[string]any `json:"arguments"`
+	}
+
+	// Binding an exchange bound to a queue or to another exchange
+	Binding struct {
+		Source          string         `json:"source"`
+		Destination     string         `json:"destination"`
+		DestinationType string         `json:"destination_type"`
+		RoutingKey      string         `json:"routing_key"`
+		Arguments       map[string]any `json:"arguments"`
+	}
+
+	IManagementClient interface {
+		// QueueStats returns the stats of a queue in the configured vhost, ErrorQueueNotFound when the queue does not exist
+		QueueStats(ctx context.Context, queue string) (*QueueStats, error)
+
+		// Queues returns the stats of all queues in the configured vhost
+		Queues(ctx context.Context) ([]*QueueStats, error)
+
+		// Exchange returns the exchange in the configured vhost, ErrorExchangeNotFound when the exchange does not exist
+		Exchange(ctx context.Context, name string) (*Exchange, error)
+
+		// ExchangeBindings returns the bindings where the exchange is the source
+		ExchangeBindings(ctx context.Context, exchange string) ([]*Binding, error)
+
+		// NodeHealth returns ErrorUnhealthy when the node has resource alarms in effect, e.g: memory or disk
+		NodeHealth(ctx context.Context) error
+	}
+
+	managementClient struct {
+		logger logging.ILogger
+		cfg    *Config
+		client *http.Client
+	}
+)
@@ -0,0 +1,44 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// recordHandlerTimeout the counter is created with the global meter provider on each call, so the provider configured after the consumer start is used
+func recordHandlerTimeout(queue, typ string) {
+	counter, err := otel.Meter(MeterName).Int64Counter(HandlerTimeoutsMetric, metric.WithDescription("handlers that exceeded the queue handler timeout"))
+	if err != nil {
+		return
+	}
+
+	counter.Add(context.Background(), 1, metric.WithAttributes(
+		attribute.String("messaging.destination", queue),
+		attribute.String("messaging.message.type", typ),
+	))
+}
+
+// REDHandler decorate a messaging handler, the operation is the queue and the code is the message type
+func REDHandler(observer REDObserver, queue string, handler ConsumerHandler) ConsumerHandler {
+	return func(msg any, metadata *DeliveryMetadata) error {
+		ctx := context.Background()
+		if metadata != nil && metadata.Ctx != nil {
+			ctx = metadata.Ctx
+		}
+
+		typ := ""
+		if metadata != nil {
+			typ = metadata.Type
+		}
+
+		done := observer.Start(ctx, queue)
+
+		err := handler(msg, metadata)
+		done(typ, err)
+
+		return err
+	}
+}
@@ -1,8 +1,15 @@
 package rabbitmq
 
 import (
+	"context"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/messaging/cloudevents"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq/management"
 	"github.com/streadway/amqp"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
 )
 
 type (
@@ -35,12 +42,44 @@ func (m *MockRabbitMQMessaging) ApplyBinds() IRabbitMQMessaging {
 	return res
 }
 
+func (m *MockRabbitMQMessaging) AddConnection(name string, cfg *env.Configs) IRabbitMQMessaging {
+	args := m.Called(name, cfg)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
 func (m *MockRabbitMQMessaging) Publisher(exchange, routingKey string, msg any, opts *PublishOpts) error {
 	args := m.Called(exchange, routingKey, msg, opts)
 
 	return args.Error(0)
 }
 
+func (m *MockRabbitMQMessaging) PublishCloudEvent(exchange, routingKey string, evt *cloudevents.Event, mode cloudevents.Mode) error {
+	args := m.Called(exchange, routingKey, evt, mode)
+
+	return args.Error(0)
+}
+
+func (m *MockRabbitMQMessaging) PublishProto(exchange, routingKey string, msg proto.Message, opts *PublishOpts) error {
+	args := m.Called(exchange, routingKey, msg, opts)
+
+	return args.Error(0)
+}
+
+func (m *MockRabbitMQMessaging) RegisterProtoDispatcher(queue string, handler ConsumerHandler, t proto.Message) error {
+	args := m.Called(queue, handler, t)
+
+	return args.Error(0)
+}
+
+func (m *MockRabbitMQMessaging) RegisterCloudEventDispatcher(queue, eventType string, handler ConsumerHandler, t any) error {
+	args := m.Called(queue, eventType, handler, t)
+
+	return args.Error(0)
+}
+
 func (m *MockRabbitMQMessaging) Consume() error {
 	args := m.Called(nil)
 
@@ -61,6 +100,166 @@ func (m *MockRabbitMQMessaging) Build() (IRabbitMQMessaging, error) {
 	return res, args.Error(1)
 }
 
+func (m *MockRabbitMQMessaging) Shutdown(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (m *MockRabbitMQMessaging) DeclareRetryTopology(opts *RetryTopologyOpts) (*RetryTopology, error) {
+	args := m.Called(opts)
+
+	res, _ := args.Get(0).(*RetryTopology)
+
+	return res, args.Error(1)
+}
+
+func (m *MockRabbitMQMessaging) QueueStats(queue string) (*management.QueueStats, error) {
+	args := m.Called(queue)
+
+	res, _ := args.Get(0).(*management.QueueStats)
+
+	return res, args.Error(1)
+}
+
+func (m *MockRabbitMQMessaging) Tap(opts *TapOpts) IRabbitMQMessaging {
+	args := m.Called(opts)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) Archive(opts *ArchiveOpts) IRabbitMQMessaging {
+	args := m.Called(opts)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) Replay(ctx context.Context, filter *ReplayFilter) (int, error) {
+	args := m.Called(ctx, filter)
+
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRabbitMQMessaging) DeduplicatePublish(opts *DedupPublishOpts) IRabbitMQMessaging {
+	args := m.Called(opts)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) AddPublishRoute(t any, exchange, routingKey string) IRabbitMQMessaging {
+	args := m.Called(t, exchange, routingKey)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) PublishRouted(ctx context.Context, msg any) error {
+	args := m.Called(ctx, msg)
+
+	return args.Error(0)
+}
+
+func (m *MockRabbitMQMessaging) WatchConnections(hook ConnectionEventHook) IRabbitMQMessaging {
+	args := m.Called(hook)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) FlowControl(opts *FlowControlOpts) IRabbitMQMessaging {
+	args := m.Called(opts)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) IsBlocked() bool {
+	args := m.Called()
+
+	return args.Bool(0)
+}
+
+func (m *MockRabbitMQMessaging) ConsumerTag(tag ConsumerTagFunc) IRabbitMQMessaging {
+	args := m.Called(tag)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) Consumers() []*ConsumerInfo {
+	args := m.Called()
+
+	res, _ := args.Get(0).([]*ConsumerInfo)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) ValidatePublish(validate PublishValidator) IRabbitMQMessaging {
+	args := m.Called(validate)
+
+	res := args.Get(0).(IRabbitMQMessaging)
+
+	return res
+}
+
+func (m *MockRabbitMQMessaging) VerifyTopology() (*TopologyDiff, error) {
+	args := m.Called()
+
+	res, _ := args.Get(0).(*TopologyDiff)
+
+	return res, args.Error(1)
+}
+
+func (m *MockRabbitMQMessaging) PauseQueue(queue string) error {
+	args := m.Called(queue)
+
+	return args.Error(0)
+}
+
+func (m *MockRabbitMQMessaging) ResumeQueue(queue string) error {
+	args := m.Called(queue)
+
+	return args.Error(0)
+}
+
+func (m *MockRabbitMQMessaging) Drain(queue string, timeout time.Duration) error {
+	args := m.Called(queue, timeout)
+
+	return args.Error(0)
+}
+
+func (m *MockAMQPConnection) Close() error {
+	called := m.Called()
+
+	return called.Error(0)
+}
+
+func (m *MockAMQPConnection) NotifyClose(receiver chan *amqp.Error) chan *amqp.Error {
+	called := m.Called(receiver)
+
+	res, _ := called.Get(0).(chan *amqp.Error)
+
+	return res
+}
+
+func (m *MockAMQPConnection) NotifyBlocked(receiver chan amqp.Blocking) chan amqp.Blocking {
+	called := m.Called(receiver)
+
+	res, _ := called.Get(0).(chan amqp.Blocking)
+
+	return res
+}
+
 func (m *MockAMQPConnection) Channel() (*amqp.Channel, error) {
 	called := m.Called()
 
@@ -103,12 +302,52 @@ func (m *MockAMQPChannel) Consume(queue, consumer string, autoAck, exclusive, no
 	return res, called.Error(1)
 }
 
+func (m *MockAMQPChannel) Qos(prefetchCount, prefetchSize int, global bool) error {
+	called := m.Called(prefetchCount, prefetchSize, global)
+
+	return called.Error(0)
+}
+
 func (m *MockAMQPChannel) Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error {
 	called := m.Called(exchange, key, mandatory, immediate, msg)
 
 	return called.Error(0)
 }
 
+func (m *MockAMQPChannel) QueueInspect(name string) (amqp.Queue, error) {
+	called := m.Called(name)
+
+	res := called.Get(0).(amqp.Queue)
+
+	return res, called.Error(1)
+}
+
+func (m *MockAMQPChannel) ExchangeDeclarePassive(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error {
+	called := m.Called(name, kind, durable, autoDelete, internal, noWait, args)
+
+	return called.Error(0)
+}
+
+func (m *MockAMQPChannel) Cancel(consumer string, noWait bool) error {
+	called := m.Called(consumer, noWait)
+
+	return called.Error(0)
+}
+
+func (m *MockAMQPChannel) NotifyClose(receiver chan *amqp.Error) chan *amqp.Error {
+	called := m.Called(receiver)
+
+	res, _ := called.Get(0).(chan *amqp.Error)
+
+	return res
+}
+
+func (m *MockAMQPChannel) Close() error {
+	called := m.Called()
+
+	return called.Error(0)
+}
+
 func NewMockRabbitMQMessaging() *MockRabbitMQMessaging {
 	return new(MockRabbitMQMessaging)
 }
@@ -0,0 +1,145 @@
+package rabbitmq
+
+import (
+	"fmt"
+
+	"github.com/streadway/amqp"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+func (m *RabbitMQMessaging) DeclareRetryTopology(opts *RetryTopologyOpts) (*RetryTopology, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	if opts == nil || opts.Queue == "" {
+		return nil, ErrorRetryTopologyQueue
+	}
+
+	tiers := opts.Tiers
+	if len(tiers) == 0 {
+		tiers = DefaultRetryTiers
+	}
+
+	rt := &RetryTopology{
+		Queue:      opts.Queue,
+		DLQ:        m.newFallbackName(DLQ_FALLBACK, opts.Queue),
+		messaging:  m,
+		escalation: opts.Escalation,
+	}
+
+	for _, ttl := range tiers {
+		rt.Tiers = append(rt.Tiers, &RetryTier{
+			Queue: m.newFallbackName(RETRY_TIER_FALLBACK, ttl.String()+"-"+opts.Queue),
+			TTL:   ttl,
+		})
+	}
+
+	m.logger.Debug(LogMessage(fmt.Sprintf("declaring retry topology to %s...", rt.Queue)))
+
+	if _, err := m.ch.QueueDeclare(rt.DLQ, true, false, false, false, nil); err != nil {
+		m.logger.Error(LogMessage("declare dlq err"), logging.ErrorField(err))
+		return nil, err
+	}
+
+	//rejected messages are sent directly to the dlq through the default exchange
+	_, err := m.ch.QueueDeclare(rt.Queue, true, false, false, false, deadLetterArguments(rt.DLQ))
+	if err != nil {
+		m.logger.Error(LogMessage("declare queue err"), logging.ErrorField(err))
+		return nil, err
+	}
+
+	//expired messages in the retry queues are sent back to the main queue
+	for _, tier := range rt.Tiers {
+		_, err := m.ch.QueueDeclare(tier.Queue, true, false, false, false, retryTierArguments(tier, rt.Queue))
+		if err != nil {
+			m.logger.Error(LogMessage("declare retry queue err"), logging.ErrorField(err))
+			return nil, err
+		}
+	}
+
+	routingKey := opts.RoutingKey
+	if routingKey == "" {
+		routingKey = opts.Queue
+	}
+
+	if opts.Exchange != "" {
+		if err := m.ch.QueueBind(rt.Queue, routingKey, opts.Exchange, false, nil); err != nil {
+			m.logger.Error(LogMessage("bind queue err"), logging.ErrorField(err))
+			return nil, err
+		}
+	}
+
+	m.topologies = append(m.topologies, &Topology{
+		Queue:    &QueueOpts{Name: rt.Queue, Concurrency: opts.Concurrency},
+		Exchange: &ExchangeOpts{Name: opts.Exchange},
+		Binding:  &BindingOpts{RoutingKey: routingKey},
+		retry:    rt,
+	})
+
+	m.logger.Debug(LogMessage("retry topology declared"))
+
+	return rt, nil
+}
+
+// retryTierArguments the expired messages in the retry queue are sent back to the main queue
+func retryTierArguments(tier *RetryTier, queue string) amqp.Table {
+	return amqp.Table{
+		"x-message-ttl":             tier.TTL.Milliseconds(),
+		"x-dead-letter-exchange":    "",
+		"x-dead-letter-routing-key": queue,
+	}
+}
+
+// Publish publish a message directly to the main queue
+func (rt *RetryTopology) Publish(msg any, opts *PublishOpts) error {
+	return rt.messaging.Publisher("", rt.Queue, msg, opts)
+}
+
+// Retry send the delivery to the next retry tier based on the x-count header, when all the tiers were used the delivery is sent to the DLQ
+//
+// The caller must ack the delivery after Retry returns without error
+func (rt *RetryTopology) Retry(delivery *amqp.Delivery) error {
+	if rt.exhausted(delivery) {
+		return rt.DeadLetter(delivery)
+	}
+
+	attempt, _ := delivery.Headers[AMQPHeaderNumberOfRetry].(int64)
+
+	return rt.republish(rt.Tiers[attempt].Queue, delivery, attempt+1)
+}
+
+// exhausted all the tiers were used
+func (rt *RetryTopology) exhausted(delivery *amqp.Delivery) bool {
+	attempt, _ := delivery.Headers[AMQPHeaderNumberOfRetry].(int64)
+	return attempt < 0 || attempt >= int64(len(rt.Tiers))
+}
+
+// DeadLetter send the delivery to the DLQ, the caller must ack the delivery after DeadLetter returns without error
+func (rt *RetryTopology) DeadLetter(delivery *amqp.Delivery) error {
+	attempt, _ := delivery.Headers[AMQPHeaderNumberOfRetry].(int64)
+	return rt.republish(rt.DLQ, delivery, attempt)
+}
+
+func (rt *RetryTopology) republish(queue string, delivery *amqp.Delivery, attempt int64) error {
+	headers := amqp.Table{}
+	for k, v := range delivery.Headers {
+		headers[k] = v
+	}
+	headers[AMQPHeaderNumberOfRetry] = attempt
+
+	return rt.messaging.ch.Publish("", queue, false, false, amqp.Publishing{
+		Headers:         headers,
+		ContentType:     delivery.ContentType,
+		ContentEncoding: delivery.ContentEncoding,
+		DeliveryMode:    delivery.DeliveryMode,
+		CorrelationId:   delivery.CorrelationId,
+		MessageId:       delivery.MessageId,
+		Timestamp:       delivery.Timestamp,
+		Type:            delivery.Type,
+		UserId:          delivery.UserId,
+		AppId:           delivery.AppId,
+		Body:            delivery.Body,
+	})
+}
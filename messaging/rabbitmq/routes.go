@@ -0,0 +1,89 @@
+package rabbitmq
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// RegisterRoute map the message type T to the exchange and the routing key used by Publish, e.g:
+//
+//	rabbitmq.RegisterRoute[OrderCreated](messaging, "orders", "orders.created")
+func RegisterRoute[T any](m IRabbitMQMessaging, exchange, routingKey string) IRabbitMQMessaging {
+	var t T
+	return m.AddPublishRoute(&t, exchange, routingKey)
+}
+
+// Publish publish the msg to the exchange and the routing key registered to T, the message type is the same used by
+// RegisterDispatcher, the types without route return ErrorRouteNotRegistered
+//
+//	err := rabbitmq.Publish(ctx, messaging, &OrderCreated{ID: id})
+func Publish[T any](ctx context.Context, m IRabbitMQMessaging, msg T) error {
+	return m.PublishRouted(ctx, msg)
+}
+
+// AddPublishRoute the pointers of t are ignored, *Order and Order share the route
+func (m *RabbitMQMessaging) AddPublishRoute(t any, exchange, routingKey string) IRabbitMQMessaging {
+	typ := routeType(t)
+	if typ == nil {
+		m.Err = ErrorRouteType
+		return m
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.routes == nil {
+		m.routes = map[reflect.Type]*PublishRoute{}
+	}
+
+	m.routes[typ] = &PublishRoute{
+		Exchange:   exchange,
+		RoutingKey: routingKey,
+		Type:       "*" + typ.String(),
+	}
+
+	return m
+}
+
+func (m *RabbitMQMessaging) PublishRouted(ctx context.Context, msg any) error {
+	route, ok := m.route(msg)
+	if !ok {
+		m.logger.Error(LogMessage("publisher route"), logging.ErrorField(ErrorRouteNotRegistered))
+		return ErrorRouteNotRegistered
+	}
+
+	opts := NewPublishOpts(msg)
+	opts.Type = route.Type
+	opts.Ctx = ctx
+
+	return m.Publisher(route.Exchange, route.RoutingKey, msg, opts)
+}
+
+func (m *RabbitMQMessaging) route(msg any) (*PublishRoute, bool) {
+	typ := routeType(msg)
+	if typ == nil {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	route, ok := m.routes[typ]
+	return route, ok
+}
+
+// routeType the message type without the pointers
+func routeType(t any) reflect.Type {
+	typ := reflect.TypeOf(t)
+	if typ == nil {
+		return nil
+	}
+
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
@@ -0,0 +1,34 @@
+package rabbitmq
+
+import (
+	"context"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/mock"
+)
+
+func (s *RabbitMQMessagingSuiteTest) TestPublishRoute() {
+	RegisterRoute[MsgBody](s.messaging, "orders", "orders.created")
+
+	s.amqpChannel.
+		On("Publish", "orders", "orders.created", false, false, mock.MatchedBy(func(pub amqp.Publishing) bool {
+			return pub.Type == "*rabbitmq.MsgBody" && pub.MessageId != ""
+		})).
+		Return(nil).
+		Twice()
+
+	s.NoError(Publish(context.Background(), s.messaging, &MsgBody{Name: "name"}))
+	s.NoError(Publish(context.Background(), s.messaging, MsgBody{Name: "name"}))
+	s.amqpChannel.AssertExpectations(s.T())
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestPublishRouteNotRegistered() {
+	type unrouted struct{}
+
+	s.ErrorIs(Publish(context.Background(), s.messaging, &unrouted{}), ErrorRouteNotRegistered)
+	s.ErrorIs(s.messaging.PublishRouted(context.Background(), nil), ErrorRouteNotRegistered)
+	s.amqpChannel.AssertNotCalled(s.T(), "Publish")
+
+	s.messaging.AddPublishRoute(nil, "orders", "orders.created")
+	s.ErrorIs(s.messaging.Err, ErrorRouteType)
+}
@@ -0,0 +1,165 @@
+package rabbitmq
+
+import (
+	"encoding/json"
+	"io"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/streadway/amqp"
+	"go.uber.org/zap"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+var tapSample = rand.Float64
+
+type (
+	logTapSink struct {
+		logger logging.ILogger
+	}
+
+	writerTapSink struct {
+		mu sync.Mutex
+		w  io.Writer
+	}
+
+	queueTapSink struct {
+		ch    AMQPChannel
+		queue string
+	}
+)
+
+// Tap a nil opts, a zero rate or a nil sink disable the tap
+func (m *RabbitMQMessaging) Tap(opts *TapOpts) IRabbitMQMessaging {
+	if opts == nil || opts.Rate <= 0 || opts.Sink == nil {
+		m.tap = nil
+		return m
+	}
+
+	if opts.MaxBodySize <= 0 {
+		opts.MaxBodySize = DefaultTapMaxBodySize
+	}
+
+	m.tap = opts
+
+	return m
+}
+
+// NewLogTapSink write the entries in the logger with info level
+func NewLogTapSink(logger logging.ILogger) TapSink {
+	return &logTapSink{logger}
+}
+
+// NewWriterTapSink write the entries as JSON lines, e.g: a file
+func NewWriterTapSink(w io.Writer) TapSink {
+	return &writerTapSink{w: w}
+}
+
+// NewQueueTapSink publish the entries as JSON in a dedicated queue using the default exchange, the queue must be declared
+func (m *RabbitMQMessaging) NewQueueTapSink(queue string) TapSink {
+	return &queueTapSink{ch: m.ch, queue: queue}
+}
+
+func (m *RabbitMQMessaging) tapPublishing(exchange, routingKey string, pub *amqp.Publishing) {
+	if m.tap == nil || tapSample() >= m.tap.Rate {
+		return
+	}
+
+	entry := m.newTapEntry(pub.Headers, pub.Body)
+	entry.Direction = PUBLISHED_TAP
+	entry.Exchange = exchange
+	entry.RoutingKey = routingKey
+	entry.MessageId = pub.MessageId
+	entry.Type = pub.Type
+	entry.ContentType = pub.ContentType
+
+	m.sendTap(entry)
+}
+
+func (m *RabbitMQMessaging) tapDelivery(queue string, delivery *amqp.Delivery) {
+	if m.tap == nil || tapSample() >= m.tap.Rate {
+		return
+	}
+
+	entry := m.newTapEntry(delivery.Headers, delivery.Body)
+	entry.Direction = CONSUMED_TAP
+	entry.Exchange = delivery.Exchange
+	entry.RoutingKey = delivery.RoutingKey
+	entry.Queue = queue
+	entry.MessageId = delivery.MessageId
+	entry.Type = delivery.Type
+	entry.ContentType = delivery.ContentType
+
+	m.sendTap(entry)
+}
+
+func (m *RabbitMQMessaging) newTapEntry(headers amqp.Table, body []byte) *TapEntry {
+	entry := &TapEntry{
+		Headers:   make(map[string]interface{}, len(headers)),
+		BodySize:  len(body),
+		Timestamp: time.Now(),
+	}
+
+	for k, v := range headers {
+		entry.Headers[k] = v
+	}
+
+	if len(body) > m.tap.MaxBodySize {
+		body = body[:m.tap.MaxBodySize]
+		entry.Truncated = true
+	}
+	entry.Body = string(body)
+
+	return entry
+}
+
+func (m *RabbitMQMessaging) sendTap(entry *TapEntry) {
+	if err := m.tap.Sink.Tap(entry); err != nil {
+		m.logger.Warn(LogMessage("failure to send the tap entry"), logging.ErrorField(err))
+	}
+}
+
+func (s *logTapSink) Tap(entry *TapEntry) error {
+	s.logger.Info(LogMessage("tap "+string(entry.Direction)),
+		logging.MessageField("exchange", entry.Exchange),
+		logging.MessageField("routingKey", entry.RoutingKey),
+		logging.MessageField("queue", entry.Queue),
+		logging.MessageField("messageId", entry.MessageId),
+		logging.MessageField("type", entry.Type),
+		zap.Any("headers", entry.Headers),
+		logging.MessageField("body", entry.Body),
+		zap.Bool("truncated", entry.Truncated),
+	)
+
+	return nil
+}
+
+func (s *writerTapSink) Tap(entry *TapEntry) error {
+	byt, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.w.Write(append(byt, '\n'))
+	return err
+}
+
+func (s *queueTapSink) Tap(entry *TapEntry) error {
+	byt, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return s.ch.Publish("", s.queue, false, false, amqp.Publishing{
+		ContentType: JsonContentType,
+		Type:        string(entry.Direction),
+		MessageId:   entry.MessageId,
+		Timestamp:   entry.Timestamp,
+		Body:        byt,
+	})
+}
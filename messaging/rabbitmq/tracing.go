@@ -0,0 +1,106 @@
+package rabbitmq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// amqpHeadersCarrier adapts the amqp headers to the otel propagators
+type amqpHeadersCarrier amqp.Table
+
+var (
+	_ propagation.TextMapCarrier = amqpHeadersCarrier{}
+
+	// propagator W3C trace context and baggage, it works even when the global propagator was not configured
+	propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+)
+
+func (c amqpHeadersCarrier) Get(key string) string {
+	v, _ := c[key].(string)
+	return v
+}
+
+func (c amqpHeadersCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c amqpHeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
+
+// startProducerSpan start the publish span linked to the span carried in ctx, e.g: the consumer span of the message being handled
+//
+// The span context and the ctx baggage are injected in the headers
+func startProducerSpan(ctx context.Context, exchange, routingKey string, headers amqp.Table) trace.Span {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination", exchange),
+			attribute.String("messaging.rabbitmq.routing_key", routingKey),
+		),
+	}
+
+	if parent := trace.SpanContextFromContext(ctx); parent.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{
+			SpanContext: parent,
+			Attributes:  []attribute.KeyValue{attribute.String("messaging.link", "consumer")},
+		}))
+	}
+
+	ctx, span := otel.Tracer(TracerName).Start(ctx, fmt.Sprintf("%s send", exchange), opts...)
+	propagator.Inject(ctx, amqpHeadersCarrier(headers))
+
+	return span
+}
+
+// startConsumerSpan start the process span as child of the producer span propagated in the headers, also linking to it
+//
+// The returned ctx carries the consumer span and the propagated baggage
+func startConsumerSpan(queue string, received *amqp.Delivery) (context.Context, trace.Span) {
+	ctx := propagator.Extract(context.Background(), amqpHeadersCarrier(received.Headers))
+
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.source", queue),
+			attribute.String("messaging.message_id", received.MessageId),
+			attribute.String("messaging.operation", "process"),
+		),
+	}
+
+	if producer := trace.SpanContextFromContext(ctx); producer.IsValid() {
+		opts = append(opts, trace.WithLinks(trace.Link{
+			SpanContext: producer,
+			Attributes:  []attribute.KeyValue{attribute.String("messaging.link", "producer")},
+		}))
+	}
+
+	return otel.Tracer(TracerName).Start(ctx, fmt.Sprintf("%s process", queue), opts...)
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -1,13 +1,21 @@
 package rabbitmq
 
 import (
+	"context"
 	"reflect"
+	"sync"
 	"time"
 
 	"github.com/streadway/amqp"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
 
 	"github.com/ralvescosta/gokit/env"
 	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/cloudevents"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq/management"
+	"github.com/ralvescosta/gokit/pool"
 )
 
 type (
@@ -18,14 +26,65 @@ type (
 	Retry struct {
 		NumberOfRetry int64
 		DelayBetween  time.Duration
+
+		// Escalation optional, hooks fired on the failed attempts
+		Escalation *RetryEscalation
+	}
+
+	// RetryEscalation hooks fired when the handler fails, e.g: to open an incident or emit a domain event, they run in the
+	// consumer goroutine before the message is retried or dead-lettered
+	RetryEscalation struct {
+		// OnAttempt called on each failed attempt that will be retried
+		OnAttempt func(failure *FailedDelivery)
+		// OnExhausted called when the message is not retried anymore, the retries are over or the error is not retryable
+		OnExhausted func(failure *FailedDelivery)
+	}
+
+	// FailedDelivery the failed attempt passed to the RetryEscalation hooks
+	FailedDelivery struct {
+		Queue string
+		// Msg the unmarshaled message
+		Msg      any
+		Metadata *DeliveryMetadata
+		// Delivery the original delivery, with the body and the headers
+		Delivery *amqp.Delivery
+		// Attempt the number of the failed attempt, starting at 1
+		Attempt int64
+		Err     error
+		// Errors the error history, the errors of the previous attempts followed by Err
+		Errors []string
 	}
 
 	// QueueOpts declare queue configuration
+	//
+	// TTL optional, the queue x-message-ttl, the expired messages are discarded or sent to the DLQ when it was configured
 	QueueOpts struct {
 		Name           string
 		TTL            time.Duration
 		Retryable      *Retry
 		WithDeadLatter bool
+		// Concurrency optional, the queue gets its own bounded buffer and worker pool, by default the messages are handled one by one
+		Concurrency *ConcurrencyOpts
+		// HandlerTimeout optional, the DeliveryMetadata.Ctx deadline, the message is retried per policy when the handler does not return in time
+		HandlerTimeout time.Duration
+	}
+
+	OverflowPolicy int8
+
+	// ConcurrencyOpts isolate the queue consumption so a slow handler on one queue does not starve the others
+	ConcurrencyOpts struct {
+		// Workers the number of handlers running in parallel, the default is DefaultConcurrencyWorkers
+		Workers int
+		// BufferSize the deliveries waiting for a free worker
+		BufferSize int
+		// Overflow what happens when the buffer is full, the default is BLOCK_OVERFLOW
+		Overflow OverflowPolicy
+
+		// PartitionKey optional, the header with the partition key, e.g: the account id, the messages with the same key are
+		// handled in order by the same worker while the different keys run in parallel, each worker gets a buffer of BufferSize.
+		// The order is only kept when the messages are not requeued, the NACK_REQUEUE_OVERFLOW and the retries send
+		// the message back to the queue
+		PartitionKey string
 	}
 
 	// ExchangeOpts exchanges to declare
@@ -64,6 +123,80 @@ type (
 		deadLetter *DeadLetterOpts
 		delayed    *DelayedOpts
 		isBindable bool
+		// retry the tiered retry topology, it was declared by DeclareRetryTopology so Build skips it
+		retry *RetryTopology
+		// Connection optional, the connection name registered by AddConnection, the default is the New connection
+		Connection string
+	}
+
+	// RetryTopologyOpts parameters to DeclareRetryTopology
+	RetryTopologyOpts struct {
+		Queue string
+		// Exchange optional exchange, already declared, bound to the main queue
+		Exchange string
+		// RoutingKey used to bind the exchange, the default is the queue name
+		RoutingKey string
+		// Tiers the TTL of each retry queue, the default is DefaultRetryTiers
+		Tiers []time.Duration
+		// Concurrency optional, see QueueOpts.Concurrency
+		Concurrency *ConcurrencyOpts
+		// Escalation optional, the OnExhausted hook is called before the message is sent to the DLQ
+		Escalation *RetryEscalation
+	}
+
+	// RetryTier a retry queue that dead-letters the messages back to the main queue after the TTL
+	RetryTier struct {
+		Queue string
+		TTL   time.Duration
+	}
+
+	// RetryTopology the main queue, the tiered retry queues and the final DLQ
+	RetryTopology struct {
+		Queue string
+		DLQ   string
+		Tiers []*RetryTier
+
+		messaging  *RabbitMQMessaging
+		escalation *RetryEscalation
+	}
+
+	// Duration a time.Duration written as a string in the topology file, e.g: "30s"
+	Duration time.Duration
+
+	// TopologyFile the declarative topology, e.g:
+	//
+	//	{
+	//		"topologies": [{"exchange": "orders", "exchangeType": "direct", "queue": "orders.created", "deadLetter": true, "retry": {"attempts": 3, "delay": "5s"}}],
+	//		"retryTopologies": [{"queue": "payments", "exchange": "orders", "tiers": ["5s", "1m"]}]
+	//	}
+	TopologyFile struct {
+		Topologies      []*TopologySpec      `json:"topologies"`
+		RetryTopologies []*RetryTopologySpec `json:"retryTopologies"`
+	}
+
+	// TopologySpec an exchange bound to a queue, see Topology
+	TopologySpec struct {
+		Exchange         string       `json:"exchange"`
+		ExchangeType     ExchangeKind `json:"exchangeType"`
+		ExchangeBindings []string     `json:"exchangeBindings"`
+		Queue            string       `json:"queue"`
+		TTL              Duration     `json:"ttl"`
+		DeadLetter       bool         `json:"deadLetter"`
+		Retry            *RetrySpec   `json:"retry"`
+		HandlerTimeout   Duration     `json:"handlerTimeout"`
+	}
+
+	RetrySpec struct {
+		Attempts int64    `json:"attempts"`
+		Delay    Duration `json:"delay"`
+	}
+
+	// RetryTopologySpec see RetryTopologyOpts
+	RetryTopologySpec struct {
+		Queue      string     `json:"queue"`
+		Exchange   string     `json:"exchange"`
+		RoutingKey string     `json:"routingKey"`
+		Tiers      []Duration `json:"tiers"`
 	}
 
 	// PUblishOpts
@@ -73,6 +206,14 @@ type (
 		TraceId   string
 		MessageId string
 		Delay     time.Duration
+		// Headers optional, additional amqp headers, e.g: correlation.AMQPHeader
+		Headers map[string]interface{}
+		// Ctx optional, the producer span is linked to the span in Ctx and the Ctx baggage is propagated, use DeliveryMetadata.Ctx to link the consume->publish chain
+		Ctx context.Context
+		// Connection optional, the connection name registered by AddConnection, the default is the New connection
+		Connection string
+		// Expiration optional, the message is discarded when it was not consumed before the expiration, the queue TTL is used when it is lower
+		Expiration time.Duration
 	}
 
 	// DeliveryMetadata amqp message received
@@ -82,11 +223,178 @@ type (
 		Type      string
 		TraceId   string
 		Headers   map[string]interface{}
+		// CloudEvent the received event when the dispatcher was registered with RegisterCloudEventDispatcher
+		CloudEvent *cloudevents.Event
+		// Ctx carries the consumer span and the propagated baggage
+		Ctx context.Context
 	}
 
 	// ConsumerHandler
 	ConsumerHandler = func(msg any, metadata *DeliveryMetadata) error
 
+	// REDObserver observe the handled messages, *metric.RED of the telemetry package implements it
+	REDObserver interface {
+		Start(ctx context.Context, operation string, attrs ...attribute.KeyValue) func(code string, err error)
+	}
+
+	// PublishValidator returns an error when the message must not be published
+	PublishValidator = func(msg any) error
+
+	TapDirection string
+
+	// TapEntry a sampled message mirrored to the TapSink
+	TapEntry struct {
+		Direction   TapDirection           `json:"direction"`
+		Exchange    string                 `json:"exchange,omitempty"`
+		RoutingKey  string                 `json:"routingKey,omitempty"`
+		Queue       string                 `json:"queue,omitempty"`
+		MessageId   string                 `json:"messageId"`
+		Type        string                 `json:"type"`
+		ContentType string                 `json:"contentType"`
+		Headers     map[string]interface{} `json:"headers"`
+		Body        string                 `json:"body"`
+		BodySize    int                    `json:"bodySize"`
+		Truncated   bool                   `json:"truncated"`
+		Timestamp   time.Time              `json:"timestamp"`
+	}
+
+	// TapSink receives the sampled messages, it must not block for long since it runs in the publish/consume path
+	TapSink interface {
+		Tap(entry *TapEntry) error
+	}
+
+	// TapOpts the debug tap configuration
+	TapOpts struct {
+		// Rate the percentage of the messages mirrored, between 0 and 1
+		Rate float64
+		// MaxBodySize the body is truncated to MaxBodySize bytes, the default is DefaultTapMaxBodySize
+		MaxBodySize int
+		Sink        TapSink
+	}
+
+	// ArchivedMessage a consumed message stored by the archive, see Archive
+	ArchivedMessage struct {
+		Queue       string                 `json:"queue"`
+		Exchange    string                 `json:"exchange"`
+		RoutingKey  string                 `json:"routingKey"`
+		MessageId   string                 `json:"messageId"`
+		Type        string                 `json:"type"`
+		ContentType string                 `json:"contentType"`
+		Headers     map[string]interface{} `json:"headers"`
+		Body        []byte                 `json:"body"`
+		Timestamp   time.Time              `json:"timestamp"`
+	}
+
+	// ReplayFilter select the archived messages of the queue, the zero From, To and Types are not filtered
+	ReplayFilter struct {
+		Queue string
+		// From inclusive
+		From time.Time
+		// To exclusive
+		To    time.Time
+		Types []string
+		// Limit zero replays all the messages
+		Limit int
+	}
+
+	// ArchiveStore persist the consumed messages, e.g: NewSqlArchiveStore or storage.NewMessageArchive to the object storage
+	ArchiveStore interface {
+		Archive(ctx context.Context, msg *ArchivedMessage) error
+		// Query the archived messages matching the filter ordered by the archive time
+		Query(ctx context.Context, filter *ReplayFilter) ([]*ArchivedMessage, error)
+	}
+
+	ArchiveOpts struct {
+		Store ArchiveStore
+		// Queues the archived queues, empty archives all the consumed queues
+		Queues []string
+	}
+
+	// DedupStore reserve the content hashes of the published messages
+	DedupStore interface {
+		// Reserve returns false when the key is already reserved and not expired
+		Reserve(ctx context.Context, key string, ttl time.Duration) (bool, error)
+		Release(ctx context.Context, key string) error
+	}
+
+	// DedupPublishOpts the messages with the same content hash published in the window are skipped
+	ConnectionEventKind string
+
+	// ConnectionEvent a broker connection lifecycle event
+	ConnectionEvent struct {
+		Kind ConnectionEventKind
+		// Connection the connection name, DefaultConnection or the AddConnection name
+		Connection string
+		// Reason the broker reason of the BLOCKED_EVENT, e.g: low on memory
+		Reason string
+		// Err the broker error when the connection or the channel was closed by the broker, nil on the graceful close
+		Err error
+	}
+
+	// FlowControlOpts the publishing while the broker blocks the connection, e.g: the memory or the disk alarm, without it
+	// the publishes hang until the unblock
+	FlowControlOpts struct {
+		// Wait the max time the publisher waits the unblock before ErrorBrokerBlocked, default DefaultFlowControlWait, a
+		// negative Wait fails immediately
+		Wait time.Duration
+		// Divert optional, receives the publishing instead of waiting, e.g: to write it in an outbox table and publish it later
+		Divert func(exchange, routingKey string, pub amqp.Publishing) error
+	}
+
+	// ConsumerInfo a started consumer, see Consumers
+	ConsumerInfo struct {
+		// Tag the consumer tag shown in the management UI
+		Tag        string
+		Queue      string
+		MsgType    string
+		Connection string
+		// Service the APP_NAME
+		Service  string
+		Hostname string
+		PID      int
+		Paused   bool
+	}
+
+	// ConsumerTagFunc the tag of the consumer, seq is unique in the messaging instance, see DefaultConsumerTag
+	ConsumerTagFunc = func(info *ConsumerInfo, seq int) string
+
+	// ConnectionEventHook called in the watcher goroutine, it must not block
+	ConnectionEventHook = func(evt *ConnectionEvent)
+
+	// PublishRoute the destination of a message type, see RegisterRoute
+	PublishRoute struct {
+		Exchange   string
+		RoutingKey string
+		// Type the message type header, the pointer type name like the RegisterDispatcher type, e.g: *orders.OrderCreated
+		Type string
+	}
+
+	DedupPublishOpts struct {
+		Store DedupStore
+		// Window default DefaultDedupWindow
+		Window time.Duration
+		// Hash default ContentHash
+		Hash func(exchange, routingKey, typ string, body []byte) string
+	}
+
+	DriftKind string
+
+	// TopologyDrift a declared property that differs in the broker
+	TopologyDrift struct {
+		Kind DriftKind
+		// Name the queue or the exchange name, the bindings are named source->destination
+		Name string
+		// Property e.g: exists, durable, type, routingKey or arguments.x-dead-letter-routing-key
+		Property string
+		Expected any
+		Actual   any
+	}
+
+	// TopologyDiff the result of VerifyTopology
+	TopologyDiff struct {
+		Drifts []*TopologyDrift
+	}
+
 	// IRabbitMQMessaging is RabbitMQ  Builder
 	IRabbitMQMessaging interface {
 		// Declare a new topology
@@ -98,6 +406,12 @@ type (
 		// Publish a message
 		Publisher(exchange, routingKey string, msg any, opts *PublishOpts) error
 
+		// PublishCloudEvent publish a CloudEvents 1.0 event in structured or binary mode
+		PublishCloudEvent(exchange, routingKey string, evt *cloudevents.Event, mode cloudevents.Mode) error
+
+		// PublishProto publish the protobuf wire format, the message type is the protobuf fully-qualified name
+		PublishProto(exchange, routingKey string, msg proto.Message, opts *PublishOpts) error
+
 		// Create a new goroutine to each dispatcher registered
 		//
 		// When messages came, some validations will be mad and based on the topology configured message could sent to dql or retry
@@ -109,12 +423,98 @@ type (
 		// After we do a coercion of the msg type to check which handler expect this msg type
 		RegisterDispatcher(event string, handler ConsumerHandler, t any) error
 
+		// RegisterCloudEventDispatcher Add the handler to the CloudEvents with the eventType type attribute
+		//
+		// The event data is unmarshaled into the t type and the event itself is available in the DeliveryMetadata
+		RegisterCloudEventDispatcher(queue, eventType string, handler ConsumerHandler, t any) error
+
+		// RegisterProtoDispatcher Add the handler to the protobuf messages with the t fully-qualified name
+		//
+		// The producers in other languages only need to send the message type as the fully-qualified name, the handler receives a new t
+		RegisterProtoDispatcher(queue string, handler ConsumerHandler, t proto.Message) error
+
+		// AddConnection connect to another broker or vhost, the Topology and the PublishOpts select it by name
+		//
+		// It is useful to bridge the messages between two brokers: consume with the default connection and publish with the named one
+		AddConnection(name string, cfg *env.Configs) IRabbitMQMessaging
+
 		// Build the topology configured
 		Build() (IRabbitMQMessaging, error)
+
+		// Tap mirror a sample of the published and consumed messages (headers and truncated body) to a debug sink
+		Tap(opts *TapOpts) IRabbitMQMessaging
+
+		// Archive persist the consumed messages (headers and body) in the store, so they could be replayed, the store
+		// failures are logged and do not stop the processing
+		Archive(opts *ArchiveOpts) IRabbitMQMessaging
+
+		// Replay publish again the archived messages matching the filter to their queue, e.g: to reprocess them after a bug fix.
+		// The replayed messages have the AMQPHeaderReplayed header, the number of replayed messages is returned
+		Replay(ctx context.Context, filter *ReplayFilter) (int, error)
+
+		// DeduplicatePublish skip the Publisher and PublishProto messages with the same content published in the window, e.g: to
+		// upstream systems that emit repeated identical events, the store failures publish the message
+		DeduplicatePublish(opts *DedupPublishOpts) IRabbitMQMessaging
+
+		// AddPublishRoute map the type of t to the exchange and the routing key used by PublishRouted, see RegisterRoute
+		AddPublishRoute(t any, exchange, routingKey string) IRabbitMQMessaging
+
+		// PublishRouted publish the msg to the route registered to its type, see Publish
+		PublishRouted(ctx context.Context, msg any) error
+
+		// WatchConnections report the connection lifecycle events to the hook and observe the open connections and channels
+		// gauges, e.g: to alert on flapping brokers
+		WatchConnections(hook ConnectionEventHook) IRabbitMQMessaging
+
+		// FlowControl wait, fail or divert the publishing while the broker applies flow control to the connection
+		FlowControl(opts *FlowControlOpts) IRabbitMQMessaging
+
+		// IsBlocked the broker is applying flow control to a connection, it requires WatchConnections or FlowControl
+		IsBlocked() bool
+
+		// ConsumerTag the consumer tag naming, the default is DefaultConsumerTag
+		ConsumerTag(tag ConsumerTagFunc) IRabbitMQMessaging
+
+		// Consumers the started consumers with their tags and host metadata
+		Consumers() []*ConsumerInfo
+
+		// ValidatePublish validate the messages before the Publisher marshal them, e.g: ValidatePublish(validation.Struct)
+		ValidatePublish(validate PublishValidator) IRabbitMQMessaging
+
+		// DeclareRetryTopology declare the main queue, one retry queue per tier with increasing TTLs and the final DLQ
+		//
+		// Dispatchers registered to the queue send the retryable failures to the next tier and the other failures to the DLQ
+		DeclareRetryTopology(opts *RetryTopologyOpts) (*RetryTopology, error)
+
+		// QueueStats returns the backlog and the consumers of the queue
+		//
+		// The management API is used when RABBIT_MANAGEMENT_URL is configured, otherwise only Messages and Consumers are filled by a passive declare
+		QueueStats(queue string) (*management.QueueStats, error)
+
+		// PauseQueue cancel the consumers of the queue, the messages stay in the broker until ResumeQueue
+		PauseQueue(queue string) error
+
+		// ResumeQueue consume again the paused queue
+		ResumeQueue(queue string) error
+
+		// Drain pause the queue and waits the in-flight messages until the timeout, ErrorDrainTimeout is returned when the timeout is reached
+		Drain(queue string, timeout time.Duration) error
+
+		// VerifyTopology compare the declared topologies (durable flags, arguments and bindings) with the broker
+		//
+		// The management API is used when RABBIT_MANAGEMENT_URL is configured, otherwise only the existence of the queues and the exchanges is verified by passive declares
+		VerifyTopology() (*TopologyDiff, error)
+
+		// Shutdown cancel the consumers, waits the in-flight messages until ctx is done and close the connection.
+		// Consume returns nil after the shutdown
+		Shutdown(ctx context.Context) error
 	}
 
 	AMQPConnection interface {
 		Channel() (*amqp.Channel, error)
+		NotifyClose(receiver chan *amqp.Error) chan *amqp.Error
+		NotifyBlocked(receiver chan amqp.Blocking) chan amqp.Blocking
+		Close() error
 	}
 
 	// AMQPChannel is an abstraction for AMQP default channel to improve unit tests
@@ -124,7 +524,13 @@ type (
 		QueueDeclare(name string, durable, autoDelete, exclusive, noWait bool, args amqp.Table) (amqp.Queue, error)
 		QueueBind(name, key, exchange string, noWait bool, args amqp.Table) error
 		Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+		Qos(prefetchCount, prefetchSize int, global bool) error
 		Publish(exchange, key string, mandatory, immediate bool, msg amqp.Publishing) error
+		QueueInspect(name string) (amqp.Queue, error)
+		Cancel(consumer string, noWait bool) error
+		ExchangeDeclarePassive(name, kind string, durable, autoDelete, internal, noWait bool, args amqp.Table) error
+		NotifyClose(receiver chan *amqp.Error) chan *amqp.Error
+		Close() error
 	}
 
 	// Dispatcher struct to register an message handler
@@ -134,6 +540,51 @@ type (
 		MsgType       string
 		ReflectedType reflect.Value
 		Handler       ConsumerHandler
+		CloudEvent    bool
+		// ProtoType set by RegisterProtoDispatcher, the body is unmarshaled with protobuf
+		ProtoType protoreflect.MessageType
+		// HandlerTimeout the queue HandlerTimeout, zero means no deadline
+		HandlerTimeout time.Duration
+	}
+
+	// namedConnection a connection registered by AddConnection
+	namedConnection struct {
+		conn AMQPConnection
+		ch   AMQPChannel
+	}
+
+	expectedExchange struct {
+		name       string
+		kind       ExchangeKind
+		args       amqp.Table
+		connection string
+	}
+
+	expectedQueue struct {
+		name       string
+		args       amqp.Table
+		connection string
+	}
+
+	expectedBinding struct {
+		source      string
+		destination string
+		routingKey  string
+		connection  string
+	}
+
+	// bufferedDelivery a delivery waiting a worker of the concurrent consumer
+	bufferedDelivery struct {
+		delivery amqp.Delivery
+		ticket   pool.Ticket
+	}
+
+	consumerState struct {
+		paused bool
+		// consuming the Consume registered the tag in the broker, guarded by mu
+		consuming bool
+		done      chan struct{}
+		info      *ConsumerInfo
 	}
 
 	// IRabbitMQMessaging is the implementation for IRabbitMQMessaging
@@ -146,6 +597,31 @@ type (
 		shotdown    chan error
 		topologies  []*Topology
 		dispatchers []*Dispatcher
+		management  management.IManagementClient
+		tap         *TapOpts
+		archive     *ArchiveOpts
+		dedup       *DedupPublishOpts
+		// consumerStates the consumers started by Consume, guarded by mu
+		consumerStates map[*Dispatcher]*consumerState
+		consumers      sync.WaitGroup
+		mu             sync.Mutex
+		closing        chan struct{}
+		// consumeMu serialize the Qos and the Consume, the prefetch applies to the next consumer of the shared channel
+		consumeMu sync.Mutex
+		// connections the connections registered by AddConnection
+		connections map[string]*namedConnection
+		// validatePublish the validator set by ValidatePublish
+		validatePublish PublishValidator
+		// routes the publish routes by message type, guarded by mu
+		routes map[reflect.Type]*PublishRoute
+
+		// watched the watched connections, guarded by mu, nil when WatchConnections and FlowControl were not called
+		watched     map[string]*connectionState
+		eventHook   ConnectionEventHook
+		flowControl *FlowControlOpts
+
+		consumerTag ConsumerTagFunc
+		consumerSeq int
 	}
 )
 
@@ -0,0 +1,314 @@
+package rabbitmq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/streadway/amqp"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq/management"
+)
+
+func (m *RabbitMQMessaging) VerifyTopology() (*TopologyDiff, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	m.logger.Debug(LogMessage("verifying the topology..."))
+
+	ctx := context.Background()
+	diff := &TopologyDiff{}
+	exchanges, queues, bindings := m.expectedTopology()
+
+	for _, e := range exchanges {
+		if err := m.verifyExchange(ctx, diff, e); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, q := range queues {
+		if err := m.verifyQueue(ctx, diff, q); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := m.verifyBindings(ctx, diff, bindings); err != nil {
+		return nil, err
+	}
+
+	if diff.HasDrift() {
+		m.logger.Warn(LogMessage(fmt.Sprintf("topology drift: %s", diff)))
+		return diff, nil
+	}
+
+	m.logger.Debug(LogMessage("topology verified"))
+
+	return diff, nil
+}
+
+// expectedTopology the exchanges, queues and bindings declared by Build and DeclareRetryTopology
+func (m *RabbitMQMessaging) expectedTopology() ([]*expectedExchange, []*expectedQueue, []*expectedBinding) {
+	exchanges := []*expectedExchange{}
+	queues := []*expectedQueue{}
+	bindings := []*expectedBinding{}
+
+	for _, t := range m.topologies {
+		if rt := t.retry; rt != nil {
+			queues = append(queues,
+				&expectedQueue{rt.DLQ, nil, t.Connection},
+				&expectedQueue{rt.Queue, deadLetterArguments(rt.DLQ), t.Connection},
+			)
+
+			for _, tier := range rt.Tiers {
+				queues = append(queues, &expectedQueue{tier.Queue, retryTierArguments(tier, rt.Queue), t.Connection})
+			}
+
+			if t.Exchange.Name != "" {
+				bindings = append(bindings, &expectedBinding{t.Exchange.Name, rt.Queue, t.Binding.RoutingKey, t.Connection})
+			}
+
+			continue
+		}
+
+		if t.Exchange != nil && t.Exchange.Name != "" {
+			exchanges = append(exchanges, &expectedExchange{t.Exchange.Name, t.Exchange.Type, nil, t.Connection})
+
+			for _, e := range t.Exchange.Bindings {
+				bindings = append(bindings, &expectedBinding{t.Exchange.Name, e, m.newRoutingKey(t.Exchange.Name, e), t.Connection})
+			}
+		}
+
+		if t.delayed != nil {
+			exchanges = append(exchanges, &expectedExchange{t.delayed.ExchangeName, DELAY_EXCHANGE, delayedExchangeArguments(), t.Connection})
+			bindings = append(bindings, &expectedBinding{t.delayed.ExchangeName, t.delayed.QueueName, t.Binding.delayedRoutingKey, t.Connection})
+		}
+
+		if t.Queue == nil {
+			continue
+		}
+
+		if t.deadLetter != nil {
+			queues = append(queues, &expectedQueue{t.deadLetter.QueueName, nil, t.Connection})
+		}
+
+		queues = append(queues, &expectedQueue{t.Queue.Name, queueArguments(t), t.Connection})
+
+		if t.Exchange != nil && t.Exchange.Name != "" && t.Binding != nil {
+			bindings = append(bindings, &expectedBinding{t.Exchange.Name, t.Queue.Name, t.Binding.RoutingKey, t.Connection})
+		}
+	}
+
+	return exchanges, queues, bindings
+}
+
+// withManagement the management API is configured to the default connection only
+func (m *RabbitMQMessaging) withManagement(connection string) bool {
+	return m.management != nil && (connection == "" || connection == DefaultConnection)
+}
+
+func (m *RabbitMQMessaging) verifyExchange(ctx context.Context, diff *TopologyDiff, e *expectedExchange) error {
+	if !m.withManagement(e.connection) {
+		exists, err := m.passiveDeclare(e.connection, func(ch AMQPChannel) error {
+			return ch.ExchangeDeclarePassive(e.name, string(e.kind), true, false, false, false, e.args)
+		})
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			diff.add(EXCHANGE_DRIFT, e.name, "exists", true, false)
+		}
+
+		return nil
+	}
+
+	exchange, err := m.management.Exchange(ctx, e.name)
+	if errors.Is(err, management.ErrorExchangeNotFound) {
+		diff.add(EXCHANGE_DRIFT, e.name, "exists", true, false)
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if exchange.Type != string(e.kind) {
+		diff.add(EXCHANGE_DRIFT, e.name, "type", string(e.kind), exchange.Type)
+	}
+
+	if !exchange.Durable {
+		diff.add(EXCHANGE_DRIFT, e.name, "durable", true, false)
+	}
+
+	diff.compareArguments(EXCHANGE_DRIFT, e.name, e.args, exchange.Arguments)
+
+	return nil
+}
+
+func (m *RabbitMQMessaging) verifyQueue(ctx context.Context, diff *TopologyDiff, q *expectedQueue) error {
+	if !m.withManagement(q.connection) {
+		exists, err := m.passiveDeclare(q.connection, func(ch AMQPChannel) error {
+			_, err := ch.QueueInspect(q.name)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		if !exists {
+			diff.add(QUEUE_DRIFT, q.name, "exists", true, false)
+		}
+
+		return nil
+	}
+
+	queue, err := m.management.QueueStats(ctx, q.name)
+	if errors.Is(err, management.ErrorQueueNotFound) {
+		diff.add(QUEUE_DRIFT, q.name, "exists", true, false)
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if !queue.Durable {
+		diff.add(QUEUE_DRIFT, q.name, "durable", true, false)
+	}
+
+	diff.compareArguments(QUEUE_DRIFT, q.name, q.args, queue.Arguments)
+
+	return nil
+}
+
+// verifyBindings the bindings can not be inspected through AMQP, so they are verified only with the management API
+func (m *RabbitMQMessaging) verifyBindings(ctx context.Context, diff *TopologyDiff, bindings []*expectedBinding) error {
+	sources := map[string][]*management.Binding{}
+
+	for _, b := range bindings {
+		if !m.withManagement(b.connection) {
+			m.logger.Debug(LogMessage(fmt.Sprintf("skipping the binding %s->%s verification, the management API is required", b.source, b.destination)))
+			continue
+		}
+
+		actual, ok := sources[b.source]
+		if !ok {
+			var err error
+			actual, err = m.management.ExchangeBindings(ctx, b.source)
+			if err != nil && !errors.Is(err, management.ErrorExchangeNotFound) {
+				return err
+			}
+
+			sources[b.source] = actual
+		}
+
+		routingKeys := []string{}
+		found := false
+		for _, a := range actual {
+			if a.Destination != b.destination {
+				continue
+			}
+
+			routingKeys = append(routingKeys, a.RoutingKey)
+			found = found || a.RoutingKey == b.routingKey
+		}
+
+		if !found {
+			diff.add(BINDING_DRIFT, b.source+"->"+b.destination, "routingKey", b.routingKey, routingKeys)
+		}
+	}
+
+	return nil
+}
+
+// passiveDeclare runs the declare in a dedicated channel, since the broker closes the channel when the queue or the exchange does not exist
+func (m *RabbitMQMessaging) passiveDeclare(connection string, declare func(ch AMQPChannel) error) (bool, error) {
+	conn, err := m.connection(connection)
+	if err != nil {
+		return false, err
+	}
+
+	ch, err := openChannel(conn)
+	if err != nil {
+		return false, err
+	}
+	defer ch.Close()
+
+	err = declare(ch)
+
+	var amqpErr *amqp.Error
+	if errors.As(err, &amqpErr) && amqpErr.Code == amqp.NotFound {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func (d *TopologyDiff) add(kind DriftKind, name, property string, expected, actual any) {
+	for _, drift := range d.Drifts {
+		if drift.Kind == kind && drift.Name == name && drift.Property == property {
+			return
+		}
+	}
+
+	d.Drifts = append(d.Drifts, &TopologyDrift{kind, name, property, expected, actual})
+}
+
+// compareArguments the broker returns the numbers as float64, so the arguments are compared by the printed value
+func (d *TopologyDiff) compareArguments(kind DriftKind, name string, expected amqp.Table, actual map[string]any) {
+	keys := map[string]bool{}
+	for k := range expected {
+		keys[k] = true
+	}
+
+	for k := range actual {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	for _, k := range sorted {
+		e, inExpected := expected[k]
+		a, inActual := actual[k]
+
+		if inExpected && inActual && fmt.Sprint(e) == fmt.Sprint(a) {
+			continue
+		}
+
+		d.add(kind, name, "arguments."+k, e, a)
+	}
+}
+
+// HasDrift returns true when the broker differs from the declared topology
+func (d *TopologyDiff) HasDrift() bool {
+	return len(d.Drifts) > 0
+}
+
+// Err returns ErrorTopologyDrift with the drifts, nil when the broker matches the declared topology
+func (d *TopologyDiff) Err() error {
+	if !d.HasDrift() {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrorTopologyDrift, d)
+}
+
+func (d *TopologyDiff) String() string {
+	drifts := make([]string, 0, len(d.Drifts))
+	for _, drift := range d.Drifts {
+		drifts = append(drifts, drift.String())
+	}
+
+	return strings.Join(drifts, "; ")
+}
+
+func (d *TopologyDrift) String() string {
+	return fmt.Sprintf("%s %s %s expected %v got %v", d.Kind, d.Name, d.Property, d.Expected, d.Actual)
+}
@@ -0,0 +1,87 @@
+package rabbitmq
+
+import (
+	"errors"
+
+	"github.com/streadway/amqp"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq/management"
+)
+
+func (s *RabbitMQMessagingSuiteTest) verifyTopologies() {
+	t := &Topology{
+		Queue:    &QueueOpts{Name: "orders.created", Retryable: &Retry{NumberOfRetry: 3}},
+		Exchange: &ExchangeOpts{Name: "orders", Type: DIRECT_EXCHANGE},
+	}
+	s.messaging.Declare(t).ApplyBinds()
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestVerifyTopologyWithManagement() {
+	s.verifyTopologies()
+
+	mgmt := management.NewMockManagementClient()
+	s.messaging.management = mgmt
+
+	mgmt.On("Exchange", mock.Anything, "orders").Return(&management.Exchange{Name: "orders", Type: "fanout", Durable: true}, nil)
+	mgmt.On("Exchange", mock.Anything, "delayed-orders").Return(nil, management.ErrorExchangeNotFound)
+	mgmt.On("QueueStats", mock.Anything, "dlq-orders.created").Return(&management.QueueStats{Durable: true}, nil)
+	mgmt.On("QueueStats", mock.Anything, "orders.created").Return(&management.QueueStats{
+		Durable:   false,
+		Arguments: map[string]any{"x-dead-letter-exchange": "", "x-dead-letter-routing-key": "orders.created-dlq", "x-message-ttl": float64(1000)},
+	}, nil)
+	mgmt.On("ExchangeBindings", mock.Anything, "orders").Return([]*management.Binding{{Source: "orders", Destination: "orders.created", RoutingKey: "orders-orders.created-key"}}, nil)
+	mgmt.On("ExchangeBindings", mock.Anything, "delayed-orders").Return(nil, management.ErrorExchangeNotFound)
+
+	diff, err := s.messaging.VerifyTopology()
+
+	s.NoError(err)
+	s.True(diff.HasDrift())
+	s.ErrorIs(diff.Err(), ErrorTopologyDrift)
+	s.Equal([]*TopologyDrift{
+		{EXCHANGE_DRIFT, "orders", "type", "direct", "fanout"},
+		{EXCHANGE_DRIFT, "delayed-orders", "exists", true, false},
+		{QUEUE_DRIFT, "orders.created", "durable", true, false},
+		{QUEUE_DRIFT, "orders.created", "arguments.x-dead-letter-routing-key", "dlq-orders.created", "orders.created-dlq"},
+		{QUEUE_DRIFT, "orders.created", "arguments.x-message-ttl", nil, float64(1000)},
+		{BINDING_DRIFT, "delayed-orders->orders.created", "routingKey", "delayed-orders-orders.created-key", []string{}},
+	}, diff.Drifts)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestVerifyTopologyPassive() {
+	s.verifyTopologies()
+
+	channel := NewMockAMQPChannel()
+	defaultOpenChannel := openChannel
+	defer func() { openChannel = defaultOpenChannel }()
+	openChannel = func(conn AMQPConnection) (AMQPChannel, error) {
+		return channel, nil
+	}
+
+	channel.On("ExchangeDeclarePassive", "orders", "direct", true, false, false, false, amqp.Table(nil)).Return(nil)
+	channel.On("ExchangeDeclarePassive", "delayed-orders", string(DELAY_EXCHANGE), true, false, false, false, delayedExchangeArguments()).Return(nil)
+	channel.On("QueueInspect", "dlq-orders.created").Return(amqp.Queue{}, &amqp.Error{Code: amqp.NotFound})
+	channel.On("QueueInspect", "orders.created").Return(amqp.Queue{}, nil)
+	channel.On("Close").Return(nil)
+
+	diff, err := s.messaging.VerifyTopology()
+
+	s.NoError(err)
+	s.Equal([]*TopologyDrift{{QUEUE_DRIFT, "dlq-orders.created", "exists", true, false}}, diff.Drifts)
+	channel.AssertNumberOfCalls(s.T(), "Close", 4)
+
+	channel.ExpectedCalls = nil
+	channel.On("ExchangeDeclarePassive", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("some error"))
+	channel.On("Close").Return(nil)
+
+	_, err = s.messaging.VerifyTopology()
+	s.Error(err)
+}
+
+func (s *RabbitMQMessagingSuiteTest) TestVerifyTopologyErr() {
+	s.messaging.Err = errors.New("some error")
+
+	_, err := s.messaging.VerifyTopology()
+	s.Error(err)
+	s.Nil((&TopologyDiff{}).Err())
+}
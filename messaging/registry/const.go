@@ -0,0 +1,21 @@
+package registry
+
+import (
+	"errors"
+)
+
+const (
+	ProtobufContentType = "application/x-protobuf"
+	// KafkaHeaderMessageType the kafka header with the fully-qualified name of the protobuf message
+	KafkaHeaderMessageType = "message-type"
+)
+
+var (
+	ErrorMessageNotRegistered = errors.New("protobuf message is not registered")
+	ErrorMessageTypeRequired  = errors.New("protobuf message type is required")
+	ErrorNoProtoMessages      = errors.New("there is no protobuf message in the package")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::registry] " + msg
+}
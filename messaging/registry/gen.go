@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// GeneratedFileName the registration file written next to the generated protobuf code
+const GeneratedFileName = "registry.gen.go"
+
+var registrationTemplate = template.Must(template.New("registration").Parse(`// Code generated by gokit registry. DO NOT EDIT.
+
+package {{ .Package }}
+
+import "github.com/ralvescosta/gokit/messaging/registry"
+
+func init() {
+	registry.Register(
+{{- range .Messages }}
+		&{{ . }}{},
+{{- end }}
+	)
+}
+`))
+
+// GenerateRegistration read the *.pb.go files of dir and returns the go file registering all their messages in the Default registry
+//
+//	//go:generate gokit registry generate -dir .
+func GenerateRegistration(dir string) ([]byte, error) {
+	fset := token.NewFileSet()
+	files, err := filepath.Glob(filepath.Join(dir, "*.pb.go"))
+	if err != nil {
+		return nil, err
+	}
+
+	pkg := ""
+	messages := []string{}
+
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file, nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+
+		pkg = f.Name.Name
+		messages = append(messages, protoMessages(f)...)
+	}
+
+	if len(messages) == 0 {
+		return nil, ErrorNoProtoMessages
+	}
+
+	sort.Strings(messages)
+
+	buf := bytes.Buffer{}
+	if err := registrationTemplate.Execute(&buf, map[string]any{"Package": pkg, "Messages": messages}); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// WriteRegistration write the GenerateRegistration output to dir/GeneratedFileName
+func WriteRegistration(dir string) (string, error) {
+	src, err := GenerateRegistration(dir)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, GeneratedFileName)
+	return path, os.WriteFile(path, src, 0o644)
+}
+
+// protoMessages the types with the ProtoReflect method generated by protoc-gen-go
+func protoMessages(f *ast.File) []string {
+	messages := []string{}
+
+	for _, decl := range f.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Name.Name != "ProtoReflect" || fn.Recv == nil || len(fn.Recv.List) != 1 {
+			continue
+		}
+
+		star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+		if !ok {
+			continue
+		}
+
+		if ident, ok := star.X.(*ast.Ident); ok && !strings.HasPrefix(ident.Name, "_") {
+			messages = append(messages, ident.Name)
+		}
+	}
+
+	return messages
+}
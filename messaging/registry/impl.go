@@ -0,0 +1,97 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Default the registry used by the package functions, the generated registration files register the messages here
+var Default = New(false)
+
+// New create a registry, in the strict mode only the registered messages are resolved,
+// otherwise the messages linked in the binary are found by the protobuf global registry
+func New(strict bool) IRegistry {
+	return &registry{types: map[string]protoreflect.MessageType{}, strict: strict}
+}
+
+// Register add the messages to the Default registry
+func Register(msgs ...protoreflect.ProtoMessage) {
+	Default.Register(msgs...)
+}
+
+// Name the fully-qualified name of the message
+func Name(msg protoreflect.ProtoMessage) string {
+	return string(proto.MessageName(msg))
+}
+
+func (r *registry) Register(msgs ...protoreflect.ProtoMessage) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, msg := range msgs {
+		mt := msg.ProtoReflect().Type()
+		r.types[string(mt.Descriptor().FullName())] = mt
+	}
+}
+
+func (r *registry) Lookup(name string) (protoreflect.MessageType, error) {
+	if name == "" {
+		return nil, ErrorMessageTypeRequired
+	}
+
+	r.mu.RLock()
+	mt, ok := r.types[name]
+	r.mu.RUnlock()
+
+	if ok {
+		return mt, nil
+	}
+
+	if !r.strict {
+		if mt, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(name)); err == nil {
+			return mt, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrorMessageNotRegistered, name)
+}
+
+func (r *registry) Marshal(msg protoreflect.ProtoMessage) (string, []byte, error) {
+	byt, err := proto.Marshal(msg)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return Name(msg), byt, nil
+}
+
+func (r *registry) Unmarshal(name string, data []byte) (protoreflect.ProtoMessage, error) {
+	mt, err := r.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := mt.New().Interface()
+	if err := proto.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+func (r *registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.types))
+	for name := range r.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
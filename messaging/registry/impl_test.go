@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type RegistryTestSuite struct {
+	suite.Suite
+}
+
+func TestRegistryTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistryTestSuite))
+}
+
+func (s *RegistryTestSuite) TestMarshalUnmarshal() {
+	r := New(false)
+
+	name, byt, err := r.Marshal(wrapperspb.String("value"))
+	s.NoError(err)
+	s.Equal("google.protobuf.StringValue", name)
+
+	msg, err := r.Unmarshal(name, byt)
+	s.NoError(err)
+	s.Equal("value", msg.(*wrapperspb.StringValue).GetValue())
+
+	_, err = r.Unmarshal("acme.Unknown", byt)
+	s.ErrorIs(err, ErrorMessageNotRegistered)
+
+	_, err = r.Lookup("")
+	s.ErrorIs(err, ErrorMessageTypeRequired)
+}
+
+func (s *RegistryTestSuite) TestStrict() {
+	r := New(true)
+
+	_, err := r.Lookup("google.protobuf.StringValue")
+	s.ErrorIs(err, ErrorMessageNotRegistered)
+
+	r.Register(&wrapperspb.StringValue{}, &wrapperspb.Int64Value{})
+
+	_, err = r.Lookup("google.protobuf.StringValue")
+	s.NoError(err)
+	s.Equal([]string{"google.protobuf.Int64Value", "google.protobuf.StringValue"}, r.Names())
+}
+
+func (s *RegistryTestSuite) TestGenerateRegistration() {
+	dir := s.T().TempDir()
+	pb := `package ordersv1
+
+type OrderCreated struct{}
+
+func (x *OrderCreated) ProtoReflect() protoreflect.Message { return nil }
+
+type OrderCanceled struct{}
+
+func (x *OrderCanceled) ProtoReflect() protoreflect.Message { return nil }
+`
+	s.NoError(os.WriteFile(filepath.Join(dir, "orders.pb.go"), []byte(pb), 0o644))
+
+	path, err := WriteRegistration(dir)
+	s.NoError(err)
+
+	src, _ := os.ReadFile(path)
+	s.Contains(string(src), "package ordersv1")
+	s.Contains(string(src), "registry.Register(\n\t\t&OrderCanceled{},\n\t\t&OrderCreated{},\n\t)")
+
+	_, err = GenerateRegistration(s.T().TempDir())
+	s.ErrorIs(err, ErrorNoProtoMessages)
+}
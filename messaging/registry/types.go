@@ -0,0 +1,30 @@
+package registry
+
+import (
+	"sync"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type (
+	// IRegistry the protobuf messages by fully-qualified name, e.g: acme.orders.v1.OrderCreated
+	IRegistry interface {
+		// Register add the messages, registering the same name again replaces the message
+		Register(msgs ...protoreflect.ProtoMessage)
+		// Lookup returns the message type, the registered messages take precedence over the protobuf global registry
+		Lookup(name string) (protoreflect.MessageType, error)
+		// Marshal returns the message fully-qualified name and the wire format
+		Marshal(msg protoreflect.ProtoMessage) (string, []byte, error)
+		// Unmarshal create a new message of the type and unmarshal the wire format
+		Unmarshal(name string, data []byte) (protoreflect.ProtoMessage, error)
+		// Names the registered messages
+		Names() []string
+	}
+
+	registry struct {
+		types map[string]protoreflect.MessageType
+		// strict only the registered messages are resolved, the global registry is not used
+		strict bool
+		mu     sync.RWMutex
+	}
+)
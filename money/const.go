@@ -0,0 +1,59 @@
+package money
+
+import "errors"
+
+const (
+	// HALF_EVEN the bankers rounding, the ties go to the even neighbor
+	HALF_EVEN RoundingMode = iota
+	// HALF_UP the ties go away from zero
+	HALF_UP
+	// HALF_DOWN the ties go toward zero
+	HALF_DOWN
+	// UP away from zero
+	UP
+	// DOWN toward zero, the truncation
+	DOWN
+	// CEILING toward the positive infinity
+	CEILING
+	// FLOOR toward the negative infinity
+	FLOOR
+)
+
+const (
+	BRL Currency = "BRL"
+	USD Currency = "USD"
+	EUR Currency = "EUR"
+	GBP Currency = "GBP"
+	JPY Currency = "JPY"
+
+	// DefaultExponent the minor units of the currencies that were not registered
+	DefaultExponent = 2
+)
+
+var (
+	ErrorCurrencyMismatch = errors.New("money the amounts have different currencies")
+	ErrorInvalidAmount    = errors.New("money invalid decimal amount")
+	ErrorPrecision        = errors.New("money the amount has more decimal places than the currency minor unit")
+	ErrorOverflow         = errors.New("money the amount overflows the int64 minor units")
+	ErrorInvalidCurrency  = errors.New("money invalid ISO 4217 currency code")
+	ErrorInvalidRatios    = errors.New("money the ratios must be positive")
+	ErrorScan             = errors.New("money unsupported scan source")
+)
+
+// exponents the minor units of the ISO 4217 currencies that differ from the DefaultExponent or are commonly used
+var exponents = map[Currency]int{
+	BRL:   2,
+	USD:   2,
+	EUR:   2,
+	GBP:   2,
+	JPY:   0,
+	"KRW": 0,
+	"CLP": 0,
+	"PYG": 0,
+	"VND": 0,
+	"BHD": 3,
+	"KWD": 3,
+	"JOD": 3,
+	"OMR": 3,
+	"TND": 3,
+}
@@ -0,0 +1,392 @@
+package money
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var registry = &currencyRegistry{exponents: exponents}
+
+// RegisterCurrency configure the minor units of the currency, e.g: RegisterCurrency("BTC", 8)
+func RegisterCurrency(code Currency, exponent int) error {
+	if !code.valid() || exponent < 0 || exponent > 18 {
+		return ErrorInvalidCurrency
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.exponents[code] = exponent
+
+	return nil
+}
+
+// Exponent the currency minor units, the currencies that were not registered use the DefaultExponent
+func (c Currency) Exponent() int {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+
+	if e, ok := registry.exponents[c]; ok {
+		return e
+	}
+
+	return DefaultExponent
+}
+
+func (c Currency) valid() bool {
+	if len(c) != 3 {
+		return false
+	}
+
+	for _, r := range c {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// New create the money with the amount in the minor units, e.g: New(1050, BRL) is R$ 10.50
+func New(amount int64, currency Currency) Money {
+	return Money{amount, currency}
+}
+
+// Zero the zero amount in the currency
+func Zero(currency Currency) Money {
+	return Money{0, currency}
+}
+
+// Parse the decimal amount, e.g: Parse("10.50", BRL), returns ErrorPrecision when the amount has more decimal places than the currency
+func Parse(amount string, currency Currency) (Money, error) {
+	r, err := parseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	r.Mul(r, new(big.Rat).SetInt(scale(currency)))
+	if !r.IsInt() {
+		return Money{}, ErrorPrecision
+	}
+
+	return fromInt(r.Num(), currency)
+}
+
+// ParseRound the decimal amount rounded to the currency minor units, e.g: ParseRound("10.505", BRL, HALF_EVEN) is R$ 10.50
+func ParseRound(amount string, currency Currency, mode RoundingMode) (Money, error) {
+	r, err := parseDecimal(amount)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return FromRat(r, currency, mode)
+}
+
+// FromRat the exact amount in the major units rounded to the currency minor units
+func FromRat(amount *big.Rat, currency Currency, mode RoundingMode) (Money, error) {
+	r := new(big.Rat).Mul(amount, new(big.Rat).SetInt(scale(currency)))
+	return fromInt(round(r.Num(), r.Denom(), mode), currency)
+}
+
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+func (m Money) Currency() Currency {
+	return m.currency
+}
+
+// Rat the exact amount in the major units
+func (m Money) Rat() *big.Rat {
+	return new(big.Rat).SetFrac(big.NewInt(m.amount), scale(m.currency))
+}
+
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+func (m Money) SameCurrency(o Money) bool {
+	return m.currency == o.currency
+}
+
+// Compare returns -1, 0 or +1, ErrorCurrencyMismatch when the currencies differ
+func (m Money) Compare(o Money) (int, error) {
+	if !m.SameCurrency(o) {
+		return 0, ErrorCurrencyMismatch
+	}
+
+	switch {
+	case m.amount < o.amount:
+		return -1, nil
+	case m.amount > o.amount:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+func (m Money) Equal(o Money) bool {
+	return m == o
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, ErrorCurrencyMismatch
+	}
+
+	return fromInt(new(big.Int).Add(big.NewInt(m.amount), big.NewInt(o.amount)), m.currency)
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if !m.SameCurrency(o) {
+		return Money{}, ErrorCurrencyMismatch
+	}
+
+	return fromInt(new(big.Int).Sub(big.NewInt(m.amount), big.NewInt(o.amount)), m.currency)
+}
+
+func (m Money) Neg() Money {
+	return Money{-m.amount, m.currency}
+}
+
+func (m Money) Abs() Money {
+	if m.amount < 0 {
+		return m.Neg()
+	}
+
+	return m
+}
+
+// Mul multiply by the decimal factor rounded to the currency minor units, e.g: the 1.5% fee is Mul("0.015", HALF_EVEN)
+func (m Money) Mul(factor string, mode RoundingMode) (Money, error) {
+	r, err := parseDecimal(factor)
+	if err != nil {
+		return Money{}, err
+	}
+
+	return m.MulRat(r, mode)
+}
+
+func (m Money) MulRat(factor *big.Rat, mode RoundingMode) (Money, error) {
+	r := new(big.Rat).Mul(new(big.Rat).SetInt64(m.amount), factor)
+	return fromInt(round(r.Num(), r.Denom(), mode), m.currency)
+}
+
+// Div divide by the divisor rounded to the currency minor units, to split the amount without losing the remainder use Split
+func (m Money) Div(divisor int64, mode RoundingMode) (Money, error) {
+	if divisor == 0 {
+		return Money{}, ErrorInvalidAmount
+	}
+
+	r := new(big.Rat).SetFrac(big.NewInt(m.amount), big.NewInt(divisor))
+	return fromInt(round(r.Num(), r.Denom(), mode), m.currency)
+}
+
+// Split the amount in n parts, the remainder minor units are given one by one to the first parts, e.g: 100 / 3 is 34, 33, 33
+func (m Money) Split(n int) ([]Money, error) {
+	if n <= 0 {
+		return nil, ErrorInvalidRatios
+	}
+
+	ratios := make([]int, n)
+	for i := range ratios {
+		ratios[i] = 1
+	}
+
+	return m.Allocate(ratios...)
+}
+
+// Allocate the amount in proportion to the ratios, the sum of the parts is always the amount, e.g: Allocate(70, 30)
+func (m Money) Allocate(ratios ...int) ([]Money, error) {
+	total := int64(0)
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, ErrorInvalidRatios
+		}
+
+		total += int64(r)
+	}
+
+	if total == 0 {
+		return nil, ErrorInvalidRatios
+	}
+
+	parts := make([]Money, len(ratios))
+	remainder := m.amount
+
+	for i, r := range ratios {
+		share := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(int64(r)))
+		share.Quo(share, big.NewInt(total))
+
+		parts[i] = Money{share.Int64(), m.currency}
+		remainder -= parts[i].amount
+	}
+
+	unit := int64(1)
+	if remainder < 0 {
+		unit = -1
+	}
+
+	for i := 0; remainder != 0; i = (i + 1) % len(parts) {
+		if ratios[i] == 0 {
+			continue
+		}
+
+		parts[i].amount += unit
+		remainder -= unit
+	}
+
+	return parts, nil
+}
+
+// Decimal the amount in the major units with the currency minor units, e.g: 10.50
+func (m Money) Decimal() string {
+	return m.Rat().FloatString(m.currency.Exponent())
+}
+
+// String e.g: 10.50 BRL
+func (m Money) String() string {
+	return fmt.Sprintf("%s %s", m.Decimal(), m.currency)
+}
+
+// MarshalJSON the amount is a decimal string, so the clients never parse it as a float
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(jsonMoney{m.Decimal(), m.currency})
+}
+
+// UnmarshalJSON accepts the amount as a decimal string or a JSON number
+func (m *Money) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency Currency        `json:"currency"`
+	}{}
+
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if !raw.Currency.valid() {
+		return ErrorInvalidCurrency
+	}
+
+	amount := strings.Trim(string(raw.Amount), `"`)
+
+	parsed, err := Parse(amount, raw.Currency)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+
+	return nil
+}
+
+// Value the text "10.50 BRL"
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
+
+// Scan the text "10.50 BRL" written by Value
+func (m *Money) Scan(src any) error {
+	var text string
+
+	switch v := src.(type) {
+	case string:
+		text = v
+	case []byte:
+		text = string(v)
+	case nil:
+		*m = Money{}
+		return nil
+	default:
+		return ErrorScan
+	}
+
+	parts := strings.Fields(text)
+	if len(parts) != 2 || !Currency(parts[1]).valid() {
+		return ErrorInvalidAmount
+	}
+
+	parsed, err := Parse(parts[0], Currency(parts[1]))
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+
+	return nil
+}
+
+func parseDecimal(s string) (*big.Rat, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.ContainsAny(s, "/eE") {
+		return nil, ErrorInvalidAmount
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	if !ok {
+		return nil, ErrorInvalidAmount
+	}
+
+	return r, nil
+}
+
+func scale(currency Currency) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(currency.Exponent())), nil)
+}
+
+func fromInt(amount *big.Int, currency Currency) (Money, error) {
+	if !amount.IsInt64() {
+		return Money{}, ErrorOverflow
+	}
+
+	return Money{amount.Int64(), currency}, nil
+}
+
+// round the num / den to an integer, the den is always positive
+func round(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	sign := big.NewInt(int64(num.Sign()))
+	twice := new(big.Int).Abs(r)
+	half := twice.Lsh(twice, 1).Cmp(den)
+
+	away := false
+
+	switch mode {
+	case UP:
+		away = true
+	case DOWN:
+		away = false
+	case CEILING:
+		away = num.Sign() > 0
+	case FLOOR:
+		away = num.Sign() < 0
+	case HALF_UP:
+		away = half >= 0
+	case HALF_DOWN:
+		away = half > 0
+	default:
+		away = half > 0 || (half == 0 && q.Bit(0) == 1)
+	}
+
+	if away {
+		q.Add(q, sign)
+	}
+
+	return q
+}
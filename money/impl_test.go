@@ -0,0 +1,178 @@
+package money
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MoneyTestSuite struct {
+	suite.Suite
+}
+
+func TestMoneyTestSuite(t *testing.T) {
+	suite.Run(t, new(MoneyTestSuite))
+}
+
+func (s *MoneyTestSuite) TestParse() {
+	m, err := Parse("10.5", BRL)
+	s.NoError(err)
+	s.Equal(int64(1050), m.Amount())
+	s.Equal("10.50 BRL", m.String())
+
+	m, err = Parse("-1234", JPY)
+	s.NoError(err)
+	s.Equal(int64(-1234), m.Amount())
+	s.Equal("-1234", m.Decimal())
+
+	_, err = Parse("10.505", BRL)
+	s.ErrorIs(err, ErrorPrecision)
+
+	for _, invalid := range []string{"", "abc", "1/3", "1e3"} {
+		_, err = Parse(invalid, BRL)
+		s.ErrorIs(err, ErrorInvalidAmount)
+	}
+
+	_, err = Parse("92233720368547758.08", USD)
+	s.ErrorIs(err, ErrorOverflow)
+
+	m, err = ParseRound("10.505", BRL, HALF_EVEN)
+	s.NoError(err)
+	s.Equal(int64(1050), m.Amount())
+}
+
+func (s *MoneyTestSuite) TestRegisterCurrency() {
+	s.ErrorIs(RegisterCurrency("btc", 8), ErrorInvalidCurrency)
+	s.NoError(RegisterCurrency("XBT", 8))
+
+	m, err := Parse("0.00000001", "XBT")
+	s.NoError(err)
+	s.Equal(int64(1), m.Amount())
+	s.Equal(DefaultExponent, Currency("XYZ").Exponent())
+}
+
+func (s *MoneyTestSuite) TestRounding() {
+	cases := []struct {
+		amount string
+		mode   RoundingMode
+		expect int64
+	}{
+		{"0.125", HALF_EVEN, 12},
+		{"0.135", HALF_EVEN, 14},
+		{"-0.125", HALF_EVEN, -12},
+		{"0.125", HALF_UP, 13},
+		{"-0.125", HALF_UP, -13},
+		{"0.125", HALF_DOWN, 12},
+		{"0.126", HALF_DOWN, 13},
+		{"0.121", UP, 13},
+		{"-0.121", UP, -13},
+		{"0.129", DOWN, 12},
+		{"-0.121", CEILING, -12},
+		{"0.121", CEILING, 13},
+		{"-0.121", FLOOR, -13},
+		{"0.129", FLOOR, 12},
+	}
+
+	for _, c := range cases {
+		m, err := ParseRound(c.amount, BRL, c.mode)
+		s.NoError(err)
+		s.Equal(c.expect, m.Amount(), c.amount)
+	}
+}
+
+func (s *MoneyTestSuite) TestArithmetic() {
+	a, b := New(1050, BRL), New(250, BRL)
+
+	sum, err := a.Add(b)
+	s.NoError(err)
+	s.Equal(New(1300, BRL), sum)
+
+	diff, err := b.Sub(a)
+	s.NoError(err)
+	s.Equal(New(-800, BRL), diff)
+	s.True(diff.IsNegative())
+	s.Equal(New(800, BRL), diff.Abs())
+
+	_, err = a.Add(New(1, USD))
+	s.ErrorIs(err, ErrorCurrencyMismatch)
+
+	_, err = New(math.MaxInt64, BRL).Add(New(1, BRL))
+	s.ErrorIs(err, ErrorOverflow)
+
+	c, err := a.Compare(b)
+	s.NoError(err)
+	s.Equal(1, c)
+
+	_, err = a.Compare(New(1050, USD))
+	s.ErrorIs(err, ErrorCurrencyMismatch)
+
+	fee, err := New(10000, BRL).Mul("0.015", HALF_EVEN)
+	s.NoError(err)
+	s.Equal(int64(150), fee.Amount())
+
+	fee, err = New(1050, BRL).MulRat(big.NewRat(1, 3), HALF_UP)
+	s.NoError(err)
+	s.Equal(int64(350), fee.Amount())
+
+	half, err := New(101, BRL).Div(2, HALF_EVEN)
+	s.NoError(err)
+	s.Equal(int64(50), half.Amount())
+
+	_, err = a.Div(0, HALF_EVEN)
+	s.ErrorIs(err, ErrorInvalidAmount)
+}
+
+func (s *MoneyTestSuite) TestAllocate() {
+	parts, err := New(100, BRL).Split(3)
+	s.NoError(err)
+	s.Equal([]Money{New(34, BRL), New(33, BRL), New(33, BRL)}, parts)
+
+	parts, err = New(-100, BRL).Split(3)
+	s.NoError(err)
+	s.Equal([]Money{New(-34, BRL), New(-33, BRL), New(-33, BRL)}, parts)
+
+	parts, err = New(1001, BRL).Allocate(0, 70, 30)
+	s.NoError(err)
+	s.Equal([]Money{New(0, BRL), New(701, BRL), New(300, BRL)}, parts)
+
+	_, err = New(100, BRL).Allocate(0, 0)
+	s.ErrorIs(err, ErrorInvalidRatios)
+
+	_, err = New(100, BRL).Split(0)
+	s.ErrorIs(err, ErrorInvalidRatios)
+}
+
+func (s *MoneyTestSuite) TestJSON() {
+	data, err := json.Marshal(New(1050, BRL))
+	s.NoError(err)
+	s.JSONEq(`{"amount":"10.50","currency":"BRL"}`, string(data))
+
+	m := Money{}
+	s.NoError(json.Unmarshal([]byte(`{"amount":"10.50","currency":"BRL"}`), &m))
+	s.Equal(New(1050, BRL), m)
+
+	s.NoError(json.Unmarshal([]byte(`{"amount":7,"currency":"JPY"}`), &m))
+	s.Equal(New(7, JPY), m)
+
+	s.ErrorIs(json.Unmarshal([]byte(`{"amount":"1.001","currency":"BRL"}`), &m), ErrorPrecision)
+	s.ErrorIs(json.Unmarshal([]byte(`{"amount":"1","currency":"real"}`), &m), ErrorInvalidCurrency)
+}
+
+func (s *MoneyTestSuite) TestSQL() {
+	v, err := New(-1050, BRL).Value()
+	s.NoError(err)
+	s.Equal("-10.50 BRL", v)
+
+	m := Money{}
+	s.NoError(m.Scan([]byte("-10.50 BRL")))
+	s.Equal(New(-1050, BRL), m)
+
+	s.NoError(m.Scan(nil))
+	s.Equal(Money{}, m)
+
+	s.ErrorIs(m.Scan("10.50"), ErrorInvalidAmount)
+	s.ErrorIs(m.Scan(10.5), ErrorScan)
+}
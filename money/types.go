@@ -0,0 +1,29 @@
+package money
+
+import "sync"
+
+type (
+	// Currency the ISO 4217 currency code, e.g: BRL
+	Currency string
+
+	RoundingMode int8
+
+	// Money the amount in the currency minor units, e.g: 1050 BRL is R$ 10.50, so the arithmetic never uses floats
+	//
+	// The zero value is zero without currency. In the JSON the amount is a decimal string: {"amount":"10.50","currency":"BRL"}.
+	// In the SQL it is the text "10.50 BRL", to numeric columns use the Amount and the Currency in two columns
+	Money struct {
+		amount   int64
+		currency Currency
+	}
+
+	currencyRegistry struct {
+		mu        sync.RWMutex
+		exponents map[Currency]int
+	}
+
+	jsonMoney struct {
+		Amount   string   `json:"amount"`
+		Currency Currency `json:"currency"`
+	}
+)
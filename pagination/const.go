@@ -0,0 +1,23 @@
+package pagination
+
+import "errors"
+
+const (
+	ASC_DIRECTION  Direction = "ASC"
+	DESC_DIRECTION Direction = "DESC"
+
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+
+	PageQueryParam     = "page"
+	PageSizeQueryParam = "page_size"
+	CursorQueryParam   = "cursor"
+	LimitQueryParam    = "limit"
+)
+
+var (
+	ErrorInvalidCursor  = errors.New("invalid pagination cursor")
+	ErrorInvalidColumn  = errors.New("invalid keyset column name")
+	ErrorKeysetColumns  = errors.New("keyset requires at least one column")
+	ErrorCursorMismatch = errors.New("cursor values does not match the keyset columns")
+)
@@ -0,0 +1,5 @@
+module github.com/ralvescosta/gokit/pagination
+
+go 1.18
+
+require github.com/stretchr/testify v1.8.0
@@ -0,0 +1,180 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var identifierRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)?$`)
+
+// EncodeCursor encode the typed cursor as url safe base64 JSON
+func EncodeCursor[C any](cursor C) (string, error) {
+	byt, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(byt), nil
+}
+
+// DecodeCursor decode a cursor created by EncodeCursor, an empty string returns the zero value
+func DecodeCursor[C any](encoded string) (C, error) {
+	var cursor C
+	if encoded == "" {
+		return cursor, nil
+	}
+
+	byt, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return cursor, ErrorInvalidCursor
+	}
+
+	if err := json.Unmarshal(byt, &cursor); err != nil {
+		return cursor, ErrorInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// ParseOffset read the page and page_size query params, invalid values use the defaults
+func ParseOffset(r *http.Request) *Offset {
+	query := r.URL.Query()
+
+	page, _ := strconv.Atoi(query.Get(PageQueryParam))
+	size, _ := strconv.Atoi(query.Get(PageSizeQueryParam))
+
+	return NewOffset(page, size)
+}
+
+// NewOffset normalize the page to >= 1 and the size to the range 1..MaxPageSize
+func NewOffset(page, size int) *Offset {
+	if page < 1 {
+		page = 1
+	}
+
+	return &Offset{Page: page, PageSize: normalizeLimit(size)}
+}
+
+// SQL the LIMIT and OFFSET clause using postgres placeholders starting in argIndex
+func (o *Offset) SQL(argIndex int) (string, []any) {
+	return fmt.Sprintf("LIMIT $%d OFFSET $%d", argIndex, argIndex+1), []any{o.PageSize, (o.Page - 1) * o.PageSize}
+}
+
+// PageInfo build the metadata with the total of rows, usually from a COUNT(*) query
+func (o *Offset) PageInfo(total int64) *PageInfo {
+	totalPages := int((total + int64(o.PageSize) - 1) / int64(o.PageSize))
+
+	return &PageInfo{
+		Page:       o.Page,
+		PageSize:   o.PageSize,
+		Total:      total,
+		TotalPages: totalPages,
+		HasMore:    o.Page < totalPages,
+	}
+}
+
+// ParseLimit read the cursor and limit query params
+func ParseLimit(r *http.Request) (cursor string, limit int) {
+	query := r.URL.Query()
+	limit, _ = strconv.Atoi(query.Get(LimitQueryParam))
+
+	return query.Get(CursorQueryParam), normalizeLimit(limit)
+}
+
+// NewKeyset validate the columns, they are written in the query and can not be user input
+func NewKeyset(direction Direction, limit int, columns ...string) (*Keyset, error) {
+	if len(columns) == 0 {
+		return nil, ErrorKeysetColumns
+	}
+
+	for _, column := range columns {
+		if !identifierRegex.MatchString(column) {
+			return nil, fmt.Errorf("%w: %s", ErrorInvalidColumn, column)
+		}
+	}
+
+	if direction != DESC_DIRECTION {
+		direction = ASC_DIRECTION
+	}
+
+	return &Keyset{Columns: columns, Direction: direction, Limit: normalizeLimit(limit)}, nil
+}
+
+// Condition the row value comparison after the cursor values, e.g: (created_at, id) > ($1, $2).
+// Returns an empty condition to the first page (no values)
+func (k *Keyset) Condition(values []any, argIndex int) (string, []any, error) {
+	if len(values) == 0 {
+		return "", nil, nil
+	}
+
+	if len(values) != len(k.Columns) {
+		return "", nil, ErrorCursorMismatch
+	}
+
+	placeholders := make([]string, len(values))
+	for i := range values {
+		placeholders[i] = fmt.Sprintf("$%d", argIndex+i)
+	}
+
+	operator := ">"
+	if k.Direction == DESC_DIRECTION {
+		operator = "<"
+	}
+
+	return fmt.Sprintf("(%s) %s (%s)", strings.Join(k.Columns, ", "), operator, strings.Join(placeholders, ", ")), values, nil
+}
+
+// OrderBy the ORDER BY clause, all the columns use the same direction
+func (k *Keyset) OrderBy() string {
+	columns := make([]string, len(k.Columns))
+	for i, column := range k.Columns {
+		columns[i] = column + " " + string(k.Direction)
+	}
+
+	return "ORDER BY " + strings.Join(columns, ", ")
+}
+
+// LimitSQL fetch one extra row, used by NewCursorPage to know if there is a next page
+func (k *Keyset) LimitSQL(argIndex int) (string, []any) {
+	return fmt.Sprintf("LIMIT $%d", argIndex), []any{k.Limit + 1}
+}
+
+// NewCursorPage build the envelope from the rows fetched with LimitSQL, cursorOf returns the cursor of a row
+func NewCursorPage[T any, C any](rows []T, limit int, cursorOf func(T) C) (*Page[T], error) {
+	info := &PageInfo{PageSize: limit}
+
+	if len(rows) > limit {
+		rows = rows[:limit]
+		info.HasMore = true
+
+		next, err := EncodeCursor(cursorOf(rows[len(rows)-1]))
+		if err != nil {
+			return nil, err
+		}
+		info.NextCursor = next
+	}
+
+	return &Page[T]{Data: rows, Pagination: info}, nil
+}
+
+// NewOffsetPage build the envelope to the offset pagination
+func NewOffsetPage[T any](rows []T, offset *Offset, total int64) *Page[T] {
+	return &Page[T]{Data: rows, Pagination: offset.PageInfo(total)}
+}
+
+func normalizeLimit(limit int) int {
+	if limit < 1 {
+		return DefaultPageSize
+	}
+
+	if limit > MaxPageSize {
+		return MaxPageSize
+	}
+
+	return limit
+}
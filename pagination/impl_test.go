@@ -0,0 +1,102 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PaginationTestSuite struct {
+	suite.Suite
+}
+
+func TestPaginationTestSuite(t *testing.T) {
+	suite.Run(t, new(PaginationTestSuite))
+}
+
+type orderCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        int       `json:"i"`
+}
+
+func (s *PaginationTestSuite) TestCursorEncoding() {
+	cursor := orderCursor{CreatedAt: time.Date(2022, 7, 21, 0, 0, 0, 0, time.UTC), ID: 10}
+
+	encoded, err := EncodeCursor(cursor)
+	s.NoError(err)
+	s.NotContains(encoded, "=")
+
+	decoded, err := DecodeCursor[orderCursor](encoded)
+	s.NoError(err)
+	s.Equal(cursor, decoded)
+
+	_, err = DecodeCursor[orderCursor]("!invalid")
+	s.ErrorIs(err, ErrorInvalidCursor)
+
+	empty, err := DecodeCursor[orderCursor]("")
+	s.NoError(err)
+	s.Zero(empty)
+}
+
+func (s *PaginationTestSuite) TestOffset() {
+	offset := ParseOffset(httptest.NewRequest(http.MethodGet, "/?page=3&page_size=1000", nil))
+	s.Equal(3, offset.Page)
+	s.Equal(MaxPageSize, offset.PageSize)
+
+	offset = NewOffset(2, 10)
+	query, args := offset.SQL(3)
+	s.Equal("LIMIT $3 OFFSET $4", query)
+	s.Equal([]any{10, 10}, args)
+
+	page := NewOffsetPage([]int{1, 2}, offset, 21)
+	s.Equal(3, page.Pagination.TotalPages)
+	s.True(page.Pagination.HasMore)
+}
+
+func (s *PaginationTestSuite) TestKeyset() {
+	keyset, err := NewKeyset(DESC_DIRECTION, 2, "created_at", "o.id")
+	s.NoError(err)
+
+	condition, args, err := keyset.Condition(nil, 1)
+	s.NoError(err)
+	s.Empty(condition)
+	s.Nil(args)
+
+	condition, args, err = keyset.Condition([]any{"2022-07-21", 10}, 2)
+	s.NoError(err)
+	s.Equal("(created_at, o.id) < ($2, $3)", condition)
+	s.Len(args, 2)
+
+	s.Equal("ORDER BY created_at DESC, o.id DESC", keyset.OrderBy())
+
+	limit, args := keyset.LimitSQL(4)
+	s.Equal("LIMIT $4", limit)
+	s.Equal([]any{3}, args)
+
+	_, _, err = keyset.Condition([]any{1}, 1)
+	s.ErrorIs(err, ErrorCursorMismatch)
+
+	_, err = NewKeyset(ASC_DIRECTION, 10, "id; DROP TABLE users")
+	s.ErrorIs(err, ErrorInvalidColumn)
+}
+
+func (s *PaginationTestSuite) TestCursorPage() {
+	cursorOf := func(id int) orderCursor { return orderCursor{ID: id} }
+
+	page, err := NewCursorPage([]int{1, 2, 3}, 2, cursorOf)
+	s.NoError(err)
+	s.Equal([]int{1, 2}, page.Data)
+	s.True(page.Pagination.HasMore)
+
+	next, err := DecodeCursor[orderCursor](page.Pagination.NextCursor)
+	s.NoError(err)
+	s.Equal(2, next.ID)
+
+	page, err = NewCursorPage([]int{1}, 2, cursorOf)
+	s.NoError(err)
+	s.False(page.Pagination.HasMore)
+	s.Empty(page.Pagination.NextCursor)
+}
@@ -0,0 +1,34 @@
+package pagination
+
+type (
+	Direction string
+
+	// Offset page based pagination, Page starts in 1
+	Offset struct {
+		Page     int
+		PageSize int
+	}
+
+	// Keyset cursor pagination over unique ordered columns, e.g: created_at, id
+	Keyset struct {
+		Columns   []string
+		Direction Direction
+		Limit     int
+	}
+
+	// PageInfo the pagination metadata of the response envelope
+	PageInfo struct {
+		Page       int    `json:"page,omitempty"`
+		PageSize   int    `json:"page_size,omitempty"`
+		Total      int64  `json:"total,omitempty"`
+		TotalPages int    `json:"total_pages,omitempty"`
+		NextCursor string `json:"next_cursor,omitempty"`
+		HasMore    bool   `json:"has_more"`
+	}
+
+	// Page the standard response envelope
+	Page[T any] struct {
+		Data       []T       `json:"data"`
+		Pagination *PageInfo `json:"pagination"`
+	}
+)
@@ -0,0 +1,29 @@
+package pool
+
+const (
+	MeterName = "github.com/ralvescosta/gokit/pool"
+
+	// WorkersMetric gauge of the max concurrent tasks, labeled by pool name and kind
+	WorkersMetric = "pool.workers"
+	// BusyWorkersMetric gauge of the workers running a task
+	BusyWorkersMetric = "pool.workers.busy"
+	// UtilizationMetric gauge of the busy workers ratio, from 0 to 1, not reported by the unbounded pools
+	UtilizationMetric = "pool.utilization"
+	// QueueSizeMetric gauge of the tasks waiting a worker
+	QueueSizeMetric = "pool.queue.size"
+	// QueueCapacityMetric gauge of the buffer capacity, not reported by the unbounded buffers
+	QueueCapacityMetric = "pool.queue.capacity"
+	// WaitDurationMetric histogram of the seconds between the task submission and a worker start it
+	WaitDurationMetric = "pool.task.wait.duration"
+	// TaskDurationMetric histogram of the seconds the workers spent in the task
+	TaskDurationMetric = "pool.task.duration"
+	// RejectedMetric counter of the tasks refused because the pool was saturated, e.g: the buffer was full
+	RejectedMetric = "pool.tasks.rejected"
+
+	NameAttribute = "pool.name"
+	KindAttribute = "pool.kind"
+
+	MESSAGING_KIND = "messaging"
+	WORKER_KIND    = "worker"
+	SCHEDULER_KIND = "scheduler"
+)
@@ -0,0 +1,211 @@
+package pool
+
+import (
+	"context"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var pools = &registry{pools: map[*Pool]struct{}{}, meters: map[metric.MeterProvider]struct{}{}}
+
+// New create and register the pool, it is observed until Close is called
+func New(name string, opts *Opts) *Pool {
+	if opts == nil {
+		opts = &Opts{}
+	}
+
+	now := opts.Now
+	if now == nil {
+		now = time.Now
+	}
+
+	p := &Pool{
+		name:     name,
+		kind:     opts.Kind,
+		workers:  int64(opts.Workers),
+		capacity: int64(opts.Capacity),
+		now:      now,
+		attrs:    metric.WithAttributes(attribute.String(NameAttribute, name), attribute.String(KindAttribute, opts.Kind)),
+	}
+
+	pools.add(p)
+	return p
+}
+
+// Enqueue the task was buffered waiting a worker, the ticket must be given to Start
+func (p *Pool) Enqueue() Ticket {
+	atomic.AddInt64(&p.queued, 1)
+	return Ticket{submittedAt: p.now(), queued: true}
+}
+
+// TicketAt the task was submitted outside the pool, e.g: the enqueue time of a broker message, the zero time does not
+// record the wait
+func TicketAt(submittedAt time.Time) Ticket {
+	return Ticket{submittedAt: submittedAt}
+}
+
+// Start a worker picked the task, the wait since the ticket submission is recorded and the returned func must be called
+// when the task is finished
+func (p *Pool) Start(ticket Ticket) (done func()) {
+	if ticket.queued {
+		atomic.AddInt64(&p.queued, -1)
+	}
+
+	start := p.now()
+	if !ticket.submittedAt.IsZero() {
+		p.record(WaitDurationMetric, "the time the tasks waited a worker", start.Sub(ticket.submittedAt))
+	}
+
+	atomic.AddInt64(&p.busy, 1)
+
+	return func() {
+		atomic.AddInt64(&p.busy, -1)
+		p.record(TaskDurationMetric, "the time the workers spent in the tasks", p.now().Sub(start))
+	}
+}
+
+// Reject the task was refused because the pool was saturated, e.g: the buffer was full or the previous run was running,
+// the ticket is not started
+func (p *Pool) Reject(ticket Ticket) {
+	if ticket.queued {
+		atomic.AddInt64(&p.queued, -1)
+	}
+
+	atomic.AddInt64(&p.rejected, 1)
+
+	counter, err := otel.Meter(MeterName).Int64Counter(RejectedMetric, metric.WithDescription("tasks refused by the saturated pools"))
+	if err != nil {
+		return
+	}
+
+	counter.Add(context.Background(), 1, p.attrs)
+}
+
+func (p *Pool) Stats() Stats {
+	return Stats{
+		Name:     p.name,
+		Kind:     p.kind,
+		Workers:  p.workers,
+		Busy:     atomic.LoadInt64(&p.busy),
+		Capacity: p.capacity,
+		Queued:   atomic.LoadInt64(&p.queued),
+		Rejected: atomic.LoadInt64(&p.rejected),
+	}
+}
+
+// Close stop observing the pool
+func (p *Pool) Close() {
+	pools.remove(p)
+}
+
+// All the stats of the open pools sorted by kind and name, e.g: to expose in a debug endpoint
+func All() []Stats {
+	pools.mu.Lock()
+	defer pools.mu.Unlock()
+
+	stats := make([]Stats, 0, len(pools.pools))
+	for p := range pools.pools {
+		stats = append(stats, p.Stats())
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Kind != stats[j].Kind {
+			return stats[i].Kind < stats[j].Kind
+		}
+		return stats[i].Name < stats[j].Name
+	})
+
+	return stats
+}
+
+// record the histogram is created with the global meter provider on each call, so the provider configured after the
+// pool creation is used
+func (p *Pool) record(name, description string, d time.Duration) {
+	histogram, err := otel.Meter(MeterName).Float64Histogram(name, metric.WithDescription(description), metric.WithUnit("s"))
+	if err != nil {
+		return
+	}
+
+	histogram.Record(context.Background(), d.Seconds(), p.attrs)
+}
+
+// add the gauges are registered once per meter provider with instrument callbacks, since they are delegated when the
+// provider is configured after the pool creation
+func (r *registry) add(p *Pool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.pools[p] = struct{}{}
+
+	provider := otel.GetMeterProvider()
+	if _, ok := r.meters[provider]; ok {
+		return
+	}
+
+	if err := r.registerGauges(provider.Meter(MeterName)); err == nil {
+		r.meters[provider] = struct{}{}
+	}
+}
+
+func (r *registry) remove(p *Pool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.pools, p)
+}
+
+func (r *registry) registerGauges(meter metric.Meter) error {
+	gauges := []struct {
+		name        string
+		description string
+		value       func(s *Stats) (int64, bool)
+	}{
+		{WorkersMetric, "the max concurrent tasks of the pools", func(s *Stats) (int64, bool) { return s.Workers, s.Workers > 0 }},
+		{BusyWorkersMetric, "the workers running a task", func(s *Stats) (int64, bool) { return s.Busy, true }},
+		{QueueSizeMetric, "the tasks waiting a worker", func(s *Stats) (int64, bool) { return s.Queued, true }},
+		{QueueCapacityMetric, "the buffer capacity of the pools", func(s *Stats) (int64, bool) { return s.Capacity, s.Capacity > 0 }},
+	}
+
+	for _, g := range gauges {
+		value := g.value
+		_, err := meter.Int64ObservableGauge(g.name, metric.WithDescription(g.description),
+			metric.WithInt64Callback(func(ctx context.Context, o metric.Int64Observer) error {
+				r.observe(func(p *Pool, s *Stats) {
+					if v, ok := value(s); ok {
+						o.Observe(v, p.attrs)
+					}
+				})
+				return nil
+			}))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := meter.Float64ObservableGauge(UtilizationMetric, metric.WithDescription("the busy workers ratio of the bounded pools"),
+		metric.WithFloat64Callback(func(ctx context.Context, o metric.Float64Observer) error {
+			r.observe(func(p *Pool, s *Stats) {
+				if s.Workers > 0 {
+					o.Observe(float64(s.Busy)/float64(s.Workers), p.attrs)
+				}
+			})
+			return nil
+		}))
+
+	return err
+}
+
+func (r *registry) observe(fn func(p *Pool, s *Stats)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for p := range r.pools {
+		s := p.Stats()
+		fn(p, &s)
+	}
+}
@@ -0,0 +1,149 @@
+package pool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+type PoolTestSuite struct {
+	suite.Suite
+
+	reader *sdkMetric.ManualReader
+	now    time.Time
+}
+
+func TestPoolTestSuite(t *testing.T) {
+	suite.Run(t, new(PoolTestSuite))
+}
+
+func (s *PoolTestSuite) SetupTest() {
+	s.reader = sdkMetric.NewManualReader()
+	s.now = time.Date(2022, 7, 21, 10, 0, 0, 0, time.UTC)
+	otel.SetMeterProvider(sdkMetric.NewMeterProvider(sdkMetric.WithReader(s.reader)))
+}
+
+func (s *PoolTestSuite) TearDownTest() {
+	otel.SetMeterProvider(noop.NewMeterProvider())
+}
+
+func (s *PoolTestSuite) clock() time.Time {
+	return s.now
+}
+
+func (s *PoolTestSuite) TestSaturation() {
+	p := New("orders", &Opts{Kind: MESSAGING_KIND, Workers: 2, Capacity: 10, Now: s.clock})
+	defer p.Close()
+
+	first, second := p.Enqueue(), p.Enqueue()
+	s.Equal(Stats{Name: "orders", Kind: MESSAGING_KIND, Workers: 2, Capacity: 10, Queued: 2}, p.Stats())
+
+	s.now = s.now.Add(2 * time.Second)
+	done := p.Start(first)
+	s.Equal(int64(1), p.Stats().Busy)
+	s.Equal(int64(1), p.Stats().Queued)
+
+	s.Equal(int64(2), s.gauge(WorkersMetric))
+	s.Equal(int64(1), s.gauge(BusyWorkersMetric))
+	s.Equal(int64(1), s.gauge(QueueSizeMetric))
+	s.Equal(int64(10), s.gauge(QueueCapacityMetric))
+	s.Equal(0.5, s.utilization())
+
+	s.now = s.now.Add(time.Second)
+	done()
+	p.Start(second)()
+	p.Reject(p.Enqueue())
+
+	s.Equal(Stats{Name: "orders", Kind: MESSAGING_KIND, Workers: 2, Capacity: 10, Rejected: 1}, p.Stats())
+
+	wait := s.histogram(WaitDurationMetric)
+	s.Equal(uint64(2), wait.Count)
+	s.Equal(5.0, wait.Sum)
+	s.Equal(1.0, s.histogram(TaskDurationMetric).Sum)
+}
+
+func (s *PoolTestSuite) TestTicketAt() {
+	p := New("tasks", &Opts{Kind: WORKER_KIND, Now: s.clock})
+	defer p.Close()
+
+	p.Start(TicketAt(s.now.Add(-time.Minute)))()
+	p.Start(TicketAt(time.Time{}))()
+
+	wait := s.histogram(WaitDurationMetric)
+	s.Equal(uint64(1), wait.Count)
+	s.Equal(60.0, wait.Sum)
+	s.Equal(Stats{Name: "tasks", Kind: WORKER_KIND}, p.Stats())
+
+	// the unbounded pools do not report the capacities
+	s.Equal(int64(0), s.gauge(BusyWorkersMetric))
+	s.Equal(int64(0), s.gauge(QueueSizeMetric))
+	s.Nil(s.find(WorkersMetric))
+	s.Nil(s.find(UtilizationMetric))
+	s.Nil(s.find(QueueCapacityMetric))
+}
+
+func (s *PoolTestSuite) TestAll() {
+	a := New("b", &Opts{Kind: WORKER_KIND})
+	b := New("a", &Opts{Kind: WORKER_KIND})
+	c := New("z", &Opts{Kind: MESSAGING_KIND})
+
+	all := All()
+	s.Require().Len(all, 3)
+	s.Equal("z", all[0].Name)
+	s.Equal("a", all[1].Name)
+	s.Equal("b", all[2].Name)
+
+	a.Close()
+	b.Close()
+	c.Close()
+	s.Empty(All())
+	s.Nil(s.find(BusyWorkersMetric))
+}
+
+func (s *PoolTestSuite) find(name string) *metricdata.Metrics {
+	rm := metricdata.ResourceMetrics{}
+	s.Require().NoError(s.reader.Collect(context.Background(), &rm))
+
+	for _, sm := range rm.ScopeMetrics {
+		for i, m := range sm.Metrics {
+			if m.Name == name {
+				return &sm.Metrics[i]
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *PoolTestSuite) gauge(name string) int64 {
+	m := s.find(name)
+	s.Require().NotNil(m, name)
+
+	gauge := m.Data.(metricdata.Gauge[int64])
+	s.Require().Len(gauge.DataPoints, 1)
+	return gauge.DataPoints[0].Value
+}
+
+func (s *PoolTestSuite) utilization() float64 {
+	m := s.find(UtilizationMetric)
+	s.Require().NotNil(m)
+
+	gauge := m.Data.(metricdata.Gauge[float64])
+	s.Require().Len(gauge.DataPoints, 1)
+	return gauge.DataPoints[0].Value
+}
+
+func (s *PoolTestSuite) histogram(name string) metricdata.HistogramDataPoint[float64] {
+	m := s.find(name)
+	s.Require().NotNil(m, name)
+
+	histogram := m.Data.(metricdata.Histogram[float64])
+	s.Require().Len(histogram.DataPoints, 1)
+	return histogram.DataPoints[0]
+}
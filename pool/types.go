@@ -0,0 +1,72 @@
+package pool
+
+import (
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+type (
+	// Opts the pool dimensions, used to report the utilization and the buffer occupancy
+	Opts struct {
+		// Kind the component running the pool, e.g: MESSAGING_KIND
+		Kind string
+		// Workers the max concurrent tasks, zero is unbounded and the utilization is not reported
+		Workers int
+		// Capacity the buffer size of the tasks waiting a worker, zero when the buffer is unbounded or the tasks are not buffered
+		// by the pool
+		Capacity int
+		// Now the time source of the wait and task durations, default time.Now
+		Now func() time.Time
+	}
+
+	// Stats a snapshot of the pool saturation
+	Stats struct {
+		Name     string `json:"name"`
+		Kind     string `json:"kind"`
+		Workers  int64  `json:"workers"`
+		Busy     int64  `json:"busy"`
+		Capacity int64  `json:"capacity"`
+		Queued   int64  `json:"queued"`
+		Rejected int64  `json:"rejected"`
+	}
+
+	// Ticket a task submitted to the pool, it carries the submission time until a worker starts the task
+	Ticket struct {
+		submittedAt time.Time
+		queued      bool
+	}
+
+	// Pool track the saturation of a bounded group of goroutines, the pool does not run the tasks, the owner calls Enqueue
+	// when the task is buffered and Start when a worker picks it, the metrics are exported by the global meter provider
+	//
+	//	p := pool.New("orders", &pool.Opts{Kind: pool.MESSAGING_KIND, Workers: 4, Capacity: 100})
+	//	defer p.Close()
+	//
+	//	ticket := p.Enqueue()
+	//	buffer <- task
+	//
+	//	done := p.Start(ticket)
+	//	defer done()
+	Pool struct {
+		name     string
+		kind     string
+		workers  int64
+		capacity int64
+		now      func() time.Time
+		attrs    metric.MeasurementOption
+
+		busy     int64
+		queued   int64
+		rejected int64
+	}
+
+	// registry the open pools observed by the gauges
+	registry struct {
+		mu    sync.Mutex
+		pools map[*Pool]struct{}
+		// meters the providers with the gauges registered, the global provider could be replaced after the pools are created
+		meters map[metric.MeterProvider]struct{}
+	}
+)
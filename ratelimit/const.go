@@ -0,0 +1,28 @@
+package ratelimit
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	TOKEN_BUCKET_ALGORITHM   Algorithm = "token_bucket"
+	SLIDING_WINDOW_ALGORITHM Algorithm = "sliding_window"
+
+	LimitHeader      = "X-RateLimit-Limit"
+	RemainingHeader  = "X-RateLimit-Remaining"
+	RetryAfterHeader = "Retry-After"
+
+	DefaultRedisPrefix = "gokit:ratelimit:"
+	cleanupEvery       = 1000
+	minRetryAfter      = time.Millisecond
+)
+
+var (
+	ErrorInvalidConfig    = errors.New("rate limit requires a limit greater than zero and a window of at least 1ms")
+	ErrorUnknownAlgorithm = errors.New("unknown rate limit algorithm")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::ratelimit] " + msg
+}
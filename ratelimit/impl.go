@@ -0,0 +1,51 @@
+package ratelimit
+
+import (
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+)
+
+// NewConfig create the limiter configuration from the env RateLimit() configs
+func NewConfig(cfg *env.Configs) *Config {
+	return &Config{
+		Algorithm: Algorithm(cfg.RATE_LIMIT_ALGORITHM),
+		Limit:     cfg.RATE_LIMIT_LIMIT,
+		Window:    cfg.RATE_LIMIT_WINDOW,
+		Burst:     cfg.RATE_LIMIT_BURST,
+	}
+}
+
+func (c *Config) validate() error {
+	// the limiters work in milliseconds, a shorter window would divide by zero
+	if c.Limit <= 0 || c.Window < time.Millisecond {
+		return ErrorInvalidConfig
+	}
+
+	if c.Algorithm == "" {
+		c.Algorithm = TOKEN_BUCKET_ALGORITHM
+	}
+
+	if c.Algorithm != TOKEN_BUCKET_ALGORITHM && c.Algorithm != SLIDING_WINDOW_ALGORITHM {
+		return ErrorUnknownAlgorithm
+	}
+
+	if c.Burst <= 0 {
+		c.Burst = c.Limit
+	}
+
+	return nil
+}
+
+// rate tokens per millisecond
+func (c *Config) rate() float64 {
+	return float64(c.Limit) / float64(c.Window.Milliseconds())
+}
+
+func retryAfter(d time.Duration) time.Duration {
+	if d < minRetryAfter {
+		return minRetryAfter
+	}
+
+	return d
+}
@@ -0,0 +1,175 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateLimitTestSuite struct {
+	suite.Suite
+
+	now time.Time
+}
+
+func TestRateLimitTestSuite(t *testing.T) {
+	suite.Run(t, new(RateLimitTestSuite))
+}
+
+func (s *RateLimitTestSuite) SetupTest() {
+	s.now = time.UnixMilli(10_000)
+}
+
+func (s *RateLimitTestSuite) clock() time.Time {
+	return s.now
+}
+
+func (s *RateLimitTestSuite) limiters(algorithm Algorithm) []ILimiter {
+	cfg := &Config{Algorithm: algorithm, Limit: 2, Window: time.Second}
+
+	memory, err := NewMemoryLimiter(cfg)
+	s.NoError(err)
+	memory.(*memoryLimiter).timeNow = s.clock
+
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(s.T()).Addr()})
+	rds, err := NewRedisLimiter(client, cfg)
+	s.NoError(err)
+	rds.(*redisLimiter).timeNow = s.clock
+
+	return []ILimiter{memory, rds}
+}
+
+func (s *RateLimitTestSuite) TestTokenBucket() {
+	for _, limiter := range s.limiters(TOKEN_BUCKET_ALGORITHM) {
+		s.SetupTest()
+
+		for i := 0; i < 2; i++ {
+			result, err := limiter.Allow(context.Background(), "key")
+			s.NoError(err)
+			s.True(result.Allowed)
+		}
+
+		result, _ := limiter.Allow(context.Background(), "key")
+		s.False(result.Allowed)
+		s.Equal(500*time.Millisecond, result.RetryAfter)
+
+		result, _ = limiter.Allow(context.Background(), "other")
+		s.True(result.Allowed)
+
+		s.now = s.now.Add(500 * time.Millisecond)
+		result, _ = limiter.Allow(context.Background(), "key")
+		s.True(result.Allowed)
+	}
+}
+
+func (s *RateLimitTestSuite) TestSlidingWindow() {
+	for _, limiter := range s.limiters(SLIDING_WINDOW_ALGORITHM) {
+		s.SetupTest()
+
+		for i := 0; i < 2; i++ {
+			result, err := limiter.Allow(context.Background(), "key")
+			s.NoError(err)
+			s.True(result.Allowed)
+		}
+
+		result, _ := limiter.Allow(context.Background(), "key")
+		s.False(result.Allowed)
+		s.Equal(time.Second, result.RetryAfter)
+
+		// half of the previous window still counts
+		s.now = s.now.Add(1500 * time.Millisecond)
+		result, _ = limiter.Allow(context.Background(), "key")
+		s.True(result.Allowed)
+		result, _ = limiter.Allow(context.Background(), "key")
+		s.False(result.Allowed)
+	}
+}
+
+func (s *RateLimitTestSuite) TestSlidingWindowKeys() {
+	mr := miniredis.RunT(s.T())
+	limiter, err := NewRedisLimiter(redis.NewClient(&redis.Options{Addr: mr.Addr()}), &Config{Algorithm: SLIDING_WINDOW_ALGORITHM, Limit: 2, Window: time.Second})
+	s.Require().NoError(err)
+	limiter.(*redisLimiter).timeNow = s.clock
+
+	_, err = limiter.Allow(context.Background(), "key")
+	s.NoError(err)
+
+	// both windows share the hash tag, so the script keys are in the same cluster slot
+	s.Equal([]string{"{gokit:ratelimit:sliding_window:key}:10"}, mr.Keys())
+}
+
+func (s *RateLimitTestSuite) TestConfig() {
+	cfg := NewConfig(&env.Configs{RATE_LIMIT_ALGORITHM: "sliding_window", RATE_LIMIT_LIMIT: 10, RATE_LIMIT_WINDOW: time.Second})
+	s.NoError(cfg.validate())
+	s.Equal(10, cfg.Burst)
+
+	_, err := NewMemoryLimiter(&Config{Limit: 1})
+	s.ErrorIs(err, ErrorInvalidConfig)
+
+	_, err = NewRedisLimiter(nil, &Config{Limit: 1, Window: time.Microsecond})
+	s.ErrorIs(err, ErrorInvalidConfig)
+
+	_, err = NewMemoryLimiter(&Config{Algorithm: "other", Limit: 1, Window: time.Second})
+	s.ErrorIs(err, ErrorUnknownAlgorithm)
+}
+
+func (s *RateLimitTestSuite) TestHTTPMiddleware() {
+	limiter := NewMockLimiter()
+	limiter.On("Allow", context.Background(), "192.0.2.1").Return(&Result{Allowed: false, Limit: 1, RetryAfter: 1500 * time.Millisecond}, nil).Once()
+	limiter.On("Allow", context.Background(), "192.0.2.1").Return(&Result{Allowed: true, Limit: 1}, nil).Once()
+
+	handler := HTTPMiddleware(logging.NewMockLogger(), limiter, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal(http.StatusTooManyRequests, rec.Code)
+	s.Equal("2", rec.Header().Get(RetryAfterHeader))
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal(http.StatusOK, rec.Code)
+	s.Equal("1", rec.Header().Get(LimitHeader))
+}
+
+func (s *RateLimitTestSuite) TestConsumerHandler() {
+	limiter := NewMockLimiter()
+	limiter.On("Allow", context.Background(), "queue").Return(&Result{Allowed: false, RetryAfter: time.Millisecond}, nil).Once()
+	limiter.On("Allow", context.Background(), "queue").Return(&Result{Allowed: true}, nil).Once()
+
+	called := false
+	handler := ConsumerHandler(logging.NewMockLogger(), limiter, "queue", func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		called = true
+		return nil
+	})
+
+	s.NoError(handler(nil, nil))
+	s.True(called)
+	limiter.AssertExpectations(s.T())
+}
+
+func (s *RateLimitTestSuite) TestConsumerHandlerCtx() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	limiter := NewMockLimiter()
+	limiter.On("Allow", ctx, "queue").Return(&Result{Allowed: false, RetryAfter: time.Hour}, nil).Once()
+
+	called := false
+	handler := ConsumerHandler(logging.NewMockLogger(), limiter, "queue", func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		called = true
+		return nil
+	})
+
+	s.ErrorIs(handler(nil, &rabbitmq.DeliveryMetadata{Ctx: ctx}), context.Canceled)
+	s.False(called)
+	limiter.AssertExpectations(s.T())
+}
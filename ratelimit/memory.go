@@ -0,0 +1,116 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// NewMemoryLimiter limiter with the state in the process memory, each instance of the service has its own limit
+func NewMemoryLimiter(cfg *Config) (ILimiter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return &memoryLimiter{
+		cfg:     cfg,
+		buckets: map[string]*tokenBucket{},
+		windows: map[string]*slidingWindow{},
+		timeNow: time.Now,
+	}, nil
+}
+
+func (l *memoryLimiter) Allow(_ context.Context, key string) (*Result, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.timeNow()
+	l.cleanup(now)
+
+	if l.cfg.Algorithm == SLIDING_WINDOW_ALGORITHM {
+		return l.slidingWindow(key, now), nil
+	}
+
+	return l.tokenBucket(key, now), nil
+}
+
+func (l *memoryLimiter) tokenBucket(key string, now time.Time) *Result {
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(l.cfg.Burst), last: now}
+		l.buckets[key] = bucket
+	}
+
+	rate := l.cfg.rate()
+	elapsed := float64(now.Sub(bucket.last).Milliseconds())
+	bucket.tokens = min(float64(l.cfg.Burst), bucket.tokens+elapsed*rate)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1-bucket.tokens)/rate) * time.Millisecond
+		return &Result{Allowed: false, Limit: l.cfg.Limit, RetryAfter: retryAfter(wait)}
+	}
+
+	bucket.tokens--
+	return &Result{Allowed: true, Limit: l.cfg.Limit, Remaining: int(bucket.tokens)}
+}
+
+// slidingWindow sliding window counter, the previous window count is weighted by the elapsed time of the current window
+func (l *memoryLimiter) slidingWindow(key string, now time.Time) *Result {
+	window := l.cfg.Window.Milliseconds()
+	index := now.UnixMilli() / window
+
+	w, ok := l.windows[key]
+	if !ok {
+		w = &slidingWindow{index: index}
+		l.windows[key] = w
+	}
+
+	switch {
+	case index == w.index+1:
+		w.previous, w.current = w.current, 0
+	case index > w.index+1:
+		w.previous, w.current = 0, 0
+	}
+	w.index = index
+	w.last = now
+
+	elapsed := float64(now.UnixMilli()%window) / float64(window)
+	count := float64(w.previous)*(1-elapsed) + float64(w.current)
+
+	if count >= float64(l.cfg.Limit) {
+		wait := time.Duration(window-now.UnixMilli()%window) * time.Millisecond
+		return &Result{Allowed: false, Limit: l.cfg.Limit, RetryAfter: retryAfter(wait)}
+	}
+
+	w.current++
+	return &Result{Allowed: true, Limit: l.cfg.Limit, Remaining: int(float64(l.cfg.Limit) - count - 1)}
+}
+
+// cleanup remove the idle keys from time to time, avoiding unbounded memory usage
+func (l *memoryLimiter) cleanup(now time.Time) {
+	l.calls++
+	if l.calls%cleanupEvery != 0 {
+		return
+	}
+
+	idle := 2 * l.cfg.Window
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.last) > idle {
+			delete(l.buckets, key)
+		}
+	}
+
+	for key, w := range l.windows {
+		if now.Sub(w.last) > idle {
+			delete(l.windows, key)
+		}
+	}
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+// KeyByIP use the client ip as the rate limit key
+func KeyByIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+// HTTPMiddleware reject the requests above the limit with 429, the limiter failures are logged and the request is allowed
+func HTTPMiddleware(logger logging.ILogger, limiter ILimiter, keyFunc KeyFunc) func(next http.Handler) http.Handler {
+	if keyFunc == nil {
+		keyFunc = KeyByIP
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			result, err := limiter.Allow(r.Context(), keyFunc(r))
+			if err != nil {
+				logger.Error(LogMessage("limiter failure"), logging.ErrorField(err))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set(LimitHeader, strconv.Itoa(result.Limit))
+			w.Header().Set(RemainingHeader, strconv.Itoa(result.Remaining))
+
+			if !result.Allowed {
+				w.Header().Set(RetryAfterHeader, strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ConsumerHandler decorate a messaging handler, the consumer waits until the limit allows the message to be processed,
+// when the metadata Ctx is done or the limiter fails the error is returned without calling the handler
+func ConsumerHandler(logger logging.ILogger, limiter ILimiter, key string, handler rabbitmq.ConsumerHandler) rabbitmq.ConsumerHandler {
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		ctx := context.Background()
+		if metadata != nil && metadata.Ctx != nil {
+			ctx = metadata.Ctx
+		}
+
+		if err := Wait(ctx, limiter, key); err != nil {
+			logger.Error(LogMessage("limiter failure"), logging.ErrorField(err))
+			return err
+		}
+
+		return handler(msg, metadata)
+	}
+}
+
+// Wait block until the limiter allows the event or the ctx is done
+func Wait(ctx context.Context, limiter ILimiter, key string) error {
+	for {
+		result, err := limiter.Allow(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		if result.Allowed {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(result.RetryAfter):
+		}
+	}
+}
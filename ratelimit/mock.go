@@ -0,0 +1,22 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockLimiter struct {
+	mock.Mock
+}
+
+func (m *MockLimiter) Allow(ctx context.Context, key string) (*Result, error) {
+	args := m.Called(ctx, key)
+
+	result, _ := args.Get(0).(*Result)
+	return result, args.Error(1)
+}
+
+func NewMockLimiter() *MockLimiter {
+	return new(MockLimiter)
+}
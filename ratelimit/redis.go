@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// tokenBucketScript returns {allowed, tokens}
+	tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local ttl = tonumber(ARGV[4])
+
+local data = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(data[1])
+local ts = tonumber(data[2])
+if tokens == nil then
+	tokens = capacity
+	ts = now
+end
+
+tokens = math.min(capacity, tokens + (now - ts) * rate)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tostring(tokens), "ts", tostring(now))
+redis.call("PEXPIRE", KEYS[1], ttl)
+
+return {allowed, tostring(tokens)}
+`)
+
+	// slidingWindowScript returns {allowed, remaining, retry after ms}, KEYS are the current and the previous windows
+	slidingWindowScript = redis.NewScript(`
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local current = tonumber(redis.call("GET", KEYS[1]) or "0")
+local previous = tonumber(redis.call("GET", KEYS[2]) or "0")
+local elapsed = (now % window) / window
+local count = previous * (1 - elapsed) + current
+
+if count >= limit then
+	return {0, 0, window - (now % window)}
+end
+
+redis.call("INCR", KEYS[1])
+redis.call("PEXPIRE", KEYS[1], window * 2)
+
+return {1, math.floor(limit - count - 1), 0}
+`)
+)
+
+// NewRedisLimiter limiter with the state in redis, the limit is shared by all the instances of the service
+func NewRedisLimiter(client redis.UniversalClient, cfg *Config) (ILimiter, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	return &redisLimiter{
+		cfg:     cfg,
+		client:  client,
+		prefix:  DefaultRedisPrefix,
+		timeNow: time.Now,
+	}, nil
+}
+
+func (l *redisLimiter) Allow(ctx context.Context, key string) (*Result, error) {
+	now := l.timeNow().UnixMilli()
+	redisKey := l.prefix + string(l.cfg.Algorithm) + ":" + key
+
+	if l.cfg.Algorithm == SLIDING_WINDOW_ALGORITHM {
+		// the hash tag keeps both windows in the same redis cluster slot
+		index := now / l.cfg.Window.Milliseconds()
+		keys := []string{
+			"{" + redisKey + "}:" + strconv.FormatInt(index, 10),
+			"{" + redisKey + "}:" + strconv.FormatInt(index-1, 10),
+		}
+
+		values, err := slidingWindowScript.Run(ctx, l.client, keys, l.cfg.Window.Milliseconds(), l.cfg.Limit, now).Int64Slice()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Result{
+			Allowed:    values[0] == 1,
+			Limit:      l.cfg.Limit,
+			Remaining:  int(values[1]),
+			RetryAfter: time.Duration(values[2]) * time.Millisecond,
+		}, nil
+	}
+
+	rate := l.cfg.rate()
+	ttl := int64(float64(l.cfg.Burst)/rate) + 1000
+
+	values, err := tokenBucketScript.Run(ctx, l.client, []string{redisKey}, strconv.FormatFloat(rate, 'f', -1, 64), l.cfg.Burst, now, ttl).Slice()
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, _ := strconv.ParseFloat(values[1].(string), 64)
+	result := &Result{Allowed: values[0].(int64) == 1, Limit: l.cfg.Limit, Remaining: int(tokens)}
+
+	if !result.Allowed {
+		result.RetryAfter = retryAfter(time.Duration((1-tokens)/rate) * time.Millisecond)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	Algorithm string
+
+	// Config limiter configuration, the same configuration could be shared by the http middleware and the consumers
+	Config struct {
+		Algorithm Algorithm
+		// Limit how many events are allowed in the window
+		Limit  int
+		Window time.Duration
+		// Burst the token bucket capacity, ignored by the sliding window
+		Burst int
+	}
+
+	// Result the limiter decision
+	Result struct {
+		Allowed    bool
+		Limit      int
+		Remaining  int
+		RetryAfter time.Duration
+	}
+
+	ILimiter interface {
+		// Allow consume one event for the key
+		Allow(ctx context.Context, key string) (*Result, error)
+	}
+
+	// KeyFunc extract the rate limit key from the request
+	KeyFunc = func(r *http.Request) string
+
+	tokenBucket struct {
+		tokens float64
+		last   time.Time
+	}
+
+	slidingWindow struct {
+		index    int64
+		current  int
+		previous int
+		last     time.Time
+	}
+
+	memoryLimiter struct {
+		cfg     *Config
+		mu      sync.Mutex
+		buckets map[string]*tokenBucket
+		windows map[string]*slidingWindow
+		calls   int
+		timeNow func() time.Time
+	}
+
+	redisLimiter struct {
+		cfg     *Config
+		client  redis.UniversalClient
+		prefix  string
+		timeNow func() time.Time
+	}
+)
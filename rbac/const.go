@@ -0,0 +1,28 @@
+package rbac
+
+import "errors"
+
+const (
+	// WILDCARD grants all the permissions, e.g: "*" or "orders:*"
+	WILDCARD = "*"
+	// PermissionSeparator the resource and action separator, e.g: orders:write
+	PermissionSeparator = ":"
+
+	DefaultPermissionsTable = "role_permissions"
+
+	PostgresSchema = `CREATE TABLE IF NOT EXISTS %s (
+	role TEXT NOT NULL,
+	permission TEXT NOT NULL,
+	PRIMARY KEY (role, permission)
+)`
+)
+
+var (
+	ErrorUnauthenticated = errors.New("rbac the request has no claims, the auth middleware must run before")
+	ErrorForbidden       = errors.New("rbac permission denied")
+	ErrorInvalidPolicy   = errors.New("rbac invalid policy")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::rbac] " + msg
+}
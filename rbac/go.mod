@@ -0,0 +1,18 @@
+module github.com/ralvescosta/gokit/rbac
+
+go 1.18
+
+require (
+	github.com/DATA-DOG/go-sqlmock v1.5.0
+	github.com/ralvescosta/gokit/auth v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/stretchr/testify v1.8.0
+	google.golang.org/grpc v1.65.0
+)
+
+require (
+	github.com/ralvescosta/gokit/clock v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/crypto v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94 // indirect
+	github.com/ralvescosta/gokit/sql v0.0.0-20261016183433-cb393702ac94 // indirect
+)
@@ -0,0 +1,176 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ralvescosta/gokit/auth"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// NewEnforcer load the policy with the loader, mapper is optional, default DefaultRoleMapper
+func NewEnforcer(ctx context.Context, logger logging.ILogger, loader PolicyLoader, mapper RoleMapper) (IEnforcer, error) {
+	if mapper == nil {
+		mapper = DefaultRoleMapper
+	}
+
+	e := &Enforcer{logger: logger, loader: loader, mapper: mapper}
+	if err := e.Reload(ctx); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// DefaultRoleMapper the roles claim
+func DefaultRoleMapper(claims *auth.Claims) []string {
+	return claims.Roles
+}
+
+// ClaimRoleMapper map the roles and the scopes of the token to the policy roles, e.g: the identity provider groups.
+// The roles claim is kept, so the policy roles can be used directly too
+func ClaimRoleMapper(mapping map[string][]string) RoleMapper {
+	return func(claims *auth.Claims) []string {
+		roles := append([]string{}, claims.Roles...)
+
+		for _, claim := range append(append([]string{}, claims.Roles...), claims.Scopes()...) {
+			roles = append(roles, mapping[claim]...)
+		}
+
+		return roles
+	}
+}
+
+func (e *Enforcer) Reload(ctx context.Context) error {
+	policy, err := e.loader(ctx)
+	if err != nil {
+		e.logger.Error(LogMessage("failure to load the policy"), logging.ErrorField(err))
+		return err
+	}
+
+	return e.SetPolicy(policy)
+}
+
+func (e *Enforcer) SetPolicy(policy *Policy) error {
+	if policy == nil {
+		return ErrorInvalidPolicy
+	}
+
+	grants := make(map[string]map[string]bool, len(policy.Roles))
+	for name := range policy.Roles {
+		granted := map[string]bool{}
+		if err := resolve(policy, name, granted, map[string]bool{}); err != nil {
+			return err
+		}
+
+		grants[name] = granted
+	}
+
+	e.mu.Lock()
+	e.grants = grants
+	e.mu.Unlock()
+
+	e.logger.Debug(LogMessage(fmt.Sprintf("policy loaded with %d roles", len(grants))))
+
+	return nil
+}
+
+func (e *Enforcer) Allowed(roles []string, permissions ...string) bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	for _, p := range permissions {
+		allowed := false
+		for _, r := range roles {
+			if granted(e.grants[r], p) {
+				allowed = true
+				break
+			}
+		}
+
+		if !allowed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (e *Enforcer) Check(ctx context.Context, permissions ...string) error {
+	claims, ok := auth.ClaimsFromContext(ctx)
+	if !ok {
+		return ErrorUnauthenticated
+	}
+
+	if !e.Allowed(e.mapper(claims), permissions...) {
+		e.logger.Debug(LogMessage(fmt.Sprintf("permission denied to %s: %s", claims.Subject, strings.Join(permissions, ", "))))
+		return ErrorForbidden
+	}
+
+	return nil
+}
+
+func (e *Enforcer) Permissions(roles ...string) []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	set := map[string]bool{}
+	for _, r := range roles {
+		for p := range e.grants[r] {
+			set[p] = true
+		}
+	}
+
+	permissions := make([]string, 0, len(set))
+	for p := range set {
+		permissions = append(permissions, p)
+	}
+	sort.Strings(permissions)
+
+	return permissions
+}
+
+// resolve add the role permissions and the inherited ones, the inheritance cycles are invalid
+func resolve(policy *Policy, name string, granted, visiting map[string]bool) error {
+	role, ok := policy.Roles[name]
+	if !ok {
+		return fmt.Errorf("%w: unknown role %s", ErrorInvalidPolicy, name)
+	}
+
+	if visiting[name] {
+		return fmt.Errorf("%w: inheritance cycle in the role %s", ErrorInvalidPolicy, name)
+	}
+
+	visiting[name] = true
+	defer delete(visiting, name)
+
+	for _, p := range role.Permissions {
+		granted[p] = true
+	}
+
+	for _, inherited := range role.Inherits {
+		if err := resolve(policy, inherited, granted, visiting); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// granted the permission is granted by itself, by the WILDCARD or by the resource wildcard, e.g: orders:* grants orders:items:write
+func granted(grants map[string]bool, permission string) bool {
+	if grants[permission] || grants[WILDCARD] {
+		return true
+	}
+
+	parts := strings.Split(permission, PermissionSeparator)
+	for i := len(parts) - 1; i > 0; i-- {
+		if grants[strings.Join(parts[:i], PermissionSeparator)+PermissionSeparator+WILDCARD] {
+			return true
+		}
+	}
+
+	return false
+}
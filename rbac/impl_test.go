@@ -0,0 +1,155 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/auth"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type RBACTestSuite struct {
+	suite.Suite
+
+	policy   *Policy
+	enforcer IEnforcer
+}
+
+func TestRBACTestSuite(t *testing.T) {
+	suite.Run(t, new(RBACTestSuite))
+}
+
+func (s *RBACTestSuite) SetupTest() {
+	s.policy = &Policy{Roles: map[string]*Role{
+		"viewer": {Permissions: []string{"orders:read"}},
+		"seller": {Permissions: []string{"orders:write"}, Inherits: []string{"viewer"}},
+		"ops":    {Permissions: []string{"orders:*"}},
+		"admin":  {Permissions: []string{WILDCARD}},
+	}}
+
+	var err error
+	s.enforcer, err = NewEnforcer(context.Background(), logging.NewMockLogger(), StaticPolicy(s.policy), nil)
+	s.NoError(err)
+}
+
+func (s *RBACTestSuite) ctx(roles ...string) context.Context {
+	return auth.ContextWithClaims(context.Background(), &auth.Claims{Roles: roles})
+}
+
+func (s *RBACTestSuite) TestAllowed() {
+	s.True(s.enforcer.Allowed([]string{"seller"}, "orders:write", "orders:read"))
+	s.False(s.enforcer.Allowed([]string{"viewer"}, "orders:read", "orders:write"))
+	s.True(s.enforcer.Allowed([]string{"ops"}, "orders:items:write"))
+	s.False(s.enforcer.Allowed([]string{"ops"}, "payments:read"))
+	s.True(s.enforcer.Allowed([]string{"admin"}, "payments:read"))
+	s.False(s.enforcer.Allowed([]string{"unknown"}, "orders:read"))
+	s.True(s.enforcer.Allowed([]string{"viewer", "seller"}, "orders:write"))
+
+	s.Equal([]string{"orders:read", "orders:write"}, s.enforcer.Permissions("seller"))
+}
+
+func (s *RBACTestSuite) TestSetPolicy() {
+	s.ErrorIs(s.enforcer.SetPolicy(nil), ErrorInvalidPolicy)
+
+	s.ErrorIs(s.enforcer.SetPolicy(&Policy{Roles: map[string]*Role{"a": {Inherits: []string{"b"}}}}), ErrorInvalidPolicy)
+	s.ErrorIs(s.enforcer.SetPolicy(&Policy{Roles: map[string]*Role{
+		"a": {Inherits: []string{"b"}},
+		"b": {Inherits: []string{"a"}},
+	}}), ErrorInvalidPolicy)
+
+	s.True(s.enforcer.Allowed([]string{"seller"}, "orders:write"))
+
+	_, err := NewEnforcer(context.Background(), logging.NewMockLogger(), func(ctx context.Context) (*Policy, error) {
+		return nil, errors.New("some error")
+	}, nil)
+	s.Error(err)
+}
+
+func (s *RBACTestSuite) TestLoadPolicyFile() {
+	path := filepath.Join(s.T().TempDir(), "policy.json")
+	s.NoError(os.WriteFile(path, []byte(`{"roles":{"viewer":{"permissions":["orders:read"]}}}`), 0o600))
+
+	policy, err := LoadPolicyFile(path)(context.Background())
+	s.NoError(err)
+	s.Equal([]string{"orders:read"}, policy.Roles["viewer"].Permissions)
+
+	_, err = ParsePolicy(strings.NewReader("{"))
+	s.ErrorIs(err, ErrorInvalidPolicy)
+
+	_, err = LoadPolicyFile("unknown.json")(context.Background())
+	s.Error(err)
+}
+
+func (s *RBACTestSuite) TestSqlPolicyLoader() {
+	db, sqlMock, _ := sqlmock.New()
+
+	sqlMock.ExpectExec("CREATE TABLE IF NOT EXISTS role_permissions").WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery("SELECT role, permission FROM role_permissions").
+		WillReturnRows(sqlmock.NewRows([]string{"role", "permission"}).AddRow("viewer", "orders:read").AddRow("viewer", "payments:read"))
+
+	s.NoError(Migrate(context.Background(), db, ""))
+
+	policy, err := NewSqlPolicyLoader(db, "")(context.Background())
+	s.NoError(err)
+	s.Equal([]string{"orders:read", "payments:read"}, policy.Roles["viewer"].Permissions)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *RBACTestSuite) TestClaimRoleMapper() {
+	enforcer, _ := NewEnforcer(context.Background(), logging.NewMockLogger(), StaticPolicy(s.policy), ClaimRoleMapper(map[string][]string{
+		"sales-team":   {"seller"},
+		"orders.admin": {"ops"},
+	}))
+
+	s.NoError(enforcer.Check(s.ctx("sales-team"), "orders:write"))
+	s.ErrorIs(enforcer.Check(s.ctx("other"), "orders:write"), ErrorForbidden)
+	s.NoError(enforcer.Check(auth.ContextWithClaims(context.Background(), &auth.Claims{Scope: "orders.admin"}), "orders:cancel"))
+	s.ErrorIs(enforcer.Check(context.Background(), "orders:read"), ErrorUnauthenticated)
+}
+
+func (s *RBACTestSuite) TestRequirePermission() {
+	handler := RequirePermission(s.enforcer, "orders:write")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	for ctx, expected := range map[context.Context]int{
+		s.ctx("seller"):      http.StatusNoContent,
+		s.ctx("viewer"):      http.StatusForbidden,
+		context.Background(): http.StatusUnauthorized,
+	} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/orders", nil).WithContext(ctx))
+		s.Equal(expected, rec.Code)
+	}
+}
+
+func (s *RBACTestSuite) TestUnaryServerInterceptor() {
+	interceptor := UnaryServerInterceptor(s.enforcer, MethodPermissions{"/orders.Orders/Create": {"orders:write"}})
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	res, err := interceptor(s.ctx("seller"), nil, &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/Create"}, handler)
+	s.NoError(err)
+	s.Equal("ok", res)
+
+	_, err = interceptor(s.ctx("viewer"), nil, &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/Create"}, handler)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/Create"}, handler)
+	s.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: "/orders.Orders/List"}, handler)
+	s.NoError(err)
+
+	s.NoError(RequireGrpcPermission(s.ctx("viewer"), s.enforcer, "orders:read"))
+}
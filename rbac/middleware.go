@@ -0,0 +1,76 @@
+package rbac
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RequirePermission must be used after auth.HTTPMiddleware, respond 403 when the token roles are not granted with the permissions
+//
+//	r.With(rbac.RequirePermission(enforcer, "orders:write")).Post("/orders", handler)
+func RequirePermission(enforcer IEnforcer, permissions ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := enforcer.Check(r.Context(), permissions...); err != nil {
+				status := http.StatusForbidden
+				if errors.Is(err, ErrorUnauthenticated) {
+					status = http.StatusUnauthorized
+				}
+
+				w.WriteHeader(status)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// UnaryServerInterceptor must be chained after auth.UnaryServerInterceptor, the methods without permissions are not verified
+func UnaryServerInterceptor(enforcer IEnforcer, methods MethodPermissions) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := checkGrpc(ctx, enforcer, methods[info.FullMethod]); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor must be chained after auth.StreamServerInterceptor, the methods without permissions are not verified
+func StreamServerInterceptor(enforcer IEnforcer, methods MethodPermissions) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := checkGrpc(ss.Context(), enforcer, methods[info.FullMethod]); err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// RequireGrpcPermission verify the permissions inside the gRPC handler, returns the status error
+func RequireGrpcPermission(ctx context.Context, enforcer IEnforcer, permissions ...string) error {
+	return checkGrpc(ctx, enforcer, permissions)
+}
+
+func checkGrpc(ctx context.Context, enforcer IEnforcer, permissions []string) error {
+	if len(permissions) == 0 {
+		return nil
+	}
+
+	err := enforcer.Check(ctx, permissions...)
+	if errors.Is(err, ErrorUnauthenticated) {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err != nil {
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+
+	return nil
+}
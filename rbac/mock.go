@@ -0,0 +1,42 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEnforcer struct {
+	mock.Mock
+}
+
+func (m *MockEnforcer) Allowed(roles []string, permissions ...string) bool {
+	args := m.Called(roles, permissions)
+	return args.Bool(0)
+}
+
+func (m *MockEnforcer) Check(ctx context.Context, permissions ...string) error {
+	args := m.Called(ctx, permissions)
+	return args.Error(0)
+}
+
+func (m *MockEnforcer) Permissions(roles ...string) []string {
+	args := m.Called(roles)
+
+	permissions, _ := args.Get(0).([]string)
+	return permissions
+}
+
+func (m *MockEnforcer) SetPolicy(policy *Policy) error {
+	args := m.Called(policy)
+	return args.Error(0)
+}
+
+func (m *MockEnforcer) Reload(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func NewMockEnforcer() *MockEnforcer {
+	return new(MockEnforcer)
+}
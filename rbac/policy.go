@@ -0,0 +1,85 @@
+package rbac
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// StaticPolicy the policy defined in the code
+func StaticPolicy(policy *Policy) PolicyLoader {
+	return func(ctx context.Context) (*Policy, error) {
+		return policy, nil
+	}
+}
+
+// LoadPolicyFile read the json policy file, the file is read again on each Reload
+func LoadPolicyFile(path string) PolicyLoader {
+	return func(ctx context.Context) (*Policy, error) {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+
+		return ParsePolicy(f)
+	}
+}
+
+func ParsePolicy(r io.Reader) (*Policy, error) {
+	policy := &Policy{}
+	if err := json.NewDecoder(r).Decode(policy); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrorInvalidPolicy, err)
+	}
+
+	if policy.Roles == nil {
+		policy.Roles = map[string]*Role{}
+	}
+
+	return policy, nil
+}
+
+// NewSqlPolicyLoader read the (role, permission) rows of the table, the table could be created with Migrate.
+// The inheritance is not stored in the table, grant the permissions to each role
+func NewSqlPolicyLoader(db *sql.DB, table string) PolicyLoader {
+	if table == "" {
+		table = DefaultPermissionsTable
+	}
+
+	return func(ctx context.Context) (*Policy, error) {
+		rows, err := db.QueryContext(ctx, fmt.Sprintf("SELECT role, permission FROM %s", table))
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		policy := &Policy{Roles: map[string]*Role{}}
+		for rows.Next() {
+			var role, permission string
+			if err := rows.Scan(&role, &permission); err != nil {
+				return nil, err
+			}
+
+			if _, ok := policy.Roles[role]; !ok {
+				policy.Roles[role] = &Role{}
+			}
+
+			policy.Roles[role].Permissions = append(policy.Roles[role].Permissions, permission)
+		}
+
+		return policy, rows.Err()
+	}
+}
+
+// Migrate create the permissions table if it does not exist
+func Migrate(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultPermissionsTable
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(PostgresSchema, table))
+	return err
+}
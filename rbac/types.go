@@ -0,0 +1,54 @@
+package rbac
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ralvescosta/gokit/auth"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	Role struct {
+		Permissions []string `json:"permissions"`
+		// Inherits the roles whose permissions are granted too
+		Inherits []string `json:"inherits,omitempty"`
+	}
+
+	// Policy the roles by name, e.g: {"roles":{"viewer":{"permissions":["orders:read"]},"seller":{"permissions":["orders:write"],"inherits":["viewer"]}}}
+	Policy struct {
+		Roles map[string]*Role `json:"roles"`
+	}
+
+	// PolicyLoader load the policy, e.g: LoadPolicyFile or NewSqlPolicyLoader
+	PolicyLoader = func(ctx context.Context) (*Policy, error)
+
+	// RoleMapper returns the roles of the token, default the roles claim, see ClaimRoleMapper
+	RoleMapper = func(claims *auth.Claims) []string
+
+	// MethodPermissions the permissions required to each gRPC full method, e.g: "/orders.Orders/Create": {"orders:write"}
+	MethodPermissions = map[string][]string
+
+	IEnforcer interface {
+		// Allowed returns true when the roles are granted with all the permissions
+		Allowed(roles []string, permissions ...string) bool
+		// Check verify the permissions of the claims in the context, returns ErrorUnauthenticated or ErrorForbidden
+		Check(ctx context.Context, permissions ...string) error
+		// Permissions the permissions granted to the roles, inheritance included
+		Permissions(roles ...string) []string
+		// SetPolicy replace the policy, safe to be called while the requests are authorized
+		SetPolicy(policy *Policy) error
+		// Reload replace the policy with the loader result
+		Reload(ctx context.Context) error
+	}
+
+	Enforcer struct {
+		logger logging.ILogger
+		loader PolicyLoader
+		mapper RoleMapper
+
+		mu sync.RWMutex
+		// grants the permissions of each role resolved with the inherited roles
+		grants map[string]map[string]bool
+	}
+)
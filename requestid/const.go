@@ -0,0 +1,18 @@
+package requestid
+
+const (
+	HTTPHeader = "X-Request-Id"
+	AMQPHeader = "x-request-id"
+	// GRPCMetadataKey the grpc metadata keys are lower case
+	GRPCMetadataKey = "x-request-id"
+
+	LogFieldKey      = "requestId"
+	SpanAttributeKey = "request.id"
+
+	// MaxLength the received ids longer than it are replaced, see Ensure
+	MaxLength = 128
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::requestid] " + msg
+}
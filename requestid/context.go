@@ -0,0 +1,63 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/guid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type requestIDCtxKey struct{}
+
+// New generate a new request id, the ids are ULIDs
+func New() string {
+	return guid.NewRequestID()
+}
+
+// ContextWithID store the request id in the context and set it in the span carried by the context
+func ContextWithID(ctx context.Context, id string) context.Context {
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String(SpanAttributeKey, id))
+	return context.WithValue(ctx, requestIDCtxKey{}, id)
+}
+
+// FromContext get the request id from the context
+func FromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+
+	id, ok := ctx.Value(requestIDCtxKey{}).(string)
+	return id, ok && id != ""
+}
+
+// Ensure return the context with the received request id, a new id is generated when the received id is empty or
+// invalid, the valid ids have up to MaxLength letters, digits, '.', '_' or '-'
+func Ensure(ctx context.Context, id string) (context.Context, string) {
+	if !valid(id) {
+		if current, ok := FromContext(ctx); ok {
+			return ctx, current
+		}
+
+		id = New()
+	}
+
+	return ContextWithID(ctx, id), id
+}
+
+// valid the ids are logged and propagated in headers, so the client values can not carry arbitrary content
+func valid(id string) bool {
+	if id == "" || len(id) > MaxLength {
+		return false
+	}
+
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '.', c == '_', c == '-':
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,27 @@
+package requestid
+
+import (
+	"net/http"
+)
+
+// Transport decorate the http client transport setting the X-Request-Id header from the request context, base is optional
+func Transport(base http.RoundTripper) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &transport{base}
+}
+
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	id, ok := FromContext(r.Context())
+	if !ok || r.Header.Get(HTTPHeader) != "" {
+		return t.base.RoundTrip(r)
+	}
+
+	// RoundTrip must not modify the received request
+	r = r.Clone(r.Context())
+	r.Header.Set(HTTPHeader, id)
+
+	return t.base.RoundTrip(r)
+}
@@ -0,0 +1,18 @@
+package requestid
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/logging"
+	"go.uber.org/zap"
+)
+
+// Field the requestId log field, the field is skipped when the context has no request id
+func Field(ctx context.Context) zap.Field {
+	id, ok := FromContext(ctx)
+	if !ok {
+		return zap.Skip()
+	}
+
+	return logging.MessageField(LogFieldKey, id)
+}
@@ -0,0 +1,92 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+type RequestIDTestSuite struct {
+	suite.Suite
+}
+
+func TestRequestIDTestSuite(t *testing.T) {
+	suite.Run(t, new(RequestIDTestSuite))
+}
+
+func (s *RequestIDTestSuite) TestEnsure() {
+	ctx, id := Ensure(context.Background(), "")
+	s.Len(id, 26)
+
+	current, ok := FromContext(ctx)
+	s.True(ok)
+	s.Equal(id, current)
+
+	_, same := Ensure(ctx, "")
+	s.Equal(id, same)
+
+	_, received := Ensure(ctx, "received")
+	s.Equal("received", received)
+
+	_, ok = FromContext(context.Background())
+	s.False(ok)
+}
+
+func (s *RequestIDTestSuite) TestEnsureInvalid() {
+	_, id := Ensure(context.Background(), "01H.order_created-1")
+	s.Equal("01H.order_created-1", id)
+
+	for _, invalid := range []string{"id\r\nx-injected: 1", "<script>", strings.Repeat("a", MaxLength+1)} {
+		_, id = Ensure(context.Background(), invalid)
+		s.Len(id, 26)
+	}
+
+	_, id = Ensure(context.Background(), strings.Repeat("a", MaxLength))
+	s.Len(id, MaxLength)
+}
+
+func (s *RequestIDTestSuite) TestTransport() {
+	var received string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Get(HTTPHeader)
+	}))
+	defer srv.Close()
+
+	req, _ := http.NewRequestWithContext(ContextWithID(context.Background(), "abc"), http.MethodGet, srv.URL, nil)
+	res, err := (&http.Client{Transport: Transport(nil)}).Do(req)
+
+	s.NoError(err)
+	res.Body.Close()
+	s.Equal("abc", received)
+	s.Empty(req.Header.Get(HTTPHeader))
+}
+
+func (s *RequestIDTestSuite) TestField() {
+	core, logs := observer.New(zap.DebugLevel)
+	logger := zap.New(core)
+
+	logger.Info("with", Field(ContextWithID(context.Background(), "abc")))
+	logger.Info("without", Field(context.Background()))
+
+	s.Equal("abc", logs.All()[0].ContextMap()[LogFieldKey])
+	s.NotContains(logs.All()[1].ContextMap(), LogFieldKey)
+}
+
+func (s *RequestIDTestSuite) TestSpanProcessor() {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(NewSpanProcessor()), sdkTrace.WithSpanProcessor(recorder))
+
+	_, span := provider.Tracer("test").Start(ContextWithID(context.Background(), "abc"), "span")
+	span.End()
+
+	s.Contains(recorder.Ended()[0].Attributes(), attribute.String(SpanAttributeKey, "abc"))
+}
@@ -0,0 +1,26 @@
+package requestid
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// NewSpanProcessor set the request.id attribute in every span started with a context carrying the request id, the
+// telemetry trace.NewOTLP registers it, the other tracer providers must register it
+func NewSpanProcessor() sdkTrace.SpanProcessor {
+	return &spanProcessor{}
+}
+
+func (p *spanProcessor) OnStart(parent context.Context, s sdkTrace.ReadWriteSpan) {
+	if id, ok := FromContext(parent); ok {
+		s.SetAttributes(attribute.String(SpanAttributeKey, id))
+	}
+}
+
+func (p *spanProcessor) OnEnd(sdkTrace.ReadOnlySpan) {}
+
+func (p *spanProcessor) Shutdown(context.Context) error { return nil }
+
+func (p *spanProcessor) ForceFlush(context.Context) error { return nil }
@@ -0,0 +1,15 @@
+package requestid
+
+import (
+	"net/http"
+)
+
+type (
+	// transport set the X-Request-Id header in the outgoing requests
+	transport struct {
+		base http.RoundTripper
+	}
+
+	// spanProcessor set the request.id attribute in the spans started with a context carrying the request id
+	spanProcessor struct{}
+)
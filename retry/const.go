@@ -0,0 +1,14 @@
+package retry
+
+import "time"
+
+const (
+	DefaultMaxAttempts    = 3
+	DefaultInitialDelay   = 100 * time.Millisecond
+	DefaultMaxDelay       = 10 * time.Second
+	DefaultMultiplier     = 2.0
+	DefaultJitter         = 0.2
+	DefaultMaxElapsedTime = 1 * time.Minute
+
+	AttemptEventName = "retry.attempt"
+)
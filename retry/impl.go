@@ -0,0 +1,180 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultPolicy exponential backoff with jitter, 3 attempts
+func DefaultPolicy() *Policy {
+	return &Policy{
+		MaxAttempts:    DefaultMaxAttempts,
+		Backoff:        Exponential(DefaultInitialDelay, DefaultMaxDelay, DefaultMultiplier),
+		Jitter:         DefaultJitter,
+		MaxElapsedTime: DefaultMaxElapsedTime,
+	}
+}
+
+// Exponential initial * multiplier^(attempt-1), limited by max
+func Exponential(initial, max time.Duration, multiplier float64) Backoff {
+	return func(attempt int) time.Duration {
+		delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+		if delay > float64(max) {
+			return max
+		}
+
+		return time.Duration(delay)
+	}
+}
+
+// Linear initial + increment*(attempt-1), limited by max
+func Linear(initial, increment, max time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		delay := initial + increment*time.Duration(attempt-1)
+		if delay > max {
+			return max
+		}
+
+		return delay
+	}
+}
+
+// Constant always the same delay
+func Constant(delay time.Duration) Backoff {
+	return func(int) time.Duration {
+		return delay
+	}
+}
+
+// Permanent wrap the error to stop the retries
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &permanentError{err}
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// IsPermanent returns true when the error was wrapped with Permanent
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Do call the operation until it succeed or the policy gives up, the last error is returned
+//
+// Each retry is registered as an event in the span of the ctx
+func Do[T any](ctx context.Context, policy *Policy, op Operation[T]) (T, error) {
+	if policy == nil {
+		policy = DefaultPolicy()
+	}
+
+	clk := policy.clock()
+	start := clk.Now()
+	span := trace.SpanFromContext(ctx)
+
+	for attempt := 1; ; attempt++ {
+		result, err := op(ctx)
+		if err == nil {
+			return result, nil
+		}
+
+		if !policy.shouldRetry(ctx, err) {
+			return result, unwrapPermanent(err)
+		}
+
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return result, err
+		}
+
+		delay := policy.delay(attempt)
+		if policy.MaxElapsedTime > 0 && clk.Since(start)+delay > policy.MaxElapsedTime {
+			return result, err
+		}
+
+		span.AddEvent(AttemptEventName, trace.WithAttributes(
+			attribute.Int("retry.attempt", attempt),
+			attribute.String("retry.error", err.Error()),
+			attribute.String("retry.delay", delay.String()),
+		))
+
+		if policy.OnRetry != nil {
+			policy.OnRetry(ctx, attempt, err, delay)
+		}
+
+		timer := clk.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C():
+		}
+	}
+}
+
+// Run same as Do to operations without result
+func Run(ctx context.Context, policy *Policy, op func(ctx context.Context) error) error {
+	_, err := Do(ctx, policy, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, op(ctx)
+	})
+
+	return err
+}
+
+func (p *Policy) clock() clock.Clock {
+	if p.Clock == nil {
+		return clock.New()
+	}
+
+	return p.Clock
+}
+
+func (p *Policy) shouldRetry(ctx context.Context, err error) bool {
+	if IsPermanent(err) || ctx.Err() != nil {
+		return false
+	}
+
+	if p.RetryOn != nil {
+		return p.RetryOn(err)
+	}
+
+	return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+}
+
+func (p *Policy) delay(attempt int) time.Duration {
+	if p.Backoff == nil {
+		return 0
+	}
+
+	delay := p.Backoff(attempt)
+	if p.Jitter <= 0 || delay <= 0 {
+		return delay
+	}
+
+	spread := float64(delay) * math.Min(p.Jitter, 1)
+	return time.Duration(float64(delay) - spread + rand.Float64()*2*spread)
+}
+
+func unwrapPermanent(err error) error {
+	var p *permanentError
+	if errors.As(err, &p) {
+		return p.err
+	}
+
+	return err
+}
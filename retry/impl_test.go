@@ -0,0 +1,148 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type RetryTestSuite struct {
+	suite.Suite
+}
+
+func TestRetryTestSuite(t *testing.T) {
+	suite.Run(t, new(RetryTestSuite))
+}
+
+func (s *RetryTestSuite) policy(attempts int) *Policy {
+	return &Policy{MaxAttempts: attempts, Backoff: Constant(time.Millisecond)}
+}
+
+func (s *RetryTestSuite) TestDo() {
+	calls := 0
+
+	result, err := Do(context.Background(), s.policy(3), func(ctx context.Context) (string, error) {
+		calls++
+		if calls < 3 {
+			return "", errors.New("some error")
+		}
+		return "ok", nil
+	})
+
+	s.NoError(err)
+	s.Equal("ok", result)
+	s.Equal(3, calls)
+}
+
+func (s *RetryTestSuite) TestMaxAttempts() {
+	calls := 0
+
+	err := Run(context.Background(), s.policy(2), func(ctx context.Context) error {
+		calls++
+		return errors.New("some error")
+	})
+
+	s.Error(err)
+	s.Equal(2, calls)
+}
+
+func (s *RetryTestSuite) TestPermanentAndRetryOn() {
+	calls := 0
+	target := errors.New("target")
+
+	err := Run(context.Background(), s.policy(5), func(ctx context.Context) error {
+		calls++
+		return Permanent(target)
+	})
+
+	s.Equal(target, err)
+	s.Equal(1, calls)
+
+	policy := s.policy(5)
+	policy.RetryOn = func(err error) bool { return !errors.Is(err, target) }
+	calls = 0
+
+	err = Run(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		return target
+	})
+
+	s.ErrorIs(err, target)
+	s.Equal(1, calls)
+}
+
+func (s *RetryTestSuite) TestMaxElapsedTime() {
+	policy := &Policy{Backoff: Constant(50 * time.Millisecond), MaxElapsedTime: 10 * time.Millisecond}
+	calls := 0
+
+	Run(context.Background(), policy, func(ctx context.Context) error {
+		calls++
+		return errors.New("some error")
+	})
+
+	s.Equal(1, calls)
+}
+
+func (s *RetryTestSuite) TestClock() {
+	clk := clock.NewFake(time.Now())
+	policy := &Policy{MaxAttempts: 3, Backoff: Constant(time.Hour), Clock: clk}
+	calls := int32(0)
+
+	done := make(chan error)
+	go func() {
+		done <- Run(context.Background(), policy, func(ctx context.Context) error {
+			atomic.AddInt32(&calls, 1)
+			return errors.New("some error")
+		})
+	}()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Hour)
+	clk.BlockUntil(1)
+	clk.Advance(time.Hour)
+
+	s.Error(<-done)
+	s.Equal(int32(3), atomic.LoadInt32(&calls))
+}
+
+func (s *RetryTestSuite) TestBackoff() {
+	exp := Exponential(time.Second, 5*time.Second, 2)
+	s.Equal(time.Second, exp(1))
+	s.Equal(4*time.Second, exp(3))
+	s.Equal(5*time.Second, exp(4))
+
+	linear := Linear(time.Second, time.Second, 2*time.Second)
+	s.Equal(time.Second, linear(1))
+	s.Equal(2*time.Second, linear(3))
+
+	policy := &Policy{Backoff: Constant(time.Second), Jitter: 0.5}
+	for i := 0; i < 10; i++ {
+		delay := policy.delay(1)
+		s.GreaterOrEqual(delay, 500*time.Millisecond)
+		s.LessOrEqual(delay, 1500*time.Millisecond)
+	}
+}
+
+func (s *RetryTestSuite) TestSpanEvents() {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	ctx, span := provider.Tracer("test").Start(context.Background(), "op")
+
+	retries := 0
+	policy := s.policy(3)
+	policy.OnRetry = func(ctx context.Context, attempt int, err error, delay time.Duration) { retries++ }
+
+	Run(ctx, policy, func(ctx context.Context) error { return errors.New("some error") })
+	span.End()
+
+	s.Equal(2, retries)
+	s.Len(recorder.Ended()[0].Events(), 2)
+	s.Equal(AttemptEventName, recorder.Ended()[0].Events()[0].Name)
+}
@@ -0,0 +1,37 @@
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+)
+
+type (
+	// Backoff returns the delay before the attempt, attempt starts in 1 for the first retry
+	Backoff = func(attempt int) time.Duration
+
+	// Policy describe how the operation is retried
+	Policy struct {
+		// MaxAttempts the max number of calls, including the first one. Zero means no limit other than MaxElapsedTime
+		MaxAttempts int
+		Backoff     Backoff
+		// Jitter randomize the delay in the range delay +- delay*Jitter, between 0 and 1
+		Jitter float64
+		// MaxElapsedTime stop retrying after this time since the first call, zero means no limit
+		MaxElapsedTime time.Duration
+		// RetryOn decides which errors are retried, by default all the errors except the Permanent ones and ctx errors
+		RetryOn func(err error) bool
+		// OnRetry called before waiting for the next attempt
+		OnRetry func(ctx context.Context, attempt int, err error, delay time.Duration)
+		// Clock the time source of the delays, nil uses the system clock
+		Clock clock.Clock
+	}
+
+	// Operation the retried function
+	Operation[T any] func(ctx context.Context) (T, error)
+
+	permanentError struct {
+		err error
+	}
+)
@@ -0,0 +1,48 @@
+package saga
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	RUNNING_STATUS      Status = "running"
+	WAITING_STATUS      Status = "waiting"
+	COMPENSATING_STATUS Status = "compensating"
+	COMPLETED_STATUS    Status = "completed"
+	COMPENSATED_STATUS  Status = "compensated"
+	// FAILED_STATUS the compensation failed, manual intervention is required
+	FAILED_STATUS Status = "failed"
+
+	DefaultStepTimeout = 30 * time.Second
+	DefaultTableName   = "sagas"
+
+	// PostgresSchema table used by the sql store
+	PostgresSchema = `CREATE TABLE IF NOT EXISTS %s (
+	id           VARCHAR(64) PRIMARY KEY,
+	name         VARCHAR(255) NOT NULL,
+	status       VARCHAR(32) NOT NULL,
+	current_step INTEGER NOT NULL,
+	data         TEXT,
+	error        TEXT,
+	deadline     TIMESTAMP WITH TIME ZONE,
+	created_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+	updated_at   TIMESTAMP WITH TIME ZONE NOT NULL
+)`
+)
+
+var (
+	ErrorDefinitionName    = errors.New("saga definition name is required")
+	ErrorDefinitionSteps   = errors.New("saga definition requires at least one step")
+	ErrorDefinitionDup     = errors.New("saga definition already registered")
+	ErrorStepAction        = errors.New("saga step action is required")
+	ErrorUnknownDefinition = errors.New("saga definition not registered")
+	ErrorSagaNotFound      = errors.New("saga not found")
+	ErrorSagaNotWaiting    = errors.New("saga is not waiting a step completion")
+	ErrorStepTimeout       = errors.New("saga step timed out")
+	ErrorInvalidReply      = errors.New("saga step reply without the saga id")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::saga] " + msg
+}
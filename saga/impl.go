@@ -0,0 +1,357 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+// New create a saga orchestrator builder
+func New(logger logging.ILogger, store Store) SagaBuilder {
+	return &Orchestrator{
+		logger:      logger,
+		store:       store,
+		definitions: map[string]*Definition{},
+		locks:       map[string]*instanceLock{},
+		timeNow:     time.Now,
+	}
+}
+
+func (o *Orchestrator) Define(def *Definition) SagaBuilder {
+	if o.Err != nil {
+		return o
+	}
+
+	if err := o.validate(def); err != nil {
+		o.logger.Error(LogMessage("invalid saga definition"), logging.MessageField("saga", def.Name), logging.ErrorField(err))
+		o.Err = err
+		return o
+	}
+
+	for _, step := range def.Steps {
+		if step.Timeout == 0 {
+			step.Timeout = DefaultStepTimeout
+		}
+	}
+
+	o.definitions[def.Name] = def
+	return o
+}
+
+func (o *Orchestrator) validate(def *Definition) error {
+	if def.Name == "" {
+		return ErrorDefinitionName
+	}
+
+	if len(def.Steps) == 0 {
+		return ErrorDefinitionSteps
+	}
+
+	if _, ok := o.definitions[def.Name]; ok {
+		return ErrorDefinitionDup
+	}
+
+	for _, step := range def.Steps {
+		if step.Action == nil {
+			return ErrorStepAction
+		}
+	}
+
+	return nil
+}
+
+func (o *Orchestrator) Build() (ISagaOrchestrator, error) {
+	if o.Err != nil {
+		return nil, o.Err
+	}
+
+	if o.store == nil {
+		o.store = NewMemoryStore()
+	}
+
+	return o, nil
+}
+
+func (o *Orchestrator) Start(ctx context.Context, name string, data any) (string, error) {
+	def, ok := o.definitions[name]
+	if !ok {
+		return "", ErrorUnknownDefinition
+	}
+
+	byt, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	now := o.timeNow()
+	instance := &Instance{
+		ID:        uuid.NewString(),
+		Name:      name,
+		Status:    RUNNING_STATUS,
+		Data:      byt,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := o.store.Save(ctx, instance); err != nil {
+		o.logger.Error(LogMessage("failure to save the saga"), logging.ErrorField(err))
+		return "", err
+	}
+
+	unlock := o.lock(instance.ID)
+	defer unlock()
+
+	return instance.ID, o.advance(ctx, def, instance)
+}
+
+func (o *Orchestrator) Complete(ctx context.Context, id string, stepErr error) error {
+	unlock := o.lock(id)
+	defer unlock()
+
+	instance, err := o.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if instance.Status != WAITING_STATUS {
+		return ErrorSagaNotWaiting
+	}
+
+	def, ok := o.definitions[instance.Name]
+	if !ok {
+		return ErrorUnknownDefinition
+	}
+
+	instance.Deadline = nil
+	if stepErr != nil {
+		o.logger.Warn(LogMessage("async step failed, compensating"), logging.MessageField("saga", id), logging.ErrorField(stepErr))
+		instance.Status = COMPENSATING_STATUS
+		instance.Error = stepErr.Error()
+	} else {
+		instance.Status = RUNNING_STATUS
+		instance.CurrentStep++
+	}
+
+	if err := o.save(ctx, instance); err != nil {
+		return err
+	}
+
+	return o.advance(ctx, def, instance)
+}
+
+func (o *Orchestrator) Resume(ctx context.Context) error {
+	instances, err := o.store.ListByStatus(ctx, RUNNING_STATUS, COMPENSATING_STATUS)
+	if err != nil {
+		return err
+	}
+
+	for _, instance := range instances {
+		def, ok := o.definitions[instance.Name]
+		if !ok {
+			o.logger.Warn(LogMessage("resuming saga without definition"), logging.MessageField("saga", instance.ID))
+			continue
+		}
+
+		o.logger.Info(LogMessage(fmt.Sprintf("resuming saga %s in step %d", instance.ID, instance.CurrentStep)))
+
+		unlock := o.lock(instance.ID)
+		err := o.advance(ctx, def, instance)
+		unlock()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Orchestrator) CheckTimeouts(ctx context.Context) error {
+	instances, err := o.store.ListByStatus(ctx, WAITING_STATUS)
+	if err != nil {
+		return err
+	}
+
+	now := o.timeNow()
+	for _, instance := range instances {
+		if instance.Deadline == nil || instance.Deadline.After(now) {
+			continue
+		}
+
+		err := o.Complete(ctx, instance.ID, ErrorStepTimeout)
+		if err != nil && err != ErrorSagaNotWaiting {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Orchestrator) Get(ctx context.Context, id string) (*Instance, error) {
+	return o.store.Get(ctx, id)
+}
+
+func (o *Orchestrator) ReplyHandler() rabbitmq.ConsumerHandler {
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		reply, ok := msg.(*StepReply)
+		if !ok || reply.SagaID == "" {
+			o.logger.Error(LogMessage("invalid step reply"), logging.MessageField("type", fmt.Sprintf("%T", msg)))
+			return ErrorInvalidReply
+		}
+
+		ctx := context.Background()
+		if metadata != nil && metadata.Ctx != nil {
+			ctx = metadata.Ctx
+		}
+
+		var stepErr error
+		if reply.Error != "" {
+			stepErr = errors.New(reply.Error)
+		}
+
+		// the redelivered replies and the replies after the step timeout are acked
+		err := o.Complete(ctx, reply.SagaID, stepErr)
+		if errors.Is(err, ErrorSagaNotWaiting) || errors.Is(err, ErrorSagaNotFound) {
+			o.logger.Warn(LogMessage("step reply ignored"), logging.MessageField("saga", reply.SagaID), logging.ErrorField(err))
+			return nil
+		}
+
+		return err
+	}
+}
+
+// advance run the steps forward while running, and backward while compensating
+func (o *Orchestrator) advance(ctx context.Context, def *Definition, instance *Instance) error {
+	for instance.Status == RUNNING_STATUS {
+		if instance.CurrentStep >= len(def.Steps) {
+			instance.Status = COMPLETED_STATUS
+			o.logger.Info(LogMessage("saga completed"), logging.MessageField("saga", instance.ID))
+			return o.save(ctx, instance)
+		}
+
+		step := def.Steps[instance.CurrentStep]
+		err := o.execute(ctx, step, step.Action, instance)
+
+		switch {
+		case err != nil:
+			o.logger.Warn(LogMessage(fmt.Sprintf("step %s failed, compensating", step.Name)), logging.MessageField("saga", instance.ID), logging.ErrorField(err))
+			instance.Status = COMPENSATING_STATUS
+			instance.Error = err.Error()
+		case step.Async:
+			deadline := o.timeNow().Add(step.Timeout)
+			instance.Status = WAITING_STATUS
+			instance.Deadline = &deadline
+		default:
+			instance.CurrentStep++
+		}
+
+		if err := o.save(ctx, instance); err != nil {
+			return err
+		}
+	}
+
+	for instance.Status == COMPENSATING_STATUS {
+		if instance.CurrentStep == 0 {
+			instance.Status = COMPENSATED_STATUS
+			o.logger.Info(LogMessage("saga compensated"), logging.MessageField("saga", instance.ID))
+			return o.save(ctx, instance)
+		}
+
+		step := def.Steps[instance.CurrentStep-1]
+		if step.Compensate != nil {
+			if err := o.execute(ctx, step, step.Compensate, instance); err != nil {
+				o.logger.Error(LogMessage(fmt.Sprintf("step %s compensation failed", step.Name)), logging.MessageField("saga", instance.ID), logging.ErrorField(err))
+				instance.Status = FAILED_STATUS
+				instance.Error = err.Error()
+				return o.save(ctx, instance)
+			}
+		}
+
+		instance.CurrentStep--
+		if err := o.save(ctx, instance); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Orchestrator) execute(ctx context.Context, step *Step, fn StepFunc, instance *Instance) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, step.Timeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("saga step panicked: %v", r)
+		}
+	}()
+
+	if err := fn(ctx, instance); err != nil {
+		return err
+	}
+
+	if ctx.Err() == context.DeadlineExceeded {
+		return ErrorStepTimeout
+	}
+
+	return nil
+}
+
+func (o *Orchestrator) save(ctx context.Context, instance *Instance) error {
+	instance.UpdatedAt = o.timeNow()
+
+	if err := o.store.Save(ctx, instance); err != nil {
+		o.logger.Error(LogMessage("failure to save the saga"), logging.MessageField("saga", instance.ID), logging.ErrorField(err))
+		return err
+	}
+
+	return nil
+}
+
+// lock serialize the progress of the same instance inside the process, the lock is removed after the last unlock so
+// the finished sagas do not retain it
+func (o *Orchestrator) lock(id string) func() {
+	o.locksMu.Lock()
+	l, ok := o.locks[id]
+	if !ok {
+		l = &instanceLock{}
+		o.locks[id] = l
+	}
+	l.refs++
+	o.locksMu.Unlock()
+
+	l.mu.Lock()
+
+	return func() {
+		l.mu.Unlock()
+
+		o.locksMu.Lock()
+		defer o.locksMu.Unlock()
+
+		if l.refs--; l.refs == 0 {
+			delete(o.locks, id)
+		}
+	}
+}
+
+// Decode unmarshal the saga data into v
+func (i *Instance) Decode(v any) error {
+	return json.Unmarshal(i.Data, v)
+}
+
+// Set replace the saga data, it is persisted after the step
+func (i *Instance) Set(v any) error {
+	byt, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	i.Data = byt
+	return nil
+}
@@ -0,0 +1,186 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/suite"
+)
+
+type SagaTestSuite struct {
+	suite.Suite
+
+	calls []string
+}
+
+type order struct {
+	ID      string
+	Charged bool
+}
+
+func TestSagaTestSuite(t *testing.T) {
+	suite.Run(t, new(SagaTestSuite))
+}
+
+func (s *SagaTestSuite) SetupTest() {
+	s.calls = []string{}
+}
+
+func (s *SagaTestSuite) step(name string, actionErr error, async bool) *Step {
+	return &Step{
+		Name:  name,
+		Async: async,
+		Action: func(ctx context.Context, instance *Instance) error {
+			s.calls = append(s.calls, name)
+			return actionErr
+		},
+		Compensate: func(ctx context.Context, instance *Instance) error {
+			s.calls = append(s.calls, "undo-"+name)
+			return nil
+		},
+	}
+}
+
+func (s *SagaTestSuite) TestCompleted() {
+	charge := &Step{
+		Name: "charge",
+		Action: func(ctx context.Context, instance *Instance) error {
+			o := &order{}
+			instance.Decode(o)
+			o.Charged = true
+			return instance.Set(o)
+		},
+	}
+
+	orch, err := New(logging.NewMockLogger(), nil).
+		Define(&Definition{Name: "order", Steps: []*Step{s.step("reserve", nil, false), charge}}).
+		Build()
+	s.NoError(err)
+
+	id, err := orch.Start(context.Background(), "order", &order{ID: "1"})
+	s.NoError(err)
+
+	instance, _ := orch.Get(context.Background(), id)
+	s.Equal(COMPLETED_STATUS, instance.Status)
+
+	o := &order{}
+	s.NoError(instance.Decode(o))
+	s.True(o.Charged)
+}
+
+func (s *SagaTestSuite) TestCompensation() {
+	orch, _ := New(logging.NewMockLogger(), NewMemoryStore()).
+		Define(&Definition{Name: "order", Steps: []*Step{
+			s.step("reserve", nil, false),
+			s.step("charge", nil, false),
+			s.step("ship", errors.New("some error"), false),
+		}}).
+		Build()
+
+	id, err := orch.Start(context.Background(), "order", nil)
+	s.NoError(err)
+
+	instance, _ := orch.Get(context.Background(), id)
+	s.Equal(COMPENSATED_STATUS, instance.Status)
+	s.Equal("some error", instance.Error)
+	s.Equal([]string{"reserve", "charge", "ship", "undo-charge", "undo-reserve"}, s.calls)
+}
+
+func (s *SagaTestSuite) TestAsyncStep() {
+	orch, _ := New(logging.NewMockLogger(), nil).
+		Define(&Definition{Name: "order", Steps: []*Step{
+			s.step("reserve", nil, true),
+			s.step("charge", nil, false),
+		}}).
+		Build()
+
+	id, _ := orch.Start(context.Background(), "order", nil)
+
+	instance, _ := orch.Get(context.Background(), id)
+	s.Equal(WAITING_STATUS, instance.Status)
+	s.NotNil(instance.Deadline)
+
+	s.NoError(orch.Complete(context.Background(), id, nil))
+
+	instance, _ = orch.Get(context.Background(), id)
+	s.Equal(COMPLETED_STATUS, instance.Status)
+	s.ErrorIs(orch.Complete(context.Background(), id, nil), ErrorSagaNotWaiting)
+	s.Empty(orch.(*Orchestrator).locks)
+}
+
+func (s *SagaTestSuite) TestReplyHandler() {
+	orch, _ := New(logging.NewMockLogger(), nil).
+		Define(&Definition{Name: "order", Steps: []*Step{
+			s.step("reserve", nil, false),
+			s.step("charge", nil, true),
+		}}).
+		Build()
+
+	handler := orch.ReplyHandler()
+
+	id, _ := orch.Start(context.Background(), "order", nil)
+	s.NoError(handler(&StepReply{SagaID: id, Error: "insufficient funds"}, &rabbitmq.DeliveryMetadata{}))
+
+	instance, _ := orch.Get(context.Background(), id)
+	s.Equal(COMPENSATED_STATUS, instance.Status)
+	s.Equal("insufficient funds", instance.Error)
+
+	// the redelivered reply is acked
+	s.NoError(handler(&StepReply{SagaID: id}, &rabbitmq.DeliveryMetadata{}))
+	s.NoError(handler(&StepReply{SagaID: "unknown"}, nil))
+	s.ErrorIs(handler(&StepReply{}, nil), ErrorInvalidReply)
+	s.ErrorIs(handler(&order{}, nil), ErrorInvalidReply)
+}
+
+func (s *SagaTestSuite) TestTimeout() {
+	builder := New(logging.NewMockLogger(), nil).
+		Define(&Definition{Name: "order", Steps: []*Step{
+			s.step("reserve", nil, false),
+			s.step("charge", nil, true),
+		}})
+	orch, _ := builder.Build()
+
+	id, _ := orch.Start(context.Background(), "order", nil)
+
+	builder.(*Orchestrator).timeNow = func() time.Time { return time.Now().Add(time.Hour) }
+	s.NoError(orch.CheckTimeouts(context.Background()))
+
+	instance, _ := orch.Get(context.Background(), id)
+	s.Equal(COMPENSATED_STATUS, instance.Status)
+	s.Equal(ErrorStepTimeout.Error(), instance.Error)
+	s.Equal([]string{"reserve", "charge", "undo-reserve"}, s.calls)
+}
+
+func (s *SagaTestSuite) TestResume() {
+	store := NewMemoryStore()
+	store.Save(context.Background(), &Instance{ID: "id", Name: "order", Status: RUNNING_STATUS, CurrentStep: 1})
+
+	orch, _ := New(logging.NewMockLogger(), store).
+		Define(&Definition{Name: "order", Steps: []*Step{
+			s.step("reserve", nil, false),
+			s.step("charge", nil, false),
+		}}).
+		Build()
+
+	s.NoError(orch.Resume(context.Background()))
+
+	instance, _ := orch.Get(context.Background(), "id")
+	s.Equal(COMPLETED_STATUS, instance.Status)
+	s.Equal([]string{"charge"}, s.calls)
+}
+
+func (s *SagaTestSuite) TestBuildErr() {
+	_, err := New(logging.NewMockLogger(), nil).Define(&Definition{Name: "order"}).Build()
+	s.ErrorIs(err, ErrorDefinitionSteps)
+
+	_, err = New(logging.NewMockLogger(), nil).Define(&Definition{Name: "order", Steps: []*Step{{Name: "step"}}}).Build()
+	s.ErrorIs(err, ErrorStepAction)
+
+	orch, _ := New(logging.NewMockLogger(), nil).Build()
+	_, err = orch.Start(context.Background(), "unknown", nil)
+	s.ErrorIs(err, ErrorUnknownDefinition)
+}
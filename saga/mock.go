@@ -0,0 +1,83 @@
+package saga
+
+import (
+	"context"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockSagaOrchestrator struct {
+		mock.Mock
+	}
+
+	MockStore struct {
+		mock.Mock
+	}
+)
+
+func (m *MockSagaOrchestrator) Start(ctx context.Context, name string, data any) (string, error) {
+	args := m.Called(ctx, name, data)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockSagaOrchestrator) ReplyHandler() rabbitmq.ConsumerHandler {
+	args := m.Called()
+
+	return args.Get(0).(rabbitmq.ConsumerHandler)
+}
+
+func (m *MockSagaOrchestrator) Complete(ctx context.Context, id string, stepErr error) error {
+	args := m.Called(ctx, id, stepErr)
+
+	return args.Error(0)
+}
+
+func (m *MockSagaOrchestrator) Resume(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (m *MockSagaOrchestrator) CheckTimeouts(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (m *MockSagaOrchestrator) Get(ctx context.Context, id string) (*Instance, error) {
+	args := m.Called(ctx, id)
+
+	instance, _ := args.Get(0).(*Instance)
+	return instance, args.Error(1)
+}
+
+func (m *MockStore) Save(ctx context.Context, instance *Instance) error {
+	args := m.Called(ctx, instance)
+
+	return args.Error(0)
+}
+
+func (m *MockStore) Get(ctx context.Context, id string) (*Instance, error) {
+	args := m.Called(ctx, id)
+
+	instance, _ := args.Get(0).(*Instance)
+	return instance, args.Error(1)
+}
+
+func (m *MockStore) ListByStatus(ctx context.Context, statuses ...Status) ([]*Instance, error) {
+	args := m.Called(ctx, statuses)
+
+	instances, _ := args.Get(0).([]*Instance)
+	return instances, args.Error(1)
+}
+
+func NewMockSagaOrchestrator() *MockSagaOrchestrator {
+	return new(MockSagaOrchestrator)
+}
+
+func NewMockStore() *MockStore {
+	return new(MockStore)
+}
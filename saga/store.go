@@ -0,0 +1,165 @@
+package saga
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	gokitSql "github.com/ralvescosta/gokit/sql"
+)
+
+// NewMemoryStore in memory store, the sagas can not be resumed after a crash
+func NewMemoryStore() Store {
+	return &memoryStore{instances: map[string]*Instance{}}
+}
+
+func (s *memoryStore) Save(_ context.Context, instance *Instance) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp := *instance
+	s.instances[instance.ID] = &cp
+
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, id string) (*Instance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	instance, ok := s.instances[id]
+	if !ok {
+		return nil, ErrorSagaNotFound
+	}
+
+	cp := *instance
+	return &cp, nil
+}
+
+func (s *memoryStore) ListByStatus(_ context.Context, statuses ...Status) ([]*Instance, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []*Instance{}
+	for _, instance := range s.instances {
+		for _, status := range statuses {
+			if instance.Status == status {
+				cp := *instance
+				result = append(result, &cp)
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// NewSqlStore PostgreSQL store, the table could be created with the PostgresSchema
+//
+// The queries use the transaction carried in the ctx when there is one, see gokit sql.WithTx, so the saga state can be
+// saved together with the step writes
+func NewSqlStore(db *sql.DB, table string) Store {
+	if table == "" {
+		table = DefaultTableName
+	}
+
+	return &sqlStore{gokitSql.NewRepository(db), table}
+}
+
+// Migrate create the saga table if it does not exist
+func Migrate(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultTableName
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(PostgresSchema, table))
+	return err
+}
+
+func (s *sqlStore) Save(ctx context.Context, instance *Instance) error {
+	query := fmt.Sprintf(`INSERT INTO %s (id, name, status, current_step, data, error, deadline, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET status = $3, current_step = $4, data = $5, error = $6, deadline = $7, updated_at = $9`, s.table)
+
+	_, err := s.Querier(ctx).ExecContext(ctx, query,
+		instance.ID,
+		instance.Name,
+		instance.Status,
+		instance.CurrentStep,
+		string(instance.Data),
+		instance.Error,
+		instance.Deadline,
+		instance.CreatedAt,
+		instance.UpdatedAt,
+	)
+
+	return err
+}
+
+func (s *sqlStore) Get(ctx context.Context, id string) (*Instance, error) {
+	query := fmt.Sprintf("SELECT id, name, status, current_step, data, error, deadline, created_at, updated_at FROM %s WHERE id = $1", s.table)
+
+	instance, err := scan(s.Querier(ctx).QueryRowContext(ctx, query, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrorSagaNotFound
+	}
+
+	return instance, err
+}
+
+func (s *sqlStore) ListByStatus(ctx context.Context, statuses ...Status) ([]*Instance, error) {
+	if len(statuses) == 0 {
+		return []*Instance{}, nil
+	}
+
+	placeholders := make([]string, 0, len(statuses))
+	args := make([]any, 0, len(statuses))
+	for i, status := range statuses {
+		placeholders = append(placeholders, fmt.Sprintf("$%d", i+1))
+		args = append(args, status)
+	}
+
+	query := fmt.Sprintf(
+		"SELECT id, name, status, current_step, data, error, deadline, created_at, updated_at FROM %s WHERE status IN (%s) ORDER BY created_at",
+		s.table,
+		strings.Join(placeholders, ", "),
+	)
+
+	rows, err := s.Querier(ctx).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []*Instance{}
+	for rows.Next() {
+		instance, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, instance)
+	}
+
+	return result, rows.Err()
+}
+
+func scan(row interface{ Scan(dest ...any) error }) (*Instance, error) {
+	instance := &Instance{}
+	var data, errMsg sql.NullString
+	var deadline sql.NullTime
+
+	err := row.Scan(&instance.ID, &instance.Name, &instance.Status, &instance.CurrentStep, &data, &errMsg, &deadline, &instance.CreatedAt, &instance.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	instance.Data = []byte(data.String)
+	instance.Error = errMsg.String
+	if deadline.Valid {
+		instance.Deadline = &deadline.Time
+	}
+
+	return instance, nil
+}
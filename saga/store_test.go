@@ -0,0 +1,86 @@
+package saga
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	gokitSql "github.com/ralvescosta/gokit/sql"
+)
+
+type SqlStoreTestSuite struct {
+	suite.Suite
+}
+
+func TestSqlStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlStoreTestSuite))
+}
+
+func (s *SqlStoreTestSuite) TestSave() {
+	db, sqlMock, _ := sqlmock.New()
+	store := NewSqlStore(db, "")
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO sagas")).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	s.NoError(store.Save(context.Background(), &Instance{ID: "id", Name: "order", Status: RUNNING_STATUS}))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *SqlStoreTestSuite) TestGet() {
+	db, sqlMock, _ := sqlmock.New()
+	store := NewSqlStore(db, "")
+	columns := []string{"id", "name", "status", "current_step", "data", "error", "deadline", "created_at", "updated_at"}
+
+	sqlMock.ExpectQuery("SELECT (.+) FROM sagas WHERE id").
+		WithArgs("id").
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("id", "order", "waiting", 1, "{}", nil, time.Now(), time.Now(), time.Now()))
+
+	instance, err := store.Get(context.Background(), "id")
+	s.NoError(err)
+	s.Equal(WAITING_STATUS, instance.Status)
+	s.NotNil(instance.Deadline)
+
+	sqlMock.ExpectQuery("SELECT (.+) FROM sagas WHERE id").WillReturnRows(sqlmock.NewRows(columns))
+
+	_, err = store.Get(context.Background(), "id")
+	s.ErrorIs(err, ErrorSagaNotFound)
+}
+
+func (s *SqlStoreTestSuite) TestListByStatus() {
+	db, sqlMock, _ := sqlmock.New()
+	store := NewSqlStore(db, "")
+	columns := []string{"id", "name", "status", "current_step", "data", "error", "deadline", "created_at", "updated_at"}
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta("WHERE status IN ($1, $2)")).
+		WithArgs(RUNNING_STATUS, COMPENSATING_STATUS).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow("id", "order", "running", 0, "{}", "", nil, time.Now(), time.Now()))
+
+	instances, err := store.ListByStatus(context.Background(), RUNNING_STATUS, COMPENSATING_STATUS)
+	s.NoError(err)
+	s.Len(instances, 1)
+	s.Nil(instances[0].Deadline)
+
+	instances, err = store.ListByStatus(context.Background())
+	s.NoError(err)
+	s.Empty(instances)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *SqlStoreTestSuite) TestSaveInTx() {
+	db, sqlMock, _ := sqlmock.New()
+	store := NewSqlStore(db, "")
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("INSERT INTO sagas")).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectCommit()
+
+	err := gokitSql.WithTx(context.Background(), db, func(ctx context.Context) error {
+		return store.Save(ctx, &Instance{ID: "id", Name: "order", Status: RUNNING_STATUS})
+	})
+	s.NoError(err)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
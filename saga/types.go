@@ -0,0 +1,112 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	gokitSql "github.com/ralvescosta/gokit/sql"
+)
+
+type (
+	Status string
+
+	// StepFunc executes the step action or compensation, changes in the instance data are persisted
+	StepFunc = func(ctx context.Context, instance *Instance) error
+
+	// Step a saga step, the compensation undo the action when a next step fails
+	Step struct {
+		Name       string
+		Action     StepFunc
+		Compensate StepFunc
+		// Async when true the action only sends a command, the saga waits until Complete is called with the step result or
+		// the ReplyHandler receives the StepReply, the action gets the saga id to send in the command from instance.ID
+		Async bool
+		// Timeout the action timeout, for async steps it is also the max time waiting the completion
+		Timeout time.Duration
+	}
+
+	// Definition the ordered steps of a saga
+	Definition struct {
+		Name  string
+		Steps []*Step
+	}
+
+	// Instance a saga execution
+	Instance struct {
+		ID          string
+		Name        string
+		Status      Status
+		CurrentStep int
+		Data        json.RawMessage
+		Error       string
+		Deadline    *time.Time
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+	}
+
+	// Store persist the saga instances, allowing the resumption after crashes
+	Store interface {
+		Save(ctx context.Context, instance *Instance) error
+		Get(ctx context.Context, id string) (*Instance, error)
+		ListByStatus(ctx context.Context, statuses ...Status) ([]*Instance, error)
+	}
+
+	SagaBuilder interface {
+		// Define register a saga definition
+		Define(def *Definition) SagaBuilder
+		Build() (ISagaOrchestrator, error)
+	}
+
+	ISagaOrchestrator interface {
+		// Start create a new instance of the saga and run the steps until an async step or the end
+		Start(ctx context.Context, name string, data any) (string, error)
+		// Complete resume a saga waiting an async step, a non nil stepErr starts the compensation
+		Complete(ctx context.Context, id string, stepErr error) error
+		// Resume continue the running and compensating sagas, should be called on start up to recover from crashes
+		Resume(ctx context.Context) error
+		// CheckTimeouts compensate the sagas waiting an async step longer than the step timeout
+		CheckTimeouts(ctx context.Context) error
+		// Get returns the saga instance
+		Get(ctx context.Context, id string) (*Instance, error)
+		// ReplyHandler the consumer handler to the StepReply messages, it completes the async steps, e.g:
+		// messaging.RegisterDispatcher("order-saga-replies", orchestrator.ReplyHandler(), &saga.StepReply{})
+		ReplyHandler() rabbitmq.ConsumerHandler
+	}
+
+	// StepReply the message published by the participant of an async step with the step result, a non empty Error starts
+	// the compensation
+	StepReply struct {
+		SagaID string `json:"saga_id"`
+		Error  string `json:"error,omitempty"`
+	}
+
+	Orchestrator struct {
+		Err         error
+		logger      logging.ILogger
+		store       Store
+		definitions map[string]*Definition
+		locksMu     sync.Mutex
+		locks       map[string]*instanceLock
+		timeNow     func() time.Time
+	}
+
+	// instanceLock the refs count the holders and the waiters, the lock is removed when there is none
+	instanceLock struct {
+		mu   sync.Mutex
+		refs int
+	}
+
+	memoryStore struct {
+		mu        sync.RWMutex
+		instances map[string]*Instance
+	}
+
+	sqlStore struct {
+		gokitSql.Repository
+		table string
+	}
+)
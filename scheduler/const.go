@@ -0,0 +1,34 @@
+package scheduler
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// SKIP_OVERLAP a new run is skipped while the previous one is still running
+	SKIP_OVERLAP OverlapPolicy = 0
+	// ALLOW_OVERLAP runs are started even if the previous one is still running
+	ALLOW_OVERLAP OverlapPolicy = 1
+	// DELAY_OVERLAP the run waits the previous one to finish
+	DELAY_OVERLAP OverlapPolicy = 2
+
+	DefaultJobTimeout = 1 * time.Minute
+	LockKeyPrefix     = "gokit-scheduler-"
+	TracerName        = "github.com/ralvescosta/gokit/scheduler"
+	// PoolName the name of the jobs pool metrics, see pool.New
+	PoolName = "scheduler"
+)
+
+var (
+	ErrorJobName          = errors.New("job name is required")
+	ErrorJobHandler       = errors.New("job handler is required")
+	ErrorJobSchedule      = errors.New("job requires a cron expression or an interval")
+	ErrorJobDuplicated    = errors.New("job already registered")
+	ErrorLockerRequired   = errors.New("single runner jobs require a locker")
+	ErrorSchedulerStopped = errors.New("scheduler stopped")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::scheduler] " + msg
+}
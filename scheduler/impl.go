@@ -0,0 +1,321 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/pool"
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// New create a scheduler builder
+func New(logger logging.ILogger) SchedulerBuilder {
+	return &Scheduler{
+		logger: logger,
+		jobs:   []*scheduledJob{},
+		clock:  clock.New(),
+	}
+}
+
+func (s *Scheduler) WithLocker(locker Locker) SchedulerBuilder {
+	s.locker = locker
+	return s
+}
+
+func (s *Scheduler) WithTracing() SchedulerBuilder {
+	s.withTracing = true
+	return s
+}
+
+func (s *Scheduler) WithClock(c clock.Clock) SchedulerBuilder {
+	s.clock = c
+	return s
+}
+
+func (s *Scheduler) Job(job *Job) SchedulerBuilder {
+	if s.Err != nil {
+		return s
+	}
+
+	if _, err := s.register(job); err != nil {
+		s.Err = err
+	}
+
+	return s
+}
+
+func (s *Scheduler) Schedule(job *Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		return ErrorSchedulerStopped
+	}
+
+	j, err := s.register(job)
+	if err != nil {
+		return err
+	}
+
+	if s.stop != nil {
+		s.logger.Debug(LogMessage(fmt.Sprintf("scheduling job: %s", j.Name)))
+		s.wg.Add(1)
+		go s.loop(j)
+	}
+
+	return nil
+}
+
+func (s *Scheduler) register(job *Job) (*scheduledJob, error) {
+	if err := s.validate(job); err != nil {
+		s.logger.Error(LogMessage("invalid job"), logging.MessageField("job", job.Name), logging.ErrorField(err))
+		return nil, err
+	}
+
+	var schedule cron.Schedule
+	if job.Cron != "" {
+		sch, err := cron.ParseStandard(job.Cron)
+		if err != nil {
+			s.logger.Error(LogMessage("invalid cron expression"), logging.MessageField("job", job.Name), logging.ErrorField(err))
+			return nil, err
+		}
+		schedule = sch
+	} else {
+		schedule = intervalSchedule{job.Every}
+	}
+
+	if job.Timeout == 0 {
+		job.Timeout = DefaultJobTimeout
+	}
+
+	j := &scheduledJob{Job: job, schedule: schedule}
+	s.jobs = append(s.jobs, j)
+
+	return j, nil
+}
+
+func (s *Scheduler) validate(job *Job) error {
+	if job.Name == "" {
+		return ErrorJobName
+	}
+
+	if job.Handler == nil {
+		return ErrorJobHandler
+	}
+
+	if job.Cron == "" && job.Every <= 0 {
+		return ErrorJobSchedule
+	}
+
+	if job.SingleRunner && s.locker == nil {
+		return ErrorLockerRequired
+	}
+
+	for _, j := range s.jobs {
+		if j.Name == job.Name {
+			return ErrorJobDuplicated
+		}
+	}
+
+	return nil
+}
+
+func (i intervalSchedule) Next(t time.Time) time.Time {
+	return t.Add(i.every)
+}
+
+func (s *Scheduler) Build() (IScheduler, error) {
+	if s.Err != nil {
+		return nil, s.Err
+	}
+
+	if s.withTracing {
+		s.tracer = otel.Tracer(TracerName)
+	}
+
+	return s, nil
+}
+
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopped {
+		s.logger.Warn(LogMessage("scheduler already stopped"))
+		return
+	}
+
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	s.stop = make(chan struct{})
+	s.pool = pool.New(PoolName, &pool.Opts{Kind: pool.SCHEDULER_KIND, Workers: s.workers(), Now: s.clock.Now})
+
+	for _, j := range s.jobs {
+		s.logger.Debug(LogMessage(fmt.Sprintf("scheduling job: %s", j.Name)))
+		s.wg.Add(1)
+		go s.loop(j)
+	}
+
+	s.logger.Info(LogMessage("scheduler started"))
+}
+
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.mu.Lock()
+	if s.stopped || s.stop == nil {
+		s.stopped = true
+		s.mu.Unlock()
+		return nil
+	}
+
+	// the runtime jobs are rejected from now on, so the wg is not increased by new loops
+	s.stopped = true
+	s.mu.Unlock()
+
+	s.logger.Info(LogMessage("draining scheduler..."))
+	close(s.stop)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	defer s.pool.Close()
+
+	select {
+	case <-done:
+		s.cancel()
+		s.logger.Info(LogMessage("scheduler drained"))
+		return nil
+	case <-ctx.Done():
+		s.logger.Warn(LogMessage("scheduler drain timed out, canceling the running jobs"))
+		s.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (s *Scheduler) loop(j *scheduledJob) {
+	defer s.wg.Done()
+
+	for {
+		now := s.clock.Now()
+		timer := s.clock.NewTimer(j.schedule.Next(now).Sub(now))
+
+		select {
+		case <-s.stop:
+			timer.Stop()
+			return
+		case <-timer.C():
+		}
+
+		// the delayed runs wait the previous one in the pool queue
+		ticket := s.pool.Enqueue()
+
+		switch j.Overlap {
+		case ALLOW_OVERLAP:
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer s.pool.Start(ticket)()
+				s.run(j)
+			}()
+		case DELAY_OVERLAP:
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				j.running.Lock()
+				defer j.running.Unlock()
+				defer s.pool.Start(ticket)()
+				s.run(j)
+			}()
+		default:
+			if !atomic.CompareAndSwapInt32(&j.active, 0, 1) {
+				s.pool.Reject(ticket)
+				s.logger.Warn(LogMessage("skipping run, previous run still running"), logging.MessageField("job", j.Name))
+				continue
+			}
+
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer atomic.StoreInt32(&j.active, 0)
+				defer s.pool.Start(ticket)()
+				s.run(j)
+			}()
+		}
+	}
+}
+
+// workers the jobs run one at a time unless they allow overlap, so the pool is unbounded when any job allows it
+func (s *Scheduler) workers() int {
+	for _, j := range s.jobs {
+		if j.Overlap == ALLOW_OVERLAP {
+			return 0
+		}
+	}
+
+	return len(s.jobs)
+}
+
+func (s *Scheduler) run(j *scheduledJob) {
+	ctx, cancel := context.WithTimeout(s.ctx, j.Timeout)
+	defer cancel()
+
+	if j.SingleRunner {
+		unlock, acquired, err := s.locker.TryLock(ctx, LockKeyPrefix+j.Name, j.Timeout)
+		if err != nil {
+			s.logger.Error(LogMessage("failure to acquire the job lock"), logging.MessageField("job", j.Name), logging.ErrorField(err))
+			return
+		}
+
+		if !acquired {
+			s.logger.Debug(LogMessage(fmt.Sprintf("job %s is running in other instance", j.Name)))
+			return
+		}
+
+		defer func() {
+			if err := unlock(context.Background()); err != nil {
+				s.logger.Warn(LogMessage("failure to release the job lock"), logging.MessageField("job", j.Name), logging.ErrorField(err))
+			}
+		}()
+	}
+
+	var span trace.Span
+	if s.tracer != nil {
+		ctx, span = s.tracer.Start(ctx, "job "+j.Name, trace.WithAttributes(attribute.String("job.name", j.Name)))
+		defer span.End()
+	}
+
+	start := s.clock.Now()
+	err := s.safeRun(ctx, j)
+
+	if span != nil && err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	if err != nil {
+		s.logger.Error(LogMessage("job failure"), logging.MessageField("job", j.Name), logging.ErrorField(err))
+		return
+	}
+
+	s.logger.Debug(LogMessage(fmt.Sprintf("job %s executed in %s", j.Name, s.clock.Since(start))))
+}
+
+func (s *Scheduler) safeRun(ctx context.Context, j *scheduledJob) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+
+	return j.Handler(ctx)
+}
@@ -0,0 +1,221 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/clock"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/pool"
+	pg "github.com/ralvescosta/gokit/sql/postgres"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, new(SchedulerTestSuite))
+}
+
+func (s *SchedulerTestSuite) TestBuildErr() {
+	_, err := New(logging.NewMockLogger()).Job(&Job{Name: "job"}).Build()
+	s.ErrorIs(err, ErrorJobHandler)
+
+	handler := func(ctx context.Context) error { return nil }
+
+	_, err = New(logging.NewMockLogger()).Job(&Job{Name: "job", Handler: handler}).Build()
+	s.ErrorIs(err, ErrorJobSchedule)
+
+	_, err = New(logging.NewMockLogger()).Job(&Job{Name: "job", Cron: "invalid", Handler: handler}).Build()
+	s.Error(err)
+
+	_, err = New(logging.NewMockLogger()).Job(&Job{Name: "job", Every: time.Second, SingleRunner: true, Handler: handler}).Build()
+	s.ErrorIs(err, ErrorLockerRequired)
+}
+
+func (s *SchedulerTestSuite) TestRunEvery() {
+	var runs int32
+
+	sch, err := New(logging.NewMockLogger()).
+		WithTracing().
+		Job(&Job{Name: "job", Cron: "@every 1h", Handler: func(ctx context.Context) error { return nil }}).
+		Job(&Job{Name: "every", Every: 10 * time.Millisecond, Handler: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		}}).
+		Build()
+	s.NoError(err)
+
+	sch.Start()
+	time.Sleep(55 * time.Millisecond)
+	s.NoError(sch.Shutdown(context.Background()))
+
+	s.GreaterOrEqual(atomic.LoadInt32(&runs), int32(3))
+}
+
+func (s *SchedulerTestSuite) TestClock() {
+	clk := clock.NewFake(time.Now())
+	runs := make(chan struct{}, 1)
+
+	sch, err := New(logging.NewMockLogger()).
+		WithClock(clk).
+		Job(&Job{Name: "hourly", Every: time.Hour, Handler: func(ctx context.Context) error {
+			runs <- struct{}{}
+			return nil
+		}}).
+		Build()
+	s.NoError(err)
+
+	sch.Start()
+
+	clk.BlockUntil(1)
+	s.Len(runs, 0)
+	clk.Advance(time.Hour)
+	<-runs
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Hour)
+	<-runs
+
+	s.NoError(sch.Shutdown(context.Background()))
+}
+
+func (s *SchedulerTestSuite) TestSkipOverlap() {
+	var runs int32
+
+	sch, _ := New(logging.NewMockLogger()).
+		Job(&Job{Name: "slow", Every: 5 * time.Millisecond, Handler: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			time.Sleep(40 * time.Millisecond)
+			return nil
+		}}).
+		Build()
+
+	sch.Start()
+	time.Sleep(30 * time.Millisecond)
+	s.NoError(sch.Shutdown(context.Background()))
+
+	s.Equal(int32(1), atomic.LoadInt32(&runs))
+}
+
+func (s *SchedulerTestSuite) TestPoolStats() {
+	clk := clock.NewFake(time.Now())
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+
+	sch, _ := New(logging.NewMockLogger()).
+		WithClock(clk).
+		Job(&Job{Name: "slow", Every: time.Hour, Handler: func(ctx context.Context) error {
+			started <- struct{}{}
+			<-release
+			return nil
+		}}).
+		Build()
+
+	sch.Start()
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Hour)
+	<-started
+
+	clk.BlockUntil(1)
+	clk.Advance(time.Hour)
+
+	expected := pool.Stats{Name: PoolName, Kind: pool.SCHEDULER_KIND, Workers: 1, Busy: 1, Rejected: 1}
+	s.Eventually(func() bool {
+		all := pool.All()
+		return len(all) == 1 && all[0] == expected
+	}, time.Second, time.Millisecond)
+
+	close(release)
+	s.NoError(sch.Shutdown(context.Background()))
+	s.Empty(pool.All())
+}
+
+func (s *SchedulerTestSuite) TestSingleRunner() {
+	var runs int32
+	locker := NewMockLocker()
+	locker.On("TryLock", mock.Anything, LockKeyPrefix+"locked", mock.Anything).Return(nil, false, nil)
+
+	sch, _ := New(logging.NewMockLogger()).
+		WithLocker(locker).
+		Job(&Job{Name: "locked", Every: 5 * time.Millisecond, SingleRunner: true, Handler: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		}}).
+		Build()
+
+	sch.Start()
+	time.Sleep(20 * time.Millisecond)
+	sch.Shutdown(context.Background())
+
+	s.Equal(int32(0), atomic.LoadInt32(&runs))
+	locker.AssertExpectations(s.T())
+}
+
+func (s *SchedulerTestSuite) TestShutdownTimeout() {
+	sch, _ := New(logging.NewMockLogger()).
+		Job(&Job{Name: "blocked", Every: time.Millisecond, Handler: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}}).
+		Build()
+
+	sch.Start()
+	time.Sleep(10 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	s.ErrorIs(sch.Shutdown(ctx), context.DeadlineExceeded)
+}
+
+func (s *SchedulerTestSuite) TestSchedule() {
+	var runs int32
+
+	sch, _ := New(logging.NewMockLogger()).Build()
+	sch.Start()
+
+	err := sch.Schedule(&Job{Name: "runtime", Every: 5 * time.Millisecond, Handler: func(ctx context.Context) error {
+		atomic.AddInt32(&runs, 1)
+		return nil
+	}})
+	s.NoError(err)
+	s.ErrorIs(sch.Schedule(&Job{Name: "runtime", Every: time.Second, Handler: func(ctx context.Context) error { return nil }}), ErrorJobDuplicated)
+
+	s.Eventually(func() bool { return atomic.LoadInt32(&runs) > 0 }, time.Second, time.Millisecond)
+	s.NoError(sch.Shutdown(context.Background()))
+
+	err = sch.Schedule(&Job{Name: "stopped", Every: time.Second, Handler: func(ctx context.Context) error { return nil }})
+	s.ErrorIs(err, ErrorSchedulerStopped)
+	s.NoError(sch.Shutdown(context.Background()))
+}
+
+func (s *SchedulerTestSuite) TestPostgresLocker() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	key := pg.AdvisoryKey(LockKeyPrefix + "job")
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(true))
+	sqlMock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(key).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(false))
+
+	locker := NewPostgresLocker(db)
+
+	unlock, acquired, err := locker.TryLock(context.Background(), LockKeyPrefix+"job", time.Second)
+	s.NoError(err)
+	s.True(acquired)
+	s.NoError(unlock(context.Background()))
+
+	unlock, acquired, err = locker.TryLock(context.Background(), LockKeyPrefix+"job", time.Second)
+	s.NoError(err)
+	s.False(acquired)
+	s.Nil(unlock)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
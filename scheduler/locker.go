@@ -0,0 +1,25 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	pg "github.com/ralvescosta/gokit/sql/postgres"
+)
+
+// NewPostgresLocker the lock is a session advisory lock held by a dedicated connection until the run finishes
+//
+// The ttl is not used, postgres releases the lock when the session ends
+func NewPostgresLocker(db *sql.DB) Locker {
+	return &postgresLocker{db}
+}
+
+func (l *postgresLocker) TryLock(ctx context.Context, key string, _ time.Duration) (func(ctx context.Context) error, bool, error) {
+	lock, acquired, err := pg.TryAdvisoryLock(ctx, l.db, key)
+	if err != nil || !acquired {
+		return nil, false, err
+	}
+
+	return lock.Unlock, true, nil
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockScheduler struct {
+		mock.Mock
+	}
+
+	MockLocker struct {
+		mock.Mock
+	}
+)
+
+func (m *MockScheduler) Start() {
+	m.Called()
+}
+
+func (m *MockScheduler) Schedule(job *Job) error {
+	args := m.Called(job)
+
+	return args.Error(0)
+}
+
+func (m *MockScheduler) Shutdown(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (m *MockLocker) TryLock(ctx context.Context, key string, ttl time.Duration) (func(ctx context.Context) error, bool, error) {
+	args := m.Called(ctx, key, ttl)
+
+	unlock, _ := args.Get(0).(func(ctx context.Context) error)
+
+	return unlock, args.Bool(1), args.Error(2)
+}
+
+func NewMockScheduler() *MockScheduler {
+	return new(MockScheduler)
+}
+
+func NewMockLocker() *MockLocker {
+	return new(MockLocker)
+}
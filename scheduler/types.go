@@ -0,0 +1,99 @@
+package scheduler
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/pool"
+	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	OverlapPolicy int8
+
+	// JobHandler the function executed in each run, the ctx is canceled when the job timeout expires
+	JobHandler = func(ctx context.Context) error
+
+	// Job a scheduled work, use Cron or Every to define when it runs
+	Job struct {
+		Name string
+		// Cron standard cron expression, e.g: "*/5 * * * *" or descriptors like "@hourly"
+		Cron string
+		// Every fixed interval between the runs
+		Every   time.Duration
+		Timeout time.Duration
+		Overlap OverlapPolicy
+		// SingleRunner when true only one instance of the service executes each run, requires a Locker
+		SingleRunner bool
+		Handler      JobHandler
+	}
+
+	// Locker distributed lock used by the single runner jobs
+	Locker interface {
+		// TryLock returns acquired false when the lock is held by other instance
+		TryLock(ctx context.Context, key string, ttl time.Duration) (unlock func(ctx context.Context) error, acquired bool, err error)
+	}
+
+	SchedulerBuilder interface {
+		// WithLocker set the distributed locker used by the single runner jobs
+		WithLocker(locker Locker) SchedulerBuilder
+		// WithTracing create an OTel span per run
+		WithTracing() SchedulerBuilder
+		// WithClock set the time source of the schedules, the system clock by default
+		WithClock(c clock.Clock) SchedulerBuilder
+		// Job register a new job
+		Job(job *Job) SchedulerBuilder
+		Build() (IScheduler, error)
+	}
+
+	IScheduler interface {
+		// Start schedule the jobs, it does not block
+		Start()
+		// Schedule register a new job at runtime, returns ErrorSchedulerStopped after Shutdown
+		Schedule(job *Job) error
+		// Shutdown stop scheduling new runs and wait the running ones, when ctx is done the runs are canceled
+		Shutdown(ctx context.Context) error
+	}
+
+	// intervalSchedule fixed delay schedule, cron.Every rounds the delay to seconds
+	intervalSchedule struct {
+		every time.Duration
+	}
+
+	postgresLocker struct {
+		db *sql.DB
+	}
+
+	scheduledJob struct {
+		*Job
+		schedule cron.Schedule
+		running  sync.Mutex
+		active   int32
+	}
+
+	Scheduler struct {
+		Err         error
+		logger      logging.ILogger
+		locker      Locker
+		withTracing bool
+		tracer      trace.Tracer
+		jobs        []*scheduledJob
+
+		// mu guards the jobs and the stopped flag once the scheduler is started
+		mu      sync.Mutex
+		stopped bool
+		ctx     context.Context
+		cancel  context.CancelFunc
+		stop    chan struct{}
+		wg      sync.WaitGroup
+		clock   clock.Clock
+
+		// pool the saturation of the runs, created by Start
+		pool *pool.Pool
+	}
+)
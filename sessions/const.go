@@ -0,0 +1,25 @@
+package sessions
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	DefaultCookieName = "session_id"
+	DefaultTTL        = 24 * time.Hour
+	DefaultKeyPrefix  = "session:"
+	DefaultCSRFKey    = "_csrf_token"
+
+	// idBytes the 256 bits session id entropy
+	idBytes = 32
+)
+
+var (
+	ErrorSessionNotFound = errors.New("sessions the session does not exist or expired")
+	ErrorNoSession       = errors.New("sessions the context has no session, the middleware must run before")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::sessions] " + msg
+}
@@ -0,0 +1,34 @@
+package sessions
+
+import "net/http"
+
+// CSRFStore keep the http server CSRF synchronizer token in the session, the session middleware must run before the CSRF middleware
+type CSRFStore struct {
+	Key string
+}
+
+// NewCSRFStore the token is stored in the DefaultCSRFKey
+func NewCSRFStore() *CSRFStore {
+	return &CSRFStore{Key: DefaultCSRFKey}
+}
+
+func (c *CSRFStore) Get(r *http.Request) (string, error) {
+	session, err := FromContext(r.Context())
+	if err != nil {
+		return "", err
+	}
+
+	token := ""
+	_, err = session.Get(c.Key, &token)
+
+	return token, err
+}
+
+func (c *CSRFStore) Set(r *http.Request, token string) error {
+	session, err := FromContext(r.Context())
+	if err != nil {
+		return err
+	}
+
+	return session.Set(c.Key, token)
+}
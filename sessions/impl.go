@@ -0,0 +1,167 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/ralvescosta/gokit/crypto"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+// NewSessionManager the cookies are always HTTP only and secure, unless opts.Insecure
+func NewSessionManager(logger logging.ILogger, store Store, opts *Opts) ISessionManager {
+	if opts == nil {
+		opts = &Opts{}
+	}
+
+	if opts.CookieName == "" {
+		opts.CookieName = DefaultCookieName
+	}
+
+	if opts.Path == "" {
+		opts.Path = "/"
+	}
+
+	if opts.SameSite == 0 {
+		opts.SameSite = http.SameSiteLaxMode
+	}
+
+	if opts.TTL == 0 {
+		opts.TTL = DefaultTTL
+	}
+
+	return &SessionManager{logger, store, opts}
+}
+
+func (m *SessionManager) Middleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session := m.load(r)
+
+			sw := &sessionWriter{ResponseWriter: w}
+			sw.save = func() { m.save(r.Context(), w, session) }
+
+			next.ServeHTTP(sw, r.WithContext(ContextWithSession(r.Context(), session)))
+
+			sw.flushSession()
+		})
+	}
+}
+
+func (m *SessionManager) load(r *http.Request) *Session {
+	cookie, err := r.Cookie(m.opts.CookieName)
+	if err != nil || cookie.Value == "" {
+		return NewSession()
+	}
+
+	values, err := m.store.Load(r.Context(), cookie.Value)
+	if err != nil {
+		if !errors.Is(err, ErrorSessionNotFound) {
+			m.logger.Error(LogMessage("failure to load the session"), logging.ErrorField(err))
+		}
+
+		return NewSession()
+	}
+
+	return &Session{id: cookie.Value, values: values}
+}
+
+// save the failures are logged, the response was already produced by the handler
+func (m *SessionManager) save(ctx context.Context, w http.ResponseWriter, s *Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.previousID != "" {
+		if err := m.store.Delete(ctx, s.previousID); err != nil {
+			m.logger.Error(LogMessage("failure to delete the regenerated session"), logging.ErrorField(err))
+		}
+	}
+
+	if s.destroyed {
+		if s.id != "" {
+			if err := m.store.Delete(ctx, s.id); err != nil {
+				m.logger.Error(LogMessage("failure to delete the session"), logging.ErrorField(err))
+			}
+		}
+
+		if s.id != "" || s.previousID != "" {
+			m.setCookie(w, "", -1)
+		}
+
+		return
+	}
+
+	// the empty new sessions are not stored, so the anonymous requests do not create sessions
+	if !s.changed || (s.id == "" && len(s.values) == 0) {
+		if s.id != "" {
+			if err := m.store.Touch(ctx, s.id, m.opts.TTL); err != nil {
+				m.logger.Error(LogMessage("failure to extend the session"), logging.ErrorField(err))
+				return
+			}
+
+			// the cookie expiration slides with the store TTL
+			m.setCookie(w, s.id, int(m.opts.TTL.Seconds()))
+		}
+
+		return
+	}
+
+	if s.id == "" {
+		id, err := crypto.RandomToken(idBytes)
+		if err != nil {
+			m.logger.Error(LogMessage("failure to generate the session id"), logging.ErrorField(err))
+			return
+		}
+
+		s.id = id
+	}
+
+	if err := m.store.Save(ctx, s.id, s.values, m.opts.TTL); err != nil {
+		m.logger.Error(LogMessage("failure to save the session"), logging.ErrorField(err))
+		return
+	}
+
+	m.setCookie(w, s.id, int(m.opts.TTL.Seconds()))
+}
+
+func (m *SessionManager) setCookie(w http.ResponseWriter, value string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     m.opts.CookieName,
+		Value:    value,
+		Path:     m.opts.Path,
+		Domain:   m.opts.Domain,
+		MaxAge:   maxAge,
+		Secure:   !m.opts.Insecure,
+		HttpOnly: true,
+		SameSite: m.opts.SameSite,
+	})
+}
+
+func (w *sessionWriter) flushSession() {
+	if !w.saved {
+		w.saved = true
+		w.save()
+	}
+}
+
+func (w *sessionWriter) WriteHeader(status int) {
+	w.flushSession()
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *sessionWriter) Write(b []byte) (int, error) {
+	w.flushSession()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *sessionWriter) Flush() {
+	w.flushSession()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *sessionWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
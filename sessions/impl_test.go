@@ -0,0 +1,185 @@
+package sessions
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type SessionsTestSuite struct {
+	suite.Suite
+
+	redis   *miniredis.Miniredis
+	store   Store
+	manager ISessionManager
+}
+
+func TestSessionsTestSuite(t *testing.T) {
+	suite.Run(t, new(SessionsTestSuite))
+}
+
+func (s *SessionsTestSuite) SetupTest() {
+	s.redis = miniredis.RunT(s.T())
+	s.store = NewRedisStore(redis.NewClient(&redis.Options{Addr: s.redis.Addr()}), "")
+	s.manager = NewSessionManager(logging.NewMockLogger(), s.store, &Opts{TTL: time.Hour})
+}
+
+func (s *SessionsTestSuite) serve(cookie *http.Cookie, fn func(session *Session)) *httptest.ResponseRecorder {
+	handler := s.manager.Middleware()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, err := FromContext(r.Context())
+		s.NoError(err)
+
+		fn(session)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func (s *SessionsTestSuite) cookie(rec *httptest.ResponseRecorder) *http.Cookie {
+	cookies := rec.Result().Cookies()
+	if len(cookies) == 0 {
+		return nil
+	}
+
+	return cookies[0]
+}
+
+func (s *SessionsTestSuite) TestAnonymous() {
+	rec := s.serve(nil, func(session *Session) {
+		s.True(session.IsNew())
+	})
+
+	s.Nil(s.cookie(rec))
+	s.Empty(s.redis.Keys())
+}
+
+func (s *SessionsTestSuite) TestSaveAndLoad() {
+	rec := s.serve(nil, func(session *Session) {
+		s.NoError(session.Set("user_id", 42))
+	})
+
+	cookie := s.cookie(rec)
+	s.Equal(DefaultCookieName, cookie.Name)
+	s.True(cookie.HttpOnly)
+	s.True(cookie.Secure)
+	s.Equal(http.SameSiteLaxMode, cookie.SameSite)
+	s.Equal(3600, cookie.MaxAge)
+	s.Len(s.redis.Keys(), 1)
+	s.NotContains(s.redis.Keys()[0], cookie.Value)
+
+	s.redis.FastForward(30 * time.Minute)
+
+	rec = s.serve(cookie, func(session *Session) {
+		s.False(session.IsNew())
+		s.Equal(cookie.Value, session.ID())
+
+		var userID int
+		ok, err := session.Get("user_id", &userID)
+		s.True(ok)
+		s.NoError(err)
+		s.Equal(42, userID)
+
+		ok, _ = session.Get("unknown", &userID)
+		s.False(ok)
+	})
+
+	// the touched session slides the cookie expiration
+	touched := s.cookie(rec)
+	s.Equal(cookie.Value, touched.Value)
+	s.Equal(3600, touched.MaxAge)
+	s.Equal(time.Hour, s.redis.TTL(s.redis.Keys()[0]))
+}
+
+func (s *SessionsTestSuite) TestRegenerate() {
+	cookie := s.cookie(s.serve(nil, func(session *Session) {
+		s.NoError(session.Set("cart", []string{"item"}))
+	}))
+
+	regenerated := s.cookie(s.serve(cookie, func(session *Session) {
+		session.Regenerate()
+		s.NoError(session.Set("role", "admin"))
+	}))
+
+	s.NotEqual(cookie.Value, regenerated.Value)
+	s.Len(s.redis.Keys(), 1)
+
+	_, err := s.store.Load(context.Background(), cookie.Value)
+	s.ErrorIs(err, ErrorSessionNotFound)
+
+	s.serve(regenerated, func(session *Session) {
+		s.Equal([]string{"cart", "role"}, session.Keys())
+	})
+
+	s.serve(cookie, func(session *Session) {
+		s.True(session.IsNew())
+	})
+}
+
+func (s *SessionsTestSuite) TestDestroy() {
+	cookie := s.cookie(s.serve(nil, func(session *Session) {
+		s.NoError(session.Set("user_id", 42))
+	}))
+
+	expired := s.cookie(s.serve(cookie, func(session *Session) {
+		session.Destroy()
+	}))
+
+	s.Equal(-1, expired.MaxAge)
+	s.Empty(s.redis.Keys())
+}
+
+func (s *SessionsTestSuite) TestDelete() {
+	cookie := s.cookie(s.serve(nil, func(session *Session) {
+		s.NoError(session.Set("a", 1))
+		s.NoError(session.Set("b", 2))
+	}))
+
+	s.serve(cookie, func(session *Session) {
+		session.Delete("a")
+	})
+
+	values, err := s.store.Load(context.Background(), cookie.Value)
+	s.NoError(err)
+	s.Len(values, 1)
+}
+
+func (s *SessionsTestSuite) TestFromContext() {
+	_, err := FromContext(context.Background())
+	s.ErrorIs(err, ErrorNoSession)
+}
+
+func (s *SessionsTestSuite) TestCSRFStore() {
+	store := NewCSRFStore()
+
+	cookie := s.cookie(s.serve(nil, func(session *Session) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ContextWithSession(context.Background(), session))
+		s.NoError(store.Set(req, "token"))
+	}))
+
+	s.serve(cookie, func(session *Session) {
+		req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ContextWithSession(context.Background(), session))
+
+		token, err := store.Get(req)
+		s.NoError(err)
+		s.Equal("token", token)
+	})
+
+	_, err := store.Get(httptest.NewRequest(http.MethodGet, "/", nil))
+	s.ErrorIs(err, ErrorNoSession)
+}
@@ -0,0 +1,39 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStore struct {
+	mock.Mock
+}
+
+func (m *MockStore) Load(ctx context.Context, id string) (map[string]json.RawMessage, error) {
+	args := m.Called(ctx, id)
+
+	values, _ := args.Get(0).(map[string]json.RawMessage)
+	return values, args.Error(1)
+}
+
+func (m *MockStore) Save(ctx context.Context, id string, values map[string]json.RawMessage, ttl time.Duration) error {
+	args := m.Called(ctx, id, values, ttl)
+	return args.Error(0)
+}
+
+func (m *MockStore) Touch(ctx context.Context, id string, ttl time.Duration) error {
+	args := m.Called(ctx, id, ttl)
+	return args.Error(0)
+}
+
+func (m *MockStore) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func NewMockStore() *MockStore {
+	return new(MockStore)
+}
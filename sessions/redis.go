@@ -0,0 +1,59 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/ralvescosta/gokit/crypto"
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisStore the keys are the prefix with the sha256 of the session id, so the ids are not readable in the redis, default prefix DefaultKeyPrefix
+func NewRedisStore(client redis.UniversalClient, prefix string) Store {
+	if prefix == "" {
+		prefix = DefaultKeyPrefix
+	}
+
+	return &redisStore{client, prefix}
+}
+
+func (s *redisStore) Load(ctx context.Context, id string) (map[string]json.RawMessage, error) {
+	byt, err := s.client.Get(ctx, s.key(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrorSessionNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(byt, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+func (s *redisStore) Save(ctx context.Context, id string, values map[string]json.RawMessage, ttl time.Duration) error {
+	byt, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, s.key(id), byt, ttl).Err()
+}
+
+func (s *redisStore) Touch(ctx context.Context, id string, ttl time.Duration) error {
+	return s.client.Expire(ctx, s.key(id), ttl).Err()
+}
+
+func (s *redisStore) Delete(ctx context.Context, id string) error {
+	return s.client.Del(ctx, s.key(id)).Err()
+}
+
+func (s *redisStore) key(id string) string {
+	return s.prefix + crypto.SHA256([]byte(id))
+}
@@ -0,0 +1,116 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+)
+
+// FromContext the session loaded by the middleware
+func FromContext(ctx context.Context) (*Session, error) {
+	s, ok := ctx.Value(sessionCtxKey{}).(*Session)
+	if !ok {
+		return nil, ErrorNoSession
+	}
+
+	return s, nil
+}
+
+// ContextWithSession store the session in the context, useful to the tests of the handlers
+func ContextWithSession(ctx context.Context, s *Session) context.Context {
+	return context.WithValue(ctx, sessionCtxKey{}, s)
+}
+
+// NewSession an empty session, it is stored only when a value is set
+func NewSession() *Session {
+	return &Session{values: map[string]json.RawMessage{}, isNew: true}
+}
+
+// ID the id is empty until the session is saved the first time
+func (s *Session) ID() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.id
+}
+
+func (s *Session) IsNew() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.isNew
+}
+
+// Get decode the value into dest, returns false when the key does not exist
+func (s *Session) Get(key string, dest any) (bool, error) {
+	s.mu.Lock()
+	raw, ok := s.values[key]
+	s.mu.Unlock()
+
+	if !ok {
+		return false, nil
+	}
+
+	return true, json.Unmarshal(raw, dest)
+}
+
+func (s *Session) Set(key string, value any) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = raw
+	s.changed = true
+
+	return nil
+}
+
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.values[key]; ok {
+		delete(s.values, key)
+		s.changed = true
+	}
+}
+
+func (s *Session) Keys() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	keys := make([]string, 0, len(s.values))
+	for k := range s.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// Regenerate replace the session id keeping the values, it must be called when the privilege changes,
+// e.g: on the login or the role change, so a session id fixed by an attacker before is not elevated
+func (s *Session) Regenerate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.previousID == "" {
+		s.previousID = s.id
+	}
+
+	s.id = ""
+	s.changed = true
+}
+
+// Destroy remove the session from the store and expire the cookie, e.g: on the logout
+func (s *Session) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values = map[string]json.RawMessage{}
+	s.destroyed = true
+}
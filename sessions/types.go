@@ -0,0 +1,73 @@
+package sessions
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/redis/go-redis/v9"
+)
+
+type (
+	// Session the values are json encoded, so the types are preserved between the requests
+	Session struct {
+		mu     sync.Mutex
+		id     string
+		values map[string]json.RawMessage
+		// previousID the id replaced by Regenerate, deleted from the store on save
+		previousID string
+		isNew      bool
+		changed    bool
+		destroyed  bool
+	}
+
+	Store interface {
+		// Load returns ErrorSessionNotFound when the session does not exist or expired
+		Load(ctx context.Context, id string) (map[string]json.RawMessage, error)
+		Save(ctx context.Context, id string, values map[string]json.RawMessage, ttl time.Duration) error
+		// Touch extend the session ttl
+		Touch(ctx context.Context, id string, ttl time.Duration) error
+		Delete(ctx context.Context, id string) error
+	}
+
+	Opts struct {
+		// CookieName default DefaultCookieName
+		CookieName string
+		Path       string
+		Domain     string
+		// Insecure allows the cookie over http, only to the local development
+		Insecure bool
+		// SameSite default http.SameSiteLaxMode
+		SameSite http.SameSite
+		// TTL the idle timeout, it is extended on each request, default DefaultTTL
+		TTL time.Duration
+	}
+
+	ISessionManager interface {
+		// Middleware load the session of the cookie into the request context and save it before the response headers are written
+		Middleware() func(http.Handler) http.Handler
+	}
+
+	SessionManager struct {
+		logger logging.ILogger
+		store  Store
+		opts   *Opts
+	}
+
+	redisStore struct {
+		client redis.UniversalClient
+		prefix string
+	}
+
+	// sessionWriter save the session before the first write, since the cookie is a header
+	sessionWriter struct {
+		http.ResponseWriter
+		save  func()
+		saved bool
+	}
+
+	sessionCtxKey struct{}
+)
@@ -0,0 +1,36 @@
+package sql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ralvescosta/gokit/clock"
+)
+
+const (
+	DefaultStmtCacheSize = 256
+
+	DefaultCanaryQuery         = "SELECT 1"
+	DefaultWarmUpRetryInterval = time.Second
+
+	DefaultIDColumn        = "id"
+	DefaultDeletedAtColumn = "deleted_at"
+	DefaultVersionColumn   = "version"
+)
+
+var (
+	ErrorStmtCacheClosed = errors.New("sql statement cache is closed")
+	ErrorModelNotFound   = errors.New("sql the model struct was not found in the file")
+	ErrorModelColumns    = errors.New("sql the model requires the primary key and at least one other db tagged field")
+	ErrorPoolNotWarm     = errors.New("sql the connection pool is not warmed up yet")
+	ErrorCanaryQuery     = errors.New("sql the warm-up canary query failed")
+	// ErrStaleObject the version-checked update did not match the row, see StaleObjectError
+	ErrStaleObject = errors.New("sql stale object, the row was changed or deleted by other transaction")
+)
+
+// systemClock is replaced in the tests
+var systemClock = clock.New()
+
+func LogMessage(msg string) string {
+	return "[gokit::sql] " + msg
+}
@@ -0,0 +1,346 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+type (
+	// ModelColumn a db tagged field of the model, see GenerateRepository
+	ModelColumn struct {
+		Field  string
+		Column string
+		Type   string
+		PK     bool
+		// Auto the value is generated by the database, e.g: serial ids or default timestamps, it is returned by the INSERT
+		Auto bool
+	}
+
+	// Model the struct used to generate the repository
+	Model struct {
+		Package string
+		Name    string
+		Table   string
+		Columns []*ModelColumn
+	}
+)
+
+var repositoryTemplate = template.Must(template.New("repository").Funcs(template.FuncMap{
+	"placeholders": placeholders,
+	"sets":         sets,
+	"fields":       fields,
+	"inc":          func(i int) int { return i + 1 },
+}).Parse(`// Code generated by gokit sql generate. DO NOT EDIT.
+
+package {{ .Package }}
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/ralvescosta/gokit/pagination"
+	gokitSQL "github.com/ralvescosta/gokit/sql"
+)
+
+// {{ .Name }}Repository the {{ .Name }} CRUD on the {{ .Table }} table, the queries use the transaction carried in the context
+type {{ .Name }}Repository struct {
+	gokitSQL.Repository
+}
+
+func New{{ .Name }}Repository(db *sql.DB) *{{ .Name }}Repository {
+	return &{{ .Name }}Repository{gokitSQL.NewRepository(db)}
+}
+
+func scan{{ .Name }}(row gokitSQL.Scanner) (*{{ .Name }}, error) {
+	m := &{{ .Name }}{}
+	if err := row.Scan({{ fields "&m." .Columns }}); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+// GetByID returns sql.ErrNoRows when the {{ .Name }} does not exist
+func (r *{{ .Name }}Repository) GetByID(ctx context.Context, id {{ .PK.Type }}) (*{{ .Name }}, error) {
+	return scan{{ .Name }}(r.Querier(ctx).QueryRowContext(ctx, "SELECT {{ .ColumnList }} FROM {{ .Table }} WHERE {{ .PK.Column }} = $1", id))
+}
+
+// List the page ordered by the primary key
+func (r *{{ .Name }}Repository) List(ctx context.Context, offset *pagination.Offset) (*pagination.Page[*{{ .Name }}], error) {
+	var total int64
+	if err := r.Querier(ctx).QueryRowContext(ctx, "SELECT COUNT(*) FROM {{ .Table }}").Scan(&total); err != nil {
+		return nil, err
+	}
+
+	limit, args := offset.SQL(1)
+	rows, err := gokitSQL.QueryRows(ctx, r.Querier(ctx), scan{{ .Name }}, "SELECT {{ .ColumnList }} FROM {{ .Table }} ORDER BY {{ .PK.Column }} "+limit, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	models := []*{{ .Name }}{}
+	for rows.Next() {
+		models = append(models, rows.Value())
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return pagination.NewOffsetPage(models, offset, total), nil
+}
+
+func (r *{{ .Name }}Repository) Insert(ctx context.Context, m *{{ .Name }}) error {
+{{- if .Auto }}
+	return r.Querier(ctx).QueryRowContext(ctx, "INSERT INTO {{ .Table }} ({{ .InsertList }}) VALUES ({{ placeholders 1 .Insert }}) RETURNING {{ .AutoList }}", {{ fields "m." .Insert }}).Scan({{ fields "&m." .Auto }})
+{{- else }}
+	_, err := r.Querier(ctx).ExecContext(ctx, "INSERT INTO {{ .Table }} ({{ .InsertList }}) VALUES ({{ placeholders 1 .Insert }})", {{ fields "m." .Insert }})
+	return err
+{{- end }}
+}
+
+// Update returns sql.ErrNoRows when the {{ .Name }} does not exist
+func (r *{{ .Name }}Repository) Update(ctx context.Context, m *{{ .Name }}) error {
+	result, err := r.Querier(ctx).ExecContext(ctx, "UPDATE {{ .Table }} SET {{ sets .Update }} WHERE {{ .PK.Column }} = ${{ len .Update | inc }}", {{ fields "m." .Update }}, m.{{ .PK.Field }})
+	if err != nil {
+		return err
+	}
+
+	return gokitSQL.CheckFound(result)
+}
+
+// Delete returns sql.ErrNoRows when the {{ .Name }} does not exist
+func (r *{{ .Name }}Repository) Delete(ctx context.Context, id {{ .PK.Type }}) error {
+	result, err := r.Querier(ctx).ExecContext(ctx, "DELETE FROM {{ .Table }} WHERE {{ .PK.Column }} = $1", id)
+	if err != nil {
+		return err
+	}
+
+	return gokitSQL.CheckFound(result)
+}
+`))
+
+// ParseModel read the struct name of the go file, the fields tagged with db are the columns
+//
+// The primary key is the id column or the field tagged with the pk option, e.g: db:"user_id,pk".
+// The fields with the auto option are generated by the database and returned by the INSERT, e.g: db:"created_at,auto"
+func ParseModel(file, name, table string) (*Model, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	if table == "" {
+		table = snakeCase(name) + "s"
+	}
+
+	model := &Model{Package: f.Name.Name, Name: name, Table: table}
+
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok || ts.Name.Name != name {
+				continue
+			}
+
+			model.Columns = modelColumns(st)
+			if model.pk() == nil || len(model.updatable()) == 0 {
+				return nil, ErrorModelColumns
+			}
+
+			return model, nil
+		}
+	}
+
+	return nil, ErrorModelNotFound
+}
+
+// GenerateRepository returns the go file with the typed repository of the model: GetByID, List, Insert, Update and Delete
+//
+//	//go:generate gokit sql generate -file $GOFILE -type User -table users
+func GenerateRepository(file, name, table string) ([]byte, error) {
+	model, err := ParseModel(file, name, table)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.Buffer{}
+	if err := repositoryTemplate.Execute(&buf, model.templateData()); err != nil {
+		return nil, err
+	}
+
+	return format.Source(buf.Bytes())
+}
+
+// WriteRepository write the GenerateRepository output to the <type>_repository.gen.go next to the file
+func WriteRepository(file, name, table string) (string, error) {
+	src, err := GenerateRepository(file, name, table)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(filepath.Dir(file), snakeCase(name)+"_repository.gen.go")
+	return path, os.WriteFile(path, src, 0o644)
+}
+
+func modelColumns(st *ast.StructType) []*ModelColumn {
+	columns := []*ModelColumn{}
+
+	for _, field := range st.Fields.List {
+		if field.Tag == nil || len(field.Names) != 1 || !field.Names[0].IsExported() {
+			continue
+		}
+
+		tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("db")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		column := &ModelColumn{Field: field.Names[0].Name, Column: parts[0], Type: types.ExprString(field.Type)}
+
+		for _, opt := range parts[1:] {
+			column.PK = column.PK || opt == "pk"
+			column.Auto = column.Auto || opt == "auto"
+		}
+
+		columns = append(columns, column)
+	}
+
+	hasPK := false
+	for _, c := range columns {
+		hasPK = hasPK || c.PK
+	}
+
+	if !hasPK {
+		for _, c := range columns {
+			if c.Column == DefaultIDColumn {
+				c.PK = true
+			}
+		}
+	}
+
+	return columns
+}
+
+func (m *Model) pk() *ModelColumn {
+	for _, c := range m.Columns {
+		if c.PK {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// updatable the columns that are neither the primary key nor auto
+func (m *Model) updatable() []*ModelColumn {
+	update := []*ModelColumn{}
+	for _, c := range m.Columns {
+		if !c.Auto && !c.PK {
+			update = append(update, c)
+		}
+	}
+
+	return update
+}
+
+func (m *Model) templateData() map[string]any {
+	insert, auto := []*ModelColumn{}, []*ModelColumn{}
+
+	for _, c := range m.Columns {
+		if c.Auto {
+			auto = append(auto, c)
+		} else {
+			insert = append(insert, c)
+		}
+	}
+
+	return map[string]any{
+		"Package":    m.Package,
+		"Name":       m.Name,
+		"Table":      m.Table,
+		"Columns":    m.Columns,
+		"PK":         m.pk(),
+		"ColumnList": columnList(m.Columns),
+		"Insert":     insert,
+		"InsertList": columnList(insert),
+		"Auto":       auto,
+		"AutoList":   columnList(auto),
+		"Update":     m.updatable(),
+	}
+}
+
+func columnList(columns []*ModelColumn) string {
+	names := make([]string, 0, len(columns))
+	for _, c := range columns {
+		names = append(names, c.Column)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func placeholders(start int, columns []*ModelColumn) string {
+	p := make([]string, 0, len(columns))
+	for i := range columns {
+		p = append(p, fmt.Sprintf("$%d", start+i))
+	}
+
+	return strings.Join(p, ", ")
+}
+
+func sets(columns []*ModelColumn) string {
+	s := make([]string, 0, len(columns))
+	for i, c := range columns {
+		s = append(s, fmt.Sprintf("%s = $%d", c.Column, i+1))
+	}
+
+	return strings.Join(s, ", ")
+}
+
+func fields(prefix string, columns []*ModelColumn) string {
+	f := make([]string, 0, len(columns))
+	for _, c := range columns {
+		f = append(f, prefix+c.Field)
+	}
+
+	return strings.Join(f, ", ")
+}
+
+// snakeCase e.g: UserProfile -> user_profile
+func snakeCase(s string) string {
+	b := strings.Builder{}
+	r := []rune(s)
+
+	for i, c := range r {
+		if unicode.IsUpper(c) {
+			if i > 0 && (unicode.IsLower(r[i-1]) || (i+1 < len(r) && unicode.IsLower(r[i+1]))) {
+				b.WriteRune('_')
+			}
+
+			c = unicode.ToLower(c)
+		}
+
+		b.WriteRune(c)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package sql
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type GenTestSuite struct {
+	suite.Suite
+
+	file string
+}
+
+func TestGenTestSuite(t *testing.T) {
+	suite.Run(t, new(GenTestSuite))
+}
+
+func (s *GenTestSuite) SetupTest() {
+	s.file = filepath.Join(s.T().TempDir(), "user.go")
+	s.NoError(os.WriteFile(s.file, []byte(`package users
+
+import "time"
+
+type UserProfile struct {
+	ID        int64     `+"`db:\"id,auto\"`"+`
+	Name      string    `+"`db:\"name\" json:\"name\"`"+`
+	Email     string    `+"`db:\"email\"`"+`
+	CreatedAt time.Time `+"`db:\"created_at,auto\"`"+`
+	Password  string    `+"`db:\"-\"`"+`
+	internal  string
+}
+
+type Empty struct {
+	ID int64 `+"`db:\"id\"`"+`
+}
+`), 0o644))
+}
+
+func (s *GenTestSuite) TestParseModel() {
+	model, err := ParseModel(s.file, "UserProfile", "")
+
+	s.NoError(err)
+	s.Equal("users", model.Package)
+	s.Equal("user_profiles", model.Table)
+	s.Len(model.Columns, 4)
+	s.Equal(&ModelColumn{Field: "ID", Column: "id", Type: "int64", PK: true, Auto: true}, model.Columns[0])
+	s.Equal("time.Time", model.Columns[3].Type)
+
+	_, err = ParseModel(s.file, "Empty", "")
+	s.ErrorIs(err, ErrorModelColumns)
+
+	_, err = ParseModel(s.file, "Unknown", "")
+	s.ErrorIs(err, ErrorModelNotFound)
+}
+
+func (s *GenTestSuite) TestWriteRepository() {
+	path, err := WriteRepository(s.file, "UserProfile", "profiles")
+	s.NoError(err)
+	s.Equal(filepath.Join(filepath.Dir(s.file), "user_profile_repository.gen.go"), path)
+
+	src, _ := os.ReadFile(path)
+	s.Contains(string(src), "func NewUserProfileRepository(db *sql.DB) *UserProfileRepository {")
+	s.Contains(string(src), `"SELECT id, name, email, created_at FROM profiles WHERE id = $1", id)`)
+	s.Contains(string(src), `"INSERT INTO profiles (name, email) VALUES ($1, $2) RETURNING id, created_at", m.Name, m.Email).Scan(&m.ID, &m.CreatedAt)`)
+	s.Contains(string(src), `"UPDATE profiles SET name = $1, email = $2 WHERE id = $3", m.Name, m.Email, m.ID)`)
+	s.Contains(string(src), `"DELETE FROM profiles WHERE id = $1", id)`)
+	s.Contains(string(src), "func (r *UserProfileRepository) List(ctx context.Context, offset *pagination.Offset) (*pagination.Page[*UserProfile], error) {")
+}
+
+func (s *GenTestSuite) TestSnakeCase() {
+	s.Equal("user", snakeCase("User"))
+	s.Equal("user_profile", snakeCase("UserProfile"))
+	s.Equal("http_request", snakeCase("HTTPRequest"))
+}
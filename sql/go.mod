@@ -3,15 +3,17 @@ module github.com/ralvescosta/gokit/sql
 go 1.18
 
 require (
+	github.com/DATA-DOG/go-sqlmock v1.5.0
 	github.com/lib/pq v1.10.6
 	github.com/stretchr/testify v1.8.0
 )
 
 require (
-	github.com/ralvescosta/gokit/env v0.0.0-20220717193252-2f9449cd88d1
-	github.com/ralvescosta/gokit/logging v0.0.0-20220718203343-66c0bdb452bc
-	github.com/uptrace/opentelemetry-go-extra/otelsql v0.1.14
-	go.opentelemetry.io/otel v1.8.0
+	github.com/ralvescosta/gokit/clock v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/env v0.0.0-20261016183433-cb393702ac94
+	github.com/ralvescosta/gokit/logging v0.0.0-20261016183433-cb393702ac94
+	github.com/uptrace/opentelemetry-go-extra/otelsql v0.3.2
+	go.opentelemetry.io/otel v1.28.0
 )
 
 require (
@@ -21,8 +23,8 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/ralvescosta/dotenv v1.0.4 // indirect
 	github.com/stretchr/objx v0.4.0 // indirect
-	go.opentelemetry.io/otel/metric v0.31.0 // indirect
-	go.opentelemetry.io/otel/trace v1.8.0 // indirect
+	go.opentelemetry.io/otel/metric v1.28.0 // indirect
+	go.opentelemetry.io/otel/trace v1.28.0 // indirect
 	go.uber.org/atomic v1.9.0 // indirect
 	go.uber.org/multierr v1.8.0 // indirect
 	go.uber.org/zap v1.21.0 // indirect
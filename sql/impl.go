@@ -1,8 +1,8 @@
 package sql
 
 import (
+	"context"
 	"database/sql"
-	"fmt"
 	"time"
 
 	"github.com/ralvescosta/gokit/env"
@@ -10,25 +10,27 @@ import (
 )
 
 type ISqlConnection interface {
+	// Schema the search_path of the connections, used to isolate the tables of a bounded context, it must be called before the Connect
+	Schema(schemas ...string) ISqlConnection
 	Connect() ISqlConnection
 	ShotdownSignal() ISqlConnection
+	// WarmUp pre-open the pool connections in background after the Connect, see IPoolWarmUp
+	WarmUp(opts *WarmUpOpts) ISqlConnection
+	// Ready the readiness check of the connection, it fails until the WarmUp succeeds
+	Ready(ctx context.Context) error
 	Build() (*sql.DB, error)
 }
 
+// GetConnectionString returns the key=value connection string
+//
+// Deprecated: use NewConnStringBuilder to configure the format and the connection options
 func GetConnectionString(cfg *env.Configs) string {
-	return fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		cfg.SQL_DB_HOST,
-		cfg.SQL_DB_PORT,
-		cfg.SQL_DB_USER,
-		cfg.SQL_DB_PASSWORD,
-		cfg.SQL_DB_NAME,
-	)
+	return NewConnStringBuilder(cfg).Build()
 }
 
 func ShotdownSignal(timeToPing int, conn *sql.DB, log logging.ILogger, shotdown chan bool, connFailureLogMsg string) {
 	for {
-		time.Sleep(time.Duration(timeToPing) * time.Millisecond)
+		systemClock.Sleep(time.Duration(timeToPing) * time.Millisecond)
 		err := conn.Ping()
 		if err != nil {
 			log.Error(connFailureLogMsg, logging.ErrorField(err))
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"context"
+	"database/sql"
 	"database/sql/driver"
 
 	"github.com/stretchr/testify/mock"
@@ -37,9 +38,13 @@ type (
 	MockConnector struct {
 		mock.Mock
 	}
+
+	MockStmtCache struct {
+		mock.Mock
+	}
 )
 
-func (m MockPingDriverConn) Ping(ctx context.Context) error {
+func (m *MockPingDriverConn) Ping(ctx context.Context) error {
 	args := m.Called(ctx)
 	return args.Error(0)
 }
@@ -97,24 +102,24 @@ func (m *MockStmt) Query(args []driver.Value) (driver.Rows, error) {
 	return d, mArgs.Error(1)
 }
 
-func (m MockSqlDbConn) Prepare(query string) (driver.Stmt, error) {
+func (m *MockSqlDbConn) Prepare(query string) (driver.Stmt, error) {
 	args := m.Called(query)
 	stmt := args.Get(0).(driver.Stmt)
 	return stmt, args.Error(1)
 }
 
-func (m MockSqlDbConn) Close() error {
+func (m *MockSqlDbConn) Close() error {
 	args := m.Called()
 	return args.Error(0)
 }
 
-func (m MockSqlDbConn) Begin() (driver.Tx, error) {
+func (m *MockSqlDbConn) Begin() (driver.Tx, error) {
 	args := m.Called()
 	tx := args.Get(0).(driver.Tx)
 	return tx, args.Error(1)
 }
 
-func (m MockSqlDbConn) Exec(query string, args []driver.Value) (driver.Result, error) {
+func (m *MockSqlDbConn) Exec(query string, args []driver.Value) (driver.Result, error) {
 	mArgs := m.Called(query, args)
 	r := mArgs.Get(0).(driver.Result)
 	return r, mArgs.Error(1)
@@ -132,6 +137,58 @@ func (m *MockConnector) Driver() driver.Driver {
 	return d
 }
 
+func (m *MockStmtCache) Prepare(ctx context.Context, query string) (*sql.Stmt, func(), error) {
+	args := m.Called(ctx, query)
+
+	stmt, _ := args.Get(0).(*sql.Stmt)
+	release, ok := args.Get(1).(func())
+	if !ok {
+		release = func() {}
+	}
+
+	return stmt, release, args.Error(2)
+}
+
+func (m *MockStmtCache) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	mArgs := m.Called(ctx, query, args)
+
+	r, _ := mArgs.Get(0).(sql.Result)
+	return r, mArgs.Error(1)
+}
+
+func (m *MockStmtCache) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	mArgs := m.Called(ctx, query, args)
+
+	rows, _ := mArgs.Get(0).(*sql.Rows)
+	return rows, mArgs.Error(1)
+}
+
+func (m *MockStmtCache) QueryRowContext(ctx context.Context, query string, args ...any) (*sql.Row, error) {
+	mArgs := m.Called(ctx, query, args)
+
+	row, _ := mArgs.Get(0).(*sql.Row)
+	return row, mArgs.Error(1)
+}
+
+func (m *MockStmtCache) TxStmt(ctx context.Context, tx *sql.Tx, query string) (*sql.Stmt, error) {
+	args := m.Called(ctx, tx, query)
+
+	stmt, _ := args.Get(0).(*sql.Stmt)
+	return stmt, args.Error(1)
+}
+
+func (m *MockStmtCache) Metrics() StmtCacheMetrics {
+	args := m.Called()
+
+	return args.Get(0).(StmtCacheMetrics)
+}
+
+func (m *MockStmtCache) Close() error {
+	args := m.Called()
+
+	return args.Error(0)
+}
+
 func NewMockMockPingDriver() *MockPingDriver {
 	return new(MockPingDriver)
 }
@@ -159,3 +216,7 @@ func NewMockSqlDbConn() *MockSqlDbConn {
 func NewMockConnector() *MockConnector {
 	return new(MockConnector)
 }
+
+func NewMockStmtCache() *MockStmtCache {
+	return new(MockStmtCache)
+}
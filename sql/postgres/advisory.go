@@ -0,0 +1,132 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+
+	pkgSql "github.com/ralvescosta/gokit/sql"
+)
+
+// AdvisoryLock a session advisory lock, it is held by a dedicated connection until Unlock
+type AdvisoryLock struct {
+	Key  string
+	id   int64
+	conn *sql.Conn
+}
+
+// AdvisoryKey the advisory locks are identified by a bigint, the key is hashed so the services share the lock by name
+func AdvisoryKey(key string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return int64(h.Sum64())
+}
+
+// TryAdvisoryLock acquire the session lock without waiting, false is returned when other session holds the lock
+func TryAdvisoryLock(ctx context.Context, db *sql.DB, key string) (*AdvisoryLock, bool, error) {
+	return advisoryLock(ctx, db, key, "SELECT pg_try_advisory_lock($1)")
+}
+
+// WaitAdvisoryLock wait until the session lock is acquired or the ctx is done
+func WaitAdvisoryLock(ctx context.Context, db *sql.DB, key string) (*AdvisoryLock, error) {
+	lock, _, err := advisoryLock(ctx, db, key, "SELECT pg_advisory_lock($1) IS NOT NULL")
+	return lock, err
+}
+
+// WithAdvisoryLock run fn holding the session lock, e.g: the migrations, it waits the lock when other session holds it
+func WithAdvisoryLock(ctx context.Context, db *sql.DB, key string, fn func(ctx context.Context) error) error {
+	lock, err := WaitAdvisoryLock(ctx, db, key)
+	if err != nil {
+		return err
+	}
+
+	return runLocked(ctx, lock, fn)
+}
+
+// TryWithAdvisoryLock run fn only when the session lock is acquired, e.g: the singleton jobs, false is returned when other session holds the lock
+func TryWithAdvisoryLock(ctx context.Context, db *sql.DB, key string, fn func(ctx context.Context) error) (bool, error) {
+	lock, acquired, err := TryAdvisoryLock(ctx, db, key)
+	if err != nil || !acquired {
+		return false, err
+	}
+
+	return true, runLocked(ctx, lock, fn)
+}
+
+// TryAdvisoryXactLock acquire the transaction lock in the transaction carried in the ctx, the lock is released by the commit or the rollback
+//
+// ErrorTransactionRequired is returned when the ctx does not carry a transaction, see pkgSql.WithTx
+func TryAdvisoryXactLock(ctx context.Context, key string) (bool, error) {
+	tx, ok := pkgSql.TxFromCtx(ctx)
+	if !ok {
+		return false, ErrorTransactionRequired
+	}
+
+	acquired := false
+	err := tx.QueryRowContext(ctx, "SELECT pg_try_advisory_xact_lock($1)", AdvisoryKey(key)).Scan(&acquired)
+
+	return acquired, err
+}
+
+// WithAdvisoryXactLock run fn in a transaction holding the transaction lock, it joins the transaction carried in the ctx and waits the lock when other transaction holds it
+func WithAdvisoryXactLock(ctx context.Context, db *sql.DB, key string, fn func(ctx context.Context) error) error {
+	return pkgSql.WithTx(ctx, db, func(ctx context.Context) error {
+		tx, _ := pkgSql.TxFromCtx(ctx)
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", AdvisoryKey(key)); err != nil {
+			return err
+		}
+
+		return fn(ctx)
+	})
+}
+
+// Ping the lock is held while the session is alive
+func (l *AdvisoryLock) Ping(ctx context.Context) error {
+	return l.conn.PingContext(ctx)
+}
+
+// Unlock release the lock and the dedicated connection, when the unlock fails the session may still hold the lock, so
+// the connection is discarded instead of returned to the pool
+func (l *AdvisoryLock) Unlock(ctx context.Context) error {
+	if _, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.id); err != nil {
+		l.discard()
+		return err
+	}
+
+	return l.conn.Close()
+}
+
+// discard close the dedicated connection without returning it to the pool, postgres releases the lock when the session ends
+func (l *AdvisoryLock) discard() {
+	_ = l.conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = l.conn.Close()
+}
+
+func advisoryLock(ctx context.Context, db *sql.DB, key, query string) (*AdvisoryLock, bool, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	lock := &AdvisoryLock{Key: key, id: AdvisoryKey(key), conn: conn}
+
+	acquired := false
+	if err := conn.QueryRowContext(ctx, query, lock.id).Scan(&acquired); err != nil || !acquired {
+		_ = conn.Close()
+		return nil, false, err
+	}
+
+	return lock, true, nil
+}
+
+// runLocked the lock is released even when the ctx is done or fn panics
+func runLocked(ctx context.Context, lock *AdvisoryLock, fn func(ctx context.Context) error) (err error) {
+	defer func() {
+		if unlockErr := lock.Unlock(context.Background()); err == nil {
+			err = unlockErr
+		}
+	}()
+
+	return fn(ctx)
+}
@@ -0,0 +1,138 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	pkgSql "github.com/ralvescosta/gokit/sql"
+)
+
+type AdvisoryLockTestSuite struct {
+	suite.Suite
+}
+
+func TestAdvisoryLockTestSuite(t *testing.T) {
+	suite.Run(t, new(AdvisoryLockTestSuite))
+}
+
+func (s *AdvisoryLockTestSuite) TestAdvisoryKey() {
+	s.Equal(AdvisoryKey("migrations"), AdvisoryKey("migrations"))
+	s.NotEqual(AdvisoryKey("migrations"), AdvisoryKey("outbox"))
+}
+
+func (s *AdvisoryLockTestSuite) TestTryAdvisoryLock() {
+	db, sqlMock, _ := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	defer db.Close()
+
+	key := AdvisoryKey("outbox")
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(false))
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(true))
+	sqlMock.ExpectPing()
+	sqlMock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(key).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	lock, acquired, err := TryAdvisoryLock(context.Background(), db, "outbox")
+	s.NoError(err)
+	s.False(acquired)
+	s.Nil(lock)
+
+	lock, acquired, err = TryAdvisoryLock(context.Background(), db, "outbox")
+	s.NoError(err)
+	s.True(acquired)
+	s.NoError(lock.Ping(context.Background()))
+	s.NoError(lock.Unlock(context.Background()))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *AdvisoryLockTestSuite) TestUnlockErr() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	key := AdvisoryKey("outbox")
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(true))
+	sqlMock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(key).WillReturnError(errors.New("unlock failure"))
+
+	lock, acquired, err := TryAdvisoryLock(context.Background(), db, "outbox")
+	s.NoError(err)
+	s.True(acquired)
+	s.Error(lock.Unlock(context.Background()))
+
+	// the session holding the lock is not returned to the pool
+	s.Equal(0, db.Stats().OpenConnections)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *AdvisoryLockTestSuite) TestWithAdvisoryLock() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	key := AdvisoryKey("migrations")
+	sqlMock.ExpectQuery("SELECT pg_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"locked"}).AddRow(true))
+	sqlMock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(key).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := WithAdvisoryLock(context.Background(), db, "migrations", func(ctx context.Context) error {
+		return errors.New("migration failure")
+	})
+
+	s.EqualError(err, "migration failure")
+	s.NoError(sqlMock.ExpectationsWereMet())
+
+	sqlMock.ExpectQuery("SELECT pg_advisory_lock").WithArgs(key).WillReturnError(context.DeadlineExceeded)
+	s.ErrorIs(WithAdvisoryLock(context.Background(), db, "migrations", nil), context.DeadlineExceeded)
+}
+
+func (s *AdvisoryLockTestSuite) TestTryWithAdvisoryLock() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	key := AdvisoryKey("report")
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(true))
+	sqlMock.ExpectExec("SELECT pg_advisory_unlock").WithArgs(key).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(false))
+
+	runs := 0
+	fn := func(ctx context.Context) error {
+		runs++
+		return nil
+	}
+
+	ran, err := TryWithAdvisoryLock(context.Background(), db, "report", fn)
+	s.NoError(err)
+	s.True(ran)
+
+	ran, err = TryWithAdvisoryLock(context.Background(), db, "report", fn)
+	s.NoError(err)
+	s.False(ran)
+	s.Equal(1, runs)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *AdvisoryLockTestSuite) TestAdvisoryXactLock() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	key := AdvisoryKey("orders")
+
+	_, err := TryAdvisoryXactLock(context.Background(), "orders")
+	s.ErrorIs(err, ErrorTransactionRequired)
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(key).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectQuery("SELECT pg_try_advisory_xact_lock").WithArgs(key).WillReturnRows(sqlmock.NewRows([]string{"acquired"}).AddRow(true))
+	sqlMock.ExpectCommit()
+
+	err = WithAdvisoryXactLock(context.Background(), db, "orders", func(ctx context.Context) error {
+		_, ok := pkgSql.TxFromCtx(ctx)
+		s.True(ok)
+
+		acquired, err := TryAdvisoryXactLock(ctx, "orders")
+		s.True(acquired)
+		return err
+	})
+
+	s.NoError(err)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
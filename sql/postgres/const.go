@@ -2,6 +2,7 @@ package pg
 
 import (
 	"database/sql"
+	"errors"
 
 	"github.com/uptrace/opentelemetry-go-extra/otelsql"
 )
@@ -12,3 +13,7 @@ var otelOpen = otelsql.Open
 const (
 	FailureConnErrorMessage = "[PostgreSQL::Connect] failure to connect to the database: %s"
 )
+
+var (
+	ErrorTransactionRequired = errors.New("[PostgreSQL::AdvisoryLock] the transaction lock requires a transaction in the context")
+)
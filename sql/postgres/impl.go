@@ -1,9 +1,11 @@
 package pg
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/ralvescosta/gokit/env"
 	"github.com/ralvescosta/gokit/logging"
@@ -15,16 +17,27 @@ import (
 )
 
 func New(logger logging.ILogger, cfg *env.Configs, shotdown chan bool) pkgSql.ISqlConnection {
-	connString := pkgSql.GetConnectionString(cfg)
+	connString := pkgSql.NewConnStringBuilder(cfg)
 
 	return &PostgresSqlConnection{
 		logger:           logger,
-		connectionString: connString,
+		connString:       connString,
+		connectionString: connString.Build(),
+		redactedConnStr:  connString.Redacted(),
 		cfg:              cfg,
 		shotdown:         shotdown,
 	}
 }
 
+// Schema override the SQL_DB_SEARCH_PATH, e.g: pg.New(logger, cfg, nil).Schema("billing", "public").Connect()
+func (pg *PostgresSqlConnection) Schema(schemas ...string) pkgSql.ISqlConnection {
+	pg.connString.SearchPath(strings.Join(schemas, ","))
+	pg.connectionString = pg.connString.Build()
+	pg.redactedConnStr = pg.connString.Redacted()
+
+	return pg
+}
+
 func (pg *PostgresSqlConnection) Open() (*sql.DB, error) {
 	var db *sql.DB
 	var err error
@@ -47,14 +60,14 @@ func (pg *PostgresSqlConnection) Open() (*sql.DB, error) {
 func (pg *PostgresSqlConnection) Connect() pkgSql.ISqlConnection {
 	db, err := pg.Open()
 	if err != nil {
-		pg.logger.Error(FailureConnErrorMessage, logging.ErrorField(err))
+		pg.logger.Error(FailureConnErrorMessage, logging.ErrorField(err), logging.MessageField("dsn", pg.redactedConnStr))
 		pg.Err = fmt.Errorf(FailureConnErrorMessage, err.Error())
 		return pg
 	}
 
 	err = db.Ping()
 	if err != nil {
-		pg.logger.Error(FailureConnErrorMessage, logging.ErrorField(err))
+		pg.logger.Error(FailureConnErrorMessage, logging.ErrorField(err), logging.MessageField("dsn", pg.redactedConnStr))
 		pg.Err = fmt.Errorf(FailureConnErrorMessage, err.Error())
 		return pg
 	}
@@ -79,6 +92,35 @@ func (pg *PostgresSqlConnection) ShotdownSignal() pkgSql.ISqlConnection {
 	return pg
 }
 
+// WarmUp the pool keeps the warm connections as idle connections, so the MaxIdleConns is raised to the opts.Connections
+func (pg *PostgresSqlConnection) WarmUp(opts *pkgSql.WarmUpOpts) pkgSql.ISqlConnection {
+	if pg.Err != nil {
+		return pg
+	}
+
+	if opts != nil && opts.Connections > 0 {
+		pg.conn.SetMaxIdleConns(opts.Connections)
+	}
+
+	pg.warmUp = pkgSql.NewPoolWarmUp(pg.logger, pg.conn, opts)
+	go pg.warmUp.Start(context.Background())
+
+	return pg
+}
+
+// Ready pings the connection when the WarmUp was not configured
+func (pg *PostgresSqlConnection) Ready(ctx context.Context) error {
+	if pg.Err != nil {
+		return pg.Err
+	}
+
+	if pg.warmUp == nil {
+		return pg.conn.PingContext(ctx)
+	}
+
+	return pg.warmUp.Ready(ctx)
+}
+
 func (pg *PostgresSqlConnection) Build() (*sql.DB, error) {
 	if pg.Err != nil {
 		return nil, pg.Err
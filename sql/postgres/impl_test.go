@@ -1,10 +1,13 @@
 package pg
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/ralvescosta/gokit/env"
 	"github.com/ralvescosta/gokit/logging"
 	mSQL "github.com/ralvescosta/gokit/sql"
@@ -39,6 +42,13 @@ func (s *PostgresSqlTestSuite) TestNew() {
 	s.IsType(&PostgresSqlConnection{}, conn)
 }
 
+func (s *PostgresSqlTestSuite) TestSchema() {
+	conn := New(&logging.MockLogger{}, &env.Configs{SQL_DB_SEARCH_PATH: "public"}, nil).Schema("billing", "public").(*PostgresSqlConnection)
+
+	s.Contains(conn.connectionString, "options='-c search_path=billing,public'")
+	s.Contains(conn.redactedConnStr, "search_path=billing,public")
+}
+
 func (s *PostgresSqlTestSuite) TestOpen() {
 	s.driverConn.On("Ping", mock.AnythingOfType("*context.emptyCtx")).Return(nil)
 	s.connector.On("Connect", mock.AnythingOfType("*context.emptyCtx")).Return(s.driverConn, nil)
@@ -162,3 +172,38 @@ func (s *PostgresSqlTestSuite) TestShotdownSignalSignalWithoutRequirements() {
 	s.driverConn.AssertExpectations(s.T())
 	s.connector.AssertExpectations(s.T())
 }
+
+func (s *PostgresSqlTestSuite) TestWarmUp() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	sqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+		return db, nil
+	}
+
+	conn := New(&logging.MockLogger{}, &env.Configs{}, nil).Connect().WarmUp(&mSQL.WarmUpOpts{Connections: 2})
+
+	s.Eventually(func() bool { return conn.Ready(context.Background()) == nil }, time.Second, time.Millisecond)
+	s.Equal(2, db.Stats().Idle)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *PostgresSqlTestSuite) TestReady() {
+	db, _, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+		return db, nil
+	}
+
+	s.NoError(New(&logging.MockLogger{}, &env.Configs{}, nil).Connect().Ready(context.Background()))
+
+	sqlOpen = func(driverName, dataSourceName string) (*sql.DB, error) {
+		return nil, errors.New("some err")
+	}
+
+	conn := New(&logging.MockLogger{}, &env.Configs{}, nil).Connect().WarmUp(nil)
+	s.Error(conn.Ready(context.Background()))
+}
@@ -5,13 +5,18 @@ import (
 
 	"github.com/ralvescosta/gokit/env"
 	"github.com/ralvescosta/gokit/logging"
+	pkgSql "github.com/ralvescosta/gokit/sql"
 )
 
 type PostgresSqlConnection struct {
 	Err              error
 	logger           logging.ILogger
+	connString       pkgSql.ConnStringBuilder
 	connectionString string
+	redactedConnStr  string
 	conn             *sql.DB
 	cfg              *env.Configs
 	shotdown         chan bool
+
+	warmUp pkgSql.IPoolWarmUp
 }
@@ -0,0 +1,41 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+type schemaCtxKey struct{}
+
+// WithSchema set the postgres schema used by the transactions started with the ctx, so the bounded contexts of a modular monolith share the pool isolating their tables
+//
+// The schema is applied with SET LOCAL when WithTx begins the transaction, the statements run outside a transaction use the search_path of the connection, see the pg builder Schema
+//
+//	ctx = sql.WithSchema(ctx, "billing")
+//	err := invoices.WithTx(ctx, func(ctx context.Context) error { ... })
+func WithSchema(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, schemaCtxKey{}, name)
+}
+
+// SchemaFromCtx get the schema from the context
+func SchemaFromCtx(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(schemaCtxKey{}).(string)
+	return name, ok && name != ""
+}
+
+// QuoteIdentifier quote the postgres identifier, e.g: the schema and table names
+func QuoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// setTxSchema the set_config with is_local true is the SET LOCAL search_path, it is reset by the commit or the rollback
+func setTxSchema(ctx context.Context, tx *sql.Tx) error {
+	name, ok := SchemaFromCtx(ctx)
+	if !ok {
+		return nil
+	}
+
+	_, err := tx.ExecContext(ctx, "SELECT set_config('search_path', $1, true)", QuoteIdentifier(name))
+	return err
+}
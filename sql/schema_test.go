@@ -0,0 +1,66 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SchemaTestSuite struct {
+	suite.Suite
+}
+
+func TestSchemaTestSuite(t *testing.T) {
+	suite.Run(t, new(SchemaTestSuite))
+}
+
+func (s *SchemaTestSuite) TestQuoteIdentifier() {
+	s.Equal(`"billing"`, QuoteIdentifier("billing"))
+	s.Equal(`"my""schema"`, QuoteIdentifier(`my"schema`))
+}
+
+func (s *SchemaTestSuite) TestWithTxSchema() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SELECT set_config").WithArgs(`"billing"`).WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec("INSERT INTO invoices").WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	ctx := WithSchema(context.Background(), "billing")
+	name, ok := SchemaFromCtx(ctx)
+	s.True(ok)
+	s.Equal("billing", name)
+
+	invoices := NewRepository(db)
+	err := invoices.WithTx(ctx, func(ctx context.Context) error {
+		_, err := invoices.Querier(ctx).ExecContext(ctx, "INSERT INTO invoices")
+		return err
+	})
+
+	s.NoError(err)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *SchemaTestSuite) TestWithTxSchemaErr() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("SELECT set_config").WillReturnError(errors.New("some error"))
+	sqlMock.ExpectRollback()
+
+	called := false
+	err := WithTx(WithSchema(context.Background(), "billing"), db, func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	s.Error(err)
+	s.False(called)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,152 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type (
+	// SoftDelete embed in the models stored in tables with the deleted_at TIMESTAMPTZ NULL column
+	SoftDelete struct {
+		DeletedAt sql.NullTime `json:"deletedAt,omitempty"`
+	}
+
+	// Versioned embed in the models stored in tables with the version BIGINT NOT NULL DEFAULT 1 column
+	Versioned struct {
+		Version int64 `json:"version"`
+	}
+
+	// StaleObjectError returned when a version-checked update does not match any row, errors.Is(err, ErrStaleObject) is true
+	StaleObjectError struct {
+		Table   string
+		ID      any
+		Version int64
+	}
+
+	// Table query helpers to the tables following the soft-delete and the optimistic locking conventions
+	Table struct {
+		Name            string
+		IDColumn        string
+		DeletedAtColumn string
+		VersionColumn   string
+	}
+)
+
+func (s SoftDelete) IsDeleted() bool {
+	return s.DeletedAt.Valid
+}
+
+func (e *StaleObjectError) Error() string {
+	return fmt.Sprintf("sql stale object %s id %v version %d", e.Table, e.ID, e.Version)
+}
+
+func (e *StaleObjectError) Is(target error) bool {
+	return target == ErrStaleObject
+}
+
+// NewTable create the table helper with the default column names
+func NewTable(name string) *Table {
+	return &Table{
+		Name:            name,
+		IDColumn:        DefaultIDColumn,
+		DeletedAtColumn: DefaultDeletedAtColumn,
+		VersionColumn:   DefaultVersionColumn,
+	}
+}
+
+// NotDeleted returns the filter to the rows that are not soft-deleted
+func (t *Table) NotDeleted() string {
+	return t.DeletedAtColumn + " IS NULL"
+}
+
+// Select build a select filtering the soft-deleted rows, where is optional
+//
+//	t.Select("id, name", "email = $1") // SELECT id, name FROM users WHERE deleted_at IS NULL AND (email = $1)
+func (t *Table) Select(columns, where string) string {
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s", columns, t.Name, t.NotDeleted())
+	if where != "" {
+		query += " AND (" + where + ")"
+	}
+
+	return query
+}
+
+// SoftDelete set the deleted_at column, sql.ErrNoRows is returned when the row does not exist or was already deleted
+func (t *Table) SoftDelete(ctx context.Context, q Querier, id any) error {
+	query := fmt.Sprintf("UPDATE %s SET %s = $1 WHERE %s = $2 AND %s", t.Name, t.DeletedAtColumn, t.IDColumn, t.NotDeleted())
+
+	result, err := q.ExecContext(ctx, query, systemClock.Now().UTC(), id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, sql.ErrNoRows)
+}
+
+// Restore clear the deleted_at column, sql.ErrNoRows is returned when the row does not exist or was not deleted
+func (t *Table) Restore(ctx context.Context, q Querier, id any) error {
+	query := fmt.Sprintf("UPDATE %s SET %s = NULL WHERE %s = $1 AND %s IS NOT NULL", t.Name, t.DeletedAtColumn, t.IDColumn, t.DeletedAtColumn)
+
+	result, err := q.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	return checkAffected(result, sql.ErrNoRows)
+}
+
+// UpdateVersioned update the columns when the row version is equal to version incrementing the version
+//
+// The new version is returned, a *StaleObjectError is returned when the row was changed, deleted or soft-deleted
+func (t *Table) UpdateVersioned(ctx context.Context, q Querier, id any, version int64, columns []string, args ...any) (int64, error) {
+	if len(columns) != len(args) {
+		return 0, fmt.Errorf("sql update versioned: %d columns and %d args", len(columns), len(args))
+	}
+
+	sets := make([]string, 0, len(columns)+1)
+	for i, c := range columns {
+		sets = append(sets, fmt.Sprintf("%s = $%d", c, i+1))
+	}
+	sets = append(sets, fmt.Sprintf("%s = %s + 1", t.VersionColumn, t.VersionColumn))
+
+	query := fmt.Sprintf(
+		"UPDATE %s SET %s WHERE %s = $%d AND %s = $%d AND %s",
+		t.Name, strings.Join(sets, ", "), t.IDColumn, len(args)+1, t.VersionColumn, len(args)+2, t.NotDeleted(),
+	)
+
+	result, err := q.ExecContext(ctx, query, append(args, id, version)...)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := checkAffected(result, &StaleObjectError{Table: t.Name, ID: id, Version: version}); err != nil {
+		return 0, err
+	}
+
+	return version + 1, nil
+}
+
+// CheckVersion check the result of a custom version-checked update, e.g: UPDATE ... WHERE id = $1 AND version = $2
+func CheckVersion(result sql.Result, table string, id any, version int64) error {
+	return checkAffected(result, &StaleObjectError{Table: table, ID: id, Version: version})
+}
+
+// CheckFound returns sql.ErrNoRows when the update or the delete did not affect any row
+func CheckFound(result sql.Result) error {
+	return checkAffected(result, sql.ErrNoRows)
+}
+
+func checkAffected(result sql.Result, notAffectedErr error) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return notAffectedErr
+	}
+
+	return nil
+}
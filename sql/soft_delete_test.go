@@ -0,0 +1,80 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/clock"
+	"github.com/stretchr/testify/suite"
+)
+
+type SoftDeleteTestSuite struct {
+	suite.Suite
+}
+
+func TestSoftDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(SoftDeleteTestSuite))
+}
+
+func (s *SoftDeleteTestSuite) TestSelect() {
+	t := NewTable("users")
+
+	s.Equal("SELECT id, name FROM users WHERE deleted_at IS NULL", t.Select("id, name", ""))
+	s.Equal("SELECT id FROM users WHERE deleted_at IS NULL AND (email = $1 OR name = $2)", t.Select("id", "email = $1 OR name = $2"))
+}
+
+func (s *SoftDeleteTestSuite) TestSoftDeleteAndRestore() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	now := time.Date(2022, 7, 21, 0, 0, 0, 0, time.UTC)
+	systemClock = clock.NewFake(now)
+	defer func() { systemClock = clock.New() }()
+
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE users SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL")).
+		WithArgs(now, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE users SET deleted_at = $1 WHERE id = $2 AND deleted_at IS NULL")).
+		WithArgs(now, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta("UPDATE users SET deleted_at = NULL WHERE id = $1 AND deleted_at IS NOT NULL")).
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	t := NewTable("users")
+
+	s.NoError(t.SoftDelete(context.Background(), db, 1))
+	s.ErrorIs(t.SoftDelete(context.Background(), db, 1), sql.ErrNoRows)
+	s.NoError(t.Restore(context.Background(), db, 1))
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *SoftDeleteTestSuite) TestUpdateVersioned() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := regexp.QuoteMeta("UPDATE users SET name = $1, email = $2, version = version + 1 WHERE id = $3 AND version = $4 AND deleted_at IS NULL")
+	sqlMock.ExpectExec(query).WithArgs("name", "email", 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	sqlMock.ExpectExec(query).WithArgs("name", "email", 1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	t := NewTable("users")
+
+	version, err := t.UpdateVersioned(context.Background(), db, 1, 2, []string{"name", "email"}, "name", "email")
+	s.NoError(err)
+	s.Equal(int64(3), version)
+
+	_, err = t.UpdateVersioned(context.Background(), db, 1, 2, []string{"name", "email"}, "name", "email")
+	s.ErrorIs(err, ErrStaleObject)
+
+	var stale *StaleObjectError
+	s.ErrorAs(err, &stale)
+	s.Equal(int64(2), stale.Version)
+
+	_, err = t.UpdateVersioned(context.Background(), db, 1, 2, []string{"name"})
+	s.Error(err)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
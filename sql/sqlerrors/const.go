@@ -0,0 +1,49 @@
+package sqlerrors
+
+// Kind the portable class of the driver error
+type Kind string
+
+const (
+	UNKNOWN                Kind = ""
+	UNIQUE_VIOLATION       Kind = "unique_violation"
+	FOREIGN_KEY_VIOLATION  Kind = "foreign_key_violation"
+	NOT_NULL_VIOLATION     Kind = "not_null_violation"
+	CHECK_VIOLATION        Kind = "check_violation"
+	SERIALIZATION_FAILURE  Kind = "serialization_failure"
+	DEADLOCK_DETECTED      Kind = "deadlock_detected"
+	LOCK_NOT_AVAILABLE     Kind = "lock_not_available"
+	QUERY_CANCELED         Kind = "query_canceled"
+	STRING_DATA_TRUNCATION Kind = "string_data_truncation"
+)
+
+// postgresCodes the SQLSTATE codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+var postgresCodes = map[string]Kind{
+	"23505": UNIQUE_VIOLATION,
+	"23503": FOREIGN_KEY_VIOLATION,
+	"23502": NOT_NULL_VIOLATION,
+	"23514": CHECK_VIOLATION,
+	"40001": SERIALIZATION_FAILURE,
+	"40P01": DEADLOCK_DETECTED,
+	"55P03": LOCK_NOT_AVAILABLE,
+	"57014": QUERY_CANCELED,
+	"22001": STRING_DATA_TRUNCATION,
+}
+
+// mysqlNumbers the server error numbers, see https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+var mysqlNumbers = map[uint16]Kind{
+	1062: UNIQUE_VIOLATION,
+	1586: UNIQUE_VIOLATION,
+	1216: FOREIGN_KEY_VIOLATION,
+	1217: FOREIGN_KEY_VIOLATION,
+	1451: FOREIGN_KEY_VIOLATION,
+	1452: FOREIGN_KEY_VIOLATION,
+	1048: NOT_NULL_VIOLATION,
+	1364: NOT_NULL_VIOLATION,
+	3819: CHECK_VIOLATION,
+	1213: DEADLOCK_DETECTED,
+	1205: LOCK_NOT_AVAILABLE,
+	3572: LOCK_NOT_AVAILABLE,
+	1317: QUERY_CANCELED,
+	3024: QUERY_CANCELED,
+	1406: STRING_DATA_TRUNCATION,
+}
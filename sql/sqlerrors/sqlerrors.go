@@ -0,0 +1,122 @@
+// Package sqlerrors decode the postgres and mysql driver errors into portable predicates
+//
+//	if _, err := users.Insert(ctx, u); sqlerrors.IsUniqueViolation(err) {
+//		return ErrEmailAlreadyUsed
+//	}
+package sqlerrors
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/lib/pq"
+)
+
+// sqlStateError is satisfied by the pgx *pgconn.PgError
+type sqlStateError interface {
+	SQLState() string
+}
+
+// Classify returns the Kind of the error wrapped in err, UNKNOWN when it is not a known driver error
+func Classify(err error) Kind {
+	if err == nil {
+		return UNKNOWN
+	}
+
+	if code, ok := Code(err); ok {
+		return postgresCodes[code]
+	}
+
+	if number, ok := mysqlNumber(err); ok {
+		return mysqlNumbers[number]
+	}
+
+	return UNKNOWN
+}
+
+// Code returns the postgres SQLSTATE of the error wrapped in err
+func Code(err error) (string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return string(pqErr.Code), true
+	}
+
+	var stateErr sqlStateError
+	if errors.As(err, &stateErr) {
+		return stateErr.SQLState(), true
+	}
+
+	return "", false
+}
+
+// Constraint returns the violated constraint name reported by postgres, e.g: users_email_key
+func Constraint(err error) string {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Constraint
+	}
+
+	return ""
+}
+
+func IsUniqueViolation(err error) bool {
+	return Classify(err) == UNIQUE_VIOLATION
+}
+
+func IsForeignKeyViolation(err error) bool {
+	return Classify(err) == FOREIGN_KEY_VIOLATION
+}
+
+func IsNotNullViolation(err error) bool {
+	return Classify(err) == NOT_NULL_VIOLATION
+}
+
+func IsCheckViolation(err error) bool {
+	return Classify(err) == CHECK_VIOLATION
+}
+
+func IsSerializationFailure(err error) bool {
+	return Classify(err) == SERIALIZATION_FAILURE
+}
+
+func IsDeadlock(err error) bool {
+	return Classify(err) == DEADLOCK_DETECTED
+}
+
+func IsLockNotAvailable(err error) bool {
+	return Classify(err) == LOCK_NOT_AVAILABLE
+}
+
+func IsQueryCanceled(err error) bool {
+	return Classify(err) == QUERY_CANCELED
+}
+
+// IsRetryable the transaction can succeed when it is run again: serialization failures, deadlocks and lock timeouts
+func IsRetryable(err error) bool {
+	switch Classify(err) {
+	case SERIALIZATION_FAILURE, DEADLOCK_DETECTED, LOCK_NOT_AVAILABLE:
+		return true
+	default:
+		return false
+	}
+}
+
+// mysqlNumber the *mysql.MySQLError is read by reflection, so the module does not depend on the mysql driver
+func mysqlNumber(err error) (uint16, bool) {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		if v.Kind() == reflect.Pointer {
+			v = v.Elem()
+		}
+
+		if v.Kind() != reflect.Struct || v.Type().Name() != "MySQLError" {
+			continue
+		}
+
+		if number := v.FieldByName("Number"); number.IsValid() && number.Kind() == reflect.Uint16 {
+			return uint16(number.Uint()), true
+		}
+	}
+
+	return 0, false
+}
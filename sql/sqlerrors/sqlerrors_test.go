@@ -0,0 +1,86 @@
+package sqlerrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	// MySQLError the go-sql-driver/mysql error shape
+	MySQLError struct {
+		Number  uint16
+		Message string
+	}
+
+	pgconnError struct {
+		code string
+	}
+)
+
+func (e *MySQLError) Error() string {
+	return e.Message
+}
+
+func (e *pgconnError) Error() string {
+	return e.code
+}
+
+func (e *pgconnError) SQLState() string {
+	return e.code
+}
+
+type SqlErrorsTestSuite struct {
+	suite.Suite
+}
+
+func TestSqlErrorsTestSuite(t *testing.T) {
+	suite.Run(t, new(SqlErrorsTestSuite))
+}
+
+func (s *SqlErrorsTestSuite) TestPostgres() {
+	err := fmt.Errorf("insert user: %w", &pq.Error{Code: "23505", Constraint: "users_email_key"})
+
+	s.True(IsUniqueViolation(err))
+	s.False(IsForeignKeyViolation(err))
+	s.Equal("users_email_key", Constraint(err))
+
+	code, ok := Code(err)
+	s.True(ok)
+	s.Equal("23505", code)
+
+	s.True(IsForeignKeyViolation(&pq.Error{Code: "23503"}))
+	s.True(IsNotNullViolation(&pq.Error{Code: "23502"}))
+	s.True(IsCheckViolation(&pq.Error{Code: "23514"}))
+	s.True(IsSerializationFailure(&pq.Error{Code: "40001"}))
+	s.True(IsDeadlock(&pq.Error{Code: "40P01"}))
+	s.True(IsLockNotAvailable(&pq.Error{Code: "55P03"}))
+	s.True(IsQueryCanceled(&pq.Error{Code: "57014"}))
+	s.Equal(UNKNOWN, Classify(&pq.Error{Code: "42P01"}))
+}
+
+func (s *SqlErrorsTestSuite) TestSQLState() {
+	s.True(IsSerializationFailure(fmt.Errorf("commit: %w", &pgconnError{"40001"})))
+	s.Empty(Constraint(&pgconnError{"40001"}))
+}
+
+func (s *SqlErrorsTestSuite) TestMySQL() {
+	s.True(IsUniqueViolation(fmt.Errorf("insert user: %w", &MySQLError{Number: 1062})))
+	s.True(IsForeignKeyViolation(&MySQLError{Number: 1452}))
+	s.True(IsDeadlock(&MySQLError{Number: 1213}))
+	s.Equal(UNKNOWN, Classify(&MySQLError{Number: 1146}))
+
+	_, ok := Code(&MySQLError{Number: 1062})
+	s.False(ok)
+}
+
+func (s *SqlErrorsTestSuite) TestIsRetryable() {
+	s.True(IsRetryable(&pq.Error{Code: "40001"}))
+	s.True(IsRetryable(&MySQLError{Number: 1205}))
+	s.False(IsRetryable(&pq.Error{Code: "23505"}))
+	s.False(IsRetryable(errors.New("some error")))
+	s.False(IsRetryable(nil))
+}
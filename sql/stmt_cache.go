@@ -0,0 +1,249 @@
+package sql
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// StmtCacheOpts prepared statement cache configuration
+	StmtCacheOpts struct {
+		// MaxSize the number of prepared statements kept, the least recently used is closed when the cache is full
+		MaxSize int
+	}
+
+	// StmtCacheMetrics snapshot of the cache
+	StmtCacheMetrics struct {
+		Hits      uint64
+		Misses    uint64
+		Evictions uint64
+		Size      int
+	}
+
+	// IStmtCache prepare each query only once and reuse the statement for the next calls
+	IStmtCache interface {
+		// Prepare returns the cached statement of the query, preparing it when the query was not cached
+		//
+		// release must be called when the statement is not used anymore, the evicted statements are closed on the last release
+		Prepare(ctx context.Context, query string) (stmt *sql.Stmt, release func(), err error)
+		ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+		QueryRowContext(ctx context.Context, query string, args ...any) (*sql.Row, error)
+		// TxStmt returns the cached statement bound to the transaction
+		TxStmt(ctx context.Context, tx *sql.Tx, query string) (*sql.Stmt, error)
+		Metrics() StmtCacheMetrics
+		// Close closes all the cached statements, the db connection is not closed
+		Close() error
+	}
+
+	cachedStmt struct {
+		query string
+		stmt  *sql.Stmt
+		// refs the callers using the statement, guarded by the cache mu
+		refs    int
+		evicted bool
+	}
+
+	StmtCache struct {
+		logger  logging.ILogger
+		db      *sql.DB
+		maxSize int
+
+		mu        sync.Mutex
+		items     map[string]*list.Element
+		lru       *list.List
+		closed    bool
+		hits      uint64
+		misses    uint64
+		evictions uint64
+	}
+)
+
+// NewStmtCache create a prepared statement cache on top of the db connection, opts is optional
+func NewStmtCache(logger logging.ILogger, db *sql.DB, opts *StmtCacheOpts) IStmtCache {
+	maxSize := DefaultStmtCacheSize
+	if opts != nil && opts.MaxSize > 0 {
+		maxSize = opts.MaxSize
+	}
+
+	return &StmtCache{
+		logger:  logger,
+		db:      db,
+		maxSize: maxSize,
+		items:   map[string]*list.Element{},
+		lru:     list.New(),
+	}
+}
+
+func (c *StmtCache) Prepare(ctx context.Context, query string) (*sql.Stmt, func(), error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil, nil, ErrorStmtCacheClosed
+	}
+
+	if el, ok := c.items[query]; ok {
+		c.lru.MoveToFront(el)
+		c.hits++
+		item := c.acquire(el)
+		c.mu.Unlock()
+		return item.stmt, func() { c.release(item) }, nil
+	}
+	c.misses++
+	c.mu.Unlock()
+
+	// the statement is prepared without holding the lock to not block the other queries
+	stmt, err := c.db.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		c.closeStmt(stmt)
+		return nil, nil, ErrorStmtCacheClosed
+	}
+
+	// other goroutine prepared the same query meanwhile
+	if el, ok := c.items[query]; ok {
+		c.closeStmt(stmt)
+		c.lru.MoveToFront(el)
+		item := c.acquire(el)
+		return item.stmt, func() { c.release(item) }, nil
+	}
+
+	el := c.lru.PushFront(&cachedStmt{query: query, stmt: stmt})
+	c.items[query] = el
+	item := c.acquire(el)
+
+	for c.lru.Len() > c.maxSize {
+		c.evict(c.lru.Back())
+	}
+
+	return item.stmt, func() { c.release(item) }, nil
+}
+
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	stmt, release, err := c.Prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return stmt.ExecContext(ctx, args...)
+}
+
+// QueryContext the statement is released when the query returns, the database/sql package keeps the driver statement
+// open while the rows are read
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	stmt, release, err := c.Prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return stmt.QueryContext(ctx, args...)
+}
+
+func (c *StmtCache) QueryRowContext(ctx context.Context, query string, args ...any) (*sql.Row, error) {
+	stmt, release, err := c.Prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return stmt.QueryRowContext(ctx, args...), nil
+}
+
+// TxStmt the transaction statement depends on the cached statement, so the cached statement is released right away
+func (c *StmtCache) TxStmt(ctx context.Context, tx *sql.Tx, query string) (*sql.Stmt, error) {
+	stmt, release, err := c.Prepare(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	return tx.StmtContext(ctx, stmt), nil
+}
+
+func (c *StmtCache) Metrics() StmtCacheMetrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return StmtCacheMetrics{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Size:      c.lru.Len(),
+	}
+}
+
+// Close the statements in use are closed on their last release
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+
+	var err error
+	for el := c.lru.Front(); el != nil; el = el.Next() {
+		item := el.Value.(*cachedStmt)
+		item.evicted = true
+
+		if item.refs > 0 {
+			continue
+		}
+
+		if cErr := item.stmt.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+
+	c.items = map[string]*list.Element{}
+	c.lru.Init()
+
+	return err
+}
+
+func (c *StmtCache) acquire(el *list.Element) *cachedStmt {
+	item := el.Value.(*cachedStmt)
+	item.refs++
+	return item
+}
+
+func (c *StmtCache) release(item *cachedStmt) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	item.refs--
+	if item.evicted && item.refs == 0 {
+		c.closeStmt(item.stmt)
+	}
+}
+
+// evict remove the statement from the cache, it is closed when the callers using it release it
+func (c *StmtCache) evict(el *list.Element) {
+	item := c.lru.Remove(el).(*cachedStmt)
+	delete(c.items, item.query)
+	c.evictions++
+	item.evicted = true
+
+	if item.refs == 0 {
+		c.closeStmt(item.stmt)
+	}
+}
+
+func (c *StmtCache) closeStmt(stmt *sql.Stmt) {
+	if err := stmt.Close(); err != nil && c.logger != nil {
+		c.logger.Warn(LogMessage("failure to close the prepared statement"), logging.ErrorField(err))
+	}
+}
@@ -0,0 +1,125 @@
+package sql
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/stretchr/testify/suite"
+)
+
+type StmtCacheTestSuite struct {
+	suite.Suite
+}
+
+func TestStmtCacheTestSuite(t *testing.T) {
+	suite.Run(t, new(StmtCacheTestSuite))
+}
+
+func (s *StmtCacheTestSuite) TestPrepareOnce() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	query := "UPDATE users SET name = $1 WHERE id = $2"
+	prep := sqlMock.ExpectPrepare(regexp.QuoteMeta(query))
+	prep.ExpectExec().WithArgs("name", 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.ExpectExec().WithArgs("other", 2).WillReturnResult(sqlmock.NewResult(0, 1))
+	prep.WillBeClosed()
+
+	cache := NewStmtCache(logging.NewMockLogger(), db, nil)
+
+	_, err := cache.ExecContext(context.Background(), query, "name", 1)
+	s.NoError(err)
+	_, err = cache.ExecContext(context.Background(), query, "other", 2)
+	s.NoError(err)
+
+	s.Equal(StmtCacheMetrics{Hits: 1, Misses: 1, Size: 1}, cache.Metrics())
+
+	s.NoError(cache.Close())
+	s.NoError(sqlMock.ExpectationsWereMet())
+
+	_, _, err = cache.Prepare(context.Background(), query)
+	s.ErrorIs(err, ErrorStmtCacheClosed)
+}
+
+func (s *StmtCacheTestSuite) TestEviction() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectPrepare("SELECT 1").WillBeClosed()
+	sqlMock.ExpectPrepare("SELECT 2")
+	sqlMock.ExpectPrepare("SELECT 3")
+
+	cache := NewStmtCache(logging.NewMockLogger(), db, &StmtCacheOpts{MaxSize: 2})
+
+	for _, query := range []string{"SELECT 1", "SELECT 2", "SELECT 2", "SELECT 3"} {
+		_, release, err := cache.Prepare(context.Background(), query)
+		s.NoError(err)
+		release()
+	}
+
+	s.Equal(StmtCacheMetrics{Hits: 1, Misses: 3, Evictions: 1, Size: 2}, cache.Metrics())
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *StmtCacheTestSuite) TestEvictionInUse() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	prep := sqlMock.ExpectPrepare("SELECT 1")
+	sqlMock.ExpectPrepare("SELECT 2")
+	prep.ExpectQuery().WillReturnRows(sqlmock.NewRows([]string{"n"}).AddRow(1))
+	prep.WillBeClosed()
+
+	cache := NewStmtCache(logging.NewMockLogger(), db, &StmtCacheOpts{MaxSize: 1})
+
+	stmt, release, err := cache.Prepare(context.Background(), "SELECT 1")
+	s.Require().NoError(err)
+
+	// the statement in use is evicted but kept open until it is released
+	_, releaseOther, err := cache.Prepare(context.Background(), "SELECT 2")
+	s.Require().NoError(err)
+	releaseOther()
+
+	var n int
+	s.NoError(stmt.QueryRowContext(context.Background()).Scan(&n))
+	s.Equal(1, n)
+
+	release()
+
+	s.Equal(StmtCacheMetrics{Misses: 2, Evictions: 1, Size: 1}, cache.Metrics())
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *StmtCacheTestSuite) TestCloseInUse() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectPrepare("SELECT 1").WillBeClosed()
+
+	cache := NewStmtCache(logging.NewMockLogger(), db, nil)
+
+	_, release, err := cache.Prepare(context.Background(), "SELECT 1")
+	s.Require().NoError(err)
+
+	s.NoError(cache.Close())
+	s.Error(sqlMock.ExpectationsWereMet())
+
+	release()
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *StmtCacheTestSuite) TestPrepareErr() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectPrepare("SELECT 1").WillReturnError(sqlmock.ErrCancelled)
+
+	cache := NewStmtCache(logging.NewMockLogger(), db, nil)
+
+	_, err := cache.QueryContext(context.Background(), "SELECT 1")
+	s.Error(err)
+	s.Equal(0, cache.Metrics().Size)
+}
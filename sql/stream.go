@@ -0,0 +1,100 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+)
+
+type (
+	// Scanner the streamed row, *sql.Rows and *sql.Row satisfy it
+	Scanner interface {
+		Scan(dest ...any) error
+	}
+
+	// RowHandler called to each streamed row, the streaming stops when it returns an error
+	RowHandler = func(row Scanner) error
+
+	// RowScanner scan the row into a T
+	RowScanner[T any] func(row Scanner) (T, error)
+
+	// Rows iterates the result set scanning one row at a time, so only the current row is kept in memory
+	//
+	//	rows, err := sql.QueryRows(ctx, db, scanUser, "SELECT id, name FROM users")
+	//	if err != nil {
+	//		return err
+	//	}
+	//	defer rows.Close()
+	//
+	//	for rows.Next() {
+	//		export(rows.Value())
+	//	}
+	//
+	//	return rows.Err()
+	Rows[T any] struct {
+		rows    *sql.Rows
+		scan    RowScanner[T]
+		current T
+		err     error
+	}
+)
+
+// Stream run the query and call fn to each row without loading the result set in memory, the rows are closed before it returns
+func Stream(ctx context.Context, q Querier, query string, args []any, fn RowHandler) error {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := fn(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// Stream see Stream, the query uses the transaction carried in the context when there is one
+func (r Repository) Stream(ctx context.Context, query string, args []any, fn RowHandler) error {
+	return Stream(ctx, r.Querier(ctx), query, args, fn)
+}
+
+// QueryRows run the query and returns the lazy iterator, the caller must close the rows
+func QueryRows[T any](ctx context.Context, q Querier, scan RowScanner[T], query string, args ...any) (*Rows[T], error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rows[T]{rows: rows, scan: scan}, nil
+}
+
+// Next scan the next row, it returns false when there are no more rows or the scan failed, see Err
+func (r *Rows[T]) Next() bool {
+	if r.err != nil || !r.rows.Next() {
+		return false
+	}
+
+	r.current, r.err = r.scan(r.rows)
+
+	return r.err == nil
+}
+
+// Value the row scanned by the last Next
+func (r *Rows[T]) Value() T {
+	return r.current
+}
+
+// Err the scan or the iteration error
+func (r *Rows[T]) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+
+	return r.rows.Err()
+}
+
+func (r *Rows[T]) Close() error {
+	return r.rows.Close()
+}
@@ -0,0 +1,119 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type StreamTestSuite struct {
+	suite.Suite
+}
+
+type streamedUser struct {
+	ID   int64
+	Name string
+}
+
+func TestStreamTestSuite(t *testing.T) {
+	suite.Run(t, new(StreamTestSuite))
+}
+
+func scanStreamedUser(row Scanner) (*streamedUser, error) {
+	u := &streamedUser{}
+	err := row.Scan(&u.ID, &u.Name)
+
+	return u, err
+}
+
+func (s *StreamTestSuite) TestStream() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectQuery("SELECT id, name FROM users").
+		WithArgs("active").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a").AddRow(2, "b")).
+		RowsWillBeClosed()
+
+	names := []string{}
+	err := NewRepository(db).Stream(context.Background(), "SELECT id, name FROM users WHERE status = $1", []any{"active"}, func(row Scanner) error {
+		u, err := scanStreamedUser(row)
+		names = append(names, u.Name)
+		return err
+	})
+
+	s.NoError(err)
+	s.Equal([]string{"a", "b"}, names)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *StreamTestSuite) TestStreamErr() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectQuery("SELECT").WillReturnError(errors.New("some error"))
+	s.Error(Stream(context.Background(), db, "SELECT", nil, nil))
+
+	sqlMock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a").AddRow(2, "b")).
+		RowsWillBeClosed()
+
+	calls := 0
+	err := Stream(context.Background(), db, "SELECT", nil, func(row Scanner) error {
+		calls++
+		return errors.New("stop")
+	})
+
+	s.EqualError(err, "stop")
+	s.Equal(1, calls)
+
+	sqlMock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a").RowError(0, errors.New("row error")))
+	s.EqualError(Stream(context.Background(), db, "SELECT", nil, func(row Scanner) error { return nil }), "row error")
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *StreamTestSuite) TestQueryRows() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectQuery("SELECT id, name FROM users").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(1, "a").AddRow(2, "b")).
+		RowsWillBeClosed()
+
+	rows, err := QueryRows(context.Background(), db, scanStreamedUser, "SELECT id, name FROM users")
+	s.NoError(err)
+
+	users := []*streamedUser{}
+	for rows.Next() {
+		users = append(users, rows.Value())
+	}
+
+	s.NoError(rows.Err())
+	s.NoError(rows.Close())
+	s.Equal([]*streamedUser{{1, "a"}, {2, "b"}}, users)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *StreamTestSuite) TestQueryRowsErr() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectQuery("SELECT").WillReturnError(errors.New("some error"))
+	_, err := QueryRows(context.Background(), db, scanStreamedUser, "SELECT")
+	s.Error(err)
+
+	sqlMock.ExpectQuery("SELECT").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow("invalid", "a").AddRow(2, "b"))
+
+	rows, err := QueryRows(context.Background(), db, scanStreamedUser, "SELECT")
+	s.NoError(err)
+	defer rows.Close()
+
+	s.False(rows.Next())
+	s.False(rows.Next())
+	s.Error(rows.Err())
+}
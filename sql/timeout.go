@@ -0,0 +1,93 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type (
+	// timeoutQuerier apply the query timeout to the ctx of each statement
+	timeoutQuerier struct {
+		Querier
+		timeout time.Duration
+	}
+
+	queryTimeoutCtxKey struct{}
+)
+
+// WithQueryTimeout override the Repository.QueryTimeout to the queries run with the ctx, 0 disables the timeout
+//
+//	ctx = sql.WithQueryTimeout(ctx, time.Minute)
+//	report, err := reports.Generate(ctx)
+func WithQueryTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, queryTimeoutCtxKey{}, timeout)
+}
+
+// QueryTimeoutFromCtx get the timeout override from the context
+func QueryTimeoutFromCtx(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(queryTimeoutCtxKey{}).(time.Duration)
+	return timeout, ok
+}
+
+// NewTimeoutQuerier wraps the querier applying the timeout to each statement, the timeout can be overridden by WithQueryTimeout
+func NewTimeoutQuerier(q Querier, timeout time.Duration) Querier {
+	return &timeoutQuerier{Querier: q, timeout: timeout}
+}
+
+func (q *timeoutQuerier) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	return q.Querier.ExecContext(ctx, query, args...)
+}
+
+func (q *timeoutQuerier) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	ctx, cancel := q.withTimeout(ctx)
+	defer cancel()
+
+	return q.Querier.PrepareContext(ctx, query)
+}
+
+func (q *timeoutQuerier) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	ctx, cancel := q.withTimeout(ctx)
+	rows, err := q.Querier.QueryContext(ctx, query, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	q.releaseAfterTimeout(ctx, cancel)
+
+	return rows, nil
+}
+
+func (q *timeoutQuerier) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	ctx, cancel := q.withTimeout(ctx)
+	row := q.Querier.QueryRowContext(ctx, query, args...)
+
+	q.releaseAfterTimeout(ctx, cancel)
+
+	return row
+}
+
+func (q *timeoutQuerier) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout := q.timeout
+	if override, ok := QueryTimeoutFromCtx(ctx); ok {
+		timeout = override
+	}
+
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+// releaseAfterTimeout the rows are read after the return, so the ctx can not be canceled before they are closed, the
+// context.WithTimeout timer releases it at the deadline without another timer per query
+func (q *timeoutQuerier) releaseAfterTimeout(ctx context.Context, cancel context.CancelFunc) {
+	if _, ok := ctx.Deadline(); !ok {
+		cancel()
+	}
+}
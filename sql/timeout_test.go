@@ -0,0 +1,83 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type QueryTimeoutTestSuite struct {
+	suite.Suite
+}
+
+func TestQueryTimeoutTestSuite(t *testing.T) {
+	suite.Run(t, new(QueryTimeoutTestSuite))
+}
+
+func (s *QueryTimeoutTestSuite) TestQuerierWithoutTimeout() {
+	db, _, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := NewRepository(db)
+	s.Equal(db, repo.Querier(context.Background()))
+
+	repo.QueryTimeout = time.Second
+	s.IsType(&timeoutQuerier{}, repo.Querier(context.Background()))
+}
+
+func (s *QueryTimeoutTestSuite) TestExecTimeout() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := Repository{DB: db, QueryTimeout: 10 * time.Millisecond}
+
+	sqlMock.ExpectExec("UPDATE users").WillDelayFor(time.Second).WillReturnResult(sqlmock.NewResult(0, 1))
+	_, err := repo.Querier(context.Background()).ExecContext(context.Background(), "UPDATE users")
+	s.Error(err)
+
+	sqlMock.ExpectExec("UPDATE users").WillDelayFor(50 * time.Millisecond).WillReturnResult(sqlmock.NewResult(0, 1))
+	ctx := WithQueryTimeout(context.Background(), 0)
+	_, err = repo.Querier(ctx).ExecContext(ctx, "UPDATE users")
+	s.NoError(err)
+}
+
+func (s *QueryTimeoutTestSuite) TestQueryOverride() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectQuery("SELECT id FROM users").WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	sqlMock.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	sqlMock.ExpectQuery("SELECT id FROM users").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	repo := NewRepository(db)
+
+	ctx := WithQueryTimeout(context.Background(), 10*time.Millisecond)
+	_, err := repo.Querier(ctx).QueryContext(ctx, "SELECT id FROM users")
+	s.Error(err)
+
+	timeout, ok := QueryTimeoutFromCtx(ctx)
+	s.True(ok)
+	s.Equal(10*time.Millisecond, timeout)
+
+	// the rows are read after the return
+	ctx = WithQueryTimeout(context.Background(), time.Second)
+	rows, err := repo.Querier(ctx).QueryContext(ctx, "SELECT id FROM users")
+	s.NoError(err)
+
+	ids := []int{}
+	for rows.Next() {
+		id := 0
+		s.NoError(rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	s.NoError(rows.Err())
+	s.NoError(rows.Close())
+	s.Equal([]int{1, 2}, ids)
+
+	id := 0
+	s.NoError(repo.Querier(ctx).QueryRowContext(ctx, "SELECT id FROM users").Scan(&id))
+	s.Equal(3, id)
+}
@@ -0,0 +1,107 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type (
+	// Querier the methods shared by *sql.DB and *sql.Tx
+	Querier interface {
+		ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+		QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+		QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+		PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	}
+
+	// Repository base to the repositories, the queries use the transaction carried in the context when there is one
+	//
+	//	type UserRepository struct{ sql.Repository }
+	//
+	//	func (r *UserRepository) Create(ctx context.Context, u *User) error {
+	//		_, err := r.Querier(ctx).ExecContext(ctx, "INSERT INTO users ...", u.Name)
+	//		return err
+	//	}
+	//
+	// The QueryTimeout is applied to each statement run through the Querier, see WithQueryTimeout to override it per call
+	Repository struct {
+		DB           *sql.DB
+		QueryTimeout time.Duration
+	}
+
+	txCtxKey struct{}
+)
+
+var (
+	_ Querier = (*sql.DB)(nil)
+	_ Querier = (*sql.Tx)(nil)
+)
+
+// CtxWithTx store the transaction in the context
+func CtxWithTx(ctx context.Context, tx *sql.Tx) context.Context {
+	return context.WithValue(ctx, txCtxKey{}, tx)
+}
+
+// TxFromCtx get the transaction from the context
+func TxFromCtx(ctx context.Context) (*sql.Tx, bool) {
+	tx, ok := ctx.Value(txCtxKey{}).(*sql.Tx)
+	return tx, ok && tx != nil
+}
+
+func NewRepository(db *sql.DB) Repository {
+	return Repository{DB: db}
+}
+
+// Querier returns the ambient transaction when present, otherwise the db
+func (r Repository) Querier(ctx context.Context) Querier {
+	var q Querier = r.DB
+	if tx, ok := TxFromCtx(ctx); ok {
+		q = tx
+	}
+
+	if _, ok := QueryTimeoutFromCtx(ctx); ok || r.QueryTimeout > 0 {
+		return NewTimeoutQuerier(q, r.QueryTimeout)
+	}
+
+	return q
+}
+
+// WithTx run fn with a transaction in the context, the transaction is committed when fn returns nil and rolled back otherwise
+//
+// When the ctx already carries a transaction fn joins it and the outermost WithTx commits or rolls back.
+// The schema carried in the ctx is applied to the transaction, see WithSchema
+func (r Repository) WithTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return WithTx(ctx, r.DB, fn)
+}
+
+// WithTx see Repository.WithTx, useful in the service layer to compose many repositories in one transaction
+func WithTx(ctx context.Context, db *sql.DB, fn func(ctx context.Context) error) (err error) {
+	if _, ok := TxFromCtx(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err = setTxSchema(ctx, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err = fn(CtxWithTx(ctx, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,89 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+)
+
+type TxTestSuite struct {
+	suite.Suite
+}
+
+func TestTxTestSuite(t *testing.T) {
+	suite.Run(t, new(TxTestSuite))
+}
+
+func (s *TxTestSuite) TestQuerierWithoutTx() {
+	db, _, _ := sqlmock.New()
+	defer db.Close()
+
+	repo := NewRepository(db)
+
+	s.Equal(db, repo.Querier(context.Background()))
+}
+
+func (s *TxTestSuite) TestWithTxCommit() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec("INSERT INTO users").WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectExec("INSERT INTO profiles").WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	users := NewRepository(db)
+	profiles := NewRepository(db)
+
+	err := WithTx(context.Background(), db, func(ctx context.Context) error {
+		tx, ok := TxFromCtx(ctx)
+		s.True(ok)
+		s.Equal(tx, users.Querier(ctx))
+
+		if _, err := users.Querier(ctx).ExecContext(ctx, "INSERT INTO users"); err != nil {
+			return err
+		}
+
+		// nested transactions join the ambient transaction
+		return profiles.WithTx(ctx, func(ctx context.Context) error {
+			_, err := profiles.Querier(ctx).ExecContext(ctx, "INSERT INTO profiles")
+			return err
+		})
+	})
+
+	s.NoError(err)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *TxTestSuite) TestWithTxRollback() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	err := NewRepository(db).WithTx(context.Background(), func(ctx context.Context) error {
+		return errors.New("some error")
+	})
+
+	s.Error(err)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *TxTestSuite) TestWithTxRollbackOnPanic() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectRollback()
+
+	s.Panics(func() {
+		_ = WithTx(context.Background(), db, func(ctx context.Context) error {
+			panic("some panic")
+		})
+	})
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
@@ -0,0 +1,170 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// WarmUpOpts pool warm-up configuration
+	WarmUpOpts struct {
+		// Connections the number of connections opened before the pool is ready, the db MaxIdleConns must be at least Connections to keep them in the pool
+		Connections int
+		// CanaryQuery executed after the connections were opened, default DefaultCanaryQuery
+		CanaryQuery string
+		// Timeout of each warm-up attempt, zero means no timeout
+		Timeout time.Duration
+		// RetryInterval the interval between the Start attempts, default DefaultWarmUpRetryInterval
+		RetryInterval time.Duration
+	}
+
+	// IPoolWarmUp pre-open the pool connections so the first requests do not pay the connection establishment
+	//
+	//	warmUp := sql.NewPoolWarmUp(logger, db, &sql.WarmUpOpts{Connections: 10})
+	//	go warmUp.Start(ctx)
+	//
+	//	healthBuilder.Readiness(health.CustomProbe("database", warmUp.Ready))
+	IPoolWarmUp interface {
+		// Run open the connections and execute the canary query once
+		Run(ctx context.Context) error
+		// Start call Run until it succeeds or the ctx is done
+		Start(ctx context.Context) error
+		// Ready returns ErrorPoolNotWarm until the warm-up succeeds, then it pings the pool
+		Ready(ctx context.Context) error
+		Warm() bool
+	}
+
+	PoolWarmUp struct {
+		logger logging.ILogger
+		db     *sql.DB
+		opts   WarmUpOpts
+		warm   int32
+	}
+)
+
+// NewPoolWarmUp create the warm-up of the db pool, opts is optional
+func NewPoolWarmUp(logger logging.ILogger, db *sql.DB, opts *WarmUpOpts) IPoolWarmUp {
+	w := &PoolWarmUp{logger: logger, db: db}
+	if opts != nil {
+		w.opts = *opts
+	}
+
+	if w.opts.CanaryQuery == "" {
+		w.opts.CanaryQuery = DefaultCanaryQuery
+	}
+
+	if w.opts.RetryInterval <= 0 {
+		w.opts.RetryInterval = DefaultWarmUpRetryInterval
+	}
+
+	return w
+}
+
+func (w *PoolWarmUp) Run(ctx context.Context) error {
+	if w.opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, w.opts.Timeout)
+		defer cancel()
+	}
+
+	start := systemClock.Now()
+
+	conns, err := w.openConns(ctx)
+	// the connections are released to the idle pool
+	defer func() {
+		for _, c := range conns {
+			_ = c.Close()
+		}
+	}()
+
+	if err != nil {
+		return err
+	}
+
+	var querier Querier = w.db
+	if len(conns) > 0 {
+		querier = conns[0]
+	}
+
+	if _, err := querier.ExecContext(ctx, w.opts.CanaryQuery); err != nil {
+		return fmt.Errorf("%w: %s", ErrorCanaryQuery, err)
+	}
+
+	atomic.StoreInt32(&w.warm, 1)
+	w.logger.Debug(LogMessage(fmt.Sprintf("pool warmed up with %d connections in %s", len(conns), systemClock.Since(start))))
+
+	return nil
+}
+
+func (w *PoolWarmUp) Start(ctx context.Context) error {
+	for {
+		err := w.Run(ctx)
+		if err == nil {
+			return nil
+		}
+
+		w.logger.Warn(LogMessage("failure to warm up the pool"), logging.ErrorField(err))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-systemClock.After(w.opts.RetryInterval):
+		}
+	}
+}
+
+func (w *PoolWarmUp) Ready(ctx context.Context) error {
+	if !w.Warm() {
+		return ErrorPoolNotWarm
+	}
+
+	return w.db.PingContext(ctx)
+}
+
+func (w *PoolWarmUp) Warm() bool {
+	return atomic.LoadInt32(&w.warm) == 1
+}
+
+// openConns the connections are opened concurrently, the opened ones are returned with the first error
+func (w *PoolWarmUp) openConns(ctx context.Context) ([]*sql.Conn, error) {
+	conns := make([]*sql.Conn, w.opts.Connections)
+	errs := make([]error, w.opts.Connections)
+
+	wg := sync.WaitGroup{}
+	for i := range conns {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			conn, err := w.db.Conn(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+
+			conns[i] = conn
+			errs[i] = conn.PingContext(ctx)
+		}(i)
+	}
+	wg.Wait()
+
+	opened := make([]*sql.Conn, 0, len(conns))
+	var err error
+	for i, c := range conns {
+		if c != nil {
+			opened = append(opened, c)
+		}
+
+		if err == nil && errs[i] != nil {
+			err = errs[i]
+		}
+	}
+
+	return opened, err
+}
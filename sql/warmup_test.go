@@ -0,0 +1,78 @@
+package sql
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type PoolWarmUpTestSuite struct {
+	suite.Suite
+}
+
+func TestPoolWarmUpTestSuite(t *testing.T) {
+	suite.Run(t, new(PoolWarmUpTestSuite))
+}
+
+func (s *PoolWarmUpTestSuite) TestNewPoolWarmUp() {
+	w := NewPoolWarmUp(logging.NewMockLogger(), nil, nil).(*PoolWarmUp)
+
+	s.Equal(DefaultCanaryQuery, w.opts.CanaryQuery)
+	s.Equal(DefaultWarmUpRetryInterval, w.opts.RetryInterval)
+}
+
+func (s *PoolWarmUpTestSuite) TestRun() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+	db.SetMaxIdleConns(3)
+
+	sqlMock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	w := NewPoolWarmUp(logging.NewMockLogger(), db, &WarmUpOpts{Connections: 3, Timeout: time.Second})
+	s.ErrorIs(w.Ready(context.Background()), ErrorPoolNotWarm)
+
+	s.NoError(w.Run(context.Background()))
+	s.True(w.Warm())
+	s.NoError(w.Ready(context.Background()))
+	s.Equal(3, db.Stats().Idle)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *PoolWarmUpTestSuite) TestRunCanaryErr() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectExec("SELECT now()").WillReturnError(errors.New("some error"))
+
+	w := NewPoolWarmUp(logging.NewMockLogger(), db, &WarmUpOpts{CanaryQuery: "SELECT now()"})
+
+	s.ErrorIs(w.Run(context.Background()), ErrorCanaryQuery)
+	s.False(w.Warm())
+	s.ErrorIs(w.Ready(context.Background()), ErrorPoolNotWarm)
+}
+
+func (s *PoolWarmUpTestSuite) TestStart() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectExec("SELECT 1").WillReturnError(errors.New("some error"))
+	sqlMock.ExpectExec("SELECT 1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	w := NewPoolWarmUp(logging.NewMockLogger(), db, &WarmUpOpts{Connections: 1, RetryInterval: time.Millisecond})
+
+	s.NoError(w.Start(context.Background()))
+	s.True(w.Warm())
+	s.NoError(sqlMock.ExpectationsWereMet())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	sqlMock.ExpectExec("SELECT 1").WillReturnError(errors.New("some error"))
+
+	s.ErrorIs(NewPoolWarmUp(logging.NewMockLogger(), db, nil).Start(ctx), context.Canceled)
+}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+type messageArchive struct {
+	storage IStorage
+	prefix  string
+}
+
+// archiveDayLayout the archived messages are partitioned by queue and day, e.g: archive/orders/2022/07/21/
+const archiveDayLayout = "2006/01/02/"
+
+// NewMessageArchive archive the consumed messages as JSON objects, one per message, partitioned by queue and day, so the
+// replays of a time window only list the days of the window, see rabbitmq.ArchiveOpts
+func NewMessageArchive(st IStorage, prefix string) rabbitmq.ArchiveStore {
+	if prefix == "" {
+		prefix = DefaultArchivePrefix
+	}
+
+	return &messageArchive{st, strings.TrimSuffix(prefix, "/") + "/"}
+}
+
+func (a *messageArchive) Archive(ctx context.Context, msg *rabbitmq.ArchivedMessage) error {
+	byt, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	ts := msg.Timestamp.UTC()
+	// the timestamp prefix keeps the keys of the day ordered by the archive time
+	key := fmt.Sprintf("%s%s%020d-%s.json", a.queuePrefix(msg.Queue), ts.Format(archiveDayLayout), ts.UnixNano(), strings.ReplaceAll(msg.MessageId, "/", "_"))
+
+	_, err = a.storage.Put(ctx, key, bytes.NewReader(byt), int64(len(byt)), &PutOpts{ContentType: "application/json"})
+	return err
+}
+
+func (a *messageArchive) Query(ctx context.Context, filter *rabbitmq.ReplayFilter) ([]*rabbitmq.ArchivedMessage, error) {
+	keys := []string{}
+	for _, prefix := range a.dayPrefixes(filter) {
+		objects, err := a.storage.List(ctx, prefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, obj := range objects {
+			if a.inWindow(obj.Key, filter) {
+				keys = append(keys, obj.Key)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	messages := []*rabbitmq.ArchivedMessage{}
+	for _, key := range keys {
+		if filter.Limit > 0 && len(messages) >= filter.Limit {
+			break
+		}
+
+		msg, err := a.get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if filter.Matches(msg) {
+			messages = append(messages, msg)
+		}
+	}
+
+	return messages, nil
+}
+
+func (a *messageArchive) queuePrefix(queue string) string {
+	return a.prefix + queue + "/"
+}
+
+// dayPrefixes the whole queue is listed when the window has no start
+func (a *messageArchive) dayPrefixes(filter *rabbitmq.ReplayFilter) []string {
+	if filter.From.IsZero() {
+		return []string{a.queuePrefix(filter.Queue)}
+	}
+
+	to := filter.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+
+	prefixes := []string{}
+	for day := filter.From.UTC().Truncate(24 * time.Hour); day.Before(to); day = day.Add(24 * time.Hour) {
+		prefixes = append(prefixes, a.queuePrefix(filter.Queue)+day.Format(archiveDayLayout))
+	}
+
+	return prefixes
+}
+
+// inWindow filter the keys by the timestamp before reading the objects
+func (a *messageArchive) inWindow(key string, filter *rabbitmq.ReplayFilter) bool {
+	name := key[strings.LastIndex(key, "/")+1:]
+	nanos, _, _ := strings.Cut(name, "-")
+
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	ts := time.Unix(0, n)
+	return (filter.From.IsZero() || !ts.Before(filter.From)) && (filter.To.IsZero() || ts.Before(filter.To))
+}
+
+func (a *messageArchive) get(ctx context.Context, key string) (*rabbitmq.ArchivedMessage, error) {
+	reader, _, err := a.storage.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	byt, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &rabbitmq.ArchivedMessage{}
+	return msg, json.Unmarshal(byt, msg)
+}
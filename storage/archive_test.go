@@ -0,0 +1,61 @@
+package storage
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/suite"
+)
+
+type MessageArchiveTestSuite struct {
+	suite.Suite
+
+	storage *memoryStorage
+	archive rabbitmq.ArchiveStore
+}
+
+func TestMessageArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(MessageArchiveTestSuite))
+}
+
+func (s *MessageArchiveTestSuite) SetupTest() {
+	s.storage = &memoryStorage{contents: map[string]string{}}
+	s.archive = NewMessageArchive(s.storage, "")
+}
+
+func (s *MessageArchiveTestSuite) TestArchiveAndQuery() {
+	ctx := context.Background()
+	yesterday := time.Date(2022, 7, 20, 23, 0, 0, 0, time.UTC)
+	today := yesterday.Add(2 * time.Hour)
+
+	for i, msg := range []*rabbitmq.ArchivedMessage{
+		{Queue: "orders", MessageId: "1", Type: "created", Timestamp: yesterday, Body: []byte(`{"id":1}`)},
+		{Queue: "orders", MessageId: "2", Type: "updated", Timestamp: today},
+		{Queue: "orders", MessageId: "3", Type: "created", Timestamp: today.Add(time.Minute)},
+		{Queue: "payments", MessageId: "4", Type: "created", Timestamp: today},
+	} {
+		s.Require().NoError(s.archive.Archive(ctx, msg), i)
+	}
+
+	for key := range s.storage.contents {
+		s.True(strings.HasPrefix(key, DefaultArchivePrefix))
+	}
+
+	messages, err := s.archive.Query(ctx, &rabbitmq.ReplayFilter{Queue: "orders"})
+	s.NoError(err)
+	s.Require().Len(messages, 3)
+	s.Equal("1", messages[0].MessageId)
+	s.Equal(`{"id":1}`, string(messages[0].Body))
+
+	messages, err = s.archive.Query(ctx, &rabbitmq.ReplayFilter{Queue: "orders", From: yesterday.Add(time.Minute), To: today.Add(time.Hour), Types: []string{"created"}})
+	s.NoError(err)
+	s.Require().Len(messages, 1)
+	s.Equal("3", messages[0].MessageId)
+
+	messages, err = s.archive.Query(ctx, &rabbitmq.ReplayFilter{Queue: "orders", Limit: 2})
+	s.NoError(err)
+	s.Len(messages, 2)
+}
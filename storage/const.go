@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	S3_PROVIDER    Provider = "s3"
+	GCS_PROVIDER   Provider = "gcs"
+	MINIO_PROVIDER Provider = "minio"
+
+	GCSEndpoint   = "storage.googleapis.com"
+	DefaultRegion = "us-east-1"
+
+	// DefaultPartSize size of each part in the streaming multipart upload
+	DefaultPartSize   = 16 * 1024 * 1024
+	DefaultPresignTTL = 15 * time.Minute
+
+	// UnknownSize used as Put size to stream the reader with multipart upload
+	UnknownSize int64 = -1
+
+	DefaultMaxValuesSize int64 = 1024 * 1024
+
+	// DefaultArchivePrefix the prefix of the archived messages, see NewMessageArchive
+	DefaultArchivePrefix = "archive/"
+)
+
+var (
+	ErrorInvalidConfig   = errors.New("storage requires a bucket, access key and secret key")
+	ErrorUnknownProvider = errors.New("unknown storage provider")
+	ErrorEmptyKey        = errors.New("object key is required")
+	ErrorInvalidMethod   = errors.New("presign supports only GET, PUT, HEAD and DELETE")
+	ErrorNotFound        = errors.New("object not found")
+
+	ErrorNotMultipart          = errors.New("the request is not multipart/form-data")
+	ErrorFileTooLarge          = errors.New("the uploaded file is too large")
+	ErrorTooManyFiles          = errors.New("too many uploaded files")
+	ErrorContentTypeNotAllowed = errors.New("the uploaded file content type is not allowed")
+	ErrorFormValuesTooLarge    = errors.New("the form values are too large")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::storage] " + msg
+}
@@ -0,0 +1,316 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/retry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// NewConfig create the storage configuration from the env Storage() configs
+func NewConfig(cfg *env.Configs) *Config {
+	return &Config{
+		Provider:  Provider(cfg.STORAGE_PROVIDER),
+		Endpoint:  cfg.STORAGE_ENDPOINT,
+		Region:    cfg.STORAGE_REGION,
+		AccessKey: cfg.STORAGE_ACCESS_KEY,
+		SecretKey: cfg.STORAGE_SECRET_KEY,
+		Bucket:    cfg.STORAGE_BUCKET,
+		SSL:       cfg.IS_STORAGE_SSL_ENABLED,
+	}
+}
+
+// New create the storage client, no request is made until the first operation
+func New(logger logging.ILogger, cfg *Config) (IStorage, error) {
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.SSL,
+		Region: cfg.Region,
+	})
+	if err != nil {
+		logger.Error(LogMessage("failure to create the client"), logging.ErrorField(err))
+		return nil, err
+	}
+
+	policy := cfg.Retry
+	if policy == nil {
+		policy = retry.DefaultPolicy()
+	}
+
+	return &objectStorage{
+		logger: logger,
+		client: client,
+		bucket: cfg.Bucket,
+		retry:  policy,
+		tracer: otel.Tracer("gokit/storage"),
+	}, nil
+}
+
+func (c *Config) validate() error {
+	if c.Bucket == "" || c.AccessKey == "" || c.SecretKey == "" {
+		return ErrorInvalidConfig
+	}
+
+	if c.Provider == "" {
+		c.Provider = S3_PROVIDER
+	}
+
+	switch c.Provider {
+	case S3_PROVIDER:
+		if c.Region == "" {
+			c.Region = DefaultRegion
+		}
+		if c.Endpoint == "" {
+			c.Endpoint = fmt.Sprintf("s3.%s.amazonaws.com", c.Region)
+		}
+	case GCS_PROVIDER:
+		if c.Region == "" {
+			c.Region = "auto"
+		}
+		if c.Endpoint == "" {
+			c.Endpoint = GCSEndpoint
+		}
+	case MINIO_PROVIDER:
+		if c.Endpoint == "" {
+			return ErrorInvalidConfig
+		}
+		// without region minio-go makes a request to discover the bucket location
+		if c.Region == "" {
+			c.Region = DefaultRegion
+		}
+	default:
+		return ErrorUnknownProvider
+	}
+
+	return nil
+}
+
+// Put is not retried because the reader can not be rewound
+func (s *objectStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, opts *PutOpts) (*Object, error) {
+	if key == "" {
+		return nil, ErrorEmptyKey
+	}
+
+	if opts == nil {
+		opts = &PutOpts{}
+	}
+
+	partSize := opts.PartSize
+	if partSize == 0 {
+		partSize = DefaultPartSize
+	}
+
+	ctx, span := s.startSpan(ctx, "put", key)
+	defer span.End()
+
+	info, err := s.client.PutObject(ctx, s.bucket, key, reader, size, minio.PutObjectOptions{
+		ContentType:  opts.ContentType,
+		UserMetadata: opts.Metadata,
+		PartSize:     partSize,
+	})
+	if err != nil {
+		s.failure(span, "failure to put the object", key, err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int64("storage.size", info.Size))
+
+	return &Object{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  opts.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+		Metadata:     opts.Metadata,
+	}, nil
+}
+
+func (s *objectStorage) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	if key == "" {
+		return nil, nil, ErrorEmptyKey
+	}
+
+	ctx, span := s.startSpan(ctx, "get", key)
+	defer span.End()
+
+	obj, err := retry.Do(ctx, s.policy(), func(ctx context.Context) (*minio.Object, error) {
+		obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+		if err != nil {
+			return nil, mapError(err)
+		}
+
+		// GetObject is lazy, the stat makes the request and reports a missing object
+		if _, err := obj.Stat(); err != nil {
+			obj.Close()
+			return nil, mapError(err)
+		}
+
+		return obj, nil
+	})
+	if err != nil {
+		s.failure(span, "failure to get the object", key, err)
+		return nil, nil, err
+	}
+
+	info, _ := obj.Stat()
+	span.SetAttributes(attribute.Int64("storage.size", info.Size))
+
+	return obj, toObject(info), nil
+}
+
+func (s *objectStorage) Delete(ctx context.Context, key string) error {
+	if key == "" {
+		return ErrorEmptyKey
+	}
+
+	ctx, span := s.startSpan(ctx, "delete", key)
+	defer span.End()
+
+	err := retry.Run(ctx, s.policy(), func(ctx context.Context) error {
+		return mapError(s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{}))
+	})
+	if err != nil {
+		s.failure(span, "failure to delete the object", key, err)
+	}
+
+	return err
+}
+
+func (s *objectStorage) List(ctx context.Context, prefix string) ([]*Object, error) {
+	ctx, span := s.startSpan(ctx, "list", prefix)
+	defer span.End()
+
+	objects, err := retry.Do(ctx, s.policy(), func(ctx context.Context) ([]*Object, error) {
+		objects := []*Object{}
+		for info := range s.client.ListObjects(ctx, s.bucket, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+			if info.Err != nil {
+				return nil, mapError(info.Err)
+			}
+
+			objects = append(objects, toObject(info))
+		}
+
+		return objects, nil
+	})
+	if err != nil {
+		s.failure(span, "failure to list the objects", prefix, err)
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("storage.count", len(objects)))
+
+	return objects, nil
+}
+
+func (s *objectStorage) Presign(ctx context.Context, method, key string, expires time.Duration) (*url.URL, error) {
+	if key == "" {
+		return nil, ErrorEmptyKey
+	}
+
+	method = strings.ToUpper(method)
+	if method != http.MethodGet && method != http.MethodPut && method != http.MethodHead && method != http.MethodDelete {
+		return nil, ErrorInvalidMethod
+	}
+
+	if expires <= 0 {
+		expires = DefaultPresignTTL
+	}
+
+	ctx, span := s.startSpan(ctx, "presign", key)
+	defer span.End()
+
+	u, err := s.client.Presign(ctx, method, s.bucket, key, expires, nil)
+	if err != nil {
+		s.failure(span, "failure to presign the object", key, err)
+		return nil, err
+	}
+
+	return u, nil
+}
+
+func (s *objectStorage) startSpan(ctx context.Context, operation, key string) (context.Context, trace.Span) {
+	return s.tracer.Start(ctx, "storage."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("storage.bucket", s.bucket),
+			attribute.String("storage.key", key),
+		),
+	)
+}
+
+func (s *objectStorage) failure(span trace.Span, msg, key string, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	s.logger.Error(LogMessage(msg), logging.MessageField("bucket", s.bucket), logging.MessageField("key", key), logging.ErrorField(err))
+}
+
+// policy the configured policy without retrying client errors as not found or access denied
+func (s *objectStorage) policy() *retry.Policy {
+	p := *s.retry
+	retryOn := p.RetryOn
+	p.RetryOn = func(err error) bool {
+		if retryOn != nil && !retryOn(err) {
+			return false
+		}
+
+		return isRetryable(err)
+	}
+
+	return &p
+}
+
+func mapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	resp := minio.ToErrorResponse(err)
+	if resp.StatusCode == http.StatusNotFound || resp.Code == "NoSuchKey" {
+		return fmt.Errorf("%w: %s", ErrorNotFound, resp.Key)
+	}
+
+	return err
+}
+
+func isRetryable(err error) bool {
+	if errors.Is(err, ErrorNotFound) {
+		return false
+	}
+
+	resp := minio.ToErrorResponse(err)
+	if resp.StatusCode == 0 {
+		// transport errors
+		return true
+	}
+
+	return resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests
+}
+
+func toObject(info minio.ObjectInfo) *Object {
+	return &Object{
+		Key:          info.Key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		ETag:         info.ETag,
+		LastModified: info.LastModified,
+		Metadata:     info.UserMetadata,
+	}
+}
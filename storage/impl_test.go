@@ -0,0 +1,119 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/retry"
+	"github.com/stretchr/testify/suite"
+)
+
+type StorageTestSuite struct {
+	suite.Suite
+
+	logger *logging.MockLogger
+}
+
+func TestStorageTestSuite(t *testing.T) {
+	suite.Run(t, new(StorageTestSuite))
+}
+
+func (s *StorageTestSuite) SetupTest() {
+	s.logger = logging.NewMockLogger()
+}
+
+func (s *StorageTestSuite) newStorage(server *httptest.Server) IStorage {
+	st, err := New(s.logger, &Config{
+		Provider:  MINIO_PROVIDER,
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		AccessKey: "access",
+		SecretKey: "secret",
+		Bucket:    "bucket",
+		Retry:     &retry.Policy{MaxAttempts: 3, Backoff: retry.Constant(time.Millisecond)},
+	})
+	s.NoError(err)
+
+	return st
+}
+
+func (s *StorageTestSuite) TestNewConfig() {
+	cfg := NewConfig(&env.Configs{
+		STORAGE_PROVIDER:   "gcs",
+		STORAGE_ACCESS_KEY: "access",
+		STORAGE_SECRET_KEY: "secret",
+		STORAGE_BUCKET:     "bucket",
+	})
+
+	s.NoError(cfg.validate())
+	s.Equal(GCSEndpoint, cfg.Endpoint)
+
+	cfg = &Config{AccessKey: "access", SecretKey: "secret", Bucket: "bucket", Region: "sa-east-1"}
+	s.NoError(cfg.validate())
+	s.Equal(S3_PROVIDER, cfg.Provider)
+	s.Equal("s3.sa-east-1.amazonaws.com", cfg.Endpoint)
+}
+
+func (s *StorageTestSuite) TestNewErr() {
+	_, err := New(s.logger, &Config{Provider: MINIO_PROVIDER, AccessKey: "access", SecretKey: "secret", Bucket: "bucket"})
+	s.ErrorIs(err, ErrorInvalidConfig)
+
+	_, err = New(s.logger, &Config{Provider: "azure", AccessKey: "access", SecretKey: "secret", Bucket: "bucket"})
+	s.ErrorIs(err, ErrorUnknownProvider)
+}
+
+func (s *StorageTestSuite) TestPresign() {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	st := s.newStorage(server)
+
+	u, err := st.Presign(context.Background(), "get", "path/file.txt", time.Minute)
+	s.NoError(err)
+	s.Equal("/bucket/path/file.txt", u.Path)
+	s.Equal("60", u.Query().Get("X-Amz-Expires"))
+
+	_, err = st.Presign(context.Background(), http.MethodPost, "path/file.txt", time.Minute)
+	s.ErrorIs(err, ErrorInvalidMethod)
+}
+
+func (s *StorageTestSuite) TestDeleteRetry() {
+	calls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	err := s.newStorage(server).Delete(context.Background(), "file.txt")
+
+	s.NoError(err)
+	s.GreaterOrEqual(atomic.LoadInt32(&calls), int32(2))
+}
+
+func (s *StorageTestSuite) TestGetNotFound() {
+	calls := int32(0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`<Error><Code>NoSuchKey</Code><Key>file.txt</Key></Error>`))
+	}))
+	defer server.Close()
+
+	_, _, err := s.newStorage(server).Get(context.Background(), "file.txt")
+
+	s.True(errors.Is(err, ErrorNotFound))
+	s.Equal(int32(1), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, opts *PutOpts) (*Object, error) {
+	args := m.Called(ctx, key, reader, size, opts)
+
+	obj, _ := args.Get(0).(*Object)
+	return obj, args.Error(1)
+}
+
+func (m *MockStorage) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	args := m.Called(ctx, key)
+
+	reader, _ := args.Get(0).(io.ReadCloser)
+	obj, _ := args.Get(1).(*Object)
+	return reader, obj, args.Error(2)
+}
+
+func (m *MockStorage) Delete(ctx context.Context, key string) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockStorage) List(ctx context.Context, prefix string) ([]*Object, error) {
+	args := m.Called(ctx, prefix)
+
+	objects, _ := args.Get(0).([]*Object)
+	return objects, args.Error(1)
+}
+
+func (m *MockStorage) Presign(ctx context.Context, method, key string, expires time.Duration) (*url.URL, error) {
+	args := m.Called(ctx, method, key, expires)
+
+	u, _ := args.Get(0).(*url.URL)
+	return u, args.Error(1)
+}
+
+func NewMockStorage() *MockStorage {
+	return new(MockStorage)
+}
@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/retry"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type (
+	Provider string
+
+	// Config the object storage connection, GCS is accessed through the S3 interoperability API with HMAC keys
+	Config struct {
+		Provider  Provider
+		Endpoint  string
+		Region    string
+		AccessKey string
+		SecretKey string
+		Bucket    string
+		SSL       bool
+		// Retry policy used in the idempotent operations, nil uses retry.DefaultPolicy
+		Retry *retry.Policy
+	}
+
+	PutOpts struct {
+		ContentType string
+		Metadata    map[string]string
+		// PartSize the multipart chunk size, used when the size is unknown or bigger than the part size
+		PartSize uint64
+	}
+
+	Object struct {
+		Key          string
+		Size         int64
+		ContentType  string
+		ETag         string
+		LastModified time.Time
+		Metadata     map[string]string
+	}
+
+	// IStorage unified object storage operations over S3, GCS and MinIO
+	IStorage interface {
+		// Put upload the reader content, size UnknownSize streams the reader with multipart upload
+		Put(ctx context.Context, key string, reader io.Reader, size int64, opts *PutOpts) (*Object, error)
+		// Get the caller must close the returned reader
+		Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+		Delete(ctx context.Context, key string) error
+		List(ctx context.Context, prefix string) ([]*Object, error)
+		// Presign create a temporary URL to the object, method GET, PUT, HEAD or DELETE
+		Presign(ctx context.Context, method, key string, expires time.Duration) (*url.URL, error)
+	}
+
+	objectStorage struct {
+		logger logging.ILogger
+		client *minio.Client
+		bucket string
+		retry  *retry.Policy
+		tracer trace.Tracer
+	}
+)
@@ -0,0 +1,241 @@
+package storage
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+type (
+	// UploadOpts the multipart files are streamed to the storage while the request is read, only one part of PartSize is kept
+	// in memory, so the limits are validated during the upload and the objects of a failed request are deleted
+	UploadOpts struct {
+		// Fields the form fields with files, empty accepts all
+		Fields []string
+		// MaxFileSize the max size of each file, zero is unlimited
+		MaxFileSize int64
+		// MaxFiles the max number of files in the request, zero is unlimited
+		MaxFiles int
+		// ContentTypes the allowed content types, sniffed from the content, the entries ending with / match the prefix,
+		// e.g: image/, empty allows all
+		ContentTypes []string
+		// Key the object key of the file, default the file name without the directories
+		Key func(file *UploadFile) string
+		// MaxValuesSize the max size of the form values, default DefaultMaxValuesSize
+		MaxValuesSize int64
+		Metadata      map[string]string
+		PartSize      uint64
+		// OnProgress called while the files are read, e.g: to publish the upload progress to the client
+		OnProgress func(progress UploadProgress)
+	}
+
+	// UploadFile a file of the multipart request
+	UploadFile struct {
+		Field       string
+		FileName    string
+		ContentType string
+	}
+
+	UploadProgress struct {
+		File *UploadFile
+		// Bytes the bytes of the file read so far
+		Bytes int64
+		// Total the request Content-Length, UnknownSize to the chunked requests
+		Total int64
+	}
+
+	// UploadedFile a file stored in the upload
+	UploadedFile struct {
+		UploadFile
+		Object *Object
+	}
+
+	UploadResult struct {
+		Files []*UploadedFile
+		// Values the form fields without files
+		Values url.Values
+	}
+
+	// uploadReader enforce the file size limit and report the progress
+	uploadReader struct {
+		reader   io.Reader
+		file     *UploadFile
+		opts     *UploadOpts
+		total    int64
+		read     int64
+		exceeded bool
+	}
+)
+
+// sniffLen the bytes used by http.DetectContentType
+const sniffLen = 512
+
+// UploadMultipart stream the files of the multipart/form-data request to the storage, when any validation or upload fails
+// the files already stored are deleted, ErrorFileTooLarge, ErrorTooManyFiles and ErrorContentTypeNotAllowed are the
+// client errors, e.g: to respond 413 and 415
+//
+//	result, err := storage.UploadMultipart(r.Context(), st, r, &storage.UploadOpts{MaxFileSize: 10 << 20, ContentTypes: []string{"image/"}})
+func UploadMultipart(ctx context.Context, st IStorage, r *http.Request, opts *UploadOpts) (*UploadResult, error) {
+	if opts == nil {
+		opts = &UploadOpts{}
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return nil, ErrorNotMultipart
+	}
+
+	result := &UploadResult{Values: url.Values{}}
+	if err := upload(ctx, st, reader, r.ContentLength, opts, result); err != nil {
+		for _, file := range result.Files {
+			st.Delete(context.Background(), file.Object.Key)
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func upload(ctx context.Context, st IStorage, reader *multipart.Reader, total int64, opts *UploadOpts, result *UploadResult) error {
+	maxValuesSize := opts.MaxValuesSize
+	if maxValuesSize == 0 {
+		maxValuesSize = DefaultMaxValuesSize
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if part.FileName() == "" {
+			value, err := io.ReadAll(io.LimitReader(part, maxValuesSize+1))
+			if err != nil {
+				return err
+			}
+
+			maxValuesSize -= int64(len(value))
+			if maxValuesSize < 0 {
+				return ErrorFormValuesTooLarge
+			}
+
+			result.Values.Add(part.FormName(), string(value))
+			continue
+		}
+
+		if !opts.acceptsField(part.FormName()) {
+			continue
+		}
+
+		if opts.MaxFiles > 0 && len(result.Files) >= opts.MaxFiles {
+			return ErrorTooManyFiles
+		}
+
+		uploaded, err := uploadPart(ctx, st, part, total, opts)
+		if err != nil {
+			return err
+		}
+
+		result.Files = append(result.Files, uploaded)
+	}
+}
+
+func uploadPart(ctx context.Context, st IStorage, part *multipart.Part, total int64, opts *UploadOpts) (*UploadedFile, error) {
+	file := &UploadFile{Field: part.FormName(), FileName: part.FileName()}
+
+	// the declared content type is only used when the content is not recognized
+	content := bufio.NewReaderSize(part, sniffLen)
+	head, err := content.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+
+	file.ContentType = http.DetectContentType(head)
+	if declared := part.Header.Get("Content-Type"); declared != "" && file.ContentType == "application/octet-stream" {
+		file.ContentType = declared
+	}
+
+	if !opts.allowsContentType(file.ContentType) {
+		return nil, ErrorContentTypeNotAllowed
+	}
+
+	key := path.Base("/" + strings.ReplaceAll(file.FileName, `\`, "/"))
+	if opts.Key != nil {
+		key = opts.Key(file)
+	}
+
+	obj, err := st.Put(ctx, key, &uploadReader{reader: content, file: file, opts: opts, total: total}, UnknownSize, &PutOpts{
+		ContentType: file.ContentType,
+		Metadata:    opts.Metadata,
+		PartSize:    opts.PartSize,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &UploadedFile{UploadFile: *file, Object: obj}, nil
+}
+
+func (opts *UploadOpts) acceptsField(field string) bool {
+	if len(opts.Fields) == 0 {
+		return true
+	}
+
+	for _, f := range opts.Fields {
+		if f == field {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (opts *UploadOpts) allowsContentType(contentType string) bool {
+	if len(opts.ContentTypes) == 0 {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, allowed := range opts.ContentTypes {
+		if strings.HasSuffix(allowed, "/") && strings.HasPrefix(mediaType, allowed) || mediaType == allowed {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (u *uploadReader) Read(p []byte) (int, error) {
+	if u.exceeded {
+		return 0, ErrorFileTooLarge
+	}
+
+	n, err := u.reader.Read(p)
+	u.read += int64(n)
+
+	if u.opts.MaxFileSize > 0 && u.read > u.opts.MaxFileSize {
+		u.exceeded = true
+		return 0, ErrorFileTooLarge
+	}
+
+	if n > 0 && u.opts.OnProgress != nil {
+		u.opts.OnProgress(UploadProgress{File: u.file, Bytes: u.read, Total: u.total})
+	}
+
+	return n, err
+}
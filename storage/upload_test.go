@@ -0,0 +1,150 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	UploadTestSuite struct {
+		suite.Suite
+
+		storage *memoryStorage
+	}
+
+	// memoryStorage read the uploads like the storage streaming them
+	memoryStorage struct {
+		MockStorage
+		contents map[string]string
+		deleted  []string
+	}
+)
+
+func TestUploadTestSuite(t *testing.T) {
+	suite.Run(t, new(UploadTestSuite))
+}
+
+func (s *UploadTestSuite) SetupTest() {
+	s.storage = &memoryStorage{contents: map[string]string{}}
+}
+
+func (m *memoryStorage) Put(ctx context.Context, key string, reader io.Reader, size int64, opts *PutOpts) (*Object, error) {
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	m.contents[key] = string(content)
+	return &Object{Key: key, Size: int64(len(content)), ContentType: opts.ContentType}, nil
+}
+
+func (m *memoryStorage) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	content, ok := m.contents[key]
+	if !ok {
+		return nil, nil, ErrorNotFound
+	}
+
+	return io.NopCloser(strings.NewReader(content)), &Object{Key: key}, nil
+}
+
+func (m *memoryStorage) List(ctx context.Context, prefix string) ([]*Object, error) {
+	objects := []*Object{}
+	for key := range m.contents {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, &Object{Key: key})
+		}
+	}
+
+	return objects, nil
+}
+
+func (m *memoryStorage) Delete(ctx context.Context, key string) error {
+	m.deleted = append(m.deleted, key)
+	return nil
+}
+
+func (s *UploadTestSuite) request(files map[string]string, values map[string]string) *http.Request {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	for name, value := range values {
+		writer.WriteField(name, value)
+	}
+
+	for name, content := range files {
+		part, _ := writer.CreateFormFile("files", name)
+		part.Write([]byte(content))
+	}
+
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/uploads", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	return req
+}
+
+func (s *UploadTestSuite) TestUploadMultipart() {
+	progress := int64(0)
+	req := s.request(map[string]string{"../../report.csv": "id,name\n1,gokit\n"}, map[string]string{"description": "monthly"})
+
+	result, err := UploadMultipart(context.Background(), s.storage, req, &UploadOpts{
+		ContentTypes: []string{"text/"},
+		OnProgress: func(p UploadProgress) {
+			progress = p.Bytes
+		},
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(result.Files, 1)
+	s.Equal("files", result.Files[0].Field)
+	s.Equal("report.csv", result.Files[0].Object.Key)
+	s.Equal("text/plain; charset=utf-8", result.Files[0].ContentType)
+	s.Equal("id,name\n1,gokit\n", s.storage.contents["report.csv"])
+	s.Equal(int64(16), progress)
+	s.Equal("monthly", result.Values.Get("description"))
+}
+
+func (s *UploadTestSuite) TestFileTooLarge() {
+	req := s.request(map[string]string{"a.txt": strings.Repeat("a", 2048)}, nil)
+
+	_, err := UploadMultipart(context.Background(), s.storage, req, &UploadOpts{MaxFileSize: 1024})
+
+	s.ErrorIs(err, ErrorFileTooLarge)
+	s.Empty(s.storage.contents)
+}
+
+func (s *UploadTestSuite) TestContentTypeNotAllowed() {
+	req := s.request(map[string]string{"image.png": "not an image"}, nil)
+
+	_, err := UploadMultipart(context.Background(), s.storage, req, &UploadOpts{ContentTypes: []string{"image/"}})
+
+	s.ErrorIs(err, ErrorContentTypeNotAllowed)
+	s.Empty(s.storage.contents)
+}
+
+func (s *UploadTestSuite) TestTooManyFilesRollback() {
+	req := s.request(map[string]string{"a.txt": "a", "b.txt": "b"}, nil)
+
+	_, err := UploadMultipart(context.Background(), s.storage, req, &UploadOpts{MaxFiles: 1})
+
+	s.ErrorIs(err, ErrorTooManyFiles)
+	s.Len(s.storage.deleted, 1)
+}
+
+func (s *UploadTestSuite) TestNotMultipart() {
+	req := httptest.NewRequest(http.MethodPost, "/uploads", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+
+	_, err := UploadMultipart(context.Background(), s.storage, req, nil)
+
+	s.ErrorIs(err, ErrorNotMultipart)
+}
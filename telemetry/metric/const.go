@@ -0,0 +1,25 @@
+package metric
+
+const (
+	MeterName = "github.com/ralvescosta/gokit/telemetry/metric"
+
+	HTTP_KIND      Kind = "http.server"
+	GRPC_KIND      Kind = "rpc.server"
+	MESSAGING_KIND Kind = "messaging.process"
+	SQL_KIND       Kind = "db.client"
+
+	// the label names shared by all the kinds so the dashboards can be reused
+	OperationLabel = "operation"
+	CodeLabel      = "code"
+	OutcomeLabel   = "outcome"
+
+	SuccessOutcome = "success"
+	ErrorOutcome   = "error"
+
+	RequestsSuffix = ".requests"
+	ErrorsSuffix   = ".errors"
+	DurationSuffix = ".duration"
+)
+
+// DefaultDurationBuckets in seconds, from 5ms to 10s
+var DefaultDurationBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
@@ -0,0 +1,119 @@
+package metric
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdkMetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MetricTestSuite struct {
+	suite.Suite
+
+	reader *sdkMetric.ManualReader
+}
+
+func TestMetricTestSuite(t *testing.T) {
+	suite.Run(t, new(MetricTestSuite))
+}
+
+func (s *MetricTestSuite) SetupTest() {
+	s.reader = sdkMetric.NewManualReader()
+}
+
+func (s *MetricTestSuite) red(kind Kind) *RED {
+	red, err := NewRED(sdkMetric.NewMeterProvider(sdkMetric.WithReader(s.reader)).Meter("test"), kind)
+	s.NoError(err)
+
+	return red
+}
+
+func (s *MetricTestSuite) collect() map[string]metricdata.Aggregation {
+	rm := metricdata.ResourceMetrics{}
+	s.NoError(s.reader.Collect(context.Background(), &rm))
+
+	result := map[string]metricdata.Aggregation{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			result[m.Name] = m.Data
+		}
+	}
+
+	return result
+}
+
+func (s *MetricTestSuite) sum(data metricdata.Aggregation, attrs ...attribute.KeyValue) int64 {
+	set := attribute.NewSet(attrs...)
+
+	for _, dp := range data.(metricdata.Sum[int64]).DataPoints {
+		if dp.Attributes.Equals(&set) {
+			return dp.Value
+		}
+	}
+
+	return 0
+}
+
+func (s *MetricTestSuite) TestTrack() {
+	now := time.Now()
+	timeNow = func() time.Time { now = now.Add(time.Second); return now }
+	defer func() { timeNow = time.Now }()
+
+	red := s.red(SQL_KIND)
+
+	s.NoError(red.Track(context.Background(), "users.find", func(ctx context.Context) error { return nil }))
+	s.Error(red.Track(context.Background(), "users.find", func(ctx context.Context) error { return errors.New("some error") }))
+
+	data := s.collect()
+	success := []attribute.KeyValue{attribute.String(CodeLabel, ""), attribute.String(OperationLabel, "users.find"), attribute.String(OutcomeLabel, SuccessOutcome)}
+	failure := []attribute.KeyValue{attribute.String(CodeLabel, ""), attribute.String(OperationLabel, "users.find"), attribute.String(OutcomeLabel, ErrorOutcome)}
+
+	s.Equal(int64(1), s.sum(data["db.client.requests"], success...))
+	s.Equal(int64(1), s.sum(data["db.client.requests"], failure...))
+	s.Equal(int64(1), s.sum(data["db.client.errors"], failure...))
+
+	histogram := data["db.client.duration"].(metricdata.Histogram[float64])
+	s.Len(histogram.DataPoints, 2)
+	s.Equal(float64(1), histogram.DataPoints[0].Sum)
+}
+
+func (s *MetricTestSuite) TestHTTPMiddleware() {
+	red := s.red(HTTP_KIND)
+
+	handler := HTTPMiddleware(red, func(r *http.Request) string { return r.Method + " /users/{id}" })(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/2" {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/1", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/2", nil))
+
+	data := s.collect()
+	s.Equal(int64(1), s.sum(data["http.server.requests"], attribute.String(CodeLabel, "200"), attribute.String(OperationLabel, "GET /users/{id}"), attribute.String(OutcomeLabel, SuccessOutcome)))
+	s.Equal(int64(1), s.sum(data["http.server.errors"], attribute.String(CodeLabel, "500"), attribute.String(OperationLabel, "GET /users/{id}"), attribute.String(OutcomeLabel, ErrorOutcome)))
+}
+
+func (s *MetricTestSuite) TestUnaryServerInterceptor() {
+	red := s.red(GRPC_KIND)
+	interceptor := UnaryServerInterceptor(red)
+	info := &grpc.UnaryServerInfo{FullMethod: "/users.Users/Find"}
+
+	_, err := interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.NotFound, "not found")
+	})
+	s.Error(err)
+
+	data := s.collect()
+	s.Equal(int64(1), s.sum(data["rpc.server.errors"], attribute.String(CodeLabel, "NotFound"), attribute.String(OperationLabel, "/users.Users/Find"), attribute.String(OutcomeLabel, ErrorOutcome)))
+}
@@ -0,0 +1,72 @@
+package metric
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusRecorder) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *statusRecorder) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w *statusRecorder) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// HTTPMiddleware observe the requests, the responses with status >= 500 are errors. operationFunc is optional, the default is the method
+func HTTPMiddleware(red *RED, operationFunc OperationFunc) func(next http.Handler) http.Handler {
+	if operationFunc == nil {
+		operationFunc = func(r *http.Request) string { return r.Method }
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := timeNow()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			next.ServeHTTP(rec, r)
+
+			red.Observe(r.Context(), operationFunc(r), strconv.Itoa(rec.status), rec.status >= http.StatusInternalServerError, timeNow().Sub(start))
+		})
+	}
+}
+
+// UnaryServerInterceptor observe the gRPC calls, the operation is the full method and the code is the gRPC status code
+func UnaryServerInterceptor(red *RED) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		done := red.Start(ctx, info.FullMethod)
+
+		resp, err := handler(ctx, req)
+		done(status.Code(err).String(), err)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor observe the gRPC streams, the duration is the stream lifetime
+func StreamServerInterceptor(red *RED) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		done := red.Start(ss.Context(), info.FullMethod)
+
+		err := handler(srv, ss)
+		done(status.Code(err).String(), err)
+
+		return err
+	}
+}
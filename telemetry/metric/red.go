@@ -0,0 +1,83 @@
+package metric
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// NewRED create the RED instruments of the kind, the global meter provider is used when meter is nil
+func NewRED(meter metric.Meter, kind Kind) (*RED, error) {
+	if meter == nil {
+		meter = otel.Meter(MeterName)
+	}
+
+	requests, err := meter.Int64Counter(string(kind)+RequestsSuffix, metric.WithDescription("the number of calls"))
+	if err != nil {
+		return nil, err
+	}
+
+	errors, err := meter.Int64Counter(string(kind)+ErrorsSuffix, metric.WithDescription("the number of failed calls"))
+	if err != nil {
+		return nil, err
+	}
+
+	duration, err := meter.Float64Histogram(
+		string(kind)+DurationSuffix,
+		metric.WithDescription("the duration of the calls"),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(DefaultDurationBuckets...),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RED{kind, requests, errors, duration}, nil
+}
+
+// Observe record a finished call
+func (r *RED) Observe(ctx context.Context, operation, code string, failed bool, elapsed time.Duration, attrs ...attribute.KeyValue) {
+	outcome := SuccessOutcome
+	if failed {
+		outcome = ErrorOutcome
+	}
+
+	attrs = append(attrs,
+		attribute.String(OperationLabel, operation),
+		attribute.String(CodeLabel, code),
+		attribute.String(OutcomeLabel, outcome),
+	)
+	opt := metric.WithAttributes(attrs...)
+
+	r.requests.Add(ctx, 1, opt)
+	if failed {
+		r.errors.Add(ctx, 1, opt)
+	}
+	r.duration.Record(ctx, elapsed.Seconds(), opt)
+}
+
+// Start observe a call that can not be wrapped in a function, the returned DoneFunc must be called when the call finishes
+func (r *RED) Start(ctx context.Context, operation string, attrs ...attribute.KeyValue) DoneFunc {
+	start := timeNow()
+
+	return func(code string, err error) {
+		r.Observe(ctx, operation, code, err != nil, timeNow().Sub(start), attrs...)
+	}
+}
+
+// Track run fn observing it, useful to the SQL calls
+//
+//	err := red.Track(ctx, "users.find", func(ctx context.Context) error { return db.QueryRowContext(ctx, ...).Scan(&u) })
+func (r *RED) Track(ctx context.Context, operation string, fn func(ctx context.Context) error) error {
+	done := r.Start(ctx, operation)
+
+	err := fn(ctx)
+	done("", err)
+
+	return err
+}
+
+var timeNow = time.Now
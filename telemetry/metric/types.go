@@ -0,0 +1,28 @@
+package metric
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/metric"
+)
+
+type (
+	// Kind the prefix of the metric names, e.g: http.server.requests, http.server.errors and http.server.duration
+	Kind string
+
+	// DoneFunc must be called when the observed call finishes, code is the status code of the call or empty
+	DoneFunc = func(code string, err error)
+
+	// OperationFunc the operation label of the HTTP requests, it is called after the handler so the router pattern is available
+	//
+	//	func(r *http.Request) string { return r.Method + " " + chi.RouteContext(r.Context()).RoutePattern() }
+	OperationFunc = func(r *http.Request) string
+
+	// RED emit the rate (requests counter), errors (errors counter) and duration (histogram in seconds) of a kind of call
+	RED struct {
+		kind     Kind
+		requests metric.Int64Counter
+		errors   metric.Int64Counter
+		duration metric.Float64Histogram
+	}
+)
@@ -0,0 +1,41 @@
+package profiling
+
+import (
+	"errors"
+
+	"github.com/ralvescosta/gokit/env"
+)
+
+const (
+	CPU_PROFILE       ProfileType = env.CPU_PROFILE
+	HEAP_PROFILE      ProfileType = env.HEAP_PROFILE
+	ALLOCS_PROFILE    ProfileType = env.ALLOCS_PROFILE
+	GOROUTINE_PROFILE ProfileType = env.GOROUTINE_PROFILE
+	MUTEX_PROFILE     ProfileType = env.MUTEX_PROFILE
+	BLOCK_PROFILE     ProfileType = env.BLOCK_PROFILE
+
+	// IngestPath the Pyroscope HTTP ingestion API, the pprof profiles are sent in the profile multipart field
+	IngestPath   = "/ingest"
+	SpyName      = "gokit"
+	CPUSampleHz  = 100
+	ProfileField = "profile"
+
+	// the tags added to all the profiles
+	ServiceVersionTag = "version"
+	EnvironmentTag    = "env"
+	HostnameTag       = "hostname"
+
+	// DefaultMutexProfileFraction one of each 5 mutex contention events is reported
+	DefaultMutexProfileFraction = 5
+	// DefaultBlockProfileRate one blocking event is sampled each 10µs blocked
+	DefaultBlockProfileRate = 10000
+)
+
+var (
+	ErrorServerURLRequired = errors.New("[gokit::profiling] the profiling server url is required")
+	ErrorUpload            = errors.New("[gokit::profiling] failure to upload the profile")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::profiling] " + msg
+}
@@ -0,0 +1,341 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/version"
+)
+
+// NewPyroscope create the profiler configured by the PROFILING_* envs, see env.Profiling
+//
+//	shutdown, err := profiling.NewPyroscope(cfg, logger).Build(ctx)
+func NewPyroscope(cfg *env.Configs, logger logging.ILogger) ProfilerBuilder {
+	types := []ProfileType{}
+	for _, t := range cfg.PROFILING_TYPES {
+		types = append(types, ProfileType(t))
+	}
+
+	if len(types) == 0 {
+		types = []ProfileType{CPU_PROFILE, HEAP_PROFILE}
+	}
+
+	interval := cfg.PROFILING_UPLOAD_INTERVAL
+	if interval == 0 {
+		interval = env.DEFAULT_PROFILING_UPLOAD_INTERVAL
+	}
+
+	hostname, _ := os.Hostname()
+	tags := map[string]string{
+		ServiceVersionTag: version.Get().Version,
+		EnvironmentTag:    env.EnvironmentMapping[cfg.GO_ENV],
+		HostnameTag:       hostname,
+	}
+
+	for k, v := range cfg.PROFILING_TAGS {
+		tags[k] = v
+	}
+
+	return &profilerBuilder{
+		logger:    logger,
+		cfg:       cfg,
+		appName:   cfg.APP_NAME,
+		serverURL: cfg.PROFILING_SERVER_URL,
+		authToken: cfg.PROFILING_AUTH_TOKEN,
+		interval:  interval,
+		types:     types,
+		tags:      tags,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (b *profilerBuilder) ServerURL(url string) ProfilerBuilder {
+	b.serverURL = url
+	return b
+}
+
+func (b *profilerBuilder) AuthToken(token string) ProfilerBuilder {
+	b.authToken = token
+	return b
+}
+
+func (b *profilerBuilder) Interval(interval time.Duration) ProfilerBuilder {
+	b.interval = interval
+	return b
+}
+
+func (b *profilerBuilder) WithTypes(types ...ProfileType) ProfilerBuilder {
+	b.types = types
+	return b
+}
+
+func (b *profilerBuilder) WithTags(tags map[string]string) ProfilerBuilder {
+	for k, v := range tags {
+		b.tags[k] = v
+	}
+	return b
+}
+
+func (b *profilerBuilder) WithClient(client *http.Client) ProfilerBuilder {
+	b.client = client
+	return b
+}
+
+func (b *profilerBuilder) Build(ctx context.Context) (shutdown func(context.Context) error, err error) {
+	if b.serverURL == "" {
+		return nil, ErrorServerURLRequired
+	}
+
+	p := &profiler{
+		logger:    b.logger,
+		client:    b.client,
+		url:       strings.TrimSuffix(b.serverURL, "/") + IngestPath,
+		authToken: b.authToken,
+		name:      AppName(b.appName, b.tags),
+		interval:  b.interval,
+		types:     b.types,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+
+	p.enableRuntimeProfiles(true)
+	p.from = time.Now()
+	p.startCPU()
+
+	go p.run()
+
+	b.logger.Debug(LogMessage(fmt.Sprintf("profiler started, uploading to %s each %s", b.serverURL, b.interval)))
+	return p.shutdown, nil
+}
+
+// AppName the Pyroscope application name with the tags, e.g: orders{env=production,version=v1.2.3}, the tags are sorted
+// and the characters reserved by the name format are replaced
+func AppName(name string, tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for k, v := range tags {
+		if k != "" && v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, sanitize(k)+"="+sanitize(tags[k]))
+	}
+
+	return sanitize(name) + "{" + strings.Join(pairs, ",") + "}"
+}
+
+func (p *profiler) run() {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.collect(context.Background(), false)
+		case <-p.stop:
+			return
+		}
+	}
+}
+
+// shutdown the last profiles are uploaded with the shutdown context
+func (p *profiler) shutdown(ctx context.Context) error {
+	close(p.stop)
+
+	select {
+	case <-p.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	err := p.collect(ctx, true)
+	p.enableRuntimeProfiles(false)
+
+	return err
+}
+
+// collect upload the profiles of the interval, the cpu profile is restarted unless the profiler is stopping
+func (p *profiler) collect(ctx context.Context, last bool) error {
+	from, until := p.from, time.Now()
+	p.from = until
+
+	var uploadErr error
+	for _, t := range p.types {
+		profile, err := p.profile(t, last)
+		if err != nil {
+			p.logger.Warn(LogMessage(fmt.Sprintf("failure to collect the %s profile", t)), logging.ErrorField(err))
+			continue
+		}
+
+		if len(profile) == 0 {
+			continue
+		}
+
+		if err := p.upload(ctx, t, profile, from, until); err != nil {
+			p.logger.Warn(LogMessage(fmt.Sprintf("failure to upload the %s profile", t)), logging.ErrorField(err))
+			uploadErr = err
+		}
+	}
+
+	return uploadErr
+}
+
+func (p *profiler) profile(t ProfileType, last bool) ([]byte, error) {
+	if t == CPU_PROFILE {
+		if !p.cpuStarted {
+			// the cpu profile is used by other profiler, e.g: the /debug/pprof/profile endpoint, it is retried the next interval
+			if !last {
+				p.startCPU()
+			}
+			return nil, nil
+		}
+
+		pprof.StopCPUProfile()
+		p.cpuStarted = false
+
+		profile := append([]byte{}, p.cpu.Bytes()...)
+		p.cpu.Reset()
+
+		if !last {
+			p.startCPU()
+		}
+
+		return profile, nil
+	}
+
+	lookup := pprof.Lookup(string(t))
+	if lookup == nil {
+		return nil, fmt.Errorf("unknown profile %s", t)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := lookup.WriteTo(buf, 0); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (p *profiler) startCPU() {
+	if !p.hasType(CPU_PROFILE) {
+		return
+	}
+
+	if err := pprof.StartCPUProfile(&p.cpu); err != nil {
+		p.logger.Warn(LogMessage("the cpu profile is not available"), logging.ErrorField(err))
+		p.cpu.Reset()
+		return
+	}
+
+	p.cpuStarted = true
+}
+
+func (p *profiler) upload(ctx context.Context, t ProfileType, profile []byte, from, until time.Time) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	part, err := writer.CreateFormFile(ProfileField, ProfileField+".pprof")
+	if err != nil {
+		return err
+	}
+
+	if _, err := part.Write(profile); err != nil {
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("name", p.name)
+	query.Set("from", strconv.FormatInt(from.Unix(), 10))
+	query.Set("until", strconv.FormatInt(until.Unix(), 10))
+	query.Set("format", "pprof")
+	query.Set("spyName", SpyName)
+	if t == CPU_PROFILE {
+		query.Set("sampleRate", strconv.Itoa(CPUSampleHz))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url+"?"+query.Encode(), body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	if p.authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.authToken)
+	}
+
+	res, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w: %s", ErrorUpload, res.Status)
+	}
+
+	return nil
+}
+
+// enableRuntimeProfiles the mutex and block profiles are only recorded when their rates are set
+func (p *profiler) enableRuntimeProfiles(enabled bool) {
+	if p.hasType(MUTEX_PROFILE) {
+		fraction := 0
+		if enabled {
+			fraction = DefaultMutexProfileFraction
+		}
+		runtime.SetMutexProfileFraction(fraction)
+	}
+
+	if p.hasType(BLOCK_PROFILE) {
+		rate := 0
+		if enabled {
+			rate = DefaultBlockProfileRate
+		}
+		runtime.SetBlockProfileRate(rate)
+	}
+}
+
+func (p *profiler) hasType(t ProfileType) bool {
+	for _, typ := range p.types {
+		if typ == t {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sanitize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '{', '}', ',', '=', ' ':
+			return '_'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,134 @@
+package profiling
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/version"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProfilerTestSuite struct {
+	suite.Suite
+
+	mu       sync.Mutex
+	uploads  []url.Values
+	profiles [][]byte
+	auth     []string
+	server   *httptest.Server
+	cfg      *env.Configs
+}
+
+func TestProfilerTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfilerTestSuite))
+}
+
+func (s *ProfilerTestSuite) SetupTest() {
+	s.uploads, s.profiles, s.auth = nil, nil, nil
+	s.server = httptest.NewServer(http.HandlerFunc(s.ingest))
+	s.cfg = &env.Configs{
+		APP_NAME:             "orders",
+		GO_ENV:               env.PRODUCTION_ENV,
+		PROFILING_SERVER_URL: s.server.URL + "/",
+		PROFILING_AUTH_TOKEN: "token",
+		PROFILING_TYPES:      []string{env.CPU_PROFILE, env.GOROUTINE_PROFILE},
+		PROFILING_TAGS:       map[string]string{"region": "us-east-1"},
+	}
+}
+
+func (s *ProfilerTestSuite) TearDownTest() {
+	s.server.Close()
+}
+
+func (s *ProfilerTestSuite) ingest(w http.ResponseWriter, r *http.Request) {
+	s.Equal(IngestPath, r.URL.Path)
+
+	file, _, err := r.FormFile(ProfileField)
+	if !s.NoError(err) {
+		return
+	}
+
+	profile, _ := io.ReadAll(file)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.uploads = append(s.uploads, r.URL.Query())
+	s.profiles = append(s.profiles, profile)
+	s.auth = append(s.auth, r.Header.Get("Authorization"))
+}
+
+func (s *ProfilerTestSuite) TestUploadEachInterval() {
+	shutdown, err := NewPyroscope(s.cfg, logging.NewMockLogger()).Interval(20 * time.Millisecond).Build(context.Background())
+	s.Require().NoError(err)
+
+	s.Eventually(func() bool {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return len(s.uploads) >= 4
+	}, 2*time.Second, 10*time.Millisecond)
+
+	s.NoError(shutdown(context.Background()))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	types := map[string]bool{}
+	for i, query := range s.uploads {
+		s.Equal(AppName("orders", map[string]string{
+			ServiceVersionTag: version.Get().Version,
+			EnvironmentTag:    "production",
+			HostnameTag:       hostname(),
+			"region":          "us-east-1",
+		}), query.Get("name"))
+		s.Equal("pprof", query.Get("format"))
+		s.Equal("Bearer token", s.auth[i])
+
+		// the pprof profiles are gzip compressed protobufs
+		_, err := gzip.NewReader(bytes.NewReader(s.profiles[i]))
+		s.NoError(err)
+
+		types[query.Get("sampleRate")] = true
+	}
+
+	// the cpu profiles have the sample rate and the goroutine profiles do not
+	s.True(types["100"])
+	s.True(types[""])
+}
+
+func (s *ProfilerTestSuite) TestUploadFailure() {
+	s.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+
+	shutdown, err := NewPyroscope(s.cfg, logging.NewMockLogger()).WithTypes(HEAP_PROFILE).Interval(time.Hour).Build(context.Background())
+	s.Require().NoError(err)
+
+	s.ErrorIs(shutdown(context.Background()), ErrorUpload)
+}
+
+func (s *ProfilerTestSuite) TestServerURLRequired() {
+	_, err := NewPyroscope(&env.Configs{}, logging.NewMockLogger()).Build(context.Background())
+
+	s.ErrorIs(err, ErrorServerURLRequired)
+}
+
+func (s *ProfilerTestSuite) TestAppName() {
+	s.Equal("orders{env=production,team=a_b}", AppName("orders", map[string]string{"team": "a,b", "env": "production", "empty": ""}))
+	s.Equal("orders_api{}", AppName("orders api", nil))
+}
+
+func hostname() string {
+	name, _ := os.Hostname()
+	return name
+}
@@ -0,0 +1,62 @@
+package profiling
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// ProfileType the runtime/pprof profiles, e.g: cpu, heap and goroutine
+	ProfileType string
+
+	// ProfilerBuilder the continuous profiler collects the pprof profiles each interval and pushes them to the Pyroscope
+	// ingestion API, the Parca agent scrapes the /debug/pprof endpoints instead, see the http server WithAdmin
+	ProfilerBuilder interface {
+		ServerURL(url string) ProfilerBuilder
+		// AuthToken sent as the bearer token, e.g: the Grafana Cloud token
+		AuthToken(token string) ProfilerBuilder
+		Interval(interval time.Duration) ProfilerBuilder
+		WithTypes(types ...ProfileType) ProfilerBuilder
+		// WithTags add tags to all the profiles, the service version, environment and hostname are always tagged
+		WithTags(tags map[string]string) ProfilerBuilder
+		WithClient(client *http.Client) ProfilerBuilder
+		// Build start the profiler, shutdown stops it and uploads the last profiles
+		Build(ctx context.Context) (shutdown func(context.Context) error, err error)
+	}
+
+	profilerBuilder struct {
+		logger logging.ILogger
+		cfg    *env.Configs
+
+		appName   string
+		serverURL string
+		authToken string
+		interval  time.Duration
+		types     []ProfileType
+		tags      map[string]string
+		client    *http.Client
+	}
+
+	profiler struct {
+		logger logging.ILogger
+		client *http.Client
+
+		url       string
+		authToken string
+		name      string
+		interval  time.Duration
+		types     []ProfileType
+
+		cpu        bytes.Buffer
+		cpuStarted bool
+		from       time.Time
+
+		stop chan struct{}
+		done chan struct{}
+	}
+)
@@ -0,0 +1,80 @@
+package resources
+
+import (
+	"time"
+)
+
+const (
+	// the OpenTelemetry semantic conventions keys
+	ServiceNameKey           = "service.name"
+	ServiceVersionKey        = "service.version"
+	VCSRevisionKey           = "vcs.ref.head.revision"
+	ProcessRuntimeVersionKey = "process.runtime.version"
+	CloudProviderKey         = "cloud.provider"
+	CloudPlatformKey         = "cloud.platform"
+	CloudRegionKey           = "cloud.region"
+	CloudAvailabilityZoneKey = "cloud.availability_zone"
+	CloudAccountIDKey        = "cloud.account.id"
+	HostIDKey                = "host.id"
+	HostNameKey              = "host.name"
+	HostTypeKey              = "host.type"
+	HostImageIDKey           = "host.image.id"
+	ContainerIDKey           = "container.id"
+	ContainerNameKey         = "container.name"
+	ContainerImageNameKey    = "container.image.name"
+	K8SClusterNameKey        = "k8s.cluster.name"
+	K8SNamespaceNameKey      = "k8s.namespace.name"
+	K8SPodNameKey            = "k8s.pod.name"
+	K8SPodUIDKey             = "k8s.pod.uid"
+	K8SNodeNameKey           = "k8s.node.name"
+	AWSECSClusterARNKey      = "aws.ecs.cluster.arn"
+	AWSECSTaskARNKey         = "aws.ecs.task.arn"
+	AWSECSTaskFamilyKey      = "aws.ecs.task.family"
+	AWSECSTaskRevisionKey    = "aws.ecs.task.revision"
+	AWSECSLaunchTypeKey      = "aws.ecs.launchtype"
+	FaaSNameKey              = "faas.name"
+	FaaSVersionKey           = "faas.version"
+
+	// BuildDateKey the build date is not in the semantic conventions
+	BuildDateKey = "build.date"
+
+	AWS_PROVIDER = "aws"
+	GCP_PROVIDER = "gcp"
+
+	AWS_EC2_PLATFORM            = "aws_ec2"
+	AWS_ECS_PLATFORM            = "aws_ecs"
+	GCP_COMPUTE_ENGINE_PLATFORM = "gcp_compute_engine"
+	GCP_KUBERNETES_PLATFORM     = "gcp_kubernetes_engine"
+	GCP_CLOUD_RUN_PLATFORM      = "gcp_cloud_run"
+
+	// the envs filled by the Kubernetes downward API in the pod spec, e.g:
+	//
+	//	env:
+	//	  - name: K8S_POD_NAME
+	//	    valueFrom:
+	//	      fieldRef:
+	//	        fieldPath: metadata.name
+	K8SServiceHostEnvKey   = "KUBERNETES_SERVICE_HOST"
+	K8SClusterNameEnvKey   = "K8S_CLUSTER_NAME"
+	K8SNamespaceEnvKey     = "K8S_NAMESPACE"
+	K8SPodNameEnvKey       = "K8S_POD_NAME"
+	K8SPodUIDEnvKey        = "K8S_POD_UID"
+	K8SNodeNameEnvKey      = "K8S_NODE_NAME"
+	ECSMetadataURIEnvKey   = "ECS_CONTAINER_METADATA_URI_V4"
+	CloudRunServiceEnvKey  = "K_SERVICE"
+	CloudRunRevisionEnvKey = "K_REVISION"
+
+	K8SNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+	CgroupFile       = "/proc/self/cgroup"
+	MountInfoFile    = "/proc/self/mountinfo"
+
+	EC2MetadataEndpoint = "http://169.254.169.254"
+	GCPMetadataEndpoint = "http://metadata.google.internal"
+
+	// DefaultProbeTimeout bounds the metadata endpoints requests, outside the cloud they are unreachable
+	DefaultProbeTimeout = 300 * time.Millisecond
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::resources] " + msg
+}
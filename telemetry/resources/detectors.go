@@ -0,0 +1,253 @@
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+var (
+	containerIDPattern = regexp.MustCompile(`[0-9a-f]{64}`)
+	mountInfoPattern   = regexp.MustCompile(`containers/([0-9a-f]{64})`)
+)
+
+// the detectors return an empty resource when they do not apply to the environment
+
+func Kubernetes() resource.Detector {
+	return &KubernetesDetector{NamespaceFile: K8SNamespaceFile}
+}
+
+func (d *KubernetesDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if os.Getenv(K8SServiceHostEnvKey) == "" {
+		return resource.Empty(), nil
+	}
+
+	namespace := os.Getenv(K8SNamespaceEnvKey)
+	if namespace == "" {
+		if byt, err := os.ReadFile(d.NamespaceFile); err == nil {
+			namespace = strings.TrimSpace(string(byt))
+		}
+	}
+
+	podName := os.Getenv(K8SPodNameEnvKey)
+	if podName == "" {
+		// the pod hostname is the pod name
+		podName, _ = os.Hostname()
+	}
+
+	return newResource(
+		K8SClusterNameKey, os.Getenv(K8SClusterNameEnvKey),
+		K8SNamespaceNameKey, namespace,
+		K8SPodNameKey, podName,
+		K8SPodUIDKey, os.Getenv(K8SPodUIDEnvKey),
+		K8SNodeNameKey, os.Getenv(K8SNodeNameEnvKey),
+	), nil
+}
+
+func Container() resource.Detector {
+	return &ContainerDetector{CgroupFile: CgroupFile, MountInfoFile: MountInfoFile}
+}
+
+// Detect the cgroup v1 paths contain the container id, in the cgroup v2 it is found in the mountinfo
+func (d *ContainerDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	if byt, err := os.ReadFile(d.CgroupFile); err == nil {
+		if id := containerIDPattern.FindString(string(byt)); id != "" {
+			return newResource(ContainerIDKey, id), nil
+		}
+	}
+
+	if byt, err := os.ReadFile(d.MountInfoFile); err == nil {
+		if match := mountInfoPattern.FindStringSubmatch(string(byt)); len(match) == 2 {
+			return newResource(ContainerIDKey, match[1]), nil
+		}
+	}
+
+	return resource.Empty(), nil
+}
+
+func ECS() resource.Detector {
+	return &ECSDetector{Client: &http.Client{Timeout: DefaultProbeTimeout}}
+}
+
+func (d *ECSDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	uri := os.Getenv(ECSMetadataURIEnvKey)
+	if uri == "" {
+		return resource.Empty(), nil
+	}
+
+	container := &ecsContainerMetadata{}
+	if err := getJSON(ctx, d.Client, uri, nil, container); err != nil {
+		return nil, err
+	}
+
+	task := &ecsTaskMetadata{}
+	if err := getJSON(ctx, d.Client, uri+"/task", nil, task); err != nil {
+		return nil, err
+	}
+
+	return newResource(
+		CloudProviderKey, AWS_PROVIDER,
+		CloudPlatformKey, AWS_ECS_PLATFORM,
+		CloudAvailabilityZoneKey, task.AvailabilityZone,
+		AWSECSClusterARNKey, task.Cluster,
+		AWSECSTaskARNKey, task.TaskARN,
+		AWSECSTaskFamilyKey, task.Family,
+		AWSECSTaskRevisionKey, task.Revision,
+		AWSECSLaunchTypeKey, strings.ToLower(task.LaunchType),
+		ContainerIDKey, container.DockerId,
+		ContainerNameKey, container.Name,
+		ContainerImageNameKey, container.Image,
+	), nil
+}
+
+func EC2() resource.Detector {
+	return &EC2Detector{Endpoint: EC2MetadataEndpoint, Client: &http.Client{Timeout: DefaultProbeTimeout}}
+}
+
+func (d *EC2Detector) Detect(ctx context.Context) (*resource.Resource, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, d.Endpoint+"/latest/api/token", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-aws-ec2-metadata-token-ttl-seconds", "60")
+
+	token, err := do(d.Client, req)
+	if err != nil {
+		// the IMDS is not reachable outside the EC2
+		return resource.Empty(), nil
+	}
+
+	doc := &ec2IdentityDocument{}
+	headers := map[string]string{"X-aws-ec2-metadata-token": string(token)}
+	if err := getJSON(ctx, d.Client, d.Endpoint+"/latest/dynamic/instance-identity/document", headers, doc); err != nil {
+		return nil, err
+	}
+
+	hostname, _ := os.Hostname()
+
+	return newResource(
+		CloudProviderKey, AWS_PROVIDER,
+		CloudPlatformKey, AWS_EC2_PLATFORM,
+		CloudRegionKey, doc.Region,
+		CloudAvailabilityZoneKey, doc.AvailabilityZone,
+		CloudAccountIDKey, doc.AccountID,
+		HostIDKey, doc.InstanceID,
+		HostNameKey, hostname,
+		HostTypeKey, doc.InstanceType,
+		HostImageIDKey, doc.ImageID,
+	), nil
+}
+
+func GCP() resource.Detector {
+	return &GCPDetector{Endpoint: GCPMetadataEndpoint, Client: &http.Client{Timeout: DefaultProbeTimeout}}
+}
+
+func (d *GCPDetector) Detect(ctx context.Context) (*resource.Resource, error) {
+	projectID, err := d.get(ctx, "project/project-id")
+	if err != nil {
+		// the metadata server is not reachable outside the GCP
+		return resource.Empty(), nil
+	}
+
+	// e.g: projects/123/zones/us-central1-a
+	zone, _ := d.get(ctx, "instance/zone")
+	zone = zone[strings.LastIndex(zone, "/")+1:]
+
+	region := zone
+	if i := strings.LastIndex(zone, "-"); i > 0 {
+		region = zone[:i]
+	}
+
+	attrs := []string{
+		CloudProviderKey, GCP_PROVIDER,
+		CloudAccountIDKey, projectID,
+		CloudAvailabilityZoneKey, zone,
+		CloudRegionKey, region,
+	}
+
+	switch {
+	case os.Getenv(CloudRunServiceEnvKey) != "":
+		attrs = append(attrs,
+			CloudPlatformKey, GCP_CLOUD_RUN_PLATFORM,
+			FaaSNameKey, os.Getenv(CloudRunServiceEnvKey),
+			FaaSVersionKey, os.Getenv(CloudRunRevisionEnvKey),
+		)
+	case os.Getenv(K8SServiceHostEnvKey) != "":
+		attrs = append(attrs, CloudPlatformKey, GCP_KUBERNETES_PLATFORM)
+	default:
+		id, _ := d.get(ctx, "instance/id")
+		machineType, _ := d.get(ctx, "instance/machine-type")
+
+		attrs = append(attrs,
+			CloudPlatformKey, GCP_COMPUTE_ENGINE_PLATFORM,
+			HostIDKey, id,
+			HostTypeKey, machineType[strings.LastIndex(machineType, "/")+1:],
+		)
+	}
+
+	return newResource(attrs...), nil
+}
+
+func (d *GCPDetector) get(ctx context.Context, path string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, d.Endpoint+"/computeMetadata/v1/"+path, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	byt, err := do(d.Client, req)
+	return string(byt), err
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, headers map[string]string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	for k, value := range headers {
+		req.Header.Set(k, value)
+	}
+
+	byt, err := do(client, req)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(byt, v)
+}
+
+func do(client *http.Client, req *http.Request) ([]byte, error) {
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s %s returned %d", req.Method, req.URL.Path, res.StatusCode)
+	}
+
+	return io.ReadAll(res.Body)
+}
+
+// newResource the empty values are skipped
+func newResource(kv ...string) *resource.Resource {
+	attrs := []attribute.KeyValue{}
+
+	for i := 0; i+1 < len(kv); i += 2 {
+		if kv[i+1] != "" {
+			attrs = append(attrs, attribute.String(kv[i], kv[i+1]))
+		}
+	}
+
+	return resource.NewSchemaless(attrs...)
+}
@@ -0,0 +1,186 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/version"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+const containerID = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+type DetectorsTestSuite struct {
+	suite.Suite
+
+	ctx context.Context
+	dir string
+}
+
+func TestDetectorsTestSuite(t *testing.T) {
+	suite.Run(t, new(DetectorsTestSuite))
+}
+
+func (s *DetectorsTestSuite) SetupTest() {
+	s.ctx = context.Background()
+	s.dir = s.T().TempDir()
+}
+
+func (s *DetectorsTestSuite) file(name, content string) string {
+	path := filepath.Join(s.dir, name)
+	s.NoError(os.WriteFile(path, []byte(content), 0o644))
+
+	return path
+}
+
+func (s *DetectorsTestSuite) value(res *resource.Resource, key string) string {
+	v, _ := res.Set().Value(attribute.Key(key))
+	return v.AsString()
+}
+
+func (s *DetectorsTestSuite) TestKubernetes() {
+	d := &KubernetesDetector{NamespaceFile: s.file("namespace", "payments\n")}
+
+	res, _ := d.Detect(s.ctx)
+	s.Equal(0, res.Len())
+
+	s.T().Setenv(K8SServiceHostEnvKey, "10.0.0.1")
+	s.T().Setenv(K8SPodNameEnvKey, "payments-7d9f")
+	s.T().Setenv(K8SNodeNameEnvKey, "node-1")
+
+	res, err := d.Detect(s.ctx)
+	s.NoError(err)
+	s.Equal("payments", s.value(res, K8SNamespaceNameKey))
+	s.Equal("payments-7d9f", s.value(res, K8SPodNameKey))
+	s.Equal("node-1", s.value(res, K8SNodeNameKey))
+	s.False(res.Set().HasValue(K8SPodUIDKey))
+}
+
+func (s *DetectorsTestSuite) TestContainer() {
+	d := &ContainerDetector{CgroupFile: s.file("cgroup", "12:memory:/docker/"+containerID+"\n"), MountInfoFile: "missing"}
+
+	res, _ := d.Detect(s.ctx)
+	s.Equal(containerID, s.value(res, ContainerIDKey))
+
+	d = &ContainerDetector{
+		CgroupFile:    s.file("cgroup", "0::/\n"),
+		MountInfoFile: s.file("mountinfo", "1 2 0:1 /var/lib/docker/containers/"+containerID+"/hostname /etc/hostname rw\n"),
+	}
+
+	res, _ = d.Detect(s.ctx)
+	s.Equal(containerID, s.value(res, ContainerIDKey))
+
+	d = &ContainerDetector{CgroupFile: "missing", MountInfoFile: "missing"}
+	res, _ = d.Detect(s.ctx)
+	s.Equal(0, res.Len())
+}
+
+func (s *DetectorsTestSuite) TestECS() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/task") {
+			w.Write([]byte(`{"Cluster":"arn:cluster","TaskARN":"arn:task","Family":"payments","Revision":"3","AvailabilityZone":"us-east-1a","LaunchType":"FARGATE"}`))
+			return
+		}
+		w.Write([]byte(`{"DockerId":"` + containerID + `","Name":"payments","Image":"payments:1.0"}`))
+	}))
+	defer srv.Close()
+
+	d := &ECSDetector{Client: srv.Client()}
+
+	res, _ := d.Detect(s.ctx)
+	s.Equal(0, res.Len())
+
+	s.T().Setenv(ECSMetadataURIEnvKey, srv.URL+"/v4/id")
+
+	res, err := d.Detect(s.ctx)
+	s.NoError(err)
+	s.Equal(AWS_ECS_PLATFORM, s.value(res, CloudPlatformKey))
+	s.Equal("arn:task", s.value(res, AWSECSTaskARNKey))
+	s.Equal("fargate", s.value(res, AWSECSLaunchTypeKey))
+	s.Equal(containerID, s.value(res, ContainerIDKey))
+}
+
+func (s *DetectorsTestSuite) TestEC2() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			w.Write([]byte("token"))
+			return
+		}
+
+		s.Equal("token", r.Header.Get("X-aws-ec2-metadata-token"))
+		w.Write([]byte(`{"instanceId":"i-123","region":"us-east-1","availabilityZone":"us-east-1a","instanceType":"t3.micro","accountId":"123","imageId":"ami-1"}`))
+	}))
+
+	d := &EC2Detector{Endpoint: srv.URL, Client: srv.Client()}
+
+	res, err := d.Detect(s.ctx)
+	s.NoError(err)
+	s.Equal(AWS_EC2_PLATFORM, s.value(res, CloudPlatformKey))
+	s.Equal("i-123", s.value(res, HostIDKey))
+	s.Equal("us-east-1", s.value(res, CloudRegionKey))
+
+	srv.Close()
+
+	res, err = d.Detect(s.ctx)
+	s.NoError(err)
+	s.Equal(0, res.Len())
+}
+
+func (s *DetectorsTestSuite) TestGCP() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch strings.TrimPrefix(r.URL.Path, "/computeMetadata/v1/") {
+		case "project/project-id":
+			w.Write([]byte("my-project"))
+		case "instance/zone":
+			w.Write([]byte("projects/123/zones/us-central1-a"))
+		case "instance/id":
+			w.Write([]byte("42"))
+		case "instance/machine-type":
+			w.Write([]byte("projects/123/machineTypes/e2-medium"))
+		}
+	}))
+	defer srv.Close()
+
+	d := &GCPDetector{Endpoint: srv.URL, Client: srv.Client()}
+
+	res, err := d.Detect(s.ctx)
+	s.NoError(err)
+	s.Equal(GCP_COMPUTE_ENGINE_PLATFORM, s.value(res, CloudPlatformKey))
+	s.Equal("us-central1", s.value(res, CloudRegionKey))
+	s.Equal("us-central1-a", s.value(res, CloudAvailabilityZoneKey))
+	s.Equal("e2-medium", s.value(res, HostTypeKey))
+
+	s.T().Setenv(CloudRunServiceEnvKey, "payments")
+	res, _ = d.Detect(s.ctx)
+	s.Equal(GCP_CLOUD_RUN_PLATFORM, s.value(res, CloudPlatformKey))
+	s.Equal("payments", s.value(res, FaaSNameKey))
+}
+
+func (s *DetectorsTestSuite) TestNew() {
+	s.T().Setenv("OTEL_RESOURCE_ATTRIBUTES", "deployment.environment=staging")
+
+	container := &ContainerDetector{CgroupFile: s.file("cgroup", "12:memory:/docker/"+containerID+"\n")}
+	failing := &EC2Detector{Endpoint: "http://%invalid", Client: http.DefaultClient}
+
+	res, err := New(s.ctx, logging.NewMockLogger(), "payments", container, failing)
+	s.NoError(err)
+	s.Equal("payments", s.value(res, ServiceNameKey))
+	s.Equal(containerID, s.value(res, ContainerIDKey))
+	s.Equal("staging", s.value(res, "deployment.environment"))
+	s.Equal(version.Get().Version, s.value(res, ServiceVersionKey))
+	s.Equal(runtime.Version(), s.value(res, ProcessRuntimeVersionKey))
+}
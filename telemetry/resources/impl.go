@@ -0,0 +1,75 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/version"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+)
+
+// DefaultDetectors the container, Kubernetes, ECS, EC2 and GCP detectors
+func DefaultDetectors() []resource.Detector {
+	return []resource.Detector{Container(), Kubernetes(), ECS(), EC2(), GCP()}
+}
+
+// New create the resource labeling the telemetry of the service with its build version, the detectors run concurrently and their failures are logged
+// so the telemetry is never disabled because a metadata endpoint failed. The OTEL_RESOURCE_ATTRIBUTES env overrides the detected attributes
+func New(ctx context.Context, logger logging.ILogger, serviceName string, detectors ...resource.Detector) (*resource.Resource, error) {
+	detected := make([]*resource.Resource, len(detectors))
+
+	wg := sync.WaitGroup{}
+	for i, detector := range detectors {
+		wg.Add(1)
+		go func(i int, detector resource.Detector) {
+			defer wg.Done()
+
+			res, err := detector.Detect(ctx)
+			if err != nil {
+				logger.Warn(LogMessage(fmt.Sprintf("resource detector %T failure: %s", detector, err)))
+				return
+			}
+
+			detected[i] = res
+		}(i, detector)
+	}
+	wg.Wait()
+
+	info := version.Get()
+	merged, err := resource.Merge(
+		resource.NewSchemaless(
+			attribute.String(ServiceNameKey, serviceName),
+			attribute.String("library.language", "go"),
+		),
+		newResource(
+			ServiceVersionKey, info.Version,
+			VCSRevisionKey, info.Commit,
+			BuildDateKey, info.BuildDate,
+			ProcessRuntimeVersionKey, info.GoVersion,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, res := range detected {
+		if res == nil {
+			continue
+		}
+
+		if merged, err = resource.Merge(merged, res); err != nil {
+			return nil, err
+		}
+	}
+
+	fromEnv, err := resource.New(ctx, resource.WithFromEnv())
+	if err != nil {
+		logger.Warn(LogMessage(fmt.Sprintf("invalid OTEL_RESOURCE_ATTRIBUTES: %s", err)))
+		return merged, nil
+	}
+
+	return resource.Merge(merged, fromEnv)
+}
@@ -0,0 +1,59 @@
+package resources
+
+import (
+	"net/http"
+)
+
+type (
+	// KubernetesDetector reads the downward API envs and the service account namespace
+	KubernetesDetector struct {
+		NamespaceFile string
+	}
+
+	// ContainerDetector reads the container id from the cgroup files
+	ContainerDetector struct {
+		CgroupFile    string
+		MountInfoFile string
+	}
+
+	// ECSDetector reads the ECS task metadata endpoint v4
+	ECSDetector struct {
+		Client *http.Client
+	}
+
+	// EC2Detector reads the instance identity document through the IMDSv2
+	EC2Detector struct {
+		Endpoint string
+		Client   *http.Client
+	}
+
+	// GCPDetector reads the GCE metadata server
+	GCPDetector struct {
+		Endpoint string
+		Client   *http.Client
+	}
+
+	ecsContainerMetadata struct {
+		DockerId string `json:"DockerId"`
+		Name     string `json:"Name"`
+		Image    string `json:"Image"`
+	}
+
+	ecsTaskMetadata struct {
+		Cluster          string `json:"Cluster"`
+		TaskARN          string `json:"TaskARN"`
+		Family           string `json:"Family"`
+		Revision         string `json:"Revision"`
+		AvailabilityZone string `json:"AvailabilityZone"`
+		LaunchType       string `json:"LaunchType"`
+	}
+
+	ec2IdentityDocument struct {
+		InstanceID       string `json:"instanceId"`
+		Region           string `json:"region"`
+		AvailabilityZone string `json:"availabilityZone"`
+		InstanceType     string `json:"instanceType"`
+		AccountID        string `json:"accountId"`
+		ImageID          string `json:"imageId"`
+	}
+)
@@ -1,5 +1,9 @@
 package trace
 
+import (
+	"time"
+)
+
 const (
 	UNKNOWN_EXPORTER  OTLPExporterType = 0
 	TLS_GRPC_EXPORTER OTLPExporterType = 1
@@ -8,8 +12,16 @@ const (
 	HTTP_EXPORTER     OTLPExporterType = 4
 
 	OTLP_GZIP_COMPRESSIONS OTLPCompression = "gzip"
+
+	// SamplingHintKey the head sampling decision, keep or drop, recorded by the tail sampling hint sampler
+	SamplingHintKey = "sampling.hint"
+	KeepHint        = "keep"
+	DropHint        = "drop"
 )
 
+// timeNow is replaced in the tests
+var timeNow = time.Now
+
 func LogMessage(msg string) string {
 	return "[gokit::otel] " + msg
 }
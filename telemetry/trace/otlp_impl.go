@@ -7,9 +7,10 @@ import (
 
 	"github.com/ralvescosta/gokit/env"
 	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/requestid"
+	"github.com/ralvescosta/gokit/telemetry/resources"
 
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
@@ -30,6 +31,8 @@ func NewOTLP(cfg *env.Configs, logger logging.ILogger) TraceBuilder {
 		timeout:            30 * time.Second,
 		compression:        OTLP_GZIP_COMPRESSIONS,
 		headers:            Headers{},
+		detectors:          resources.DefaultDetectors(),
+		sampler:            NewSampler(cfg),
 	}
 }
 
@@ -73,6 +76,21 @@ func (b *traceBuilder) WithCompression(c OTLPCompression) TraceBuilder {
 	return b
 }
 
+func (b *traceBuilder) WithSampler(sampler sdkTrace.Sampler) TraceBuilder {
+	b.sampler = sampler
+	return b
+}
+
+func (b *traceBuilder) WithResourceDetectors(detectors ...resource.Detector) TraceBuilder {
+	b.detectors = detectors
+	return b
+}
+
+func (b *traceBuilder) WithSpanProcessors(processors ...sdkTrace.SpanProcessor) TraceBuilder {
+	b.processors = append(b.processors, processors...)
+	return b
+}
+
 func (b *traceBuilder) Build(ctx context.Context) (shutdown func(context.Context) error, err error) {
 	switch b.exporterType {
 	case GRPC_EXPORTER:
@@ -113,24 +131,21 @@ func (b *traceBuilder) buildGrpcExporter(ctx context.Context) (shutdown func(con
 	}
 
 	b.logger.Debug(LogMessage("creating otlp resource..."))
-	resources, err := resource.New(
-		ctx,
-		resource.WithAttributes(
-			attribute.String("service.name", b.appName),
-			attribute.String("library.language", "go"),
-		),
-	)
+	res, err := resources.New(ctx, b.logger, b.appName, b.detectors...)
 	if err != nil {
 		b.logger.Error(LogMessage("could not set resources"), logging.ErrorField(err))
 		return nil, err
 	}
 
 	b.logger.Debug(LogMessage("setting otlp provider..."))
+	providerOpts := []sdkTrace.TracerProviderOption{sdkTrace.WithSampler(b.sampler)}
+	for _, p := range append([]sdkTrace.SpanProcessor{requestid.NewSpanProcessor()}, b.processors...) {
+		providerOpts = append(providerOpts, sdkTrace.WithSpanProcessor(p))
+	}
+
 	otel.SetTracerProvider(
 		sdkTrace.NewTracerProvider(
-			sdkTrace.WithSampler(sdkTrace.AlwaysSample()),
-			sdkTrace.WithBatcher(exporter),
-			sdkTrace.WithResource(resources),
+			append(providerOpts, sdkTrace.WithBatcher(exporter), sdkTrace.WithResource(res))...,
 		),
 	)
 
@@ -0,0 +1,131 @@
+package trace
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ralvescosta/gokit/env"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// the span attributes matched by the overrides besides the span name
+var overrideAttributes = []attribute.Key{"messaging.source", "messaging.destination"}
+
+// NewSampler create the sampler configured by the TRACING_SAMPLER envs
+//
+// The overrides are verified first, e.g: TRACING_SAMPLER_OVERRIDES="GET /health=0,heartbeat=0" never samples the health check route and the heartbeat queue
+func NewSampler(cfg *env.Configs) sdkTrace.Sampler {
+	var sampler sdkTrace.Sampler
+
+	switch cfg.TRACING_SAMPLER {
+	case env.ALWAYS_OFF_SAMPLER:
+		sampler = sdkTrace.NeverSample()
+	case env.RATIO_SAMPLER:
+		sampler = sdkTrace.TraceIDRatioBased(cfg.TRACING_SAMPLER_RATIO)
+	case env.RATE_LIMITING_SAMPLER:
+		sampler = NewRateLimitingSampler(cfg.TRACING_SAMPLER_RATE)
+	default:
+		sampler = sdkTrace.AlwaysSample()
+	}
+
+	if cfg.TRACING_SAMPLER_TAIL_HINT {
+		sampler = NewTailSamplingHintSampler(sampler)
+	}
+
+	if cfg.TRACING_SAMPLER_PARENT_BASED {
+		sampler = sdkTrace.ParentBased(sampler)
+	}
+
+	if len(cfg.TRACING_SAMPLER_OVERRIDES) > 0 {
+		sampler = NewOverrideSampler(sampler, cfg.TRACING_SAMPLER_OVERRIDES)
+	}
+
+	return sampler
+}
+
+// NewRateLimitingSampler sample at most rate traces per second, the burst is the rate or 1 trace when the rate is lower
+func NewRateLimitingSampler(rate float64) sdkTrace.Sampler {
+	return &rateLimitingSampler{rate: rate, tokens: math.Max(rate, 1), lastRefill: timeNow()}
+}
+
+func (s *rateLimitingSampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := timeNow()
+	s.tokens += now.Sub(s.lastRefill).Seconds() * s.rate
+	s.tokens = math.Min(s.tokens, math.Max(s.rate, 1))
+	s.lastRefill = now
+
+	decision := sdkTrace.Drop
+	if s.tokens >= 1 {
+		s.tokens--
+		decision = sdkTrace.RecordAndSample
+	}
+
+	return sdkTrace.SamplingResult{Decision: decision, Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState()}
+}
+
+func (s *rateLimitingSampler) Description() string {
+	return fmt.Sprintf("RateLimitingSampler{%g}", s.rate)
+}
+
+// NewTailSamplingHintSampler the spans are always exported with the sampler decision in the SamplingHintKey attribute
+func NewTailSamplingHintSampler(sampler sdkTrace.Sampler) sdkTrace.Sampler {
+	return &tailSamplingHintSampler{sampler}
+}
+
+func (s *tailSamplingHintSampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	result := s.sampler.ShouldSample(p)
+
+	hint := KeepHint
+	if result.Decision == sdkTrace.Drop {
+		hint = DropHint
+	}
+
+	result.Decision = sdkTrace.RecordAndSample
+	result.Attributes = append(result.Attributes, attribute.String(SamplingHintKey, hint))
+
+	return result
+}
+
+func (s *tailSamplingHintSampler) Description() string {
+	return fmt.Sprintf("TailSamplingHint{%s}", s.sampler.Description())
+}
+
+// NewOverrideSampler the overrides are the ratio by span name, e.g: GET /health, or by queue
+func NewOverrideSampler(sampler sdkTrace.Sampler, overrides map[string]float64) sdkTrace.Sampler {
+	s := &overrideSampler{sampler: sampler, overrides: map[string]sdkTrace.Sampler{}}
+
+	for name, ratio := range overrides {
+		s.overrides[name] = sdkTrace.TraceIDRatioBased(ratio)
+	}
+
+	return s
+}
+
+func (s *overrideSampler) ShouldSample(p sdkTrace.SamplingParameters) sdkTrace.SamplingResult {
+	if override, ok := s.overrides[p.Name]; ok {
+		return override.ShouldSample(p)
+	}
+
+	for _, attr := range p.Attributes {
+		for _, key := range overrideAttributes {
+			if attr.Key != key {
+				continue
+			}
+
+			if override, ok := s.overrides[attr.Value.AsString()]; ok {
+				return override.ShouldSample(p)
+			}
+		}
+	}
+
+	return s.sampler.ShouldSample(p)
+}
+
+func (s *overrideSampler) Description() string {
+	return fmt.Sprintf("OverrideSampler{%s,overrides:%d}", s.sampler.Description(), len(s.overrides))
+}
@@ -0,0 +1,81 @@
+package trace
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ralvescosta/gokit/env"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type SamplerTestSuite struct {
+	suite.Suite
+}
+
+func TestSamplerTestSuite(t *testing.T) {
+	suite.Run(t, new(SamplerTestSuite))
+}
+
+func (s *SamplerTestSuite) TearDownTest() {
+	timeNow = time.Now
+}
+
+func (s *SamplerTestSuite) params(name string, attrs ...attribute.KeyValue) sdkTrace.SamplingParameters {
+	return sdkTrace.SamplingParameters{
+		ParentContext: context.Background(),
+		TraceID:       trace.TraceID{1},
+		Name:          name,
+		Attributes:    attrs,
+	}
+}
+
+func (s *SamplerTestSuite) TestRateLimiting() {
+	now := time.Now()
+	timeNow = func() time.Time { return now }
+
+	sampler := NewRateLimitingSampler(2)
+
+	s.Equal(sdkTrace.RecordAndSample, sampler.ShouldSample(s.params("a")).Decision)
+	s.Equal(sdkTrace.RecordAndSample, sampler.ShouldSample(s.params("a")).Decision)
+	s.Equal(sdkTrace.Drop, sampler.ShouldSample(s.params("a")).Decision)
+
+	now = now.Add(500 * time.Millisecond)
+	s.Equal(sdkTrace.RecordAndSample, sampler.ShouldSample(s.params("a")).Decision)
+	s.Equal(sdkTrace.Drop, sampler.ShouldSample(s.params("a")).Decision)
+}
+
+func (s *SamplerTestSuite) TestTailSamplingHint() {
+	result := NewTailSamplingHintSampler(sdkTrace.NeverSample()).ShouldSample(s.params("a"))
+
+	s.Equal(sdkTrace.RecordAndSample, result.Decision)
+	s.Contains(result.Attributes, attribute.String(SamplingHintKey, DropHint))
+}
+
+func (s *SamplerTestSuite) TestNewSamplerWithOverrides() {
+	sampler := NewSampler(&env.Configs{
+		TRACING_SAMPLER:              env.ALWAYS_ON_SAMPLER,
+		TRACING_SAMPLER_PARENT_BASED: true,
+		TRACING_SAMPLER_OVERRIDES:    map[string]float64{"GET /health": 0, "heartbeat": 0},
+	})
+
+	s.Equal(sdkTrace.Drop, sampler.ShouldSample(s.params("GET /health")).Decision)
+	s.Equal(sdkTrace.Drop, sampler.ShouldSample(s.params("heartbeat process", attribute.String("messaging.source", "heartbeat"))).Decision)
+	s.Equal(sdkTrace.RecordAndSample, sampler.ShouldSample(s.params("GET /orders")).Decision)
+}
+
+func (s *SamplerTestSuite) TestNewSampler() {
+	sampler := NewSampler(&env.Configs{TRACING_SAMPLER: env.ALWAYS_OFF_SAMPLER})
+	s.Equal(sdkTrace.Drop, sampler.ShouldSample(s.params("a")).Decision)
+
+	sampler = NewSampler(&env.Configs{TRACING_SAMPLER: env.RATIO_SAMPLER, TRACING_SAMPLER_RATIO: 0, TRACING_SAMPLER_TAIL_HINT: true})
+	result := sampler.ShouldSample(s.params("a"))
+	s.Equal(sdkTrace.RecordAndSample, result.Decision)
+	s.Contains(result.Attributes, attribute.String(SamplingHintKey, DropHint))
+
+	sampler = NewSampler(&env.Configs{TRACING_SAMPLER: env.RATE_LIMITING_SAMPLER, TRACING_SAMPLER_RATE: 1, TRACING_SAMPLER_PARENT_BASED: true})
+	s.Contains(sampler.Description(), "RateLimitingSampler{1}")
+}
@@ -2,10 +2,13 @@ package trace
 
 import (
 	"context"
+	"sync"
 	"time"
 
 	"github.com/ralvescosta/gokit/env"
 	"github.com/ralvescosta/gokit/logging"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type (
@@ -22,6 +25,13 @@ type (
 		WithTimeout(t time.Duration) TraceBuilder
 		WithReconnection(t time.Duration) TraceBuilder
 		WithCompression(c OTLPCompression) TraceBuilder
+		// WithSampler replace the sampler configured by the env, see NewSampler
+		WithSampler(sampler sdkTrace.Sampler) TraceBuilder
+		// WithResourceDetectors replace the default resource detectors, without detectors only the service name is set
+		WithResourceDetectors(detectors ...resource.Detector) TraceBuilder
+		// WithSpanProcessors register span processors before the exporter, e.g: correlation.NewSpanProcessor, the
+		// requestid.NewSpanProcessor is always registered
+		WithSpanProcessors(processors ...sdkTrace.SpanProcessor) TraceBuilder
 		Build(context.Context) (shutdown func(context.Context) error, err error)
 	}
 
@@ -36,5 +46,27 @@ type (
 		reconnectionPeriod time.Duration
 		timeout            time.Duration
 		compression        OTLPCompression
+		detectors          []resource.Detector
+		sampler            sdkTrace.Sampler
+		processors         []sdkTrace.SpanProcessor
+	}
+
+	// rateLimitingSampler token bucket refilled with rate tokens per second
+	rateLimitingSampler struct {
+		mu         sync.Mutex
+		rate       float64
+		tokens     float64
+		lastRefill time.Time
+	}
+
+	// tailSamplingHintSampler record all the spans with the head decision as an attribute, so the collector tail sampling decides
+	tailSamplingHintSampler struct {
+		sampler sdkTrace.Sampler
+	}
+
+	// overrideSampler the ratio by span name or queue takes precedence over the sampler
+	overrideSampler struct {
+		sampler   sdkTrace.Sampler
+		overrides map[string]sdkTrace.Sampler
 	}
 )
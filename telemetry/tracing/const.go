@@ -0,0 +1,5 @@
+package tracing
+
+const (
+	TracerName = "github.com/ralvescosta/gokit"
+)
@@ -0,0 +1,97 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/baggage"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// Propagator the W3C trace context and baggage propagator used by the toolkit, it works even when the global propagator was not configured
+var Propagator propagation.TextMapPropagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// SetBaggage add a member in the ctx baggage, the baggage is propagated through HTTP and AMQP headers
+func SetBaggage(ctx context.Context, key, value string) (context.Context, error) {
+	member, err := baggage.NewMember(key, value)
+	if err != nil {
+		return ctx, err
+	}
+
+	b, err := baggage.FromContext(ctx).SetMember(member)
+	if err != nil {
+		return ctx, err
+	}
+
+	return baggage.ContextWithBaggage(ctx, b), nil
+}
+
+// GetBaggage returns the value of a baggage member, empty when it does not exist
+func GetBaggage(ctx context.Context, key string) string {
+	return baggage.FromContext(ctx).Member(key).Value()
+}
+
+// BaggageAttributes converts the baggage members into span attributes
+func BaggageAttributes(ctx context.Context) []attribute.KeyValue {
+	members := baggage.FromContext(ctx).Members()
+
+	attrs := make([]attribute.KeyValue, 0, len(members))
+	for _, m := range members {
+		attrs = append(attrs, attribute.String(m.Key(), m.Value()))
+	}
+
+	return attrs
+}
+
+// Inject write the span context and the baggage in the carrier
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	Propagator.Inject(ctx, carrier)
+}
+
+// Extract read the span context and the baggage from the carrier
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return Propagator.Extract(ctx, carrier)
+}
+
+// InjectHTTP write the span context and the baggage in the request headers
+func InjectHTTP(ctx context.Context, header http.Header) {
+	Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHTTP read the span context and the baggage from the request headers
+func ExtractHTTP(ctx context.Context, header http.Header) context.Context {
+	return Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// InjectAMQP write the span context and the baggage in the amqp headers
+func InjectAMQP(ctx context.Context, headers map[string]interface{}) {
+	Inject(ctx, AMQPHeadersCarrier(headers))
+}
+
+// ExtractAMQP read the span context and the baggage from the amqp headers
+func ExtractAMQP(ctx context.Context, headers map[string]interface{}) context.Context {
+	return Extract(ctx, AMQPHeadersCarrier(headers))
+}
+
+func (c AMQPHeadersCarrier) Get(key string) string {
+	v, ok := c[key].(string)
+	if !ok {
+		return ""
+	}
+
+	return v
+}
+
+func (c AMQPHeadersCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c AMQPHeadersCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Span start an internal span using the global tracer provider
+func Span(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, EndFunc) {
+	return SpanKind(ctx, trace.SpanKindInternal, name, attrs...)
+}
+
+// SpanKind start a span with the kind, e.g: trace.SpanKindClient to calls to external services
+func SpanKind(ctx context.Context, kind trace.SpanKind, name string, attrs ...attribute.KeyValue) (context.Context, EndFunc) {
+	ctx, span := otel.Tracer(TracerName).Start(ctx, name, trace.WithSpanKind(kind), trace.WithAttributes(attrs...))
+
+	return ctx, func(err *error) {
+		if err != nil && *err != nil {
+			span.RecordError(*err)
+			span.SetStatus(codes.Error, (*err).Error())
+		}
+
+		span.End()
+	}
+}
+
+// WithSpan execute fn inside a span recording the returned error
+func WithSpan(ctx context.Context, name string, fn SpanFunc, attrs ...attribute.KeyValue) (err error) {
+	ctx, end := Span(ctx, name, attrs...)
+	defer end(&err)
+
+	return fn(ctx)
+}
+
+// WithSpanResult execute fn inside a span recording the returned error
+func WithSpanResult[T any](ctx context.Context, name string, fn func(ctx context.Context) (T, error), attrs ...attribute.KeyValue) (res T, err error) {
+	ctx, end := Span(ctx, name, attrs...)
+	defer end(&err)
+
+	return fn(ctx)
+}
+
+// RecordError record the error in the span of the ctx without ending it
+func RecordError(ctx context.Context, err error) {
+	if err == nil {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// AddAttributes set attributes in the span of the ctx
+func AddAttributes(ctx context.Context, attrs ...attribute.KeyValue) {
+	trace.SpanFromContext(ctx).SetAttributes(attrs...)
+}
@@ -0,0 +1,135 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type TracingTestSuite struct {
+	suite.Suite
+
+	recorder *tracetest.SpanRecorder
+}
+
+func TestTracingTestSuite(t *testing.T) {
+	suite.Run(t, new(TracingTestSuite))
+}
+
+func (s *TracingTestSuite) SetupTest() {
+	s.recorder = tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdkTrace.NewTracerProvider(sdkTrace.WithSpanProcessor(s.recorder)))
+}
+
+func (s *TracingTestSuite) TestSpan() {
+	err := func() (err error) {
+		_, end := Span(context.Background(), "span", attribute.String("key", "value"))
+		defer end(&err)
+
+		return errors.New("some error")
+	}()
+
+	s.Error(err)
+
+	spans := s.recorder.Ended()
+	s.Len(spans, 1)
+	s.Equal("span", spans[0].Name())
+	s.Equal(trace.SpanKindInternal, spans[0].SpanKind())
+	s.Equal(codes.Error, spans[0].Status().Code)
+	s.Contains(spans[0].Attributes(), attribute.String("key", "value"))
+	s.Len(spans[0].Events(), 1)
+}
+
+func (s *TracingTestSuite) TestSpanKindWithoutError() {
+	_, end := SpanKind(context.Background(), trace.SpanKindClient, "client")
+	end(nil)
+
+	spans := s.recorder.Ended()
+	s.Len(spans, 1)
+	s.Equal(trace.SpanKindClient, spans[0].SpanKind())
+	s.Equal(codes.Unset, spans[0].Status().Code)
+}
+
+func (s *TracingTestSuite) TestWithSpan() {
+	var parent trace.SpanContext
+	err := WithSpan(context.Background(), "parent", func(ctx context.Context) error {
+		parent = trace.SpanContextFromContext(ctx)
+
+		_, err := WithSpanResult(ctx, "child", func(ctx context.Context) (int, error) {
+			return 0, errors.New("some error")
+		})
+
+		return err
+	})
+
+	s.Error(err)
+
+	spans := s.recorder.Ended()
+	s.Len(spans, 2)
+	s.Equal("child", spans[0].Name())
+	s.Equal(parent.SpanID(), spans[0].Parent().SpanID())
+	s.Equal(codes.Error, spans[1].Status().Code)
+}
+
+func (s *TracingTestSuite) TestRecordErrorAndAttributes() {
+	ctx, end := Span(context.Background(), "span")
+	RecordError(ctx, nil)
+	RecordError(ctx, errors.New("some error"))
+	AddAttributes(ctx, attribute.Int("count", 1))
+	end(nil)
+
+	spans := s.recorder.Ended()
+	s.Equal(codes.Error, spans[0].Status().Code)
+	s.Contains(spans[0].Attributes(), attribute.Int("count", 1))
+}
+
+func (s *TracingTestSuite) TestBaggage() {
+	ctx, err := SetBaggage(context.Background(), "tenant", "acme")
+	s.NoError(err)
+
+	s.Equal("acme", GetBaggage(ctx, "tenant"))
+	s.Equal("", GetBaggage(ctx, "unknown"))
+	s.Equal([]attribute.KeyValue{attribute.String("tenant", "acme")}, BaggageAttributes(ctx))
+
+	_, err = SetBaggage(ctx, "invalid key", "value")
+	s.Error(err)
+}
+
+func (s *TracingTestSuite) TestHTTPPropagation() {
+	ctx, _ := SetBaggage(context.Background(), "tenant", "acme")
+	ctx, end := Span(ctx, "span")
+	defer end(nil)
+
+	header := http.Header{}
+	InjectHTTP(ctx, header)
+	s.NotEmpty(header.Get("traceparent"))
+
+	extracted := ExtractHTTP(context.Background(), header)
+	s.Equal("acme", GetBaggage(extracted, "tenant"))
+	s.Equal(trace.SpanContextFromContext(ctx).TraceID(), trace.SpanContextFromContext(extracted).TraceID())
+}
+
+func (s *TracingTestSuite) TestAMQPPropagation() {
+	ctx, _ := SetBaggage(context.Background(), "tenant", "acme")
+	ctx, end := Span(ctx, "span")
+	defer end(nil)
+
+	headers := map[string]interface{}{"x-count": int64(1)}
+	InjectAMQP(ctx, headers)
+	s.NotEmpty(headers["traceparent"])
+	s.ElementsMatch([]string{"x-count", "traceparent", "baggage"}, AMQPHeadersCarrier(headers).Keys())
+	s.Equal("", AMQPHeadersCarrier(headers).Get("x-count"))
+
+	extracted := ExtractAMQP(context.Background(), headers)
+	s.Equal("acme", GetBaggage(extracted, "tenant"))
+	s.Equal(trace.SpanContextFromContext(ctx).TraceID(), trace.SpanContextFromContext(extracted).TraceID())
+}
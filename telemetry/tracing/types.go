@@ -0,0 +1,21 @@
+package tracing
+
+import (
+	"context"
+)
+
+type (
+	// EndFunc ends the span, when err points to a non nil error the error is recorded and the span status is set to error
+	//
+	// e.g:
+	//
+	//	ctx, end := tracing.Span(ctx, "repository.find")
+	//	defer end(&err)
+	EndFunc = func(err *error)
+
+	// SpanFunc a function executed inside a span by WithSpan
+	SpanFunc = func(ctx context.Context) error
+
+	// AMQPHeadersCarrier adapts the amqp.Table headers as a propagation.TextMapCarrier
+	AMQPHeadersCarrier map[string]interface{}
+)
@@ -0,0 +1,238 @@
+package tenancy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+// NewTenantConfig resolve the tenant values with the source, they are cached by tenant until the ttl, when the source fails
+// the expired values are still used. The keys without a tenant value use the defaults
+func NewTenantConfig(logger logging.ILogger, source ConfigSource, opts *ConfigOpts) ITenantConfig {
+	if opts == nil {
+		opts = &ConfigOpts{}
+	}
+
+	ttl := opts.TTL
+	if ttl == 0 {
+		ttl = DefaultConfigTTL
+	}
+
+	return &tenantConfig{
+		logger:   logger,
+		source:   source,
+		ttl:      ttl,
+		defaults: opts.Defaults,
+		entries:  map[string]*configEntry{},
+		timeNow:  time.Now,
+	}
+}
+
+func (f ConfigSourceFunc) Load(ctx context.Context, tenantID string) (map[string]string, error) {
+	return f(ctx, tenantID)
+}
+
+func (c *tenantConfig) Get(ctx context.Context, key string) (string, bool, error) {
+	tenantID, ok := TenantFromContext(ctx)
+	if !ok {
+		value, ok := c.defaults[key]
+		return value, ok, nil
+	}
+
+	values, err := c.values(ctx, tenantID)
+	if err != nil {
+		return "", false, err
+	}
+
+	if value, ok := values[key]; ok {
+		return value, true, nil
+	}
+
+	value, ok := c.defaults[key]
+	return value, ok, nil
+}
+
+func (c *tenantConfig) String(ctx context.Context, key, fallback string) string {
+	value, ok := c.lookup(ctx, key)
+	if !ok {
+		return fallback
+	}
+
+	return value
+}
+
+func (c *tenantConfig) Int(ctx context.Context, key string, fallback int) int {
+	value, ok := c.lookup(ctx, key)
+	if !ok {
+		return fallback
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		c.logger.Warn(LogMessage(fmt.Sprintf("invalid int config: %s", key)), logging.ErrorField(err))
+		return fallback
+	}
+
+	return i
+}
+
+func (c *tenantConfig) Bool(ctx context.Context, key string, fallback bool) bool {
+	value, ok := c.lookup(ctx, key)
+	if !ok {
+		return fallback
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		c.logger.Warn(LogMessage(fmt.Sprintf("invalid bool config: %s", key)), logging.ErrorField(err))
+		return fallback
+	}
+
+	return b
+}
+
+func (c *tenantConfig) Duration(ctx context.Context, key string, fallback time.Duration) time.Duration {
+	value, ok := c.lookup(ctx, key)
+	if !ok {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		c.logger.Warn(LogMessage(fmt.Sprintf("invalid duration config: %s", key)), logging.ErrorField(err))
+		return fallback
+	}
+
+	return d
+}
+
+func (c *tenantConfig) Invalidate(tenantID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tenantID == "" {
+		c.entries = map[string]*configEntry{}
+		return
+	}
+
+	delete(c.entries, tenantID)
+}
+
+// lookup the typed getters use the fallback when the source fails
+func (c *tenantConfig) lookup(ctx context.Context, key string) (string, bool) {
+	value, ok, err := c.Get(ctx, key)
+	if err != nil {
+		c.logger.Error(LogMessage(fmt.Sprintf("failure to resolve the config: %s", key)), logging.ErrorField(err))
+		return "", false
+	}
+
+	return value, ok
+}
+
+// values the concurrent requests of a tenant with an expired entry wait the same load
+func (c *tenantConfig) values(ctx context.Context, tenantID string) (map[string]string, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[tenantID]
+	if ok && entry.loading != nil {
+		loading := entry.loading
+		c.mu.Unlock()
+
+		select {
+		case <-loading:
+			return entry.values, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if ok && entry.expiresAt.After(c.timeNow()) {
+		c.mu.Unlock()
+		return entry.values, nil
+	}
+
+	loading := &configEntry{loading: make(chan struct{})}
+	if ok {
+		loading.values = entry.values
+	}
+
+	c.entries[tenantID] = loading
+	c.mu.Unlock()
+
+	values, err := c.source.Load(ctx, tenantID)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch {
+	case err == nil:
+		loading.values = values
+		loading.expiresAt = c.timeNow().Add(c.ttl)
+	case loading.values != nil:
+		// the stale values are used until the source recovers, the next call loads them again
+		c.logger.Warn(LogMessage(fmt.Sprintf("using the stale config of the tenant: %s", tenantID)), logging.ErrorField(err))
+		err = nil
+	default:
+		loading.err = err
+		delete(c.entries, tenantID)
+	}
+
+	close(loading.loading)
+	loading.loading = nil
+
+	return loading.values, err
+}
+
+// NewSqlConfigSource read the tenant values from the table, it could be created with MigrateConfig
+func NewSqlConfigSource(db *sql.DB, table string) ConfigSource {
+	if table == "" {
+		table = DefaultConfigTable
+	}
+
+	query := fmt.Sprintf("SELECT key, value FROM %s WHERE tenant_id = $1", table)
+
+	return ConfigSourceFunc(func(ctx context.Context, tenantID string) (map[string]string, error) {
+		rows, err := db.QueryContext(ctx, query, tenantID)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		values := map[string]string{}
+		for rows.Next() {
+			var key, value string
+			if err := rows.Scan(&key, &value); err != nil {
+				return nil, err
+			}
+
+			values[key] = value
+		}
+
+		return values, rows.Err()
+	})
+}
+
+// MigrateConfig create the tenant config table if it does not exist
+func MigrateConfig(ctx context.Context, db *sql.DB, table string) error {
+	if table == "" {
+		table = DefaultConfigTable
+	}
+
+	_, err := db.ExecContext(ctx, fmt.Sprintf(ConfigPostgresSchema, table))
+	return err
+}
+
+// InvalidationHandler invalidate the cached config of the x-tenant-id header, the messages without the header invalidate
+// all the tenants. Bind it to an exclusive queue of each instance, so all the instances receive the changes
+func InvalidationHandler(config ITenantConfig) rabbitmq.ConsumerHandler {
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		tenantID, _ := TenantFromMetadata(metadata)
+		config.Invalidate(tenantID)
+
+		return nil
+	}
+}
@@ -0,0 +1,136 @@
+package tenancy
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/suite"
+)
+
+type TenantConfigTestSuite struct {
+	suite.Suite
+
+	loads  int32
+	err    error
+	now    time.Time
+	config *tenantConfig
+}
+
+func TestTenantConfigTestSuite(t *testing.T) {
+	suite.Run(t, new(TenantConfigTestSuite))
+}
+
+func (s *TenantConfigTestSuite) SetupTest() {
+	s.loads = 0
+	s.err = nil
+	s.now = time.Now()
+
+	source := ConfigSourceFunc(func(ctx context.Context, tenantID string) (map[string]string, error) {
+		atomic.AddInt32(&s.loads, 1)
+		if s.err != nil {
+			return nil, s.err
+		}
+
+		if tenantID == "acme" {
+			return map[string]string{"rate_limit": "100", "beta": "true", "webhook_timeout": "3s"}, nil
+		}
+
+		return map[string]string{}, nil
+	})
+
+	s.config = NewTenantConfig(logging.NewMockLogger(), source, &ConfigOpts{
+		TTL:      time.Minute,
+		Defaults: map[string]string{"rate_limit": "10"},
+	}).(*tenantConfig)
+	s.config.timeNow = func() time.Time { return s.now }
+}
+
+func (s *TenantConfigTestSuite) TestResolve() {
+	acme := ContextWithTenant(context.Background(), "acme")
+	globex := ContextWithTenant(context.Background(), "globex")
+
+	s.Equal(100, s.config.Int(acme, "rate_limit", 1))
+	s.True(s.config.Bool(acme, "beta", false))
+	s.Equal(3*time.Second, s.config.Duration(acme, "webhook_timeout", time.Second))
+	s.Equal(10, s.config.Int(globex, "rate_limit", 1))
+	s.Equal(10, s.config.Int(context.Background(), "rate_limit", 1))
+	s.Equal("fallback", s.config.String(acme, "unknown", "fallback"))
+	s.Equal(1, s.config.Int(acme, "beta", 1))
+
+	s.Equal(int32(2), atomic.LoadInt32(&s.loads))
+}
+
+func (s *TenantConfigTestSuite) TestCacheAndInvalidate() {
+	ctx := ContextWithTenant(context.Background(), "acme")
+
+	s.config.Int(ctx, "rate_limit", 1)
+	s.config.Int(ctx, "rate_limit", 1)
+	s.Equal(int32(1), atomic.LoadInt32(&s.loads))
+
+	s.config.Invalidate("acme")
+	s.config.Int(ctx, "rate_limit", 1)
+	s.Equal(int32(2), atomic.LoadInt32(&s.loads))
+
+	s.now = s.now.Add(2 * time.Minute)
+	s.config.Int(ctx, "rate_limit", 1)
+	s.Equal(int32(3), atomic.LoadInt32(&s.loads))
+
+	handler := InvalidationHandler(s.config)
+	s.NoError(handler(nil, &rabbitmq.DeliveryMetadata{Headers: map[string]interface{}{}}))
+	s.config.Int(ctx, "rate_limit", 1)
+	s.Equal(int32(4), atomic.LoadInt32(&s.loads))
+}
+
+func (s *TenantConfigTestSuite) TestSourceFailure() {
+	ctx := ContextWithTenant(context.Background(), "acme")
+	s.config.Int(ctx, "rate_limit", 1)
+
+	// the stale values are used when the source fails
+	s.err = errors.New("unavailable")
+	s.now = s.now.Add(2 * time.Minute)
+	s.Equal(100, s.config.Int(ctx, "rate_limit", 1))
+
+	s.config.Invalidate("")
+	_, _, err := s.config.Get(ctx, "rate_limit")
+	s.Error(err)
+	s.Equal(1, s.config.Int(ctx, "rate_limit", 1))
+}
+
+func (s *TenantConfigTestSuite) TestConcurrentLoad() {
+	ctx := ContextWithTenant(context.Background(), "acme")
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Equal(100, s.config.Int(ctx, "rate_limit", 1))
+		}()
+	}
+
+	wg.Wait()
+	s.Equal(int32(1), atomic.LoadInt32(&s.loads))
+}
+
+func (s *TenantConfigTestSuite) TestSqlConfigSource() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectQuery(regexp.QuoteMeta("SELECT key, value FROM tenant_configs WHERE tenant_id = $1")).
+		WithArgs("acme").
+		WillReturnRows(sqlmock.NewRows([]string{"key", "value"}).AddRow("rate_limit", "100").AddRow("beta", "true"))
+
+	values, err := NewSqlConfigSource(db, "").Load(context.Background(), "acme")
+
+	s.NoError(err)
+	s.Equal(map[string]string{"rate_limit": "100", "beta": "true"}, values)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
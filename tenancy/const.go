@@ -0,0 +1,36 @@
+package tenancy
+
+import (
+	"errors"
+	"regexp"
+	"time"
+)
+
+const (
+	TenantIDHeader     = "X-Tenant-Id"
+	AMQPHeaderTenantID = "x-tenant-id"
+
+	// DefaultSetting the postgres setting read by the RLS policies, e.g: current_setting('app.tenant_id')
+	DefaultSetting = "app.tenant_id"
+
+	DefaultConfigTTL   = 5 * time.Minute
+	DefaultConfigTable = "tenant_configs"
+
+	ConfigPostgresSchema = `CREATE TABLE IF NOT EXISTS %s (
+	tenant_id TEXT NOT NULL,
+	key       TEXT NOT NULL,
+	value     TEXT NOT NULL,
+	PRIMARY KEY (tenant_id, key)
+)`
+)
+
+var (
+	ErrorTenantRequired = errors.New("tenant id is required")
+	ErrorInvalidTenant  = errors.New("invalid tenant id")
+
+	tenantIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,64}$`)
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::tenancy] " + msg
+}
@@ -0,0 +1,29 @@
+package tenancy
+
+import "context"
+
+type tenantCtxKey struct{}
+
+// ContextWithTenant store the tenant id in the context
+func ContextWithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantCtxKey{}, tenantID)
+}
+
+// TenantFromContext get the tenant id from the context
+func TenantFromContext(ctx context.Context) (string, bool) {
+	tenantID, ok := ctx.Value(tenantCtxKey{}).(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// Validate check the tenant id format, only letters, digits, "_" and "-" with up to 64 characters are allowed
+func Validate(tenantID string) error {
+	if tenantID == "" {
+		return ErrorTenantRequired
+	}
+
+	if !tenantIDPattern.MatchString(tenantID) {
+		return ErrorInvalidTenant
+	}
+
+	return nil
+}
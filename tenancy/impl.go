@@ -0,0 +1,79 @@
+package tenancy
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// NewTenantDB create the transactions helper used with the postgres RLS policies, opts is optional
+func NewTenantDB(db *sql.DB, opts *Opts) ITenantDB {
+	if opts == nil {
+		opts = &Opts{}
+	}
+
+	if opts.Setting == "" {
+		opts.Setting = DefaultSetting
+	}
+
+	return &tenantDB{db, opts}
+}
+
+func (t *tenantDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	tenantID, ok := TenantFromContext(ctx)
+	if !ok {
+		return nil, ErrorTenantRequired
+	}
+
+	if err := Validate(tenantID); err != nil {
+		return nil, err
+	}
+
+	tx, err := t.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.configure(ctx, tx, tenantID); err != nil {
+		_ = tx.Rollback()
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (t *tenantDB) WithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := t.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// configure use set_config with is_local = true, the same as SET LOCAL but accepting bind parameters
+func (t *tenantDB) configure(ctx context.Context, tx *sql.Tx, tenantID string) error {
+	if _, err := tx.ExecContext(ctx, "SELECT set_config($1, $2, true)", t.opts.Setting, tenantID); err != nil {
+		return fmt.Errorf("%s: %w", LogMessage("failure to set the tenant"), err)
+	}
+
+	if t.opts.Schema == nil {
+		return nil
+	}
+
+	if _, err := tx.ExecContext(ctx, "SELECT set_config('search_path', $1, true)", quoteIdentifier(t.opts.Schema(tenantID))); err != nil {
+		return fmt.Errorf("%s: %w", LogMessage("failure to set the search_path"), err)
+	}
+
+	return nil
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
@@ -0,0 +1,125 @@
+package tenancy
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/stretchr/testify/suite"
+)
+
+type TenancyTestSuite struct {
+	suite.Suite
+}
+
+func TestTenancyTestSuite(t *testing.T) {
+	suite.Run(t, new(TenancyTestSuite))
+}
+
+func (s *TenancyTestSuite) TestValidate() {
+	s.NoError(Validate("acme_01-br"))
+	s.ErrorIs(Validate(""), ErrorTenantRequired)
+	s.ErrorIs(Validate("acme'; DROP TABLE users"), ErrorInvalidTenant)
+}
+
+func (s *TenancyTestSuite) TestWithTx() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, true)")).
+		WithArgs(DefaultSetting, "acme").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec(regexp.QuoteMeta("SELECT set_config('search_path', $1, true)")).
+		WithArgs(`"tenant_acme"`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectExec("INSERT INTO orders").WillReturnResult(sqlmock.NewResult(1, 1))
+	sqlMock.ExpectCommit()
+
+	tenantDB := NewTenantDB(db, &Opts{Schema: func(tenantID string) string { return "tenant_" + tenantID }})
+
+	err := tenantDB.WithTx(ContextWithTenant(context.Background(), "acme"), func(tx *sql.Tx) error {
+		_, err := tx.Exec("INSERT INTO orders")
+		return err
+	})
+
+	s.NoError(err)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *TenancyTestSuite) TestWithTxRollback() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	sqlMock.ExpectBegin()
+	sqlMock.ExpectExec(regexp.QuoteMeta("SELECT set_config($1, $2, true)")).
+		WithArgs("custom.tenant", "acme").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	sqlMock.ExpectRollback()
+
+	tenantDB := NewTenantDB(db, &Opts{Setting: "custom.tenant"})
+
+	err := tenantDB.WithTx(ContextWithTenant(context.Background(), "acme"), func(tx *sql.Tx) error {
+		return errors.New("some error")
+	})
+
+	s.Error(err)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *TenancyTestSuite) TestBeginTxWithoutTenant() {
+	db, sqlMock, _ := sqlmock.New()
+	defer db.Close()
+
+	_, err := NewTenantDB(db, nil).BeginTx(context.Background(), nil)
+
+	s.ErrorIs(err, ErrorTenantRequired)
+	s.NoError(sqlMock.ExpectationsWereMet())
+}
+
+func (s *TenancyTestSuite) TestHTTPMiddleware() {
+	validator := func(ctx context.Context, tenantID string) error {
+		if tenantID != "acme" {
+			return errors.New("unknown tenant")
+		}
+		return nil
+	}
+
+	handler := HTTPMiddleware(logging.NewMockLogger(), validator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenantID, ok := TenantFromContext(r.Context())
+		s.True(ok)
+		s.Equal("acme", tenantID)
+	}))
+
+	for tenantID, status := range map[string]int{"acme": http.StatusOK, "other": http.StatusForbidden, "": http.StatusBadRequest, "a b": http.StatusBadRequest} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(TenantIDHeader, tenantID)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		s.Equal(status, rec.Code, tenantID)
+	}
+}
+
+func (s *TenancyTestSuite) TestConsumerHandler() {
+	called := 0
+	handler := ConsumerHandler(logging.NewMockLogger(), nil, func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		tenantID, ok := TenantFromMetadata(metadata)
+		s.True(ok)
+		s.Equal("acme", tenantID)
+		called++
+		return nil
+	})
+
+	s.NoError(handler(nil, &rabbitmq.DeliveryMetadata{Headers: map[string]interface{}{AMQPHeaderTenantID: "acme"}}))
+	s.ErrorIs(handler(nil, &rabbitmq.DeliveryMetadata{Headers: map[string]interface{}{}}), ErrorTenantRequired)
+	s.Equal(1, called)
+}
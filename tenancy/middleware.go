@@ -0,0 +1,73 @@
+package tenancy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+)
+
+// HTTPMiddleware extract the tenant id from the X-Tenant-Id header and store it in the request context
+//
+// The requests without tenant or with an invalid tenant are rejected with 400, the requests rejected by the validator with 403.
+// The validator is optional
+func HTTPMiddleware(logger logging.ILogger, validator ValidatorFunc) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			tenantID := r.Header.Get(TenantIDHeader)
+
+			if err := Validate(tenantID); err != nil {
+				logger.Warn(LogMessage("request rejected"), logging.ErrorField(err))
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			if validator != nil {
+				if err := validator(r.Context(), tenantID); err != nil {
+					logger.Warn(LogMessage("tenant rejected by the validator"), logging.ErrorField(err))
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r.WithContext(ContextWithTenant(r.Context(), tenantID)))
+		})
+	}
+}
+
+// TenantFromMetadata get the tenant id from the x-tenant-id header of the received message
+func TenantFromMetadata(metadata *rabbitmq.DeliveryMetadata) (string, bool) {
+	if metadata == nil {
+		return "", false
+	}
+
+	tenantID, ok := metadata.Headers[AMQPHeaderTenantID].(string)
+	return tenantID, ok && tenantID != ""
+}
+
+// ConsumerHandler decorate a messaging handler rejecting the messages without a valid x-tenant-id header
+//
+// The rejected messages are not retried, use TenantFromMetadata in the handler to get the tenant id. The validator is optional
+func ConsumerHandler(logger logging.ILogger, validator ValidatorFunc, handler rabbitmq.ConsumerHandler) rabbitmq.ConsumerHandler {
+	return func(msg any, metadata *rabbitmq.DeliveryMetadata) error {
+		if metadata == nil {
+			return ErrorTenantRequired
+		}
+
+		tenantID, _ := TenantFromMetadata(metadata)
+
+		err := Validate(tenantID)
+		if err == nil && validator != nil {
+			err = validator(ContextWithTenant(context.Background(), tenantID), tenantID)
+		}
+
+		if err != nil {
+			logger.Warn(LogMessage("message rejected"), logging.ErrorField(err), logging.MessageIdField(metadata.MessageId))
+			return fmt.Errorf("%s: %w", LogMessage("message rejected"), err)
+		}
+
+		return handler(msg, metadata)
+	}
+}
@@ -0,0 +1,71 @@
+package tenancy
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockTenantDB struct {
+		mock.Mock
+	}
+
+	MockTenantConfig struct {
+		mock.Mock
+	}
+)
+
+func (m *MockTenantDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	args := m.Called(ctx, opts)
+
+	tx, _ := args.Get(0).(*sql.Tx)
+	return tx, args.Error(1)
+}
+
+func (m *MockTenantDB) WithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	args := m.Called(ctx, fn)
+
+	return args.Error(0)
+}
+
+func NewMockTenantDB() *MockTenantDB {
+	return new(MockTenantDB)
+}
+
+func (m *MockTenantConfig) Get(ctx context.Context, key string) (string, bool, error) {
+	args := m.Called(ctx, key)
+	return args.String(0), args.Bool(1), args.Error(2)
+}
+
+func (m *MockTenantConfig) String(ctx context.Context, key, fallback string) string {
+	args := m.Called(ctx, key, fallback)
+	return args.String(0)
+}
+
+func (m *MockTenantConfig) Int(ctx context.Context, key string, fallback int) int {
+	args := m.Called(ctx, key, fallback)
+	return args.Int(0)
+}
+
+func (m *MockTenantConfig) Bool(ctx context.Context, key string, fallback bool) bool {
+	args := m.Called(ctx, key, fallback)
+	return args.Bool(0)
+}
+
+func (m *MockTenantConfig) Duration(ctx context.Context, key string, fallback time.Duration) time.Duration {
+	args := m.Called(ctx, key, fallback)
+
+	d, _ := args.Get(0).(time.Duration)
+	return d
+}
+
+func (m *MockTenantConfig) Invalidate(tenantID string) {
+	m.Called(tenantID)
+}
+
+func NewMockTenantConfig() *MockTenantConfig {
+	return new(MockTenantConfig)
+}
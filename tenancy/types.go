@@ -0,0 +1,82 @@
+package tenancy
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+)
+
+type (
+	// ValidatorFunc validate the tenant id, e.g: check if the tenant exist or if the authenticated user belongs to the tenant
+	ValidatorFunc = func(ctx context.Context, tenantID string) error
+
+	// SchemaFunc returns the schema used as search_path to the tenant
+	SchemaFunc = func(tenantID string) string
+
+	Opts struct {
+		// Setting the postgres setting configured in each transaction, the default is DefaultSetting
+		Setting string
+		// Schema optional, when configured the search_path is also configured in each transaction
+		Schema SchemaFunc
+	}
+
+	// ITenantDB begin transactions scoped to the tenant carried in the context
+	ITenantDB interface {
+		// BeginTx begin a transaction with the tenant settings configured with SET LOCAL semantics, they are discarded on commit or rollback
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+		// WithTx run fn in a tenant transaction, the transaction is committed when fn returns nil and rolled back otherwise
+		WithTx(ctx context.Context, fn func(tx *sql.Tx) error) error
+	}
+
+	tenantDB struct {
+		db   *sql.DB
+		opts *Opts
+	}
+
+	// ConfigSource load the values of the tenant, e.g: from a table or a remote service
+	ConfigSource interface {
+		Load(ctx context.Context, tenantID string) (map[string]string, error)
+	}
+
+	ConfigSourceFunc func(ctx context.Context, tenantID string) (map[string]string, error)
+
+	ConfigOpts struct {
+		// TTL how long the tenant values are cached, default DefaultConfigTTL
+		TTL time.Duration
+		// Defaults the values of the keys not configured to the tenant, e.g: the global rate limit
+		Defaults map[string]string
+	}
+
+	// ITenantConfig resolve the config values of the tenant carried in the context, e.g: rate limits, feature flags and
+	// webhook endpoints. The typed getters use the fallback when the key is not configured, invalid or the source fails
+	ITenantConfig interface {
+		Get(ctx context.Context, key string) (value string, ok bool, err error)
+		String(ctx context.Context, key, fallback string) string
+		Int(ctx context.Context, key string, fallback int) int
+		Bool(ctx context.Context, key string, fallback bool) bool
+		Duration(ctx context.Context, key string, fallback time.Duration) time.Duration
+		// Invalidate discard the cached values of the tenant, empty discard all the tenants
+		Invalidate(tenantID string)
+	}
+
+	tenantConfig struct {
+		logger   logging.ILogger
+		source   ConfigSource
+		ttl      time.Duration
+		defaults map[string]string
+		mu       sync.Mutex
+		entries  map[string]*configEntry
+		timeNow  func() time.Time
+	}
+
+	// configEntry the values are not changed after the loading is closed, a new load creates a new entry
+	configEntry struct {
+		values    map[string]string
+		err       error
+		expiresAt time.Time
+		loading   chan struct{}
+	}
+)
@@ -0,0 +1,46 @@
+package validation
+
+import "errors"
+
+const (
+	// CPF_TAG the brazilian individual taxpayer id, formatted or only digits
+	CPF_TAG = "cpf"
+	// CNPJ_TAG the brazilian company taxpayer id, formatted or only digits
+	CNPJ_TAG = "cnpj"
+	// PHONE_TAG the E.164 phone number, e.g: +5511999999999
+	PHONE_TAG = "phone"
+	// CURRENCY_TAG the ISO 4217 currency code, e.g: BRL
+	CURRENCY_TAG = "currency"
+	ULID_TAG     = "ulid"
+	UUID_TAG     = "uuid"
+
+	// DefaultMessage used to the tags without message, the param is the tag
+	DefaultMessage = "failed on the '%s' rule"
+)
+
+var (
+	ErrorValidation = errors.New("validation failure")
+)
+
+// messages the field-level messages by tag, the param of the tag is formatted in the %s
+var messages = map[string]string{
+	"required":   "is required",
+	"email":      "must be a valid email",
+	"url":        "must be a valid URL",
+	"min":        "must be at least %s",
+	"max":        "must be at most %s",
+	"len":        "must have the length %s",
+	"gt":         "must be greater than %s",
+	"gte":        "must be greater than or equal to %s",
+	"lt":         "must be less than %s",
+	"lte":        "must be less than or equal to %s",
+	"oneof":      "must be one of [%s]",
+	"e164":       "must be a valid E.164 phone number",
+	"iso4217":    "must be a valid ISO 4217 currency code",
+	CPF_TAG:      "must be a valid CPF",
+	CNPJ_TAG:     "must be a valid CNPJ",
+	PHONE_TAG:    "must be a valid E.164 phone number",
+	CURRENCY_TAG: "must be a valid ISO 4217 currency code",
+	ULID_TAG:     "must be a valid ULID",
+	UUID_TAG:     "must be a valid UUID",
+}
@@ -0,0 +1,132 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	validate     *validator.Validate
+	validateOnce sync.Once
+
+	registry = &messageRegistry{messages: messages}
+)
+
+// New create a validator with the toolkit rules, the field names are the json names
+//
+//	type CreateCustomerRequest struct {
+//		Document string `json:"document" validate:"required,cpf"`
+//		Phone    string `json:"phone" validate:"omitempty,phone"`
+//		Currency string `json:"currency" validate:"required,currency"`
+//	}
+func New() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name := strings.SplitN(f.Tag.Get("json"), ",", 2)[0]
+		if name == "" || name == "-" {
+			return f.Name
+		}
+
+		return name
+	})
+
+	registerRules(v)
+
+	return v
+}
+
+// Default returns the shared validator, use it to register custom validations
+func Default() *validator.Validate {
+	validateOnce.Do(func() {
+		validate = New()
+	})
+
+	return validate
+}
+
+// Struct validate the struct with the Default validator, the failures are returned as *ValidationError
+func Struct(v any) error {
+	return Translate(Default().Struct(v))
+}
+
+// Var validate a single value with the Default validator, e.g: validation.Var(document, "cpf")
+func Var(v any, tag string) error {
+	return Translate(Default().Var(v, tag))
+}
+
+// Translate convert the validator.ValidationErrors into *ValidationError, the other errors are returned as they are
+func Translate(err error) error {
+	var vErrs validator.ValidationErrors
+	if !errors.As(err, &vErrs) {
+		return err
+	}
+
+	vErr := &ValidationError{Fields: make([]*FieldError, 0, len(vErrs))}
+	for _, fe := range vErrs {
+		vErr.Fields = append(vErr.Fields, &FieldError{
+			Field:   fieldPath(fe),
+			Tag:     fe.Tag(),
+			Param:   fe.Param(),
+			Message: Message(fe),
+		})
+	}
+
+	return vErr
+}
+
+// RegisterMessage set the message of the tag, used to the custom validations or to translate the default messages
+func RegisterMessage(tag, message string) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	registry.messages[tag] = message
+}
+
+// Message the field-level message of the failure, e.g: must be at least 3
+func Message(fe validator.FieldError) string {
+	registry.mu.RLock()
+	msg, ok := registry.messages[fe.Tag()]
+	registry.mu.RUnlock()
+
+	if !ok {
+		return fmt.Sprintf(DefaultMessage, fe.Tag())
+	}
+
+	if strings.Contains(msg, "%s") {
+		return fmt.Sprintf(msg, fe.Param())
+	}
+
+	return msg
+}
+
+func (e *ValidationError) Error() string {
+	fields := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		fields = append(fields, f.Field+" "+f.Message)
+	}
+
+	return fmt.Sprintf("%s: %s", ErrorValidation, strings.Join(fields, "; "))
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrorValidation
+}
+
+// fieldPath the namespace without the root struct name, e.g: CreateUser.address.city -> address.city
+func fieldPath(fe validator.FieldError) string {
+	ns := fe.Namespace()
+	if i := strings.Index(ns, "."); i >= 0 {
+		return ns[i+1:]
+	}
+
+	if ns == "" {
+		return fe.Field()
+	}
+
+	return ns
+}
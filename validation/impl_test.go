@@ -0,0 +1,102 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidationTestSuite struct {
+	suite.Suite
+}
+
+func TestValidationTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidationTestSuite))
+}
+
+type (
+	address struct {
+		City string `json:"city" validate:"required"`
+	}
+
+	customer struct {
+		Name     string   `json:"name" validate:"required,min=3"`
+		Document string   `json:"document" validate:"required,cpf"`
+		Company  string   `json:"company" validate:"omitempty,cnpj"`
+		Phone    string   `json:"phone" validate:"omitempty,phone"`
+		Currency string   `json:"currency" validate:"required,currency"`
+		ID       string   `json:"id" validate:"omitempty,ulid"`
+		Address  *address `json:"address" validate:"required"`
+	}
+)
+
+func (s *ValidationTestSuite) TestIsCPF() {
+	s.True(IsCPF("529.982.247-25"))
+	s.True(IsCPF("52998224725"))
+	s.False(IsCPF("529.982.247-24"))
+	s.False(IsCPF("111.111.111-11"))
+	s.False(IsCPF("5299822472"))
+	s.False(IsCPF("52998224725a"))
+}
+
+func (s *ValidationTestSuite) TestIsCNPJ() {
+	s.True(IsCNPJ("11.222.333/0001-81"))
+	s.True(IsCNPJ("11222333000181"))
+	s.False(IsCNPJ("11.222.333/0001-80"))
+	s.False(IsCNPJ("00.000.000/0000-00"))
+}
+
+func (s *ValidationTestSuite) TestStruct() {
+	valid := &customer{
+		Name:     "gokit",
+		Document: "529.982.247-25",
+		Company:  "11.222.333/0001-81",
+		Phone:    "+5511999999999",
+		Currency: "BRL",
+		ID:       "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		Address:  &address{City: "São Paulo"},
+	}
+	s.NoError(Struct(valid))
+
+	err := Struct(&customer{
+		Name:     "go",
+		Document: "529.982.247-24",
+		Phone:    "11999999999",
+		Currency: "XXY",
+		ID:       "invalid",
+		Address:  &address{},
+	})
+
+	s.ErrorIs(err, ErrorValidation)
+
+	vErr := &ValidationError{}
+	s.True(errors.As(err, &vErr))
+	s.Equal([]*FieldError{
+		{Field: "name", Tag: "min", Param: "3", Message: "must be at least 3"},
+		{Field: "document", Tag: CPF_TAG, Message: "must be a valid CPF"},
+		{Field: "phone", Tag: PHONE_TAG, Message: "must be a valid E.164 phone number"},
+		{Field: "currency", Tag: CURRENCY_TAG, Message: "must be a valid ISO 4217 currency code"},
+		{Field: "id", Tag: ULID_TAG, Message: "must be a valid ULID"},
+		{Field: "address.city", Tag: "required", Message: "is required"},
+	}, vErr.Fields)
+	s.Contains(err.Error(), "name must be at least 3")
+}
+
+func (s *ValidationTestSuite) TestVar() {
+	s.NoError(Var("6ba7b810-9dad-11d1-80b4-00c04fd430c8", UUID_TAG))
+	s.ErrorIs(Var("invalid", UUID_TAG), ErrorValidation)
+	s.Error(Struct(nil))
+	s.NotErrorIs(Struct(nil), ErrorValidation)
+}
+
+func (s *ValidationTestSuite) TestRegisterMessage() {
+	RegisterMessage("required", "é obrigatório")
+	defer RegisterMessage("required", "is required")
+
+	err := Var("", "required").(*ValidationError)
+	s.Equal("é obrigatório", err.Fields[0].Message)
+
+	err = Var("abc", "alpha,contains=1").(*ValidationError)
+	s.Equal("failed on the 'contains' rule", err.Fields[0].Message)
+}
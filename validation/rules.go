@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var ulidRegex = regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`)
+
+// registerRules the phone and currency tags are aliases to the validator e164 and iso4217 rules
+func registerRules(v *validator.Validate) {
+	_ = v.RegisterValidation(CPF_TAG, func(fl validator.FieldLevel) bool {
+		return IsCPF(fl.Field().String())
+	})
+
+	_ = v.RegisterValidation(CNPJ_TAG, func(fl validator.FieldLevel) bool {
+		return IsCNPJ(fl.Field().String())
+	})
+
+	_ = v.RegisterValidation(ULID_TAG, func(fl validator.FieldLevel) bool {
+		return ulidRegex.MatchString(fl.Field().String())
+	})
+
+	v.RegisterAlias(PHONE_TAG, "e164")
+	v.RegisterAlias(CURRENCY_TAG, "iso4217")
+}
+
+// IsCPF verify the CPF check digits, the dots and dash are optional
+func IsCPF(value string) bool {
+	d := digits(value)
+	if len(d) != 11 || repeated(d) {
+		return false
+	}
+
+	return checkDigit(d[:9], 10) == d[9] && checkDigit(d[:10], 11) == d[10]
+}
+
+// IsCNPJ verify the CNPJ check digits, the dots, slash and dash are optional
+func IsCNPJ(value string) bool {
+	d := digits(value)
+	if len(d) != 14 || repeated(d) {
+		return false
+	}
+
+	return cnpjCheckDigit(d[:12]) == d[12] && cnpjCheckDigit(d[:13]) == d[13]
+}
+
+// checkDigit the CPF weights start in weight and decrease to 2
+func checkDigit(d []int, weight int) int {
+	sum := 0
+	for i, n := range d {
+		sum += n * (weight - i)
+	}
+
+	r := sum * 10 % 11
+	if r == 10 {
+		return 0
+	}
+
+	return r
+}
+
+// cnpjCheckDigit the CNPJ weights go from 2 to 9 starting at the rightmost digit
+func cnpjCheckDigit(d []int) int {
+	sum := 0
+	for i := range d {
+		sum += d[len(d)-1-i] * (2 + i%8)
+	}
+
+	if r := sum % 11; r >= 2 {
+		return 11 - r
+	}
+
+	return 0
+}
+
+// digits returns nil when the value has other characters than the digits and the format separators
+func digits(value string) []int {
+	d := make([]int, 0, len(value))
+	for _, c := range value {
+		switch {
+		case c >= '0' && c <= '9':
+			d = append(d, int(c-'0'))
+		case strings.ContainsRune(".-/ ", c):
+		default:
+			return nil
+		}
+	}
+
+	return d
+}
+
+func repeated(d []int) bool {
+	for _, n := range d[1:] {
+		if n != d[0] {
+			return false
+		}
+	}
+
+	return true
+}
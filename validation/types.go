@@ -0,0 +1,24 @@
+package validation
+
+import "sync"
+
+type (
+	// FieldError the field-level validation failure
+	FieldError struct {
+		// Field the json path of the field, e.g: address.city
+		Field   string `json:"field"`
+		Tag     string `json:"tag"`
+		Param   string `json:"param,omitempty"`
+		Message string `json:"message"`
+	}
+
+	// ValidationError the failures of the validated value, errors.Is(err, ErrorValidation) is true
+	ValidationError struct {
+		Fields []*FieldError `json:"fields"`
+	}
+
+	messageRegistry struct {
+		mu       sync.RWMutex
+		messages map[string]string
+	}
+)
@@ -0,0 +1,7 @@
+package version
+
+const (
+	DefaultVersion = "dev"
+
+	JsonContentType = "application/json"
+)
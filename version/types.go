@@ -0,0 +1,14 @@
+package version
+
+type (
+	// Info the build information of the binary, stamped with -ldflags or read from the vcs settings of the go build
+	Info struct {
+		Version   string `json:"version"`
+		Commit    string `json:"commit,omitempty"`
+		BuildDate string `json:"buildDate,omitempty"`
+		// Modified the binary was built with uncommitted changes
+		Modified  bool   `json:"modified,omitempty"`
+		GoVersion string `json:"goVersion"`
+		Platform  string `json:"platform"`
+	}
+)
@@ -0,0 +1,112 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/debug"
+	"sync"
+)
+
+// the build variables, stamped with -ldflags, e.g:
+//
+//	go build -ldflags "-X github.com/ralvescosta/gokit/version.Version=v1.2.3 \
+//		-X github.com/ralvescosta/gokit/version.Commit=$(git rev-parse HEAD) \
+//		-X github.com/ralvescosta/gokit/version.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = ""
+	Commit    = ""
+	BuildDate = ""
+)
+
+var (
+	info     *Info
+	infoOnce sync.Once
+
+	readBuildInfo = debug.ReadBuildInfo
+)
+
+// Get the build information, the values not stamped are taken from the vcs settings and the module version embedded
+// by the go build, so BuildDate defaults to the commit time and Version to DefaultVersion
+func Get() *Info {
+	infoOnce.Do(func() {
+		info = newInfo()
+	})
+
+	copied := *info
+	return &copied
+}
+
+// Handler write the build information as JSON, e.g: router.Get("/version", version.Handler)
+func Handler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", JsonContentType)
+	json.NewEncoder(w).Encode(Get())
+}
+
+// String e.g: v1.2.3 (4f2a9c1, 2022-07-21T10:00:00Z, go1.18)
+func (i *Info) String() string {
+	details := ""
+	for _, detail := range []string{i.shortCommit(), i.BuildDate, i.GoVersion} {
+		if detail == "" {
+			continue
+		}
+
+		if details != "" {
+			details += ", "
+		}
+		details += detail
+	}
+
+	return i.Version + " (" + details + ")"
+}
+
+func (i *Info) shortCommit() string {
+	commit := i.Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+
+	if commit != "" && i.Modified {
+		commit += "-dirty"
+	}
+
+	return commit
+}
+
+func newInfo() *Info {
+	i := &Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if bi, ok := readBuildInfo(); ok {
+		// the module version is (devel) when the binary is built inside its own module
+		if i.Version == "" && bi.Main.Version != "(devel)" {
+			i.Version = bi.Main.Version
+		}
+
+		for _, setting := range bi.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				if i.Commit == "" {
+					i.Commit = setting.Value
+				}
+			case "vcs.time":
+				if i.BuildDate == "" {
+					i.BuildDate = setting.Value
+				}
+			case "vcs.modified":
+				i.Modified = setting.Value == "true"
+			}
+		}
+	}
+
+	if i.Version == "" {
+		i.Version = DefaultVersion
+	}
+
+	return i
+}
@@ -0,0 +1,91 @@
+package version
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"runtime/debug"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type VersionTestSuite struct {
+	suite.Suite
+}
+
+func TestVersionTestSuite(t *testing.T) {
+	suite.Run(t, new(VersionTestSuite))
+}
+
+func (s *VersionTestSuite) SetupTest() {
+	infoOnce = sync.Once{}
+	Version, Commit, BuildDate = "", "", ""
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			Main: debug.Module{Version: "(devel)"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs.revision", Value: "4f2a9c1b8e7d6f5a4b3c2d1e0f9a8b7c6d5e4f3a"},
+				{Key: "vcs.time", Value: "2022-07-21T10:00:00Z"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+}
+
+func (s *VersionTestSuite) TearDownTest() {
+	infoOnce = sync.Once{}
+	readBuildInfo = debug.ReadBuildInfo
+}
+
+func (s *VersionTestSuite) TestStamped() {
+	Version, Commit, BuildDate = "v1.2.3", "0123456789abcdef", "2022-07-22T08:00:00Z"
+
+	info := Get()
+
+	s.Equal("v1.2.3", info.Version)
+	s.Equal("0123456789abcdef", info.Commit)
+	s.Equal("2022-07-22T08:00:00Z", info.BuildDate)
+	s.True(info.Modified)
+	s.Equal(runtime.Version(), info.GoVersion)
+	s.Equal(runtime.GOOS+"/"+runtime.GOARCH, info.Platform)
+	s.Equal("v1.2.3 (0123456-dirty, 2022-07-22T08:00:00Z, "+runtime.Version()+")", info.String())
+}
+
+func (s *VersionTestSuite) TestBuildInfoFallback() {
+	info := Get()
+
+	s.Equal(DefaultVersion, info.Version)
+	s.Equal("4f2a9c1b8e7d6f5a4b3c2d1e0f9a8b7c6d5e4f3a", info.Commit)
+	s.Equal("2022-07-21T10:00:00Z", info.BuildDate)
+
+	infoOnce = sync.Once{}
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{Main: debug.Module{Version: "v0.4.0"}}, true
+	}
+
+	info = Get()
+	s.Equal("v0.4.0", info.Version)
+	s.Empty(info.Commit)
+	s.Equal("v0.4.0 ("+runtime.Version()+")", info.String())
+}
+
+func (s *VersionTestSuite) TestGetReturnsCopy() {
+	Get().Version = "changed"
+
+	s.Equal(DefaultVersion, Get().Version)
+}
+
+func (s *VersionTestSuite) TestHandler() {
+	Version = "v1.2.3"
+
+	rec := httptest.NewRecorder()
+	Handler(rec, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	info := &Info{}
+	s.Equal(JsonContentType, rec.Header().Get("Content-Type"))
+	s.NoError(json.Unmarshal(rec.Body.Bytes(), info))
+	s.Equal(Get(), info)
+}
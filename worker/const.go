@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	PENDING_STATE   TaskState = "pending"
+	SCHEDULED_STATE TaskState = "scheduled"
+	RUNNING_STATE   TaskState = "running"
+	RETRYING_STATE  TaskState = "retrying"
+	COMPLETED_STATE TaskState = "completed"
+	FAILED_STATE    TaskState = "failed"
+
+	DefaultConcurrency  = 1
+	DefaultMaxRetries   = 3
+	DefaultTaskTimeout  = 5 * time.Minute
+	DefaultBackoffBase  = 1 * time.Second
+	DefaultBackoffLimit = 10 * time.Minute
+
+	DefaultRedisPrefix    = "gokit:worker:"
+	DefaultRedisStatusTTL = 7 * 24 * time.Hour
+	DefaultPollInterval   = 500 * time.Millisecond
+	// DefaultVisibilityTimeout the time the redis broker waits the consumer before delivering the task again
+	DefaultVisibilityTimeout = DefaultTaskTimeout + time.Minute
+
+	TaskMessageType = "gokit.worker.task"
+
+	// PoolName the name of the consumers pool metrics, see pool.New
+	PoolName = "worker"
+)
+
+var (
+	ErrorTaskType        = errors.New("task type is required")
+	ErrorHandlerNotFound = errors.New("there is no handler registered to the task type")
+	ErrorTaskNotFound    = errors.New("task not found")
+	ErrorBrokerRequired  = errors.New("worker broker is required")
+	ErrorBrokerClosed    = errors.New("worker broker connection closed")
+)
+
+func LogMessage(msg string) string {
+	return "[gokit::worker] " + msg
+}
+
+// AllStates all the task states, useful to build dashboards
+func AllStates() []TaskState {
+	return []TaskState{PENDING_STATE, SCHEDULED_STATE, RUNNING_STATE, RETRYING_STATE, COMPLETED_STATE, FAILED_STATE}
+}
@@ -0,0 +1,267 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	gokitErrors "github.com/ralvescosta/gokit/errors"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/pool"
+)
+
+// New create a worker builder, the broker is used to enqueue and consume the tasks
+func New(logger logging.ILogger, broker Broker) WorkerBuilder {
+	return &Worker{
+		logger:      logger,
+		broker:      broker,
+		store:       NewMemoryStatusStore(),
+		handlers:    map[string]TaskHandler{},
+		callbacks:   []CompletionCallback{},
+		concurrency: DefaultConcurrency,
+		backoff:     ExponentialBackoff(DefaultBackoffBase, DefaultBackoffLimit),
+		timeNow:     time.Now,
+	}
+}
+
+func (w *Worker) Handle(taskType string, handler TaskHandler) WorkerBuilder {
+	w.handlers[taskType] = handler
+	return w
+}
+
+func (w *Worker) OnComplete(callback CompletionCallback) WorkerBuilder {
+	w.callbacks = append(w.callbacks, callback)
+	return w
+}
+
+func (w *Worker) Store(store StatusStore) WorkerBuilder {
+	w.store = store
+	return w
+}
+
+func (w *Worker) Concurrency(n int) WorkerBuilder {
+	if n > 0 {
+		w.concurrency = n
+	}
+	return w
+}
+
+func (w *Worker) Backoff(backoff BackoffFunc) WorkerBuilder {
+	w.backoff = backoff
+	return w
+}
+
+func (w *Worker) Build() (IWorker, error) {
+	if w.broker == nil {
+		return nil, ErrorBrokerRequired
+	}
+
+	return w, nil
+}
+
+func (w *Worker) Enqueue(ctx context.Context, taskType string, payload any, opts *EnqueueOpts) (string, error) {
+	if taskType == "" {
+		return "", ErrorTaskType
+	}
+
+	if opts == nil {
+		opts = &EnqueueOpts{}
+	}
+
+	byt, err := json.Marshal(payload)
+	if err != nil {
+		w.logger.Error(LogMessage("enqueue marshal"), logging.ErrorField(err))
+		return "", err
+	}
+
+	task := &Task{
+		ID:         opts.ID,
+		Type:       taskType,
+		Payload:    byt,
+		MaxRetries: opts.MaxRetries,
+		Timeout:    opts.Timeout,
+		EnqueuedAt: w.timeNow(),
+	}
+
+	task.AvailableAt = task.EnqueuedAt.Add(opts.Delay)
+
+	if task.ID == "" {
+		task.ID = uuid.NewString()
+	}
+
+	if task.MaxRetries == 0 {
+		task.MaxRetries = DefaultMaxRetries
+	}
+
+	if task.Timeout == 0 {
+		task.Timeout = DefaultTaskTimeout
+	}
+
+	state := PENDING_STATE
+	if opts.Delay > 0 {
+		state = SCHEDULED_STATE
+	}
+
+	if err := w.store.Save(ctx, w.newStatus(task, state, nil)); err != nil {
+		w.logger.Error(LogMessage("failure to save the task status"), logging.ErrorField(err))
+		return "", err
+	}
+
+	if err := w.broker.Publish(ctx, task, opts.Delay); err != nil {
+		w.logger.Error(LogMessage("failure to publish the task"), logging.ErrorField(err))
+		return "", err
+	}
+
+	return task.ID, nil
+}
+
+func (w *Worker) Status(ctx context.Context, id string) (*TaskStatus, error) {
+	return w.store.Get(ctx, id)
+}
+
+func (w *Worker) Run(ctx context.Context) error {
+	errs := make(chan error, w.concurrency)
+	wg := sync.WaitGroup{}
+
+	w.pool = pool.New(PoolName, &pool.Opts{Kind: pool.WORKER_KIND, Workers: w.concurrency, Now: w.timeNow})
+	defer w.pool.Close()
+
+	for i := 0; i < w.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- w.broker.Consume(ctx, w.process)
+		}()
+	}
+
+	w.logger.Info(LogMessage(fmt.Sprintf("worker started with %d consumers", w.concurrency)))
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil && err != context.Canceled {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (w *Worker) process(ctx context.Context, task *Task) error {
+	// the tasks wait in the broker, so the wait is measured since they were available to the consumers
+	availableAt := task.AvailableAt
+	if availableAt.IsZero() {
+		availableAt = task.EnqueuedAt
+	}
+
+	done := w.pool.Start(pool.TicketAt(availableAt))
+	defer done()
+
+	handler, ok := w.handlers[task.Type]
+	if !ok {
+		w.logger.Error(LogMessage("task without handler"), logging.MessageField("type", task.Type))
+		w.complete(ctx, w.newStatus(task, FAILED_STATE, ErrorHandlerNotFound))
+		return nil
+	}
+
+	if err := w.store.Save(ctx, w.newStatus(task, RUNNING_STATE, nil)); err != nil {
+		w.logger.Warn(LogMessage("failure to save the task status"), logging.ErrorField(err))
+	}
+
+	err := w.execute(ctx, task, handler)
+	if err == nil {
+		w.complete(ctx, w.newStatus(task, COMPLETED_STATE, nil))
+		return nil
+	}
+
+	w.logger.Error(LogMessage("task failure"), logging.MessageField("id", task.ID), logging.ErrorField(err))
+
+	if task.Attempt >= task.MaxRetries || !shouldRetry(err) {
+		w.complete(ctx, w.newStatus(task, FAILED_STATE, err))
+		return nil
+	}
+
+	task.Attempt++
+	delay := w.backoff(task.Attempt)
+	task.AvailableAt = w.timeNow().Add(delay)
+
+	if err := w.store.Save(ctx, w.newStatus(task, RETRYING_STATE, err)); err != nil {
+		w.logger.Warn(LogMessage("failure to save the task status"), logging.ErrorField(err))
+	}
+
+	return w.broker.Publish(ctx, task, delay)
+}
+
+func (w *Worker) execute(ctx context.Context, task *Task, handler TaskHandler) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, task.Timeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panicked: %v", r)
+		}
+	}()
+
+	return handler(ctx, task)
+}
+
+func (w *Worker) complete(ctx context.Context, status *TaskStatus) {
+	if err := w.store.Save(ctx, status); err != nil {
+		w.logger.Warn(LogMessage("failure to save the task status"), logging.ErrorField(err))
+	}
+
+	for _, callback := range w.callbacks {
+		callback(ctx, status)
+	}
+}
+
+func (w *Worker) newStatus(task *Task, state TaskState, err error) *TaskStatus {
+	status := &TaskStatus{
+		ID:        task.ID,
+		Type:      task.Type,
+		State:     state,
+		Attempt:   task.Attempt,
+		CreatedAt: task.EnqueuedAt,
+		UpdatedAt: w.timeNow(),
+	}
+
+	if err != nil {
+		status.Error = err.Error()
+	}
+
+	return status
+}
+
+// shouldRetry plain errors are retried, AppErrors only when they are retryable
+func shouldRetry(err error) bool {
+	var appErr *gokitErrors.AppError
+	if gokitErrors.As(err, &appErr) {
+		return gokitErrors.IsRetryable(err)
+	}
+
+	return true
+}
+
+// Decode unmarshal the task payload into v
+func (t *Task) Decode(v any) error {
+	return json.Unmarshal(t.Payload, v)
+}
+
+// ExponentialBackoff doubles the delay each attempt, limited by max
+func ExponentialBackoff(base, max time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		delay := base
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+
+		if delay > max {
+			return max
+		}
+
+		return delay
+	}
+}
@@ -0,0 +1,215 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gokitErrors "github.com/ralvescosta/gokit/errors"
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/pool"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WorkerTestSuite struct {
+	suite.Suite
+}
+
+func TestWorkerTestSuite(t *testing.T) {
+	suite.Run(t, new(WorkerTestSuite))
+}
+
+func (s *WorkerTestSuite) run(w IWorker, done chan *TaskStatus) *TaskStatus {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(stopped)
+	}()
+
+	// Run closes its pool when it returns, so the next tests do not observe it
+	defer func() {
+		cancel()
+		<-stopped
+	}()
+
+	select {
+	case status := <-done:
+		return status
+	case <-time.After(time.Second):
+		s.FailNow("task not completed")
+		return nil
+	}
+}
+
+func (s *WorkerTestSuite) TestEnqueueAndComplete() {
+	done := make(chan *TaskStatus, 1)
+
+	w, err := New(logging.NewMockLogger(), NewMemoryBroker(10)).
+		Handle("email", func(ctx context.Context, task *Task) error {
+			payload := map[string]string{}
+			s.NoError(task.Decode(&payload))
+			s.Equal("value", payload["key"])
+			return nil
+		}).
+		OnComplete(func(ctx context.Context, status *TaskStatus) { done <- status }).
+		Build()
+	s.NoError(err)
+
+	id, err := w.Enqueue(context.Background(), "email", map[string]string{"key": "value"}, &EnqueueOpts{Delay: 10 * time.Millisecond})
+	s.NoError(err)
+
+	status, _ := w.Status(context.Background(), id)
+	s.Equal(SCHEDULED_STATE, status.State)
+
+	status = s.run(w, done)
+	s.Equal(id, status.ID)
+	s.Equal(COMPLETED_STATE, status.State)
+}
+
+func (s *WorkerTestSuite) TestRetries() {
+	done := make(chan *TaskStatus, 1)
+	calls := 0
+
+	w, _ := New(logging.NewMockLogger(), NewMemoryBroker(10)).
+		Backoff(func(attempt int) time.Duration { return time.Millisecond }).
+		Handle("job", func(ctx context.Context, task *Task) error {
+			calls++
+			return errors.New("some error")
+		}).
+		OnComplete(func(ctx context.Context, status *TaskStatus) { done <- status }).
+		Build()
+
+	w.Enqueue(context.Background(), "job", nil, &EnqueueOpts{MaxRetries: 2})
+
+	status := s.run(w, done)
+	s.Equal(FAILED_STATE, status.State)
+	s.Equal(2, status.Attempt)
+	s.Equal(3, calls)
+}
+
+func (s *WorkerTestSuite) TestNotRetryableErr() {
+	done := make(chan *TaskStatus, 1)
+	calls := 0
+
+	w, _ := New(logging.NewMockLogger(), NewMemoryBroker(10)).
+		Handle("job", func(ctx context.Context, task *Task) error {
+			calls++
+			return gokitErrors.NotFound("not found")
+		}).
+		OnComplete(func(ctx context.Context, status *TaskStatus) { done <- status }).
+		Build()
+
+	w.Enqueue(context.Background(), "job", nil, nil)
+
+	status := s.run(w, done)
+	s.Equal(FAILED_STATE, status.State)
+	s.Equal(1, calls)
+}
+
+func (s *WorkerTestSuite) TestPoolStats() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan *TaskStatus, 1)
+
+	w, _ := New(logging.NewMockLogger(), NewMemoryBroker(10)).
+		Concurrency(2).
+		Handle("job", func(ctx context.Context, task *Task) error {
+			close(started)
+			<-release
+			return nil
+		}).
+		OnComplete(func(ctx context.Context, status *TaskStatus) { done <- status }).
+		Build()
+
+	w.Enqueue(context.Background(), "job", nil, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		w.Run(ctx)
+		close(stopped)
+	}()
+
+	<-started
+	s.Equal([]pool.Stats{{Name: PoolName, Kind: pool.WORKER_KIND, Workers: 2, Busy: 1}}, workerPools())
+
+	close(release)
+	<-done
+	cancel()
+	<-stopped
+
+	s.Empty(workerPools())
+}
+
+// workerPools the pool registry is global, so the pools of the other packages are ignored
+func workerPools() []pool.Stats {
+	stats := []pool.Stats{}
+	for _, p := range pool.All() {
+		if p.Name == PoolName && p.Kind == pool.WORKER_KIND {
+			stats = append(stats, p)
+		}
+	}
+
+	return stats
+}
+
+func (s *WorkerTestSuite) TestMemoryBrokerRedeliveryBufferFull() {
+	broker := NewMemoryBroker(1)
+	s.NoError(broker.Publish(context.Background(), &Task{ID: "first"}, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	delivered := make(chan string, 3)
+	calls := 0
+	go broker.Consume(ctx, func(ctx context.Context, task *Task) error {
+		delivered <- task.ID
+		if calls++; calls == 1 {
+			// fills the buffer before the redelivery
+			s.NoError(broker.Publish(ctx, &Task{ID: "second"}, 0))
+			return errors.New("some error")
+		}
+		return nil
+	})
+
+	ids := []string{}
+	for len(ids) < 3 {
+		select {
+		case id := <-delivered:
+			ids = append(ids, id)
+		case <-ctx.Done():
+			s.Fail("consumer blocked by the redelivery")
+			return
+		}
+	}
+
+	s.Equal([]string{"first", "second", "first"}, ids)
+}
+
+func (s *WorkerTestSuite) TestEnqueueErr() {
+	broker := NewMockBroker()
+	broker.On("Publish", mock.Anything, mock.Anything, time.Duration(0)).Return(errors.New("some error"))
+
+	w, _ := New(logging.NewMockLogger(), broker).Build()
+
+	_, err := w.Enqueue(context.Background(), "", nil, nil)
+	s.ErrorIs(err, ErrorTaskType)
+
+	_, err = w.Enqueue(context.Background(), "job", nil, nil)
+	s.Error(err)
+
+	_, err = New(logging.NewMockLogger(), nil).Build()
+	s.ErrorIs(err, ErrorBrokerRequired)
+}
+
+func (s *WorkerTestSuite) TestExponentialBackoff() {
+	backoff := ExponentialBackoff(time.Second, 5*time.Second)
+
+	s.Equal(time.Second, backoff(1))
+	s.Equal(2*time.Second, backoff(2))
+	s.Equal(4*time.Second, backoff(3))
+	s.Equal(5*time.Second, backoff(4))
+}
@@ -0,0 +1,118 @@
+package worker
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// NewMemoryBroker in process broker, useful for tests and local development
+func NewMemoryBroker(buffer int) Broker {
+	return &memoryBroker{
+		tasks:   make(chan *Task, buffer),
+		pending: map[string]*time.Timer{},
+	}
+}
+
+func (b *memoryBroker) Publish(ctx context.Context, task *Task, delay time.Duration) error {
+	cp := *task
+
+	if delay <= 0 {
+		select {
+		case b.tasks <- &cp:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.pending[cp.ID] = time.AfterFunc(delay, func() {
+		b.mu.Lock()
+		delete(b.pending, cp.ID)
+		b.mu.Unlock()
+
+		b.tasks <- &cp
+	})
+
+	return nil
+}
+
+func (b *memoryBroker) Consume(ctx context.Context, deliver DeliverFunc) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case task := <-b.tasks:
+			// the delayed publish does not block the consumer when the buffer is full
+			if err := deliver(ctx, task); err != nil {
+				b.Publish(ctx, task, DefaultPollInterval)
+			}
+		}
+	}
+}
+
+// NewMemoryStatusStore in memory status store, the statuses are lost when the process stops
+func NewMemoryStatusStore() StatusStore {
+	return &memoryStore{statuses: map[string]*TaskStatus{}}
+}
+
+func (s *memoryStore) Save(_ context.Context, status *TaskStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cp := *status
+	s.statuses[status.ID] = &cp
+
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, id string) (*TaskStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	status, ok := s.statuses[id]
+	if !ok {
+		return nil, ErrorTaskNotFound
+	}
+
+	cp := *status
+	return &cp, nil
+}
+
+func (s *memoryStore) List(_ context.Context, state TaskState, limit int) ([]*TaskStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := []*TaskStatus{}
+	for _, status := range s.statuses {
+		if state != "" && status.State != state {
+			continue
+		}
+
+		cp := *status
+		result = append(result, &cp)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].UpdatedAt.After(result[j].UpdatedAt) })
+
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+
+	return result, nil
+}
+
+func (s *memoryStore) Counts(_ context.Context) (map[TaskState]int64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	counts := map[TaskState]int64{}
+	for _, status := range s.statuses {
+		counts[status.State]++
+	}
+
+	return counts, nil
+}
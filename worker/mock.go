@@ -0,0 +1,92 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type (
+	MockWorker struct {
+		mock.Mock
+	}
+
+	MockBroker struct {
+		mock.Mock
+	}
+
+	MockStatusStore struct {
+		mock.Mock
+	}
+)
+
+func (m *MockWorker) Enqueue(ctx context.Context, taskType string, payload any, opts *EnqueueOpts) (string, error) {
+	args := m.Called(ctx, taskType, payload, opts)
+
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockWorker) Status(ctx context.Context, id string) (*TaskStatus, error) {
+	args := m.Called(ctx, id)
+
+	status, _ := args.Get(0).(*TaskStatus)
+	return status, args.Error(1)
+}
+
+func (m *MockWorker) Run(ctx context.Context) error {
+	args := m.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (m *MockBroker) Publish(ctx context.Context, task *Task, delay time.Duration) error {
+	args := m.Called(ctx, task, delay)
+
+	return args.Error(0)
+}
+
+func (m *MockBroker) Consume(ctx context.Context, deliver DeliverFunc) error {
+	args := m.Called(ctx, deliver)
+
+	return args.Error(0)
+}
+
+func (m *MockStatusStore) Save(ctx context.Context, status *TaskStatus) error {
+	args := m.Called(ctx, status)
+
+	return args.Error(0)
+}
+
+func (m *MockStatusStore) Get(ctx context.Context, id string) (*TaskStatus, error) {
+	args := m.Called(ctx, id)
+
+	status, _ := args.Get(0).(*TaskStatus)
+	return status, args.Error(1)
+}
+
+func (m *MockStatusStore) List(ctx context.Context, state TaskState, limit int) ([]*TaskStatus, error) {
+	args := m.Called(ctx, state, limit)
+
+	statuses, _ := args.Get(0).([]*TaskStatus)
+	return statuses, args.Error(1)
+}
+
+func (m *MockStatusStore) Counts(ctx context.Context) (map[TaskState]int64, error) {
+	args := m.Called(ctx)
+
+	counts, _ := args.Get(0).(map[TaskState]int64)
+	return counts, args.Error(1)
+}
+
+func NewMockWorker() *MockWorker {
+	return new(MockWorker)
+}
+
+func NewMockBroker() *MockBroker {
+	return new(MockBroker)
+}
+
+func NewMockStatusStore() *MockStatusStore {
+	return new(MockStatusStore)
+}
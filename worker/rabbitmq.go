@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/messaging/rabbitmq"
+	"github.com/streadway/amqp"
+)
+
+type rabbitMQBroker struct {
+	logger   logging.ILogger
+	ch       rabbitmq.AMQPChannel
+	exchange string
+	queue    string
+}
+
+// NewRabbitMQBroker declare a delayed exchange and the tasks queue, the delay is handled by the rabbitmq_delayed_message_exchange plugin
+func NewRabbitMQBroker(logger logging.ILogger, ch rabbitmq.AMQPChannel, exchange, queue string) (Broker, error) {
+	err := ch.ExchangeDeclare(exchange, string(rabbitmq.DELAY_EXCHANGE), true, false, false, false, amqp.Table{
+		"x-delayed-type": string(rabbitmq.DIRECT_EXCHANGE),
+	})
+	if err != nil {
+		logger.Error(LogMessage("failure to declare the tasks exchange"), logging.ErrorField(err))
+		return nil, err
+	}
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+		logger.Error(LogMessage("failure to declare the tasks queue"), logging.ErrorField(err))
+		return nil, err
+	}
+
+	if err := ch.QueueBind(queue, queue, exchange, false, nil); err != nil {
+		logger.Error(LogMessage("failure to bind the tasks queue"), logging.ErrorField(err))
+		return nil, err
+	}
+
+	return &rabbitMQBroker{logger, ch, exchange, queue}, nil
+}
+
+func (b *rabbitMQBroker) Publish(_ context.Context, task *Task, delay time.Duration) error {
+	byt, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	return b.ch.Publish(b.exchange, b.queue, false, false, amqp.Publishing{
+		Headers: amqp.Table{
+			rabbitmq.AMQPHeaderDelay: delay.Milliseconds(),
+		},
+		Type:        TaskMessageType,
+		ContentType: rabbitmq.JsonContentType,
+		MessageId:   task.ID,
+		Body:        byt,
+	})
+}
+
+func (b *rabbitMQBroker) Consume(ctx context.Context, deliver DeliverFunc) error {
+	deliveries, err := b.ch.Consume(b.queue, "", false, false, false, false, nil)
+	if err != nil {
+		b.logger.Error(LogMessage("failure to consume the tasks queue"), logging.ErrorField(err))
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case received, ok := <-deliveries:
+			if !ok {
+				return ErrorBrokerClosed
+			}
+
+			task := &Task{}
+			if err := json.Unmarshal(received.Body, task); err != nil {
+				b.logger.Error(LogMessage("unformatted task, discarding"), logging.MessageIdField(received.MessageId))
+				received.Nack(false, false)
+				continue
+			}
+
+			if err := deliver(ctx, task); err != nil {
+				received.Nack(false, true)
+				continue
+			}
+
+			received.Ack(false)
+		}
+	}
+}
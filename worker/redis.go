@@ -0,0 +1,230 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// claimScript returns the first due task moving it to the processing set leased until ARGV[2], the tasks with the
+	// lease expired are moved back to the queue before, so the tasks of the crashed consumers are delivered again
+	claimScript = redis.NewScript(`
+local expired = redis.call("ZRANGEBYSCORE", KEYS[2], "-inf", ARGV[1])
+for _, member in ipairs(expired) do
+	redis.call("ZREM", KEYS[2], member)
+	redis.call("ZADD", KEYS[1], ARGV[1], member)
+end
+
+local members = redis.call("ZRANGEBYSCORE", KEYS[1], "-inf", ARGV[1], "LIMIT", 0, 1)
+if #members == 0 then
+	return false
+end
+
+redis.call("ZREM", KEYS[1], members[1])
+redis.call("ZADD", KEYS[2], ARGV[2], members[1])
+
+return members[1]
+`)
+
+	// requeueScript move the task from the processing set back to the queue scored by ARGV[2]
+	requeueScript = redis.NewScript(`
+if redis.call("ZREM", KEYS[2], ARGV[1]) == 1 then
+	redis.call("ZADD", KEYS[1], ARGV[2], ARGV[1])
+end
+
+return 1
+`)
+)
+
+type (
+	redisBroker struct {
+		client       redis.UniversalClient
+		key          string
+		processing   string
+		pollInterval time.Duration
+		visibility   time.Duration
+	}
+
+	redisStore struct {
+		client redis.UniversalClient
+		prefix string
+		ttl    time.Duration
+	}
+)
+
+// NewRedisBroker tasks are stored in a sorted set scored by the execution time, so delayed tasks are supported without plugins
+//
+// The consumed tasks stay in a processing set until they are delivered, when the consumer does not finish the task in
+// the DefaultVisibilityTimeout the task is delivered again, so the timeout must be greater than the tasks Timeout
+func NewRedisBroker(client redis.UniversalClient, queue string) Broker {
+	// the hash tag keeps the queue and the processing set in the same redis cluster slot
+	key := DefaultRedisPrefix + "queue:{" + queue + "}"
+
+	return &redisBroker{
+		client:       client,
+		key:          key,
+		processing:   key + ":processing",
+		pollInterval: DefaultPollInterval,
+		visibility:   DefaultVisibilityTimeout,
+	}
+}
+
+func (b *redisBroker) Publish(ctx context.Context, task *Task, delay time.Duration) error {
+	byt, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+
+	return b.client.ZAdd(ctx, b.key, redis.Z{
+		Score:  float64(time.Now().Add(delay).UnixMilli()),
+		Member: byt,
+	}).Err()
+}
+
+func (b *redisBroker) Consume(ctx context.Context, deliver DeliverFunc) error {
+	for {
+		now := time.Now()
+		member, err := claimScript.Run(ctx, b.client, []string{b.key, b.processing}, now.UnixMilli(), now.Add(b.visibility).UnixMilli()).Text()
+		if err != nil && err != redis.Nil && ctx.Err() == nil {
+			return err
+		}
+
+		if err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(b.pollInterval):
+				continue
+			}
+		}
+
+		task := &Task{}
+		if err := json.Unmarshal([]byte(member), task); err != nil {
+			// the malformed tasks would never be delivered
+			b.client.ZRem(context.Background(), b.processing, member)
+			continue
+		}
+
+		// the ack and the requeue use a new ctx, so the delivered task is not leased again when the ctx is done
+		if err := deliver(ctx, task); err != nil {
+			score := time.Now().Add(b.pollInterval).UnixMilli()
+			if err := requeueScript.Run(context.Background(), b.client, []string{b.key, b.processing}, member, score).Err(); err != nil {
+				return fmt.Errorf("failure to requeue the task %s: %w", task.ID, err)
+			}
+			continue
+		}
+
+		if err := b.client.ZRem(context.Background(), b.processing, member).Err(); err != nil {
+			return fmt.Errorf("failure to ack the task %s: %w", task.ID, err)
+		}
+	}
+}
+
+// NewRedisStatusStore statuses are stored as JSON with ttl and indexed by state in sorted sets
+func NewRedisStatusStore(client redis.UniversalClient, ttl time.Duration) StatusStore {
+	if ttl == 0 {
+		ttl = DefaultRedisStatusTTL
+	}
+
+	return &redisStore{client, DefaultRedisPrefix, ttl}
+}
+
+func (s *redisStore) taskKey(id string) string {
+	return s.prefix + "task:" + id
+}
+
+func (s *redisStore) stateKey(state TaskState) string {
+	return s.prefix + "state:" + string(state)
+}
+
+func (s *redisStore) Save(ctx context.Context, status *TaskStatus) error {
+	byt, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, s.taskKey(status.ID), byt, s.ttl)
+
+		for _, state := range AllStates() {
+			if state != status.State {
+				pipe.ZRem(ctx, s.stateKey(state), status.ID)
+			}
+		}
+
+		pipe.ZAdd(ctx, s.stateKey(status.State), redis.Z{Score: float64(status.UpdatedAt.UnixMilli()), Member: status.ID})
+		return nil
+	})
+
+	return err
+}
+
+func (s *redisStore) Get(ctx context.Context, id string) (*TaskStatus, error) {
+	byt, err := s.client.Get(ctx, s.taskKey(id)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrorTaskNotFound
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TaskStatus{}
+	if err := json.Unmarshal(byt, status); err != nil {
+		return nil, err
+	}
+
+	return status, nil
+}
+
+func (s *redisStore) List(ctx context.Context, state TaskState, limit int) ([]*TaskStatus, error) {
+	ids, err := s.client.ZRevRange(ctx, s.stateKey(state), 0, int64(limit)-1).Result()
+	if err != nil || len(ids) == 0 {
+		return []*TaskStatus{}, err
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, s.taskKey(id))
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := []*TaskStatus{}
+	for _, v := range values {
+		str, ok := v.(string)
+		if !ok {
+			// status expired
+			continue
+		}
+
+		status := &TaskStatus{}
+		if err := json.Unmarshal([]byte(str), status); err == nil {
+			result = append(result, status)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *redisStore) Counts(ctx context.Context) (map[TaskState]int64, error) {
+	counts := map[TaskState]int64{}
+
+	for _, state := range AllStates() {
+		count, err := s.client.ZCard(ctx, s.stateKey(state)).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		counts[state] = count
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,140 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisTestSuite struct {
+	suite.Suite
+
+	server *miniredis.Miniredis
+	client *redis.Client
+}
+
+func TestRedisTestSuite(t *testing.T) {
+	suite.Run(t, new(RedisTestSuite))
+}
+
+func (s *RedisTestSuite) SetupTest() {
+	s.server = miniredis.RunT(s.T())
+	s.client = redis.NewClient(&redis.Options{Addr: s.server.Addr()})
+}
+
+func (s *RedisTestSuite) TestBroker() {
+	broker := NewRedisBroker(s.client, "tasks")
+	broker.(*redisBroker).pollInterval = time.Millisecond
+
+	s.NoError(broker.Publish(context.Background(), &Task{ID: "id", Type: "job"}, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	received := make(chan *Task, 1)
+	go broker.Consume(ctx, func(ctx context.Context, task *Task) error {
+		received <- task
+		return nil
+	})
+
+	select {
+	case task := <-received:
+		s.Equal("id", task.ID)
+	case <-ctx.Done():
+		s.Fail("task not received")
+	}
+}
+
+func (s *RedisTestSuite) TestBrokerRequeue() {
+	broker := NewRedisBroker(s.client, "tasks").(*redisBroker)
+	broker.pollInterval = time.Millisecond
+
+	s.NoError(broker.Publish(context.Background(), &Task{ID: "id", Type: "job"}, 0))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	attempts := make(chan *Task, 2)
+	calls := 0
+	go broker.Consume(ctx, func(ctx context.Context, task *Task) error {
+		attempts <- task
+		if calls++; calls == 1 {
+			return errors.New("some error")
+		}
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		select {
+		case task := <-attempts:
+			s.Equal("id", task.ID)
+		case <-ctx.Done():
+			s.Fail("task not delivered again")
+			return
+		}
+	}
+
+	s.Eventually(func() bool {
+		processing, _ := s.client.ZCard(context.Background(), broker.processing).Result()
+		queued, _ := s.client.ZCard(context.Background(), broker.key).Result()
+		return processing == 0 && queued == 0
+	}, time.Second, time.Millisecond)
+}
+
+func (s *RedisTestSuite) TestBrokerVisibilityTimeout() {
+	broker := NewRedisBroker(s.client, "tasks").(*redisBroker)
+	broker.pollInterval = time.Millisecond
+
+	// a task leased by a consumer which crashed
+	byt, _ := json.Marshal(&Task{ID: "id", Type: "job"})
+	s.NoError(s.client.ZAdd(context.Background(), broker.processing, redis.Z{
+		Score:  float64(time.Now().Add(-time.Second).UnixMilli()),
+		Member: byt,
+	}).Err())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	received := make(chan *Task, 1)
+	go broker.Consume(ctx, func(ctx context.Context, task *Task) error {
+		received <- task
+		return nil
+	})
+
+	select {
+	case task := <-received:
+		s.Equal("id", task.ID)
+	case <-ctx.Done():
+		s.Fail("expired task not delivered again")
+	}
+}
+
+func (s *RedisTestSuite) TestStatusStore() {
+	store := NewRedisStatusStore(s.client, 0)
+	ctx := context.Background()
+
+	s.NoError(store.Save(ctx, &TaskStatus{ID: "id", State: PENDING_STATE, UpdatedAt: time.Now()}))
+	s.NoError(store.Save(ctx, &TaskStatus{ID: "id", State: COMPLETED_STATE, UpdatedAt: time.Now()}))
+
+	status, err := store.Get(ctx, "id")
+	s.NoError(err)
+	s.Equal(COMPLETED_STATE, status.State)
+
+	list, err := store.List(ctx, COMPLETED_STATE, 10)
+	s.NoError(err)
+	s.Len(list, 1)
+
+	counts, err := store.Counts(ctx)
+	s.NoError(err)
+	s.Equal(int64(0), counts[PENDING_STATE])
+	s.Equal(int64(1), counts[COMPLETED_STATE])
+
+	_, err = store.Get(ctx, "unknown")
+	s.ErrorIs(err, ErrorTaskNotFound)
+}
@@ -0,0 +1,126 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/ralvescosta/gokit/logging"
+	"github.com/ralvescosta/gokit/pool"
+)
+
+type (
+	TaskState string
+
+	// Task the unit of work transported by the broker, AvailableAt is when it could be consumed, after the enqueue delay or
+	// the retry backoff
+	Task struct {
+		ID          string          `json:"id"`
+		Type        string          `json:"type"`
+		Payload     json.RawMessage `json:"payload"`
+		Attempt     int             `json:"attempt"`
+		MaxRetries  int             `json:"max_retries"`
+		Timeout     time.Duration   `json:"timeout"`
+		EnqueuedAt  time.Time       `json:"enqueued_at"`
+		AvailableAt time.Time       `json:"available_at,omitempty"`
+	}
+
+	// EnqueueOpts optional parameters used when a task is enqueued
+	EnqueueOpts struct {
+		// ID allow the caller to define the task id, a new uuid is used when empty
+		ID string
+		// Delay postpone the first execution
+		Delay time.Duration
+		// MaxRetries how many times the task is retried after a failure, negative values disable the retries
+		MaxRetries int
+		// Timeout the max duration of each execution
+		Timeout time.Duration
+	}
+
+	// TaskStatus the state of a task persisted in the StatusStore
+	TaskStatus struct {
+		ID        string    `json:"id"`
+		Type      string    `json:"type"`
+		State     TaskState `json:"state"`
+		Attempt   int       `json:"attempt"`
+		Error     string    `json:"error,omitempty"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+	}
+
+	// TaskHandler executes the task, the payload could be decoded using task.Decode
+	TaskHandler = func(ctx context.Context, task *Task) error
+
+	// CompletionCallback called when the task reach a final state (completed or failed)
+	CompletionCallback = func(ctx context.Context, status *TaskStatus)
+
+	// BackoffFunc returns the delay before the next attempt
+	BackoffFunc = func(attempt int) time.Duration
+
+	// DeliverFunc called by the broker to each task received
+	DeliverFunc = func(ctx context.Context, task *Task) error
+
+	// Broker transport the tasks, supporting delayed delivery
+	Broker interface {
+		Publish(ctx context.Context, task *Task, delay time.Duration) error
+		// Consume blocks delivering the received tasks until ctx is done
+		Consume(ctx context.Context, deliver DeliverFunc) error
+	}
+
+	// StatusStore persist the task status, used to follow the tasks and build dashboards
+	StatusStore interface {
+		Save(ctx context.Context, status *TaskStatus) error
+		Get(ctx context.Context, id string) (*TaskStatus, error)
+		List(ctx context.Context, state TaskState, limit int) ([]*TaskStatus, error)
+		Counts(ctx context.Context) (map[TaskState]int64, error)
+	}
+
+	WorkerBuilder interface {
+		// Handle register the handler for the task type
+		Handle(taskType string, handler TaskHandler) WorkerBuilder
+		// OnComplete register a callback called when the tasks are completed or failed
+		OnComplete(callback CompletionCallback) WorkerBuilder
+		// Store set the status store, default in memory
+		Store(store StatusStore) WorkerBuilder
+		// Concurrency how many tasks are executed at the same time
+		Concurrency(n int) WorkerBuilder
+		// Backoff set the delay strategy between the retries, default exponential
+		Backoff(backoff BackoffFunc) WorkerBuilder
+		Build() (IWorker, error)
+	}
+
+	IWorker interface {
+		// Enqueue a new task, the payload is JSON encoded
+		Enqueue(ctx context.Context, taskType string, payload any, opts *EnqueueOpts) (string, error)
+		// Status returns the current status of a task
+		Status(ctx context.Context, id string) (*TaskStatus, error)
+		// Run consume the tasks until ctx is done
+		Run(ctx context.Context) error
+	}
+
+	Worker struct {
+		logger      logging.ILogger
+		broker      Broker
+		store       StatusStore
+		handlers    map[string]TaskHandler
+		callbacks   []CompletionCallback
+		concurrency int
+		backoff     BackoffFunc
+		timeNow     func() time.Time
+
+		// pool the saturation of the consumers, created by Run
+		pool *pool.Pool
+	}
+
+	memoryBroker struct {
+		mu      sync.Mutex
+		tasks   chan *Task
+		pending map[string]*time.Timer
+	}
+
+	memoryStore struct {
+		mu       sync.RWMutex
+		statuses map[string]*TaskStatus
+	}
+)